@@ -27,7 +27,9 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/engine"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
@@ -89,6 +91,14 @@ const (
 
 func PreviewThenPrompt(ctx context.Context, kind apitype.UpdateKind, stack Stack,
 	op UpdateOperation, apply Applier, explainer Explainer,
+) (*deploy.Plan, sdkDisplay.ResourceChanges, error) {
+	return previewThenPrompt(ctx, kind, stack, op, apply, explainer, nil)
+}
+
+// previewThenPrompt is PreviewThenPrompt, except that if skipPrompt is non-nil and returns true once the preview has
+// finished, the user isn't asked to confirm it.
+func previewThenPrompt(ctx context.Context, kind apitype.UpdateKind, stack Stack,
+	op UpdateOperation, apply Applier, explainer Explainer, skipPrompt func() bool,
 ) (*deploy.Plan, sdkDisplay.ResourceChanges, error) {
 	// create a channel to hear about the update events from the engine. this will be used so that
 	// we can build up the diff display in case the user asks to see the details of the diff
@@ -132,7 +142,7 @@ func PreviewThenPrompt(ctx context.Context, kind apitype.UpdateKind, stack Stack
 	}
 
 	// If there are no changes, or we're auto-approving or just previewing, we can skip the confirmation prompt.
-	if op.Opts.AutoApprove || kind == apitype.PreviewUpdate {
+	if op.Opts.AutoApprove || kind == apitype.PreviewUpdate || (skipPrompt != nil && skipPrompt()) {
 		close(eventsChannel)
 		// If we're running in experimental mode then return the plan generated, else discard it. The user may
 		// be explicitly setting a plan but that's handled higher up the call stack.
@@ -288,9 +298,41 @@ func PreviewThenPromptThenExecute(ctx context.Context, kind apitype.UpdateKind,
 			originalPlan = op.Opts.Engine.Plan.Clone()
 		}
 
-		plan, changes, err := PreviewThenPrompt(ctx, kind, stack, op, apply, explainer)
-		if err != nil || kind == apitype.PreviewUpdate {
-			return changes, err
+		// Renames are detected by a preview of their own, so that the preview the user is asked to approve is the
+		// one with the renames aliased, and is therefore the same as the update that follows. If there are no renames,
+		// that preview is the one the user approves, and it isn't run again.
+		var plan *deploy.Plan
+		var changes sdkDisplay.ResourceChanges
+		var err error
+		previewed := false
+		if op.Opts.AliasRenames && kind != apitype.PreviewUpdate {
+			var renames []deploy.RenameSuggestion
+			renames, plan, changes, err = previewRenameSuggestions(ctx, kind, stack, op, apply, explainer)
+			if err != nil {
+				return changes, err
+			}
+			if len(renames) > 0 {
+				aliases := make(map[resource.URN]resource.URN, len(renames)+len(op.Opts.Engine.RenameAliases))
+				for urn, alias := range op.Opts.Engine.RenameAliases {
+					aliases[urn] = alias
+				}
+				for _, r := range renames {
+					aliases[r.URN] = r.OldURN
+				}
+				op.Opts.Engine.RenameAliases = aliases
+
+				cmdutil.Diag().Infof(
+					diag.Message("", "Previewing again with %d renamed resource(s) aliased\n"), len(renames))
+			} else {
+				previewed = true
+			}
+		}
+
+		if !previewed {
+			plan, changes, err = PreviewThenPrompt(ctx, kind, stack, op, apply, explainer)
+			if err != nil || kind == apitype.PreviewUpdate {
+				return changes, err
+			}
 		}
 
 		// If we had an original plan use it, else if prompt said to use the plan from Preview then use the
@@ -317,6 +359,53 @@ func PreviewThenPromptThenExecute(ctx context.Context, kind apitype.UpdateKind,
 	return changes, res
 }
 
+// previewRenameSuggestions previews the operation and returns the renames it suggests. If there are none, the user is
+// asked to confirm the preview as usual, and the plan and changes it returns are those of the approved preview;
+// otherwise the prompt is skipped, so that the operation can be previewed again with the renames aliased. The preview
+// is checked against a copy of the operation's plan, if it has one, since plans are mutated as they're checked.
+func previewRenameSuggestions(ctx context.Context, kind apitype.UpdateKind, stack Stack,
+	op UpdateOperation, apply Applier, explainer Explainer,
+) ([]deploy.RenameSuggestion, *deploy.Plan, sdkDisplay.ResourceChanges, error) {
+	if op.Opts.Engine.Plan != nil {
+		op.Opts.Engine.Plan = op.Opts.Engine.Plan.Clone()
+	}
+
+	var renames []deploy.RenameSuggestion
+	plan, changes, err := previewThenPrompt(ctx, kind, stack, op, captureRenameSuggestions(apply, &renames), explainer,
+		func() bool { return len(renames) > 0 })
+	if err != nil {
+		return nil, plan, changes, err
+	}
+	return renames, plan, changes, nil
+}
+
+// captureRenameSuggestions wraps an applier so that the rename suggestions from its summary event are recorded in
+// the given slice.
+func captureRenameSuggestions(apply Applier, renames *[]deploy.RenameSuggestion) Applier {
+	return func(ctx context.Context, kind apitype.UpdateKind, stack Stack, op UpdateOperation,
+		opts ApplierOptions, events chan<- engine.Event,
+	) (*deploy.Plan, sdkDisplay.ResourceChanges, error) {
+		tee := make(chan engine.Event)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for e := range tee {
+				if e.Type == engine.SummaryEvent {
+					*renames = e.Payload().(engine.SummaryEventPayload).RenameSuggestions
+				}
+				if events != nil {
+					events <- e
+				}
+			}
+		}()
+
+		plan, changes, err := apply(ctx, kind, stack, op, opts, tee)
+		close(tee)
+		<-done
+		return plan, changes, err
+	}
+}
+
 type updateStats struct {
 	numNonStackResources int
 	retainedResources    []engine.StepEventMetadata
@@ -145,3 +145,63 @@ func TestConfirmBeforeUpdating_ExplainerErrorDoesNotCrash(t *testing.T) {
 	require.Error(t, callErr)
 	require.Contains(t, callErr.Error(), "confirmation declined")
 }
+
+// TestPreviewThenPromptThenExecuteAliasRenames tests that renames are detected before the preview that is approved,
+// so that the approved preview and the update both run with the renames aliased, and that the operation is only
+// previewed once if there are no renames.
+func TestPreviewThenPromptThenExecuteAliasRenames(t *testing.T) {
+	t.Parallel()
+
+	oldURN := resource.NewURN("stack", "project", "", "pkg:index:type", "old")
+	newURN := resource.NewURN("stack", "project", "", "pkg:index:type", "new")
+
+	type call struct {
+		dryRun  bool
+		aliases map[resource.URN]resource.URN
+	}
+	run := func(t *testing.T, suggestRenames bool) []call {
+		var calls []call
+		apply := func(ctx context.Context, kind apitype.UpdateKind, stack Stack, op UpdateOperation,
+			opts ApplierOptions, events chan<- engine.Event,
+		) (*deploy.Plan, display.ResourceChanges, error) {
+			calls = append(calls, call{dryRun: opts.DryRun, aliases: op.Opts.Engine.RenameAliases})
+			if events != nil {
+				summary := engine.SummaryEventPayload{}
+				if suggestRenames && op.Opts.Engine.RenameAliases == nil {
+					summary.RenameSuggestions = []deploy.RenameSuggestion{{URN: newURN, OldURN: oldURN}}
+				}
+				events <- engine.NewEvent(summary)
+			}
+			return nil, nil, nil
+		}
+
+		op := UpdateOperation{Opts: UpdateOptions{
+			AliasRenames: true,
+			AutoApprove:  true,
+			Display:      backenddisplay.Options{Color: colors.Never},
+		}}
+		_, err := PreviewThenPromptThenExecute(context.Background(), apitype.UpdateUpdate, nil, op, apply, nil, nil)
+		require.NoError(t, err)
+		return calls
+	}
+
+	t.Run("renames", func(t *testing.T) {
+		t.Parallel()
+
+		aliases := map[resource.URN]resource.URN{newURN: oldURN}
+		assert.Equal(t, []call{
+			{dryRun: true},
+			{dryRun: true, aliases: aliases},
+			{dryRun: false, aliases: aliases},
+		}, run(t, true))
+	})
+
+	t.Run("no renames", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []call{
+			{dryRun: true},
+			{dryRun: false},
+		}, run(t, false))
+	})
+}
@@ -20,6 +20,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/pulumi/esc"
@@ -190,9 +192,10 @@ type Backend interface {
 	) (sdkDisplay.ResourceChanges, error)
 	// Import imports resources into a stack.
 	Import(ctx context.Context, stack Stack, op UpdateOperation,
-		imports []deploy.Import) (sdkDisplay.ResourceChanges, error)
+		imports []deploy.Import, events chan<- engine.Event) (sdkDisplay.ResourceChanges, error)
 	// Refresh refreshes the stack's state from the cloud provider.
-	Refresh(ctx context.Context, stack Stack, op UpdateOperation) (sdkDisplay.ResourceChanges, error)
+	Refresh(ctx context.Context, stack Stack, op UpdateOperation,
+		events chan<- engine.Event) (sdkDisplay.ResourceChanges, error)
 	// Destroy destroys all of this stack's resources.
 	Destroy(ctx context.Context, stack Stack, op UpdateOperation) (sdkDisplay.ResourceChanges, error)
 	// Watch watches the project's working directory for changes and automatically updates the active stack.
@@ -340,6 +343,8 @@ type UpdateOptions struct {
 	SkipPreview bool
 	// PreviewOnly, when true, causes only the preview step to be run, without running the Update.
 	PreviewOnly bool
+	// AliasRenames, when true, causes the renames suggested by the preview to be treated as aliases by the update.
+	AliasRenames bool
 }
 
 // CancellationScope provides a scoped source of cancellation and termination requests.
@@ -356,6 +361,36 @@ type CancellationScopeSource interface {
 	NewScope(events chan<- engine.Event, isPreview bool) CancellationScope
 }
 
+// OpenBackendFunc opens the backend with the given URL, e.g. "s3://my-bucket" or "https://api.pulumi.com".
+type OpenBackendFunc func(ctx context.Context, url string) (Backend, error)
+
+var (
+	backendOpenerLock sync.RWMutex
+	backendOpener     OpenBackendFunc
+)
+
+// SetBackendOpener sets the function used to open the backends of stack references that name a stack in a different
+// backend from the one the referencing stack is in. Without one, such stack references fail to resolve.
+func SetBackendOpener(open OpenBackendFunc) {
+	backendOpenerLock.Lock()
+	defer backendOpenerLock.Unlock()
+	backendOpener = open
+}
+
+// ParseCrossBackendStackReference splits a stack reference of the form "<backend-url>#<stack>", such as
+// "s3://my-bucket?region=us-west-2#org/project/stack", into the URL of the backend and the name of the stack within
+// it. ok is false if the stack reference doesn't name a backend.
+func ParseCrossBackendStackReference(name string) (url, stackName string, ok bool) {
+	if !strings.Contains(name, "://") {
+		return "", "", false
+	}
+	i := strings.LastIndex(name, "#")
+	if i <= 0 || i == len(name)-1 {
+		return "", "", false
+	}
+	return name[:i], name[i+1:], true
+}
+
 // NewBackendClient returns a deploy.BackendClient that wraps the given Backend.
 func NewBackendClient(backend Backend, secretsProvider secrets.Provider) deploy.BackendClient {
 	return &backendClient{backend: backend, secretsProvider: secretsProvider}
@@ -364,6 +399,69 @@ func NewBackendClient(backend Backend, secretsProvider secrets.Provider) deploy.
 type backendClient struct {
 	backend         Backend
 	secretsProvider secrets.Provider
+
+	// open opens other backends for stack references, overriding the function set with SetBackendOpener.
+	open OpenBackendFunc
+	// backends caches the other backends that stack references have been resolved through, keyed by URL.
+	backends     map[string]Backend
+	backendsLock sync.Mutex
+}
+
+// getStack returns the stack with the given name, which may name a stack in another backend, along with the secrets
+// provider to read its snapshot with.
+func (c *backendClient) getStack(ctx context.Context, name string) (Stack, secrets.Provider, error) {
+	b, stackName, secretsProvider := c.backend, name, c.secretsProvider
+	if url, other, ok := ParseCrossBackendStackReference(name); ok {
+		var err error
+		if b, err = c.openBackend(ctx, url); err != nil {
+			return nil, nil, err
+		}
+		// The stack's secrets are decrypted with the secrets manager recorded in its own deployment, rather than
+		// anything particular to the referencing stack.
+		stackName, secretsProvider = other, stack.DefaultSecretsProvider
+	}
+
+	ref, err := b.ParseStackReference(stackName)
+	if err != nil {
+		return nil, nil, err
+	}
+	s, err := b.GetStack(ctx, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s == nil {
+		return nil, nil, fmt.Errorf("unknown stack %q", name)
+	}
+	return s, secretsProvider, nil
+}
+
+func (c *backendClient) openBackend(ctx context.Context, url string) (Backend, error) {
+	c.backendsLock.Lock()
+	defer c.backendsLock.Unlock()
+
+	if b, ok := c.backends[url]; ok {
+		return b, nil
+	}
+
+	open := c.open
+	if open == nil {
+		backendOpenerLock.RLock()
+		open = backendOpener
+		backendOpenerLock.RUnlock()
+	}
+	if open == nil {
+		return nil, fmt.Errorf("cannot read stacks from other backends such as %s", url)
+	}
+
+	b, err := open(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("opening backend %s: %w", url, err)
+	}
+	if c.backends == nil {
+		c.backends = map[string]Backend{}
+	}
+	c.backends[url] = b
+	return b, nil
 }
 
 // GetStackOutputs returns the outputs of the stack with the given name.
@@ -372,20 +470,12 @@ func (c *backendClient) GetStackOutputs(
 	name string,
 	onDecryptError func(err error) error,
 ) (resource.PropertyMap, error) {
-	ref, err := c.backend.ParseStackReference(name)
-	if err != nil {
-		return nil, err
-	}
-	s, err := c.backend.GetStack(ctx, ref)
+	s, secretsProvider, err := c.getStack(ctx, name)
 	if err != nil {
 		return nil, err
 	}
-	if s == nil {
-		return nil, fmt.Errorf("unknown stack %q", name)
-	}
 
-	secretsProvider := newErrorCatchingSecretsProvider(c.secretsProvider, onDecryptError)
-	snap, err := s.Snapshot(ctx, secretsProvider)
+	snap, err := s.Snapshot(ctx, newErrorCatchingSecretsProvider(secretsProvider, onDecryptError))
 	if err != nil {
 		return nil, err
 	}
@@ -404,18 +494,11 @@ func (c *backendClient) GetStackOutputs(
 func (c *backendClient) GetStackResourceOutputs(
 	ctx context.Context, name string,
 ) (resource.PropertyMap, error) {
-	ref, err := c.backend.ParseStackReference(name)
+	s, secretsProvider, err := c.getStack(ctx, name)
 	if err != nil {
 		return nil, err
 	}
-	s, err := c.backend.GetStack(ctx, ref)
-	if err != nil {
-		return nil, err
-	}
-	if s == nil {
-		return nil, fmt.Errorf("unknown stack %q", name)
-	}
-	snap, err := s.Snapshot(ctx, c.secretsProvider)
+	snap, err := s.Snapshot(ctx, secretsProvider)
 	if err != nil {
 		return nil, err
 	}
@@ -16,6 +16,7 @@ package backend
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -122,3 +123,94 @@ func liveState(typ, name string, outs resource.PropertyMap) *resource.State {
 		Delete: false, Type: tokens.Type(typ), URN: testURN(typ, name), Outputs: outs,
 	}
 }
+
+func TestParseCrossBackendStackReference(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name, url, stack string
+		ok               bool
+	}{
+		{"org/proj/stack", "", "", false},
+		{"dev", "", "", false},
+		{"s3://bucket?region=us-west-2#org/proj/stack", "s3://bucket?region=us-west-2", "org/proj/stack", true},
+		{"file:///var/state#proj/dev", "file:///var/state", "proj/dev", true},
+		{"postgres://user@host/db?sslmode=disable#dev", "postgres://user@host/db?sslmode=disable", "dev", true},
+		{"https://api.pulumi.com#org/proj/stack", "https://api.pulumi.com", "org/proj/stack", true},
+		{"s3://bucket", "", "", false},
+		{"s3://bucket#", "", "", false},
+	}
+	for _, c := range cases {
+		url, stack, ok := ParseCrossBackendStackReference(c.name)
+		assert.Equal(t, c.ok, ok, c.name)
+		assert.Equal(t, c.url, url, c.name)
+		assert.Equal(t, c.stack, stack, c.name)
+	}
+}
+
+func TestGetStackOutputsFromOtherBackend(t *testing.T) {
+	t.Parallel()
+
+	newBackend := func(outputs map[string]resource.PropertyMap) *MockBackend {
+		return &MockBackend{
+			ParseStackReferenceF: func(s string) (StackReference, error) {
+				return &MockStackReference{StringV: s}, nil
+			},
+			GetStackF: func(ctx context.Context, ref StackReference) (Stack, error) {
+				outs, ok := outputs[ref.String()]
+				if !ok {
+					return nil, nil
+				}
+				return &MockStack{
+					SnapshotF: func(ctx context.Context, sp secrets.Provider) (*deploy.Snapshot, error) {
+						return &deploy.Snapshot{Resources: []*resource.State{{
+							Type:    resource.RootStackType,
+							URN:     resource.NewURN("stack", "proj", "", resource.RootStackType, "proj-stack"),
+							Outputs: outs,
+						}}}, nil
+					},
+				}, nil
+			},
+		}
+	}
+
+	current := newBackend(map[string]resource.PropertyMap{
+		"org/proj/local": {"where": resource.NewStringProperty("current")},
+	})
+	other := newBackend(map[string]resource.PropertyMap{
+		"org/proj/remote": {"where": resource.NewStringProperty("other")},
+	})
+
+	var opened []string
+	client := &backendClient{
+		backend: current,
+		open: func(ctx context.Context, url string) (Backend, error) {
+			opened = append(opened, url)
+			return other, nil
+		},
+	}
+
+	outs, err := client.GetStackOutputs(context.Background(), "org/proj/local", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "current", outs["where"].StringValue())
+
+	for i := 0; i < 2; i++ {
+		outs, err = client.GetStackOutputs(context.Background(), "s3://bucket?region=us-west-2#org/proj/remote", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "other", outs["where"].StringValue())
+	}
+	// The other backend is only opened once.
+	assert.Equal(t, []string{"s3://bucket?region=us-west-2"}, opened)
+
+	_, err = client.GetStackOutputs(context.Background(), "s3://bucket?region=us-west-2#org/proj/missing", nil)
+	assert.EqualError(t, err, `unknown stack "s3://bucket?region=us-west-2#org/proj/missing"`)
+
+	client = &backendClient{
+		backend: current,
+		open: func(ctx context.Context, url string) (Backend, error) {
+			return nil, errors.New("not logged in")
+		},
+	}
+	_, err = client.GetStackOutputs(context.Background(), "https://api.pulumi.com#org/proj/remote", nil)
+	assert.EqualError(t, err, "opening backend https://api.pulumi.com: not logged in")
+}
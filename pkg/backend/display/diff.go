@@ -294,6 +294,8 @@ func renderSummaryEvent(event engine.SummaryEventPayload, diffStyleSummary bool,
 		renderPolicyPacks(out, event.PolicyPacks, opts)
 	}
 
+	renderRenameSuggestions(out, event.RenameSuggestions, opts)
+
 	// For actual deploys, we print some additional summary information
 	if !event.IsPreview {
 		// Round up to the nearest second.  It's not useful to spit out time with 9 digits of
@@ -335,6 +337,23 @@ func renderPolicyPacks(out io.Writer, policyPacks map[string]string, opts Option
 	}
 }
 
+// renderRenameSuggestions prints the resources that look like they have been renamed, along with the aliases that
+// would let them be updated in place rather than replaced.
+func renderRenameSuggestions(out io.Writer, suggestions []deploy.RenameSuggestion, opts Options) {
+	if len(suggestions) == 0 {
+		return
+	}
+	fprintIgnoreError(out, opts.Color.Colorize(fmt.Sprintf("\n%sSuggested aliases:%s\n",
+		colors.SpecHeadline, colors.Reset)))
+	for _, s := range suggestions {
+		fprintIgnoreError(out, opts.Color.Colorize(fmt.Sprintf("    %s\n        <= %s (%.0f%% of inputs match)\n",
+			s.URN, s.OldURN, s.Similarity*100)))
+	}
+	fprintIgnoreError(out, opts.Color.Colorize(fmt.Sprintf(
+		"    %sAdd these as aliases, or run `pulumi up --alias-renames`, to update these resources in place.%s\n",
+		colors.SpecInfo, colors.Reset)))
+}
+
 func renderPreludeEvent(event engine.PreludeEventPayload, opts Options) string {
 	// Only if we have been instructed to show configuration values will we print anything during the prelude.
 	if !opts.ShowConfig {
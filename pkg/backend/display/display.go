@@ -171,11 +171,12 @@ func logJSONEvent(encoder *json.Encoder, event engine.StampedEvent, opts Options
 func startEventLogger(
 	events <-chan engine.StampedEvent, done chan<- bool, opts Options,
 ) (<-chan engine.StampedEvent, chan<- bool) {
+	// The filter is validated when the options are built, so it can't fail to parse here.
+	filter, err := newEventLogFilter(opts.EventLogExclude)
+	contract.AssertNoErrorf(err, "invalid event log filter")
+
 	// Before moving further, attempt to open the log file.
-	//
-	// Try setting O_APPEND to see if that helps with the malformed reads we've been seeing in automation api:
-	// https://github.com/pulumi/pulumi/issues/6768
-	logFile, err := os.OpenFile(opts.EventLogPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND, 0o666)
+	logFile, err := openEventLogFile(opts)
 	if err != nil {
 		logging.V(7).Infof("could not create event log: %v", err)
 		return events, done
@@ -191,8 +192,10 @@ func startEventLogger(
 		encoder := json.NewEncoder(logFile)
 		encoder.SetEscapeHTML(false)
 		for e := range events {
-			if err = logJSONEvent(encoder, e, opts); err != nil {
-				logging.V(7).Infof("failed to log event: %v", err)
+			if filter.Include(e.Event) {
+				if err := logJSONEvent(encoder, e, opts); err != nil {
+					logging.V(7).Infof("failed to log event: %v", err)
+				}
 			}
 
 			outEvents <- e
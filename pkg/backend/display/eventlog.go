@@ -0,0 +1,263 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/events"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// eventLogFile is an io.WriteCloser for event logs that rotates the underlying file once it grows beyond a maximum
+// size or age. Rotated segments are named as described by events.LogSegments so that the Automation API can read
+// them back, may optionally be gzip-compressed, and the number of retained segments may be bounded.
+//
+// Each call to Write is assumed to contain one or more complete lines, so that a rotation never splits a single
+// event across two segments.
+type eventLogFile struct {
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+	compress   bool
+
+	file   *os.File
+	size   int64
+	opened time.Time
+	next   int
+
+	now func() time.Time
+}
+
+// openEventLogFile creates (or truncates) the event log at the path given by opts, removing any segments left behind
+// by a previous operation.
+func openEventLogFile(opts Options) (*eventLogFile, error) {
+	f := &eventLogFile{
+		path:       opts.EventLogPath,
+		maxSize:    opts.EventLogMaxSize,
+		maxAge:     opts.EventLogMaxAge,
+		maxBackups: opts.EventLogMaxBackups,
+		compress:   opts.EventLogCompress,
+		next:       1,
+		now:        time.Now,
+	}
+
+	stale, err := events.LogSegments(f.path)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range stale {
+		if err := os.Remove(s); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *eventLogFile) open() error {
+	// Try setting O_APPEND to see if that helps with the malformed reads we've been seeing in automation api:
+	// https://github.com/pulumi/pulumi/issues/6768
+	file, err := os.OpenFile(f.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND, 0o666)
+	if err != nil {
+		return err
+	}
+	f.file, f.size, f.opened = file, 0, f.now()
+	return nil
+}
+
+func (f *eventLogFile) shouldRotate(n int) bool {
+	if f.size == 0 {
+		return false
+	}
+	if f.maxSize > 0 && f.size+int64(n) > f.maxSize {
+		return true
+	}
+	return f.maxAge > 0 && f.now().Sub(f.opened) >= f.maxAge
+}
+
+func (f *eventLogFile) Write(p []byte) (int, error) {
+	if f.shouldRotate(len(p)) {
+		if err := f.rotate(); err != nil {
+			return 0, fmt.Errorf("rotating event log: %w", err)
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// rotate closes the live log, moves it aside as the next numbered segment, and opens a fresh live log in its place.
+func (f *eventLogFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+
+	segment := fmt.Sprintf("%s.%d", f.path, f.next)
+	f.next++
+	if err := os.Rename(f.path, segment); err != nil {
+		return err
+	}
+	if f.compress {
+		if err := compressEventLogSegment(segment); err != nil {
+			return err
+		}
+	}
+	if err := f.prune(); err != nil {
+		return err
+	}
+
+	return f.open()
+}
+
+// prune removes the oldest rotated segments so that at most maxBackups remain.
+func (f *eventLogFile) prune() error {
+	if f.maxBackups <= 0 {
+		return nil
+	}
+	segments, err := events.LogSegments(f.path)
+	if err != nil {
+		return err
+	}
+	for len(segments) > f.maxBackups {
+		if err := os.Remove(segments[0]); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		segments = segments[1:]
+	}
+	return nil
+}
+
+func (f *eventLogFile) Close() error {
+	return f.file.Close()
+}
+
+// compressEventLogSegment replaces the segment at path with a gzip-compressed copy at `<path>.gz`. The copy is
+// written to a temporary file and renamed into place, so that readers of the log never see a partial segment.
+func compressEventLogSegment(path string) (err error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer contract.IgnoreClose(src)
+
+	// The temporary file's name doesn't end in a segment number, so it is ignored by events.LogSegments.
+	tmp := path + ".gz.tmp"
+	dst, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o666)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			contract.IgnoreError(os.Remove(tmp))
+		}
+	}()
+
+	zw := gzip.NewWriter(dst)
+	if _, err = io.Copy(zw, src); err != nil {
+		return errors.Join(err, zw.Close(), dst.Close())
+	}
+	if err = errors.Join(zw.Close(), dst.Close()); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, path+".gz"); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// eventLogFilter decides whether an engine event should be written to the event log.
+type eventLogFilter struct {
+	types      map[engine.EventType]bool
+	severities map[diag.Severity]bool
+}
+
+// newEventLogFilter parses a list of event kinds to exclude from the event log. Each entry is either an engine event
+// type (e.g. "diag", "stdoutcolor", "progress") or a diagnostic severity of the form "diag:<severity>" (e.g.
+// "diag:debug").
+func newEventLogFilter(exclude []string) (*eventLogFilter, error) {
+	filter := &eventLogFilter{
+		types:      map[engine.EventType]bool{},
+		severities: map[diag.Severity]bool{},
+	}
+	for _, kind := range exclude {
+		typ, sev, hasSev := strings.Cut(kind, ":")
+		switch {
+		case !isKnownEventType(engine.EventType(typ)):
+			return nil, fmt.Errorf("unknown event kind %q", kind)
+		case hasSev && engine.EventType(typ) != engine.DiagEvent:
+			return nil, fmt.Errorf("invalid event kind %q: only diag events may be filtered by severity", kind)
+		case hasSev:
+			switch s := diag.Severity(sev); s {
+			case diag.Debug, diag.Info, diag.Infoerr, diag.Warning, diag.Error:
+				filter.severities[s] = true
+			default:
+				return nil, fmt.Errorf("invalid event kind %q: unknown severity %q", kind, sev)
+			}
+		default:
+			filter.types[engine.EventType(typ)] = true
+		}
+	}
+	return filter, nil
+}
+
+// ValidateEventLogExclude checks that every entry in a list of event kinds to exclude from the event log is valid, as
+// accepted by Options.EventLogExclude.
+func ValidateEventLogExclude(exclude []string) error {
+	_, err := newEventLogFilter(exclude)
+	return err
+}
+
+func isKnownEventType(typ engine.EventType) bool {
+	switch typ {
+	case engine.CancelEvent, engine.StdoutColorEvent, engine.DiagEvent, engine.PreludeEvent, engine.SummaryEvent,
+		engine.ResourcePreEvent, engine.ResourceOutputsEvent, engine.ResourceOperationFailed,
+		engine.PolicyViolationEvent, engine.PolicyRemediationEvent, engine.PolicyLoadEvent,
+		engine.StartDebuggingEvent, engine.ProgressEvent:
+		return true
+	default:
+		return false
+	}
+}
+
+// Include returns true if the given event should be written to the event log.
+func (f *eventLogFilter) Include(e engine.Event) bool {
+	// Cancellation events are always logged, since readers rely on them to detect the end of an operation.
+	if e.Type == engine.CancelEvent {
+		return true
+	}
+	if f.types[e.Type] {
+		return false
+	}
+	if e.Type == engine.DiagEvent && len(f.severities) > 0 {
+		if payload, ok := e.Payload().(engine.DiagEventPayload); ok && f.severities[payload.Severity] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+// Copyright 2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto/events"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventLogFileRotatesBySize(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "events.json")
+	f, err := openEventLogFile(Options{
+		EventLogPath:       path,
+		EventLogMaxSize:    16,
+		EventLogMaxBackups: 2,
+		EventLogCompress:   true,
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		_, err := fmt.Fprintf(f, "{\"sequence\":%d}\n", i)
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+
+	segments, err := events.LogSegments(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{path + ".3.gz", path + ".4.gz"}, segments)
+
+	var sequences []int
+	err = events.ReadLog(path, func(e events.EngineEvent) error {
+		require.NoError(t, e.Error)
+		sequences = append(sequences, e.Sequence)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 3, 4}, sequences)
+}
+
+func TestEventLogFileRotatesByAge(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "events.json")
+	f, err := openEventLogFile(Options{
+		EventLogPath:   path,
+		EventLogMaxAge: time.Hour,
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	f.now = func() time.Time { return now }
+	f.opened = now
+
+	_, err = f.Write([]byte("{}\n"))
+	require.NoError(t, err)
+	now = now.Add(2 * time.Hour)
+	_, err = f.Write([]byte("{}\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	segments, err := events.LogSegments(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{path + ".1"}, segments)
+}
+
+func TestEventLogFileRemovesStaleSegments(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "events.json")
+	f, err := openEventLogFile(Options{EventLogPath: path, EventLogMaxSize: 1})
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, err := f.Write([]byte("{}\n"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+
+	f, err = openEventLogFile(Options{EventLogPath: path})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	segments, err := events.LogSegments(path)
+	require.NoError(t, err)
+	assert.Empty(t, segments)
+}
+
+func TestEventLogFilter(t *testing.T) {
+	t.Parallel()
+
+	filter, err := newEventLogFilter([]string{"progress", "diag:debug"})
+	require.NoError(t, err)
+
+	assert.False(t, filter.Include(engine.NewEvent(engine.ProgressEventPayload{})))
+	assert.False(t, filter.Include(engine.NewEvent(engine.DiagEventPayload{Severity: diag.Debug})))
+	assert.True(t, filter.Include(engine.NewEvent(engine.DiagEventPayload{Severity: diag.Warning})))
+	assert.True(t, filter.Include(engine.NewEvent(engine.StdoutEventPayload{})))
+	assert.True(t, filter.Include(engine.NewCancelEvent()))
+
+	for _, kind := range []string{"bogus", "progress:debug", "diag:loud"} {
+		_, err := newEventLogFilter([]string{kind})
+		assert.Error(t, err, kind)
+		assert.True(t, strings.Contains(err.Error(), kind), err.Error())
+		assert.Error(t, ValidateEventLogExclude([]string{kind}), kind)
+	}
+	assert.NoError(t, ValidateEventLogExclude([]string{"progress", "diag:debug"}))
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/pulumi/pulumi/pkg/v3/display"
 	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
@@ -158,10 +159,11 @@ func ConvertEngineEvent(e engine.Event, showSecrets bool) (apitype.EngineEvent,
 			changes[apitype.OpType(op)] = count
 		}
 		apiEvent.SummaryEvent = &apitype.SummaryEvent{
-			MaybeCorrupt:    p.MaybeCorrupt,
-			DurationSeconds: int(math.Ceil(p.Duration.Seconds())),
-			ResourceChanges: changes,
-			PolicyPacks:     p.PolicyPacks,
+			MaybeCorrupt:      p.MaybeCorrupt,
+			DurationSeconds:   int(math.Ceil(p.Duration.Seconds())),
+			ResourceChanges:   changes,
+			PolicyPacks:       p.PolicyPacks,
+			RenameSuggestions: ConvertRenameSuggestions(p.RenameSuggestions),
 		}
 
 	case engine.ResourcePreEvent:
@@ -294,6 +296,13 @@ func convertStepEventStateMetadata(md *engine.StepEventStateMetadata,
 	outputs, err := stack.SerializeProperties(ctx, md.Outputs, encrypter, showSecrets)
 	contract.IgnoreError(err)
 
+	var dependencies []string
+	if md.State != nil {
+		for _, dep := range md.State.Dependencies {
+			dependencies = append(dependencies, string(dep))
+		}
+	}
+
 	return &apitype.StepEventStateMetadata{
 		Type: string(md.Type),
 		URN:  string(md.URN),
@@ -307,6 +316,7 @@ func convertStepEventStateMetadata(md *engine.StepEventStateMetadata,
 		RetainOnDelete: md.RetainOnDelete,
 		Inputs:         inputs,
 		Outputs:        outputs,
+		Dependencies:   dependencies,
 		InitErrors:     md.InitErrors,
 	}
 }
@@ -390,11 +400,26 @@ func ConvertJSONEvent(apiEvent apitype.EngineEvent) (engine.Event, error) {
 		for op, count := range p.ResourceChanges {
 			changes[display.StepOp(op)] = count
 		}
+		var renames []deploy.RenameSuggestion
+		for _, r := range p.RenameSuggestions {
+			changed := make([]resource.PropertyKey, len(r.ChangedInputs))
+			for i, k := range r.ChangedInputs {
+				changed[i] = resource.PropertyKey(k)
+			}
+			renames = append(renames, deploy.RenameSuggestion{
+				URN:           resource.URN(r.URN),
+				OldURN:        resource.URN(r.OldURN),
+				Type:          tokens.Type(r.Type),
+				Similarity:    r.Similarity,
+				ChangedInputs: changed,
+			})
+		}
 		event = engine.NewEvent(engine.SummaryEventPayload{
-			MaybeCorrupt:    p.MaybeCorrupt,
-			Duration:        time.Duration(p.DurationSeconds) * time.Second,
-			ResourceChanges: changes,
-			PolicyPacks:     p.PolicyPacks,
+			MaybeCorrupt:      p.MaybeCorrupt,
+			Duration:          time.Duration(p.DurationSeconds) * time.Second,
+			ResourceChanges:   changes,
+			PolicyPacks:       p.PolicyPacks,
+			RenameSuggestions: renames,
 		})
 
 	case apiEvent.ResourcePreEvent != nil:
@@ -534,3 +559,22 @@ func convertJSONStepEventStateMetadata(md *apitype.StepEventStateMetadata) *engi
 		InitErrors:     md.InitErrors,
 	}
 }
+
+// ConvertRenameSuggestions converts the engine's rename suggestions into their API representation.
+func ConvertRenameSuggestions(suggestions []deploy.RenameSuggestion) []apitype.RenameSuggestion {
+	var result []apitype.RenameSuggestion
+	for _, s := range suggestions {
+		var changed []string
+		for _, k := range s.ChangedInputs {
+			changed = append(changed, string(k))
+		}
+		result = append(result, apitype.RenameSuggestion{
+			URN:           string(s.URN),
+			OldURN:        string(s.OldURN),
+			Type:          string(s.Type),
+			Similarity:    s.Similarity,
+			ChangedInputs: changed,
+		})
+	}
+	return result
+}
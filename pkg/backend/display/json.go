@@ -88,7 +88,7 @@ func stateForJSONOutput(s *resource.State, opts Options) *resource.State {
 		outputs, s.Parent, s.Protect, s.External, s.Dependencies, s.InitErrors, s.Provider,
 		s.PropertyDependencies, s.PendingReplacement, s.AdditionalSecretOutputs, s.Aliases, &s.CustomTimeouts,
 		s.ImportID, s.RetainOnDelete, s.DeletedWith, s.Created, s.Modified, s.SourcePosition, s.IgnoreChanges,
-		s.ReplaceOnChanges, s.RefreshBeforeUpdate, s.ViewOf, s.ResourceHooks)
+		s.ReplaceOnChanges, s.RefreshBeforeUpdate, s.ViewOf, s.ResourceHooks, s.Expires, s.Annotations)
 }
 
 // ShowJSONEvents renders incremental engine events to stdout.
@@ -203,6 +203,7 @@ func ShowPreviewDigest(events <-chan engine.Event, done chan<- bool, opts Option
 			digest.Duration = p.Duration
 			digest.ChangeSummary = p.ResourceChanges
 			digest.MaybeCorrupt = p.MaybeCorrupt
+			digest.RenameSuggestions = ConvertRenameSuggestions(p.RenameSuggestions)
 		case engine.ProgressEvent:
 			// Progress events are ephemeral and should be skipped.
 			continue
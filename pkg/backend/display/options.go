@@ -16,6 +16,7 @@ package display
 
 import (
 	"io"
+	"time"
 
 	"github.com/pulumi/pulumi/pkg/v3/backend/display/internal/terminal"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
@@ -50,6 +51,11 @@ type Options struct {
 	Type                   Type                // type of display (rich diff, progress, or query).
 	JSONDisplay            bool                // true if we should emit the entire diff as JSON.
 	EventLogPath           string              // the path to the file to use for logging events, if any.
+	EventLogMaxSize        int64               // the size in bytes at which to rotate the event log, if any.
+	EventLogMaxAge         time.Duration       // the age at which to rotate the event log, if any.
+	EventLogMaxBackups     int                 // the number of rotated event log segments to retain, if bounded.
+	EventLogCompress       bool                // true to gzip-compress rotated event log segments.
+	EventLogExclude        []string            // event kinds (e.g. "diag:debug") to omit from the event log.
 	Debug                  bool                // true to enable debug output.
 	Stdin                  io.Reader           // the reader to use for stdin. Defaults to os.Stdin if unset.
 	Stdout                 io.Writer           // the writer to use for stdout. Defaults to os.Stdout if unset.
@@ -25,6 +25,7 @@ import (
 	"path"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -113,6 +114,11 @@ type diyBackend struct {
 
 	lockID string
 
+	// locksMutex guards heldLocks, the number of holds this backend has on each of the stack locks it has taken,
+	// keyed by lock path.
+	locksMutex sync.Mutex
+	heldLocks  map[string]int
+
 	gzip bool
 
 	Env env.Env
@@ -127,6 +133,11 @@ type diyBackend struct {
 	store referenceStore
 }
 
+var (
+	_ backend.SpecificDeploymentExporter = (*diyBackend)(nil)
+	_ backend.StackLocker                = (*diyBackend)(nil)
+)
+
 type diyBackendReference struct {
 	name    tokens.StackName
 	project tokens.Name
@@ -631,24 +642,6 @@ func (b *diyBackend) SetCurrentProject(project *workspace.Project) {
 	b.currentProject.Store(project)
 }
 
-func (b *diyBackend) GetPolicyPack(ctx context.Context, policyPack string,
-	d diag.Sink,
-) (backend.PolicyPack, error) {
-	return nil, errors.New("DIY backend does not support resource policy")
-}
-
-func (b *diyBackend) ListPolicyGroups(ctx context.Context, orgName string, _ backend.ContinuationToken) (
-	apitype.ListPolicyGroupsResponse, backend.ContinuationToken, error,
-) {
-	return apitype.ListPolicyGroupsResponse{}, nil, errors.New("DIY backend does not support resource policy")
-}
-
-func (b *diyBackend) ListPolicyPacks(ctx context.Context, orgName string, _ backend.ContinuationToken) (
-	apitype.ListPolicyPacksResponse, backend.ContinuationToken, error,
-) {
-	return apitype.ListPolicyPacksResponse{}, nil, errors.New("DIY backend does not support resource policy")
-}
-
 func (b *diyBackend) SupportsTags() bool {
 	return false
 }
@@ -1054,7 +1047,7 @@ func (b *diyBackend) Update(ctx context.Context, stack backend.Stack,
 }
 
 func (b *diyBackend) Import(ctx context.Context, stack backend.Stack,
-	op backend.UpdateOperation, imports []deploy.Import,
+	op backend.UpdateOperation, imports []deploy.Import, events chan<- engine.Event,
 ) (sdkDisplay.ResourceChanges, error) {
 	err := b.Lock(ctx, stack.Ref())
 	if err != nil {
@@ -1073,15 +1066,15 @@ func (b *diyBackend) Import(ctx context.Context, stack backend.Stack,
 
 		op.Opts.Engine.GeneratePlan = false
 		_, changes, err := b.apply(
-			ctx, apitype.ResourceImportUpdate, stack, op, opts, nil /*events*/)
+			ctx, apitype.ResourceImportUpdate, stack, op, opts, events)
 		return changes, err
 	}
 
-	return backend.PreviewThenPromptThenExecute(ctx, apitype.ResourceImportUpdate, stack, op, b.apply, nil, nil)
+	return backend.PreviewThenPromptThenExecute(ctx, apitype.ResourceImportUpdate, stack, op, b.apply, nil, events)
 }
 
 func (b *diyBackend) Refresh(ctx context.Context, stack backend.Stack,
-	op backend.UpdateOperation,
+	op backend.UpdateOperation, events chan<- engine.Event,
 ) (sdkDisplay.ResourceChanges, error) {
 	err := b.Lock(ctx, stack.Ref())
 	if err != nil {
@@ -1098,11 +1091,11 @@ func (b *diyBackend) Refresh(ctx context.Context, stack backend.Stack,
 
 		op.Opts.Engine.GeneratePlan = false
 		_, changes, err := b.apply(
-			ctx, apitype.RefreshUpdate, stack, op, opts, nil /*events*/)
+			ctx, apitype.RefreshUpdate, stack, op, opts, events)
 		return changes, err
 	}
 
-	return backend.PreviewThenPromptThenExecute(ctx, apitype.RefreshUpdate, stack, op, b.apply, nil, nil)
+	return backend.PreviewThenPromptThenExecute(ctx, apitype.RefreshUpdate, stack, op, b.apply, nil, events)
 }
 
 func (b *diyBackend) Destroy(ctx context.Context, stack backend.Stack,
@@ -1162,6 +1155,13 @@ func (b *diyBackend) apply(
 			colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, stackRef)
 	}
 
+	// Run any Policy Packs that have been enabled for this stack.
+	requiredPolicies, err := b.requiredPolicies(ctx, diyStackRef)
+	if err != nil {
+		return nil, nil, err
+	}
+	op.Opts.Engine.RequiredPolicies = append(op.Opts.Engine.RequiredPolicies, requiredPolicies...)
+
 	// Start the update.
 	update, err := b.newUpdate(ctx, op.SecretsProvider, diyStackRef, op)
 	if err != nil {
@@ -1417,6 +1417,38 @@ func (b *diyBackend) ExportDeployment(ctx context.Context,
 	}, nil
 }
 
+func (b *diyBackend) ExportDeploymentForVersion(ctx context.Context,
+	stk backend.Stack, version string,
+) (*apitype.UntypedDeployment, error) {
+	diyStackRef, err := b.getReference(stk.Ref())
+	if err != nil {
+		return nil, err
+	}
+
+	// As with the Pulumi Cloud, versions are positive integers. The first update is version 1.
+	versionNumber, err := strconv.Atoi(version)
+	if err != nil || versionNumber <= 0 {
+		return nil, fmt.Errorf(
+			"%q is not a valid stack version. It should be a positive integer",
+			version)
+	}
+
+	chk, err := b.getHistoricalCheckpoint(ctx, diyStackRef, versionNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+
+	data, err := encoding.JSON.Marshal(chk.Latest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apitype.UntypedDeployment{
+		Version:    3,
+		Deployment: json.RawMessage(data),
+	}, nil
+}
+
 func (b *diyBackend) ImportDeployment(ctx context.Context, stk backend.Stack,
 	deployment *apitype.UntypedDeployment,
 ) error {
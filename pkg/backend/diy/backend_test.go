@@ -38,6 +38,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/backend"
 	"github.com/pulumi/pulumi/pkg/v3/operations"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/edit"
 	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
 	"github.com/pulumi/pulumi/pkg/v3/secrets/b64"
 	"github.com/pulumi/pulumi/pkg/v3/secrets/passphrase"
@@ -406,6 +407,47 @@ func TestCancel(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestLockStack(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	ctx := context.Background()
+	b, err := New(ctx, diagtest.LogSink(t), "file://"+filepath.ToSlash(tmpDir), nil)
+	require.NoError(t, err)
+	lb, ok := b.(*diyBackend)
+	require.True(t, ok)
+
+	aStackRef, err := lb.parseStackReference("organization/project/a")
+	require.NoError(t, err)
+	aStack, err := b.CreateStack(ctx, aStackRef, "", nil, nil)
+	require.NoError(t, err)
+	deployment, err := b.ExportDeployment(ctx, aStack)
+	require.NoError(t, err)
+
+	// Importing while the stack is locked reuses the lock rather than releasing it.
+	unlock, err := lb.LockStack(ctx, aStackRef)
+	require.NoError(t, err)
+	err = b.ImportDeployment(ctx, aStack, deployment)
+	require.NoError(t, err)
+	lockExists, err := lb.bucket.Exists(ctx, lb.lockPath(aStackRef))
+	require.NoError(t, err)
+	assert.True(t, lockExists)
+
+	unlock()
+	lockExists, err = lb.bucket.Exists(ctx, lb.lockPath(aStackRef))
+	require.NoError(t, err)
+	assert.False(t, lockExists)
+
+	// If the lock is removed while it's held, for example by `pulumi cancel`, imports fail rather than writing
+	// without it.
+	unlock, err = lb.LockStack(ctx, aStackRef)
+	require.NoError(t, err)
+	require.NoError(t, lb.CancelCurrentUpdate(ctx, aStackRef))
+	err = b.ImportDeployment(ctx, aStack, deployment)
+	assert.ErrorIs(t, err, edit.ErrConcurrentModification)
+	unlock()
+}
+
 func TestRemoveMakesBackups(t *testing.T) {
 	t.Parallel()
 
@@ -1862,3 +1904,97 @@ func TestListStackNames(t *testing.T) {
 	// This ensures we're not fetching metadata unnecessarily
 	assert.IsType(t, []backend.StackReference{}, stackRefs)
 }
+
+func TestExportDeploymentForVersion(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	ctx := context.Background()
+	b, err := New(ctx, diagtest.LogSink(t), "file://"+filepath.ToSlash(tmpDir), nil)
+	require.NoError(t, err)
+	lb, ok := b.(*diyBackend)
+	require.True(t, ok)
+
+	aStackRef, err := lb.parseStackReference("organization/project/a")
+	require.NoError(t, err)
+	aStack, err := b.CreateStack(ctx, aStackRef, "", nil, nil)
+	require.NoError(t, err)
+
+	// Version 1 has no resources.
+	err = lb.addToHistory(ctx, aStackRef, backend.UpdateInfo{Kind: apitype.UpdateUpdate})
+	require.NoError(t, err)
+
+	// Version 2 has the root stack resource.
+	deployment, err := json.Marshal(apitype.DeploymentV3{
+		Resources: []apitype.ResourceV3{{
+			URN:  resource.CreateURN("project-a", string(resource.RootStackType), "", "project", "a"),
+			Type: resource.RootStackType,
+		}},
+	})
+	require.NoError(t, err)
+	err = b.ImportDeployment(ctx, aStack, &apitype.UntypedDeployment{Version: 3, Deployment: deployment})
+	require.NoError(t, err)
+	err = lb.addToHistory(ctx, aStackRef, backend.UpdateInfo{Kind: apitype.UpdateUpdate})
+	require.NoError(t, err)
+
+	history, err := b.GetHistory(ctx, aStackRef, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, 2, history[0].Version)
+	assert.Equal(t, 1, history[1].Version)
+
+	for version, resources := range map[string]int{"1": 0, "2": 1} {
+		exported, err := lb.ExportDeploymentForVersion(ctx, aStack, version)
+		require.NoError(t, err)
+		var d apitype.DeploymentV3
+		require.NoError(t, json.Unmarshal(exported.Deployment, &d))
+		assert.Len(t, d.Resources, resources, "version %s", version)
+	}
+
+	_, err = lb.ExportDeploymentForVersion(ctx, aStack, "3")
+	assert.ErrorContains(t, err, "has no version 3")
+	_, err = lb.ExportDeploymentForVersion(ctx, aStack, "latest")
+	assert.ErrorContains(t, err, `"latest" is not a valid stack version`)
+}
+
+func TestGetHistoryNumbersVersions(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	ctx := context.Background()
+	b, err := New(ctx, diagtest.LogSink(t), "file://"+filepath.ToSlash(tmpDir), nil)
+	require.NoError(t, err)
+	lb, ok := b.(*diyBackend)
+	require.True(t, ok)
+
+	aStackRef, err := lb.parseStackReference("organization/project/a")
+	require.NoError(t, err)
+	_, err = b.CreateStack(ctx, aStackRef, "", nil, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		err = lb.addToHistory(ctx, aStackRef, backend.UpdateInfo{Kind: apitype.UpdateUpdate})
+		require.NoError(t, err)
+	}
+
+	// History files do not record a version, so versions are numbered from the oldest update, independently of
+	// paging. These are the versions accepted by `pulumi stack output --since`.
+	versions := func(history []backend.UpdateInfo) []int {
+		var vs []int
+		for _, u := range history {
+			vs = append(vs, u.Version)
+		}
+		return vs
+	}
+	history, err := b.GetHistory(ctx, aStackRef, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 2, 1}, versions(history))
+
+	history, err = b.GetHistory(ctx, aStackRef, 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 2}, versions(history))
+
+	history, err = b.GetHistory(ctx, aStackRef, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, versions(history))
+}
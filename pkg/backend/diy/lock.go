@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/resource/edit"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
@@ -60,16 +61,23 @@ func newLockContent() (*lockContent, error) {
 
 // checkForLock looks for any existing locks for this stack, and returns a helpful diagnostic if there is one.
 func (b *diyBackend) checkForLock(ctx context.Context, stackRef backend.StackReference) error {
-	stackName := stackRef.FullyQualifiedName()
-	allFiles, err := listBucket(ctx, b.bucket, stackLockDir(stackName))
+	return b.checkForLockIn(ctx, stackLockDir(stackRef.FullyQualifiedName()), b.lockPath(stackRef),
+		"the stack is currently locked by %v lock(s). Either wait for the other "+
+			"process(es) to end or delete the lock file with `pulumi cancel`.")
+}
+
+// checkForLockIn looks for any locks in the given directory other than our own, and returns a diagnostic built from
+// the given message if there are any. The message is formatted with the number of locks found.
+func (b *diyBackend) checkForLockIn(ctx context.Context, dir, ownLock, message string) error {
+	allFiles, err := listBucket(ctx, b.bucket, dir)
 	if err != nil {
 		return err
 	}
 
-	// lockPath may return a path with backslashes (\) on Windows.
+	// ownLock may be a path with backslashes (\) on Windows.
 	// We need to convert it to a slash path (/) to compare it to
 	// the keys in the bucket which are always slash paths.
-	wantLock := filepath.ToSlash(b.lockPath(stackRef))
+	wantLock := filepath.ToSlash(ownLock)
 	var lockKeys []string
 	for _, file := range allFiles {
 		if file.IsDir {
@@ -81,8 +89,7 @@ func (b *diyBackend) checkForLock(ctx context.Context, stackRef backend.StackRef
 	}
 
 	if len(lockKeys) > 0 {
-		errorString := fmt.Sprintf("the stack is currently locked by %v lock(s). Either wait for the other "+
-			"process(es) to end or delete the lock file with `pulumi cancel`.", len(lockKeys))
+		errorString := fmt.Sprintf(message, len(lockKeys))
 
 		for _, lock := range lockKeys {
 			content, err := b.bucket.ReadAll(ctx, lock)
@@ -120,8 +127,27 @@ func (b *diyBackend) lockURLForError(lockPath string) string {
 	return b.url + "/" + lockPath
 }
 
+// Lock takes the lock for the given stack. Locks are reentrant: if this backend already holds the stack's lock, Lock
+// checks that the lock file is still present and counts the extra hold, and the lock is only released by the
+// matching last call to Unlock.
 func (b *diyBackend) Lock(ctx context.Context, stackRef backend.StackReference) error {
-	//
+	b.locksMutex.Lock()
+	defer b.locksMutex.Unlock()
+
+	lockPath := b.lockPath(stackRef)
+	if held := b.heldLocks[lockPath]; held > 0 {
+		exists, err := b.bucket.Exists(ctx, lockPath)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("%w: the lock on stack %v was removed while it was held",
+				edit.ErrConcurrentModification, stackRef)
+		}
+		b.heldLocks[lockPath] = held + 1
+		return nil
+	}
+
 	err := b.checkForLock(ctx, stackRef)
 	if err != nil {
 		return err
@@ -134,19 +160,47 @@ func (b *diyBackend) Lock(ctx context.Context, stackRef backend.StackReference)
 	if err != nil {
 		return err
 	}
-	err = b.bucket.WriteAll(ctx, b.lockPath(stackRef), content, nil)
+	err = b.bucket.WriteAll(ctx, lockPath, content, nil)
 	if err != nil {
 		return err
 	}
 	err = b.checkForLock(ctx, stackRef)
 	if err != nil {
-		b.Unlock(ctx, stackRef)
+		b.deleteLock(ctx, stackRef)
 		return err
 	}
+	if b.heldLocks == nil {
+		b.heldLocks = make(map[string]int)
+	}
+	b.heldLocks[lockPath] = 1
 	return nil
 }
 
+// Unlock releases one hold on the given stack's lock, deleting the lock file once the last hold is released.
 func (b *diyBackend) Unlock(ctx context.Context, stackRef backend.StackReference) {
+	b.locksMutex.Lock()
+	defer b.locksMutex.Unlock()
+
+	lockPath := b.lockPath(stackRef)
+	if held := b.heldLocks[lockPath]; held > 1 {
+		b.heldLocks[lockPath] = held - 1
+		return
+	}
+	delete(b.heldLocks, lockPath)
+	b.deleteLock(ctx, stackRef)
+}
+
+// LockStack takes the given stack's lock until the returned function is called. Operations on the stack made through
+// this backend in the meantime, such as ImportDeployment, reuse the lock rather than releasing it when they finish.
+func (b *diyBackend) LockStack(ctx context.Context, stackRef backend.StackReference) (func(), error) {
+	if err := b.Lock(ctx, stackRef); err != nil {
+		return nil, err
+	}
+	return func() { b.Unlock(ctx, stackRef) }, nil
+}
+
+// deleteLock deletes this backend's lock file for the given stack.
+func (b *diyBackend) deleteLock(ctx context.Context, stackRef backend.StackReference) {
 	err := b.bucket.Delete(ctx, b.lockPath(stackRef))
 	if err != nil {
 		b.d.Errorf(
@@ -156,6 +210,49 @@ func (b *diyBackend) Unlock(ctx context.Context, stackRef backend.StackReference
 	}
 }
 
+// lockPolicyGroups takes the lock that guards changes to the backend's Policy Groups. Unlike stack locks, this lock
+// is held only for the duration of a single change to the policy groups file.
+func (b *diyBackend) lockPolicyGroups(ctx context.Context) error {
+	err := b.checkForPolicyGroupsLock(ctx)
+	if err != nil {
+		return err
+	}
+	lockContent, err := newLockContent()
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(lockContent)
+	if err != nil {
+		return err
+	}
+	err = b.bucket.WriteAll(ctx, b.policyGroupsLockPath(), content, nil)
+	if err != nil {
+		return err
+	}
+	err = b.checkForPolicyGroupsLock(ctx)
+	if err != nil {
+		b.unlockPolicyGroups(ctx)
+		return err
+	}
+	return nil
+}
+
+func (b *diyBackend) unlockPolicyGroups(ctx context.Context) {
+	err := b.bucket.Delete(ctx, b.policyGroupsLockPath())
+	if err != nil {
+		b.d.Errorf(
+			diag.Message("", "there was a problem deleting the lock at %v, manual clean up may be required: %v"),
+			path.Join(b.url, b.policyGroupsLockPath()),
+			err)
+	}
+}
+
+func (b *diyBackend) checkForPolicyGroupsLock(ctx context.Context) error {
+	return b.checkForLockIn(ctx, policyGroupsLockDir(), b.policyGroupsLockPath(),
+		"the policy groups are currently locked by %v lock(s). Either wait for the other "+
+			"process(es) to end or delete the lock file.")
+}
+
 func lockDir() string {
 	return path.Join(workspace.BookkeepingDir, workspace.LockDir)
 }
@@ -169,3 +266,13 @@ func (b *diyBackend) lockPath(stackRef backend.StackReference) string {
 	contract.Requiref(stackRef != nil, "stack", "must not be nil")
 	return path.Join(stackLockDir(stackRef.FullyQualifiedName()), b.lockID+".json")
 }
+
+// policyGroupsLockDir returns the directory holding the locks on the Policy Groups. It is kept outside lockDir, whose
+// subdirectories are named after stacks.
+func policyGroupsLockDir() string {
+	return path.Join(workspace.BookkeepingDir, "policygroups-locks")
+}
+
+func (b *diyBackend) policyGroupsLockPath() string {
+	return path.Join(policyGroupsLockDir(), b.lockID+".json")
+}
@@ -0,0 +1,659 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diy
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gocloud.dev/gcerrors"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	resourceanalyzer "github.com/pulumi/pulumi/pkg/v3/resource/analyzer"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// PolicyPacksDir is a path under the state's root directory
+// where the diy backend stores published Policy Packs.
+//
+// Each version of a Policy Pack is stored as a pair of files,
+// $PolicyPacksDir/$name/$versionTag.json holding its metadata
+// and $PolicyPacksDir/$name/$versionTag.tgz holding its contents.
+var PolicyPacksDir = filepath.Join(workspace.BookkeepingDir, "policypacks")
+
+// Path inside the bucket where we store which Policy Packs are enabled for which stacks.
+var policyGroupsPath = filepath.Join(workspace.BookkeepingDir, "policygroups.json")
+
+// defaultPolicyGroup is the name of the Policy Group that applies to every stack in the backend.
+const defaultPolicyGroup = "default-policy-group"
+
+// policyPackNameRE matches the names and version tags that may be used for Policy Packs.
+// These become object names in the bucket, so they must not contain path separators.
+var policyPackNameRE = regexp.MustCompile("^[a-zA-Z0-9-_.]{1,100}$")
+
+// diyPolicyPackVersion is the metadata stored for a published version of a Policy Pack.
+type diyPolicyPackVersion struct {
+	apitype.CreatePolicyPackRequest
+
+	// Version is the position of this version in the order the Policy Pack's versions were published,
+	// starting at 1. The latest version of a Policy Pack is the one with the highest Version.
+	Version int `json:"version"`
+}
+
+// diyPolicyGroups holds the contents of the policy groups file in a diy backend.
+//
+// A Policy Group enables a set of Policy Packs for a set of stacks. The groups are named after the stacks they
+// apply to: the default group applies to every stack, a group named "<project>" applies to every stack of that
+// project, and a group named "<project>/<stack>" applies to that single stack.
+type diyPolicyGroups struct {
+	PolicyGroups map[string][]apitype.PolicyPackMetadata `json:"policyGroups"`
+}
+
+// diyRequiredPolicy is a Policy Pack that has been published to, and enabled in, a diy backend.
+type diyRequiredPolicy struct {
+	apitype.PolicyPackMetadata
+	bucket Bucket
+	// namespace is the directory the Policy Pack is installed under, which is unique to the backend.
+	namespace string
+}
+
+var _ engine.RequiredPolicy = (*diyRequiredPolicy)(nil)
+
+func (rp *diyRequiredPolicy) Name() string    { return rp.PolicyPackMetadata.Name }
+func (rp *diyRequiredPolicy) Version() string { return rp.VersionTag }
+func (rp *diyRequiredPolicy) Config() map[string]*json.RawMessage {
+	return rp.PolicyPackMetadata.Config
+}
+
+func (rp *diyRequiredPolicy) Install(ctx *plugin.Context) (string, error) {
+	policyPackPath, installed, err := workspace.GetPolicyPath(rp.namespace,
+		strings.ReplaceAll(rp.Name(), tokens.QNameDelimiter, "_"), rp.VersionTag)
+	if err != nil {
+		// Failed to get a sensible PolicyPack path.
+		return "", err
+	} else if installed {
+		// We've already installed the PolicyPack. Return.
+		return policyPackPath, nil
+	}
+
+	fmt.Fprintf(os.Stderr, "Installing policy pack %s %s...\r\n", rp.Name(), rp.VersionTag)
+
+	tarballPath := policyPackTarballPath(rp.Name(), rp.VersionTag)
+	logging.V(7).Infof("Reading policy pack %s %s from %s", rp.Name(), rp.VersionTag, tarballPath)
+	tarball, err := rp.bucket.ReadAll(ctx.Request(), tarballPath)
+	if err != nil {
+		return "", fmt.Errorf("reading policy pack %s %s: %w", rp.Name(), rp.VersionTag, err)
+	}
+
+	return policyPackPath, backend.InstallPolicyPack(ctx, policyPackPath, io.NopCloser(bytes.NewReader(tarball)))
+}
+
+// diyPolicyPackReference is a reference to a Policy Pack stored in a diy backend.
+type diyPolicyPackReference struct {
+	// name of the Policy Pack. This is empty for a Policy Pack that is about to be published,
+	// whose name is determined by the publish operation.
+	name tokens.QName
+}
+
+var _ backend.PolicyPackReference = (*diyPolicyPackReference)(nil)
+
+func (pr *diyPolicyPackReference) String() string {
+	return fmt.Sprintf("%s/%s", pr.OrgName(), pr.name)
+}
+
+func (pr *diyPolicyPackReference) OrgName() string {
+	// diy has no organizations really, but we just always say it's "organization"
+	return "organization"
+}
+
+func (pr *diyPolicyPackReference) Name() tokens.QName {
+	return pr.name
+}
+
+// parsePolicyPackReference parses a Policy Pack reference of the form "[<org-name>/]<policy-pack-name>". As with
+// stack references, the organization, if given, must be "organization".
+func parsePolicyPackReference(s string) (*diyPolicyPackReference, error) {
+	name := s
+	if org, rest, ok := strings.Cut(s, "/"); ok {
+		if org != "" && org != "organization" {
+			return nil, fmt.Errorf("policy pack %q: organization name must be \"organization\" in a DIY backend", s)
+		}
+		name = rest
+	}
+	if name != "" && !policyPackNameRE.MatchString(name) {
+		return nil, fmt.Errorf("invalid policy pack name %q", name)
+	}
+	return &diyPolicyPackReference{name: tokens.QName(name)}, nil
+}
+
+// diyPolicyPack is the diy backend implementation of the PolicyPack interface.
+type diyPolicyPack struct {
+	ref *diyPolicyPackReference
+	b   *diyBackend
+}
+
+var _ backend.PolicyPack = (*diyPolicyPack)(nil)
+
+func (pack *diyPolicyPack) Ref() backend.PolicyPackReference {
+	return pack.ref
+}
+
+func (pack *diyPolicyPack) Backend() backend.Backend {
+	return pack.b
+}
+
+func (pack *diyPolicyPack) Publish(ctx context.Context, op backend.PublishOperation) error {
+	analyzerInfo, packTarball, err := backend.PackPolicyPack(ctx, op)
+	if err != nil {
+		return err
+	}
+
+	version, err := pack.b.publishPolicyPack(ctx, analyzerInfo, packTarball)
+	if err != nil {
+		return err
+	}
+	pack.ref.name = tokens.QName(analyzerInfo.Name)
+
+	fmt.Printf("\nPublished %q - version %s to %s\n", analyzerInfo.Name, version, pack.b.originalURL)
+
+	return nil
+}
+
+func (pack *diyPolicyPack) Enable(ctx context.Context, policyGroup string, op backend.PolicyPackOperation) error {
+	group, err := pack.b.parsePolicyGroup(policyGroup)
+	if err != nil {
+		return err
+	}
+
+	return pack.b.updatePolicyGroups(ctx, func(groups *diyPolicyGroups) error {
+		// Look the version up while the Policy Groups are locked, so that it can't be removed before it is enabled.
+		version, err := pack.b.getPolicyPackVersion(ctx, string(pack.ref.name), op.VersionTag)
+		if err != nil {
+			return err
+		}
+		if err := resourceanalyzer.ValidatePolicyPackConfig(version.configSchema(), op.Config); err != nil {
+			return err
+		}
+
+		// Only one version of a Policy Pack can be enabled in a group at a time,
+		// so enabling a version replaces any other.
+		packs := slices.DeleteFunc(groups.PolicyGroups[group], func(p apitype.PolicyPackMetadata) bool {
+			return p.Name == version.Name
+		})
+		groups.PolicyGroups[group] = append(packs, apitype.PolicyPackMetadata{
+			Name:        version.Name,
+			DisplayName: version.DisplayName,
+			Version:     version.Version,
+			VersionTag:  version.VersionTag,
+			Config:      op.Config,
+		})
+		return nil
+	})
+}
+
+func (pack *diyPolicyPack) Disable(ctx context.Context, policyGroup string, op backend.PolicyPackOperation) error {
+	group, err := pack.b.parsePolicyGroup(policyGroup)
+	if err != nil {
+		return err
+	}
+
+	return pack.b.updatePolicyGroups(ctx, func(groups *diyPolicyGroups) error {
+		packs := groups.PolicyGroups[group]
+		n := len(packs)
+		packs = slices.DeleteFunc(packs, func(p apitype.PolicyPackMetadata) bool {
+			return p.Name == string(pack.ref.name) && (op.VersionTag == nil || p.VersionTag == *op.VersionTag)
+		})
+		if len(packs) == n {
+			return fmt.Errorf("policy pack %s is not enabled in policy group %q", pack.ref, group)
+		}
+		if len(packs) == 0 {
+			delete(groups.PolicyGroups, group)
+		} else {
+			groups.PolicyGroups[group] = packs
+		}
+		return nil
+	})
+}
+
+func (pack *diyPolicyPack) Validate(ctx context.Context, op backend.PolicyPackOperation) error {
+	version, err := pack.b.getPolicyPackVersion(ctx, string(pack.ref.name), op.VersionTag)
+	if err != nil {
+		return err
+	}
+	return resourceanalyzer.ValidatePolicyPackConfig(version.configSchema(), op.Config)
+}
+
+func (pack *diyPolicyPack) Remove(ctx context.Context, op backend.PolicyPackOperation) error {
+	name := string(pack.ref.name)
+	versions, err := pack.b.listPolicyPackVersions(ctx, name)
+	if err != nil {
+		return err
+	}
+	if op.VersionTag != nil {
+		versions = slices.DeleteFunc(versions, func(v *diyPolicyPackVersion) bool {
+			return v.VersionTag != *op.VersionTag
+		})
+	}
+	if len(versions) == 0 {
+		return fmt.Errorf("could not find PolicyPack %q", pack.ref)
+	}
+
+	// Hold the Policy Groups lock while removing, so that the versions can't be enabled until they are gone.
+	if err := pack.b.lockPolicyGroups(ctx); err != nil {
+		return err
+	}
+	defer pack.b.unlockPolicyGroups(ctx)
+
+	groups, err := pack.b.readPolicyGroups(ctx)
+	if err != nil {
+		return err
+	}
+	for group, packs := range groups.PolicyGroups {
+		for _, p := range packs {
+			if p.Name == name && (op.VersionTag == nil || p.VersionTag == *op.VersionTag) {
+				return fmt.Errorf("policy pack %s %s is enabled in policy group %q and must be disabled "+
+					"before it can be removed", pack.ref, p.VersionTag, group)
+			}
+		}
+	}
+
+	for _, v := range versions {
+		for _, key := range []string{
+			policyPackTarballPath(name, v.VersionTag),
+			policyPackMetadataPath(name, v.VersionTag),
+		} {
+			if err := pack.b.bucket.Delete(ctx, key); err != nil {
+				return fmt.Errorf("removing policy pack %s %s: %w", pack.ref, v.VersionTag, err)
+			}
+		}
+	}
+	return nil
+}
+
+// configSchema returns the configuration schemas of the policies in this version of the Policy Pack.
+func (v *diyPolicyPackVersion) configSchema() map[string]apitype.PolicyConfigSchema {
+	schema := map[string]apitype.PolicyConfigSchema{}
+	for _, policy := range v.Policies {
+		if policy.ConfigSchema != nil {
+			schema[policy.Name] = *policy.ConfigSchema
+		}
+	}
+	return schema
+}
+
+func policyPackMetadataPath(name, versionTag string) string {
+	return path.Join(filepath.ToSlash(PolicyPacksDir), name, versionTag+".json")
+}
+
+func policyPackTarballPath(name, versionTag string) string {
+	return path.Join(filepath.ToSlash(PolicyPacksDir), name, versionTag+".tgz")
+}
+
+// publishPolicyPack stores a new version of a Policy Pack in the bucket, returning the version tag it was published
+// with. As with the Pulumi Cloud, a published version can not be republished.
+func (b *diyBackend) publishPolicyPack(
+	ctx context.Context, analyzerInfo plugin.AnalyzerInfo, tarball []byte,
+) (string, error) {
+	if !policyPackNameRE.MatchString(analyzerInfo.Name) {
+		return "", fmt.Errorf("invalid policy pack name %q - name may only contain alphanumeric, hyphens, "+
+			"underscores, or periods", analyzerInfo.Name)
+	}
+	if analyzerInfo.Version != "" && !policyPackNameRE.MatchString(analyzerInfo.Version) {
+		return "", fmt.Errorf("invalid version %q - version may only contain alphanumeric, hyphens, "+
+			"underscores, or periods", analyzerInfo.Version)
+	}
+
+	existing, err := b.listPolicyPackVersions(ctx, analyzerInfo.Name)
+	if err != nil {
+		return "", err
+	}
+	version := 1
+	for _, v := range existing {
+		if v.VersionTag == analyzerInfo.Version {
+			return "", fmt.Errorf("policy pack %q version %s has already been published",
+				analyzerInfo.Name, analyzerInfo.Version)
+		}
+		version = max(version, v.Version+1)
+	}
+
+	// Older versions of pulumi/policy do not provide a version tag,
+	// so we use the version number instead, as the Pulumi Cloud does.
+	versionTag := analyzerInfo.Version
+	if versionTag == "" {
+		versionTag = strconv.Itoa(version)
+	}
+
+	policies := make([]apitype.Policy, len(analyzerInfo.Policies))
+	for i, policy := range analyzerInfo.Policies {
+		configSchema, err := convertPolicyConfigSchema(policy.ConfigSchema)
+		if err != nil {
+			return "", err
+		}
+		policies[i] = apitype.Policy{
+			Name:             policy.Name,
+			DisplayName:      policy.DisplayName,
+			Description:      policy.Description,
+			EnforcementLevel: policy.EnforcementLevel,
+			Message:          policy.Message,
+			ConfigSchema:     configSchema,
+		}
+	}
+	metadata, err := json.MarshalIndent(diyPolicyPackVersion{
+		CreatePolicyPackRequest: apitype.CreatePolicyPackRequest{
+			Name:        analyzerInfo.Name,
+			DisplayName: analyzerInfo.DisplayName,
+			VersionTag:  versionTag,
+			Policies:    policies,
+		},
+		Version: version,
+	}, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Printf("Publishing %q - version %s\n", analyzerInfo.Name, versionTag)
+
+	// Write the tarball first, so that the version only becomes visible once it can be installed.
+	if err := b.bucket.WriteAll(ctx, policyPackTarballPath(analyzerInfo.Name, versionTag), tarball, nil); err != nil {
+		return "", fmt.Errorf("writing policy pack: %w", err)
+	}
+	if err := b.bucket.WriteAll(ctx, policyPackMetadataPath(analyzerInfo.Name, versionTag), metadata, nil); err != nil {
+		return "", fmt.Errorf("writing policy pack: %w", err)
+	}
+	return versionTag, nil
+}
+
+// convertPolicyConfigSchema converts a policy's configuration schema to the form it is stored in.
+func convertPolicyConfigSchema(schema *plugin.AnalyzerPolicyConfigSchema) (*apitype.PolicyConfigSchema, error) {
+	if schema == nil {
+		return nil, nil
+	}
+	properties := map[string]*json.RawMessage{}
+	for k, v := range schema.Properties {
+		bytes, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		raw := json.RawMessage(bytes)
+		properties[k] = &raw
+	}
+	return &apitype.PolicyConfigSchema{
+		Type:       apitype.Object,
+		Properties: properties,
+		Required:   schema.Required,
+	}, nil
+}
+
+// listPolicyPackVersions returns the published versions of the named Policy Pack, ordered from oldest to newest.
+func (b *diyBackend) listPolicyPackVersions(ctx context.Context, name string) ([]*diyPolicyPackVersion, error) {
+	files, err := listBucket(ctx, b.bucket, path.Join(filepath.ToSlash(PolicyPacksDir), name))
+	if err != nil {
+		return nil, fmt.Errorf("error listing policy packs: %w", err)
+	}
+
+	var versions []*diyPolicyPackVersion
+	for _, file := range files {
+		if file.IsDir || path.Ext(file.Key) != ".json" {
+			continue
+		}
+		data, err := b.bucket.ReadAll(ctx, file.Key)
+		if err != nil {
+			return nil, fmt.Errorf("reading %q: %w", file.Key, err)
+		}
+		var version diyPolicyPackVersion
+		if err := json.Unmarshal(data, &version); err != nil {
+			return nil, fmt.Errorf("corrupt store: unmarshal %q: %w", file.Key, err)
+		}
+		versions = append(versions, &version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Version < versions[j].Version })
+	return versions, nil
+}
+
+// getPolicyPackVersion returns the given version of the named Policy Pack, or its latest version if versionTag is nil.
+func (b *diyBackend) getPolicyPackVersion(
+	ctx context.Context, name string, versionTag *string,
+) (*diyPolicyPackVersion, error) {
+	versions, err := b.listPolicyPackVersions(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("could not find PolicyPack %q", name)
+	}
+	if versionTag == nil {
+		return versions[len(versions)-1], nil
+	}
+	for _, v := range versions {
+		if v.VersionTag == *versionTag {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find version %s of PolicyPack %q", *versionTag, name)
+}
+
+// parsePolicyGroup validates the name of a Policy Group, returning the name it is stored under.
+// An empty name refers to the default Policy Group.
+func (b *diyBackend) parsePolicyGroup(policyGroup string) (string, error) {
+	if policyGroup == "" || policyGroup == defaultPolicyGroup {
+		return defaultPolicyGroup, nil
+	}
+
+	project, stack, hasStack := strings.Cut(policyGroup, "/")
+	if !tokens.IsName(project) {
+		return "", fmt.Errorf("invalid policy group %q: must be %q, a project name, or <project>/<stack>",
+			policyGroup, defaultPolicyGroup)
+	}
+	if hasStack {
+		if _, err := tokens.ParseStackName(stack); err != nil {
+			return "", fmt.Errorf("invalid policy group %q: %w", policyGroup, err)
+		}
+	}
+	return policyGroup, nil
+}
+
+// policyPackNamespace returns the directory name under which Policy Packs from this backend are installed. Every diy
+// backend calls its organization "organization", so Policy Packs with the same name and version in different backends
+// are installed under a hash of the backend's URL to keep them apart.
+func (b *diyBackend) policyPackNamespace() string {
+	sum := sha256.Sum256([]byte(b.url))
+	return "diy-" + hex.EncodeToString(sum[:8])
+}
+
+// readPolicyGroups loads the Policy Groups from the bucket.
+// If the file does not exist, there are no Policy Groups.
+func (b *diyBackend) readPolicyGroups(ctx context.Context) (*diyPolicyGroups, error) {
+	groups := &diyPolicyGroups{}
+	data, err := b.bucket.ReadAll(ctx, policyGroupsPath)
+	if err != nil && gcerrors.Code(err) != gcerrors.NotFound {
+		return nil, fmt.Errorf("read %q: %w", policyGroupsPath, err)
+	} else if err == nil {
+		if err := json.Unmarshal(data, groups); err != nil {
+			return nil, fmt.Errorf("corrupt store: unmarshal %q: %w", policyGroupsPath, err)
+		}
+	}
+	if groups.PolicyGroups == nil {
+		groups.PolicyGroups = map[string][]apitype.PolicyPackMetadata{}
+	}
+	return groups, nil
+}
+
+// updatePolicyGroups applies the given change to the Policy Groups stored in the bucket. The Policy Groups are locked
+// for the duration of the change, so that concurrent changes are not lost.
+func (b *diyBackend) updatePolicyGroups(ctx context.Context, update func(groups *diyPolicyGroups) error) error {
+	if err := b.lockPolicyGroups(ctx); err != nil {
+		return err
+	}
+	defer b.unlockPolicyGroups(ctx)
+
+	groups, err := b.readPolicyGroups(ctx)
+	if err != nil {
+		return err
+	}
+	if err := update(groups); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(groups, "", "    ")
+	if err != nil {
+		return err
+	}
+	if err := b.bucket.WriteAll(ctx, policyGroupsPath, data, nil); err != nil {
+		return fmt.Errorf("write %q: %w", policyGroupsPath, err)
+	}
+	return nil
+}
+
+// policyGroupsForStack returns the names of the Policy Groups that apply to the given stack,
+// ordered from least to most specific.
+func policyGroupsForStack(ref *diyBackendReference) []string {
+	groups := []string{defaultPolicyGroup}
+	if ref.project != "" {
+		groups = append(groups, string(ref.project), fmt.Sprintf("%s/%s", ref.project, ref.name))
+	}
+	return groups
+}
+
+// requiredPolicies returns the Policy Packs that are enabled for the given stack. If the same Policy Pack is enabled
+// by several of the stack's Policy Groups, the version and configuration from the most specific group are used.
+func (b *diyBackend) requiredPolicies(
+	ctx context.Context, ref *diyBackendReference,
+) ([]engine.RequiredPolicy, error) {
+	groups, err := b.readPolicyGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	enabled := map[string]apitype.PolicyPackMetadata{}
+	for _, group := range policyGroupsForStack(ref) {
+		for _, pack := range groups.PolicyGroups[group] {
+			if _, has := enabled[pack.Name]; !has {
+				names = append(names, pack.Name)
+			}
+			enabled[pack.Name] = pack
+		}
+	}
+
+	policies := make([]engine.RequiredPolicy, len(names))
+	for i, name := range names {
+		policies[i] = &diyRequiredPolicy{
+			PolicyPackMetadata: enabled[name],
+			bucket:             b.bucket,
+			namespace:          b.policyPackNamespace(),
+		}
+	}
+	return policies, nil
+}
+
+func (b *diyBackend) GetPolicyPack(ctx context.Context, policyPack string,
+	d diag.Sink,
+) (backend.PolicyPack, error) {
+	ref, err := parsePolicyPackReference(policyPack)
+	if err != nil {
+		return nil, err
+	}
+	return &diyPolicyPack{ref: ref, b: b}, nil
+}
+
+func (b *diyBackend) ListPolicyGroups(ctx context.Context, orgName string, _ backend.ContinuationToken) (
+	apitype.ListPolicyGroupsResponse, backend.ContinuationToken, error,
+) {
+	groups, err := b.readPolicyGroups(ctx)
+	if err != nil {
+		return apitype.ListPolicyGroupsResponse{}, nil, err
+	}
+	stacks, err := b.store.ListReferences(ctx)
+	if err != nil {
+		return apitype.ListPolicyGroupsResponse{}, nil, err
+	}
+
+	// The default group is always listed, even when it has no Policy Packs enabled.
+	names := []string{defaultPolicyGroup}
+	for name := range groups.PolicyGroups {
+		if name != defaultPolicyGroup {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names[1:])
+
+	resp := apitype.ListPolicyGroupsResponse{PolicyGroups: make([]apitype.PolicyGroupSummary, len(names))}
+	for i, name := range names {
+		numStacks := 0
+		for _, stack := range stacks {
+			if slices.Contains(policyGroupsForStack(stack), name) {
+				numStacks++
+			}
+		}
+		resp.PolicyGroups[i] = apitype.PolicyGroupSummary{
+			Name:                  name,
+			IsOrgDefault:          name == defaultPolicyGroup,
+			NumStacks:             numStacks,
+			NumEnabledPolicyPacks: len(groups.PolicyGroups[name]),
+		}
+	}
+	return resp, nil, nil
+}
+
+func (b *diyBackend) ListPolicyPacks(ctx context.Context, orgName string, _ backend.ContinuationToken) (
+	apitype.ListPolicyPacksResponse, backend.ContinuationToken, error,
+) {
+	files, err := listBucket(ctx, b.bucket, filepath.ToSlash(PolicyPacksDir))
+	if err != nil {
+		return apitype.ListPolicyPacksResponse{}, nil, fmt.Errorf("error listing policy packs: %w", err)
+	}
+
+	resp := apitype.ListPolicyPacksResponse{PolicyPacks: []apitype.PolicyPackWithVersions{}}
+	for _, file := range files {
+		if !file.IsDir {
+			continue
+		}
+		versions, err := b.listPolicyPackVersions(ctx, objectName(file))
+		if err != nil {
+			return apitype.ListPolicyPacksResponse{}, nil, err
+		}
+		if len(versions) == 0 {
+			continue
+		}
+		pack := apitype.PolicyPackWithVersions{
+			Name:        versions[0].Name,
+			DisplayName: versions[len(versions)-1].DisplayName,
+		}
+		for _, v := range versions {
+			pack.Versions = append(pack.Versions, v.Version)
+			pack.VersionTags = append(pack.VersionTags, v.VersionTag)
+		}
+		resp.PolicyPacks = append(resp.PolicyPacks, pack)
+	}
+	return resp, nil, nil
+}
@@ -0,0 +1,224 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diy
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/testing/diagtest"
+)
+
+func TestPolicyPackPublishAndList(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	b, err := New(ctx, diagtest.LogSink(t), "file://"+filepath.ToSlash(t.TempDir()), nil)
+	require.NoError(t, err)
+	lb, ok := b.(*diyBackend)
+	require.True(t, ok)
+
+	_, err = lb.publishPolicyPack(ctx, plugin.AnalyzerInfo{Name: "security", Version: "1.0.0"}, []byte("v1"))
+	require.NoError(t, err)
+	_, err = lb.publishPolicyPack(ctx, plugin.AnalyzerInfo{Name: "security", Version: "1.1.0"}, []byte("v2"))
+	require.NoError(t, err)
+	// Packs without a version tag are given their version number instead.
+	versionTag, err := lb.publishPolicyPack(ctx, plugin.AnalyzerInfo{Name: "cost"}, []byte("v1"))
+	require.NoError(t, err)
+	assert.Equal(t, "1", versionTag)
+
+	// Published versions can not be republished.
+	_, err = lb.publishPolicyPack(ctx, plugin.AnalyzerInfo{Name: "security", Version: "1.0.0"}, []byte("v3"))
+	assert.ErrorContains(t, err, "has already been published")
+
+	resp, _, err := b.ListPolicyPacks(ctx, "organization", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []apitype.PolicyPackWithVersions{
+		{Name: "cost", Versions: []int{1}, VersionTags: []string{"1"}},
+		{Name: "security", Versions: []int{1, 2}, VersionTags: []string{"1.0.0", "1.1.0"}},
+	}, resp.PolicyPacks)
+}
+
+func TestPolicyPackEnableForStacks(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	b, err := New(ctx, diagtest.LogSink(t), "file://"+filepath.ToSlash(t.TempDir()), nil)
+	require.NoError(t, err)
+	lb, ok := b.(*diyBackend)
+	require.True(t, ok)
+
+	_, err = lb.publishPolicyPack(ctx, plugin.AnalyzerInfo{Name: "security", Version: "1.0.0"}, []byte("v1"))
+	require.NoError(t, err)
+	_, err = lb.publishPolicyPack(ctx, plugin.AnalyzerInfo{Name: "security", Version: "2.0.0"}, []byte("v2"))
+	require.NoError(t, err)
+
+	var refs []*diyBackendReference
+	for _, name := range []string{"organization/proj/dev", "organization/proj/prod", "organization/other/dev"} {
+		ref, err := lb.parseStackReference(name)
+		require.NoError(t, err)
+		_, err = b.CreateStack(ctx, ref, "", nil, nil)
+		require.NoError(t, err)
+		refs = append(refs, ref)
+	}
+	dev, prod, other := refs[0], refs[1], refs[2]
+
+	pack, err := b.GetPolicyPack(ctx, "organization/security", nil)
+	require.NoError(t, err)
+
+	requiredVersions := func(ref *diyBackendReference) map[string]string {
+		policies, err := lb.requiredPolicies(ctx, ref)
+		require.NoError(t, err)
+		versions := map[string]string{}
+		for _, p := range policies {
+			versions[p.Name()] = p.Version()
+		}
+		return versions
+	}
+
+	// Enabling the latest version for the default group applies it to every stack.
+	require.NoError(t, pack.Enable(ctx, "", backend.PolicyPackOperation{}))
+	assert.Equal(t, map[string]string{"security": "2.0.0"}, requiredVersions(dev))
+	assert.Equal(t, map[string]string{"security": "2.0.0"}, requiredVersions(other))
+
+	// A more specific group overrides the version and configuration used for its stacks.
+	v1 := "1.0.0"
+	config := json.RawMessage(`{"enforcementLevel":"mandatory"}`)
+	require.NoError(t, pack.Enable(ctx, "proj/prod", backend.PolicyPackOperation{
+		VersionTag: &v1,
+		Config:     map[string]*json.RawMessage{"no-public-buckets": &config},
+	}))
+	assert.Equal(t, map[string]string{"security": "2.0.0"}, requiredVersions(dev))
+	assert.Equal(t, map[string]string{"security": "1.0.0"}, requiredVersions(prod))
+	policies, err := lb.requiredPolicies(ctx, prod)
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	require.Contains(t, policies[0].Config(), "no-public-buckets")
+	assert.JSONEq(t, string(config), string(*policies[0].Config()["no-public-buckets"]))
+
+	groups, _, err := b.ListPolicyGroups(ctx, "organization", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []apitype.PolicyGroupSummary{
+		{Name: "default-policy-group", IsOrgDefault: true, NumStacks: 3, NumEnabledPolicyPacks: 1},
+		{Name: "proj/prod", NumStacks: 1, NumEnabledPolicyPacks: 1},
+	}, groups.PolicyGroups)
+
+	// Enabled packs can not be removed.
+	err = pack.Remove(ctx, backend.PolicyPackOperation{VersionTag: &v1})
+	assert.ErrorContains(t, err, "must be disabled before it can be removed")
+
+	require.NoError(t, pack.Disable(ctx, "proj/prod", backend.PolicyPackOperation{}))
+	assert.Equal(t, map[string]string{"security": "2.0.0"}, requiredVersions(prod))
+	require.NoError(t, pack.Remove(ctx, backend.PolicyPackOperation{VersionTag: &v1}))
+
+	require.NoError(t, pack.Disable(ctx, "", backend.PolicyPackOperation{}))
+	assert.Empty(t, requiredVersions(dev))
+
+	// Unknown versions and malformed groups are rejected.
+	err = pack.Enable(ctx, "", backend.PolicyPackOperation{VersionTag: &v1})
+	assert.ErrorContains(t, err, "could not find version 1.0.0")
+	err = pack.Enable(ctx, "proj/not a stack", backend.PolicyPackOperation{})
+	assert.ErrorContains(t, err, "invalid policy group")
+}
+
+func TestParsePolicyPackReference(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{"security", "organization/security", "/security"} {
+		ref, err := parsePolicyPackReference(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, "organization/security", ref.String())
+	}
+
+	_, err := parsePolicyPackReference("acme/security")
+	assert.ErrorContains(t, err, `organization name must be "organization"`)
+	_, err = parsePolicyPackReference("organization/a/b")
+	assert.ErrorContains(t, err, "invalid policy pack name")
+}
+
+func TestPolicyPackNamespace(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	newBackend := func() *diyBackend {
+		b, err := New(ctx, diagtest.LogSink(t), "file://"+filepath.ToSlash(t.TempDir()), nil)
+		require.NoError(t, err)
+		lb, ok := b.(*diyBackend)
+		require.True(t, ok)
+		return lb
+	}
+	a, b := newBackend(), newBackend()
+
+	// Policy Packs from different backends are installed in different places, even though both backends
+	// call their organization "organization".
+	assert.NotEqual(t, a.policyPackNamespace(), b.policyPackNamespace())
+
+	_, err := a.publishPolicyPack(ctx, plugin.AnalyzerInfo{Name: "security", Version: "1.0.0"}, []byte("v1"))
+	require.NoError(t, err)
+	pack, err := a.GetPolicyPack(ctx, "security", nil)
+	require.NoError(t, err)
+	require.NoError(t, pack.Enable(ctx, "", backend.PolicyPackOperation{}))
+
+	ref, err := a.parseStackReference("organization/proj/dev")
+	require.NoError(t, err)
+	policies, err := a.requiredPolicies(ctx, ref)
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	assert.Equal(t, a.policyPackNamespace(), policies[0].(*diyRequiredPolicy).namespace)
+}
+
+func TestPolicyGroupsLock(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	b, err := New(ctx, diagtest.LogSink(t), "file://"+filepath.ToSlash(t.TempDir()), nil)
+	require.NoError(t, err)
+	lb, ok := b.(*diyBackend)
+	require.True(t, ok)
+
+	_, err = lb.publishPolicyPack(ctx, plugin.AnalyzerInfo{Name: "security", Version: "1.0.0"}, []byte("v1"))
+	require.NoError(t, err)
+	pack, err := b.GetPolicyPack(ctx, "security", nil)
+	require.NoError(t, err)
+
+	// The lock is released once the change has been made.
+	require.NoError(t, pack.Enable(ctx, "", backend.PolicyPackOperation{}))
+	locks, err := listBucket(ctx, lb.bucket, policyGroupsLockDir())
+	require.NoError(t, err)
+	assert.Empty(t, locks)
+
+	// Changes are refused while another process holds the lock.
+	content, err := json.Marshal(lockContent{Pid: 1, Username: "other", Hostname: "elsewhere"})
+	require.NoError(t, err)
+	require.NoError(t, lb.bucket.WriteAll(ctx, path.Join(policyGroupsLockDir(), "other.json"), content, nil))
+
+	err = pack.Disable(ctx, "", backend.PolicyPackOperation{})
+	assert.ErrorContains(t, err, "the policy groups are currently locked by 1 lock(s)")
+	err = pack.Remove(ctx, backend.PolicyPackOperation{})
+	assert.ErrorContains(t, err, "the policy groups are currently locked by 1 lock(s)")
+
+	groups, err := lb.readPolicyGroups(ctx)
+	require.NoError(t, err)
+	assert.Len(t, groups.PolicyGroups[defaultPolicyGroup], 1)
+}
@@ -403,36 +403,11 @@ func (b *diyBackend) getHistory(
 ) ([]backend.UpdateInfo, error) {
 	contract.Requiref(stack != nil, "stack", "must not be nil")
 
-	dir := stack.HistoryDir()
-	// TODO: we could consider optimizing the list operation using `page` and `pageSize`.
-	// Unfortunately, this is mildly invasive given the gocloud List API.
-	allFiles, err := listBucket(ctx, b.bucket, dir)
+	historyEntries, err := b.listHistoryEntries(ctx, stack)
 	if err != nil {
-		// History doesn't exist until a stack has been updated.
-		if gcerrors.Code(err) == gcerrors.NotFound {
-			return nil, nil
-		}
 		return nil, err
 	}
 
-	var historyEntries []*blob.ListObject
-
-	// filter down to just history entries, reversing list to be in most recent order.
-	// listBucket returns the array sorted by file name, but because of how we name files, older updates come before
-	// newer ones.
-	for i := len(allFiles) - 1; i >= 0; i-- {
-		file := allFiles[i]
-		filepath := file.Key
-
-		// ignore checkpoints
-		if !strings.HasSuffix(filepath, ".history.json") &&
-			!strings.HasSuffix(filepath, ".history.json.gz") {
-			continue
-		}
-
-		historyEntries = append(historyEntries, file)
-	}
-
 	start := 0
 	end := len(historyEntries) - 1
 	if pageSize > 0 {
@@ -465,6 +440,8 @@ func (b *diyBackend) getHistory(
 		if err != nil {
 			return nil, fmt.Errorf("reading history file %s: %w", filepath, err)
 		}
+		// History entries are numbered from 1, starting with the oldest.
+		update.Version = len(historyEntries) - i
 
 		updates = append(updates, update)
 	}
@@ -472,6 +449,75 @@ func (b *diyBackend) getHistory(
 	return updates, nil
 }
 
+// listHistoryEntries returns the history files for the given stack, most recent first.
+func (b *diyBackend) listHistoryEntries(ctx context.Context, stack *diyBackendReference) ([]*blob.ListObject, error) {
+	dir := stack.HistoryDir()
+	// TODO: we could consider optimizing the list operation using `page` and `pageSize`.
+	// Unfortunately, this is mildly invasive given the gocloud List API.
+	allFiles, err := listBucket(ctx, b.bucket, dir)
+	if err != nil {
+		// History doesn't exist until a stack has been updated.
+		if gcerrors.Code(err) == gcerrors.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var historyEntries []*blob.ListObject
+
+	// filter down to just history entries, reversing list to be in most recent order.
+	// listBucket returns the array sorted by file name, but because of how we name files, older updates come before
+	// newer ones.
+	for i := len(allFiles) - 1; i >= 0; i-- {
+		file := allFiles[i]
+		filepath := file.Key
+
+		// ignore checkpoints
+		if !strings.HasSuffix(filepath, ".history.json") &&
+			!strings.HasSuffix(filepath, ".history.json.gz") {
+			continue
+		}
+
+		historyEntries = append(historyEntries, file)
+	}
+
+	return historyEntries, nil
+}
+
+// getHistoricalCheckpoint returns the checkpoint that was saved alongside the given version of the stack's history.
+// Versions are numbered from 1, starting with the oldest update.
+func (b *diyBackend) getHistoricalCheckpoint(
+	ctx context.Context, ref *diyBackendReference, version int,
+) (*apitype.CheckpointV3, error) {
+	historyEntries, err := b.listHistoryEntries(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if version <= 0 || version > len(historyEntries) {
+		return nil, fmt.Errorf("stack %s has no version %d", ref, version)
+	}
+
+	// The checkpoint copy shares its prefix with the history file. See addToHistory.
+	historyFile := historyEntries[len(historyEntries)-version].Key
+	pathPrefix := historyFile[:strings.LastIndex(historyFile, ".history.json")]
+	for _, ext := range []string{"json", "json.gz"} {
+		checkpointFile := fmt.Sprintf("%s.checkpoint.%s", pathPrefix, ext)
+		bytes, err := b.bucket.ReadAll(ctx, checkpointFile)
+		if err != nil {
+			if gcerrors.Code(err) == gcerrors.NotFound {
+				continue
+			}
+			return nil, fmt.Errorf("reading checkpoint file %s: %w", checkpointFile, err)
+		}
+		m := encoding.JSON
+		if encoding.IsCompressed(bytes) {
+			m = encoding.Gzip(m)
+		}
+		return stack.UnmarshalVersionedCheckpointToLatestCheckpoint(m, bytes)
+	}
+	return nil, fmt.Errorf("no checkpoint was saved for version %d of stack %s", version, ref)
+}
+
 func (b *diyBackend) renameHistory(ctx context.Context, oldName, newName *diyBackendReference) error {
 	contract.Requiref(oldName != nil, "oldName", "must not be nil")
 	contract.Requiref(newName != nil, "newName", "must not be nil")
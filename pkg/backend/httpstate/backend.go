@@ -1261,7 +1261,7 @@ func (b *cloudBackend) Explain(
 }
 
 func (b *cloudBackend) Import(ctx context.Context, stack backend.Stack,
-	op backend.UpdateOperation, imports []deploy.Import,
+	op backend.UpdateOperation, imports []deploy.Import, events chan<- engine.Event,
 ) (sdkDisplay.ResourceChanges, error) {
 	op.Imports = imports
 
@@ -1274,15 +1274,15 @@ func (b *cloudBackend) Import(ctx context.Context, stack backend.Stack,
 
 		op.Opts.Engine.GeneratePlan = false
 		_, changes, err := b.apply(
-			ctx, apitype.ResourceImportUpdate, stack, op, opts, nil /*events*/)
+			ctx, apitype.ResourceImportUpdate, stack, op, opts, events)
 		return changes, err
 	}
 
-	return backend.PreviewThenPromptThenExecute(ctx, apitype.ResourceImportUpdate, stack, op, b.apply, b, nil)
+	return backend.PreviewThenPromptThenExecute(ctx, apitype.ResourceImportUpdate, stack, op, b.apply, b, events)
 }
 
 func (b *cloudBackend) Refresh(ctx context.Context, stack backend.Stack,
-	op backend.UpdateOperation,
+	op backend.UpdateOperation, events chan<- engine.Event,
 ) (sdkDisplay.ResourceChanges, error) {
 	if op.Opts.PreviewOnly {
 		// We can skip PreviewThenPromptThenExecute, and just go straight to Execute.
@@ -1293,10 +1293,10 @@ func (b *cloudBackend) Refresh(ctx context.Context, stack backend.Stack,
 
 		op.Opts.Engine.GeneratePlan = false
 		_, changes, err := b.apply(
-			ctx, apitype.RefreshUpdate, stack, op, opts, nil /*events*/)
+			ctx, apitype.RefreshUpdate, stack, op, opts, events)
 		return changes, err
 	}
-	return backend.PreviewThenPromptThenExecute(ctx, apitype.RefreshUpdate, stack, op, b.apply, b, nil)
+	return backend.PreviewThenPromptThenExecute(ctx, apitype.RefreshUpdate, stack, op, b.apply, b, events)
 }
 
 func (b *cloudBackend) Destroy(ctx context.Context, stack backend.Stack,
@@ -2341,8 +2341,9 @@ func (c httpstateBackendClient) GetStackOutputs(
 	onDecryptError func(error) error,
 ) (resource.PropertyMap, error) {
 	// When using the cloud backend, require that stack references are fully qualified so they
-	// look like "<org>/<project>/<stack>"
-	if strings.Count(name, "/") != 2 {
+	// look like "<org>/<project>/<stack>", unless they name a stack in another backend.
+	_, _, crossBackend := backend.ParseCrossBackendStackReference(name)
+	if !crossBackend && strings.Count(name, "/") != 2 {
 		return nil, errors.New("a stack reference's name should be of the form '<organization>/<project>/<stack>'. " +
 			"See https://www.pulumi.com/docs/using-pulumi/stack-outputs-and-references/#using-stack-references " +
 			"for more information.")
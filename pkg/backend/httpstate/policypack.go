@@ -19,9 +19,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"os"
-	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -29,15 +27,11 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/backend/httpstate/client"
 	"github.com/pulumi/pulumi/pkg/v3/engine"
 	resourceanalyzer "github.com/pulumi/pulumi/pkg/v3/resource/analyzer"
-	pkgCmdUtil "github.com/pulumi/pulumi/pkg/v3/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/util/archive"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
-	"github.com/pulumi/pulumi/sdk/v3/nodejs/npm"
 )
 
 type cloudRequiredPolicy struct {
@@ -91,7 +85,7 @@ func (rp *cloudRequiredPolicy) Install(ctx *plugin.Context) (string, error) {
 		return "", err
 	}
 
-	return policyPackPath, installRequiredPolicy(ctx, policyPackPath, policyPackTarball)
+	return policyPackPath, backend.InstallPolicyPack(ctx, policyPackPath, policyPackTarball)
 }
 
 func (rp *cloudRequiredPolicy) Config() map[string]*json.RawMessage { return rp.RequiredPolicy.Config }
@@ -163,23 +157,7 @@ func (pack *cloudPolicyPack) Backend() backend.Backend {
 func (pack *cloudPolicyPack) Publish(
 	ctx context.Context, op backend.PublishOperation,
 ) error {
-	//
-	// Get PolicyPack metadata from the plugin.
-	//
-
-	fmt.Println("Obtaining policy metadata from policy plugin")
-
-	abs, err := filepath.Abs(op.PlugCtx.Pwd)
-	if err != nil {
-		return err
-	}
-
-	analyzer, err := op.PlugCtx.Host.PolicyAnalyzer(tokens.QName(abs), op.PlugCtx.Pwd, nil /*opts*/)
-	if err != nil {
-		return err
-	}
-
-	analyzerInfo, err := analyzer.GetAnalyzerInfo()
+	analyzerInfo, packTarball, err := backend.PackPolicyPack(ctx, op)
 	if err != nil {
 		return err
 	}
@@ -188,27 +166,6 @@ func (pack *cloudPolicyPack) Publish(
 	pack.ref.name = tokens.QName(analyzerInfo.Name)
 	pack.ref.versionTag = analyzerInfo.Version
 
-	fmt.Println("Compressing policy pack")
-
-	var packTarball []byte
-
-	// TODO[pulumi/pulumi#1334]: move to the language plugins so we don't have to hard code here.
-	runtime := op.PolicyPack.Runtime.Name()
-	if strings.EqualFold(runtime, "nodejs") {
-		packTarball, err = npm.Pack(ctx, npm.AutoPackageManager, op.PlugCtx.Pwd, os.Stderr)
-		if err != nil {
-			return fmt.Errorf("could not publish policies because of error running npm pack: %w", err)
-		}
-	} else {
-		// npm pack puts all the files in a "package" subdirectory inside the .tgz it produces, so we'll do
-		// the same for other runtimes. That way, after unpacking, we can look for the PulumiPolicy.yaml inside the
-		// package directory to determine the runtime of the policy pack.
-		packTarball, err = archive.TGZ(op.PlugCtx.Pwd, "package", true /*useDefaultExcludes*/)
-		if err != nil {
-			return fmt.Errorf("could not publish policies because of error creating the .tgz: %w", err)
-		}
-	}
-
 	//
 	// Publish.
 	//
@@ -258,82 +215,3 @@ func (pack *cloudPolicyPack) Remove(ctx context.Context, op backend.PolicyPackOp
 	}
 	return pack.cl.RemovePolicyPackByVersion(ctx, pack.ref.orgName, string(pack.ref.name), *op.VersionTag)
 }
-
-const packageDir = "package"
-
-func installRequiredPolicy(ctx *plugin.Context, finalDir string, tgz io.ReadCloser) error {
-	// If part of the directory tree is missing, os.MkdirTemp will return an error, so make sure
-	// the path we're going to create the temporary folder in actually exists.
-	if err := os.MkdirAll(filepath.Dir(finalDir), 0o700); err != nil {
-		return fmt.Errorf("creating plugin root: %w", err)
-	}
-
-	tempDir, err := os.MkdirTemp(filepath.Dir(finalDir), filepath.Base(finalDir)+".tmp")
-	if err != nil {
-		return fmt.Errorf("creating plugin directory %s: %w", tempDir, err)
-	}
-
-	// The policy pack files are actually in a directory called `package`.
-	tempPackageDir := filepath.Join(tempDir, packageDir)
-	if err := os.MkdirAll(tempPackageDir, 0o700); err != nil {
-		return fmt.Errorf("creating plugin root: %w", err)
-	}
-
-	// If we early out of this function, try to remove the temp folder we created.
-	defer func() {
-		contract.IgnoreError(os.RemoveAll(tempDir))
-	}()
-
-	// Uncompress the policy pack.
-	err = archive.ExtractTGZ(tgz, tempDir)
-	if err != nil {
-		return fmt.Errorf("failed to extract tarball: %w", err)
-	}
-
-	logging.V(7).Infof("Unpacking policy pack %q %q\n", tempDir, finalDir)
-
-	// If two calls to `plugin install` for the same plugin are racing, the second one will be
-	// unable to rename the directory. That's OK, just ignore the error. The temp directory created
-	// as part of the install will be cleaned up when we exit by the defer above.
-	if err := os.Rename(tempPackageDir, finalDir); err != nil && !os.IsExist(err) {
-		return fmt.Errorf("moving plugin: %w", err)
-	}
-
-	projPath := filepath.Join(finalDir, "PulumiPolicy.yaml")
-	proj, err := workspace.LoadPolicyPack(projPath)
-	if err != nil {
-		return fmt.Errorf("failed to load policy project at %s: %w", finalDir, err)
-	}
-
-	// Workaround for python, some policy packs don't specify a venv but we want to use one
-	if proj.Runtime.Name() == "python" {
-		// If the policy's options provide a virtualenv use it, else default to "venv"
-		if _, has := proj.Runtime.Options()["virtualenv"]; !has {
-			proj.Runtime.SetOption("virtualenv", "venv")
-			err = proj.Save(projPath)
-			if err != nil {
-				return fmt.Errorf("failed to save policy project at %s: %w", finalDir, err)
-			}
-		}
-	}
-
-	info := plugin.NewProgramInfo(finalDir, finalDir, ".", proj.Runtime.Options())
-	language, err := ctx.Host.LanguageRuntime(proj.Runtime.Name(), info)
-	if err != nil {
-		return fmt.Errorf("failed to load language plugin %s: %w", proj.Runtime.Name(), err)
-	}
-
-	err = pkgCmdUtil.InstallDependencies(language, plugin.InstallDependenciesRequest{
-		Info:                    info,
-		UseLanguageVersionTools: false,
-		IsPlugin:                true,
-	})
-	if err != nil {
-		return fmt.Errorf("installing dependencies: %w", err)
-	}
-
-	fmt.Fprintln(os.Stderr, "Finished installing policy pack\r")
-	fmt.Fprintln(os.Stderr)
-
-	return nil
-}
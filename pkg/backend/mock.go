@@ -318,7 +318,7 @@ func (be *MockBackend) Update(ctx context.Context, stack Stack,
 }
 
 func (be *MockBackend) Import(ctx context.Context, stack Stack,
-	op UpdateOperation, imports []deploy.Import,
+	op UpdateOperation, imports []deploy.Import, events chan<- engine.Event,
 ) (sdkDisplay.ResourceChanges, error) {
 	if be.ImportF != nil {
 		return be.ImportF(ctx, stack, op, imports)
@@ -327,7 +327,7 @@ func (be *MockBackend) Import(ctx context.Context, stack Stack,
 }
 
 func (be *MockBackend) Refresh(ctx context.Context, stack Stack,
-	op UpdateOperation,
+	op UpdateOperation, events chan<- engine.Event,
 ) (sdkDisplay.ResourceChanges, error) {
 	if be.RefreshF != nil {
 		return be.RefreshF(ctx, stack, op)
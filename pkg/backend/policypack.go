@@ -1,4 +1,4 @@
-// Copyright 2016-2018, Pulumi Corporation.
+// Copyright 2016-2025, Pulumi Corporation.
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -17,9 +17,20 @@ package backend
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 
+	pkgCmdUtil "github.com/pulumi/pulumi/pkg/v3/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/archive"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+	"github.com/pulumi/pulumi/sdk/v3/nodejs/npm"
 )
 
 // PublishOperation publishes a PolicyPack to the backend.
@@ -61,3 +72,134 @@ type PolicyPack interface {
 	// all Policy Groups before it can be removed.
 	Remove(ctx context.Context, op PolicyPackOperation) error
 }
+
+// PackPolicyPack obtains the metadata of the Policy Pack being published by op from its analyzer plugin, and
+// compresses the Policy Pack's directory into a tarball that can later be installed by [InstallPolicyPack].
+func PackPolicyPack(ctx context.Context, op PublishOperation) (plugin.AnalyzerInfo, []byte, error) {
+	//
+	// Get PolicyPack metadata from the plugin.
+	//
+
+	fmt.Println("Obtaining policy metadata from policy plugin")
+
+	abs, err := filepath.Abs(op.PlugCtx.Pwd)
+	if err != nil {
+		return plugin.AnalyzerInfo{}, nil, err
+	}
+
+	analyzer, err := op.PlugCtx.Host.PolicyAnalyzer(tokens.QName(abs), op.PlugCtx.Pwd, nil /*opts*/)
+	if err != nil {
+		return plugin.AnalyzerInfo{}, nil, err
+	}
+
+	analyzerInfo, err := analyzer.GetAnalyzerInfo()
+	if err != nil {
+		return plugin.AnalyzerInfo{}, nil, err
+	}
+
+	fmt.Println("Compressing policy pack")
+
+	var packTarball []byte
+
+	// TODO[pulumi/pulumi#1334]: move to the language plugins so we don't have to hard code here.
+	runtime := op.PolicyPack.Runtime.Name()
+	if strings.EqualFold(runtime, "nodejs") {
+		packTarball, err = npm.Pack(ctx, npm.AutoPackageManager, op.PlugCtx.Pwd, os.Stderr)
+		if err != nil {
+			return plugin.AnalyzerInfo{}, nil,
+				fmt.Errorf("could not publish policies because of error running npm pack: %w", err)
+		}
+	} else {
+		// npm pack puts all the files in a "package" subdirectory inside the .tgz it produces, so we'll do
+		// the same for other runtimes. That way, after unpacking, we can look for the PulumiPolicy.yaml inside the
+		// package directory to determine the runtime of the policy pack.
+		packTarball, err = archive.TGZ(op.PlugCtx.Pwd, "package", true /*useDefaultExcludes*/)
+		if err != nil {
+			return plugin.AnalyzerInfo{}, nil,
+				fmt.Errorf("could not publish policies because of error creating the .tgz: %w", err)
+		}
+	}
+
+	return analyzerInfo, packTarball, nil
+}
+
+const packageDir = "package"
+
+// InstallPolicyPack unpacks a published Policy Pack's tarball into finalDir and installs the Policy Pack's
+// dependencies, so that it can be loaded as an analyzer.
+func InstallPolicyPack(ctx *plugin.Context, finalDir string, tgz io.ReadCloser) error {
+	// If part of the directory tree is missing, os.MkdirTemp will return an error, so make sure
+	// the path we're going to create the temporary folder in actually exists.
+	if err := os.MkdirAll(filepath.Dir(finalDir), 0o700); err != nil {
+		return fmt.Errorf("creating plugin root: %w", err)
+	}
+
+	tempDir, err := os.MkdirTemp(filepath.Dir(finalDir), filepath.Base(finalDir)+".tmp")
+	if err != nil {
+		return fmt.Errorf("creating plugin directory %s: %w", tempDir, err)
+	}
+
+	// The policy pack files are actually in a directory called `package`.
+	tempPackageDir := filepath.Join(tempDir, packageDir)
+	if err := os.MkdirAll(tempPackageDir, 0o700); err != nil {
+		return fmt.Errorf("creating plugin root: %w", err)
+	}
+
+	// If we early out of this function, try to remove the temp folder we created.
+	defer func() {
+		contract.IgnoreError(os.RemoveAll(tempDir))
+	}()
+
+	// Uncompress the policy pack.
+	err = archive.ExtractTGZ(tgz, tempDir)
+	if err != nil {
+		return fmt.Errorf("failed to extract tarball: %w", err)
+	}
+
+	logging.V(7).Infof("Unpacking policy pack %q %q\n", tempDir, finalDir)
+
+	// If two calls to `plugin install` for the same plugin are racing, the second one will be
+	// unable to rename the directory. That's OK, just ignore the error. The temp directory created
+	// as part of the install will be cleaned up when we exit by the defer above.
+	if err := os.Rename(tempPackageDir, finalDir); err != nil && !os.IsExist(err) {
+		return fmt.Errorf("moving plugin: %w", err)
+	}
+
+	projPath := filepath.Join(finalDir, "PulumiPolicy.yaml")
+	proj, err := workspace.LoadPolicyPack(projPath)
+	if err != nil {
+		return fmt.Errorf("failed to load policy project at %s: %w", finalDir, err)
+	}
+
+	// Workaround for python, some policy packs don't specify a venv but we want to use one
+	if proj.Runtime.Name() == "python" {
+		// If the policy's options provide a virtualenv use it, else default to "venv"
+		if _, has := proj.Runtime.Options()["virtualenv"]; !has {
+			proj.Runtime.SetOption("virtualenv", "venv")
+			err = proj.Save(projPath)
+			if err != nil {
+				return fmt.Errorf("failed to save policy project at %s: %w", finalDir, err)
+			}
+		}
+	}
+
+	info := plugin.NewProgramInfo(finalDir, finalDir, ".", proj.Runtime.Options())
+	language, err := ctx.Host.LanguageRuntime(proj.Runtime.Name(), info)
+	if err != nil {
+		return fmt.Errorf("failed to load language plugin %s: %w", proj.Runtime.Name(), err)
+	}
+
+	err = pkgCmdUtil.InstallDependencies(language, plugin.InstallDependenciesRequest{
+		Info:                    info,
+		UseLanguageVersionTools: false,
+		IsPlugin:                true,
+	})
+	if err != nil {
+		return fmt.Errorf("installing dependencies: %w", err)
+	}
+
+	fmt.Fprintln(os.Stderr, "Finished installing policy pack\r")
+	fmt.Fprintln(os.Stderr)
+
+	return nil
+}
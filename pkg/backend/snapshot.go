@@ -17,6 +17,7 @@ package backend
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"reflect"
 	"strings"
@@ -301,6 +302,12 @@ func (ssm *sameSnapshotMutation) mustWrite(step deploy.Step) bool {
 		return true
 	}
 
+	// Annotations are never sent to the provider, so changing them alone results in a same step.
+	if !maps.Equal(old.Annotations, new.Annotations) || !maps.Equal(old.StateAnnotations, new.StateAnnotations) {
+		logging.V(9).Infof("SnapshotManager: mustWrite() true because of Annotations")
+		return true
+	}
+
 	// Init errors are strictly advisory, so we do not consider them when deciding whether or not to write the
 	// checkpoint. Likewise source positions are purely metadata and do not affect the system correctness, so
 	// for performance we elide those as well. This prevents _every_ resource needing a snapshot write when
@@ -92,14 +92,16 @@ func UpdateStack(
 
 // ImportStack updates the target stack with the current workspace's contents (config and code).
 func ImportStack(ctx context.Context, s Stack, op UpdateOperation,
-	imports []deploy.Import,
+	imports []deploy.Import, events chan<- engine.Event,
 ) (display.ResourceChanges, error) {
-	return s.Backend().Import(ctx, s, op, imports)
+	return s.Backend().Import(ctx, s, op, imports, events)
 }
 
 // RefreshStack refresh's the stack's state from the cloud provider.
-func RefreshStack(ctx context.Context, s Stack, op UpdateOperation) (display.ResourceChanges, error) {
-	return s.Backend().Refresh(ctx, s, op)
+func RefreshStack(ctx context.Context, s Stack, op UpdateOperation,
+	events chan<- engine.Event,
+) (display.ResourceChanges, error) {
+	return s.Backend().Refresh(ctx, s, op, events)
 }
 
 // DestroyStack destroys all of this stack's resources.
@@ -138,6 +140,46 @@ func ImportStackDeployment(ctx context.Context, s Stack, deployment *apitype.Unt
 	return s.Backend().ImportDeployment(ctx, s, deployment)
 }
 
+// StackStore reads and writes the deployment of a single stack through its backend. It satisfies the edit.Store
+// interface, so that state edits can be applied to a stack with edit.Open.
+type StackStore struct {
+	Stack Stack
+}
+
+// NewStackStore returns a StackStore for the given stack.
+func NewStackStore(s Stack) *StackStore {
+	return &StackStore{Stack: s}
+}
+
+// ExportDeployment exports the stack's deployment.
+func (s *StackStore) ExportDeployment(ctx context.Context) (*apitype.UntypedDeployment, error) {
+	return ExportStackDeployment(ctx, s.Stack)
+}
+
+// ImportDeployment imports the given deployment into the stack.
+func (s *StackStore) ImportDeployment(ctx context.Context, deployment *apitype.UntypedDeployment) error {
+	return ImportStackDeployment(ctx, s.Stack, deployment)
+}
+
+// Lock takes the stack's lock if its backend supports locking stacks, returning a function that releases it. For
+// backends that don't, such as the Pulumi Cloud, which rejects imports while an update is in progress, the returned
+// function does nothing.
+func (s *StackStore) Lock(ctx context.Context) (func(), error) {
+	locker, ok := s.Stack.Backend().(StackLocker)
+	if !ok {
+		return func() {}, nil
+	}
+	return locker.LockStack(ctx, s.Stack.Ref())
+}
+
+// StackLocker is implemented by backends whose stacks can be locked for the duration of a read-modify-write of their
+// state.
+type StackLocker interface {
+	// LockStack takes the given stack's lock, returning a function that releases it. While the lock is held, the
+	// backend's own operations on the stack reuse it rather than taking and releasing it themselves.
+	LockStack(ctx context.Context, stackRef StackReference) (func(), error)
+}
+
 // UpdateStackTags updates the stacks's tags, replacing all existing tags.
 func UpdateStackTags(ctx context.Context, s Stack, tags map[apitype.StackTagName]string) error {
 	return s.Backend().UpdateStackTags(ctx, s, tags)
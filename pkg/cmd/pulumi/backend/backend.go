@@ -63,6 +63,7 @@ func NonInteractiveCurrentBackend(
 	}
 
 	// Only set current if we don't currently have a cloud URL set.
+	registerBackendOpener(ws, lm)
 	return lm.Current(ctx, ws, cmdutil.Diag(), url, project, url == "")
 }
 
@@ -80,5 +81,22 @@ func CurrentBackend(
 	}
 
 	// Only set current if we don't currently have a cloud URL set.
+	registerBackendOpener(ws, lm)
 	return lm.Login(ctx, ws, cmdutil.Diag(), url, project, url == "", opts.Color)
 }
+
+// registerBackendOpener allows stack references to read stacks from other backends that the user is logged in to, by
+// opening them with the given login manager.
+func registerBackendOpener(ws pkgWorkspace.Context, lm LoginManager) {
+	backend.SetBackendOpener(func(ctx context.Context, url string) (backend.Backend, error) {
+		// Never prompt for credentials in the middle of an operation; the user must already be logged in.
+		b, err := lm.Current(ctx, ws, cmdutil.Diag(), url, nil, false)
+		if err != nil {
+			return nil, err
+		}
+		if b == nil {
+			return nil, fmt.Errorf("not logged in to %s; run `pulumi login %s` to log in", url, url)
+		}
+		return b, nil
+	})
+}
@@ -23,7 +23,6 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/blang/semver"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/hcl/v2"
 	hclsyntax "github.com/pulumi/pulumi/pkg/v3/codegen/hcl2/syntax"
@@ -38,8 +37,8 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/codegen/convert"
 	"github.com/pulumi/pulumi/pkg/v3/codegen/pcl"
 	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/terraform"
 	"github.com/pulumi/pulumi/pkg/v3/engine"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/encoding"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/env"
@@ -74,7 +73,7 @@ func NewConvertCmd(ws pkgWorkspace.Context) *cobra.Command {
 			"\n" +
 			"Valid source languages: yaml, terraform, bicep, arm, kubernetes\n" +
 			"\n" +
-			"Valid target languages: typescript, python, csharp, go, java, yaml" +
+			"Valid target languages: typescript, python, csharp, go, java, yaml, terraform" +
 			"\n" +
 			"Example command usage:" +
 			"\n" +
@@ -169,6 +168,64 @@ func pclGenerateProject(
 	return diagnostics, err
 }
 
+// terraformGenerateProject writes out a pcl.Program as Terraform HCL, using the given mapper to map Pulumi tokens and
+// property names back to those of the bridged Terraform providers that the program uses.
+func terraformGenerateProject(
+	ctx context.Context, sourceDirectory, targetDirectory string, loader schema.ReferenceLoader, strict bool,
+	mapper convert.Mapper,
+) (hcl.Diagnostics, error) {
+	program, diagnostics, err := safePclBindDirectory(sourceDirectory, loader, strict)
+	if err != nil || diagnostics.HasErrors() {
+		return diagnostics, err
+	}
+
+	files, ds, err := terraform.GenerateProgram(ctx, program, mapper)
+	diagnostics = append(diagnostics, ds...)
+	if err != nil || diagnostics.HasErrors() {
+		return diagnostics, err
+	}
+
+	for filename, contents := range files {
+		outPath := filepath.Join(targetDirectory, filename)
+		if err := os.MkdirAll(filepath.Dir(outPath), 0o755); err != nil {
+			return diagnostics, fmt.Errorf("create output directory: %w", err)
+		}
+		if err := os.WriteFile(outPath, contents, 0o600); err != nil {
+			return diagnostics, fmt.Errorf("write %s: %w", outPath, err)
+		}
+	}
+
+	if err := copyAssets(sourceDirectory, targetDirectory); err != nil {
+		return diagnostics, fmt.Errorf("copying files from source directory: %w", err)
+	}
+	return diagnostics, nil
+}
+
+// copyAssets copies all non-PCL files from the source directory to the target directory such that any assets are
+// copied over, excluding the Pulumi.yaml project file.
+func copyAssets(sourceDirectory, targetDirectory string) error {
+	return aferoUtil.CopyDir(afero.NewOsFs(), sourceDirectory, targetDirectory,
+		func(file os.FileInfo) bool {
+			if file.IsDir() {
+				sourceAbsPath, err := filepath.Abs(filepath.Join(sourceDirectory, file.Name()))
+				if err != nil {
+					return false
+				}
+
+				targetAbsPath, err := filepath.Abs(targetDirectory)
+				if err != nil {
+					return false
+				}
+				// if the target directory is a subdirectory of the source directory,
+				// skip copying it over
+				return sourceAbsPath != targetAbsPath
+			}
+
+			return file.Name() != "Pulumi.yaml" &&
+				path.Ext(file.Name()) != ".pp"
+		})
+}
+
 type projectGeneratorFunction func(
 	string, string, *workspace.Project, schema.ReferenceLoader, bool,
 ) (hcl.Diagnostics, error)
@@ -221,6 +278,12 @@ func runConvert(
 		language = "dotnet"
 	case "typescript":
 		language = "nodejs"
+	case "tf", "hcl":
+		language = "terraform"
+	}
+
+	log := func(sev diag.Severity, msg string) {
+		pCtx.Diag.Logf(sev, diag.RawMessage("", msg))
 	}
 
 	var projectGenerator projectGeneratorFunction
@@ -229,6 +292,23 @@ func runConvert(
 		// No plugin for PCL to install dependencies with
 		generateOnly = true
 		projectGenerator = pclGenerateProject
+	case "terraform":
+		// Terraform has no dependencies to install, and its mappings are read from the same providers that map
+		// Terraform programs into Pulumi when converting from Terraform.
+		generateOnly = true
+		terraformMapper, err := NewPluginMapper(ctx, pCtx, "terraform" /*conversionKey*/, mappings)
+		if err != nil {
+			return err
+		}
+		projectGenerator = func(
+			sourceDirectory, targetDirectory string,
+			_ *workspace.Project,
+			loader schema.ReferenceLoader,
+			strict bool,
+		) (hcl.Diagnostics, error) {
+			return terraformGenerateProject(
+				ctx, sourceDirectory, targetDirectory, loader, strict, terraformMapper)
+		}
 	default:
 		projectGenerator = func(
 			sourceDirectory, targetDirectory string,
@@ -266,28 +346,7 @@ func runConvert(
 				return nil, err
 			}
 
-			// copy all non-PCL files from the source directory to the target directory
-			// such that any assets are copied over, excluding the Pulumi.yaml project file
-			err = aferoUtil.CopyDir(afero.NewOsFs(), sourceDirectory, targetDirectory,
-				func(file os.FileInfo) bool {
-					if file.IsDir() {
-						sourceAbsPath, err := filepath.Abs(filepath.Join(sourceDirectory, file.Name()))
-						if err != nil {
-							return false
-						}
-
-						targetAbsPath, err := filepath.Abs(targetDirectory)
-						if err != nil {
-							return false
-						}
-						// if the target directory is a subdirectory of the source directory,
-						// skip copying it over
-						return sourceAbsPath != targetAbsPath
-					}
-
-					return file.Name() != "Pulumi.yaml" &&
-						path.Ext(file.Name()) != ".pp"
-				})
+			err = copyAssets(sourceDirectory, targetDirectory)
 			if err != nil {
 				return nil, fmt.Errorf("copying files from source directory: %w", err)
 			}
@@ -321,45 +380,13 @@ func runConvert(
 		return fmt.Errorf("create output directory: %w", err)
 	}
 
-	log := func(sev diag.Severity, msg string) {
-		pCtx.Diag.Logf(sev, diag.RawMessage("", msg))
-	}
-
-	installPlugin := func(pluginName string) *semver.Version {
-		// If auto plugin installs are disabled just return nil, the mapper will still carry on
-		if env.DisableAutomaticPluginAcquisition.Value() {
-			return nil
-		}
-
-		pluginSpec, err := workspace.NewPluginSpec(ctx, pluginName, apitype.ResourcePlugin, nil, "", nil)
-		if err != nil {
-			pCtx.Diag.Errorf(diag.Message("", "failed to create plugin spec for %q: %v"), pluginName, err)
-			return nil
-		}
-
-		version, err := pkgWorkspace.InstallPlugin(pCtx.Base(), pluginSpec, log)
-		if err != nil {
-			pCtx.Diag.Warningf(diag.Message("", "failed to install provider %q: %v"), pluginName, err)
-			return nil
-		}
-		return version
-	}
-
 	loader := schema.NewPluginLoader(pCtx.Host)
 
-	baseMapper, err := convert.NewBasePluginMapper(
-		convert.DefaultWorkspace(),
-		from, /*conversionKey*/
-		convert.ProviderFactoryFromHost(ctx, pCtx.Host),
-		installPlugin,
-		mappings,
-	)
+	mapper, err := NewPluginMapper(ctx, pCtx, from /*conversionKey*/, mappings)
 	if err != nil {
-		return fmt.Errorf("create provider mapper: %w", err)
+		return err
 	}
 
-	mapper := convert.NewCachingMapper(baseMapper)
-
 	pclDirectory, err := os.MkdirTemp("", "pulumi-convert")
 	if err != nil {
 		return fmt.Errorf("create temporary directory: %w", err)
@@ -90,6 +90,40 @@ output result {
 }
 
 // Tests that project names default to the directory of the source project.
+func TestTerraformConvert(t *testing.T) {
+	t.Parallel()
+
+	// Check that we can run convert from PCL to Terraform
+	tmp := t.TempDir()
+
+	cwd, err := filepath.Abs("pcl_testdata")
+	require.NoError(t, err)
+
+	result := runConvert(
+		context.Background(), pkgWorkspace.Instance, env.Global(), []string{}, cwd,
+		[]string{}, "pcl", "terraform", tmp, true, true, "")
+	assert.Nil(t, result)
+
+	mainBytes, err := os.ReadFile(filepath.Join(tmp, "main.tf"))
+	require.NoError(t, err)
+	outputsBytes, err := os.ReadFile(filepath.Join(tmp, "outputs.tf"))
+	require.NoError(t, err)
+	// On Windows, we need to replace \r\n with \n to match the expected strings below
+	mainCode, outputsCode := string(mainBytes), string(outputsBytes)
+	if runtime.GOOS == "windows" {
+		mainCode = strings.ReplaceAll(mainCode, "\r\n", "\n")
+		outputsCode = strings.ReplaceAll(outputsCode, "\r\n", "\n")
+	}
+	assert.Equal(t, `locals {
+  key = file("key.pub")
+}
+`, mainCode)
+	assert.Equal(t, `output "result" {
+  value = local.key
+}
+`, outputsCode)
+}
+
 func TestProjectNameDefaults(t *testing.T) {
 	t.Parallel()
 
@@ -15,9 +15,13 @@
 package convert
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
+	"github.com/blang/semver"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/convert"
 	"github.com/pulumi/pulumi/pkg/v3/util"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
@@ -66,3 +70,49 @@ func LoadConverterPlugin(
 	}
 	return converter, nil
 }
+
+// NewPluginMapper returns a caching mapper that reads mappings for the given conversion key from the given mapping
+// files and from provider plugins. Providers that aren't installed are installed when they are first asked for a
+// mapping, unless automatic plugin installs are turned off.
+func NewPluginMapper(
+	ctx context.Context,
+	pCtx *plugin.Context,
+	conversionKey string,
+	mappings []string,
+) (convert.Mapper, error) {
+	log := func(sev diag.Severity, msg string) {
+		pCtx.Diag.Logf(sev, diag.RawMessage("", msg))
+	}
+
+	installPlugin := func(pluginName string) *semver.Version {
+		// If auto plugin installs are disabled just return nil, the mapper will still carry on
+		if env.DisableAutomaticPluginAcquisition.Value() {
+			return nil
+		}
+
+		pluginSpec, err := workspace.NewPluginSpec(ctx, pluginName, apitype.ResourcePlugin, nil, "", nil)
+		if err != nil {
+			pCtx.Diag.Errorf(diag.Message("", "failed to create plugin spec for %q: %v"), pluginName, err)
+			return nil
+		}
+
+		version, err := pkgWorkspace.InstallPlugin(pCtx.Base(), pluginSpec, log)
+		if err != nil {
+			pCtx.Diag.Warningf(diag.Message("", "failed to install provider %q: %v"), pluginName, err)
+			return nil
+		}
+		return version
+	}
+
+	baseMapper, err := convert.NewBasePluginMapper(
+		convert.DefaultWorkspace(),
+		conversionKey,
+		convert.ProviderFactoryFromHost(ctx, pCtx.Host),
+		installPlugin,
+		mappings,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create provider mapper: %w", err)
+	}
+	return convert.NewCachingMapper(baseMapper), nil
+}
@@ -0,0 +1,300 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/ui"
+	eventlog "github.com/pulumi/pulumi/sdk/v3/go/auto/events"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// NewEventsCmd creates the `pulumi events` command, which groups commands that operate on event logs.
+func NewEventsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "Inspect event logs from prior operations",
+		Long: "Inspect event logs from prior operations.\n" +
+			"\n" +
+			"Event logs are written by `pulumi up --event-log [file]` and friends, as well as by the\n" +
+			"Automation API.",
+		Args: cmdutil.NoArgs,
+	}
+
+	cmd.AddCommand(newAnalyzeCmd())
+
+	return cmd
+}
+
+// analysis is the result of analyzing the timing of an operation's event log.
+type analysis struct {
+	Start            int           `json:"start"`
+	End              int           `json:"end"`
+	Duration         int           `json:"duration"`
+	Steps            int           `json:"steps"`
+	Parallel         int           `json:"parallel"`
+	PeakConcurrency  int           `json:"peakConcurrency"`
+	BusySeconds      int           `json:"busySeconds"`
+	IdleSeconds      int           `json:"idleSeconds"`
+	Utilization      float64       `json:"utilization"`
+	CriticalPath     []*stepTiming `json:"criticalPath"`
+	SlowestResources []*stepTiming `json:"slowestResources"`
+	SlowestTypes     []aggregate   `json:"slowestTypes"`
+	SlowestProviders []aggregate   `json:"slowestProviders"`
+}
+
+// analyze computes timing statistics for the given timeline. If parallel is zero, the peak observed concurrency is
+// used as the number of available workers. At most top entries are reported for each of the slowest lists.
+func analyze(tl *timeline, parallel, top int) *analysis {
+	a := &analysis{
+		Start:        tl.Start,
+		End:          tl.End,
+		Duration:     tl.End - tl.Start,
+		Steps:        len(tl.Steps),
+		CriticalPath: tl.criticalPath(),
+	}
+
+	active := tl.concurrency()
+	for _, n := range active {
+		a.PeakConcurrency = max(a.PeakConcurrency, n)
+		a.BusySeconds += n
+	}
+	a.Parallel = parallel
+	if a.Parallel <= 0 {
+		a.Parallel = a.PeakConcurrency
+	}
+	for _, n := range active {
+		a.IdleSeconds += max(a.Parallel-n, 0)
+	}
+	if capacity := a.Parallel * len(active); capacity > 0 {
+		a.Utilization = float64(a.BusySeconds) / float64(capacity)
+	}
+
+	slowest := make([]*stepTiming, len(tl.Steps))
+	copy(slowest, tl.Steps)
+	sort.SliceStable(slowest, func(i, j int) bool { return slowest[i].Duration() > slowest[j].Duration() })
+	a.SlowestResources = truncate(slowest, top)
+	a.SlowestTypes = truncate(tl.aggregateBy(typeName), top)
+	a.SlowestProviders = truncate(tl.aggregateBy(func(s *stepTiming) string { return providerName(s.Provider) }), top)
+
+	return a
+}
+
+func truncate[T any](s []T, n int) []T {
+	if n > 0 && len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+func newAnalyzeCmd() *cobra.Command {
+	var jsonOut bool
+	var parallel int
+	var top int
+	var traceFile string
+
+	cmd := &cobra.Command{
+		Use:   "analyze <event-log>",
+		Short: "Analyze the timing of a prior operation",
+		Long: "Analyze the timing of a prior operation.\n" +
+			"\n" +
+			"This command reconstructs when each resource step started and finished from an event log\n" +
+			"written by a prior update, refresh, destroy or import, including any rotated segments of\n" +
+			"that log. It reports the critical path through the dependency graph (the chain of steps\n" +
+			"that determined how long the operation took), the slowest resources, resource types and\n" +
+			"providers, and how much of the available parallelism went unused.\n" +
+			"\n" +
+			"Events are timestamped with a resolution of one second, so very short steps are reported\n" +
+			"as taking no time.\n" +
+			"\n" +
+			"Use `--trace-file` to export the steps in the Chrome trace event format, which can be\n" +
+			"viewed as a flame graph in tools such as https://ui.perfetto.dev.",
+		Args: cmdutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var events []apitype.EngineEvent
+			err := eventlog.ReadLog(args[0], func(e eventlog.EngineEvent) error {
+				if e.Error != nil {
+					return fmt.Errorf("decoding event: %w", e.Error)
+				}
+				events = append(events, e.EngineEvent)
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("error reading events: %w", err)
+			}
+
+			tl := buildTimeline(events)
+
+			if traceFile != "" {
+				if err := writeTraceFile(traceFile, tl); err != nil {
+					return fmt.Errorf("writing trace file: %w", err)
+				}
+			}
+
+			result := analyze(tl, parallel, top)
+			if jsonOut {
+				return ui.FprintJSON(cmd.OutOrStdout(), result)
+			}
+			return renderAnalysis(cmd.OutOrStdout(), result)
+		},
+	}
+
+	cmd.Flags().BoolVarP(
+		&jsonOut, "json", "j", false,
+		"Emit the analysis as JSON")
+	cmd.Flags().IntVar(
+		&parallel, "parallel", 0,
+		"The --parallel value the operation ran with, used to compute idle worker time; "+
+			"defaults to the peak concurrency observed in the log")
+	cmd.Flags().IntVar(
+		&top, "top", 10,
+		"The number of entries to show in each list of slowest resources, types and providers")
+	cmd.Flags().StringVar(
+		&traceFile, "trace-file", "",
+		"Write the steps of the operation to this file in the Chrome trace event format")
+
+	return cmd
+}
+
+func formatSeconds(seconds int) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
+
+func renderAnalysis(out io.Writer, a *analysis) error {
+	fmt.Fprintf(out, "Duration: %s\n", formatSeconds(a.Duration))
+	fmt.Fprintf(out, "Steps: %d\n", a.Steps)
+	fmt.Fprintf(out, "Workers: %d (peak concurrency %d)\n", a.Parallel, a.PeakConcurrency)
+	fmt.Fprintf(out, "Worker utilization: %.1f%% (%s busy, %s idle)\n",
+		a.Utilization*100, formatSeconds(a.BusySeconds), formatSeconds(a.IdleSeconds))
+
+	stepRows := func(steps []*stepTiming, offsets bool) []cmdutil.TableRow {
+		rows := make([]cmdutil.TableRow, 0, len(steps))
+		for _, s := range steps {
+			columns := []string{formatSeconds(s.Duration())}
+			if offsets {
+				columns = append(columns, "+"+formatSeconds(s.Start-a.Start))
+			}
+			columns = append(columns, string(s.Op), typeName(s), resource.URN(s.URN).Name())
+			rows = append(rows, cmdutil.TableRow{Columns: columns})
+		}
+		return rows
+	}
+	aggregateRows := func(groups []aggregate) []cmdutil.TableRow {
+		rows := make([]cmdutil.TableRow, 0, len(groups))
+		for _, g := range groups {
+			rows = append(rows, cmdutil.TableRow{Columns: []string{
+				g.Name, strconv.Itoa(g.Count), formatSeconds(g.Total), formatSeconds(g.Max),
+			}})
+		}
+		return rows
+	}
+
+	sections := []struct {
+		title string
+		table cmdutil.Table
+	}{
+		{"Critical path", cmdutil.Table{
+			Headers: []string{"DURATION", "STARTED", "OP", "TYPE", "NAME"},
+			Rows:    stepRows(a.CriticalPath, true),
+		}},
+		{"Slowest resources", cmdutil.Table{
+			Headers: []string{"DURATION", "OP", "TYPE", "NAME"},
+			Rows:    stepRows(a.SlowestResources, false),
+		}},
+		{"Slowest types", cmdutil.Table{
+			Headers: []string{"TYPE", "COUNT", "TOTAL", "MAX"},
+			Rows:    aggregateRows(a.SlowestTypes),
+		}},
+		{"Slowest providers", cmdutil.Table{
+			Headers: []string{"PROVIDER", "COUNT", "TOTAL", "MAX"},
+			Rows:    aggregateRows(a.SlowestProviders),
+		}},
+	}
+	for _, section := range sections {
+		fmt.Fprintf(out, "\n%s:\n", section.title)
+		if len(section.table.Rows) == 0 {
+			fmt.Fprintln(out, "    (none)")
+			continue
+		}
+		section.table.Prefix = "    "
+		ui.FprintTable(out, section.table, nil)
+	}
+	return nil
+}
+
+// traceEvent is a single event in the Chrome trace event format. See
+// https://docs.google.com/document/d/1CvAClvFfyA5R-PhYUmn5OOQtYMH4h6I0nSsKchNAySU for details.
+type traceEvent struct {
+	Name      string         `json:"name"`
+	Category  string         `json:"cat,omitempty"`
+	Phase     string         `json:"ph"`
+	Timestamp int64          `json:"ts"`
+	Duration  int64          `json:"dur,omitempty"`
+	PID       int            `json:"pid"`
+	TID       int            `json:"tid"`
+	Args      map[string]any `json:"args,omitempty"`
+}
+
+// writeTraceFile writes the steps in the given timeline to path as a Chrome trace. Each step is placed on a thread
+// approximating the worker that ran it, so that the trace renders as a flame graph of the operation.
+func writeTraceFile(path string, tl *timeline) error {
+	lanes := tl.assignLanes()
+
+	events := make([]traceEvent, 0, len(tl.Steps)+lanes)
+	for i := 0; i < lanes; i++ {
+		events = append(events, traceEvent{
+			Name:  "thread_name",
+			Phase: "M",
+			PID:   1,
+			TID:   i,
+			Args:  map[string]any{"name": fmt.Sprintf("worker %d", i)},
+		})
+	}
+	for _, s := range tl.Steps {
+		events = append(events, traceEvent{
+			Name:      resource.URN(s.URN).Name(),
+			Category:  string(s.Op),
+			Phase:     "X",
+			Timestamp: int64(s.Start-tl.Start) * int64(time.Second/time.Microsecond),
+			Duration:  int64(s.Duration()) * int64(time.Second/time.Microsecond),
+			PID:       1,
+			TID:       s.lane,
+			Args: map[string]any{
+				"urn":      s.URN,
+				"type":     typeName(s),
+				"provider": s.Provider,
+				"failed":   s.Failed,
+			},
+		})
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer contract.IgnoreClose(f)
+	return ui.FprintJSON(f, map[string]any{"traceEvents": events})
+}
@@ -0,0 +1,166 @@
+// Copyright 2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+)
+
+const (
+	awsProviderURN = "urn:pulumi:dev::proj::pulumi:providers:aws::default"
+	vpcURN         = "urn:pulumi:dev::proj::aws:ec2/vpc:Vpc::vpc"
+	subnetURN      = "urn:pulumi:dev::proj::aws:ec2/subnet:Subnet::subnet"
+	bucketURN      = "urn:pulumi:dev::proj::aws:s3/bucket:Bucket::bucket"
+	stackURN       = "urn:pulumi:dev::proj::pulumi:pulumi:Stack::proj-dev"
+)
+
+func stepEvents(urn, typ string, start, end int, deps ...string) []apitype.EngineEvent {
+	md := apitype.StepEventMetadata{
+		Op:       apitype.OpCreate,
+		URN:      urn,
+		Type:     typ,
+		Provider: awsProviderURN + "::04da6b54-80e4-46f7-96ec-b56ff0331ba9",
+		New: &apitype.StepEventStateMetadata{
+			URN:          urn,
+			Type:         typ,
+			Custom:       true,
+			Dependencies: deps,
+		},
+	}
+	return []apitype.EngineEvent{
+		{Timestamp: start, ResourcePreEvent: &apitype.ResourcePreEvent{Metadata: md}},
+		{Timestamp: end, ResOutputsEvent: &apitype.ResOutputsEvent{Metadata: md}},
+	}
+}
+
+func testEvents() []apitype.EngineEvent {
+	var events []apitype.EngineEvent
+	stack := apitype.StepEventMetadata{
+		Op:  apitype.OpCreate,
+		URN: stackURN,
+		New: &apitype.StepEventStateMetadata{URN: stackURN},
+	}
+	events = append(events, apitype.EngineEvent{Timestamp: 100, ResourcePreEvent: &apitype.ResourcePreEvent{
+		Metadata: stack,
+	}})
+	events = append(events, stepEvents(vpcURN, "aws:ec2/vpc:Vpc", 100, 110)...)
+	events = append(events, stepEvents(bucketURN, "aws:s3/bucket:Bucket", 101, 105)...)
+	events = append(events, stepEvents(subnetURN, "aws:ec2/subnet:Subnet", 110, 140, vpcURN)...)
+	events = append(events, apitype.EngineEvent{Timestamp: 140, ResOutputsEvent: &apitype.ResOutputsEvent{
+		Metadata: stack,
+	}})
+	return events
+}
+
+func TestBuildTimeline(t *testing.T) {
+	t.Parallel()
+
+	tl := buildTimeline(testEvents())
+	assert.Equal(t, 100, tl.Start)
+	assert.Equal(t, 140, tl.End)
+
+	// The stack is a component, so it isn't counted as a step.
+	require.Len(t, tl.Steps, 3)
+	assert.Equal(t, vpcURN, tl.Steps[0].URN)
+	assert.Equal(t, 10, tl.Steps[0].Duration())
+	assert.Equal(t, bucketURN, tl.Steps[1].URN)
+	assert.Equal(t, subnetURN, tl.Steps[2].URN)
+	assert.Equal(t, 30, tl.Steps[2].Duration())
+}
+
+func TestBuildTimelineIncompleteStep(t *testing.T) {
+	t.Parallel()
+
+	events := stepEvents(vpcURN, "aws:ec2/vpc:Vpc", 100, 110)
+	events = append(events, stepEvents(subnetURN, "aws:ec2/subnet:Subnet", 110, 0)[0])
+	events = append(events, apitype.EngineEvent{Timestamp: 120, CancelEvent: &apitype.CancelEvent{}})
+
+	tl := buildTimeline(events)
+	require.Len(t, tl.Steps, 2)
+	assert.True(t, tl.Steps[1].Incomplete)
+	assert.Equal(t, 120, tl.Steps[1].End)
+}
+
+func TestAnalyze(t *testing.T) {
+	t.Parallel()
+
+	a := analyze(buildTimeline(testEvents()), 4, 2)
+
+	assert.Equal(t, 40, a.Duration)
+	assert.Equal(t, 3, a.Steps)
+	assert.Equal(t, 2, a.PeakConcurrency)
+	assert.Equal(t, 4, a.Parallel)
+	assert.Equal(t, 44, a.BusySeconds)
+	assert.Equal(t, 4*40-44, a.IdleSeconds)
+
+	require.Len(t, a.CriticalPath, 2)
+	assert.Equal(t, vpcURN, a.CriticalPath[0].URN)
+	assert.Equal(t, subnetURN, a.CriticalPath[1].URN)
+
+	require.Len(t, a.SlowestResources, 2)
+	assert.Equal(t, subnetURN, a.SlowestResources[0].URN)
+	assert.Equal(t, vpcURN, a.SlowestResources[1].URN)
+
+	require.Len(t, a.SlowestTypes, 2)
+	assert.Equal(t, "aws:ec2/subnet:Subnet", a.SlowestTypes[0].Name)
+
+	require.Len(t, a.SlowestProviders, 1)
+	assert.Equal(t, aggregate{Name: "aws:default", Count: 3, Total: 44, Max: 30}, a.SlowestProviders[0])
+
+	var out bytes.Buffer
+	require.NoError(t, renderAnalysis(&out, a))
+	assert.Contains(t, out.String(), "Critical path:")
+	assert.Contains(t, out.String(), "Worker utilization: 27.5%")
+}
+
+func TestAnalyzeDefaultsParallelToPeakConcurrency(t *testing.T) {
+	t.Parallel()
+
+	a := analyze(buildTimeline(testEvents()), 0, 10)
+	assert.Equal(t, 2, a.Parallel)
+	assert.Equal(t, 2*40-44, a.IdleSeconds)
+}
+
+func TestWriteTraceFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "trace.json")
+	require.NoError(t, writeTraceFile(path, buildTimeline(testEvents())))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var trace struct {
+		TraceEvents []traceEvent `json:"traceEvents"`
+	}
+	require.NoError(t, json.Unmarshal(data, &trace))
+
+	// Two worker lanes, plus one event for each step.
+	require.Len(t, trace.TraceEvents, 5)
+	subnet := trace.TraceEvents[4]
+	assert.Equal(t, "subnet", subnet.Name)
+	assert.Equal(t, "X", subnet.Phase)
+	assert.Equal(t, int64(10_000_000), subnet.Timestamp)
+	assert.Equal(t, int64(30_000_000), subnet.Duration)
+	assert.Equal(t, 0, subnet.TID)
+}
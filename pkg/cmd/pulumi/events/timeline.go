@@ -0,0 +1,294 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+// stepTiming records when a single resource step started and finished, as reconstructed from the
+// `resourcePreEvent` and `resOutputsEvent`/`resOpFailedEvent` pair for that step. Times are Unix timestamps in
+// seconds, which is the resolution at which events are stamped.
+type stepTiming struct {
+	URN      string         `json:"urn"`
+	Type     string         `json:"type"`
+	Op       apitype.OpType `json:"op"`
+	Provider string         `json:"provider,omitempty"`
+	Start    int            `json:"start"`
+	End      int            `json:"end"`
+	Failed   bool           `json:"failed,omitempty"`
+	// Incomplete is true if the log ended before the step finished.
+	Incomplete bool `json:"incomplete,omitempty"`
+
+	dependencies []string
+	lane         int
+}
+
+// Duration returns the number of seconds the step took.
+func (s *stepTiming) Duration() int {
+	return s.End - s.Start
+}
+
+// isDelete returns true if the step deletes a resource, in which case it waits on its dependents rather than its
+// dependencies.
+func (s *stepTiming) isDelete() bool {
+	switch s.Op {
+	case apitype.OpDelete, apitype.OpDeleteReplaced, apitype.OpReadDiscard, apitype.OpDiscardReplaced:
+		return true
+	default:
+		return false
+	}
+}
+
+// timeline is the set of steps reconstructed from an event log.
+type timeline struct {
+	Steps []*stepTiming
+	Start int
+	End   int
+}
+
+// buildTimeline pairs up the resource events in the given log into steps. Only steps for custom resources are
+// considered, since component resources span the lifetime of their children and do no work of their own.
+func buildTimeline(events []apitype.EngineEvent) *timeline {
+	tl := &timeline{}
+	pending := map[string]*stepTiming{}
+	key := func(md apitype.StepEventMetadata) string {
+		return md.URN + "\x00" + string(md.Op)
+	}
+
+	for i, e := range events {
+		if i == 0 || e.Timestamp < tl.Start {
+			tl.Start = e.Timestamp
+		}
+		if e.Timestamp > tl.End {
+			tl.End = e.Timestamp
+		}
+
+		switch {
+		case e.ResourcePreEvent != nil:
+			md := e.ResourcePreEvent.Metadata
+			state := md.New
+			if state == nil {
+				state = md.Old
+			}
+			if state == nil || !state.Custom {
+				continue
+			}
+			pending[key(md)] = &stepTiming{
+				URN:          md.URN,
+				Type:         md.Type,
+				Op:           md.Op,
+				Provider:     md.Provider,
+				Start:        e.Timestamp,
+				dependencies: state.Dependencies,
+			}
+		case e.ResOutputsEvent != nil, e.ResOpFailedEvent != nil:
+			var md apitype.StepEventMetadata
+			if e.ResOutputsEvent != nil {
+				md = e.ResOutputsEvent.Metadata
+			} else {
+				md = e.ResOpFailedEvent.Metadata
+			}
+			step, ok := pending[key(md)]
+			if !ok {
+				continue
+			}
+			delete(pending, key(md))
+			step.End = e.Timestamp
+			step.Failed = e.ResOpFailedEvent != nil
+			tl.Steps = append(tl.Steps, step)
+		}
+	}
+
+	for _, step := range pending {
+		step.End = tl.End
+		step.Incomplete = true
+		tl.Steps = append(tl.Steps, step)
+	}
+
+	sort.SliceStable(tl.Steps, func(i, j int) bool {
+		if tl.Steps[i].Start != tl.Steps[j].Start {
+			return tl.Steps[i].Start < tl.Steps[j].Start
+		}
+		return tl.Steps[i].URN < tl.Steps[j].URN
+	})
+	return tl
+}
+
+// providerURN returns the URN portion of a provider reference, which has the form `<urn>::<id>`.
+func providerURN(ref string) string {
+	if i := strings.LastIndex(ref, "::"); i != -1 {
+		return ref[:i]
+	}
+	return ref
+}
+
+// providerName returns a short, human-readable name for the provider referenced by the given step, e.g.
+// `aws:default_6_0_0`.
+func providerName(ref string) string {
+	if ref == "" {
+		return "(none)"
+	}
+	urn := resource.URN(providerURN(ref))
+	if !urn.IsValid() {
+		return ref
+	}
+	return strings.TrimPrefix(string(urn.Type()), "pulumi:providers:") + ":" + urn.Name()
+}
+
+// criticalPath returns the chain of steps that determined the end time of the operation. Starting from the step that
+// finished last, it repeatedly walks back to the prerequisite step that finished last before the current step started:
+// its latest dependency or provider for creates and updates, or its latest dependent for deletes.
+func (tl *timeline) criticalPath() []*stepTiming {
+	if len(tl.Steps) == 0 {
+		return nil
+	}
+
+	byURN := map[string][]*stepTiming{}
+	dependents := map[string][]string{}
+	for _, s := range tl.Steps {
+		byURN[s.URN] = append(byURN[s.URN], s)
+		for _, dep := range s.dependencies {
+			dependents[dep] = append(dependents[dep], s.URN)
+		}
+	}
+
+	predecessor := func(s *stepTiming) *stepTiming {
+		var prereqs []string
+		if s.isDelete() {
+			prereqs = dependents[s.URN]
+		} else {
+			prereqs = append(prereqs, s.dependencies...)
+			if s.Provider != "" {
+				prereqs = append(prereqs, providerURN(s.Provider))
+			}
+		}
+
+		var best *stepTiming
+		for _, urn := range prereqs {
+			for _, p := range byURN[urn] {
+				if p == s || p.End > s.Start || p.isDelete() != s.isDelete() {
+					continue
+				}
+				if best == nil || p.End > best.End {
+					best = p
+				}
+			}
+		}
+		return best
+	}
+
+	last := tl.Steps[0]
+	for _, s := range tl.Steps[1:] {
+		if s.End > last.End || s.End == last.End && s.Duration() > last.Duration() {
+			last = s
+		}
+	}
+
+	var path []*stepTiming
+	seen := map[*stepTiming]bool{}
+	for s := last; s != nil && !seen[s]; s = predecessor(s) {
+		seen[s] = true
+		path = append(path, s)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// concurrency returns the number of steps that were in progress during each second of the operation.
+func (tl *timeline) concurrency() []int {
+	if tl.End <= tl.Start {
+		return nil
+	}
+	active := make([]int, tl.End-tl.Start)
+	for _, s := range tl.Steps {
+		for t := s.Start; t < s.End; t++ {
+			active[t-tl.Start]++
+		}
+	}
+	return active
+}
+
+// assignLanes assigns each step to a lane such that no two steps in the same lane overlap, approximating the worker
+// that ran each step. It returns the number of lanes used.
+func (tl *timeline) assignLanes() int {
+	var laneEnds []int
+	for _, s := range tl.Steps {
+		s.lane = -1
+		for i, end := range laneEnds {
+			if end <= s.Start {
+				s.lane = i
+				break
+			}
+		}
+		if s.lane == -1 {
+			s.lane = len(laneEnds)
+			laneEnds = append(laneEnds, 0)
+		}
+		// Zero-length steps still occupy their lane for the instant they run.
+		laneEnds[s.lane] = max(s.End, s.Start+1)
+	}
+	return len(laneEnds)
+}
+
+// aggregate summarizes the time spent on a group of steps.
+type aggregate struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+	Total int    `json:"total"`
+	Max   int    `json:"max"`
+}
+
+// aggregateBy groups steps using the given key function and returns the groups ordered by total time spent.
+func (tl *timeline) aggregateBy(key func(*stepTiming) string) []aggregate {
+	groups := map[string]*aggregate{}
+	for _, s := range tl.Steps {
+		k := key(s)
+		g, ok := groups[k]
+		if !ok {
+			g = &aggregate{Name: k}
+			groups[k] = g
+		}
+		g.Count++
+		g.Total += s.Duration()
+		g.Max = max(g.Max, s.Duration())
+	}
+
+	result := make([]aggregate, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, *g)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Total != result[j].Total {
+			return result[i].Total > result[j].Total
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// typeName returns the type token of the given step's resource.
+func typeName(s *stepTiming) string {
+	if s.Type != "" {
+		return s.Type
+	}
+	return string(resource.URN(s.URN).Type())
+}
@@ -42,6 +42,7 @@ func NewInstallCmd(ws pkgWorkspace.Context) *cobra.Command {
 	var reinstall bool
 	var noPlugins, noDependencies bool
 	var useLanguageVersionTools bool
+	var updateLock bool
 
 	cmd := &cobra.Command{
 		Use:   "install",
@@ -156,14 +157,40 @@ func NewInstallCmd(ws pkgWorkspace.Context) *cobra.Command {
 				}
 
 				pluginSet := engine.NewPluginSet()
+				packageSet := engine.NewPackageSet()
 				for _, pkg := range packages {
 					pluginSet.Add(pkg.PluginSpec)
+					packageSet.Add(pkg)
 				}
 
+				// Install the plugins at the versions pinned by Pulumi.lock, and record any new plugins and the
+				// checksums for this platform in it.
+				lock, err := workspace.LoadPluginLock(root)
+				if err != nil {
+					return err
+				}
+				if updateLock {
+					lock.AllowUpdates()
+				}
+				projectPlugins := pctx.Host.GetProjectPlugins()
+				if err := engine.CheckPluginLockVersions(lock, pluginSet, projectPlugins); err != nil {
+					return err
+				}
 				if err = engine.EnsurePluginsAreInstalled(ctx, nil, pctx.Diag, pluginSet,
-					pctx.Host.GetProjectPlugins(), reinstall, true); err != nil {
+					projectPlugins, lock, reinstall, true); err != nil {
+					return err
+				}
+				if err := engine.VerifyPluginLock(pctx.Diag, pluginSet, projectPlugins, lock); err != nil {
 					return err
 				}
+				lockPlugins := packageSet.ToPluginSet()
+				if spec, ok := engine.LanguagePluginSpec(lang); ok {
+					lockPlugins.Add(spec)
+				}
+				engine.RecordPluginLock(lock, lockPlugins, packageSet, projectPlugins)
+				if err := lock.Save(); err != nil {
+					return fmt.Errorf("writing %s: %w", lock.Path(), err)
+				}
 			}
 
 			return nil
@@ -178,6 +205,8 @@ func NewInstallCmd(ws pkgWorkspace.Context) *cobra.Command {
 		"no-dependencies", false, "Skip installing dependencies")
 	cmd.PersistentFlags().BoolVar(&useLanguageVersionTools,
 		"use-language-version-tools", false, "Use language version tools to setup and install the language runtime")
+	cmd.PersistentFlags().BoolVar(&updateLock,
+		"update-lock", false, "Install plugin versions other than the ones locked in Pulumi.lock, and update the lock")
 
 	return cmd
 }
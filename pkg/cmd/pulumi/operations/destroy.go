@@ -63,7 +63,7 @@ func NewDestroyCmd() *cobra.Command {
 	// Flags for engine.UpdateOptions.
 	var jsonDisplay bool
 	var diffDisplay bool
-	var eventLogPath string
+	var eventLog eventLogArgs
 	var parallel int32
 	var previewOnly bool
 	var refresh string
@@ -146,10 +146,12 @@ func NewDestroyCmd() *cobra.Command {
 				SuppressProgress:     suppressProgress,
 				IsInteractive:        interactive,
 				Type:                 displayType,
-				EventLogPath:         eventLogPath,
 				Debug:                debug,
 				JSONDisplay:          jsonDisplay,
 			}
+			if err := eventLog.Apply(&opts.Display); err != nil {
+				return err
+			}
 
 			// we only suppress permalinks if the user passes true. the default is an empty string
 			// which we pass as 'false'
@@ -266,8 +268,14 @@ func NewDestroyCmd() *cobra.Command {
 			}
 
 			var protectedCount int
-			targetUrns := *targets
-			excludeUrns := *excludes
+			targetUrns, err := resolveTargetFilters(ctx, s, "target", *targets, true)
+			if err != nil {
+				return err
+			}
+			excludeUrns, err := resolveTargetFilters(ctx, s, "exclude", *excludes, false)
+			if err != nil {
+				return err
+			}
 			if excludeProtected {
 				contract.Assertf(len(targetUrns) == 0, "Expected no target URNs, got %d", len(targetUrns))
 				targetUrns, protectedCount, err = handleExcludeProtected(ctx, s)
@@ -369,12 +377,12 @@ func NewDestroyCmd() *cobra.Command {
 		"target", "t", []string{},
 		"Specify a single resource URN to destroy. All resources necessary to destroy this target will also be destroyed."+
 			" Multiple resources can be specified using: --target urn1 --target urn2."+
-			" Wildcards (*, **) are also supported")
+			" Wildcards (*, **) and filter expressions (see 'pulumi stack query') are also supported")
 	excludes = cmd.PersistentFlags().StringArrayP(
 		"exclude", "x", []string{},
 		"Specify a resource URN to ignore. These resources will not be updated."+
 			" Multiple resources can be specified using --exclude urn1 --exclude urn2."+
-			" Wildcards (*, **) are also supported")
+			" Wildcards (*, **) and filter expressions (see 'pulumi stack query') are also supported")
 	cmd.PersistentFlags().BoolVar(
 		&targetDependents, "target-dependents", false,
 		"Allows destroying of dependent targets discovered but not specified in --target list")
@@ -440,11 +448,7 @@ func NewDestroyCmd() *cobra.Command {
 	// Remote flags
 	remoteArgs.ApplyFlags(cmd)
 
-	if env.DebugCommands.Value() {
-		cmd.PersistentFlags().StringVar(
-			&eventLogPath, "event-log", "",
-			"Log events to a file at this path")
-	}
+	eventLog.ApplyFlags(cmd)
 
 	// internal flags
 	cmd.PersistentFlags().StringVar(&execKind, "exec-kind", "", "")
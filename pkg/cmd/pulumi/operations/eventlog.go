@@ -0,0 +1,86 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/env"
+)
+
+// Flags for writing engine events to an event log.
+type eventLogArgs struct {
+	Path       string
+	MaxSize    string
+	MaxAge     time.Duration
+	MaxBackups int
+	Compress   bool
+	Exclude    []string
+}
+
+// ApplyFlags adds the event log flags to the given command. The flags are only available when debug commands are
+// enabled.
+func (a *eventLogArgs) ApplyFlags(cmd *cobra.Command) {
+	if !env.DebugCommands.Value() {
+		return
+	}
+
+	cmd.PersistentFlags().StringVar(
+		&a.Path, "event-log", "",
+		"Log events to a file at this path")
+	cmd.PersistentFlags().StringVar(
+		&a.MaxSize, "event-log-max-size", "",
+		"Rotate the event log once it reaches this size (e.g. `100MB`)")
+	cmd.PersistentFlags().DurationVar(
+		&a.MaxAge, "event-log-max-age", 0,
+		"Rotate the event log once it has been written to for this long (e.g. `1h`)")
+	cmd.PersistentFlags().IntVar(
+		&a.MaxBackups, "event-log-max-backups", 0,
+		"The number of rotated event log segments to keep; 0 keeps all of them")
+	cmd.PersistentFlags().BoolVar(
+		&a.Compress, "event-log-compress", false,
+		"Compress rotated event log segments with gzip")
+	cmd.PersistentFlags().StringSliceVar(
+		&a.Exclude, "event-log-exclude", nil,
+		"Event kinds to omit from the event log, either an event type (e.g. `progress`) "+
+			"or a diagnostic severity (e.g. `diag:debug`)")
+}
+
+// Apply sets the event log options on the given display options.
+func (a *eventLogArgs) Apply(opts *display.Options) error {
+	opts.EventLogPath = a.Path
+	opts.EventLogMaxAge = a.MaxAge
+	opts.EventLogMaxBackups = a.MaxBackups
+	opts.EventLogCompress = a.Compress
+	opts.EventLogExclude = a.Exclude
+
+	if err := display.ValidateEventLogExclude(a.Exclude); err != nil {
+		return fmt.Errorf("invalid --event-log-exclude: %w", err)
+	}
+
+	if a.MaxSize != "" {
+		size, err := humanize.ParseBytes(a.MaxSize)
+		if err != nil {
+			return fmt.Errorf("invalid --event-log-max-size %q: %w", a.MaxSize, err)
+		}
+		opts.EventLogMaxSize = int64(size)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+)
+
+func TestEventLogArgsApply(t *testing.T) {
+	t.Parallel()
+
+	var opts display.Options
+	args := eventLogArgs{Path: "events.json", MaxSize: "1KB", Exclude: []string{"progress", "diag:debug"}}
+	require.NoError(t, args.Apply(&opts))
+	assert.Equal(t, "events.json", opts.EventLogPath)
+	assert.Equal(t, int64(1000), opts.EventLogMaxSize)
+	assert.Equal(t, []string{"progress", "diag:debug"}, opts.EventLogExclude)
+
+	// Invalid exclusions are rejected up front, rather than silently disabling the event log.
+	args = eventLogArgs{Path: "events.json", Exclude: []string{"bogus"}}
+	err := args.Apply(&opts)
+	assert.ErrorContains(t, err, "invalid --event-log-exclude")
+	assert.ErrorContains(t, err, `"bogus"`)
+
+	args = eventLogArgs{Path: "events.json", MaxSize: "lots"}
+	assert.ErrorContains(t, args.Apply(&opts), "invalid --event-log-max-size")
+}
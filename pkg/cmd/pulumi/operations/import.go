@@ -47,7 +47,6 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
 	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
 	pkgWorkspace "github.com/pulumi/pulumi/pkg/v3/workspace"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/env"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
@@ -548,8 +547,10 @@ func NewImportCmd() *cobra.Command {
 	// Flags for engine.UpdateOptions.
 	var jsonDisplay bool
 	var diffDisplay bool
-	var eventLogPath string
+	var eventLog eventLogArgs
 	var parallel int32
+	var policyPackPaths []string
+	var policyPackConfigPaths []string
 	var previewOnly bool
 	var showConfig bool
 	var skipPreview bool
@@ -662,37 +663,7 @@ func NewImportCmd() *cobra.Command {
 				}
 				defer contract.IgnoreClose(converter)
 
-				installPlugin := func(pluginName string) *semver.Version {
-					// If auto plugin installs are disabled just return nil, the mapper will still carry on
-					if env.DisableAutomaticPluginAcquisition.Value() {
-						return nil
-					}
-
-					log := func(sev diag.Severity, msg string) {
-						pCtx.Diag.Logf(sev, diag.RawMessage("", msg))
-					}
-
-					pluginSpec, err := workspace.NewPluginSpec(ctx, pluginName, apitype.ResourcePlugin, nil, "", nil)
-					if err != nil {
-						pCtx.Diag.Warningf(diag.Message("", "failed to create plugin spec for provider %q: %v"), pluginName, err)
-						return nil
-					}
-					version, err := pkgWorkspace.InstallPlugin(ctx, pluginSpec, log)
-					if err != nil {
-						pCtx.Diag.Warningf(diag.Message("", "failed to install provider %q: %v"), pluginName, err)
-						return nil
-					}
-					return version
-				}
-
-				baseMapper, err := convert.NewBasePluginMapper(
-					convert.DefaultWorkspace(),
-					from, /*conversionKey*/
-					convert.ProviderFactoryFromHost(ctx, pCtx.Host),
-					installPlugin,
-					nil, /*mappings*/
-				)
-				mapper := convert.NewCachingMapper(baseMapper)
+				mapper, err := cmdConvert.NewPluginMapper(ctx, pCtx, from /*conversionKey*/, nil /*mappings*/)
 				if err != nil {
 					return err
 				}
@@ -782,6 +753,10 @@ func NewImportCmd() *cobra.Command {
 				return err
 			}
 
+			if err = validatePolicyPackConfig(policyPackPaths, policyPackConfigPaths); err != nil {
+				return err
+			}
+
 			displayType := display.DisplayProgress
 			if diffDisplay {
 				displayType = display.DisplayDiff
@@ -794,10 +769,12 @@ func NewImportCmd() *cobra.Command {
 				SuppressProgress: suppressProgress,
 				IsInteractive:    interactive,
 				Type:             displayType,
-				EventLogPath:     eventLogPath,
 				Debug:            debug,
 				JSONDisplay:      jsonDisplay,
 			}
+			if err := eventLog.Apply(&opts.Display); err != nil {
+				return err
+			}
 
 			// we only suppress permalinks if the user passes true. the default is an empty string
 			// which we pass as 'false'
@@ -903,6 +880,7 @@ func NewImportCmd() *cobra.Command {
 			}
 
 			opts.Engine = engine.UpdateOptions{
+				LocalPolicyPacks:     engine.MakeLocalPolicyPacks(policyPackPaths, policyPackConfigPaths),
 				Parallel:             parallel,
 				Debug:                debug,
 				UseLegacyDiff:        env.EnableLegacyDiff.Value(),
@@ -910,6 +888,7 @@ func NewImportCmd() *cobra.Command {
 				Experimental:         env.Experimental.Value(),
 			}
 
+			events, remediations := collectRemediations()
 			_, err = backend.ImportStack(ctx, s, backend.UpdateOperation{
 				Proj:               proj,
 				Root:               root,
@@ -919,7 +898,8 @@ func NewImportCmd() *cobra.Command {
 				SecretsManager:     sm,
 				SecretsProvider:    stack.DefaultSecretsProvider,
 				Scopes:             backend.CancellationScopes,
-			}, imports)
+			}, imports, events)
+			remediated := remediations()
 
 			if generateCode {
 				deployment, err := getCurrentDeploymentForStack(ctx, s)
@@ -957,6 +937,10 @@ func NewImportCmd() *cobra.Command {
 				}
 			}
 
+			if err == nil && !previewOnly && !jsonDisplay {
+				reportImportRemediations(s, remediated)
+			}
+
 			if err != nil {
 				if err == context.Canceled {
 					return errors.New("import cancelled")
@@ -1016,6 +1000,12 @@ func NewImportCmd() *cobra.Command {
 	cmd.PersistentFlags().Int32VarP(
 		&parallel, "parallel", "p", defaultParallel(),
 		"Allow P resource operations to run in parallel at once (1 for no parallelism).")
+	cmd.PersistentFlags().StringSliceVar(
+		&policyPackPaths, "policy-pack", []string{},
+		"Run one or more policy packs against the imported resources")
+	cmd.PersistentFlags().StringSliceVar(
+		&policyPackConfigPaths, "policy-pack-config", []string{},
+		`Path to JSON file containing the config for the policy pack of the corresponding "--policy-pack" flag`)
 	cmd.PersistentFlags().BoolVar(
 		&previewOnly, "preview-only", false,
 		"Only show a preview of the import, but don't perform the import itself")
@@ -1045,11 +1035,7 @@ func NewImportCmd() *cobra.Command {
 		&from, "from", "",
 		"Invoke a converter to import the resources")
 
-	if env.DebugCommands.Value() {
-		cmd.PersistentFlags().StringVar(
-			&eventLogPath, "event-log", "",
-			"Log events to a file at this path")
-	}
+	eventLog.ApplyFlags(cmd)
 
 	// internal flags
 	cmd.PersistentFlags().StringVar(&execKind, "exec-kind", "", "")
@@ -25,6 +25,9 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/backend"
 	"github.com/pulumi/pulumi/pkg/v3/backend/display"
 	"github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/newcmd"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/filter"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
 	pkgWorkspace "github.com/pulumi/pulumi/pkg/v3/workspace"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/env"
@@ -145,3 +148,64 @@ func configureCopilotOptions(copilotEnabledFlag bool, cmd *cobra.Command, displa
 	displayOpts.CopilotSummaryModel = env.CopilotSummaryModel.Value()
 	displayOpts.CopilotSummaryMaxLen = env.CopilotSummaryMaxLen.Value()
 }
+
+// resolveTargetFilters replaces the filter expressions in values, as passed to flags such as --target and --exclude,
+// with the URNs of the resources in the stack that match them. URNs and URN globs are returned unchanged. If
+// mustMatch is set, it's an error for an expression to match no resources, since an empty list of targets would
+// otherwise mean that every resource is targeted.
+func resolveTargetFilters(
+	ctx context.Context, s backend.Stack, flag string, values []string, mustMatch bool,
+) ([]string, error) {
+	var snap *deploy.Snapshot
+	resolved := make([]string, 0, len(values))
+	for _, value := range values {
+		if !filter.IsExpression(value) {
+			resolved = append(resolved, value)
+			continue
+		}
+		f, err := filter.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --%s: %w", flag, err)
+		}
+		if snap == nil {
+			if snap, err = s.Snapshot(ctx, stack.DefaultSecretsProvider); err != nil {
+				return nil, fmt.Errorf("getting snapshot: %w", err)
+			}
+			if snap == nil {
+				snap = &deploy.Snapshot{}
+			}
+		}
+		matches := f.Select(snap.Resources)
+		if len(matches) == 0 && mustMatch {
+			return nil, fmt.Errorf("--%s %q does not match any resources in the stack", flag, value)
+		}
+		for _, r := range matches {
+			resolved = append(resolved, string(r.URN))
+		}
+	}
+	return resolved, nil
+}
+
+// resolveUpdateTargetFilters resolves the filter expressions passed to the --target, --exclude, --replace and
+// --target-replace flags of `pulumi up` and `pulumi preview`.
+func resolveUpdateTargetFilters(
+	ctx context.Context, s backend.Stack, targets, excludes, replaces, targetReplaces []string,
+) ([]string, []string, []string, []string, error) {
+	targets, err := resolveTargetFilters(ctx, s, "target", targets, true)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	excludes, err = resolveTargetFilters(ctx, s, "exclude", excludes, false)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	replaces, err = resolveTargetFilters(ctx, s, "replace", replaces, true)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	targetReplaces, err = resolveTargetFilters(ctx, s, "target-replace", targetReplaces, true)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return targets, excludes, replaces, targetReplaces, nil
+}
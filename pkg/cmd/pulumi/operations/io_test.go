@@ -15,8 +15,16 @@
 package operations
 
 import (
+	"context"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 )
 
@@ -87,3 +95,48 @@ func TestGetRefreshOption(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveTargetFilters(t *testing.T) {
+	t.Parallel()
+
+	snapshots := 0
+	s := &backend.MockStack{
+		SnapshotF: func(context.Context, secrets.Provider) (*deploy.Snapshot, error) {
+			snapshots++
+			return &deploy.Snapshot{
+				Resources: []*resource.State{
+					{Type: "aws:s3/bucket:Bucket", URN: "urn:pulumi:dev::proj::aws:s3/bucket:Bucket::a", Protect: true},
+					{Type: "aws:s3/bucket:Bucket", URN: "urn:pulumi:dev::proj::aws:s3/bucket:Bucket::b"},
+					{Type: "aws:sqs/queue:Queue", URN: "urn:pulumi:dev::proj::aws:sqs/queue:Queue::c"},
+				},
+			}, nil
+		},
+	}
+	ctx := context.Background()
+
+	// URNs and globs are passed through without loading the snapshot.
+	resolved, err := resolveTargetFilters(ctx, s, "target", []string{"urn:pulumi:dev::proj::**"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"urn:pulumi:dev::proj::**"}, resolved)
+	assert.Equal(t, 0, snapshots)
+
+	resolved, err = resolveTargetFilters(ctx, s, "target", []string{
+		`type = "aws:s3/*" and not protect`,
+		"urn:pulumi:dev::proj::aws:sqs/queue:Queue::c",
+	}, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"urn:pulumi:dev::proj::aws:s3/bucket:Bucket::b",
+		"urn:pulumi:dev::proj::aws:sqs/queue:Queue::c",
+	}, resolved)
+
+	// Expressions that don't match anything are an error for targets, since no targets means everything.
+	_, err = resolveTargetFilters(ctx, s, "target", []string{"type = gcp*"}, true)
+	assert.ErrorContains(t, err, `--target "type = gcp*" does not match any resources in the stack`)
+	resolved, err = resolveTargetFilters(ctx, s, "exclude", []string{"type = gcp*"}, false)
+	require.NoError(t, err)
+	assert.Empty(t, resolved)
+
+	_, err = resolveTargetFilters(ctx, s, "exclude", []string{"colour = red"}, false)
+	assert.ErrorContains(t, err, `invalid --exclude: invalid filter "colour = red"`)
+}
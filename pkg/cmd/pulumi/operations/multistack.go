@@ -0,0 +1,425 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	cmdStack "github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/stack"
+	"github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/ui"
+	sdkDisplay "github.com/pulumi/pulumi/pkg/v3/display"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// Flags for running an operation against several stacks at once.
+type multiStackArgs struct {
+	Stacks      string
+	StackSet    string
+	Concurrency int
+}
+
+// ApplyFlags adds the multi-stack flags to the given command.
+func (a *multiStackArgs) ApplyFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(
+		&a.Stacks, "stacks", "",
+		"Run the operation against every stack of the current project whose name matches this glob "+
+			"(e.g. `prod-*`)")
+	cmd.PersistentFlags().StringVar(
+		&a.StackSet, "stack-set", "",
+		"Run the operation against the stacks listed in this file, one stack name or glob per line")
+	cmd.PersistentFlags().IntVar(
+		&a.Concurrency, "stack-concurrency", 4,
+		"The number of stacks to operate on at once when using --stacks or --stack-set")
+
+	cmd.MarkFlagsMutuallyExclusive("stacks", "stack-set")
+}
+
+// Enabled returns true if the operation should run against several stacks.
+func (a *multiStackArgs) Enabled() bool {
+	return a.Stacks != "" || a.StackSet != ""
+}
+
+// patterns returns the stack name patterns selected by the flags.
+func (a *multiStackArgs) patterns() ([]string, error) {
+	if a.Stacks != "" {
+		return []string{a.Stacks}, nil
+	}
+
+	f, err := os.Open(a.StackSet)
+	if err != nil {
+		return nil, fmt.Errorf("reading stack set: %w", err)
+	}
+	defer contract.IgnoreClose(f)
+
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i != -1 {
+			line = line[:i]
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			patterns = append(patterns, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading stack set: %w", err)
+	}
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("stack set %s does not list any stacks", a.StackSet)
+	}
+	return patterns, nil
+}
+
+// Resolve returns the stacks of the given project that are selected by the flags, ordered by name. Patterns are
+// matched against both the short and the fully qualified name of each stack. A pattern that contains no wildcards
+// must match a stack.
+func (a *multiStackArgs) Resolve(
+	ctx context.Context, b backend.Backend, proj *workspace.Project,
+) ([]backend.Stack, error) {
+	patterns, err := a.patterns()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("invalid stack pattern %q: %w", p, err)
+		}
+	}
+
+	projectName := string(proj.Name)
+	filter := backend.ListStackNamesFilter{Project: &projectName}
+	var refs []backend.StackReference
+	var inContToken backend.ContinuationToken
+	for {
+		page, outContToken, err := b.ListStackNames(ctx, filter, inContToken)
+		if err != nil {
+			return nil, fmt.Errorf("listing stacks: %w", err)
+		}
+		refs = append(refs, page...)
+		if outContToken == nil {
+			break
+		}
+		inContToken = outContToken
+	}
+
+	matched := map[string]backend.StackReference{}
+	for _, p := range patterns {
+		found := false
+		for _, ref := range refs {
+			short, full := ref.Name().String(), ref.String()
+			if ok, _ := path.Match(p, short); !ok {
+				if ok, _ = path.Match(p, full); !ok {
+					continue
+				}
+			}
+			matched[full] = ref
+			found = true
+		}
+		if !found && !strings.ContainsAny(p, "*?[") {
+			return nil, fmt.Errorf("no stack named '%s' found", p)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("no stacks of project %s match %s", projectName, strings.Join(patterns, ", "))
+	}
+
+	names := make([]string, 0, len(matched))
+	for name := range matched {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	stacks := make([]backend.Stack, 0, len(names))
+	for _, name := range names {
+		s, err := b.GetStack(ctx, matched[name])
+		if err != nil {
+			return nil, err
+		}
+		if s == nil {
+			return nil, fmt.Errorf("no stack named '%s' found", name)
+		}
+		stacks = append(stacks, s)
+	}
+	return stacks, nil
+}
+
+// stackOperation runs an operation against a single stack using the given display options.
+type stackOperation func(
+	ctx context.Context, s backend.Stack, opts display.Options,
+) (sdkDisplay.ResourceChanges, error)
+
+// stackResult is the outcome of running an operation against a single stack.
+type stackResult struct {
+	Stack    string
+	Changes  sdkDisplay.ResourceChanges
+	Duration time.Duration
+	Err      error
+	// Skipped is true if the operation didn't run because an operation on a stack this stack depends on failed.
+	Skipped bool
+}
+
+// runMultiStack runs op against each of the given stacks, running at most concurrency operations at once. If graph is
+// not nil, the operation on a stack only starts once the operations on the stacks it reads outputs from have
+// succeeded, and is skipped if any of them failed. Each operation's display is rendered non-interactively, with every
+// line prefixed by the name of its stack so that the output of concurrent operations can be told apart. Once every
+// operation has finished a summary of the results is written, and an error is returned if any of the operations
+// failed.
+func runMultiStack(
+	ctx context.Context, stacks []backend.Stack, graph *cmdStack.StackGraph, concurrency int, opts display.Options,
+	op stackOperation,
+) error {
+	if concurrency <= 0 {
+		return fmt.Errorf("--stack-concurrency must be positive, got %d", concurrency)
+	}
+
+	index := map[string]int{}
+	for i, s := range stacks {
+		index[s.Ref().FullyQualifiedName().String()] = i
+	}
+	upstream := make([][]int, len(stacks))
+	if graph != nil {
+		if _, err := graph.TopologicalOrder(); err != nil {
+			return err
+		}
+		for i, s := range stacks {
+			for _, u := range graph.Upstream(s.Ref().FullyQualifiedName().String()) {
+				if j, ok := index[u]; ok && j != i {
+					upstream[i] = append(upstream[i], j)
+				}
+			}
+		}
+	}
+
+	stdout, stderr := opts.Stdout, opts.Stderr
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	if stderr == nil {
+		stderr = os.Stderr
+	}
+
+	width := 0
+	for _, s := range stacks {
+		width = max(width, len(s.Ref().Name().String()))
+	}
+
+	var mu sync.Mutex
+	results := make([]stackResult, len(stacks))
+	done := make([]chan struct{}, len(stacks))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, s := range stacks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(done[i])
+
+			// Wait for the stacks this one depends on before taking a slot, so that waiting stacks can't starve the
+			// stacks they're waiting on.
+			for _, j := range upstream[i] {
+				<-done[j]
+				if results[j].Err != nil {
+					results[i] = stackResult{
+						Stack:   s.Ref().String(),
+						Err:     fmt.Errorf("skipped because %s did not succeed", results[j].Stack),
+						Skipped: true,
+					}
+					return
+				}
+			}
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			name := s.Ref().Name().String()
+			prefix := opts.Color.Colorize(fmt.Sprintf("%s%-*s%s | ", colors.Cyan, width, name, colors.Reset))
+			out := &prefixWriter{mu: &mu, w: stdout, prefix: prefix}
+			errOut := &prefixWriter{mu: &mu, w: stderr, prefix: prefix}
+
+			stackOpts := opts
+			stackOpts.IsInteractive = false
+			stackOpts.Stdout = out
+			stackOpts.Stderr = errOut
+			if opts.EventLogPath != "" {
+				stackOpts.EventLogPath = stackEventLogPath(opts.EventLogPath, name)
+			}
+
+			start := time.Now()
+			changes, err := op(ctx, s, stackOpts)
+			results[i] = stackResult{
+				Stack:    s.Ref().String(),
+				Changes:  changes,
+				Duration: time.Since(start).Round(time.Second),
+				Err:      err,
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			out.flush()
+			errOut.flush()
+		}()
+	}
+	wg.Wait()
+
+	fmt.Fprintln(stdout)
+	renderStackResults(stdout, results)
+
+	var failed, skipped []string
+	for _, r := range results {
+		switch {
+		case r.Skipped:
+			skipped = append(skipped, r.Stack)
+		case r.Err != nil:
+			failed = append(failed, r.Stack)
+		}
+	}
+	if len(failed) > 0 {
+		err := fmt.Errorf("%d of %d stacks failed: %s", len(failed), len(results), strings.Join(failed, ", "))
+		if len(skipped) > 0 {
+			err = fmt.Errorf("%w; skipped %s", err, strings.Join(skipped, ", "))
+		}
+		return err
+	}
+	return nil
+}
+
+// warnStaleDependents writes a warning for each stack outside of the given stacks that reads outputs from one of
+// them which have changed since it was last updated.
+func warnStaleDependents(out io.Writer, graph *cmdStack.StackGraph, stacks []backend.Stack) {
+	updated := map[string]bool{}
+	for _, s := range stacks {
+		updated[s.Ref().FullyQualifiedName().String()] = true
+	}
+	for _, d := range graph.Dependencies {
+		if updated[d.Upstream] && !updated[d.Stack] && len(d.Changed) > 0 {
+			fmt.Fprintf(out, "warning: %s reads outputs of %s that have changed (%s); update %s to pick up the changes\n",
+				d.Stack, d.Upstream, strings.Join(d.Changed, ", "), d.Stack)
+		}
+	}
+}
+
+// stackEventLogPath returns the path of the event log for the given stack, which is derived from the event log path
+// passed on the command line so that concurrent operations don't write to the same file.
+func stackEventLogPath(path, stack string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + strings.ReplaceAll(stack, "/", "_") + ext
+}
+
+// renderStackResults writes a table summarizing the result of the operation on each stack.
+func renderStackResults(out io.Writer, results []stackResult) {
+	rows := make([]cmdutil.TableRow, 0, len(results))
+	for _, r := range results {
+		result := "succeeded"
+		switch {
+		case r.Skipped:
+			result = "skipped"
+		case r.Err != nil:
+			result = "failed"
+		}
+		rows = append(rows, cmdutil.TableRow{Columns: []string{
+			r.Stack, result, formatResourceChanges(r.Changes), r.Duration.String(),
+		}})
+	}
+	ui.FprintTable(out, cmdutil.Table{
+		Headers: []string{"STACK", "RESULT", "CHANGES", "DURATION"},
+		Rows:    rows,
+	}, nil)
+
+	for _, r := range results {
+		if r.Err != nil {
+			fmt.Fprintf(out, "\n%s: %v\n", r.Stack, r.Err)
+		}
+	}
+}
+
+// formatResourceChanges returns a short summary of the given changes, e.g. `+2 ~1 -1`.
+func formatResourceChanges(changes sdkDisplay.ResourceChanges) string {
+	var parts []string
+	for _, op := range []struct {
+		op     sdkDisplay.StepOp
+		symbol string
+	}{
+		{deploy.OpCreate, "+"},
+		{deploy.OpUpdate, "~"},
+		{deploy.OpReplace, "+-"},
+		{deploy.OpDelete, "-"},
+	} {
+		if n := changes[op.op]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%s%d", op.symbol, n))
+		}
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, " ")
+}
+
+// prefixWriter writes each line written to it to an underlying writer, prefixed with a label. Writers that share a
+// mutex never interleave their output within a line.
+type prefixWriter struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	prefix string
+	buf    []byte
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i == -1 {
+			break
+		}
+		if _, err := fmt.Fprintf(w.w, "%s%s\n", w.prefix, w.buf[:i]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// flush writes any partial line that remains buffered. The caller must hold the writer's mutex.
+func (w *prefixWriter) flush() {
+	if len(w.buf) > 0 {
+		fmt.Fprintf(w.w, "%s%s\n", w.prefix, w.buf)
+		w.buf = nil
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	cmdStack "github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/stack"
+	sdkDisplay "github.com/pulumi/pulumi/pkg/v3/display"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+func newMultiStackBackend(t *testing.T, names ...string) *backend.MockBackend {
+	refs := make([]backend.StackReference, 0, len(names))
+	for _, name := range names {
+		refs = append(refs, &backend.MockStackReference{
+			StringV:             "org/proj/" + name,
+			NameV:               tokens.MustParseStackName(name),
+			ProjectV:            "proj",
+			FullyQualifiedNameV: tokens.QName("org/proj/" + name),
+		})
+	}
+
+	return &backend.MockBackend{
+		ListStackNamesF: func(
+			_ context.Context, filter backend.ListStackNamesFilter, _ backend.ContinuationToken,
+		) ([]backend.StackReference, backend.ContinuationToken, error) {
+			require.NotNil(t, filter.Project)
+			assert.Equal(t, "proj", *filter.Project)
+			return refs, nil, nil
+		},
+		GetStackF: func(_ context.Context, ref backend.StackReference) (backend.Stack, error) {
+			return &backend.MockStack{RefF: func() backend.StackReference { return ref }}, nil
+		},
+	}
+}
+
+func stackNames(stacks []backend.Stack) []string {
+	names := make([]string, 0, len(stacks))
+	for _, s := range stacks {
+		names = append(names, s.Ref().Name().String())
+	}
+	return names
+}
+
+func TestMultiStackResolveGlob(t *testing.T) {
+	t.Parallel()
+
+	b := newMultiStackBackend(t, "prod-us", "dev", "prod-eu")
+	proj := &workspace.Project{Name: "proj"}
+
+	args := multiStackArgs{Stacks: "prod-*"}
+	stacks, err := args.Resolve(context.Background(), b, proj)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod-eu", "prod-us"}, stackNames(stacks))
+
+	args = multiStackArgs{Stacks: "org/proj/d*"}
+	stacks, err = args.Resolve(context.Background(), b, proj)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev"}, stackNames(stacks))
+
+	args = multiStackArgs{Stacks: "staging-*"}
+	_, err = args.Resolve(context.Background(), b, proj)
+	assert.ErrorContains(t, err, "no stacks of project proj match staging-*")
+}
+
+func TestMultiStackResolveStackSet(t *testing.T) {
+	t.Parallel()
+
+	b := newMultiStackBackend(t, "prod-us", "dev", "prod-eu", "test")
+	proj := &workspace.Project{Name: "proj"}
+
+	path := filepath.Join(t.TempDir(), "stacks.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# Regional stacks\nprod-*\n\ndev # and dev\nprod-us\n"), 0o600))
+
+	args := multiStackArgs{StackSet: path}
+	stacks, err := args.Resolve(context.Background(), b, proj)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev", "prod-eu", "prod-us"}, stackNames(stacks))
+
+	require.NoError(t, os.WriteFile(path, []byte("dev\nstaging\n"), 0o600))
+	_, err = args.Resolve(context.Background(), b, proj)
+	assert.ErrorContains(t, err, "no stack named 'staging' found")
+
+	require.NoError(t, os.WriteFile(path, []byte("# nothing\n"), 0o600))
+	_, err = args.Resolve(context.Background(), b, proj)
+	assert.ErrorContains(t, err, "does not list any stacks")
+}
+
+func TestRunMultiStack(t *testing.T) {
+	t.Parallel()
+
+	b := newMultiStackBackend(t, "a", "bb", "c", "d")
+	stacks, err := (&multiStackArgs{Stacks: "*"}).Resolve(context.Background(), b, &workspace.Project{Name: "proj"})
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	opts := display.Options{
+		Color:         colors.Never,
+		IsInteractive: true,
+		Stdout:        &stdout,
+		Stderr:        &stderr,
+		EventLogPath:  "/tmp/events.json",
+	}
+
+	var running, peak atomic.Int32
+	err = runMultiStack(context.Background(), stacks, nil, 2, opts,
+		func(ctx context.Context, s backend.Stack, opts display.Options) (sdkDisplay.ResourceChanges, error) {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+
+			name := s.Ref().Name().String()
+			assert.False(t, opts.IsInteractive)
+			assert.Equal(t, "/tmp/events."+name+".json", opts.EventLogPath)
+
+			fmt.Fprintf(opts.Stdout, "Updating %s\n", name)
+			fmt.Fprintf(opts.Stdout, "partial")
+			if name == "c" {
+				fmt.Fprintln(opts.Stderr, "boom")
+				return nil, errors.New("update failed")
+			}
+			return sdkDisplay.ResourceChanges{deploy.OpCreate: 2, deploy.OpSame: 3, deploy.OpDelete: 1}, nil
+		})
+	assert.EqualError(t, err, "1 of 4 stacks failed: org/proj/c")
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+
+	out := stdout.String()
+	assert.Contains(t, out, "a  | Updating a\n")
+	assert.Contains(t, out, "bb | Updating bb\n")
+	assert.Contains(t, out, "bb | partial\n")
+	assert.Equal(t, "c  | boom\n", stderr.String())
+
+	var summary []string
+	for _, line := range strings.Split(out, "\n") {
+		if !strings.Contains(line, " | ") {
+			summary = append(summary, strings.Join(strings.Fields(line), " "))
+		}
+	}
+	assert.Contains(t, summary, "STACK RESULT CHANGES DURATION")
+	assert.Contains(t, summary, "org/proj/a succeeded +2 -1 0s")
+	assert.Contains(t, summary, "org/proj/c failed - 0s")
+	assert.Contains(t, summary, "org/proj/c: update failed")
+}
+
+func TestRunMultiStackOrdersByDependencies(t *testing.T) {
+	t.Parallel()
+
+	b := newMultiStackBackend(t, "network", "app", "db", "web")
+	stacks, err := (&multiStackArgs{Stacks: "*"}).Resolve(context.Background(), b, &workspace.Project{Name: "proj"})
+	require.NoError(t, err)
+
+	graph := &cmdStack.StackGraph{
+		Stacks: []string{"org/proj/app", "org/proj/db", "org/proj/network", "org/proj/web"},
+		Dependencies: []cmdStack.StackDependency{
+			{Stack: "org/proj/app", Upstream: "org/proj/db"},
+			{Stack: "org/proj/db", Upstream: "org/proj/network"},
+			{Stack: "org/proj/web", Upstream: "org/proj/app"},
+		},
+	}
+
+	var mu sync.Mutex
+	var order []string
+	var stdout bytes.Buffer
+	err = runMultiStack(context.Background(), stacks, graph, 4, display.Options{Color: colors.Never, Stdout: &stdout},
+		func(ctx context.Context, s backend.Stack, opts display.Options) (sdkDisplay.ResourceChanges, error) {
+			name := s.Ref().Name().String()
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			if name == "app" {
+				return nil, errors.New("update failed")
+			}
+			return nil, nil
+		})
+	assert.EqualError(t, err, "1 of 4 stacks failed: org/proj/app; skipped org/proj/web")
+	assert.Equal(t, []string{"network", "db", "app"}, order)
+	assert.Contains(t, stdout.String(), "org/proj/web: skipped because org/proj/app did not succeed")
+
+	graph.Dependencies = append(graph.Dependencies, cmdStack.StackDependency{
+		Stack: "org/proj/network", Upstream: "org/proj/web",
+	})
+	err = runMultiStack(context.Background(), stacks, graph, 4, display.Options{}, nil)
+	assert.ErrorContains(t, err, "stack references form a cycle")
+}
+
+func TestWarnStaleDependents(t *testing.T) {
+	t.Parallel()
+
+	b := newMultiStackBackend(t, "network")
+	stacks, err := (&multiStackArgs{Stacks: "*"}).Resolve(context.Background(), b, &workspace.Project{Name: "proj"})
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	warnStaleDependents(&out, &cmdStack.StackGraph{
+		Dependencies: []cmdStack.StackDependency{
+			{Stack: "org/proj/app", Upstream: "org/proj/network", Changed: []string{"vpcId"}},
+			{Stack: "org/proj/db", Upstream: "org/proj/network"},
+			{Stack: "org/proj/web", Upstream: "org/proj/app", Changed: []string{"url"}},
+		},
+	}, stacks)
+	assert.Equal(t, "warning: org/proj/app reads outputs of org/proj/network that have changed (vpcId); "+
+		"update org/proj/app to pick up the changes\n", out.String())
+}
+
+func TestRunMultiStackRequiresPositiveConcurrency(t *testing.T) {
+	t.Parallel()
+
+	err := runMultiStack(context.Background(), nil, nil, 0, display.Options{}, nil)
+	assert.ErrorContains(t, err, "--stack-concurrency must be positive")
+}
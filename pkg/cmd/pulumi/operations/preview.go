@@ -264,7 +264,7 @@ func NewPreviewCmd() *cobra.Command {
 	var policyPackPaths []string
 	var policyPackConfigPaths []string
 	var diffDisplay bool
-	var eventLogPath string
+	var eventLog eventLogArgs
 	var parallel int32
 	var refresh string
 	var runProgram bool
@@ -283,6 +283,7 @@ func NewPreviewCmd() *cobra.Command {
 	var targetDependents bool
 	var excludeDependents bool
 	var attachDebugger []string
+	var updateLock bool
 
 	// Flags for Copilot.
 	var copilotEnabled bool
@@ -337,9 +338,11 @@ func NewPreviewCmd() *cobra.Command {
 				IsInteractive:          cmdutil.Interactive(),
 				Type:                   displayType,
 				JSONDisplay:            jsonDisplay,
-				EventLogPath:           eventLogPath,
 				Debug:                  debug,
 			}
+			if err := eventLog.Apply(&displayOpts); err != nil {
+				return err
+			}
 
 			// we only suppress permalinks if the user passes true. the default is an empty string
 			// which we pass as 'false'
@@ -436,6 +439,12 @@ func NewPreviewCmd() *cobra.Command {
 				return fmt.Errorf("validating stack config: %w", configErr)
 			}
 
+			targets, excludes, replaces, targetReplaces, err := resolveUpdateTargetFilters(
+				ctx, s, targets, excludes, replaces, targetReplaces)
+			if err != nil {
+				return err
+			}
+
 			targetURNs := []string{}
 			targetURNs = append(targetURNs, targets...)
 
@@ -485,6 +494,9 @@ func NewPreviewCmd() *cobra.Command {
 					Experimental:   env.Experimental.Value(),
 					AttachDebugger: attachDebugger,
 					Autonamer:      autonamer,
+					// The lock isn't written by a preview, but the program may still use the versions it would be
+					// updated to.
+					UpdatePluginLock: updateLock,
 				},
 				Display: displayOpts,
 			}
@@ -604,12 +616,13 @@ func NewPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().StringArrayVarP(
 		&targets, "target", "t", []string{},
 		"Specify a single resource URN to update. Other resources will not be updated."+
-			" Multiple resources can be specified using --target urn1 --target urn2")
+			" Multiple resources can be specified using --target urn1 --target urn2."+
+			" Wildcards (*, **) and filter expressions (see 'pulumi stack query') are also supported")
 	cmd.PersistentFlags().StringArrayVarP(
 		&excludes, "exclude", "x", []string{},
 		"Specify a resource URN to ignore. These resources will not be updated."+
 			" Multiple resources can be specified using --exclude urn1 --exclude urn2."+
-			" Wildcards (*, **) are also supported")
+			" Wildcards (*, **) and filter expressions (see 'pulumi stack query') are also supported")
 	cmd.PersistentFlags().StringArrayVar(
 		&replaces, "replace", []string{},
 		"Specify resources to replace. Multiple resources can be specified using --replace urn1 --replace urn2")
@@ -682,6 +695,9 @@ func NewPreviewCmd() *cobra.Command {
 		&attachDebugger, "attach-debugger", []string{},
 		"Enable the ability to attach a debugger to the program and source based plugins being executed. Can limit debug type to 'program', 'plugins', 'plugin:<name>' or 'all'.")
 	cmd.Flag("attach-debugger").NoOptDefVal = "program"
+	cmd.PersistentFlags().BoolVar(
+		&updateLock, "update-lock", false,
+		"Allow the program to use plugin versions other than the ones locked in Pulumi.lock")
 
 	cmd.PersistentFlags().BoolVar(
 		&copilotEnabled, "copilot", false,
@@ -691,11 +707,7 @@ func NewPreviewCmd() *cobra.Command {
 	// Remote flags
 	remoteArgs.ApplyFlags(cmd)
 
-	if env.DebugCommands.Value() {
-		cmd.PersistentFlags().StringVar(
-			&eventLogPath, "event-log", "",
-			"Log events to a file at this path")
-	}
+	eventLog.ApplyFlags(cmd)
 
 	// internal flags
 	cmd.PersistentFlags().StringVar(&execKind, "exec-kind", "", "")
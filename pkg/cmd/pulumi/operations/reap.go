@@ -0,0 +1,369 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	cmdBackend "github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/backend"
+	"github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/config"
+	"github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/metadata"
+	cmdStack "github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/stack"
+	sdkDisplay "github.com/pulumi/pulumi/pkg/v3/display"
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/graph"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	pkgWorkspace "github.com/pulumi/pulumi/pkg/v3/workspace"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/env"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// NewReapCmd returns the `pulumi stack reap` command. It lives alongside the other operations as it runs a destroy,
+// and is added to `pulumi stack` when the command tree is built.
+func NewReapCmd() *cobra.Command {
+	var debug bool
+	var stackName string
+	var message string
+
+	// Flags for engine.UpdateOptions.
+	var diffDisplay bool
+	var eventLog eventLogArgs
+	var multiStack multiStackArgs
+	var parallel int32
+	var previewOnly bool
+	var refresh string
+	var showSames bool
+	var skipPreview bool
+	var suppressOutputs bool
+	var continueOnError bool
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "reap",
+		Args:  cmdutil.NoArgs,
+		Short: "Destroy the expired resources of one or more stacks",
+		Long: "Destroy the expired resources of one or more stacks.\n" +
+			"\n" +
+			"Resources can be given an expiry with the `expires` resource option, or for every\n" +
+			"resource in a stack with the `pulumi:expires` config setting, either as an RFC3339\n" +
+			"time or as a time-to-live such as `72h` or `7d` measured from when the resource was\n" +
+			"created. This command destroys each resource whose expiry has passed, along with\n" +
+			"every resource that depends on it, in dependency order.\n" +
+			"\n" +
+			"Protected resources are never destroyed. An expired resource that a protected resource\n" +
+			"depends on is kept, with a warning. Resources marked with `retainOnDelete` are removed\n" +
+			"from the stack but not deleted from the cloud provider.\n" +
+			"\n" +
+			"Use `--stacks` or `--stack-set` to reap several stacks of the current project at once.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			ssml := cmdStack.SecretsManagerLoader{FallbackToState: true}
+			ws := pkgWorkspace.Instance
+
+			yes = yes || skipPreview || env.SkipConfirmations.Value()
+			interactive := cmdutil.Interactive()
+			if !interactive && !yes && !previewOnly {
+				return errors.New("--yes or --skip-preview or --preview-only " +
+					"must be passed in to proceed when running in non-interactive mode")
+			}
+			if multiStack.Enabled() && !yes && !previewOnly {
+				return errors.New("--yes or --skip-preview or --preview-only must be passed in to reap multiple stacks")
+			}
+
+			opts, err := updateFlagsToOptions(interactive, skipPreview, yes, previewOnly)
+			if err != nil {
+				return err
+			}
+
+			displayType := display.DisplayProgress
+			if diffDisplay {
+				displayType = display.DisplayDiff
+			}
+			opts.Display = display.Options{
+				Color:             cmdutil.GetGlobalColorization(),
+				ShowSameResources: showSames,
+				SuppressOutputs:   suppressOutputs,
+				IsInteractive:     interactive,
+				Type:              displayType,
+				Debug:             debug,
+			}
+			if err := eventLog.Apply(&opts.Display); err != nil {
+				return err
+			}
+			opts.Engine = engine.UpdateOptions{
+				ParallelDiff:              env.ParallelDiff.Value(),
+				Parallel:                  parallel,
+				Debug:                     debug,
+				UseLegacyDiff:             env.EnableLegacyDiff.Value(),
+				UseLegacyRefreshDiff:      env.EnableLegacyRefreshDiff.Value(),
+				DisableProviderPreview:    env.DisableProviderPreview.Value(),
+				DisableResourceReferences: env.DisableResourceReferences.Value(),
+				DisableOutputValues:       env.DisableOutputValues.Value(),
+				Experimental:              env.Experimental.Value(),
+				ContinueOnError:           continueOnError,
+			}
+
+			r := &stackReaper{
+				ssml:    ssml,
+				ws:      ws,
+				opts:    opts,
+				refresh: refresh,
+				message: message,
+				now:     time.Now(),
+				cmd:     cmd,
+			}
+
+			if !multiStack.Enabled() {
+				s, err := cmdStack.RequireStack(ctx, cmdutil.Diag(), ws, cmdBackend.DefaultLoginManager,
+					stackName, cmdStack.LoadOnly, opts.Display)
+				if err != nil {
+					return err
+				}
+				_, err = r.reap(ctx, s, opts.Display)
+				return err
+			}
+
+			proj, _, err := ws.ReadProject()
+			if err != nil {
+				return err
+			}
+			b, err := cmdBackend.CurrentBackend(ctx, ws, cmdBackend.DefaultLoginManager, proj, opts.Display)
+			if err != nil {
+				return err
+			}
+			stacks, err := multiStack.Resolve(ctx, b, proj)
+			if err != nil {
+				return err
+			}
+			// Stacks are independent once their own resources have expired, so they're reaped without waiting on
+			// each other.
+			return runMultiStack(ctx, stacks, nil, multiStack.Concurrency, opts.Display, r.reap)
+		},
+	}
+
+	cmd.PersistentFlags().BoolVarP(
+		&debug, "debug", "d", false,
+		"Print detailed debugging output during resource operations")
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().StringVarP(
+		&message, "message", "m", "",
+		"Optional message to associate with the destroy operation")
+
+	cmd.PersistentFlags().BoolVar(
+		&diffDisplay, "diff", false,
+		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().Int32VarP(
+		&parallel, "parallel", "p", defaultParallel(),
+		"Allow P resource operations to run in parallel at once (1 for no parallelism).")
+	cmd.PersistentFlags().BoolVar(
+		&previewOnly, "preview-only", false,
+		"Only show a preview of the destroy, but don't perform the destroy itself")
+	cmd.PersistentFlags().StringVarP(
+		&refresh, "refresh", "r", "",
+		"Refresh the state of the stack's resources before this update")
+	cmd.PersistentFlags().Lookup("refresh").NoOptDefVal = "true"
+	cmd.PersistentFlags().BoolVar(
+		&showSames, "show-sames", false,
+		"Show resources that don't need to be updated because they haven't changed, alongside those that do")
+	cmd.PersistentFlags().BoolVarP(
+		&skipPreview, "skip-preview", "f", false,
+		"Do not calculate a preview before performing the destroy")
+	cmd.PersistentFlags().BoolVar(
+		&suppressOutputs, "suppress-outputs", false,
+		"Suppress display of stack outputs (in case they contain sensitive values)")
+	cmd.PersistentFlags().BoolVar(
+		&continueOnError, "continue-on-error", env.ContinueOnError.Value(),
+		"Continue to perform the destroy operation despite the occurrence of errors "+
+			"(can also be set with PULUMI_CONTINUE_ON_ERROR env var)")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false,
+		"Automatically approve and perform the destroy after previewing it")
+
+	multiStack.ApplyFlags(cmd)
+	eventLog.ApplyFlags(cmd)
+
+	return cmd
+}
+
+// stackReaper destroys the expired resources of stacks.
+type stackReaper struct {
+	ssml    cmdStack.SecretsManagerLoader
+	ws      pkgWorkspace.Context
+	opts    backend.UpdateOptions
+	refresh string
+	message string
+	// now is the time against which resource expiry is checked.
+	now time.Time
+	cmd *cobra.Command
+}
+
+// reap destroys the expired resources of a single stack, and the resources that depend on them.
+func (r *stackReaper) reap(
+	ctx context.Context, s backend.Stack, displayOpts display.Options,
+) (sdkDisplay.ResourceChanges, error) {
+	stdout := displayOpts.Stdout
+	if stdout == nil {
+		stdout = r.cmd.OutOrStdout()
+	}
+
+	snap, err := s.Snapshot(ctx, stack.DefaultSecretsProvider)
+	if err != nil {
+		return nil, err
+	}
+	var resources []*resource.State
+	if snap != nil {
+		resources = snap.Resources
+	}
+	targets, kept := expiredResources(resources, r.now)
+	for _, k := range kept {
+		cmdutil.Diag().Warningf(diag.Message(k.Expired,
+			"not destroying expired resource %s as protected resource %s depends on it"), k.Expired, k.Protected)
+	}
+	if len(targets) == 0 {
+		fmt.Fprintf(stdout, "No expired resources to destroy in stack %s\n", s.Ref())
+		return nil, nil
+	}
+
+	proj, root, err := readProjectForUpdate(r.ws, "")
+	if errors.Is(err, workspace.ErrProjectNotFound) {
+		projectName, _ := s.Ref().Project()
+		proj, root = &workspace.Project{Name: tokens.PackageName(projectName)}, ""
+	} else if err != nil {
+		return nil, err
+	}
+
+	// As with `pulumi destroy --stack`, the stack's config may not be available locally, so fall back to the config
+	// of its last update.
+	cfg, sm, err := config.GetStackConfigurationOrLatest(ctx, cmdutil.Diag(), r.ssml, s, proj)
+	if err != nil {
+		return nil, fmt.Errorf("getting stack configuration: %w", err)
+	}
+	if err := workspace.ValidateStackConfigAndApplyProjectConfig(ctx, s.Ref().Name().String(), proj,
+		cfg.Environment, cfg.Config, sm.Encrypter(), sm.Decrypter()); err != nil {
+		return nil, fmt.Errorf("validating stack config: %w", err)
+	}
+
+	m, err := metadata.GetUpdateMetadata(r.message, root, "", "", false, cfg, r.cmd.Flags())
+	if err != nil {
+		return nil, fmt.Errorf("gathering environment metadata: %w", err)
+	}
+
+	refreshOption, err := getRefreshOption(proj, r.refresh)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := r.opts
+	opts.Display = displayOpts
+	opts.Engine.Refresh = refreshOption
+	opts.Engine.Targets = deploy.NewUrnTargetsFromUrns(targets)
+
+	changes, err := backend.DestroyStack(ctx, s, backend.UpdateOperation{
+		Proj:               proj,
+		Root:               root,
+		M:                  m,
+		Opts:               opts,
+		StackConfiguration: cfg,
+		SecretsManager:     sm,
+		SecretsProvider:    stack.DefaultSecretsProvider,
+		Scopes:             backend.CancellationScopes,
+	})
+	if err == context.Canceled {
+		return changes, errors.New("reap cancelled")
+	}
+	return changes, err
+}
+
+// keptResource is an expired resource that can't be destroyed because a protected resource depends on it.
+type keptResource struct {
+	Expired   resource.URN
+	Protected resource.URN
+}
+
+// expiredResources returns the URNs of the resources that have expired as of now, together with every resource that
+// depends on them, in the order they appear in the given topologically sorted resources. Expired resources that a
+// protected resource depends on, directly or indirectly, are kept and returned in kept, and only the dependents of the
+// expired resources that are removed are destroyed.
+func expiredResources(resources []*resource.State, now time.Time) ([]resource.URN, []keptResource) {
+	dg := graph.NewDependencyGraph(resources)
+
+	dependentsOf := func(expired mapset.Set[*resource.State]) mapset.Set[*resource.State] {
+		doomed := mapset.NewSet[*resource.State]()
+		for _, r := range expired.ToSlice() {
+			doomed.Add(r)
+			for _, d := range dg.DependingOn(r, nil, true) {
+				doomed.Add(d)
+			}
+		}
+		return doomed
+	}
+
+	expired := mapset.NewSet[*resource.State]()
+	for _, r := range resources {
+		if !r.Delete && r.Expires != nil && !r.Expires.After(now) {
+			expired.Add(r)
+		}
+	}
+
+	// Every protected resource that would be destroyed keeps the expired resources it depends on. Removing the rest
+	// can't doom a protected resource, since any expired resource it depends on is kept.
+	var kept []keptResource
+	removed := expired.Clone()
+	affected := dependentsOf(expired)
+	for _, r := range resources {
+		if !r.Protect || !affected.Contains(r) {
+			continue
+		}
+		spared := dg.TransitiveDependenciesOf(r)
+		spared.Add(r)
+		for _, s := range spared.ToSlice() {
+			if expired.Contains(s) {
+				kept = append(kept, keptResource{Expired: s.URN, Protected: r.URN})
+				removed.Remove(s)
+			}
+		}
+	}
+	doomed := dependentsOf(removed)
+	sort.Slice(kept, func(i, j int) bool {
+		if kept[i].Expired != kept[j].Expired {
+			return kept[i].Expired < kept[j].Expired
+		}
+		return kept[i].Protected < kept[j].Protected
+	})
+
+	var targets []resource.URN
+	for _, r := range resources {
+		if doomed.Contains(r) {
+			targets = append(targets, r.URN)
+		}
+	}
+	return targets, kept
+}
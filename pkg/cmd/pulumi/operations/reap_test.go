@@ -0,0 +1,83 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+func TestExpiredResources(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	urn := func(name string) resource.URN {
+		return resource.NewURN("dev", "proj", "", "test:index:Resource", name)
+	}
+	provider := &resource.State{
+		URN:    "urn:pulumi:dev::proj::pulumi:providers:test::default",
+		Type:   "pulumi:providers:test",
+		Custom: true,
+		ID:     "provider",
+	}
+	ref := string(provider.URN) + "::provider"
+	newResource := func(name string, expires *time.Time, deps ...string) *resource.State {
+		r := &resource.State{
+			URN:      urn(name),
+			Type:     "test:index:Resource",
+			Custom:   true,
+			Provider: ref,
+			Expires:  expires,
+		}
+		for _, d := range deps {
+			r.Dependencies = append(r.Dependencies, urn(d))
+		}
+		return r
+	}
+
+	network := newResource("network", &past)
+	server := newResource("server", nil, "network")
+	disk := newResource("disk", &future, "server")
+	bucket := newResource("bucket", &past)
+	database := newResource("database", &past)
+	backup := newResource("backup", nil, "database")
+	backup.Protect = true
+	keep := newResource("keep", &future)
+
+	targets, kept := expiredResources(
+		[]*resource.State{provider, network, server, disk, bucket, database, backup, keep}, now)
+	assert.Equal(t, []resource.URN{urn("network"), urn("server"), urn("disk"), urn("bucket")}, targets)
+	assert.Equal(t, []keptResource{{Expired: urn("database"), Protected: urn("backup")}}, kept)
+
+	// A protected resource keeps the expired resource it depends on, and so nothing that depends on the protected
+	// resource is destroyed either.
+	cluster := newResource("cluster", &past)
+	node := newResource("node", nil, "cluster")
+	node.Protect = true
+	workload := newResource("workload", nil, "node")
+	targets, kept = expiredResources([]*resource.State{provider, cluster, node, workload, bucket}, now)
+	assert.Equal(t, []resource.URN{urn("bucket")}, targets)
+	assert.Equal(t, []keptResource{{Expired: urn("cluster"), Protected: urn("node")}}, kept)
+
+	targets, kept = expiredResources([]*resource.State{provider, keep}, now)
+	assert.Empty(t, targets)
+	assert.Empty(t, kept)
+}
@@ -61,8 +61,10 @@ func NewRefreshCmd() *cobra.Command {
 	// Flags for engine.UpdateOptions.
 	var jsonDisplay bool
 	var diffDisplay bool
-	var eventLogPath string
+	var eventLog eventLogArgs
 	var parallel int32
+	var policyPackPaths []string
+	var policyPackConfigPaths []string
 	var previewOnly bool
 	var showConfig bool
 	var showReplacementSteps bool
@@ -125,6 +127,10 @@ func NewRefreshCmd() *cobra.Command {
 				return err
 			}
 
+			if err = validatePolicyPackConfig(policyPackPaths, policyPackConfigPaths); err != nil {
+				return err
+			}
+
 			displayType := display.DisplayProgress
 			if diffDisplay {
 				displayType = display.DisplayDiff
@@ -139,10 +145,12 @@ func NewRefreshCmd() *cobra.Command {
 				SuppressProgress:     suppressProgress,
 				IsInteractive:        interactive,
 				Type:                 displayType,
-				EventLogPath:         eventLogPath,
 				Debug:                debug,
 				JSONDisplay:          jsonDisplay,
 			}
+			if err := eventLog.Apply(&opts.Display); err != nil {
+				return err
+			}
 
 			// we only suppress permalinks if the user passes true. the default is an empty string
 			// which we pass as 'false'
@@ -153,8 +161,8 @@ func NewRefreshCmd() *cobra.Command {
 			}
 
 			if remoteArgs.Remote {
-				err = deployment.ValidateUnsupportedRemoteFlags(expectNop, nil, false, client, jsonDisplay, nil,
-					nil, "", showConfig, false, showReplacementSteps, showSames, false,
+				err = deployment.ValidateUnsupportedRemoteFlags(expectNop, nil, false, client, jsonDisplay, policyPackPaths,
+					policyPackConfigPaths, "", showConfig, false, showReplacementSteps, showSames, false,
 					suppressOutputs, "default", targets, nil, nil, nil,
 					false, "", cmdStack.ConfigFile, runProgram)
 				if err != nil {
@@ -279,13 +287,18 @@ func NewRefreshCmd() *cobra.Command {
 				}
 			}
 
-			targetUrns := []string{}
-			targetUrns = append(targetUrns, *targets...)
+			targetUrns, err := resolveTargetFilters(ctx, s, "target", *targets, true)
+			if err != nil {
+				return err
+			}
 
-			excludeUrns := []string{}
-			excludeUrns = append(excludeUrns, *excludes...)
+			excludeUrns, err := resolveTargetFilters(ctx, s, "exclude", *excludes, false)
+			if err != nil {
+				return err
+			}
 
 			opts.Engine = engine.UpdateOptions{
+				LocalPolicyPacks:          engine.MakeLocalPolicyPacks(policyPackPaths, policyPackConfigPaths),
 				ParallelDiff:              env.ParallelDiff.Value(),
 				Parallel:                  parallel,
 				Debug:                     debug,
@@ -303,7 +316,7 @@ func NewRefreshCmd() *cobra.Command {
 				RefreshProgram:            runProgram,
 			}
 
-			changes, err := backend.RefreshStack(ctx, s, backend.UpdateOperation{
+			op := backend.UpdateOperation{
 				Proj:               proj,
 				Root:               root,
 				M:                  m,
@@ -312,7 +325,10 @@ func NewRefreshCmd() *cobra.Command {
 				SecretsManager:     sm,
 				SecretsProvider:    stack.DefaultSecretsProvider,
 				Scopes:             backend.CancellationScopes,
-			})
+			}
+			events, remediations := collectRemediations()
+			changes, err := backend.RefreshStack(ctx, s, op, events)
+			remediated := remediations()
 
 			switch {
 			case err == context.Canceled:
@@ -321,8 +337,12 @@ func NewRefreshCmd() *cobra.Command {
 				return err
 			case expectNop && changes != nil && engine.HasChanges(changes):
 				return errors.New("no changes were expected but changes occurred")
-			default:
+			case previewOnly || jsonDisplay:
 				return nil
+			default:
+				// Resources that are out of compliance with a remediation policy are only reported by the refresh, so
+				// offer to run an update to apply the remediations.
+				return offerRemediationUpdate(ctx, s, op, remediated, yes)
 			}
 		},
 	}
@@ -350,12 +370,13 @@ func NewRefreshCmd() *cobra.Command {
 
 	targets = cmd.PersistentFlags().StringArrayP(
 		"target", "t", []string{},
-		"Specify a single resource URN to refresh. Multiple resource can be specified using: --target urn1 --target urn2")
+		"Specify a single resource URN to refresh. Multiple resource can be specified using: --target urn1 --target urn2."+
+			" Wildcards (*, **) and filter expressions (see 'pulumi stack query') are also supported")
 	excludes = cmd.PersistentFlags().StringArrayP(
 		"exclude", "x", []string{},
 		"Specify a resource URN to ignore. These resources will not be refreshed."+
 			" Multiple resources can be specified using --exclude urn1 --exclude urn2."+
-			" Wildcards (*, **) are also supported")
+			" Wildcards (*, **) and filter expressions (see 'pulumi stack query') are also supported")
 
 	// Flags for engine.UpdateOptions.
 	cmd.PersistentFlags().BoolVar(
@@ -367,6 +388,13 @@ func NewRefreshCmd() *cobra.Command {
 	cmd.PersistentFlags().Int32VarP(
 		&parallel, "parallel", "p", defaultParallel(),
 		"Allow P resource operations to run in parallel at once (1 for no parallelism).")
+	cmd.PersistentFlags().StringSliceVar(
+		&policyPackPaths, "policy-pack", []string{},
+		"Run one or more policy packs against the refreshed resources. If any resources need remediating, "+
+			"an update targeting them is offered afterwards, or run without asking with --yes")
+	cmd.PersistentFlags().StringSliceVar(
+		&policyPackConfigPaths, "policy-pack-config", []string{},
+		`Path to JSON file containing the config for the policy pack of the corresponding "--policy-pack" flag`)
 	cmd.PersistentFlags().BoolVar(
 		&previewOnly, "preview-only", false,
 		"Only show a preview of the refresh, but don't perform the refresh itself")
@@ -421,11 +449,7 @@ func NewRefreshCmd() *cobra.Command {
 	// Remote flags
 	remoteArgs.ApplyFlags(cmd)
 
-	if env.DebugCommands.Value() {
-		cmd.PersistentFlags().StringVar(
-			&eventLogPath, "event-log", "",
-			"Log events to a file at this path")
-	}
+	eventLog.ApplyFlags(cmd)
 
 	// internal flags
 	cmd.PersistentFlags().StringVar(&execKind, "exec-kind", "", "")
@@ -0,0 +1,108 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/ui"
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+// collectRemediations returns a channel of engine events, and a function that returns the resources those events
+// reported as out of compliance with a policy that remediates them, in the order they were first reported. The
+// function must only be called once the operation sending the events has finished, and closes the channel.
+func collectRemediations() (chan<- engine.Event, func() []resource.URN) {
+	events := make(chan engine.Event)
+	done := make(chan struct{})
+	var urns []resource.URN
+	go func() {
+		defer close(done)
+		seen := map[resource.URN]bool{}
+		for e := range events {
+			if e.Type != engine.PolicyViolationEvent {
+				continue
+			}
+			p := e.Payload().(engine.PolicyViolationEventPayload)
+			if p.EnforcementLevel == apitype.Remediate && !seen[p.ResourceURN] {
+				seen[p.ResourceURN] = true
+				urns = append(urns, p.ResourceURN)
+			}
+		}
+	}()
+
+	return events, func() []resource.URN {
+		close(events)
+		<-done
+		return urns
+	}
+}
+
+// remediationCommand returns the `pulumi up` command that applies the remediations for the given resources.
+func remediationCommand(s backend.Stack, urns []resource.URN) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "pulumi up --stack %s", s.Ref())
+	for _, urn := range urns {
+		fmt.Fprintf(&sb, " --target '%s'", urn)
+	}
+	return sb.String()
+}
+
+// offerRemediationUpdate offers to run an update targeting the given resources, which a refresh found to be out of
+// compliance with policies that remediate them, so that the remediations are applied. If yes is true, the update is
+// run without asking first. The update is made with the refresh's options, so it is checked against the same policy
+// packs.
+func offerRemediationUpdate(
+	ctx context.Context, s backend.Stack, op backend.UpdateOperation, urns []resource.URN, yes bool,
+) error {
+	if len(urns) == 0 {
+		return nil
+	}
+
+	prompt := fmt.Sprintf("%d resource(s) are out of compliance with policies that remediate them. "+
+		"An update targeting them will apply the remediations.", len(urns))
+	if yes {
+		fmt.Printf("%s\n\n", prompt)
+	} else if !ui.ConfirmPrompt(prompt, "yes", op.Opts.Display) {
+		fmt.Printf("To apply the remediations later, run:\n    %s\n", remediationCommand(s, urns))
+		return nil
+	}
+
+	op.Opts.Engine.Targets = deploy.NewUrnTargetsFromUrns(urns)
+	op.Opts.Engine.Excludes = deploy.NewUrnTargets(nil)
+	op.Opts.Engine.TargetDependents = false
+	op.Opts.Engine.ExcludeDependents = false
+	op.Opts.Engine.RefreshProgram = false
+	_, err := backend.UpdateStack(ctx, s, op, nil)
+	return err
+}
+
+// reportImportRemediations tells the user how to apply the remediations for the given resources, which an import found
+// to be out of compliance with policies that remediate them. The update isn't offered here as it is after a refresh,
+// since until the imported resources are declared by the program, a targeted update would delete them.
+func reportImportRemediations(s backend.Stack, urns []resource.URN) {
+	if len(urns) == 0 {
+		return
+	}
+	fmt.Printf("%d imported resource(s) are out of compliance with policies that remediate them. Once they are "+
+		"declared by your program, run the following to apply the remediations:\n    %s\n\n",
+		len(urns), remediationCommand(s, urns))
+}
@@ -0,0 +1,66 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+func TestCollectRemediations(t *testing.T) {
+	t.Parallel()
+
+	a := resource.URN("urn:pulumi:dev::proj::pkg:index:type::a")
+	b := resource.URN("urn:pulumi:dev::proj::pkg:index:type::b")
+	c := resource.URN("urn:pulumi:dev::proj::pkg:index:type::c")
+
+	events, remediations := collectRemediations()
+	for _, p := range []engine.PolicyViolationEventPayload{
+		{ResourceURN: b, EnforcementLevel: apitype.Remediate},
+		{ResourceURN: c, EnforcementLevel: apitype.Advisory},
+		{ResourceURN: a, EnforcementLevel: apitype.Remediate},
+		{ResourceURN: b, EnforcementLevel: apitype.Remediate},
+	} {
+		events <- engine.NewEvent(p)
+	}
+	events <- engine.NewEvent(engine.SummaryEventPayload{})
+
+	// Only remediate-level violations are collected, once per resource, in the order they were reported.
+	assert.Equal(t, []resource.URN{b, a}, remediations())
+}
+
+func TestRemediationCommand(t *testing.T) {
+	t.Parallel()
+
+	s := &backend.MockStack{
+		RefF: func() backend.StackReference {
+			return &backend.MockStackReference{StringV: "org/proj/dev"}
+		},
+	}
+	urns := []resource.URN{
+		"urn:pulumi:dev::proj::pkg:index:type::a",
+		"urn:pulumi:dev::proj::pkg:index:type::b",
+	}
+	assert.Equal(t,
+		"pulumi up --stack org/proj/dev --target 'urn:pulumi:dev::proj::pkg:index:type::a' "+
+			"--target 'urn:pulumi:dev::proj::pkg:index:type::b'",
+		remediationCommand(s, urns))
+}
@@ -37,12 +37,14 @@ import (
 	cmdStack "github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/stack"
 	cmdTemplates "github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/templates"
 	"github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/ui"
+	sdkDisplay "github.com/pulumi/pulumi/pkg/v3/display"
 	"github.com/pulumi/pulumi/pkg/v3/engine"
 	"github.com/pulumi/pulumi/pkg/v3/resource/autonaming"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
 	pkgWorkspace "github.com/pulumi/pulumi/pkg/v3/workspace"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/env"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
@@ -93,7 +95,8 @@ func NewUpCmd() *cobra.Command {
 	var policyPackPaths []string
 	var policyPackConfigPaths []string
 	var diffDisplay bool
-	var eventLogPath string
+	var eventLog eventLogArgs
+	var multiStack multiStackArgs
 	var parallel int32
 	var refresh string
 	var runProgram bool
@@ -104,10 +107,12 @@ func NewUpCmd() *cobra.Command {
 	var showSecrets bool
 	var showReads bool
 	var skipPreview bool
+	var aliasRenames bool
 	var showFullOutput bool
 	var suppressOutputs bool
 	var suppressProgress bool
 	var continueOnError bool
+	var updateLock bool
 	var suppressPermalink string
 	var yes bool
 	var secretsProvider string
@@ -123,46 +128,30 @@ func NewUpCmd() *cobra.Command {
 	// Flags for Copilot.
 	var copilotEnabled bool
 
-	// up implementation used when the source of the Pulumi program is in the current working directory.
-	upWorkingDirectory := func(
+	// upStack updates the given stack using the Pulumi program in the current working directory. Any config values
+	// passed via flags must already have been saved. Diagnostics are written to sink.
+	upStack := func(
 		ctx context.Context,
+		sink diag.Sink,
 		ssml cmdStack.SecretsManagerLoader,
 		ws pkgWorkspace.Context,
-		lm cmdBackend.LoginManager,
+		s backend.Stack,
 		opts backend.UpdateOptions,
 		cmd *cobra.Command,
-	) error {
-		s, err := cmdStack.RequireStack(
-			ctx,
-			cmdutil.Diag(),
-			ws,
-			lm,
-			stackName,
-			cmdStack.OfferNew,
-			opts.Display,
-		)
-		if err != nil {
-			return err
-		}
-
-		// Save any config values passed via flags.
-		if err := parseAndSaveConfigArray(ctx, cmdutil.Diag(), ws, s, configArray, path); err != nil {
-			return err
-		}
-
+	) (sdkDisplay.ResourceChanges, error) {
 		proj, root, err := readProjectForUpdate(ws, client)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		cfg, sm, err := cmdConfig.GetStackConfiguration(ctx, cmdutil.Diag(), ssml, s, proj)
+		cfg, sm, err := cmdConfig.GetStackConfiguration(ctx, sink, ssml, s, proj)
 		if err != nil {
-			return fmt.Errorf("getting stack configuration: %w", err)
+			return nil, fmt.Errorf("getting stack configuration: %w", err)
 		}
 
 		m, err := metadata.GetUpdateMetadata(message, root, execKind, execAgent, planFilePath != "", cfg, cmd.Flags())
 		if err != nil {
-			return fmt.Errorf("gathering environment metadata: %w", err)
+			return nil, fmt.Errorf("gathering environment metadata: %w", err)
 		}
 
 		decrypter := sm.Decrypter()
@@ -178,7 +167,13 @@ func NewUpCmd() *cobra.Command {
 			encrypter,
 			decrypter)
 		if configErr != nil {
-			return fmt.Errorf("validating stack config: %w", configErr)
+			return nil, fmt.Errorf("validating stack config: %w", configErr)
+		}
+
+		targets, excludes, replaces, targetReplaces, err := resolveUpdateTargetFilters(
+			ctx, s, targets, excludes, replaces, targetReplaces)
+		if err != nil {
+			return nil, err
 		}
 
 		targetURNs, replaceURNs, excludeURNs := []string{}, []string{}, []string{}
@@ -193,12 +188,12 @@ func NewUpCmd() *cobra.Command {
 
 		refreshOption, err := getRefreshOption(proj, refresh)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		autonamer, err := autonaming.ParseAutonamingConfig(autonamingStackContext(proj, s), cfg.Config, decrypter)
 		if err != nil {
-			return fmt.Errorf("getting autonaming config: %w", err)
+			return nil, fmt.Errorf("getting autonaming config: %w", err)
 		}
 
 		opts.Engine = engine.UpdateOptions{
@@ -221,18 +216,20 @@ func NewUpCmd() *cobra.Command {
 			ExcludeDependents:         excludeDependents,
 			// Trigger a plan to be generated during the preview phase which can be constrained to during the
 			// update phase.
-			GeneratePlan:    true,
-			Experimental:    env.Experimental.Value(),
-			ContinueOnError: continueOnError,
-			AttachDebugger:  attachDebugger,
-			Autonamer:       autonamer,
+			GeneratePlan:     true,
+			Experimental:     env.Experimental.Value(),
+			ContinueOnError:  continueOnError,
+			AttachDebugger:   attachDebugger,
+			Autonamer:        autonamer,
+			WritePluginLock:  true,
+			UpdatePluginLock: updateLock,
 		}
 
 		if planFilePath != "" {
 			dec := sm.Decrypter()
 			p, err := plan.Read(planFilePath, dec)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			opts.Engine.Plan = p
 		}
@@ -249,14 +246,110 @@ func NewUpCmd() *cobra.Command {
 		}, nil /* events */)
 		switch {
 		case err == context.Canceled:
-			return errors.New("update cancelled")
+			return changes, errors.New("update cancelled")
 		case err != nil:
-			return err
+			return changes, err
 		case expectNop && changes != nil && engine.HasChanges(changes):
-			return errors.New("no changes were expected but changes occurred")
+			return changes, errors.New("no changes were expected but changes occurred")
 		default:
-			return nil
+			return changes, nil
+		}
+	}
+
+	// up implementation used when the source of the Pulumi program is in the current working directory.
+	upWorkingDirectory := func(
+		ctx context.Context,
+		ssml cmdStack.SecretsManagerLoader,
+		ws pkgWorkspace.Context,
+		lm cmdBackend.LoginManager,
+		opts backend.UpdateOptions,
+		cmd *cobra.Command,
+	) error {
+		s, err := cmdStack.RequireStack(
+			ctx,
+			cmdutil.Diag(),
+			ws,
+			lm,
+			stackName,
+			cmdStack.OfferNew,
+			opts.Display,
+		)
+		if err != nil {
+			return err
+		}
+
+		// Save any config values passed via flags.
+		if err := parseAndSaveConfigArray(ctx, cmdutil.Diag(), ws, s, configArray, path); err != nil {
+			return err
+		}
+
+		_, err = upStack(ctx, cmdutil.Diag(), ssml, ws, s, opts, cmd)
+		return err
+	}
+
+	// up implementation used when updating several stacks of the project in the current working directory at once.
+	upMultiStack := func(
+		ctx context.Context,
+		ssml cmdStack.SecretsManagerLoader,
+		ws pkgWorkspace.Context,
+		lm cmdBackend.LoginManager,
+		opts backend.UpdateOptions,
+		cmd *cobra.Command,
+	) error {
+		proj, _, err := ws.ReadProject()
+		if err != nil {
+			return err
+		}
+
+		// All of the updates share a single backend, and with it the backend's connection and credentials.
+		b, err := cmdBackend.CurrentBackend(ctx, ws, lm, proj, opts.Display)
+		if err != nil {
+			return err
+		}
+
+		stacks, err := multiStack.Resolve(ctx, b, proj)
+		if err != nil {
+			return err
+		}
+
+		// Stacks that read the outputs of other stacks through stack references are updated after them.
+		graph, err := cmdStack.LoadStackGraph(ctx, b, stacks)
+		if err != nil {
+			return fmt.Errorf("loading stack references: %w", err)
+		}
+
+		// Save any config values passed via flags before starting the updates, so that the workspace is only ever
+		// modified by one stack at a time.
+		for _, s := range stacks {
+			if err := parseAndSaveConfigArray(ctx, cmdutil.Diag(), ws, s, configArray, path); err != nil {
+				return fmt.Errorf("%s: %w", s.Ref(), err)
+			}
+		}
+
+		err = runMultiStack(ctx, stacks, graph, multiStack.Concurrency, opts.Display,
+			func(ctx context.Context, s backend.Stack, displayOpts display.Options) (sdkDisplay.ResourceChanges, error) {
+				stackOpts := opts
+				stackOpts.Display = displayOpts
+				// Diagnostics are written through the stack's prefixed display writers, rather than the global sink,
+				// so that they can be attributed to the stack.
+				sink := diag.DefaultSink(displayOpts.Stdout, displayOpts.Stderr, diag.FormatOptions{
+					Color: displayOpts.Color,
+				})
+				return upStack(ctx, sink, ssml, ws, s, stackOpts, cmd)
+			})
+
+		// Point out any stacks of the project that weren't updated but read outputs that the updates changed.
+		projectName := string(proj.Name)
+		all, listErr := cmdStack.ListStacks(ctx, b, backend.ListStackNamesFilter{Project: &projectName})
+		if listErr == nil {
+			if graph, listErr = cmdStack.LoadStackGraph(ctx, b, all); listErr == nil {
+				warnStaleDependents(cmd.ErrOrStderr(), graph, stacks)
+			}
 		}
+		if listErr != nil {
+			logging.V(7).Infof("checking for stale stack references: %v", listErr)
+		}
+		return err
 	}
 
 	// up implementation used when the source of the Pulumi program is a template name or a URL to a template.
@@ -457,8 +550,10 @@ func NewUpCmd() *cobra.Command {
 			ShowSecrets:      showSecrets,
 			// If we're in experimental mode then we trigger a plan to be generated during the preview phase
 			// which will be constrained to during the update phase.
-			GeneratePlan: env.Experimental.Value(),
-			Experimental: env.Experimental.Value(),
+			GeneratePlan:     env.Experimental.Value(),
+			Experimental:     env.Experimental.Value(),
+			WritePluginLock:  true,
+			UpdatePluginLock: updateLock,
 
 			UseLegacyRefreshDiff: env.EnableLegacyRefreshDiff.Value(),
 			ContinueOnError:      continueOnError,
@@ -508,7 +603,14 @@ func NewUpCmd() *cobra.Command {
 			"afterwards so that the stack may be updated incrementally again later on.\n" +
 			"\n" +
 			"The program to run is loaded from the project in the current directory by default. Use the `-C` or\n" +
-			"`--cwd` flag to use a different directory.",
+			"`--cwd` flag to use a different directory.\n" +
+			"\n" +
+			"Several stacks of the project can be updated at once by passing a glob such as `--stacks 'prod-*'`,\n" +
+			"or a file listing the stacks with `--stack-set`. Up to `--stack-concurrency` stacks are updated\n" +
+			"concurrently, their output is interleaved with each line prefixed by the stack name, and a summary\n" +
+			"of every update is shown at the end. Stacks that read the outputs of other selected stacks through\n" +
+			"stack references are only updated once those stacks have been updated successfully. The command\n" +
+			"fails if any of the updates failed.",
 		Args: cmdutil.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
@@ -520,6 +622,11 @@ func NewUpCmd() *cobra.Command {
 				skipPreview = true
 			}
 
+			if aliasRenames && skipPreview {
+				return errors.New("--alias-renames cannot be used with --skip-preview, since renames are detected " +
+					"during the preview")
+			}
+
 			yes = yes || skipPreview || env.SkipConfirmations.Value()
 
 			interactive := cmdutil.Interactive()
@@ -538,6 +645,7 @@ func NewUpCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			opts.AliasRenames = aliasRenames
 
 			if err = validatePolicyPackConfig(policyPackPaths, policyPackConfigPaths); err != nil {
 				return err
@@ -560,11 +668,13 @@ func NewUpCmd() *cobra.Command {
 				TruncateOutput:         !showFullOutput,
 				IsInteractive:          interactive,
 				Type:                   displayType,
-				EventLogPath:           eventLogPath,
 				Debug:                  debug,
 				JSONDisplay:            jsonDisplay,
 				ShowSecrets:            showSecrets,
 			}
+			if err := eventLog.Apply(&opts.Display); err != nil {
+				return err
+			}
 
 			// we only suppress permalinks if the user passes true. the default is an empty string
 			// which we pass as 'false'
@@ -574,6 +684,19 @@ func NewUpCmd() *cobra.Command {
 				opts.Display.SuppressPermalink = false
 			}
 
+			if multiStack.Enabled() {
+				// Prompts from concurrent updates can't be answered, so every update must be approved up front.
+				if !yes {
+					return errors.New("--yes or --skip-preview must be passed in to update multiple stacks")
+				}
+				if remoteArgs.Remote {
+					return errors.New("--stacks and --stack-set cannot be used with --remote")
+				}
+				if jsonDisplay {
+					return errors.New("--stacks and --stack-set cannot be used with --json")
+				}
+			}
+
 			if remoteArgs.Remote {
 				err = deployment.ValidateUnsupportedRemoteFlags(expectNop, configArray, path, client, jsonDisplay, policyPackPaths,
 					policyPackConfigPaths, refresh, showConfig, showPolicyRemediations, showReplacementSteps, showSames,
@@ -613,6 +736,20 @@ func NewUpCmd() *cobra.Command {
 
 			configureCopilotOptions(copilotEnabled, cmd, &opts.Display, isDIYBackend)
 
+			if multiStack.Enabled() {
+				if len(args) > 0 {
+					return errors.New("--stacks and --stack-set cannot be used with a template")
+				}
+				return upMultiStack(
+					ctx,
+					ssml,
+					ws,
+					cmdBackend.DefaultLoginManager,
+					opts,
+					cmd,
+				)
+			}
+
 			if len(args) > 0 {
 				return upTemplateNameOrURL(
 					ctx,
@@ -671,16 +808,16 @@ func NewUpCmd() *cobra.Command {
 		&targets, "target", "t", []string{},
 		"Specify a single resource URN to update. Other resources will not be updated."+
 			" Multiple resources can be specified using --target urn1 --target urn2."+
-			" Wildcards (*, **) are also supported")
+			" Wildcards (*, **) and filter expressions (see 'pulumi stack query') are also supported")
 	cmd.PersistentFlags().StringArrayVar(
 		&excludes, "exclude", []string{},
 		"Specify a resource URN to ignore. These resources will not be updated."+
 			" Multiple resources can be specified using --exclude urn1 --exclude urn2."+
-			" Wildcards (*, **) are also supported")
+			" Wildcards (*, **) and filter expressions (see 'pulumi stack query') are also supported")
 	cmd.PersistentFlags().StringArrayVar(
 		&replaces, "replace", []string{},
 		"Specify a single resource URN to replace. Multiple resources can be specified using --replace urn1 --replace urn2."+
-			" Wildcards (*, **) are also supported")
+			" Wildcards (*, **) and filter expressions (see 'pulumi stack query') are also supported")
 	cmd.PersistentFlags().StringArrayVar(
 		&targetReplaces, "target-replace", []string{},
 		"Specify a single resource URN to replace. Other resources will not be updated."+
@@ -742,6 +879,11 @@ func NewUpCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVarP(
 		&skipPreview, "skip-preview", "f", false,
 		"Do not calculate a preview before performing the update")
+	cmd.PersistentFlags().BoolVar(
+		&aliasRenames, "alias-renames", false,
+		"Treat resources that the preview suggests have been renamed as aliases of the resources they replace, "+
+			"so that they are updated in place rather than replaced. If the preview finds renames, it is run again "+
+			"with them aliased, and that is the preview shown for confirmation")
 	cmd.PersistentFlags().BoolVar(
 		&suppressOutputs, "suppress-outputs", false,
 		"Suppress display of stack outputs (in case they contain sensitive values)")
@@ -762,6 +904,9 @@ func NewUpCmd() *cobra.Command {
 		&continueOnError, "continue-on-error", env.ContinueOnError.Value(),
 		"Continue updating resources even if an error is encountered "+
 			"(can also be set with PULUMI_CONTINUE_ON_ERROR environment variable)")
+	cmd.PersistentFlags().BoolVar(
+		&updateLock, "update-lock", false,
+		"Allow the program to use plugin versions other than the ones locked in Pulumi.lock, and update the lock")
 	//nolint:lll // long description
 	cmd.PersistentFlags().StringArrayVar(
 		&attachDebugger, "attach-debugger", []string{},
@@ -788,11 +933,14 @@ func NewUpCmd() *cobra.Command {
 	// Remote flags
 	remoteArgs.ApplyFlags(cmd)
 
-	if env.DebugCommands.Value() {
-		cmd.PersistentFlags().StringVar(
-			&eventLogPath, "event-log", "",
-			"Log events to a file at this path")
-	}
+	eventLog.ApplyFlags(cmd)
+
+	multiStack.ApplyFlags(cmd)
+	cmd.MarkFlagsMutuallyExclusive("stack", "stacks", "stack-set")
+	cmd.MarkFlagsMutuallyExclusive("config-file", "stacks", "stack-set")
+	cmd.MarkFlagsMutuallyExclusive("plan", "stacks", "stack-set")
+	// A plan is made without the aliases that --alias-renames adds, so the update would not match it.
+	cmd.MarkFlagsMutuallyExclusive("plan", "alias-renames")
 
 	// internal flags
 	cmd.PersistentFlags().StringVar(&execKind, "exec-kind", "", "")
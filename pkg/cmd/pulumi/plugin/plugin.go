@@ -48,6 +48,7 @@ func NewPluginCmd() *cobra.Command {
 
 	cmd.AddCommand(newPluginInstallCmd())
 	cmd.AddCommand(newPluginLsCmd())
+	cmd.AddCommand(newPluginMirrorCmd())
 	cmd.AddCommand(newPluginRmCmd())
 	cmd.AddCommand(newPluginRunCmd())
 
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"runtime"
 	"time"
 
@@ -57,7 +58,11 @@ func newPluginInstallCmd() *cobra.Command {
 			"\n" +
 			"If VERSION is specified, it cannot be a range; it must be a specific number.\n" +
 			"If VERSION is unspecified, Pulumi will attempt to look up the latest version of\n" +
-			"the plugin, though the result is not guaranteed.",
+			"the plugin, though the result is not guaranteed.\n" +
+			"\n" +
+			"If the current project has a Pulumi.lock file, plugins without a VERSION are\n" +
+			"installed at their locked version, and downloads are checked against the locked\n" +
+			"download URL and checksums.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			return picmd.Run(ctx, args)
@@ -99,6 +104,17 @@ type pluginInstallCmd struct {
 		install workspace.PluginSpec, file string,
 		sink diag.Sink, color colors.Colorization, reinstall bool,
 	) error // == installPluginSpec
+
+	loadPluginLock func() (*workspace.PluginLock, error) // == loadProjectPluginLock
+}
+
+// loadProjectPluginLock loads the plugin lock of the current project, returning nil if there is no current project.
+func loadProjectPluginLock() (*workspace.PluginLock, error) {
+	path, err := workspace.DetectProjectPath()
+	if err != nil || path == "" {
+		return nil, nil //nolint:nilerr // not being in a project just means there's no lock to honor
+	}
+	return workspace.LoadPluginLock(filepath.Dir(path))
 }
 
 func (cmd *pluginInstallCmd) Run(ctx context.Context, args []string) error {
@@ -120,6 +136,14 @@ func (cmd *pluginInstallCmd) Run(ctx context.Context, args []string) error {
 	if cmd.registry == nil {
 		cmd.registry = cmdCmd.NewDefaultRegistry(ctx, pkgWorkspace.Instance, nil, cmd.diag, cmd.env)
 	}
+	if cmd.loadPluginLock == nil {
+		cmd.loadPluginLock = loadProjectPluginLock
+	}
+
+	lock, err := cmd.loadPluginLock()
+	if err != nil {
+		return err
+	}
 
 	// Parse the kind, name, and version, if specified.
 	var installs []workspace.PluginSpec
@@ -205,6 +229,13 @@ func (cmd *pluginInstallCmd) Run(ctx context.Context, args []string) error {
 			}
 		}
 
+		// Apply the project's plugin lock, which pins the version if none was given.
+		if lock != nil {
+			if pluginSpec, err = lock.Pin(pluginSpec); err != nil {
+				return err
+			}
+		}
+
 		// If we don't have a version try to look one up
 		if version == nil && pluginSpec.Version == nil {
 			latestVersion, err := cmd.pluginGetLatestVersion(pluginSpec, ctx)
@@ -232,6 +263,11 @@ func (cmd *pluginInstallCmd) Run(ctx context.Context, args []string) error {
 			// Skip language plugins; by definition, we already have one installed.
 			// TODO[pulumi/pulumi#956]: eventually we will want to honor and install these in the usual way.
 			if plugin.Kind != apitype.LanguagePlugin {
+				if lock != nil {
+					if plugin, err = lock.Pin(plugin); err != nil {
+						return err
+					}
+				}
 				installs = append(installs, plugin)
 			}
 		}
@@ -275,6 +311,7 @@ func installPluginSpec(
 	// If we got here, actually try to do the download.
 	var source string
 	var payload workspace.PluginContent
+	var checksum []byte
 	var err error
 	if file == "" {
 		withProgress := func(stream io.ReadCloser, size int64) io.ReadCloser {
@@ -291,6 +328,9 @@ func installPluginSpec(
 		}
 		defer func() { contract.IgnoreError(os.Remove(r.Name())) }()
 
+		if checksum, err = workspace.FileChecksum(r); err != nil {
+			return fmt.Errorf("%s computing checksum: %w", label, err)
+		}
 		payload = workspace.TarPlugin(r)
 	} else {
 		source = file
@@ -304,6 +344,12 @@ func installPluginSpec(
 	if err = install.InstallWithContext(ctx, payload, reinstall); err != nil {
 		return fmt.Errorf("installing %s from %s: %w", label, source, err)
 	}
+	// Remember the checksum of downloaded archives, so that the plugin can be checked against plugin locks.
+	if checksum != nil {
+		if err := install.WriteInstalledChecksum(checksum); err != nil {
+			return fmt.Errorf("%s recording checksum: %w", label, err)
+		}
+	}
 	return nil
 }
 
@@ -312,3 +312,48 @@ func TestRegistryIsNotUsedWhenAFileIsSpecified(t *testing.T) {
 
 	require.NoError(t, cmd.Run(ctx, []string{"resource", "some-file", "v1.0.0"}))
 }
+
+func TestPluginInstallHonorsLock(t *testing.T) {
+	t.Parallel()
+
+	var pluginWasInstalled bool
+	defer func() {
+		assert.True(t, pluginWasInstalled, "installPluginSpec should have been called")
+	}()
+
+	cmd := &pluginInstallCmd{
+		diag: diagtest.LogSink(t),
+		env:  env.NewEnv(env.MapStore{"PULUMI_DISABLE_REGISTRY_RESOLVE": "true"}),
+		pluginGetLatestVersion: func(ps workspace.PluginSpec, ctx context.Context) (*semver.Version, error) {
+			assert.Fail(t, "GetLatestVersion should not have been called")
+			return nil, nil
+		},
+		loadPluginLock: func() (*workspace.PluginLock, error) {
+			return &workspace.PluginLock{Plugins: []*workspace.PluginLockEntry{{
+				Name:      "locked-test",
+				Kind:      apitype.ResourcePlugin,
+				Version:   "1.2.3",
+				Server:    "https://example.com/plugins",
+				Checksums: map[string]string{"linux-amd64": "abcd"},
+			}}}, nil
+		},
+		installPluginSpec: func(
+			_ context.Context, _ string,
+			install workspace.PluginSpec, file string,
+			_ diag.Sink, _ colors.Colorization, _ bool,
+		) error {
+			pluginWasInstalled = true
+			assert.Equal(t, workspace.PluginSpec{
+				Name:              "locked-test",
+				Kind:              apitype.ResourcePlugin,
+				Version:           &semver.Version{Major: 1, Minor: 2, Patch: 3},
+				PluginDownloadURL: "https://example.com/plugins",
+				Checksums:         map[string][]byte{"linux-amd64": {0xab, 0xcd}},
+			}, install)
+			return nil
+		},
+	}
+
+	err := cmd.Run(context.Background(), []string{"resource", "locked-test"})
+	require.NoError(t, err)
+}
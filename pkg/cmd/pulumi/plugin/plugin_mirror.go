@@ -0,0 +1,205 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/util"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+func newPluginMirrorCmd() *cobra.Command {
+	var pmcmd pluginMirrorCmd
+	cmd := &cobra.Command{
+		Use:   "mirror <dir>",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Download the plugins the current project needs into a mirror directory",
+		Long: "Download the plugins the current project needs into a mirror directory.\n" +
+			"\n" +
+			"This command resolves the plugins required by the current project and downloads\n" +
+			"their archives for each of the given platforms into DIR, so that they can be\n" +
+			"installed on machines that can't reach the plugins' download servers.\n" +
+			"\n" +
+			"To install plugins from the mirror, set PULUMI_PLUGIN_MIRROR to the mirror\n" +
+			"directory; plugins found in the mirror are used before trying to download them.\n" +
+			"Individual plugins can also be downloaded from a mirror by using a file:// URL\n" +
+			"as their download URL.\n" +
+			"\n" +
+			"If the project has a Pulumi.lock file, plugins are mirrored at their locked versions\n" +
+			"and the checksums of the mirrored archives are recorded in it.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pmcmd.out = cmd.OutOrStdout()
+			return pmcmd.Run(cmd.Context(), args[0])
+		},
+	}
+
+	cmd.PersistentFlags().StringSliceVar(&pmcmd.platforms,
+		"platform", []string{runtime.GOOS + "-" + runtime.GOARCH},
+		"The platforms, as OS-ARCH, to download plugins for. May be repeated.")
+
+	return cmd
+}
+
+type pluginMirrorCmd struct {
+	platforms []string
+
+	out io.Writer
+
+	getProjectPlugins func() ([]workspace.PluginSpec, error) // == getProjectPlugins
+
+	loadPluginLock func() (*workspace.PluginLock, error) // == loadProjectPluginLock
+
+	pluginGetLatestVersion func(
+		workspace.PluginSpec, context.Context,
+	) (*semver.Version, error) // == workspace.PluginSpec.GetLatestVersion
+
+	mirrorPlugin func(
+		ctx context.Context, spec workspace.PluginSpec, dir, opSy, arch string,
+	) (string, []byte, error) // == workspace.MirrorPlugin
+}
+
+// parsePluginPlatform splits a platform of the form OS-ARCH into its OS and architecture.
+func parsePluginPlatform(platform string) (string, string, error) {
+	opSy, arch, ok := strings.Cut(platform, "-")
+	if !ok {
+		return "", "", fmt.Errorf("invalid platform %q, expected OS-ARCH, e.g. linux-amd64", platform)
+	}
+	switch opSy {
+	case "darwin", "linux", "windows":
+	default:
+		return "", "", fmt.Errorf("unsupported plugin OS %q in platform %q", opSy, platform)
+	}
+	switch arch {
+	case "amd64", "arm64":
+	default:
+		return "", "", fmt.Errorf("unsupported plugin architecture %q in platform %q", arch, platform)
+	}
+	return opSy, arch, nil
+}
+
+func (cmd *pluginMirrorCmd) Run(ctx context.Context, dir string) error {
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+	if cmd.getProjectPlugins == nil {
+		cmd.getProjectPlugins = getProjectPlugins
+	}
+	if cmd.loadPluginLock == nil {
+		cmd.loadPluginLock = loadProjectPluginLock
+	}
+	if cmd.pluginGetLatestVersion == nil {
+		cmd.pluginGetLatestVersion = (workspace.PluginSpec).GetLatestVersion
+	}
+	if cmd.mirrorPlugin == nil {
+		cmd.mirrorPlugin = workspace.MirrorPlugin
+	}
+
+	if len(cmd.platforms) == 0 {
+		return errors.New("at least one --platform is required")
+	}
+	for _, platform := range cmd.platforms {
+		if _, _, err := parsePluginPlatform(platform); err != nil {
+			return err
+		}
+	}
+
+	plugins, err := cmd.getProjectPlugins()
+	if err != nil {
+		return err
+	}
+
+	// Only record checksums if the project already has a lock file; mirroring shouldn't create one.
+	lock, err := cmd.loadPluginLock()
+	if err != nil {
+		return err
+	}
+	if lock != nil {
+		if _, err := os.Stat(lock.Path()); err != nil {
+			lock = nil
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating mirror directory: %w", err)
+	}
+
+	for _, spec := range plugins {
+		// Bundled plugins ship with the CLI, so they never need to be downloaded.
+		if spec.Name == "pulumi" && spec.Kind == apitype.ResourcePlugin {
+			continue
+		}
+		if workspace.IsPluginBundled(spec.Kind, spec.Name) {
+			continue
+		}
+
+		if lock != nil {
+			if err := lock.CheckVersion(spec); err != nil {
+				return err
+			}
+			if spec, err = lock.Pin(spec); err != nil {
+				return err
+			}
+		}
+		util.SetKnownPluginDownloadURL(&spec)
+		if spec.Version == nil {
+			if spec.Version, err = cmd.pluginGetLatestVersion(spec, ctx); err != nil {
+				return fmt.Errorf("could not get latest version for %s plugin %s: %w", spec.Kind, spec.Name, err)
+			}
+		}
+
+		for _, platform := range cmd.platforms {
+			opSy, arch, err := parsePluginPlatform(platform)
+			contract.AssertNoErrorf(err, "platforms were validated above")
+			path, checksum, err := cmd.mirrorPlugin(ctx, spec, dir, opSy, arch)
+			if err != nil {
+				return fmt.Errorf("mirroring %s plugin %s for %s: %w", spec.Kind, spec, platform, err)
+			}
+			fmt.Fprintf(cmd.out, "Mirrored %s plugin %s for %s to %s\n", spec.Kind, spec, platform, path)
+
+			if lock != nil {
+				if err := lock.RecordChecksum(spec, platform, checksum); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if lock != nil {
+		if err := lock.Save(); err != nil {
+			return fmt.Errorf("writing %s: %w", lock.Path(), err)
+		}
+	}
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		abs = dir
+	}
+	fmt.Fprintf(cmd.out, "\nTo install plugins from this mirror, set PULUMI_PLUGIN_MIRROR=%s\n", abs)
+	return nil
+}
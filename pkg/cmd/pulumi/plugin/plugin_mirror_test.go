@@ -0,0 +1,97 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+func TestPluginMirror(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, workspace.PluginLockFile), []byte(
+		"version: 1\nplugins:\n- name: random\n  kind: resource\n  version: 4.0.0\n"), 0o600))
+
+	v := semver.MustParse("6.0.0")
+	var mirrored []string
+	var out bytes.Buffer
+	cmd := &pluginMirrorCmd{
+		platforms: []string{"linux-amd64", "darwin-arm64"},
+		out:       &out,
+		getProjectPlugins: func() ([]workspace.PluginSpec, error) {
+			return []workspace.PluginSpec{
+				{Name: "nodejs", Kind: apitype.LanguagePlugin},
+				{Name: "pulumi", Kind: apitype.ResourcePlugin},
+				{Name: "aws", Kind: apitype.ResourcePlugin, Version: &v},
+				{Name: "random", Kind: apitype.ResourcePlugin},
+			}, nil
+		},
+		loadPluginLock: func() (*workspace.PluginLock, error) {
+			return workspace.LoadPluginLock(root)
+		},
+		pluginGetLatestVersion: func(workspace.PluginSpec, context.Context) (*semver.Version, error) {
+			assert.Fail(t, "GetLatestVersion should not have been called")
+			return nil, nil
+		},
+		mirrorPlugin: func(
+			_ context.Context, spec workspace.PluginSpec, dir, opSy, arch string,
+		) (string, []byte, error) {
+			mirrored = append(mirrored, spec.String()+" "+opSy+"-"+arch)
+			return filepath.Join(dir, spec.Name), []byte{byte(len(mirrored))}, nil
+		},
+	}
+
+	dir := filepath.Join(t.TempDir(), "mirror")
+	require.NoError(t, cmd.Run(context.Background(), dir))
+	assert.DirExists(t, dir)
+	assert.Equal(t, []string{
+		"aws-6.0.0 linux-amd64",
+		"aws-6.0.0 darwin-arm64",
+		"random-4.0.0 linux-amd64",
+		"random-4.0.0 darwin-arm64",
+	}, mirrored)
+	assert.Contains(t, out.String(), "PULUMI_PLUGIN_MIRROR=")
+
+	lock, err := workspace.LoadPluginLock(root)
+	require.NoError(t, err)
+	require.Len(t, lock.Plugins, 2)
+	assert.Equal(t, "aws", lock.Plugins[0].Name)
+	assert.Equal(t, map[string]string{"linux-amd64": "01", "darwin-arm64": "02"}, lock.Plugins[0].Checksums)
+	assert.Equal(t, map[string]string{"linux-amd64": "03", "darwin-arm64": "04"}, lock.Plugins[1].Checksums)
+}
+
+func TestPluginMirrorInvalidPlatform(t *testing.T) {
+	t.Parallel()
+
+	cmd := &pluginMirrorCmd{platforms: []string{"linux"}}
+	err := cmd.Run(context.Background(), t.TempDir())
+	assert.ErrorContains(t, err, `invalid platform "linux"`)
+
+	cmd = &pluginMirrorCmd{platforms: []string{"solaris-amd64"}}
+	err = cmd.Run(context.Background(), t.TempDir())
+	assert.ErrorContains(t, err, `unsupported plugin OS "solaris"`)
+}
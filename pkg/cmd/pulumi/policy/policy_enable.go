@@ -40,7 +40,11 @@ func newPolicyEnableCmd() *cobra.Command {
 		Args:  cmdutil.ExactArgs(2),
 		Short: "Enable a Policy Pack for a Pulumi organization",
 		Long: "Enable a Policy Pack for a Pulumi organization. " +
-			"Can specify latest to enable the latest version of the Policy Pack or a specific version number.",
+			"Can specify latest to enable the latest version of the Policy Pack or a specific version number.\n" +
+			"\n" +
+			"When using a DIY backend, the Policy Pack is enabled for every stack in the backend, or only for the " +
+			"stacks of a project or a single stack by passing `--policy-group <project>` or " +
+			"`--policy-group <project>/<stack>`.",
 		RunE: func(cmd *cobra.Command, cliArgs []string) error {
 			ctx := cmd.Context()
 			// Obtain current PolicyPack, tied to the Pulumi Cloud backend.
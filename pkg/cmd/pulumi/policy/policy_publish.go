@@ -41,7 +41,9 @@ func newPolicyPublishCmd() *cobra.Command {
 		Short: "Publish a Policy Pack to the Pulumi Cloud",
 		Long: "Publish a Policy Pack to the Pulumi Cloud\n" +
 			"\n" +
-			"If an organization name is not specified, the default org (if set) or the current user account is used.",
+			"If an organization name is not specified, the default org (if set) or the current user account is used.\n" +
+			"\n" +
+			"When using a DIY backend, the Policy Pack is stored in the backend alongside its stacks.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return policyPublishCmd.Run(cmd.Context(), cmdBackend.DefaultLoginManager, args)
 		},
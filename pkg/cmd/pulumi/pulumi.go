@@ -341,6 +341,10 @@ func NewPulumiCmd() (*cobra.Command, func()) {
 	cmd.PersistentFlags().StringVar(
 		&color, "color", "auto", "Colorize output. Choices are: always, never, raw, auto")
 
+	// `pulumi stack reap` runs a destroy, so it is defined with the other operations.
+	stackCmd := cmdStack.NewStackCmd()
+	stackCmd.AddCommand(operations.NewReapCmd())
+
 	setCommandGroups(cmd, []commandGroup{
 		// Common commands:
 		{
@@ -348,7 +352,7 @@ func NewPulumiCmd() (*cobra.Command, func()) {
 			Commands: []*cobra.Command{
 				newcmd.NewNewCmd(),
 				config.NewConfigCmd(pkgWorkspace.Instance),
-				cmdStack.NewStackCmd(),
+				stackCmd,
 				console.NewConsoleCmd(pkgWorkspace.Instance),
 				operations.NewImportCmd(),
 				operations.NewRefreshCmd(),
@@ -432,6 +436,7 @@ func NewPulumiCmd() (*cobra.Command, func()) {
 				trace.NewViewTraceCmd(),
 				trace.NewConvertTraceCmd(),
 				events.NewReplayEventsCmd(),
+				events.NewEventsCmd(),
 			},
 		},
 		// AI Commands relating to specifically the Pulumi AI service
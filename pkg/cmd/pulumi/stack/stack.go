@@ -18,8 +18,11 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
 	"os"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
@@ -41,6 +44,7 @@ import (
 type stackArgs struct {
 	showIDs                bool
 	showURNs               bool
+	showAnnotations        bool
 	showSecrets            bool
 	startTime              string
 	showStackName          bool
@@ -92,17 +96,22 @@ func NewStackCmd() *cobra.Command {
 		&args.showIDs, "show-ids", "i", false, "Display each resource's provider-assigned unique ID")
 	cmd.Flags().BoolVarP(
 		&args.showURNs, "show-urns", "u", false, "Display each resource's Pulumi-assigned globally unique URN")
+	cmd.Flags().BoolVar(
+		&args.showAnnotations, "show-annotations", false, "Display each resource's user-defined annotations")
 	cmd.Flags().BoolVar(
 		&args.showSecrets, "show-secrets", false, "Display stack outputs which are marked as secret in plaintext")
 	cmd.Flags().BoolVar(
 		&args.showStackName, "show-name", false, "Display only the stack name")
 
 	cmd.AddCommand(newStackExportCmd())
+	cmd.AddCommand(newStackBrowseCmd())
+	cmd.AddCommand(newStackDepsCmd())
 	cmd.AddCommand(newStackGraphCmd())
 	cmd.AddCommand(newStackImportCmd())
 	cmd.AddCommand(newStackInitCmd())
 	cmd.AddCommand(newStackLsCmd())
 	cmd.AddCommand(newStackOutputCmd())
+	cmd.AddCommand(newStackQueryCmd())
 	cmd.AddCommand(newStackRmCmd())
 	cmd.AddCommand(newStackSelectCmd())
 	cmd.AddCommand(newStackTagCmd())
@@ -180,10 +189,10 @@ func runStack(ctx context.Context, s backend.Stack, out io.Writer, args stackArg
 	if resourceCount == 0 {
 		fmt.Fprintf(out, "    No resources currently in this stack\n")
 	} else {
-		rows, ok := renderTree(snap, args.showURNs, args.showIDs)
+		rows, ok := renderTree(snap, args)
 		if !ok {
 			for _, res := range snap.Resources {
-				rows = append(rows, renderResourceRow(res, "", "    ", args.showURNs, args.showIDs))
+				rows = append(rows, renderResourceRow(res, "", "    ", args))
 			}
 		}
 
@@ -268,7 +277,7 @@ type treeNode struct {
 	children []*treeNode
 }
 
-func renderNode(node *treeNode, padding, branch string, showURNs, showIDs bool, rows *[]cmdutil.TableRow) {
+func renderNode(node *treeNode, padding, branch string, args stackArgs, rows *[]cmdutil.TableRow) {
 	padBranch := ""
 	switch branch {
 	case "├─ ":
@@ -284,18 +293,18 @@ func renderNode(node *treeNode, padding, branch string, showURNs, showIDs bool,
 	}
 	infoPadding := childPadding + infoBranch
 
-	*rows = append(*rows, renderResourceRow(node.res, padding+branch, infoPadding, showURNs, showIDs))
+	*rows = append(*rows, renderResourceRow(node.res, padding+branch, infoPadding, args))
 
 	for i, child := range node.children {
 		childBranch := "├─ "
 		if i == len(node.children)-1 {
 			childBranch = "└─ "
 		}
-		renderNode(child, childPadding, childBranch, showURNs, showIDs, rows)
+		renderNode(child, childPadding, childBranch, args, rows)
 	}
 }
 
-func renderTree(snap *deploy.Snapshot, showURNs, showIDs bool) ([]cmdutil.TableRow, bool) {
+func renderTree(snap *deploy.Snapshot, args stackArgs) ([]cmdutil.TableRow, bool) {
 	var root *treeNode
 	var orphans []*treeNode
 	nodes := make(map[resource.URN]*treeNode)
@@ -339,22 +348,29 @@ func renderTree(snap *deploy.Snapshot, showURNs, showIDs bool) ([]cmdutil.TableR
 	root.children = append(root.children, orphans...)
 
 	var rows []cmdutil.TableRow
-	renderNode(root, "", "", showURNs, showIDs, &rows)
+	renderNode(root, "", "", args, &rows)
 	return rows, true
 }
 
-func renderResourceRow(res *resource.State, prefix, infoPrefix string, showURN, showID bool) cmdutil.TableRow {
+func renderResourceRow(res *resource.State, prefix, infoPrefix string, args stackArgs) cmdutil.TableRow {
 	columns := []string{prefix + string(res.Type), res.URN.Name()}
 	additionalInfo := ""
 
 	// If the ID and/or URN is requested, show it on the following line.  It would be nice to do
 	// this on a single line, but this can get quite lengthy and so this formatting is better.
-	if showURN {
+	if args.showURNs {
 		additionalInfo += fmt.Sprintf("    %sURN: %s\n", infoPrefix, res.URN)
 	}
-	if showID && res.ID != "" {
+	if args.showIDs && res.ID != "" {
 		additionalInfo += fmt.Sprintf("    %sID: %s\n", infoPrefix, res.ID)
 	}
+	if args.showAnnotations && len(res.Annotations) > 0 {
+		annotations := make([]string, 0, len(res.Annotations))
+		for _, k := range slices.Sorted(maps.Keys(res.Annotations)) {
+			annotations = append(annotations, k+"="+res.Annotations[k])
+		}
+		additionalInfo += fmt.Sprintf("    %sAnnotations: %s\n", infoPrefix, strings.Join(annotations, ", "))
+	}
 
 	return cmdutil.TableRow{Columns: columns, AdditionalInfo: additionalInfo}
 }
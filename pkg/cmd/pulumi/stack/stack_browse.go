@@ -0,0 +1,755 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	cmdBackend "github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/backend"
+	sdkDisplay "github.com/pulumi/pulumi/pkg/v3/display"
+	"github.com/pulumi/pulumi/pkg/v3/operations"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/v3/resource/edit"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	pkgWorkspace "github.com/pulumi/pulumi/pkg/v3/workspace"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+func newStackBrowseCmd() *cobra.Command {
+	var sbcmd stackBrowseCmd
+	cmd := &cobra.Command{
+		Use:   "browse",
+		Args:  cmdutil.NoArgs,
+		Short: "Interactively browse a stack's resources and history",
+		Long: "Interactively browse a stack's resources and history.\n" +
+			"\n" +
+			"This command opens a terminal UI that shows the stack's resources as a tree. Selecting\n" +
+			"a resource shows its inputs, outputs, dependencies and dependents, and the stack's\n" +
+			"update history can be shown alongside the tree. From the browser you can run a\n" +
+			"preview or refresh targeting the selected resource, or protect and unprotect it.\n" +
+			"\n" +
+			"Keys:\n" +
+			"\n" +
+			"    up/down, j/k      select a resource\n" +
+			"    right/left, l/h   expand or collapse the selected resource's children\n" +
+			"    J/K               scroll the details pane\n" +
+			"    tab               switch between resource details and stack history\n" +
+			"    s                 show or hide secret values\n" +
+			"    p                 preview the selected resource\n" +
+			"    r                 refresh the selected resource\n" +
+			"    t                 protect or unprotect the selected resource\n" +
+			"    q                 quit",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sbcmd.Stdin = cmd.InOrStdin()
+			sbcmd.Stdout = cmd.OutOrStdout()
+			return sbcmd.Run(cmd.Context())
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&sbcmd.stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().BoolVar(
+		&sbcmd.showSecrets, "show-secrets", false, "Start with secret values shown in plaintext")
+	cmd.PersistentFlags().IntVar(
+		&sbcmd.historySize, "history-size", 50, "The number of most recent updates to show in the history view")
+
+	return cmd
+}
+
+type stackBrowseCmd struct {
+	stackName   string
+	showSecrets bool
+	historySize int
+
+	ws pkgWorkspace.Context
+
+	// requireStack is a reference to the top-level requireStack function. This is a field on stackBrowseCmd so that
+	// we can replace it from tests.
+	requireStack func(
+		ctx context.Context, sink diag.Sink, ws pkgWorkspace.Context, lm cmdBackend.LoginManager,
+		name string, lopt LoadOption, opts display.Options,
+	) (backend.Stack, error)
+
+	Stdin  io.Reader // defaults to os.Stdin
+	Stdout io.Writer // defaults to os.Stdout
+}
+
+func (cmd *stackBrowseCmd) Run(ctx context.Context) error {
+	opts := display.Options{
+		Color: cmdutil.GetGlobalColorization(),
+	}
+
+	if !cmdutil.Interactive() {
+		return errors.New("pulumi stack browse requires an interactive terminal; " +
+			"use `pulumi stack`, `pulumi stack query` or `pulumi stack history` instead")
+	}
+
+	requireStack := RequireStack
+	if cmd.requireStack != nil {
+		requireStack = cmd.requireStack
+	}
+	if cmd.ws == nil {
+		cmd.ws = pkgWorkspace.Instance
+	}
+	stdin := io.Reader(os.Stdin)
+	if cmd.Stdin != nil {
+		stdin = cmd.Stdin
+	}
+	stdout := io.Writer(os.Stdout)
+	if cmd.Stdout != nil {
+		stdout = cmd.Stdout
+	}
+
+	s, err := requireStack(ctx, cmdutil.Diag(), cmd.ws, cmdBackend.DefaultLoginManager, cmd.stackName, LoadOnly, opts)
+	if err != nil {
+		return err
+	}
+
+	model := newBrowseModel(ctx, newStackBrowser(s, cmd.historySize), opts.Color, cmd.showSecrets)
+	final, err := tea.NewProgram(model, tea.WithAltScreen(), tea.WithInput(stdin), tea.WithOutput(stdout)).Run()
+	if err != nil {
+		return err
+	}
+	m, ok := final.(browseModel)
+	contract.Assertf(ok, "expected browseModel, got %T", final)
+	if m.showedSecrets {
+		Log3rdPartySecretsProviderDecryptionEvent(ctx, s, "", "pulumi stack browse")
+	}
+	return m.err
+}
+
+// stackBrowser provides the browser with access to a stack. It is a set of functions so that tests can replace them.
+type stackBrowser struct {
+	// The fully qualified name of the stack being browsed.
+	name string
+
+	loadSnapshot func(ctx context.Context) (*deploy.Snapshot, error)
+	loadHistory  func(ctx context.Context) ([]backend.UpdateInfo, error)
+	setProtect   func(ctx context.Context, urn resource.URN, protect bool) error
+	// targetCommand returns a command that runs `pulumi <op>` targeting the given resource.
+	targetCommand func(op string, urn resource.URN) (tea.ExecCommand, error)
+}
+
+func newStackBrowser(s backend.Stack, historySize int) stackBrowser {
+	name := s.Ref().FullyQualifiedName().String()
+	return stackBrowser{
+		name: name,
+		loadSnapshot: func(ctx context.Context) (*deploy.Snapshot, error) {
+			return s.Snapshot(ctx, stack.DefaultSecretsProvider)
+		},
+		loadHistory: func(ctx context.Context) ([]backend.UpdateInfo, error) {
+			return s.Backend().GetHistory(ctx, s.Ref(), historySize, 1)
+		},
+		setProtect: func(ctx context.Context, urn resource.URN, protect bool) error {
+			tx, err := edit.Open(ctx, backend.NewStackStore(s), stack.DefaultSecretsProvider)
+			if err != nil {
+				return err
+			}
+			if err := tx.SetFlag(urn, edit.FlagProtect, protect); err != nil {
+				return err
+			}
+			return tx.Commit(ctx)
+		},
+		targetCommand: func(op string, urn resource.URN) (tea.ExecCommand, error) {
+			exe, err := os.Executable()
+			if err != nil {
+				return nil, err
+			}
+			return &pausingCommand{Cmd: exec.Command(exe, op, "--stack", name, "--target", string(urn))}, nil
+		},
+	}
+}
+
+// pausingCommand runs a command in the terminal while the browser is suspended, and waits for the user to press enter
+// before returning so that they can read its output.
+type pausingCommand struct {
+	*exec.Cmd
+}
+
+var _ tea.ExecCommand = (*pausingCommand)(nil)
+
+func (c *pausingCommand) SetStdin(r io.Reader)  { c.Stdin = r }
+func (c *pausingCommand) SetStdout(w io.Writer) { c.Stdout = w }
+func (c *pausingCommand) SetStderr(w io.Writer) { c.Stderr = w }
+
+func (c *pausingCommand) Run() error {
+	err := c.Cmd.Run()
+	if c.Stdout != nil {
+		fmt.Fprint(c.Stdout, "\nPress enter to return to the stack browser...")
+	}
+	if c.Stdin != nil {
+		_, _ = bufio.NewReader(c.Stdin).ReadString('\n')
+	}
+	return err
+}
+
+// Messages sent to the browser when background work completes.
+type (
+	browseSnapshotMsg struct {
+		snap *deploy.Snapshot
+		err  error
+	}
+	browseHistoryMsg struct {
+		updates []backend.UpdateInfo
+		err     error
+	}
+	browseActionMsg struct {
+		action string
+		urn    resource.URN
+		err    error
+	}
+)
+
+// browseRow is a single line of the resource tree.
+type browseRow struct {
+	node  *operations.Resource
+	depth int
+}
+
+// browseModel drives the bubbletea program behind `pulumi stack browse`.
+type browseModel struct {
+	ctx     context.Context
+	browser stackBrowser
+	color   colors.Colorization
+
+	snap       *deploy.Snapshot
+	rows       []browseRow
+	collapsed  map[resource.URN]bool
+	cursor     int
+	treeOffset int
+
+	detailsOffset int
+	showSecrets   bool
+	// showedSecrets records whether secrets were ever shown, so that the decryption can be logged.
+	showedSecrets bool
+	showHistory   bool
+	history       []backend.UpdateInfo
+	historyErr    error
+
+	status        string
+	width, height int
+
+	// err is set if the browser exits because the stack couldn't be loaded.
+	err error
+}
+
+var _ tea.Model = browseModel{}
+
+func newBrowseModel(
+	ctx context.Context, browser stackBrowser, color colors.Colorization, showSecrets bool,
+) browseModel {
+	return browseModel{
+		ctx:           ctx,
+		browser:       browser,
+		color:         color,
+		collapsed:     map[resource.URN]bool{},
+		showSecrets:   showSecrets,
+		showedSecrets: showSecrets,
+		width:         120,
+		height:        40,
+		status:        "Loading " + browser.name + "...",
+	}
+}
+
+// Init starts loading the stack's state and history.
+func (m browseModel) Init() tea.Cmd {
+	return tea.Batch(m.loadSnapshot(), m.loadHistory())
+}
+
+func (m browseModel) loadSnapshot() tea.Cmd {
+	return func() tea.Msg {
+		snap, err := m.browser.loadSnapshot(m.ctx)
+		return browseSnapshotMsg{snap: snap, err: err}
+	}
+}
+
+func (m browseModel) loadHistory() tea.Cmd {
+	return func() tea.Msg {
+		updates, err := m.browser.loadHistory(m.ctx)
+		return browseHistoryMsg{updates: updates, err: err}
+	}
+}
+
+// Update handles a single tick of the bubbletea loop.
+func (m browseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scrollToCursor()
+		return m, nil
+
+	case browseSnapshotMsg:
+		if msg.err != nil {
+			m.err = fmt.Errorf("loading stack %s: %w", m.browser.name, msg.err)
+			return m, tea.Quit
+		}
+		m.setSnapshot(msg.snap)
+		m.status = ""
+		return m, nil
+
+	case browseHistoryMsg:
+		m.history, m.historyErr = msg.updates, msg.err
+		return m, nil
+
+	case browseActionMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("%s of %s failed: %v", msg.action, msg.urn.Name(), msg.err)
+		} else {
+			m.status = fmt.Sprintf("%s of %s finished", msg.action, msg.urn.Name())
+		}
+		// Actions can change the state and add to the history, so reload both.
+		return m, tea.Batch(m.loadSnapshot(), m.loadHistory())
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+
+	return m, nil
+}
+
+func (m browseModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c", "esc":
+		return m, tea.Quit
+	case "up", "k":
+		m.moveCursor(-1)
+	case "down", "j":
+		m.moveCursor(1)
+	case "pgup":
+		m.moveCursor(-m.paneHeight())
+	case "pgdown":
+		m.moveCursor(m.paneHeight())
+	case "home", "g":
+		m.moveCursor(-len(m.rows))
+	case "end", "G":
+		m.moveCursor(len(m.rows))
+	case "right", "l", "enter", " ":
+		if node := m.selected(); node != nil && len(node.Children) > 0 {
+			urn := node.State.URN
+			if msg.String() == "right" || msg.String() == "l" {
+				m.collapsed[urn] = false
+			} else {
+				m.collapsed[urn] = !m.collapsed[urn]
+			}
+			m.rebuildRows(urn)
+		}
+	case "left", "h":
+		node := m.selected()
+		if node == nil {
+			break
+		}
+		if len(node.Children) > 0 && !m.collapsed[node.State.URN] {
+			m.collapsed[node.State.URN] = true
+			m.rebuildRows(node.State.URN)
+		} else if node.Parent != nil && node.Parent.State != nil {
+			m.selectURN(node.Parent.State.URN)
+		}
+	case "J":
+		m.detailsOffset++
+	case "K":
+		m.detailsOffset = max(0, m.detailsOffset-1)
+	case "tab":
+		m.showHistory = !m.showHistory
+		m.detailsOffset = 0
+	case "s":
+		m.showSecrets = !m.showSecrets
+		m.showedSecrets = m.showedSecrets || m.showSecrets
+	case "p", "r":
+		node := m.selected()
+		if node == nil {
+			break
+		}
+		op := "preview"
+		if msg.String() == "r" {
+			op = "refresh"
+		}
+		urn := node.State.URN
+		c, err := m.browser.targetCommand(op, urn)
+		if err != nil {
+			m.status = fmt.Sprintf("%s of %s failed: %v", op, urn.Name(), err)
+			break
+		}
+		return m, tea.Exec(c, func(err error) tea.Msg {
+			return browseActionMsg{action: op, urn: urn, err: err}
+		})
+	case "t":
+		node := m.selected()
+		if node == nil {
+			break
+		}
+		urn, protect := node.State.URN, !node.State.Protect
+		action := "protect"
+		if !protect {
+			action = "unprotect"
+		}
+		m.status = fmt.Sprintf("Running %s of %s...", action, urn.Name())
+		return m, func() tea.Msg {
+			return browseActionMsg{action: action, urn: urn, err: m.browser.setProtect(m.ctx, urn, protect)}
+		}
+	}
+
+	return m, nil
+}
+
+// setSnapshot replaces the browsed snapshot, keeping the current selection if the resource still exists.
+func (m *browseModel) setSnapshot(snap *deploy.Snapshot) {
+	var selected resource.URN
+	if node := m.selected(); node != nil {
+		selected = node.State.URN
+	}
+	m.snap = snap
+	m.rebuildRows(selected)
+}
+
+// rebuildRows flattens the resource tree into rows, skipping the children of collapsed resources, and then selects
+// the given resource if it is visible.
+func (m *browseModel) rebuildRows(selected resource.URN) {
+	m.rows = nil
+	if m.snap == nil {
+		return
+	}
+
+	order := make(map[resource.URN]int, len(m.snap.Resources))
+	for i, res := range m.snap.Resources {
+		order[res.URN] = i
+	}
+
+	var visit func(node *operations.Resource, depth int)
+	visit = func(node *operations.Resource, depth int) {
+		children := make([]*operations.Resource, 0, len(node.Children))
+		for _, child := range node.Children {
+			children = append(children, child)
+		}
+		slices.SortFunc(children, func(a, b *operations.Resource) int {
+			return order[a.State.URN] - order[b.State.URN]
+		})
+		for _, child := range children {
+			m.rows = append(m.rows, browseRow{node: child, depth: depth})
+			if !m.collapsed[child.State.URN] {
+				visit(child, depth+1)
+			}
+		}
+	}
+	visit(operations.NewResourceTree(m.snap.Resources), 0)
+
+	if !m.selectURN(selected) {
+		m.cursor = min(m.cursor, max(0, len(m.rows)-1))
+		m.scrollToCursor()
+	}
+}
+
+// selectURN moves the cursor to the resource with the given URN, returning false if it isn't visible.
+func (m *browseModel) selectURN(urn resource.URN) bool {
+	for i, row := range m.rows {
+		if row.node.State.URN == urn {
+			m.cursor = i
+			m.detailsOffset = 0
+			m.scrollToCursor()
+			return true
+		}
+	}
+	return false
+}
+
+func (m *browseModel) moveCursor(delta int) {
+	if len(m.rows) == 0 {
+		return
+	}
+	m.cursor = min(max(0, m.cursor+delta), len(m.rows)-1)
+	m.detailsOffset = 0
+	m.scrollToCursor()
+}
+
+// scrollToCursor scrolls the tree so that the selected row is visible.
+func (m *browseModel) scrollToCursor() {
+	height := m.paneHeight()
+	if m.cursor < m.treeOffset {
+		m.treeOffset = m.cursor
+	} else if m.cursor >= m.treeOffset+height {
+		m.treeOffset = m.cursor - height + 1
+	}
+}
+
+func (m browseModel) selected() *operations.Resource {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return nil
+	}
+	return m.rows[m.cursor].node
+}
+
+// paneHeight returns the number of lines available to the tree and details panes, leaving room for the header,
+// status line and key help.
+func (m browseModel) paneHeight() int {
+	return max(1, m.height-3)
+}
+
+var (
+	browseHeaderStyle   = lipgloss.NewStyle().Bold(true)
+	browseSelectedStyle = lipgloss.NewStyle().Reverse(true)
+	browseHelpStyle     = lipgloss.NewStyle().Faint(true)
+)
+
+// View renders the browser.
+func (m browseModel) View() string {
+	if m.snap == nil {
+		return m.status + "\n"
+	}
+
+	header := fmt.Sprintf("Stack %s (%d resources)", m.browser.name, len(m.snap.Resources))
+	if m.showSecrets {
+		header += " [secrets shown]"
+	}
+
+	height := m.paneHeight()
+	treeWidth := max(20, m.width*2/5)
+	detailsWidth := max(20, m.width-treeWidth-3)
+
+	var details []string
+	if m.showHistory {
+		details = m.historyLines()
+	} else {
+		details = m.detailsLines()
+	}
+	offset := min(m.detailsOffset, max(0, len(details)-1))
+	details = details[offset:]
+
+	panes := lipgloss.JoinHorizontal(lipgloss.Top,
+		renderPane(m.treeLines(treeWidth), treeWidth, height),
+		renderPane(slices.Repeat([]string{" | "}, height), 3, height),
+		renderPane(details, detailsWidth, height))
+
+	help := "up/down select  left/right collapse/expand  J/K scroll  tab history  s secrets  " +
+		"p preview  r refresh  t protect  q quit"
+
+	return browseHeaderStyle.Render(header) + "\n" +
+		panes + "\n" +
+		m.status + "\n" +
+		browseHelpStyle.Render(truncateLine(help, m.width))
+}
+
+// renderPane renders lines into a block of exactly the given size, truncating and padding as necessary.
+func renderPane(lines []string, width, height int) string {
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	content := lipgloss.NewStyle().MaxWidth(width).Render(strings.Join(lines, "\n"))
+	return lipgloss.NewStyle().Width(width).Height(height).Render(content)
+}
+
+func truncateLine(s string, width int) string {
+	return lipgloss.NewStyle().MaxWidth(width).Render(s)
+}
+
+func (m browseModel) treeLines(width int) []string {
+	height := m.paneHeight()
+	var lines []string
+	for i := m.treeOffset; i < len(m.rows) && i < m.treeOffset+height; i++ {
+		row := m.rows[i]
+		marker := "  "
+		if len(row.node.Children) > 0 {
+			marker = "v "
+			if m.collapsed[row.node.State.URN] {
+				marker = "> "
+			}
+		}
+		state := row.node.State
+		line := strings.Repeat("  ", row.depth) + marker + string(state.Type) + " " + state.URN.Name()
+		if state.Protect {
+			line += " [protected]"
+		}
+		line = truncateLine(line, width)
+		if i == m.cursor {
+			line = browseSelectedStyle.Render(line + strings.Repeat(" ", max(0, width-lipgloss.Width(line))))
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "This stack has no resources")
+	}
+	return lines
+}
+
+// detailsLines renders the state of the selected resource.
+func (m browseModel) detailsLines() []string {
+	node := m.selected()
+	if node == nil {
+		return nil
+	}
+	state := node.State
+
+	var b strings.Builder
+	field := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, "%s: %s\n", name, value)
+		}
+	}
+	field("URN", string(state.URN))
+	field("Type", string(state.Type))
+	field("ID", string(state.ID))
+	field("Parent", string(state.Parent))
+	field("Provider", state.Provider)
+	var flags []string
+	for _, flag := range []struct {
+		name string
+		set  bool
+	}{
+		{"custom", state.Custom},
+		{"protect", state.Protect},
+		{"retainOnDelete", state.RetainOnDelete},
+		{"external", state.External},
+		{"pendingReplacement", state.PendingReplacement},
+	} {
+		if flag.set {
+			flags = append(flags, flag.name)
+		}
+	}
+	field("Flags", strings.Join(flags, ", "))
+	if state.Created != nil {
+		field("Created", formatBrowseTime(*state.Created))
+	}
+	if state.Modified != nil {
+		field("Modified", formatBrowseTime(*state.Modified))
+	}
+
+	for _, props := range []struct {
+		title string
+		value resource.PropertyMap
+	}{
+		{"Inputs", state.Inputs},
+		{"Outputs", state.Outputs},
+	} {
+		fmt.Fprintf(&b, "\n%s:\n", props.title)
+		if len(props.value) == 0 {
+			b.WriteString("    (none)\n")
+			continue
+		}
+		var buf bytes.Buffer
+		display.PrintObject(&buf, props.value, false, 1, deploy.OpSame,
+			false /*prefix*/, false /*truncateOutput*/, false /*debug*/, m.showSecrets)
+		b.WriteString(m.color.Colorize(buf.String()))
+	}
+
+	b.WriteString("\nDependencies:\n")
+	writeURNs(&b, m.dependenciesOf(state))
+	b.WriteString("\nDependents:\n")
+	writeURNs(&b, m.dependentsOf(state))
+
+	return strings.Split(strings.TrimRight(b.String(), "\n"), "\n")
+}
+
+// historyLines renders the stack's update history.
+func (m browseModel) historyLines() []string {
+	if m.historyErr != nil {
+		return []string{fmt.Sprintf("Could not load history: %v", m.historyErr)}
+	}
+	if m.history == nil {
+		return []string{"Loading history..."}
+	}
+	if len(m.history) == 0 {
+		return []string{"Stack has never been updated"}
+	}
+
+	var lines []string
+	for _, update := range m.history {
+		result := string(update.Result)
+		if update.Result == backend.SucceededResult {
+			result = m.color.Colorize(colors.Green + result + colors.Reset)
+		} else {
+			result = m.color.Colorize(colors.Red + result + colors.Reset)
+		}
+		lines = append(lines,
+			fmt.Sprintf("Version %d: %s %s, %s", update.Version, update.Kind, result,
+				humanize.Time(time.Unix(update.StartTime, 0))))
+		if update.Message != "" {
+			lines = append(lines, "    "+update.Message)
+		}
+		var changes []string
+		for _, op := range []sdkDisplay.StepOp{deploy.OpCreate, deploy.OpUpdate, deploy.OpDelete, deploy.OpSame} {
+			if n := update.ResourceChanges[op]; n > 0 {
+				changes = append(changes, fmt.Sprintf("%d %s", n, op))
+			}
+		}
+		if len(changes) > 0 {
+			lines = append(lines, "    "+strings.Join(changes, ", "))
+		}
+	}
+	return lines
+}
+
+// dependenciesOf returns the URNs of the resources the given resource depends on, including its provider.
+func (m browseModel) dependenciesOf(state *resource.State) []resource.URN {
+	var urns []resource.URN
+	provider, deps := state.GetAllDependencies()
+	if provider != "" {
+		if ref, err := providers.ParseReference(provider); err == nil {
+			urns = append(urns, ref.URN())
+		}
+	}
+	for _, dep := range deps {
+		if dep.Type != resource.ResourceParent && !slices.Contains(urns, dep.URN) {
+			urns = append(urns, dep.URN)
+		}
+	}
+	return urns
+}
+
+// dependentsOf returns the URNs of the resources that directly depend on the given resource.
+func (m browseModel) dependentsOf(state *resource.State) []resource.URN {
+	var urns []resource.URN
+	for _, res := range m.snap.Resources {
+		if res.Delete || res == state {
+			continue
+		}
+		if slices.Contains(m.dependenciesOf(res), state.URN) {
+			urns = append(urns, res.URN)
+		}
+	}
+	return urns
+}
+
+func writeURNs(b *strings.Builder, urns []resource.URN) {
+	if len(urns) == 0 {
+		b.WriteString("    (none)\n")
+	}
+	for _, urn := range urns {
+		fmt.Fprintf(b, "    %s\n", urn)
+	}
+}
+
+func formatBrowseTime(t time.Time) string {
+	return fmt.Sprintf("%s (%s)", t.Format(time.RFC3339), humanize.Time(t))
+}
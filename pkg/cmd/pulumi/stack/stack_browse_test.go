@@ -0,0 +1,261 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+// newBrowseTestSnapshot returns a snapshot with a component that has two children, one of which depends on a
+// top-level bucket with a secret output.
+func newBrowseTestSnapshot() *deploy.Snapshot {
+	component := &resource.State{
+		Type: "my:index:Component",
+		URN:  resource.NewURN("dev", "proj", "", "my:index:Component", "comp"),
+	}
+	childA := &resource.State{
+		Type:   "pkg:index:Child",
+		URN:    resource.NewURN("dev", "proj", "my:index:Component", "pkg:index:Child", "a"),
+		Parent: component.URN,
+		Custom: true,
+		ID:     "a-id",
+	}
+	bucket := &resource.State{
+		Type:   "pkg:index:Bucket",
+		URN:    resource.NewURN("dev", "proj", "", "pkg:index:Bucket", "bucket"),
+		Custom: true,
+		ID:     "bucket-id",
+		Outputs: resource.PropertyMap{
+			"password": resource.MakeSecret(resource.NewStringProperty("hunter2")),
+		},
+	}
+	childB := &resource.State{
+		Type:         "pkg:index:Child",
+		URN:          resource.NewURN("dev", "proj", "my:index:Component", "pkg:index:Child", "b"),
+		Parent:       component.URN,
+		Custom:       true,
+		ID:           "b-id",
+		Dependencies: []resource.URN{bucket.URN},
+	}
+	return &deploy.Snapshot{Resources: []*resource.State{component, childA, bucket, childB}}
+}
+
+// newTestBrowseModel returns a browser over the given snapshot, with the snapshot already loaded.
+func newTestBrowseModel(t *testing.T, snap *deploy.Snapshot, browser stackBrowser) browseModel {
+	t.Helper()
+
+	browser.name = "org/proj/dev"
+	m := newBrowseModel(context.Background(), browser, colors.Never, false)
+	return browseUpdate(t, m, browseSnapshotMsg{snap: snap})
+}
+
+func browseUpdate(t *testing.T, m browseModel, msg tea.Msg) browseModel {
+	t.Helper()
+
+	next, _ := m.Update(msg)
+	bm, ok := next.(browseModel)
+	require.True(t, ok)
+	return bm
+}
+
+func browseKey(s string) tea.KeyMsg {
+	switch s {
+	case "left":
+		return tea.KeyMsg{Type: tea.KeyLeft}
+	case "right":
+		return tea.KeyMsg{Type: tea.KeyRight}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}
+	default:
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+}
+
+func rowNames(m browseModel) []string {
+	names := make([]string, len(m.rows))
+	for i, row := range m.rows {
+		names[i] = row.node.State.URN.Name()
+	}
+	return names
+}
+
+func TestStackBrowseTree(t *testing.T) {
+	t.Parallel()
+
+	m := newTestBrowseModel(t, newBrowseTestSnapshot(), stackBrowser{})
+
+	// Resources are shown as a tree in the order they appear in the snapshot.
+	assert.Equal(t, []string{"comp", "a", "b", "bucket"}, rowNames(m))
+	assert.Equal(t, 1, m.rows[1].depth)
+
+	// Collapsing the component hides its children, and expanding it shows them again.
+	m = browseUpdate(t, m, browseKey("left"))
+	assert.Equal(t, []string{"comp", "bucket"}, rowNames(m))
+	m = browseUpdate(t, m, browseKey("right"))
+	assert.Equal(t, []string{"comp", "a", "b", "bucket"}, rowNames(m))
+
+	// Moving left from a child selects its parent.
+	m = browseUpdate(t, m, browseKey("down"))
+	m = browseUpdate(t, m, browseKey("j"))
+	assert.Equal(t, "b", m.selected().State.URN.Name())
+	m = browseUpdate(t, m, browseKey("left"))
+	assert.Equal(t, "comp", m.selected().State.URN.Name())
+
+	// The cursor stops at the ends of the tree.
+	m = browseUpdate(t, m, browseKey("k"))
+	assert.Equal(t, 0, m.cursor)
+	m = browseUpdate(t, m, browseKey("G"))
+	assert.Equal(t, "bucket", m.selected().State.URN.Name())
+}
+
+func TestStackBrowseDetails(t *testing.T) {
+	t.Parallel()
+
+	snap := newBrowseTestSnapshot()
+	m := newTestBrowseModel(t, snap, stackBrowser{})
+
+	// Select the bucket, which b depends on.
+	m = browseUpdate(t, m, browseKey("G"))
+	view := m.View()
+	assert.Contains(t, view, "ID: bucket-id")
+	assert.Contains(t, view, "[secret]")
+	assert.NotContains(t, view, "hunter2")
+	assert.Equal(t, []resource.URN{snap.Resources[3].URN}, m.dependentsOf(snap.Resources[2]))
+
+	// Secrets can be toggled, and showing them is recorded so that it can be logged.
+	m = browseUpdate(t, m, browseKey("s"))
+	assert.Contains(t, m.View(), "hunter2")
+	assert.True(t, m.showedSecrets)
+	m = browseUpdate(t, m, browseKey("s"))
+	assert.NotContains(t, m.View(), "hunter2")
+
+	// b's details list the bucket as a dependency.
+	m = browseUpdate(t, m, browseKey("k"))
+	assert.Equal(t, []resource.URN{snap.Resources[2].URN}, m.dependenciesOf(m.selected().State))
+}
+
+func TestStackBrowseHistory(t *testing.T) {
+	t.Parallel()
+
+	m := newTestBrowseModel(t, newBrowseTestSnapshot(), stackBrowser{})
+	m = browseUpdate(t, m, browseKey("tab"))
+	assert.Contains(t, m.View(), "Loading history...")
+
+	m = browseUpdate(t, m, browseHistoryMsg{updates: []backend.UpdateInfo{{
+		Version: 3,
+		Kind:    "update",
+		Result:  backend.SucceededResult,
+		Message: "Add the bucket",
+	}}})
+	view := m.View()
+	assert.Contains(t, view, "Version 3: update succeeded")
+	assert.Contains(t, view, "Add the bucket")
+
+	m = browseUpdate(t, m, browseHistoryMsg{err: errors.New("boom")})
+	assert.Contains(t, m.View(), "Could not load history: boom")
+}
+
+func TestStackBrowseProtect(t *testing.T) {
+	t.Parallel()
+
+	var protected []resource.URN
+	snap := newBrowseTestSnapshot()
+	m := newTestBrowseModel(t, snap, stackBrowser{
+		setProtect: func(_ context.Context, urn resource.URN, protect bool) error {
+			assert.True(t, protect)
+			protected = append(protected, urn)
+			return nil
+		},
+		loadSnapshot: func(context.Context) (*deploy.Snapshot, error) {
+			return snap, nil
+		},
+		loadHistory: func(context.Context) ([]backend.UpdateInfo, error) {
+			return nil, nil
+		},
+	})
+
+	next, cmd := m.Update(browseKey("t"))
+	require.NotNil(t, cmd)
+	msg := cmd()
+	assert.Equal(t, []resource.URN{snap.Resources[0].URN}, protected)
+	assert.Equal(t, browseActionMsg{action: "protect", urn: snap.Resources[0].URN}, msg)
+
+	// Finishing the action reloads the stack.
+	m = next.(browseModel)
+	m = browseUpdate(t, m, msg)
+	assert.Equal(t, "protect of comp finished", m.status)
+}
+
+type fakeExecCommand struct{ ran bool }
+
+func (c *fakeExecCommand) Run() error          { c.ran = true; return nil }
+func (c *fakeExecCommand) SetStdin(io.Reader)  {}
+func (c *fakeExecCommand) SetStdout(io.Writer) {}
+func (c *fakeExecCommand) SetStderr(io.Writer) {}
+
+func TestStackBrowseTargetedOperations(t *testing.T) {
+	t.Parallel()
+
+	type call struct {
+		op  string
+		urn resource.URN
+	}
+	var calls []call
+	snap := newBrowseTestSnapshot()
+	m := newTestBrowseModel(t, snap, stackBrowser{
+		targetCommand: func(op string, urn resource.URN) (tea.ExecCommand, error) {
+			calls = append(calls, call{op, urn})
+			if op == "refresh" {
+				return nil, errors.New("no pulumi")
+			}
+			return &fakeExecCommand{}, nil
+		},
+	})
+	m = browseUpdate(t, m, browseKey("G"))
+
+	_, cmd := m.Update(browseKey("p"))
+	assert.NotNil(t, cmd)
+
+	m = browseUpdate(t, m, browseKey("r"))
+	assert.Equal(t, "refresh of bucket failed: no pulumi", m.status)
+
+	assert.Equal(t, []call{
+		{"preview", snap.Resources[2].URN},
+		{"refresh", snap.Resources[2].URN},
+	}, calls)
+}
+
+func TestStackBrowseLoadError(t *testing.T) {
+	t.Parallel()
+
+	m := newBrowseModel(context.Background(), stackBrowser{name: "org/proj/dev"}, colors.Never, false)
+	next, cmd := m.Update(browseSnapshotMsg{err: errors.New("boom")})
+	require.NotNil(t, cmd)
+	assert.EqualError(t, next.(browseModel).err, "loading stack org/proj/dev: boom")
+}
@@ -0,0 +1,428 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	cmdBackend "github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/backend"
+	"github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/ui"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	pkgWorkspace "github.com/pulumi/pulumi/pkg/v3/workspace"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// stackReferenceType is the type token of the resource that records a read of another stack's outputs.
+const stackReferenceType = "pulumi:pulumi:StackReference"
+
+// StackDependency records that a stack read the outputs of another stack through a StackReference during its last
+// update.
+type StackDependency struct {
+	// Stack is the fully qualified name of the stack that read the outputs.
+	Stack string `json:"stack"`
+	// Upstream is the fully qualified name of the stack whose outputs were read.
+	Upstream string `json:"upstream"`
+	// Outputs are the names of the upstream outputs that were read. If the program's SDK doesn't record which outputs
+	// it reads, these are the names of all of the upstream's outputs at the time.
+	Outputs []string `json:"outputs,omitempty"`
+	// Changed are the names of the outputs whose current value in the upstream stack differs from the value that was
+	// read, including outputs that have been added to or removed from the upstream stack since.
+	Changed []string `json:"changed,omitempty"`
+	// Missing is true if the upstream stack no longer exists.
+	Missing bool `json:"missing,omitempty"`
+}
+
+// StackGraph is the graph of dependencies between stacks that is formed by their StackReferences.
+type StackGraph struct {
+	// Stacks are the fully qualified names of the stacks in the graph, in sorted order.
+	Stacks []string `json:"stacks"`
+	// Dependencies are the edges of the graph.
+	Dependencies []StackDependency `json:"dependencies"`
+}
+
+// Upstream returns the stacks in the graph whose outputs were read by the given stack.
+func (g *StackGraph) Upstream(name string) []string {
+	var upstream []string
+	for _, d := range g.Dependencies {
+		if d.Stack == name {
+			upstream = append(upstream, d.Upstream)
+		}
+	}
+	return upstream
+}
+
+// TopologicalOrder returns the stacks in the graph ordered such that every stack comes after the stacks it reads
+// outputs from. Stacks that don't depend on each other are ordered by name. An error is returned if the graph contains
+// a cycle.
+func (g *StackGraph) TopologicalOrder() ([]string, error) {
+	inGraph := map[string]bool{}
+	for _, s := range g.Stacks {
+		inGraph[s] = true
+	}
+	indegree := map[string]int{}
+	dependents := map[string][]string{}
+	for _, d := range g.Dependencies {
+		if !inGraph[d.Upstream] || d.Upstream == d.Stack {
+			continue
+		}
+		indegree[d.Stack]++
+		dependents[d.Upstream] = append(dependents[d.Upstream], d.Stack)
+	}
+
+	var ready []string
+	for _, s := range g.Stacks {
+		if indegree[s] == 0 {
+			ready = append(ready, s)
+		}
+	}
+
+	order := make([]string, 0, len(g.Stacks))
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		s := ready[0]
+		ready = ready[1:]
+		order = append(order, s)
+		for _, d := range dependents[s] {
+			if indegree[d]--; indegree[d] == 0 {
+				ready = append(ready, d)
+			}
+		}
+	}
+
+	if len(order) != len(g.Stacks) {
+		var cycle []string
+		for _, s := range g.Stacks {
+			if indegree[s] > 0 {
+				cycle = append(cycle, s)
+			}
+		}
+		return nil, fmt.Errorf("stack references form a cycle between %s", strings.Join(cycle, ", "))
+	}
+	return order, nil
+}
+
+// LoadStackGraph builds the graph of dependencies between the given stacks from the StackReference resources that
+// were recorded in each stack's last deployment. Upstream stacks that aren't among the given stacks are loaded as
+// needed to check whether the outputs that were read have since changed, but are not added to the graph's stacks.
+func LoadStackGraph(ctx context.Context, b backend.Backend, stacks []backend.Stack) (*StackGraph, error) {
+	snapshots := map[string]*deploy.Snapshot{}
+	loadSnapshot := func(s backend.Stack) (*deploy.Snapshot, error) {
+		name := s.Ref().FullyQualifiedName().String()
+		if snap, ok := snapshots[name]; ok {
+			return snap, nil
+		}
+		snap, err := s.Snapshot(ctx, stack.DefaultSecretsProvider)
+		if err != nil {
+			return nil, fmt.Errorf("loading stack %s: %w", name, err)
+		}
+		snapshots[name] = snap
+		return snap, nil
+	}
+
+	missing := map[string]bool{}
+	g := &StackGraph{}
+	for _, s := range stacks {
+		name := s.Ref().FullyQualifiedName().String()
+		g.Stacks = append(g.Stacks, name)
+
+		snap, err := loadSnapshot(s)
+		if err != nil {
+			return nil, err
+		}
+		if snap == nil {
+			continue
+		}
+
+		for _, res := range snap.Resources {
+			if res.Type != stackReferenceType || res.Delete {
+				continue
+			}
+			refName := res.Outputs["name"]
+			if !refName.IsString() {
+				refName = res.Inputs["name"]
+			}
+			if !refName.IsString() {
+				continue
+			}
+
+			read, names := readStackReferenceOutputs(res)
+			outputs := names
+			if outputs == nil {
+				for _, k := range read.StableKeys() {
+					outputs = append(outputs, string(k))
+				}
+			}
+
+			// Stacks in other backends can't be updated alongside this backend's stacks, so they're listed as
+			// upstreams without checking them for changes.
+			if _, _, ok := backend.ParseCrossBackendStackReference(refName.StringValue()); ok {
+				g.Dependencies = append(g.Dependencies, StackDependency{
+					Stack:    name,
+					Upstream: refName.StringValue(),
+					Outputs:  outputs,
+				})
+				continue
+			}
+
+			upstreamRef, err := b.ParseStackReference(qualifyStackReferenceName(s.Ref(), refName.StringValue()))
+			if err != nil {
+				return nil, fmt.Errorf("stack %s references %q: %w", name, refName.StringValue(), err)
+			}
+			dep := StackDependency{
+				Stack:    name,
+				Upstream: upstreamRef.FullyQualifiedName().String(),
+				Outputs:  outputs,
+			}
+
+			if !missing[dep.Upstream] {
+				upstream, err := b.GetStack(ctx, upstreamRef)
+				if err != nil {
+					return nil, err
+				}
+				if upstream == nil {
+					missing[dep.Upstream] = true
+				} else {
+					upstreamSnap, err := loadSnapshot(upstream)
+					if err != nil {
+						return nil, err
+					}
+					var current resource.PropertyMap
+					if root, err := stack.GetRootStackResource(upstreamSnap); err == nil && root != nil {
+						current = root.Outputs
+					}
+					dep.Changed = changedOutputs(read, current, names)
+				}
+			}
+			dep.Missing = missing[dep.Upstream]
+
+			g.Dependencies = append(g.Dependencies, dep)
+		}
+	}
+
+	sort.Strings(g.Stacks)
+	sort.SliceStable(g.Dependencies, func(i, j int) bool {
+		if g.Dependencies[i].Stack != g.Dependencies[j].Stack {
+			return g.Dependencies[i].Stack < g.Dependencies[j].Stack
+		}
+		return g.Dependencies[i].Upstream < g.Dependencies[j].Upstream
+	})
+	return g, nil
+}
+
+// qualifyStackReferenceName qualifies a stack name that was passed to a StackReference by the program of the given
+// stack, so that it can be parsed independently of the current project. Unqualified names refer to a stack in the
+// same organization and project as the referencing stack.
+func qualifyStackReferenceName(ref backend.StackReference, name string) string {
+	if strings.Contains(name, "/") {
+		return name
+	}
+	parts := strings.Split(ref.FullyQualifiedName().String(), "/")
+	if len(parts) != 3 {
+		return name
+	}
+	return fmt.Sprintf("%s/%s/%s", parts[0], parts[1], name)
+}
+
+// readStackReferenceOutputs returns the upstream outputs that a StackReference resource saw and the sorted names of the
+// outputs that the program read from it. The names are recorded in the resource's readOutputs property by SDKs that
+// report their reads. If no reads were recorded, e.g. because the StackReference was read by another SDK, the names are
+// nil and every output should be assumed to have been read.
+func readStackReferenceOutputs(res *resource.State) (resource.PropertyMap, []string) {
+	var seen resource.PropertyMap
+	if v := res.Outputs["outputs"]; v.IsObject() {
+		seen = v.ObjectValue()
+	}
+
+	v, ok := res.Outputs["readOutputs"]
+	if !ok || !v.IsArray() {
+		return seen, nil
+	}
+	names := []string{}
+	for _, n := range v.ArrayValue() {
+		if n.IsString() {
+			names = append(names, n.StringValue())
+		}
+	}
+	sort.Strings(names)
+	return seen, names
+}
+
+// changedOutputs returns the sorted names of the given outputs whose values differ between the outputs that were seen
+// and the current outputs, including outputs that have since been added or removed. If names is nil, every output
+// of either map is compared.
+func changedOutputs(seen, current resource.PropertyMap, names []string) []string {
+	if names == nil {
+		all := map[string]bool{}
+		for k := range seen {
+			all[string(k)] = true
+		}
+		for k := range current {
+			all[string(k)] = true
+		}
+		for k := range all {
+			names = append(names, k)
+		}
+	}
+
+	var changed []string
+	for _, n := range names {
+		k := resource.PropertyKey(n)
+		v, wasSeen := seen[k]
+		cur, isCurrent := current[k]
+		if wasSeen != isCurrent || (wasSeen && !v.DeepEquals(cur)) {
+			changed = append(changed, n)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func newStackDepsCmd() *cobra.Command {
+	var all bool
+	var jsonOut bool
+
+	cmd := &cobra.Command{
+		Use:   "deps",
+		Args:  cmdutil.NoArgs,
+		Short: "Show the dependencies between stacks formed by stack references",
+		Long: "Show the dependencies between stacks formed by stack references.\n" +
+			"\n" +
+			"Every time a program reads the outputs of another stack with a StackReference, the outputs it\n" +
+			"read are recorded in its stack's state. This command uses those records to show which stacks\n" +
+			"depend on which, the order in which they should be updated, and which stacks read outputs that\n" +
+			"have since changed in the stack they were read from and so need to be updated.\n" +
+			"\n" +
+			"By default only the stacks of the current project are shown. Use `--all` to show every stack.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			ws := pkgWorkspace.Instance
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			project, _, err := ws.ReadProject()
+			if err != nil && !errors.Is(err, workspace.ErrProjectNotFound) {
+				return err
+			}
+			if project == nil && !all {
+				return errors.New("no Pulumi.yaml found; please run this command in a project directory or pass --all")
+			}
+
+			b, err := cmdBackend.CurrentBackend(ctx, ws, cmdBackend.DefaultLoginManager, project, opts)
+			if err != nil {
+				return err
+			}
+
+			var filter backend.ListStackNamesFilter
+			if !all {
+				projectName := string(project.Name)
+				filter.Project = &projectName
+			}
+			stacks, err := ListStacks(ctx, b, filter)
+			if err != nil {
+				return err
+			}
+
+			g, err := LoadStackGraph(ctx, b, stacks)
+			if err != nil {
+				return err
+			}
+			if jsonOut {
+				return ui.FprintJSON(cmd.OutOrStdout(), g)
+			}
+			return renderStackGraph(cmd.OutOrStdout(), g)
+		},
+	}
+
+	cmd.PersistentFlags().BoolVarP(
+		&all, "all", "a", false,
+		"Show the stacks of every project, rather than just the current one")
+	cmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false,
+		"Emit the graph as JSON")
+
+	return cmd
+}
+
+// ListStacks returns every stack in the backend that matches the given filter.
+func ListStacks(ctx context.Context, b backend.Backend, filter backend.ListStackNamesFilter) ([]backend.Stack, error) {
+	var stacks []backend.Stack
+	var inContToken backend.ContinuationToken
+	for {
+		refs, outContToken, err := b.ListStackNames(ctx, filter, inContToken)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range refs {
+			s, err := b.GetStack(ctx, ref)
+			if err != nil {
+				return nil, err
+			}
+			if s != nil {
+				stacks = append(stacks, s)
+			}
+		}
+		if outContToken == nil {
+			return stacks, nil
+		}
+		inContToken = outContToken
+	}
+}
+
+func renderStackGraph(out io.Writer, g *StackGraph) error {
+	if len(g.Dependencies) == 0 {
+		fmt.Fprintln(out, "No stack references found.")
+		return nil
+	}
+
+	rows := make([]cmdutil.TableRow, 0, len(g.Dependencies))
+	for _, d := range g.Dependencies {
+		status := "up to date"
+		switch {
+		case d.Missing:
+			status = "upstream stack not found"
+		case len(d.Changed) > 0:
+			status = "changed: " + strings.Join(d.Changed, ", ")
+		}
+		rows = append(rows, cmdutil.TableRow{Columns: []string{d.Stack, d.Upstream, status}})
+	}
+	ui.FprintTable(out, cmdutil.Table{
+		Headers: []string{"STACK", "READS FROM", "OUTPUTS"},
+		Rows:    rows,
+	}, nil)
+
+	order, err := g.TopologicalOrder()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Update order:")
+	for i, s := range order {
+		fmt.Fprintf(out, "    %d. %s\n", i+1, s)
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+// newDepsTestStack returns a mock stack with the given outputs that references each of the given stacks, having read
+// the given outputs from them.
+func newDepsTestStack(
+	t *testing.T, b *backend.MockBackend, name string, outputs resource.PropertyMap,
+	refs map[string]resource.PropertyMap,
+) backend.Stack {
+	ref, err := b.ParseStackReference("org/proj/" + name)
+	require.NoError(t, err)
+
+	root := &resource.State{
+		Type:    resource.RootStackType,
+		URN:     resource.URN("urn:pulumi:" + name + "::proj::pulumi:pulumi:Stack::proj-" + name),
+		Outputs: outputs,
+	}
+	resources := []*resource.State{root}
+	for refName, read := range refs {
+		resources = append(resources, &resource.State{
+			Type:   stackReferenceType,
+			URN:    resource.URN("urn:pulumi:" + name + "::proj::pulumi:pulumi:StackReference::" + refName),
+			Custom: true,
+			Inputs: resource.PropertyMap{"name": resource.NewStringProperty(refName)},
+			Outputs: resource.PropertyMap{
+				"name":    resource.NewStringProperty(refName),
+				"outputs": resource.NewObjectProperty(read),
+			},
+		})
+	}
+	snap := deploy.NewSnapshot(deploy.Manifest{}, nil, resources, nil, deploy.SnapshotMetadata{})
+
+	return &backend.MockStack{
+		RefF: func() backend.StackReference { return ref },
+		SnapshotF: func(context.Context, secrets.Provider) (*deploy.Snapshot, error) {
+			return snap, nil
+		},
+	}
+}
+
+func TestLoadStackGraph(t *testing.T) {
+	t.Parallel()
+
+	b := &backend.MockBackend{}
+	network := newDepsTestStack(t, b, "network", resource.PropertyMap{
+		"vpcId":   resource.NewStringProperty("vpc-2"),
+		"subnets": resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("a")}),
+	}, nil)
+	db := newDepsTestStack(t, b, "db", resource.PropertyMap{
+		"endpoint": resource.NewStringProperty("db.internal"),
+	}, map[string]resource.PropertyMap{
+		"network": {
+			"vpcId":   resource.NewStringProperty("vpc-1"),
+			"subnets": resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("a")}),
+		},
+	})
+	app := newDepsTestStack(t, b, "app", nil, map[string]resource.PropertyMap{
+		"org/proj/db": {"endpoint": resource.NewStringProperty("db.internal")},
+		"other/old":   {"url": resource.NewStringProperty("https://example.com")},
+		"s3://bucket?region=us-west-2#org/proj/shared": {"zone": resource.NewStringProperty("example.com")},
+	})
+
+	stacks := map[string]backend.Stack{"org/proj/network": network, "org/proj/db": db, "org/proj/app": app}
+	b.GetStackF = func(_ context.Context, ref backend.StackReference) (backend.Stack, error) {
+		return stacks[ref.FullyQualifiedName().String()], nil
+	}
+
+	g, err := LoadStackGraph(context.Background(), b, []backend.Stack{network, db, app})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"org/proj/app", "org/proj/db", "org/proj/network"}, g.Stacks)
+	assert.Equal(t, []StackDependency{
+		{Stack: "org/proj/app", Upstream: "org/proj/db", Outputs: []string{"endpoint"}},
+		{Stack: "org/proj/app", Upstream: "other/old", Outputs: []string{"url"}, Missing: true},
+		{Stack: "org/proj/app", Upstream: "s3://bucket?region=us-west-2#org/proj/shared", Outputs: []string{"zone"}},
+		{
+			Stack:    "org/proj/db",
+			Upstream: "org/proj/network",
+			Outputs:  []string{"subnets", "vpcId"},
+			Changed:  []string{"vpcId"},
+		},
+	}, g.Dependencies)
+	assert.Equal(t, []string{"org/proj/db", "other/old", "s3://bucket?region=us-west-2#org/proj/shared"},
+		g.Upstream("org/proj/app"))
+
+	order, err := g.TopologicalOrder()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"org/proj/network", "org/proj/db", "org/proj/app"}, order)
+
+	var out bytes.Buffer
+	require.NoError(t, renderStackGraph(&out, g))
+	assert.Contains(t, out.String(), "changed: vpcId")
+	assert.Contains(t, out.String(), "upstream stack not found")
+	assert.Contains(t, out.String(), "    3. org/proj/app\n")
+}
+
+func TestLoadStackGraphRecordedReads(t *testing.T) {
+	t.Parallel()
+
+	b := &backend.MockBackend{}
+	network := newDepsTestStack(t, b, "network", resource.PropertyMap{
+		"vpcId":   resource.NewStringProperty("vpc-2"),
+		"subnets": resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("a")}),
+		"zone":    resource.NewStringProperty("example.com"),
+	}, nil)
+	db := newDepsTestStack(t, b, "db", nil, map[string]resource.PropertyMap{
+		"network": {
+			"vpcId":   resource.NewStringProperty("vpc-1"),
+			"subnets": resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("a")}),
+		},
+	})
+
+	// The db stack's program only read the subnets, so changes to other outputs don't affect it.
+	snap, err := db.Snapshot(context.Background(), nil)
+	require.NoError(t, err)
+	for _, res := range snap.Resources {
+		if res.Type == stackReferenceType {
+			res.Outputs["readOutputs"] = resource.NewArrayProperty([]resource.PropertyValue{
+				resource.NewStringProperty("subnets"),
+			})
+		}
+	}
+
+	stacks := map[string]backend.Stack{"org/proj/network": network, "org/proj/db": db}
+	b.GetStackF = func(_ context.Context, ref backend.StackReference) (backend.Stack, error) {
+		return stacks[ref.FullyQualifiedName().String()], nil
+	}
+
+	g, err := LoadStackGraph(context.Background(), b, []backend.Stack{network, db})
+	require.NoError(t, err)
+	assert.Equal(t, []StackDependency{
+		{Stack: "org/proj/db", Upstream: "org/proj/network", Outputs: []string{"subnets"}},
+	}, g.Dependencies)
+}
+
+func TestStackGraphTopologicalOrderCycle(t *testing.T) {
+	t.Parallel()
+
+	g := &StackGraph{
+		Stacks: []string{"a", "b", "c"},
+		Dependencies: []StackDependency{
+			{Stack: "a", Upstream: "b"},
+			{Stack: "b", Upstream: "a"},
+		},
+	}
+	_, err := g.TopologicalOrder()
+	assert.EqualError(t, err, "stack references form a cycle between a, b")
+}
+
+func TestChangedOutputs(t *testing.T) {
+	t.Parallel()
+
+	read := resource.PropertyMap{
+		"same":    resource.NewStringProperty("x"),
+		"changed": resource.NewNumberProperty(1),
+		"removed": resource.NewBoolProperty(true),
+		"secret":  resource.MakeSecret(resource.NewStringProperty("s")),
+	}
+	current := resource.PropertyMap{
+		"same":    resource.NewStringProperty("x"),
+		"changed": resource.NewNumberProperty(2),
+		"added":   resource.NewStringProperty("y"),
+		"secret":  resource.MakeSecret(resource.NewStringProperty("s")),
+	}
+	assert.Equal(t, []string{"added", "changed", "removed"}, changedOutputs(read, current, nil))
+	assert.Empty(t, changedOutputs(current, current, nil))
+	assert.Equal(t, []string{"added"}, changedOutputs(read, current, []string{"same", "added", "secret"}))
+	assert.Empty(t, changedOutputs(read, current, []string{}))
+}
@@ -16,16 +16,20 @@ package stack
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"reflect"
+	"regexp"
 	"runtime"
 	"sort"
 	"strings"
 
 	"github.com/kballard/go-shellquote"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 
 	"github.com/pulumi/pulumi/pkg/v3/backend"
 	"github.com/pulumi/pulumi/pkg/v3/backend/display"
@@ -35,9 +39,11 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
 	pkgWorkspace "github.com/pulumi/pulumi/pkg/v3/workspace"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/slice"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
 )
 
 func newStackOutputCmd() *cobra.Command {
@@ -49,7 +55,12 @@ func newStackOutputCmd() *cobra.Command {
 		Long: "Show a stack's output properties.\n" +
 			"\n" +
 			"By default, this command lists all output properties exported from a stack.\n" +
-			"If a specific property-name is supplied, just that property's value is shown.",
+			"If a specific property-name is supplied, just that property's value is shown.\n" +
+			"With `--path`, the property-name may be a path into a structured output,\n" +
+			"e.g. `db.endpoint` or `subnets[0].id`.\n" +
+			"\n" +
+			"Use `--since` to show only the outputs that have changed since a previous\n" +
+			"version of the stack, as listed by `pulumi stack history`.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return socmd.Run(cmd.Context(), args)
 		},
@@ -59,6 +70,16 @@ func newStackOutputCmd() *cobra.Command {
 		&socmd.jsonOut, "json", "j", false, "Emit output as JSON")
 	cmd.PersistentFlags().BoolVar(
 		&socmd.shellOut, "shell", false, "Emit output as a shell script")
+	cmd.PersistentFlags().StringVar(
+		&socmd.format, "format", "",
+		"Emit output in the given format: `json`, `shell`, `dotenv`, `tfvars`, "+
+			"`configmap` (a Kubernetes ConfigMap) or `secret` (a Kubernetes Secret, which implies --show-secrets)")
+	cmd.PersistentFlags().BoolVar(
+		&socmd.path, "path", false,
+		"Parse the property name as a path into a structured output")
+	cmd.PersistentFlags().StringVar(
+		&socmd.since, "since", "",
+		"Only show outputs that were added or changed since the given stack version")
 	cmd.PersistentFlags().StringVarP(
 		&socmd.stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
 	cmd.PersistentFlags().BoolVar(
@@ -72,6 +93,9 @@ type stackOutputCmd struct {
 	showSecrets bool
 	jsonOut     bool
 	shellOut    bool
+	format      string
+	path        bool
+	since       string
 
 	ws pkgWorkspace.Context
 	OS string // defaults to runtime.GOOS
@@ -87,6 +111,31 @@ type stackOutputCmd struct {
 	Stdout io.Writer // defaults to os.Stdout
 }
 
+// outputFormat returns the format selected by the --json, --shell and --format flags.
+func (cmd *stackOutputCmd) outputFormat() (string, error) {
+	if cmd.shellOut && cmd.jsonOut {
+		return "", errors.New("only one of --json and --shell may be set")
+	}
+	if cmd.format != "" && (cmd.shellOut || cmd.jsonOut) {
+		return "", errors.New("--format may not be combined with --json or --shell")
+	}
+
+	switch {
+	case cmd.jsonOut:
+		return "json", nil
+	case cmd.shellOut:
+		return "shell", nil
+	}
+
+	switch cmd.format {
+	case "", "json", "shell", "dotenv", "tfvars", "configmap", "secret":
+		return cmd.format, nil
+	default:
+		return "", fmt.Errorf("unknown output format %q; expected one of "+
+			"json, shell, dotenv, tfvars, configmap or secret", cmd.format)
+	}
+}
+
 func (cmd *stackOutputCmd) Run(ctx context.Context, args []string) error {
 	opts := display.Options{
 		Color: cmdutil.GetGlobalColorization(),
@@ -111,16 +160,15 @@ func (cmd *stackOutputCmd) Run(ctx context.Context, args []string) error {
 		stdout = cmd.Stdout
 	}
 
-	var outw stackOutputWriter
-	if cmd.shellOut && cmd.jsonOut {
-		return errors.New("only one of --json and --shell may be set")
-	} else if cmd.jsonOut {
-		outw = &jsonStackOutputWriter{W: stdout}
-	} else if cmd.shellOut {
-		outw = newShellStackOutputWriter(stdout, osys)
-	} else {
-		outw = &consoleStackOutputWriter{W: stdout}
+	format, err := cmd.outputFormat()
+	if err != nil {
+		return err
 	}
+	if cmd.since != "" && len(args) > 0 {
+		return errors.New("--since may not be used with a property name")
+	}
+	// A Kubernetes Secret exists to hold secret values, and would otherwise hold the encoded text "[secret]".
+	showSecrets := cmd.showSecrets || format == "secret"
 
 	// Fetch the current stack and its output properties.
 	s, err := requireStack(
@@ -140,7 +188,7 @@ func (cmd *stackOutputCmd) Run(ctx context.Context, args []string) error {
 		return err
 	}
 
-	outputs, err := getStackOutputs(snap, cmd.showSecrets)
+	outputs, err := getStackOutputs(snap, showSecrets)
 	if err != nil {
 		return fmt.Errorf("getting outputs: %w", err)
 	}
@@ -148,16 +196,62 @@ func (cmd *stackOutputCmd) Run(ctx context.Context, args []string) error {
 		outputs = make(map[string]interface{})
 	}
 
+	var outw stackOutputWriter
+	switch format {
+	case "json":
+		outw = &jsonStackOutputWriter{W: stdout}
+	case "shell":
+		outw = newShellStackOutputWriter(stdout, osys)
+	case "dotenv":
+		outw = &dotenvStackOutputWriter{W: stdout}
+	case "tfvars":
+		outw = &tfvarsStackOutputWriter{W: stdout}
+	case "configmap", "secret":
+		outw = &kubernetesStackOutputWriter{
+			W:      stdout,
+			Name:   kubernetesName(s.Ref().Name().String() + "-outputs"),
+			Secret: format == "secret",
+		}
+	default:
+		outw = &consoleStackOutputWriter{W: stdout}
+	}
+
 	// If there is an argument, just print that property.  Else, print them all (similar to `pulumi stack`).
 	if len(args) > 0 {
 		name := args[0]
 		v, has := outputs[name]
+		if cmd.path {
+			v, has, name, err = lookupStackOutputPath(outputs, name)
+			if err != nil {
+				return err
+			}
+		}
 		if has {
 			if err := outw.WriteOne(name, v); err != nil {
 				return err
 			}
 		} else {
-			return fmt.Errorf("current stack does not have output property '%v'", name)
+			return fmt.Errorf("current stack does not have output property '%v'", args[0])
+		}
+	} else if cmd.since != "" {
+		changes, err := cmd.changesSince(ctx, s, snap)
+		if err != nil {
+			return err
+		}
+		if cw, ok := outw.(*consoleStackOutputWriter); ok {
+			if err := cw.WriteChanges(cmd.since, outputs, changes); err != nil {
+				return err
+			}
+		} else {
+			changed := map[string]interface{}{}
+			for _, c := range changes {
+				if c.kind != outputRemoved {
+					changed[c.name] = outputs[c.name]
+				}
+			}
+			if err := outw.WriteMany(changed); err != nil {
+				return err
+			}
 		}
 	} else {
 		if err := outw.WriteMany(outputs); err != nil {
@@ -165,13 +259,114 @@ func (cmd *stackOutputCmd) Run(ctx context.Context, args []string) error {
 		}
 	}
 
-	if cmd.showSecrets {
+	if showSecrets {
 		Log3rdPartySecretsProviderDecryptionEvent(ctx, s, "", "pulumi stack output")
 	}
 
 	return nil
 }
 
+// lookupStackOutputPath returns the value at the given property path within the stack's outputs, along with a name
+// for it: the last named element of the path.
+func lookupStackOutputPath(
+	outputs map[string]interface{}, path string,
+) (value interface{}, has bool, name string, err error) {
+	p, err := resource.ParsePropertyPath(path)
+	if err != nil {
+		return nil, false, "", fmt.Errorf("invalid property path %q: %w", path, err)
+	}
+
+	name = path
+	var v interface{} = outputs
+	for _, elem := range p {
+		switch elem := elem.(type) {
+		case string:
+			obj, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, false, "", nil
+			}
+			if v, ok = obj[elem]; !ok {
+				return nil, false, "", nil
+			}
+			name = elem
+		case int:
+			arr, ok := v.([]interface{})
+			if !ok || elem < 0 || elem >= len(arr) {
+				return nil, false, "", nil
+			}
+			v = arr[elem]
+		}
+	}
+	return v, true, name, nil
+}
+
+type outputChangeKind string
+
+const (
+	outputAdded   outputChangeKind = "+"
+	outputChanged outputChangeKind = "~"
+	outputRemoved outputChangeKind = "-"
+)
+
+// outputChange records how a single stack output differs from a previous version of the stack.
+type outputChange struct {
+	name string
+	kind outputChangeKind
+}
+
+// changesSince compares the outputs in the given snapshot with those at the version given by --since. Values are
+// compared in plaintext, so that changes to secret outputs are detected even if they are not displayed.
+func (cmd *stackOutputCmd) changesSince(
+	ctx context.Context, s backend.Stack, snap *deploy.Snapshot,
+) ([]outputChange, error) {
+	be := s.Backend()
+	exporter, ok := be.(backend.SpecificDeploymentExporter)
+	if !ok {
+		return nil, fmt.Errorf("the current backend (%s) does not provide the ability to export previous deployments",
+			be.Name())
+	}
+	deployment, err := exporter.ExportDeploymentForVersion(ctx, s, cmd.since)
+	if err != nil {
+		return nil, err
+	}
+	prevSnap, err := stack.DeserializeUntypedDeployment(ctx, deployment, stack.DefaultSecretsProvider)
+	if err != nil {
+		return nil, stack.FormatDeploymentDeserializationError(err, s.Ref().Name().String())
+	}
+
+	prev, err := getStackOutputs(prevSnap, true /* showSecrets */)
+	if err != nil {
+		return nil, fmt.Errorf("getting outputs for version %s: %w", cmd.since, err)
+	}
+	cur, err := getStackOutputs(snap, true /* showSecrets */)
+	if err != nil {
+		return nil, fmt.Errorf("getting outputs: %w", err)
+	}
+
+	return diffStackOutputs(prev, cur), nil
+}
+
+// diffStackOutputs returns the outputs that were added, changed or removed between prev and cur, sorted by name.
+func diffStackOutputs(prev, cur map[string]interface{}) []outputChange {
+	var changes []outputChange
+	for k, v := range cur {
+		old, has := prev[k]
+		switch {
+		case !has:
+			changes = append(changes, outputChange{name: k, kind: outputAdded})
+		case !reflect.DeepEqual(old, v):
+			changes = append(changes, outputChange{name: k, kind: outputChanged})
+		}
+	}
+	for k := range prev {
+		if _, has := cur[k]; !has {
+			changes = append(changes, outputChange{name: k, kind: outputRemoved})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].name < changes[j].name })
+	return changes
+}
+
 // stackOutputWriter writes one or more properties to stdout
 // on behalf of 'pulumi stack output'.
 type stackOutputWriter interface {
@@ -195,6 +390,36 @@ func (w *consoleStackOutputWriter) WriteMany(outputs map[string]interface{}) err
 	return fprintStackOutputs(w.W, outputs)
 }
 
+// WriteChanges writes the outputs that changed since the given version of the stack.
+func (w *consoleStackOutputWriter) WriteChanges(
+	version string, outputs map[string]interface{}, changes []outputChange,
+) error {
+	_, err := fmt.Fprintf(w.W, "Stack outputs changed since version %s (%d):\n", version, len(changes))
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		_, err = fmt.Fprintf(w.W, "    No output values have changed\n")
+		return err
+	}
+
+	rows := []cmdutil.TableRow{}
+	for _, c := range changes {
+		value := ""
+		if c.kind != outputRemoved {
+			value = stringifyOutput(outputs[c.name])
+		}
+		rows = append(rows, cmdutil.TableRow{Columns: []string{string(c.kind), c.name, value}})
+	}
+
+	return cmdutil.FprintTable(w.W, cmdutil.Table{
+		Headers: []string{"", "OUTPUT", "VALUE"},
+		Rows:    rows,
+		Prefix:  "    ",
+	})
+}
+
 // jsonStackOutputWriter writes stack outputs as machine-parseable JSON.
 type jsonStackOutputWriter struct {
 	W io.Writer
@@ -280,6 +505,173 @@ func (w *powershellStackOutputWriter) WriteMany(outputs map[string]interface{})
 	return nil
 }
 
+// dotenvStackOutputWriter prints stack outputs as a dotenv file.
+type dotenvStackOutputWriter struct {
+	W io.Writer
+}
+
+var _ stackOutputWriter = (*dotenvStackOutputWriter)(nil)
+
+func (w *dotenvStackOutputWriter) WriteOne(k string, v interface{}) error {
+	// Double-quoted dotenv values support backslash escapes, which lets us keep multi-line values on one line.
+	s := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`).Replace(stringifyOutput(v))
+	_, err := fmt.Fprintf(w.W, "%v=\"%v\"\n", k, s)
+	return err
+}
+
+func (w *dotenvStackOutputWriter) WriteMany(outputs map[string]interface{}) error {
+	for _, k := range sortedOutputKeys(outputs) {
+		if err := w.WriteOne(k, outputs[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tfvarsStackOutputWriter prints stack outputs as a Terraform variable definitions (.tfvars) file.
+type tfvarsStackOutputWriter struct {
+	W io.Writer
+}
+
+var _ stackOutputWriter = (*tfvarsStackOutputWriter)(nil)
+
+func (w *tfvarsStackOutputWriter) WriteOne(k string, v interface{}) error {
+	var b strings.Builder
+	writeHCLValue(&b, v, "")
+	_, err := fmt.Fprintf(w.W, "%v = %v\n", hclKey(k), b.String())
+	return err
+}
+
+func (w *tfvarsStackOutputWriter) WriteMany(outputs map[string]interface{}) error {
+	for _, k := range sortedOutputKeys(outputs) {
+		if err := w.WriteOne(k, outputs[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var hclIdentifierRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+// hclKey returns k as an HCL attribute or object key, quoting it if it is not a valid identifier.
+func hclKey(k string) string {
+	if hclIdentifierRegexp.MatchString(k) {
+		return k
+	}
+	var b strings.Builder
+	writeHCLValue(&b, k, "")
+	return b.String()
+}
+
+// writeHCLValue writes v, a JSON-like value, to b as an HCL literal expression.
+func writeHCLValue(b *strings.Builder, v interface{}, indent string) {
+	switch v := v.(type) {
+	case nil:
+		b.WriteString("null")
+	case string:
+		// HCL string escapes are a superset of JSON's, but HCL also treats ${ and %{ as template sequences.
+		quoted, err := ui.MakeJSONString(v, false /* single line */)
+		contract.AssertNoErrorf(err, "marshaling a string cannot fail")
+		quoted = strings.NewReplacer("${", "$${", "%{", "%%{").Replace(quoted)
+		b.WriteString(quoted)
+	case []interface{}:
+		if len(v) == 0 {
+			b.WriteString("[]")
+			return
+		}
+		b.WriteString("[\n")
+		for _, e := range v {
+			b.WriteString(indent + "  ")
+			writeHCLValue(b, e, indent+"  ")
+			b.WriteString(",\n")
+		}
+		b.WriteString(indent + "]")
+	case map[string]interface{}:
+		if len(v) == 0 {
+			b.WriteString("{}")
+			return
+		}
+		b.WriteString("{\n")
+		for _, k := range sortedOutputKeys(v) {
+			b.WriteString(indent + "  " + hclKey(k) + " = ")
+			writeHCLValue(b, v[k], indent+"  ")
+			b.WriteString("\n")
+		}
+		b.WriteString(indent + "}")
+	default:
+		// Numbers and booleans are written the same way in HCL as in JSON.
+		b.WriteString(stringifyOutput(v))
+	}
+}
+
+// kubernetesStackOutputWriter prints stack outputs as a Kubernetes ConfigMap or Secret manifest.
+type kubernetesStackOutputWriter struct {
+	W      io.Writer
+	Name   string // the name of the ConfigMap or Secret.
+	Secret bool   // true to emit a Secret rather than a ConfigMap.
+}
+
+var _ stackOutputWriter = (*kubernetesStackOutputWriter)(nil)
+
+func (w *kubernetesStackOutputWriter) WriteOne(k string, v interface{}) error {
+	return w.WriteMany(map[string]interface{}{k: v})
+}
+
+func (w *kubernetesStackOutputWriter) WriteMany(outputs map[string]interface{}) error {
+	type metadata struct {
+		Name string `yaml:"name"`
+	}
+	type manifest struct {
+		APIVersion string            `yaml:"apiVersion"`
+		Kind       string            `yaml:"kind"`
+		Metadata   metadata          `yaml:"metadata"`
+		Type       string            `yaml:"type,omitempty"`
+		Data       map[string]string `yaml:"data"`
+	}
+
+	m := manifest{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Metadata:   metadata{Name: w.Name},
+		Data:       make(map[string]string, len(outputs)),
+	}
+	if w.Secret {
+		m.Kind, m.Type = "Secret", "Opaque"
+	}
+	for k, v := range outputs {
+		s := stringifyOutput(v)
+		if w.Secret {
+			s = base64.StdEncoding.EncodeToString([]byte(s))
+		}
+		m.Data[k] = s
+	}
+
+	enc := yaml.NewEncoder(w.W)
+	enc.SetIndent(2)
+	if err := enc.Encode(m); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+var kubernetesNameRegexp = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// kubernetesName converts s into a valid Kubernetes object name: lowercase alphanumerics, '-' and '.', beginning and
+// ending with an alphanumeric character.
+func kubernetesName(s string) string {
+	s = kubernetesNameRegexp.ReplaceAllString(strings.ToLower(s), "-")
+	return strings.Trim(s, "-.")
+}
+
+func sortedOutputKeys(outputs map[string]interface{}) []string {
+	keys := slice.Prealloc[string](len(outputs))
+	for k := range outputs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func getStackOutputs(snap *deploy.Snapshot, showSecrets bool) (map[string]interface{}, error) {
 	state, err := stack.GetRootStackResource(snap)
 	if err != nil {
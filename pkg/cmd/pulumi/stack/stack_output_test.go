@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -26,10 +27,13 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/backend/display"
 	cmdBackend "github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/backend"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
 	"github.com/pulumi/pulumi/pkg/v3/secrets"
 	pkgWorkspace "github.com/pulumi/pulumi/pkg/v3/workspace"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -469,3 +473,274 @@ func TestShellStackOutputWriter_quoting(t *testing.T) {
 		})
 	}
 }
+
+// Tests the output of 'pulumi stack output --format'
+// for the formats that don't have dedicated flags.
+func TestStackOutputCmd_format(t *testing.T) {
+	t.Parallel()
+
+	outputs := resource.PropertyMap{
+		"bucketName": resource.NewStringProperty("mybucket-1234"),
+		"password":   resource.MakeSecret(resource.NewStringProperty("hunter2")),
+		"message":    resource.NewStringProperty("say \"hi\"\nto ${name}"),
+		"db": resource.NewObjectProperty(resource.PropertyMap{
+			"endpoint": resource.NewStringProperty("db.example.com"),
+			"ports": resource.NewArrayProperty([]resource.PropertyValue{
+				resource.NewNumberProperty(5432),
+			}),
+		}),
+	}
+
+	tests := []struct {
+		format string
+		args   []string
+		want   string
+	}{
+		{
+			format: "dotenv",
+			want: `bucketName="mybucket-1234"
+db="{\"endpoint\":\"db.example.com\",\"ports\":[5432]}"
+message="say \"hi\"\nto ${name}"
+password="[secret]"
+`,
+		},
+		{
+			format: "tfvars",
+			want: `bucketName = "mybucket-1234"
+db = {
+  endpoint = "db.example.com"
+  ports = [
+    5432,
+  ]
+}
+message = "say \"hi\"\nto $${name}"
+password = "[secret]"
+`,
+		},
+		{
+			format: "configmap",
+			args:   []string{"bucketName"},
+			want: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-stack-outputs
+data:
+  bucketName: mybucket-1234
+`,
+		},
+		{
+			format: "secret",
+			args:   []string{"bucketName"},
+			want: `apiVersion: v1
+kind: Secret
+metadata:
+  name: my-stack-outputs
+type: Opaque
+data:
+  bucketName: bXlidWNrZXQtMTIzNA==
+`,
+		},
+		{
+			// Secrets are always shown in a Kubernetes Secret, rather than encoding the text "[secret]".
+			format: "secret",
+			args:   []string{"password"},
+			want: `apiVersion: v1
+kind: Secret
+metadata:
+  name: my-stack-outputs
+type: Opaque
+data:
+  password: aHVudGVyMg==
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.format+strings.Join(tt.args, ","), func(t *testing.T) {
+			t.Parallel()
+
+			snap := deploy.Snapshot{
+				Resources: []*resource.State{
+					{
+						Type:    resource.RootStackType,
+						Outputs: outputs,
+					},
+				},
+			}
+			requireStack := func(context.Context, diag.Sink, pkgWorkspace.Context, cmdBackend.LoginManager,
+				string, LoadOption, display.Options,
+			) (backend.Stack, error) {
+				return &backend.MockStack{
+					RefF: func() backend.StackReference {
+						return &backend.MockStackReference{NameV: tokens.MustParseStackName("My_Stack")}
+					},
+					SnapshotF: func(_ context.Context, _ secrets.Provider) (*deploy.Snapshot, error) {
+						return &snap, nil
+					},
+				}, nil
+			}
+
+			var stdoutBuff bytes.Buffer
+			cmd := stackOutputCmd{
+				requireStack: requireStack,
+				format:       tt.format,
+				Stdout:       &stdoutBuff,
+			}
+			require.NoError(t, cmd.Run(context.Background(), tt.args))
+			assert.Equal(t, tt.want, stdoutBuff.String())
+		})
+	}
+}
+
+func TestStackOutputCmd_formatConflicts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		cmd     stackOutputCmd
+		wantErr string
+	}{
+		{stackOutputCmd{format: "dotenv", jsonOut: true}, "--format may not be combined with --json or --shell"},
+		{stackOutputCmd{format: "xml"}, `unknown output format "xml"`},
+	}
+	for _, tt := range tests {
+		cmd := tt.cmd
+		cmd.requireStack = func(
+			context.Context, diag.Sink, pkgWorkspace.Context, cmdBackend.LoginManager, string, LoadOption, display.Options,
+		) (backend.Stack, error) {
+			t.Fatal("This function should not be called")
+			return nil, errors.New("should not be called")
+		}
+		assert.ErrorContains(t, cmd.Run(context.Background(), nil), tt.wantErr)
+	}
+}
+
+// Tests 'pulumi stack output --path'.
+func TestStackOutputCmd_path(t *testing.T) {
+	t.Parallel()
+
+	snap := deploy.Snapshot{
+		Resources: []*resource.State{
+			{
+				Type: resource.RootStackType,
+				Outputs: resource.PropertyMap{
+					"subnets": resource.NewArrayProperty([]resource.PropertyValue{
+						resource.NewObjectProperty(resource.PropertyMap{
+							"id": resource.NewStringProperty("subnet-1234"),
+						}),
+					}),
+				},
+			},
+		},
+	}
+	requireStack := func(context.Context, diag.Sink, pkgWorkspace.Context, cmdBackend.LoginManager,
+		string, LoadOption, display.Options,
+	) (backend.Stack, error) {
+		return &backend.MockStack{
+			SnapshotF: func(_ context.Context, _ secrets.Provider) (*deploy.Snapshot, error) {
+				return &snap, nil
+			},
+		}, nil
+	}
+
+	var stdoutBuff bytes.Buffer
+	cmd := stackOutputCmd{
+		requireStack: requireStack,
+		path:         true,
+		shellOut:     true,
+		OS:           "linux",
+		Stdout:       &stdoutBuff,
+	}
+	require.NoError(t, cmd.Run(context.Background(), []string{"subnets[0].id"}))
+	assert.Equal(t, "id=subnet-1234\n", stdoutBuff.String())
+
+	err := cmd.Run(context.Background(), []string{"subnets[1].id"})
+	assert.ErrorContains(t, err, "current stack does not have output property 'subnets[1].id'")
+}
+
+// versionedMockBackend is a MockBackend that can export previous deployments.
+type versionedMockBackend struct {
+	backend.MockBackend
+
+	deployments map[string]*apitype.UntypedDeployment
+}
+
+func (b *versionedMockBackend) ExportDeploymentForVersion(
+	_ context.Context, _ backend.Stack, version string,
+) (*apitype.UntypedDeployment, error) {
+	d, ok := b.deployments[version]
+	if !ok {
+		return nil, fmt.Errorf("no version %s", version)
+	}
+	return d, nil
+}
+
+// Tests 'pulumi stack output --since'.
+func TestStackOutputCmd_since(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	newSnap := func(outputs resource.PropertyMap) *deploy.Snapshot {
+		return &deploy.Snapshot{
+			Resources: []*resource.State{
+				{
+					URN:     resource.CreateURN("proj-dev", string(resource.RootStackType), "", "proj", "dev"),
+					Type:    resource.RootStackType,
+					Outputs: outputs,
+				},
+			},
+		}
+	}
+
+	prev, err := stack.SerializeDeployment(ctx, newSnap(resource.PropertyMap{
+		"same":    resource.NewStringProperty("a"),
+		"changed": resource.NewStringProperty("old"),
+		"removed": resource.NewStringProperty("gone"),
+	}), false)
+	require.NoError(t, err)
+	prevBytes, err := json.Marshal(prev)
+	require.NoError(t, err)
+
+	cur := newSnap(resource.PropertyMap{
+		"same":    resource.NewStringProperty("a"),
+		"changed": resource.NewStringProperty("new"),
+		"added":   resource.NewStringProperty("hello"),
+	})
+
+	be := &versionedMockBackend{
+		deployments: map[string]*apitype.UntypedDeployment{
+			"1": {Version: 3, Deployment: prevBytes},
+		},
+	}
+	requireStack := func(context.Context, diag.Sink, pkgWorkspace.Context, cmdBackend.LoginManager,
+		string, LoadOption, display.Options,
+	) (backend.Stack, error) {
+		return &backend.MockStack{
+			BackendF: func() backend.Backend { return be },
+			SnapshotF: func(_ context.Context, _ secrets.Provider) (*deploy.Snapshot, error) {
+				return cur, nil
+			},
+		}, nil
+	}
+
+	var stdoutBuff bytes.Buffer
+	cmd := stackOutputCmd{
+		requireStack: requireStack,
+		since:        "1",
+		Stdout:       &stdoutBuff,
+	}
+	require.NoError(t, cmd.Run(ctx, nil))
+	stdout := stdoutBuff.String()
+	assert.Contains(t, stdout, "Stack outputs changed since version 1 (3):")
+	assert.Regexp(t, `\+\s+added\s+hello`, stdout)
+	assert.Regexp(t, `~\s+changed\s+new`, stdout)
+	assert.Regexp(t, `-\s+removed`, stdout)
+	assert.NotContains(t, stdout, "same")
+
+	stdoutBuff.Reset()
+	cmd.jsonOut = true
+	require.NoError(t, cmd.Run(ctx, nil))
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(stdoutBuff.Bytes(), &got))
+	assert.Equal(t, map[string]interface{}{"added": "hello", "changed": "new"}, got)
+}
@@ -0,0 +1,215 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	cmdBackend "github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/backend"
+	"github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/ui"
+	"github.com/pulumi/pulumi/pkg/v3/resource/filter"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	pkgWorkspace "github.com/pulumi/pulumi/pkg/v3/workspace"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+)
+
+func newStackQueryCmd() *cobra.Command {
+	var sqcmd stackQueryCmd
+	cmd := &cobra.Command{
+		Use:   "query <filter>",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Find resources in a stack's state",
+		Long: "Find resources in a stack's state.\n" +
+			"\n" +
+			"This command lists the resources in the stack that match a filter expression. Filters\n" +
+			"compare fields of each resource with values, and can be combined with `and`, `or`,\n" +
+			"`not` and parentheses, e.g.\n" +
+			"\n" +
+			"    pulumi stack query 'type = \"aws:s3/*\" and outputs.tags.env = prod'\n" +
+			"    pulumi stack query 'ancestor = \"urn:pulumi:dev::proj::my:index:Website::site\"'\n" +
+			"    pulumi stack query 'provider = aws and created > -7d and not protect'\n" +
+			"\n" +
+			"The fields that can be used are type, name, id, urn, parent, ancestor, provider,\n" +
+			"custom, delete, protect, retainOnDelete, external, pendingReplacement, created,\n" +
+			"modified, expires, and paths into the resource's inputs and outputs such as `outputs.arn`\n" +
+			"or `inputs.tags[\"Name\"]`. Strings are compared as globs, where `*` matches any\n" +
+			"characters. Times can be RFC3339 times, dates or relative times such as `-7d`.\n" +
+			"\n" +
+			"The same filters can be passed to `--target` and `--exclude` of `pulumi up`,\n" +
+			"`preview`, `refresh` and `destroy`, and to `pulumi state delete`, `protect`\n" +
+			"and `unprotect`.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sqcmd.Stdout = cmd.OutOrStdout()
+			return sqcmd.Run(cmd.Context(), args[0])
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&sqcmd.stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().StringSliceVar(
+		&sqcmd.selects, "select", nil,
+		"Fields or property paths, such as `outputs.arn`, to show for each matching resource. May be repeated")
+	cmd.PersistentFlags().BoolVar(
+		&sqcmd.sourcePositions, "source", false, "Show the source position that registered each resource")
+	cmd.PersistentFlags().BoolVarP(
+		&sqcmd.jsonOut, "json", "j", false, "Emit output as JSON")
+	cmd.PersistentFlags().BoolVar(
+		&sqcmd.showSecrets, "show-secrets", false, "Display selected properties which are secret in plaintext")
+
+	return cmd
+}
+
+type stackQueryCmd struct {
+	stackName       string
+	selects         []string
+	sourcePositions bool
+	jsonOut         bool
+	showSecrets     bool
+
+	ws pkgWorkspace.Context
+
+	// requireStack is a reference to the top-level requireStack function. This is a field on stackQueryCmd so that
+	// we can replace it from tests.
+	requireStack func(
+		ctx context.Context, sink diag.Sink, ws pkgWorkspace.Context, lm cmdBackend.LoginManager,
+		name string, lopt LoadOption, opts display.Options,
+	) (backend.Stack, error)
+
+	Stdout io.Writer // defaults to os.Stdout
+}
+
+// queryResult is the JSON representation of a resource matched by `pulumi stack query`.
+type queryResult struct {
+	URN            resource.URN           `json:"urn"`
+	Type           string                 `json:"type"`
+	Properties     map[string]interface{} `json:"properties,omitempty"`
+	SourcePosition string                 `json:"sourcePosition,omitempty"`
+}
+
+func (cmd *stackQueryCmd) Run(ctx context.Context, expr string) error {
+	opts := display.Options{
+		Color: cmdutil.GetGlobalColorization(),
+	}
+
+	requireStack := RequireStack
+	if cmd.requireStack != nil {
+		requireStack = cmd.requireStack
+	}
+	if cmd.ws == nil {
+		cmd.ws = pkgWorkspace.Instance
+	}
+	stdout := io.Writer(os.Stdout)
+	if cmd.Stdout != nil {
+		stdout = cmd.Stdout
+	}
+
+	f, err := filter.Parse(expr)
+	if err != nil {
+		return err
+	}
+	for _, sel := range cmd.selects {
+		if !filter.IsField(sel) {
+			return fmt.Errorf("cannot select %q: expected a field or a path into inputs or outputs", sel)
+		}
+	}
+
+	s, err := requireStack(ctx, cmdutil.Diag(), cmd.ws, cmdBackend.DefaultLoginManager, cmd.stackName, LoadOnly, opts)
+	if err != nil {
+		return err
+	}
+	snap, err := s.Snapshot(ctx, stack.DefaultSecretsProvider)
+	if err != nil {
+		return err
+	}
+	if snap == nil {
+		return fmt.Errorf("stack %s has no resources", s.Ref())
+	}
+
+	results := []queryResult{}
+	for _, r := range f.Select(snap.Resources) {
+		result := queryResult{URN: r.URN, Type: string(r.Type)}
+		if len(cmd.selects) > 0 {
+			result.Properties = map[string]interface{}{}
+		}
+		for _, sel := range cmd.selects {
+			v, err := cmd.selectValue(ctx, r, sel)
+			if err != nil {
+				return err
+			}
+			result.Properties[sel] = v
+		}
+		if cmd.sourcePositions {
+			result.SourcePosition = r.SourcePosition
+		}
+		results = append(results, result)
+	}
+
+	if cmd.showSecrets && len(cmd.selects) > 0 {
+		Log3rdPartySecretsProviderDecryptionEvent(ctx, s, "", "pulumi stack query")
+	}
+
+	if cmd.jsonOut {
+		return ui.FprintJSON(stdout, results)
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintln(stdout, "No resources match the filter")
+		return nil
+	}
+
+	headers := []string{"URN"}
+	headers = append(headers, cmd.selects...)
+	if cmd.sourcePositions {
+		headers = append(headers, "SOURCE")
+	}
+	rows := make([]cmdutil.TableRow, 0, len(results))
+	for _, result := range results {
+		columns := []string{string(result.URN)}
+		for _, sel := range cmd.selects {
+			v := result.Properties[sel]
+			if v == nil {
+				columns = append(columns, "")
+			} else {
+				columns = append(columns, stringifyOutput(v))
+			}
+		}
+		if cmd.sourcePositions {
+			columns = append(columns, result.SourcePosition)
+		}
+		rows = append(rows, cmdutil.TableRow{Columns: columns})
+	}
+	ui.FprintTable(stdout, cmdutil.Table{Headers: headers, Rows: rows}, nil)
+	return nil
+}
+
+// selectValue returns the value of a selected field of a resource, with secrets masked unless --show-secrets is set.
+func (cmd *stackQueryCmd) selectValue(ctx context.Context, r *resource.State, sel string) (interface{}, error) {
+	v, ok, err := filter.Get(r, sel)
+	if err != nil || !ok {
+		return nil, err
+	}
+	masked := display.MassageSecrets(resource.PropertyMap{"v": v}, cmd.showSecrets)["v"]
+	return stack.SerializePropertyValue(ctx, masked, config.NewPanicCrypter(), cmd.showSecrets)
+}
@@ -0,0 +1,125 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	cmdBackend "github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/backend"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	pkgWorkspace "github.com/pulumi/pulumi/pkg/v3/workspace"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+func newQueryTestCmd(stdout *bytes.Buffer) *stackQueryCmd {
+	snap := &deploy.Snapshot{
+		Resources: []*resource.State{
+			{
+				Type: resource.RootStackType,
+				URN:  "urn:pulumi:dev::proj::pulumi:pulumi:Stack::proj-dev",
+			},
+			{
+				Type:           "aws:s3/bucket:Bucket",
+				URN:            "urn:pulumi:dev::proj::aws:s3/bucket:Bucket::content",
+				Custom:         true,
+				Protect:        true,
+				SourcePosition: "project:///index.ts#3,1",
+				Outputs: resource.PropertyMap{
+					"arn":      resource.NewStringProperty("arn:aws:s3:::content"),
+					"password": resource.MakeSecret(resource.NewStringProperty("hunter2")),
+				},
+			},
+			{
+				Type:   "aws:s3/bucket:Bucket",
+				URN:    "urn:pulumi:dev::proj::aws:s3/bucket:Bucket::logs",
+				Custom: true,
+			},
+		},
+	}
+	return &stackQueryCmd{
+		Stdout: stdout,
+		requireStack: func(context.Context, diag.Sink, pkgWorkspace.Context, cmdBackend.LoginManager,
+			string, LoadOption, display.Options,
+		) (backend.Stack, error) {
+			return &backend.MockStack{
+				SnapshotF: func(context.Context, secrets.Provider) (*deploy.Snapshot, error) {
+					return snap, nil
+				},
+			}, nil
+		},
+	}
+}
+
+func TestStackQueryTable(t *testing.T) {
+	t.Parallel()
+
+	var stdout bytes.Buffer
+	cmd := newQueryTestCmd(&stdout)
+	cmd.selects = []string{"outputs.arn", "outputs.password"}
+	cmd.sourcePositions = true
+	require.NoError(t, cmd.Run(context.Background(), `type = "aws:s3/*" and protect`))
+
+	out := stdout.String()
+	assert.Contains(t, out, "urn:pulumi:dev::proj::aws:s3/bucket:Bucket::content")
+	assert.Contains(t, out, "arn:aws:s3:::content")
+	assert.Contains(t, out, "[secret]")
+	assert.Contains(t, out, "project:///index.ts#3,1")
+	assert.NotContains(t, out, "hunter2")
+	assert.NotContains(t, out, "::logs")
+}
+
+func TestStackQueryJSON(t *testing.T) {
+	t.Parallel()
+
+	var stdout bytes.Buffer
+	cmd := newQueryTestCmd(&stdout)
+	cmd.jsonOut = true
+	cmd.showSecrets = true
+	cmd.selects = []string{"outputs.password"}
+	require.NoError(t, cmd.Run(context.Background(), `custom`))
+
+	var results []queryResult
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &results))
+	require.Len(t, results, 2)
+	assert.Equal(t, resource.URN("urn:pulumi:dev::proj::aws:s3/bucket:Bucket::content"), results[0].URN)
+	assert.Equal(t, "hunter2", results[0].Properties["outputs.password"])
+	assert.Equal(t, resource.URN("urn:pulumi:dev::proj::aws:s3/bucket:Bucket::logs"), results[1].URN)
+	assert.Nil(t, results[1].Properties["outputs.password"])
+}
+
+func TestStackQueryErrors(t *testing.T) {
+	t.Parallel()
+
+	var stdout bytes.Buffer
+	cmd := newQueryTestCmd(&stdout)
+	assert.ErrorContains(t, cmd.Run(context.Background(), `colour = red`), `unknown field "colour"`)
+
+	cmd.selects = []string{"colour"}
+	assert.ErrorContains(t, cmd.Run(context.Background(), `protect`), `cannot select "colour"`)
+
+	cmd.selects = nil
+	require.NoError(t, cmd.Run(context.Background(), `retainOnDelete`))
+	assert.Equal(t, "No resources match the filter\n", stdout.String())
+}
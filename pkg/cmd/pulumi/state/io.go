@@ -33,6 +33,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/ui"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v3/resource/edit"
+	"github.com/pulumi/pulumi/pkg/v3/resource/filter"
 	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
 	pkgWorkspace "github.com/pulumi/pulumi/pkg/v3/workspace"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
@@ -237,6 +238,47 @@ func locateStackResource(opts display.Options, snap *deploy.Snapshot, urn resour
 	return optionMap[option], nil
 }
 
+// selectResources returns the resources in the snapshot that are named by urnsOrFilters, each of which is either a
+// URN or a filter expression as accepted by `pulumi stack query`. An error is returned for each URN that doesn't exist
+// and each filter that doesn't match any resources.
+func selectResources(snap *deploy.Snapshot, urnsOrFilters []string) ([]*resource.State, []error) {
+	var selected []*resource.State
+	var errs []error
+	seen := make(map[*resource.State]bool)
+	for _, arg := range urnsOrFilters {
+		var matches []*resource.State
+		if filter.IsExpression(arg) {
+			f, err := filter.Parse(arg)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if matches = f.Select(snap.Resources); len(matches) == 0 {
+				errs = append(errs, fmt.Errorf("No resources in the current state match %q", arg))
+				continue
+			}
+		} else {
+			for _, res := range snap.Resources {
+				if res.URN == resource.URN(arg) {
+					matches = append(matches, res)
+					break
+				}
+			}
+			if len(matches) == 0 {
+				errs = append(errs, fmt.Errorf("No such resource %q exists in the current state", arg))
+				continue
+			}
+		}
+		for _, res := range matches {
+			if !seen[res] {
+				seen[res] = true
+				selected = append(selected, res)
+			}
+		}
+	}
+	return selected, errs
+}
+
 // Prompt the user to select a URN from the passed in state.
 //
 // stackName is the name of the current stack.
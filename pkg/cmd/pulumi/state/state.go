@@ -37,9 +37,12 @@ troubleshooting a stack or when performing specific edits that otherwise would r
 	cmd.AddCommand(newStateDeleteCommand(pkgWorkspace.Instance, cmdBackend.DefaultLoginManager))
 	cmd.AddCommand(newStateUnprotectCommand())
 	cmd.AddCommand(newStateProtectCommand())
+	cmd.AddCommand(newStateAnnotateCommand())
 	cmd.AddCommand(newStateRenameCommand())
 	cmd.AddCommand(newStateUpgradeCommand(pkgWorkspace.Instance, cmdBackend.DefaultLoginManager))
 	cmd.AddCommand(newStateMoveCommand())
 	cmd.AddCommand(newStateRepairCommand())
+	cmd.AddCommand(newStateVerifyCommand())
+	cmd.AddCommand(newStateExportCommand())
 	return cmd
 }
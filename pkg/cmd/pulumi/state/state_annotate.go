@@ -0,0 +1,161 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/backend"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	pkgWorkspace "github.com/pulumi/pulumi/pkg/v3/workspace"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/env"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+
+	"github.com/spf13/cobra"
+)
+
+func newStateAnnotateCommand() *cobra.Command {
+	var stack string
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "annotate <resource URN or filter> <key=value | key->...",
+		Short: "Set or remove annotations on resources in a stack's state",
+		Long: `Set or remove annotations on resources in a stack's state
+
+Annotations are key/value metadata, such as an owner, cost centre or ticket, that are stored with a
+resource in the stack's state but never sent to its provider. This command changes them without running
+a deployment. Each 'key=value' argument sets an annotation and each 'key-' argument removes one.
+
+The resources to annotate can be given by URN or by a filter expression, as accepted by ` +
+			"`pulumi stack query`" + `, e.g.
+'type = "aws:s3/*" and not annotations.owner'.
+
+Annotations set by this command are kept when the program's 'annotations' resource option changes,
+unless the program sets an annotation with the same key, which takes precedence. Removing an annotation
+with this command doesn't stop the program from setting it again.
+
+To see the annotations of the resources in a stack, use ` + "`pulumi stack --show-annotations`" + `.`,
+		Args: cmdutil.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			sink := cmdutil.Diag()
+			ws := pkgWorkspace.Instance
+			yes = yes || env.SkipConfirmations.Value()
+
+			set, remove, err := parseAnnotationArgs(args[1:])
+			if err != nil {
+				return err
+			}
+
+			// The count is reported only once the edited state has been saved. It stays negative if there was no state.
+			count := -1
+			err = runTotalStateEdit(
+				ctx, sink, ws, backend.DefaultLoginManager, stack, !yes,
+				func(_ display.Options, snap *deploy.Snapshot) error {
+					var errs []error
+					count, errs = annotateResourcesInSnapshot(snap, args[0], set, remove)
+					if len(errs) > 0 {
+						var errMsgs []string
+						for _, err := range errs {
+							errMsgs = append(errMsgs, err.Error())
+						}
+						return errors.New(strings.Join(errMsgs, "\n"))
+					}
+					return nil
+				})
+			if err != nil {
+				return err
+			}
+			if count >= 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "%d resources annotated\n", count)
+			}
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompts")
+
+	return cmd
+}
+
+// parseAnnotationArgs parses `key=value` arguments, which set annotations, and `key-` arguments, which remove them.
+func parseAnnotationArgs(args []string) (map[string]string, []string, error) {
+	set := map[string]string{}
+	var remove []string
+	for _, arg := range args {
+		if key, value, ok := strings.Cut(arg, "="); ok {
+			if key == "" {
+				return nil, nil, fmt.Errorf("invalid annotation %q: the key must not be empty", arg)
+			}
+			set[key] = value
+			continue
+		}
+		if key, ok := strings.CutSuffix(arg, "-"); ok && key != "" {
+			remove = append(remove, key)
+			continue
+		}
+		return nil, nil, fmt.Errorf("invalid annotation %q: expected key=value to set it or key- to remove it", arg)
+	}
+	for _, key := range remove {
+		if _, has := set[key]; has {
+			return nil, nil, fmt.Errorf("annotation %q cannot be both set and removed", key)
+		}
+	}
+	return set, remove, nil
+}
+
+// annotateResourcesInSnapshot sets and removes annotations on the resources in the snapshot that match the given URN
+// or filter, returning the number of resources that matched.
+func annotateResourcesInSnapshot(
+	snap *deploy.Snapshot, urnOrFilter string, set map[string]string, remove []string,
+) (int, []error) {
+	if snap == nil {
+		return 0, []error{errors.New("no resources found to annotate")}
+	}
+
+	resources, errs := selectResources(snap, []string{urnOrFilter})
+	for _, res := range resources {
+		// The annotations set here are also kept separately, so that they survive the program changing its own.
+		res.Annotations = editAnnotations(res.Annotations, set, remove)
+		res.StateAnnotations = editAnnotations(res.StateAnnotations, set, remove)
+	}
+
+	return len(resources), errs
+}
+
+// editAnnotations returns a copy of the given annotations with the given keys set and removed. The annotations are
+// copied rather than edited in place, as they may be shared with other copies of the state.
+func editAnnotations(annotations map[string]string, set map[string]string, remove []string) map[string]string {
+	annotations = maps.Clone(annotations)
+	if annotations == nil {
+		annotations = make(map[string]string, len(set))
+	}
+	maps.Copy(annotations, set)
+	for _, key := range remove {
+		delete(annotations, key)
+	}
+	if len(annotations) == 0 {
+		return nil
+	}
+	return annotations
+}
@@ -0,0 +1,83 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+func TestParseAnnotationArgs(t *testing.T) {
+	t.Parallel()
+
+	set, remove, err := parseAnnotationArgs([]string{"owner=alice", "ticket=", "cost-centre-"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"owner": "alice", "ticket": ""}, set)
+	assert.Equal(t, []string{"cost-centre"}, remove)
+
+	for _, arg := range []string{"owner", "=alice", "-"} {
+		_, _, err := parseAnnotationArgs([]string{arg})
+		assert.ErrorContains(t, err, "invalid annotation")
+	}
+
+	_, _, err = parseAnnotationArgs([]string{"owner=alice", "owner-"})
+	assert.ErrorContains(t, err, `annotation "owner" cannot be both set and removed`)
+}
+
+func TestAnnotateResourcesInSnapshot(t *testing.T) {
+	t.Parallel()
+
+	shared := map[string]string{"owner": "alice", "ticket": "OPS-1"}
+	bucket := &resource.State{
+		Type:        "aws:s3/bucket:Bucket",
+		URN:         "urn:pulumi:dev::proj::aws:s3/bucket:Bucket::bucket",
+		Custom:      true,
+		Annotations: shared,
+	}
+	queue := &resource.State{
+		Type:   "aws:sqs/queue:Queue",
+		URN:    "urn:pulumi:dev::proj::aws:sqs/queue:Queue::queue",
+		Custom: true,
+	}
+	snap := &deploy.Snapshot{Resources: []*resource.State{bucket, queue}}
+
+	count, errs := annotateResourcesInSnapshot(snap, `type = "aws:*"`,
+		map[string]string{"owner": "bob"}, []string{"ticket"})
+	assert.Empty(t, errs)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, map[string]string{"owner": "bob"}, bucket.Annotations)
+	assert.Equal(t, map[string]string{"owner": "bob"}, queue.Annotations)
+	// The annotations set by the command are also recorded separately from the program's.
+	assert.Equal(t, map[string]string{"owner": "bob"}, bucket.StateAnnotations)
+	assert.Equal(t, map[string]string{"owner": "bob"}, queue.StateAnnotations)
+	// The original map must not have been modified in place.
+	assert.Equal(t, map[string]string{"owner": "alice", "ticket": "OPS-1"}, shared)
+
+	// Removing the last annotation clears them entirely.
+	count, errs = annotateResourcesInSnapshot(snap, string(queue.URN), nil, []string{"owner"})
+	assert.Empty(t, errs)
+	assert.Equal(t, 1, count)
+	assert.Nil(t, queue.Annotations)
+	assert.Nil(t, queue.StateAnnotations)
+
+	_, errs = annotateResourcesInSnapshot(snap, "urn:pulumi:dev::proj::aws:s3/bucket:Bucket::missing", nil, nil)
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "No such resource")
+}
@@ -17,11 +17,13 @@ package state
 import (
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/pulumi/pulumi/pkg/v3/backend/display"
 	"github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/backend"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v3/resource/edit"
+	"github.com/pulumi/pulumi/pkg/v3/resource/filter"
 	pkgWorkspace "github.com/pulumi/pulumi/pkg/v3/workspace"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/env"
@@ -39,13 +41,16 @@ func newStateDeleteCommand(ws pkgWorkspace.Context, lm backend.LoginManager) *co
 	var all bool
 
 	cmd := &cobra.Command{
-		Use:   "delete [resource URN]",
+		Use:   "delete [resource URN or filter]",
 		Short: "Deletes a resource from a stack's state",
 		Long: `Deletes a resource from a stack's state
 
 This command deletes a resource from a stack's state, as long as it is safe to do so. The resource is specified
 by its Pulumi URN. If the URN is omitted, this command will prompt for it.
 
+Instead of a URN, a filter expression as accepted by ` + "`pulumi stack query`" + ` can be given to delete every
+resource that matches it, e.g. 'type = "aws:s3/*" and ancestor = "urn:pulumi:dev::proj::my:index:Website::site"'.
+
 Resources can't be deleted if other resources depend on it or are parented to it. Protected resources
 will not be deleted unless specifically requested using the --force flag.
 
@@ -60,6 +65,7 @@ To see the list of URNs in a stack, use ` + "`pulumi stack --show-urns`" + `.
 			sink := cmdutil.Diag()
 			yes = yes || env.SkipConfirmations.Value()
 			var urn resource.URN
+			var query *filter.Filter
 			if all {
 				if len(args) != 0 {
 					return errors.New("cannot specify a resource URN when deleting all resources")
@@ -76,6 +82,11 @@ To see the list of URNs in a stack, use ` + "`pulumi stack --show-urns`" + `.
 					if err != nil {
 						return fmt.Errorf("failed to select resource: %w", err)
 					}
+				} else if filter.IsExpression(args[0]) {
+					var err error
+					if query, err = filter.Parse(args[0]); err != nil {
+						return err
+					}
 				} else {
 					urn = resource.URN(args[0])
 				}
@@ -93,9 +104,18 @@ To see the list of URNs in a stack, use ` + "`pulumi stack --show-urns`" + `.
 				}
 			}
 
-			// If we're deleting everything then run a total state edit, else run on just the resource given.
+			// If we're deleting everything or a filter was given then run a total state edit, else run on just the
+			// resource given.
 			var err error
-			if all {
+			var deleted int
+			if query != nil {
+				err = runTotalStateEdit(ctx, sink, ws, lm, stack, showPrompt,
+					func(opts display.Options, snap *deploy.Snapshot) error {
+						var deleteErr error
+						deleted, deleteErr = deleteFilteredResources(snap, query, handleProtected, targetDependents)
+						return deleteErr
+					})
+			} else if all {
 				err = runTotalStateEdit(ctx, sink, ws, lm, stack, showPrompt,
 					func(opts display.Options, snap *deploy.Snapshot) error {
 						// Iterate the resources backwards (so we delete dependents first) and delete them.
@@ -132,7 +152,9 @@ To see the list of URNs in a stack, use ` + "`pulumi stack --show-urns`" + `.
 					return err
 				}
 			}
-			if all {
+			if query != nil {
+				fmt.Printf("%d resources deleted\n", deleted)
+			} else if all {
 				fmt.Println("Resources deleted")
 			} else {
 				fmt.Println("Resource deleted")
@@ -150,3 +172,32 @@ To see the list of URNs in a stack, use ` + "`pulumi stack --show-urns`" + `.
 	cmd.Flags().BoolVar(&targetDependents, "target-dependents", false, "Delete the URN and all its dependents")
 	return cmd
 }
+
+// deleteFilteredResources deletes the resources in the snapshot that match the filter. Resources are deleted in
+// reverse order so that dependents are deleted before the resources they depend on.
+func deleteFilteredResources(
+	snap *deploy.Snapshot, query *filter.Filter, handleProtected func(*resource.State) error, targetDependents bool,
+) (int, error) {
+	if snap == nil {
+		return 0, errors.New("no resources found to delete")
+	}
+	matches := query.Select(snap.Resources)
+	if len(matches) == 0 {
+		return 0, fmt.Errorf("no resources in the current state match %q", query)
+	}
+
+	deleted := 0
+	for i := len(matches) - 1; i >= 0; i-- {
+		res := matches[i]
+		// Deleting an earlier match with --target-dependents may have already deleted this one.
+		if !slices.Contains(snap.Resources, res) {
+			continue
+		}
+		before := len(snap.Resources)
+		if err := edit.DeleteResource(snap, res, handleProtected, targetDependents); err != nil {
+			return deleted, err
+		}
+		deleted += before - len(snap.Resources)
+	}
+	return deleted, nil
+}
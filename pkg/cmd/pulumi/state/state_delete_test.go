@@ -327,3 +327,103 @@ func TestStateDeleteAll(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, deployment.Resources, 0)
 }
+
+func TestStateDeleteFilter(t *testing.T) {
+	t.Parallel()
+
+	snapshot := &deploy.Snapshot{
+		Resources: []*resource.State{
+			{
+				URN: "urn:pulumi:proj::stk::pkg:index:typ::dependency",
+			},
+			{
+				URN: "urn:pulumi:proj::stk::pkg:index:typ::dependee",
+				Dependencies: []resource.URN{
+					"urn:pulumi:proj::stk::pkg:index:typ::dependency",
+				},
+			},
+			{
+				URN: "urn:pulumi:proj::stk::pkg:index:typ::other",
+			},
+		},
+	}
+
+	var mockStack *backend.MockStack
+
+	var mockDeployment *apitype.UntypedDeployment
+	mockBackend := &backend.MockBackend{
+		GetStackF: func(_ context.Context, ref backend.StackReference) (backend.Stack, error) {
+			return mockStack, nil
+		},
+		ImportDeploymentF: func(_ context.Context, _ backend.Stack, deployment *apitype.UntypedDeployment) error {
+			mockDeployment = deployment
+			return nil
+		},
+	}
+
+	mockStack = &backend.MockStack{
+		BackendF: func() backend.Backend {
+			return mockBackend
+		},
+		SnapshotF: func(ctx context.Context, secretsProvider secrets.Provider) (*deploy.Snapshot, error) {
+			return snapshot, nil
+		},
+	}
+	ws := &pkgWorkspace.MockContext{
+		ReadProjectF: func() (*workspace.Project, string, error) {
+			return &workspace.Project{
+				Name: "proj",
+			}, "/testing/project", nil
+		},
+	}
+	lm := &cmdBackend.MockLoginManager{
+		LoginF: func(
+			_ context.Context, _ pkgWorkspace.Context, _ diag.Sink,
+			url string, project *workspace.Project, _ bool, _ colors.Colorization,
+		) (backend.Backend, error) {
+			return mockBackend, nil
+		},
+	}
+
+	cmd := newStateDeleteCommand(ws, lm)
+	cmd.SetArgs([]string{"--stack=stk", "name = depend*"})
+	err := cmd.ExecuteContext(context.Background())
+	require.NoError(t, err)
+
+	deployment := apitype.DeploymentV3{}
+	err = json.Unmarshal(mockDeployment.Deployment, &deployment)
+	require.NoError(t, err)
+	require.Len(t, deployment.Resources, 1)
+	assert.Equal(t, resource.URN("urn:pulumi:proj::stk::pkg:index:typ::other"), deployment.Resources[0].URN)
+}
+
+func TestSelectResources(t *testing.T) {
+	t.Parallel()
+
+	snap := &deploy.Snapshot{
+		Resources: []*resource.State{
+			{URN: "urn:pulumi:proj::stk::pkg:index:typ::a", Type: "pkg:index:typ", Protect: true},
+			{URN: "urn:pulumi:proj::stk::pkg:index:typ::b", Type: "pkg:index:typ"},
+			{URN: "urn:pulumi:proj::stk::pkg:index:other::c", Type: "pkg:index:other"},
+		},
+	}
+
+	selected, errs := selectResources(snap, []string{
+		"type = pkg:index:typ",
+		"urn:pulumi:proj::stk::pkg:index:typ::a",
+		"urn:pulumi:proj::stk::pkg:index:other::c",
+	})
+	assert.Empty(t, errs)
+	assert.Equal(t, []*resource.State{snap.Resources[0], snap.Resources[1], snap.Resources[2]}, selected)
+
+	selected, errs = selectResources(snap, []string{
+		"protect and type = pkg:index:other",
+		"urn:pulumi:proj::stk::pkg:index:typ::missing",
+		"colour = red",
+	})
+	assert.Empty(t, selected)
+	require.Len(t, errs, 3)
+	assert.ErrorContains(t, errs[0], `No resources in the current state match "protect and type = pkg:index:other"`)
+	assert.ErrorContains(t, errs[1], `No such resource "urn:pulumi:proj::stk::pkg:index:typ::missing" exists`)
+	assert.ErrorContains(t, errs[2], `unknown field "colour"`)
+}
@@ -0,0 +1,195 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	cmdBackend "github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/backend"
+	cmdConvert "github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/convert"
+	cmdDiag "github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/diag"
+	"github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/packagecmd"
+	cmdStack "github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/stack"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/convert"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/terraform"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	pkgWorkspace "github.com/pulumi/pulumi/pkg/v3/workspace"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+type stateExportCmd struct {
+	// The name of the stack to operate on.
+	Stack string
+	// The format to export the state in.
+	Format string
+	// The file to write the exported state to. Defaults to standard output.
+	File string
+	// True if secret values may be written to the exported state in plaintext.
+	ShowSecrets bool
+	// The colorization to use for output.
+	Colorizer colors.Colorization
+
+	// The command's standard output.
+	Stdout io.Writer
+
+	// The workspace to operate on.
+	Workspace pkgWorkspace.Context
+	// The login manager to use for authenticating with and loading backends.
+	LoginManager cmdBackend.LoginManager
+	// The mapper to read Terraform mappings from. If nil, mappings are read from the stack's provider plugins.
+	Mapper convert.Mapper
+}
+
+func newStateExportCommand() *cobra.Command {
+	stateExport := &stateExportCmd{
+		Colorizer:    cmdutil.GetGlobalColorization(),
+		Stdout:       os.Stdout,
+		Workspace:    pkgWorkspace.Instance,
+		LoginManager: cmdBackend.DefaultLoginManager,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export a stack's state in the format of another tool",
+		Long: `Export a stack's state in the format of another tool
+
+This command converts a stack's state into a state file for another
+infrastructure as code tool, so that the resources it manages can be handed
+over to that tool. The only supported format is ` + "`terraform`" + `, which writes a
+version 4 Terraform state file.
+
+Resources from bridged Terraform providers are mapped back to their Terraform
+resource types and attribute names, and the stack's outputs become the state's
+outputs. Resources that have no Terraform equivalent, such as components and
+resources from native Pulumi providers, are skipped with a warning.
+
+Terraform state files hold all values in plaintext, so the command fails if the
+state contains secrets unless ` + "`--show-secrets`" + ` is passed.
+
+Providers are assumed to be published in the ` + "`hashicorp`" + ` namespace of the
+Terraform registry. For providers published elsewhere, use
+` + "`terraform state replace-provider`" + ` to correct the exported state.`,
+		Example: "pulumi state export --format terraform --file terraform.tfstate",
+		Args:    cmdutil.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return stateExport.run(cmd.Context())
+		},
+	}
+
+	cmd.Flags().StringVarP(&stateExport.Stack,
+		"stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+	cmd.Flags().StringVar(&stateExport.Format,
+		"format", "", "The format to export the state in. Must be `terraform`")
+	cmd.Flags().StringVarP(&stateExport.File,
+		"file", "f", "", "A filename to write the exported state to. Defaults to standard output")
+	cmd.Flags().BoolVar(&stateExport.ShowSecrets,
+		"show-secrets", false, "Write secret values to the exported state in plaintext")
+
+	return cmd
+}
+
+func (cmd *stateExportCmd) run(ctx context.Context) error {
+	if cmd.Format != "terraform" {
+		return fmt.Errorf("unsupported export format %q, must be \"terraform\"", cmd.Format)
+	}
+
+	s, err := cmdStack.RequireStack(
+		ctx,
+		cmdutil.Diag(),
+		cmd.Workspace,
+		cmd.LoginManager,
+		cmd.Stack,
+		cmdStack.LoadOnly,
+		display.Options{Color: cmd.Colorizer},
+	)
+	if err != nil {
+		return err
+	}
+
+	deployment, err := backend.ExportStackDeployment(ctx, s)
+	if err != nil {
+		return err
+	}
+	snap, err := stack.DeserializeUntypedDeployment(ctx, deployment, stack.DefaultSecretsProvider)
+	if err != nil {
+		return stack.FormatDeploymentDeserializationError(err, s.Ref().Name().String())
+	}
+
+	var resources []*resource.State
+	if snap != nil {
+		resources = snap.Resources
+	}
+
+	mapper := cmd.Mapper
+	if mapper == nil {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		pCtx, err := packagecmd.NewPluginContext(cwd)
+		if err != nil {
+			return fmt.Errorf("create plugin host: %w", err)
+		}
+		defer contract.IgnoreClose(pCtx.Host)
+
+		mapper, err = cmdConvert.NewPluginMapper(ctx, pCtx, "terraform" /*conversionKey*/, nil /*mappings*/)
+		if err != nil {
+			return err
+		}
+	}
+
+	state, diagnostics, err := terraform.ConvertState(ctx, resources, mapper, terraform.StateOptions{
+		ShowSecrets: cmd.ShowSecrets,
+	})
+	cmdDiag.PrintDiagnostics(cmdutil.Diag(), diagnostics)
+	if errors.Is(err, terraform.ErrSecretsInState) {
+		return fmt.Errorf("%w; pass --show-secrets to export them", err)
+	} else if err != nil {
+		return fmt.Errorf("could not convert state: %w", err)
+	}
+	if cmd.ShowSecrets {
+		cmdStack.Log3rdPartySecretsProviderDecryptionEvent(ctx, s, "", "pulumi state export")
+	}
+
+	writer := cmd.Stdout
+	if cmd.File != "" {
+		f, err := os.Create(cmd.File)
+		if err != nil {
+			return fmt.Errorf("could not open file: %w", err)
+		}
+		defer contract.IgnoreClose(f)
+		writer = f
+	}
+
+	enc := json.NewEncoder(writer)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(state); err != nil {
+		return fmt.Errorf("could not export state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/convert"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stateExportMapper struct{}
+
+func (stateExportMapper) GetMapping(_ context.Context, provider string, _ *convert.MapperPackageHint) ([]byte, error) {
+	if provider != "random" {
+		return nil, nil
+	}
+	return []byte(`{
+		"name": "random",
+		"provider": {"resources": {"random_pet": {"prefix": {"type": 4}, "length": {"type": 2}}}},
+		"resources": {"random_pet": {"tok": "random:index/randomPet:RandomPet"}}
+	}`), nil
+}
+
+func TestStateExport_Terraform(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	cmd, stdout := newStateExportCmdFixture(t, []*resource.State{
+		{
+			URN:     "urn:pulumi:dev::proj::random:index/randomPet:RandomPet::pet",
+			Type:    "random:index/randomPet:RandomPet",
+			Custom:  true,
+			ID:      "pet-id",
+			Outputs: resource.PropertyMap{"prefix": resource.NewStringProperty("my")},
+		},
+	})
+
+	// Act.
+	err := cmd.run(context.Background())
+
+	// Assert.
+	require.NoError(t, err)
+	var state struct {
+		Version   int `json:"version"`
+		Resources []struct {
+			Type      string `json:"type"`
+			Name      string `json:"name"`
+			Instances []struct {
+				Attributes map[string]interface{} `json:"attributes"`
+			} `json:"instances"`
+		} `json:"resources"`
+	}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &state))
+	assert.Equal(t, 4, state.Version)
+	require.Len(t, state.Resources, 1)
+	assert.Equal(t, "random_pet", state.Resources[0].Type)
+	assert.Equal(t, "pet", state.Resources[0].Name)
+	assert.Equal(t, map[string]interface{}{
+		"id":     "pet-id",
+		"prefix": "my",
+		"length": nil,
+	}, state.Resources[0].Instances[0].Attributes)
+}
+
+func TestStateExport_UnsupportedFormat(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	cmd, _ := newStateExportCmdFixture(t, nil)
+	cmd.Format = "cloudformation"
+
+	// Act.
+	err := cmd.run(context.Background())
+
+	// Assert.
+	assert.ErrorContains(t, err, `unsupported export format "cloudformation"`)
+}
+
+func newStateExportCmdFixture(t *testing.T, resources []*resource.State) (*stateExportCmd, *bytes.Buffer) {
+	// Exporting loads states in the same way as repair, so we can reuse its fixture.
+	fx := newStateRepairCmdFixture(t, resources)
+
+	var stdout bytes.Buffer
+	return &stateExportCmd{
+		Stack:        fx.cmd.Args.Stack,
+		Format:       "terraform",
+		Colorizer:    colors.Never,
+		Stdout:       &stdout,
+		Workspace:    fx.cmd.Workspace,
+		LoginManager: fx.cmd.LoginManager,
+		Mapper:       stateExportMapper{},
+	}, &stdout
+}
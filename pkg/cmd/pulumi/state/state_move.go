@@ -20,22 +20,19 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"strings"
 
-	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/pulumi/pulumi/pkg/v3/backend"
 	"github.com/pulumi/pulumi/pkg/v3/backend/display"
 	cmdBackend "github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/backend"
 	cmdStack "github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/stack"
 	"github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/ui"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
-	"github.com/pulumi/pulumi/pkg/v3/resource/graph"
+	"github.com/pulumi/pulumi/pkg/v3/resource/edit"
 	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
 	"github.com/pulumi/pulumi/pkg/v3/secrets"
 	pkgWorkspace "github.com/pulumi/pulumi/pkg/v3/workspace"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/urn"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
@@ -209,156 +206,36 @@ func (cmd *stateMoveCmd) Run(
 		destSnapshot.SecretsManager = destSecretManager
 	}
 
-	resourcesToMove := make(map[string]*resource.State)
-	providersToCopy := make(map[string]bool)
-	unmatchedArgs := mapset.NewSet(args...)
-	rootStackURN := ""
-	for _, res := range sourceSnapshot.Resources {
-		matchedArg := resourceMatches(res, args)
-		if matchedArg != "" {
-			if strings.HasPrefix(string(res.Type), "pulumi:providers:") {
-				//nolint:lll
-				return errors.New("cannot move providers. Only resources can be moved, and providers will be included automatically")
-			}
-			if res.Type == resource.RootStackType && res.Parent == "" {
-				rootStackURN = string(res.URN)
-			}
-			resourcesToMove[string(res.URN)] = res
-			providersToCopy[res.Provider] = true
-			unmatchedArgs.Remove(matchedArg)
-		}
-	}
-
-	for _, arg := range unmatchedArgs.ToSlice() {
-		fmt.Fprintf(cmd.Stdout, cmd.Colorizer.Colorize(colors.SpecWarning+"warning:"+
-			colors.Reset+" Resource %s not found in source stack\n"), arg)
-	}
-
-	if len(resourcesToMove) == 0 {
-		return errors.New("no resources found to move")
-	}
-
-	sourceDepGraph := graph.NewDependencyGraph(sourceSnapshot.Resources)
-
-	if cmd.IncludeParents {
-		for _, res := range resourcesToMove {
-			for _, parent := range sourceDepGraph.ParentsOf(res) {
-				if res.Type == resource.RootStackType && res.Parent == "" {
-					// We don't move the root stack explicitly, the code below will take care of dealing with that correctly.
-					continue
-				}
-				resourcesToMove[string(parent.URN)] = parent
-				providersToCopy[parent.Provider] = true
-			}
-		}
-	}
-
-	// include all children in the list of resources to move
-	for _, res := range resourcesToMove {
-		for _, dep := range sourceDepGraph.ChildrenOf(res) {
-			resourcesToMove[string(dep.URN)] = dep
-			providersToCopy[dep.Provider] = true
-		}
-	}
-
-	// We don't want to include the root stack in the list of resources to move.  The root stack
-	// either already exists in the destination stack or will be created when we move the resources.
-	delete(resourcesToMove, rootStackURN)
-
-	// We want to move the resources in the order they appear in the source snapshot,
-	// so that resources with relationships are in the right order.  Also check which
-	// resources are remaining in the source stack, now that we know all resources
-	// that are going to be moved.
-	remainingResources := make(map[string]*resource.State)
-	var resourcesToMoveOrdered []*resource.State
-	for _, res := range sourceSnapshot.Resources {
-		if _, ok := resourcesToMove[string(res.URN)]; ok {
-			resourcesToMoveOrdered = append(resourcesToMoveOrdered, res)
-		} else {
-			remainingResources[string(res.URN)] = res
+	for _, arg := range args {
+		if len(edit.LocateResource(sourceSnapshot, resource.URN(arg))) == 0 {
+			fmt.Fprintf(cmd.Stdout, cmd.Colorizer.Colorize(colors.SpecWarning+"warning:"+
+				colors.Reset+" Resource %s not found in source stack\n"), arg)
 		}
 	}
 
-	// run through the source snapshot and find all the providers
-	// that need to be copied, remove all the resources that need
-	// to be removed, and break the dependencies that are no
-	// longer valid.
-	var providers []*resource.State
-	var brokenSourceDependencies []brokenDependency
-	i := 0
-	for _, res := range sourceSnapshot.Resources {
-		// Find providers that need to be copied
-		if _, ok := resourcesToMove[string(res.URN)]; ok {
-			continue
-		}
-		if providersToCopy[string(res.URN)+"::"+string(res.ID)] {
-			providers = append(providers, res)
-		}
-
-		sourceSnapshot.Resources[i] = res
-		i++
-		brokenSourceDependencies = append(brokenSourceDependencies, breakDependencies(res, resourcesToMove)...)
-	}
-	sourceSnapshot.Resources = sourceSnapshot.Resources[:i]
+	// Stacks from backends that don't support projects have no project name. MoveResources only fails because of that
+	// once it knows there are resources to move.
+	projectName, _ := dest.Ref().Project()
 
 	// Save a copy of the destination snapshot so we can restore it if saving the source snapshot with the
 	// deleted resources fails.
 	originalDestResources := destSnapshot.Resources
 
-	// Create a root stack if there is none
-	rootStack, err := stack.GetRootStackResource(destSnapshot)
+	urns := make([]resource.URN, len(args))
+	for i, arg := range args {
+		urns[i] = resource.URN(arg)
+	}
+	result, err := edit.MoveResources(sourceSnapshot, destSnapshot, urns, edit.MoveOptions{
+		DestStack:      dest.Ref().Name(),
+		DestProject:    tokens.PackageName(projectName),
+		IncludeParents: cmd.IncludeParents,
+	})
 	if err != nil {
 		return err
 	}
-	if rootStack == nil {
-		projectName, ok := dest.Ref().Project()
-		if !ok {
-			return errors.New("failed to get project name of source stack")
-		}
-		rootStack = stack.CreateRootStackResource(
-			dest.Ref().Name().Q(), tokens.PackageName(projectName))
-		destSnapshot.Resources = append([]*resource.State{rootStack}, destSnapshot.Resources...)
-	}
-
-	destResMap := make(map[urn.URN]*resource.State)
-	for _, res := range destSnapshot.Resources {
-		destResMap[res.URN] = res
-	}
-
-	rewriteMap := make(map[string]string)
-	for _, res := range providers {
-		// Providers stay in the source stack, so we need a copy of the provider to be able to
-		// rewrite the URNs of the resource.
-		r := res.Copy()
-		if _, ok := resourcesToMove[string(r.Parent)]; !ok {
-			rootStack, err := stack.GetRootStackResource(destSnapshot)
-			if err != nil {
-				return err
-			}
-			r.Parent = rootStack.URN
-		}
-		err = rewriteURNs(r, dest, nil)
-		if err != nil {
-			return err
-		}
-
-		if destRes, ok := destResMap[r.URN]; ok {
-			// If the provider ID matches, we can assume that the provider has previously been copied and we can just copy it.
-			if destRes.ID == r.ID {
-				continue
-			}
-			// If all the inputs of the provider in the destination stack are the same as the provider in the source stack,
-			// we can assume that the provider is equal for the purpose of resources depending on it.  We don't need to copy
-			// it, but we need to set the provider for all resources to the provider in the destination stack.
-			if destRes.Inputs.DeepEquals(r.Inputs) {
-				rewriteMap[fmt.Sprintf("%s::%s", res.URN, res.ID)] = fmt.Sprintf("%s::%s", destRes.URN, destRes.ID)
-				continue
-			}
-			return fmt.Errorf("provider %s already exists in destination stack", r.URN)
-		}
-
-		destSnapshot.Resources = append(destSnapshot.Resources, r)
-	}
+	resourcesToMoveOrdered := result.Moved
+	brokenSourceDependencies := result.BrokenSourceDependencies
+	brokenDestDependencies := result.BrokenDestDependencies
 
 	fmt.Fprintf(cmd.Stdout, cmd.Colorizer.Colorize(
 		colors.SpecHeadline+"Planning to move the following resources from %s to %s:\n"+colors.Reset),
@@ -370,31 +247,6 @@ func (cmd *stateMoveCmd) Run(
 	}
 	fmt.Fprintf(cmd.Stdout, "\n")
 
-	var brokenDestDependencies []brokenDependency
-	for _, res := range resourcesToMoveOrdered {
-		// We need the original resources URNs later in case of errors, so make a copy here before modifying them.
-		r := res.Copy()
-		if _, ok := resourcesToMove[string(r.Parent)]; !ok {
-			rootStack, err := stack.GetRootStackResource(destSnapshot)
-			if err != nil {
-				return err
-			}
-			r.Parent = rootStack.URN
-		}
-
-		brokenDestDependencies = append(brokenDestDependencies, breakDependencies(r, remainingResources)...)
-		err = rewriteURNs(r, dest, rewriteMap)
-		if err != nil {
-			return err
-		}
-
-		if _, ok := destResMap[r.URN]; ok {
-			return fmt.Errorf("resource %s already exists in destination stack", r.URN)
-		}
-
-		destSnapshot.Resources = append(destSnapshot.Resources, r)
-	}
-
 	if len(brokenSourceDependencies) > 0 {
 		fmt.Fprintf(cmd.Stdout, cmd.Colorizer.Colorize(
 			colors.SpecWarning+"The following resources remaining in %s have dependencies on resources moved to %s:\n\n"+
@@ -495,153 +347,16 @@ None of the resources have been moved.  Please fix the error and try again`, err
 	return nil
 }
 
-func resourceMatches(res *resource.State, args []string) string {
-	for _, arg := range args {
-		if string(res.URN) == arg {
-			return arg
-		}
-	}
-	return ""
-}
-
-type dependencyType int
-
-const (
-	dependency dependencyType = iota
-	propertyDependency
-	deletedWith
-)
-
-type brokenDependency struct {
-	dependencyURN  urn.URN
-	dependencyType dependencyType
-	propdepKey     resource.PropertyKey
-	resourceURN    urn.URN
-}
-
-func breakDependencies(res *resource.State, resourcesToMove map[string]*resource.State) []brokenDependency {
-	var brokenDeps []brokenDependency
-
-	var preservedDeps []urn.URN
-	preservedPropDeps := map[resource.PropertyKey][]urn.URN{}
-	preservedDeletedWith := urn.URN("")
-
-	// Providers are always moved, so we don't need to break the dependency and can ignore them here.
-	_, allDeps := res.GetAllDependencies()
-	for _, dep := range allDeps {
+func (cmd *stateMoveCmd) printBrokenDependencyRelationships(brokenDeps []edit.BrokenDependency) {
+	for _, dep := range brokenDeps {
 		switch dep.Type {
-		case resource.ResourceParent:
-			// Resources are reparented appropriately later on, so we ignore parent dependencies here.
-			continue
 		case resource.ResourceDependency:
-			if _, ok := resourcesToMove[string(dep.URN)]; ok {
-				brokenDeps = append(brokenDeps, brokenDependency{
-					dependencyURN:  dep.URN,
-					dependencyType: dependency,
-					resourceURN:    res.URN,
-				})
-			} else {
-				preservedDeps = append(preservedDeps, dep.URN)
-			}
+			fmt.Fprintf(cmd.Stdout, "  - %s has a dependency on %s\n", dep.Resource, dep.Dependency)
 		case resource.ResourcePropertyDependency:
-			if _, ok := resourcesToMove[string(dep.URN)]; ok {
-				brokenDeps = append(brokenDeps, brokenDependency{
-					dependencyURN:  dep.URN,
-					dependencyType: propertyDependency,
-					propdepKey:     dep.Key,
-					resourceURN:    res.URN,
-				})
-			} else {
-				preservedPropDeps[dep.Key] = append(preservedPropDeps[dep.Key], dep.URN)
-			}
-		case resource.ResourceDeletedWith:
-			if _, ok := resourcesToMove[string(dep.URN)]; ok {
-				brokenDeps = append(brokenDeps, brokenDependency{
-					dependencyURN:  dep.URN,
-					dependencyType: deletedWith,
-					resourceURN:    res.URN,
-				})
-			} else {
-				preservedDeletedWith = dep.URN
-			}
-		}
-	}
-
-	res.Dependencies = preservedDeps
-	res.PropertyDependencies = preservedPropDeps
-	res.DeletedWith = preservedDeletedWith
-
-	return brokenDeps
-}
-
-func renameStackAndProject(urn urn.URN, stack backend.Stack) (urn.URN, error) {
-	newURN := urn.RenameStack(stack.Ref().Name())
-	if project, ok := stack.Ref().Project(); ok {
-		newURN = newURN.RenameProject(tokens.PackageName(project))
-	} else {
-		return "", errors.New("cannot get project name. " +
-			"Please upgrade your project with `pulumi state upgrade` to solve this.")
-	}
-	return newURN, nil
-}
-
-func rewriteURNs(res *resource.State, dest backend.Stack, rewriteMap map[string]string) error {
-	var err error
-	res.URN, err = renameStackAndProject(res.URN, dest)
-	if err != nil {
-		return err
-	}
-
-	provider, allDeps := res.GetAllDependencies()
-	if provider != "" {
-		if newProviderURN, ok := rewriteMap[provider]; ok {
-			res.Provider = newProviderURN
-		} else {
-			providerURN, err := renameStackAndProject(urn.URN(provider), dest)
-			if err != nil {
-				return err
-			}
-			res.Provider = string(providerURN)
-		}
-	}
-
-	var rewrittenDeps []urn.URN
-	rewrittenPropDeps := map[resource.PropertyKey][]urn.URN{}
-
-	for _, dep := range allDeps {
-		rewrittenURN, err := renameStackAndProject(dep.URN, dest)
-		if err != nil {
-			return err
-		}
-
-		switch dep.Type {
-		case resource.ResourceParent:
-			res.Parent = rewrittenURN
-		case resource.ResourceDependency:
-			rewrittenDeps = append(rewrittenDeps, rewrittenURN)
-		case resource.ResourcePropertyDependency:
-			rewrittenPropDeps[dep.Key] = append(rewrittenPropDeps[dep.Key], rewrittenURN)
-		case resource.ResourceDeletedWith:
-			res.DeletedWith = rewrittenURN
-		}
-	}
-
-	res.Dependencies = rewrittenDeps
-	res.PropertyDependencies = rewrittenPropDeps
-
-	return nil
-}
-
-func (cmd *stateMoveCmd) printBrokenDependencyRelationships(brokenDeps []brokenDependency) {
-	for _, res := range brokenDeps {
-		switch res.dependencyType {
-		case dependency:
-			fmt.Fprintf(cmd.Stdout, "  - %s has a dependency on %s\n", res.resourceURN, res.dependencyURN)
-		case propertyDependency:
 			fmt.Fprintf(cmd.Stdout, "  - %s (%s) has a property dependency on %s\n",
-				res.resourceURN, res.propdepKey, res.dependencyURN)
-		case deletedWith:
-			fmt.Fprintf(cmd.Stdout, "  - %s is marked as deleted with %s\n", res.resourceURN, res.dependencyURN)
+				dep.Resource, dep.Key, dep.Dependency)
+		case resource.ResourceDeletedWith:
+			fmt.Fprintf(cmd.Stdout, "  - %s is marked as deleted with %s\n", dep.Resource, dep.Dependency)
 		}
 	}
 }
@@ -26,7 +26,6 @@ import (
 	pkgWorkspace "github.com/pulumi/pulumi/pkg/v3/workspace"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/env"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
 
 	"github.com/spf13/cobra"
@@ -43,11 +42,13 @@ func newStateProtectCommand() *cobra.Command {
 	var yes bool
 
 	cmd := &cobra.Command{
-		Use:   "protect [resource URN...]",
+		Use:   "protect [resource URN or filter...]",
 		Short: "protect resource in a stack's state",
 		Long: `Protect resource in a stack's state
 
 This command sets the 'protect' bit on one or more resources, preventing those resources from being deleted.
+Resources can be given by URN or by a filter expression, as accepted by ` + "`pulumi stack query`" + `, e.g.
+'type = "aws:rds/*"'.
 
 Caution: this command is a low-level operation that directly modifies your stack's state.
 Setting the 'protect' bit on a resource in your stack's state is not sufficient to protect it in
@@ -131,28 +132,12 @@ func protectResourcesInSnapshot(snap *deploy.Snapshot, urns []string) (int, []er
 		return 0, []error{errors.New("no resources found to protect")}
 	}
 
-	var errs []error
-	resourceCount := 0
-
-	// Map URNs to resources for efficient lookup
-	urnToResource := make(map[resource.URN]*resource.State)
-	for _, res := range snap.Resources {
-		urnToResource[res.URN] = res
-	}
-
-	for _, urnStr := range urns {
-		urn := resource.URN(urnStr)
-		res, found := urnToResource[urn]
-
-		if found {
-			res.Protect = true
-			resourceCount++
-		} else {
-			errs = append(errs, fmt.Errorf("No such resource %q exists in the current state", urn))
-		}
+	resources, errs := selectResources(snap, urns)
+	for _, res := range resources {
+		res.Protect = true
 	}
 
-	return resourceCount, errs
+	return len(resources), errs
 }
 
 // protectMultipleResources protects multiple resources specified by their URNs.
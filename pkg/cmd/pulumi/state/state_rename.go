@@ -22,7 +22,6 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/backend"
 	"github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/ui"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
-	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
 	"github.com/pulumi/pulumi/pkg/v3/resource/edit"
 	pkgWorkspace "github.com/pulumi/pulumi/pkg/v3/workspace"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/env"
@@ -40,110 +39,7 @@ func stateReurnOperation(
 	contract.Requiref(oldURN != "", "oldURN", "must not be empty")
 	contract.Requiref(newURN != "", "newURN", "must not be empty")
 
-	// Check whether the input URN corresponds to an existing resource
-	existingResources := edit.LocateResource(snap, oldURN)
-	if len(existingResources) != 1 {
-		return errors.New("The input URN does not correspond to an existing resource")
-	}
-
-	// If the URN hasn't changed then there's nothing to do.
-	if oldURN == newURN {
-		return nil
-	}
-
-	inputResource := existingResources[0]
-	contract.Assertf(inputResource.URN == oldURN, "The input resource does not match the input URN")
-	// Check whether the new URN _does not_ correspond to an existing resource
-	candidateResources := edit.LocateResource(snap, newURN)
-	if len(candidateResources) > 0 {
-		return errors.New("The chosen new urn for the state corresponds to an already existing resource")
-	}
-
-	// Update the URN of the input resource
-	inputResource.URN = newURN
-	// Update the dependants of the input resource
-	for _, existingResource := range snap.Resources {
-		// update resources other than the input resource
-		if existingResource.URN != inputResource.URN {
-			var updatedDeps []resource.URN
-			updatedPropDeps := map[resource.PropertyKey][]resource.URN{}
-
-			// We handle providers separately later on, so ignore the return here.
-			_, allDeps := existingResource.GetAllDependencies()
-			for _, dep := range allDeps {
-				switch dep.Type {
-				case resource.ResourceParent:
-					if dep.URN == oldURN {
-						existingResource.Parent = newURN
-
-						// We also need to update this resources URN now
-						oldChildURN := existingResource.URN
-						newChildURN := resource.NewURN(
-							oldChildURN.Stack(), oldChildURN.Project(),
-							newURN.QualifiedType(), oldChildURN.Type(),
-							oldChildURN.Name())
-						err := stateReurnOperation(oldChildURN, newChildURN, opts, snap)
-						if err != nil {
-							return fmt.Errorf("failed to update %s with new parent %s: %w", oldChildURN, newURN, err)
-						}
-					}
-				case resource.ResourceDependency:
-					if dep.URN == oldURN {
-						dep.URN = newURN
-					}
-					updatedDeps = append(updatedDeps, dep.URN)
-				case resource.ResourcePropertyDependency:
-					if dep.URN == oldURN {
-						dep.URN = newURN
-					}
-					updatedPropDeps[dep.Key] = append(updatedPropDeps[dep.Key], dep.URN)
-				case resource.ResourceDeletedWith:
-					if dep.URN == oldURN {
-						dep.URN = newURN
-					}
-					existingResource.DeletedWith = dep.URN
-				}
-			}
-
-			existingResource.Dependencies = updatedDeps
-			existingResource.PropertyDependencies = updatedPropDeps
-		}
-	}
-
-	updateProvider := func(newRef providers.Reference) error {
-		// Loop through all resources and rename references to the provider.
-		for _, curResource := range snap.Resources {
-			if curResource.Provider == "" {
-				// Skip resources that don't use a provider.
-				continue
-			}
-
-			curResourceProviderRef, err := providers.ParseReference(curResource.Provider)
-			if err != nil {
-				return err
-			}
-
-			// Skip resources that don't use the renamed provider.
-			if curResourceProviderRef.URN() != oldURN {
-				continue
-			}
-
-			// Update the provider.
-			curResource.Provider = newRef.String()
-		}
-		return nil
-	}
-
-	// If the renamed resource is a Provider, fix all resources referring to the old name.
-	if providers.IsProviderType(inputResource.Type) {
-		newRef, err := providers.NewReference(newURN, inputResource.ID)
-		if err != nil {
-			return err
-		}
-		return updateProvider(newRef)
-	}
-
-	return nil
+	return edit.ChangeResourceURN(snap, oldURN, newURN)
 }
 
 // stateRenameOperation renames a resource (or provider) and mutates/rewrites references to it in the snapshot.
@@ -17,10 +17,12 @@ package state
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"runtime"
+	"slices"
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
@@ -30,6 +32,7 @@ import (
 	cmdBackend "github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/backend"
 	cmdStack "github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/stack"
 	"github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/ui"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
 	pkgWorkspace "github.com/pulumi/pulumi/pkg/v3/workspace"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
@@ -66,6 +69,10 @@ type stateRepairArgs struct {
 	Stack     string
 	Colorizer colors.Colorization
 	Yes       bool
+	Preview   bool
+	// DropUndecryptableSecrets is true if secrets that cannot be decrypted should be replaced with null values. Their
+	// values are lost, so this has to be asked for explicitly.
+	DropUndecryptableSecrets bool
 }
 
 func newStateRepairCommand() *cobra.Command {
@@ -85,12 +92,28 @@ func newStateRepairCommand() *cobra.Command {
 		Short: "Repair an invalid state",
 		Long: `Repair an invalid state,
 
-This command can be used to repair an invalid state file. It will attempt to
-sort resources that appear out of order and remove references to resources that
-are no longer present in the state. If the state is already valid, this command
-will not attempt to make or write any changes. If the state is not already
-valid, and remains invalid after repair has been attempted, this command will
-not write any changes.
+This command can be used to repair an invalid state file. It checks the state
+for the same issues as ` + "`pulumi state verify`" + ` and attempts to fix each of them:
+
+* resources that appear out of order are sorted;
+* references to resources that are no longer present in the state are removed;
+* where several resources share a URN, all but the most recently written are
+  removed;
+* pending update and delete operations on resources that are no longer present
+  in the state are removed;
+* references to providers that are pending deletion are redirected to their
+  replacement if there is one, and otherwise the provider is restored;
+* secrets that cannot be decrypted are replaced with null values, which a
+  subsequent ` + "`pulumi refresh`" + ` can restore from the cloud provider. The
+  values of such secrets are lost, so this is only done when
+  --drop-undecryptable-secrets is given. Otherwise they are reported and the
+  state is left unchanged.
+
+A summary of the changes is shown before they are written, and --preview can be
+used to print the repaired state without writing it. If the state is already
+valid, this command will not attempt to make or write any changes. If the state
+is not already valid, and remains invalid after repair has been attempted, this
+command will not write any changes.
 `,
 		Args: cmdutil.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -109,6 +132,10 @@ not write any changes.
 		"stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
 	cmd.Flags().BoolVarP(&stateRepair.Args.Yes,
 		"yes", "y", false, "Automatically approve and perform the repair")
+	cmd.Flags().BoolVar(&stateRepair.Args.Preview,
+		"preview", false, "Print the repaired state as JSON instead of writing it")
+	cmd.Flags().BoolVar(&stateRepair.Args.DropUndecryptableSecrets,
+		"drop-undecryptable-secrets", false, "Replace secrets that cannot be decrypted with null values")
 
 	return cmd
 }
@@ -137,7 +164,7 @@ func (cmd *stateRepairCmd) run(ctx context.Context) error {
 		return err
 	}
 
-	snap, err := s.Snapshot(ctx, stack.DefaultSecretsProvider)
+	snap, secretIssues, err := loadSnapshotForRepair(ctx, s)
 	if err != nil {
 		return err
 	} else if snap == nil {
@@ -149,11 +176,35 @@ func (cmd *stateRepairCmd) run(ctx context.Context) error {
 	})
 
 	// If the snapshot is already valid, we won't touch it.
-	initialErr := snap.VerifyIntegrity()
-	if initialErr == nil {
+	issues := slices.Concat(secretIssues, snap.Verify())
+	if len(issues) == 0 {
 		sink.Infof(diag.RawMessage("" /*urn*/, "The snapshot is already valid; skipping repair"))
 		return nil
 	}
+	initialErr := snapshotIssuesError(issues)
+
+	// Secrets that could not be decrypted have already been replaced with null values as part of loading the snapshot.
+	// Writing the snapshot back would lose their values, so unless the user has asked for that, we leave the state as it
+	// is, with the secrets still encrypted.
+	if len(secretIssues) > 0 && !cmd.Args.DropUndecryptableSecrets {
+		for _, issue := range secretIssues {
+			sink.Errorf(diag.RawMessage(issue.URN, issue.Message))
+		}
+		sink.Errorf(diag.RawMessage("" /*urn*/, "The state has secrets that cannot be decrypted, so it has not been "+
+			"repaired. Check the stack's secrets provider configuration, or rerun with --drop-undecryptable-secrets "+
+			"to replace these secrets with null values, which a subsequent `pulumi refresh` can restore."))
+
+		// We've already taken care of printing an error message, so we'll wrap the error we return in a Bail so that
+		// callers higher up the stack don't print anything of their own.
+		return result.BailError(snapshotIssuesError(secretIssues))
+	}
+	ops := stateRepairOperations{UndecryptableSecrets: secretIssues}
+
+	// Fix the issues that would otherwise get in the way of sorting: duplicate URNs make dependencies ambiguous, and
+	// restoring providers may leave them out of order.
+	ops.Duplicates = snap.RemoveDuplicateResources()
+	ops.OrphanedOperations = snap.RemoveOrphanedPendingOperations()
+	ops.ProviderRepairs, ops.RestoredProviders = snap.RepairProviderReferences()
 
 	beforeSort := snap.Resources
 
@@ -170,29 +221,46 @@ func (cmd *stateRepairCmd) run(ctx context.Context) error {
 	}
 
 	afterSort := snap.Resources
-	reorderings := computeStateRepairReorderings(beforeSort, afterSort)
+	ops.Reorderings = computeStateRepairReorderings(beforeSort, afterSort)
+
+	ops.PruneResults = snap.Prune()
 
-	pruneResults := snap.Prune()
+	// The magic cookie is derived from the manifest, so a mismatch can be fixed by recomputing it.
+	snap.Manifest.Magic = snap.Manifest.NewMagic()
 
 	// In the case that we complete repairs (sorting, pruning and so on) but the snapshot is still invalid, we'll
 	// produce a banner that helps the user conduct a manual repair but also includes both errors, so that if they
 	// file a report we can hopefully diagnose both what caused the invalid snapshot but also why we failed to repair
 	// it (assuming a repair was possible).
-	err = snap.VerifyIntegrity()
-	if err != nil {
-		sink.Errorf(diag.RawMessage("" /*urn*/, cmd.manualRepairError(initialErr, err)))
+	if remaining := snap.Verify(); len(remaining) > 0 {
+		sink.Errorf(diag.RawMessage("" /*urn*/, cmd.manualRepairError(initialErr, snapshotIssuesError(remaining))))
 
 		// We've already taken care of printing an error message, so we'll wrap the error we return in a Bail so that
 		// callers higher up the stack don't print anything of their own.
 		return result.BailError(initialErr)
 	}
 
+	// We've managed to repair the snapshot -- serialize it so that we can either preview or import it.
+	sdep, err := stack.SerializeDeployment(ctx, snap, false /*showSecrets*/)
+	if err != nil {
+		return fmt.Errorf("serializing deployment: %w", err)
+	}
+
+	// When previewing, the summary goes to stderr so that the repaired state can be redirected to a file.
+	if cmd.Args.Preview {
+		fmt.Fprint(cmd.Stderr, renderStateRepairOperations(cmd.Args.Colorizer, ops))
+
+		enc := json.NewEncoder(cmd.Stdout)
+		enc.SetIndent("", "    ")
+		return enc.Encode(sdep)
+	}
+
 	// We've managed to repair the invalid snapshot. If the user has passed the --yes flag, we'll just write the changes.
 	// If not, we'll render a summary of the operations we've performed and ask for confirmation before writing.
 	if !cmd.Args.Yes {
 		sink.Infof(diag.RawMessage(
 			"", /*urn*/
-			renderStateRepairOperations(cmd.Args.Colorizer, reorderings, pruneResults),
+			renderStateRepairOperations(cmd.Args.Colorizer, ops),
 		))
 
 		yes := "yes"
@@ -212,12 +280,7 @@ func (cmd *stateRepairCmd) run(ctx context.Context) error {
 		}
 	}
 
-	// We've managed to repair the snapshot -- import it back into the backend.
-	sdep, err := stack.SerializeDeployment(ctx, snap, false /*showSecrets*/)
-	if err != nil {
-		return fmt.Errorf("serializing deployment: %w", err)
-	}
-
+	// Import the repaired snapshot back into the backend.
 	bytes, err := json.Marshal(sdep)
 	if err != nil {
 		return err
@@ -235,6 +298,36 @@ func (cmd *stateRepairCmd) run(ctx context.Context) error {
 	return nil
 }
 
+// loadSnapshotForRepair loads the given stack's snapshot for verification or repair. Rather than failing, secrets that
+// cannot be decrypted are replaced with null values and returned as issues. The snapshot returned is nil if the stack
+// has no deployment.
+func loadSnapshotForRepair(
+	ctx context.Context,
+	s backend.Stack,
+) (*deploy.Snapshot, []deploy.SnapshotIssue, error) {
+	untyped, err := backend.ExportStackDeployment(ctx, s)
+	if err != nil {
+		return nil, nil, err
+	} else if untyped == nil || len(untyped.Deployment) == 0 {
+		return nil, nil, nil
+	}
+
+	deployment, err := stack.UnmarshalUntypedDeployment(ctx, untyped)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return stack.DeserializeDeploymentV3AllowingUndecryptableSecrets(ctx, *deployment, stack.DefaultSecretsProvider)
+}
+
+// snapshotIssuesError summarises a non-empty list of snapshot issues as a single error.
+func snapshotIssuesError(issues []deploy.SnapshotIssue) error {
+	if len(issues) == 1 {
+		return errors.New(issues[0].Message)
+	}
+	return fmt.Errorf("%s (and %d more issues)", issues[0].Message, len(issues)-1)
+}
+
 // Returns a help banner detailing the given error and providing instructions for manual state repair.
 func (cmd *stateRepairCmd) manualRepairError(initialErr error, err error) string {
 	stateFile := "state.json"
@@ -92,14 +92,115 @@ func computeStateRepairReorderings(
 	return reorderings
 }
 
+// The set of operations performed by `state repair`, grouped by the kind of issue they fix.
+type stateRepairOperations struct {
+	// Resources whose secrets could not be decrypted and have been replaced with null values.
+	UndecryptableSecrets []deploy.SnapshotIssue
+	// Resources removed because a more recent resource has the same URN.
+	Duplicates []*resource.State
+	// Pending operations removed because their resources are no longer in the state.
+	OrphanedOperations []resource.Operation
+	// Provider references redirected to a replacement provider.
+	ProviderRepairs []deploy.ProviderRepairResult
+	// Providers which were pending deletion but have been restored because they are still referenced.
+	RestoredProviders []*resource.State
+	// Resources moved so that they appear before their dependents.
+	Reorderings []*resource.State
+	// Resources modified to remove references to missing resources.
+	PruneResults []deploy.PruneResult
+}
+
 // Renders a set of state repair operations as a human-readable string.
 func renderStateRepairOperations(
 	colorization colors.Colorization,
-	reorderings []*resource.State,
-	pruneResults []deploy.PruneResult,
+	ops stateRepairOperations,
 ) string {
 	var b strings.Builder
 
+	update := colorization.Colorize(colors.SpecUpdate + "~" + colors.Reset + " ")
+	remove := colorization.Colorize(colors.SpecDelete + "-" + colors.Reset + " ")
+	add := colorization.Colorize(colors.SpecCreate + "+" + colors.Reset + " ")
+
+	if len(ops.UndecryptableSecrets) > 0 {
+		b.WriteString(`The following resources have secrets that could not be decrypted and will be replaced with null:
+
+`)
+
+		seen := map[resource.URN]bool{}
+		for _, issue := range ops.UndecryptableSecrets {
+			if !seen[issue.URN] {
+				seen[issue.URN] = true
+				b.WriteString(update)
+				b.WriteString(string(issue.URN))
+				b.WriteString("\n")
+			}
+		}
+
+		b.WriteString("\n")
+	}
+
+	if len(ops.Duplicates) > 0 {
+		b.WriteString(`The following resources will be removed, since a more recent resource has the same URN:
+
+`)
+
+		for _, state := range ops.Duplicates {
+			b.WriteString(remove)
+			b.WriteString(string(state.URN))
+			if state.ID != "" {
+				b.WriteString(" (id: " + string(state.ID) + ")")
+			}
+			b.WriteString("\n")
+		}
+
+		b.WriteString("\n")
+	}
+
+	if len(ops.OrphanedOperations) > 0 {
+		b.WriteString(`The following pending operations will be removed, since their resources no longer exist:
+
+`)
+
+		for _, op := range ops.OrphanedOperations {
+			b.WriteString(remove)
+			b.WriteString(string(op.Resource.URN))
+			b.WriteString(" [" + string(op.Type) + "]\n")
+		}
+
+		b.WriteString("\n")
+	}
+
+	if len(ops.RestoredProviders) > 0 {
+		b.WriteString(`The following providers are pending deletion but still in use, and will be restored:
+
+`)
+
+		for _, state := range ops.RestoredProviders {
+			b.WriteString(add)
+			b.WriteString(string(state.URN))
+			b.WriteString("\n")
+		}
+
+		b.WriteString("\n")
+	}
+
+	if len(ops.ProviderRepairs) > 0 {
+		b.WriteString(`The following resources will be modified to use the replacement of a provider pending deletion:
+
+`)
+
+		for _, result := range ops.ProviderRepairs {
+			b.WriteString(update)
+			b.WriteString(string(result.URN))
+			b.WriteString("\n")
+			b.WriteString("  " + remove + result.OldProvider + " [provider]\n")
+			b.WriteString("  " + add + result.NewProvider + " [provider]\n")
+		}
+
+		b.WriteString("\n")
+	}
+
+	reorderings, pruneResults := ops.Reorderings, ops.PruneResults
 	if len(reorderings) > 0 {
 		b.WriteString(`The following resources will be reordered to appear before their dependents:
 
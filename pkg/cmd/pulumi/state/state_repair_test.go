@@ -24,8 +24,8 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/backend"
 	cmdBackend "github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/backend"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
-	"github.com/pulumi/pulumi/pkg/v3/secrets"
-	"github.com/pulumi/pulumi/pkg/v3/secrets/b64"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/passphrase"
 	pkgWorkspace "github.com/pulumi/pulumi/pkg/v3/workspace"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
@@ -263,6 +263,121 @@ func TestStateRepair_RepairsSnapshots(t *testing.T) {
 	assert.Equal(t, "b", string(fx.imported.Resources[1].URN))
 }
 
+//nolint:paralleltest // State repairing modifies the DisableIntegrityChecking global variable
+func TestStateRepair_RepairsDuplicatesPendingOperationsAndProviders(t *testing.T) {
+	// Arrange.
+	fx := newStateRepairCmdFixtureWithOperations(t, []*resource.State{
+		{URN: "a", ID: "1", Custom: true},
+		{
+			Type:   "pulumi:providers:p",
+			URN:    "urn:pulumi:stack::project::pulumi:providers:p::x",
+			ID:     "id",
+			Custom: true,
+			Delete: true,
+		},
+		{URN: "b", Provider: "urn:pulumi:stack::project::pulumi:providers:p::x::id"},
+		{URN: "a", ID: "2", Custom: true},
+	}, []resource.Operation{
+		resource.NewOperation(&resource.State{URN: "gone", Type: "pkgA:m:typA"}, resource.OperationTypeDeleting),
+	})
+	fx.cmd.Args.Yes = true
+
+	// Act.
+	err := fx.cmd.run(context.Background())
+
+	// Assert.
+	require.NoError(t, err)
+	require.NotNil(t, fx.imported, "Import should have proceeded")
+	require.Len(t, fx.imported.Resources, 3)
+	assert.Equal(t, "urn:pulumi:stack::project::pulumi:providers:p::x", string(fx.imported.Resources[0].URN))
+	assert.False(t, fx.imported.Resources[0].Delete)
+	assert.Equal(t, "b", string(fx.imported.Resources[1].URN))
+	assert.Equal(t, "a", string(fx.imported.Resources[2].URN))
+	assert.Equal(t, "2", string(fx.imported.Resources[2].ID))
+	assert.Empty(t, fx.imported.PendingOperations)
+}
+
+//nolint:paralleltest // State repairing modifies the DisableIntegrityChecking global variable
+func TestStateRepair_UndecryptableSecrets(t *testing.T) {
+	// Arrange.
+	//
+	// The state's secret was encrypted with a different passphrase than the stack's, so it can't be decrypted.
+	t.Setenv("PULUMI_CONFIG_PASSPHRASE", "password")
+	_, sm, err := passphrase.NewPassphraseSecretsManager("password")
+	require.NoError(t, err)
+	_, other, err := passphrase.NewPassphraseSecretsManager("other")
+	require.NoError(t, err)
+	ciphertext, err := other.Encrypter().EncryptValue(context.Background(), `"hunter2"`)
+	require.NoError(t, err)
+
+	deployment := apitype.DeploymentV3{
+		SecretsProviders: &apitype.SecretsProvidersV1{Type: sm.Type(), State: sm.State()},
+		Resources: []apitype.ResourceV3{{
+			URN:  "urn:pulumi:stack::project::pkgA:m:typA::a",
+			Type: "pkgA:m:typA",
+			Outputs: map[string]interface{}{
+				"secret": map[string]interface{}{
+					resource.SigKey: resource.SecretSig,
+					"ciphertext":    ciphertext,
+				},
+			},
+		}},
+	}
+
+	t.Run("without opting in", func(t *testing.T) {
+		fx := newStateRepairCmdFixtureFromDeployment(t, deployment)
+		fx.cmd.Args.Yes = true
+
+		// Act.
+		err := fx.cmd.run(context.Background())
+
+		// Assert.
+		assert.ErrorContains(t, err, "failed to decrypt a secret")
+		assert.Contains(t, fx.stderr.String(), "rerun with --drop-undecryptable-secrets")
+		assert.Nil(t, fx.imported, "Import should not have proceeded")
+	})
+
+	t.Run("dropping undecryptable secrets", func(t *testing.T) {
+		fx := newStateRepairCmdFixtureFromDeployment(t, deployment)
+		fx.cmd.Args.Yes = true
+		fx.cmd.Args.DropUndecryptableSecrets = true
+
+		// Act.
+		err := fx.cmd.run(context.Background())
+
+		// Assert.
+		require.NoError(t, err)
+		require.NotNil(t, fx.imported, "Import should have proceeded")
+		require.Len(t, fx.imported.Resources, 1)
+		snap, err := stack.DeserializeDeploymentV3(context.Background(), *fx.imported, stack.DefaultSecretsProvider)
+		require.NoError(t, err)
+		assert.Equal(t, resource.MakeSecret(resource.NewNullProperty()), snap.Resources[0].Outputs["secret"])
+	})
+}
+
+//nolint:paralleltest // State repairing modifies the DisableIntegrityChecking global variable
+func TestStateRepair_PreviewPrintsRepairedStateWithoutWriting(t *testing.T) {
+	// Arrange.
+	fx := newStateRepairCmdFixture(t, []*resource.State{
+		{URN: "a", ID: "1", Custom: true},
+		{URN: "a", ID: "2", Custom: true},
+	})
+	fx.cmd.Args.Preview = true
+
+	// Act.
+	err := fx.cmd.run(context.Background())
+
+	// Assert.
+	require.NoError(t, err)
+	assert.Nil(t, fx.imported, "Import should not have proceeded")
+	assert.Contains(t, fx.stderr.String(), "will be removed, since a more recent resource has the same URN")
+
+	var preview apitype.DeploymentV3
+	require.NoError(t, json.Unmarshal([]byte(fx.stdout.String()), &preview))
+	require.Len(t, preview.Resources, 1)
+	assert.Equal(t, "2", string(preview.Resources[0].ID))
+}
+
 type stateRepairCmdFixture struct {
 	cmd *stateRepairCmd
 
@@ -276,6 +391,30 @@ type stateRepairCmdFixture struct {
 func newStateRepairCmdFixture(
 	t *testing.T,
 	resources []*resource.State,
+) *stateRepairCmdFixture {
+	return newStateRepairCmdFixtureWithOperations(t, resources, nil)
+}
+
+func newStateRepairCmdFixtureWithOperations(
+	t *testing.T,
+	resources []*resource.State,
+	ops []resource.Operation,
+) *stateRepairCmdFixture {
+	// Resources in these tests are identified only by their URNs, but deserialization requires a type.
+	for _, r := range resources {
+		if r.Type == "" {
+			r.Type = "pkgA:m:typA"
+		}
+	}
+	snap := deploy.NewSnapshot(deploy.Manifest{}, nil, resources, ops, deploy.SnapshotMetadata{})
+	sdep, err := stack.SerializeDeployment(context.Background(), snap, false /*showSecrets*/)
+	require.NoError(t, err)
+	return newStateRepairCmdFixtureFromDeployment(t, *sdep)
+}
+
+func newStateRepairCmdFixtureFromDeployment(
+	t *testing.T,
+	deployment apitype.DeploymentV3,
 ) *stateRepairCmdFixture {
 	fx := &stateRepairCmdFixture{
 		stdin:  &mockFileReader{fd: 0},
@@ -289,6 +428,14 @@ func newStateRepairCmdFixture(
 		GetStackF: func(context.Context, backend.StackReference) (backend.Stack, error) {
 			return s, nil
 		},
+		ExportDeploymentF: func(ctx context.Context, _ backend.Stack) (*apitype.UntypedDeployment, error) {
+			bytes, err := json.Marshal(deployment)
+			require.NoError(t, err)
+			return &apitype.UntypedDeployment{
+				Version:    apitype.DeploymentSchemaVersionCurrent,
+				Deployment: bytes,
+			}, nil
+		},
 		ImportDeploymentF: func(_ context.Context, _ backend.Stack, d *apitype.UntypedDeployment) error {
 			err := json.Unmarshal(d.Deployment, &fx.imported)
 			require.NoError(t, err)
@@ -300,10 +447,6 @@ func newStateRepairCmdFixture(
 		BackendF: func() backend.Backend {
 			return b
 		},
-		SnapshotF: func(context.Context, secrets.Provider) (*deploy.Snapshot, error) {
-			sm := b64.NewBase64SecretsManager()
-			return deploy.NewSnapshot(deploy.Manifest{}, sm, resources, nil, deploy.SnapshotMetadata{}), nil
-		},
 	}
 
 	ws := &pkgWorkspace.MockContext{
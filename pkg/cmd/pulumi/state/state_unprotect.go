@@ -38,11 +38,13 @@ func newStateUnprotectCommand() *cobra.Command {
 	var yes bool
 
 	cmd := &cobra.Command{
-		Use:   "unprotect [resource URN...]",
+		Use:   "unprotect [resource URN or filter...]",
 		Short: "Unprotect resources in a stack's state",
 		Long: `Unprotect resources in a stack's state
 
 This command clears the 'protect' bit on one or more resources, allowing those resources to be deleted.
+Resources can be given by URN or by a filter expression, as accepted by ` + "`pulumi stack query`" + `, e.g.
+'ancestor = "urn:pulumi:dev::proj::my:index:Website::site"'.
 
 To see the list of URNs in a stack, use ` + "`pulumi stack --show-urns`" + `.`,
 		Args: cobra.ArbitraryArgs,
@@ -124,28 +126,12 @@ func unprotectResourcesInSnapshot(snap *deploy.Snapshot, urns []string) (int, []
 		return 0, []error{errors.New("no resources found to unprotect")}
 	}
 
-	var errs []error
-	resourceCount := 0
-
-	// Map URNs to resources for efficient lookup
-	urnToResource := make(map[resource.URN]*resource.State)
-	for _, res := range snap.Resources {
-		urnToResource[res.URN] = res
-	}
-
-	for _, urnStr := range urns {
-		urn := resource.URN(urnStr)
-		res, found := urnToResource[urn]
-
-		if found {
-			res.Protect = false
-			resourceCount++
-		} else {
-			errs = append(errs, fmt.Errorf("No such resource %q exists in the current state", urn))
-		}
+	resources, errs := selectResources(snap, urns)
+	for _, res := range resources {
+		res.Protect = false
 	}
 
-	return resourceCount, errs
+	return len(resources), errs
 }
 
 // unprotectMultipleResources unprotects multiple resources specified by their URNs.
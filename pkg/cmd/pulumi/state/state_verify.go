@@ -0,0 +1,121 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	cmdBackend "github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/backend"
+	cmdStack "github.com/pulumi/pulumi/pkg/v3/cmd/pulumi/stack"
+	pkgWorkspace "github.com/pulumi/pulumi/pkg/v3/workspace"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+type stateVerifyCmd struct {
+	// The name of the stack to operate on.
+	Stack string
+	// The colorization to use for output.
+	Colorizer colors.Colorization
+
+	// The command's standard output.
+	Stdout io.Writer
+
+	// The workspace to operate on.
+	Workspace pkgWorkspace.Context
+	// The login manager to use for authenticating with and loading backends.
+	LoginManager cmdBackend.LoginManager
+}
+
+func newStateVerifyCommand() *cobra.Command {
+	stateVerify := &stateVerifyCmd{
+		Colorizer:    cmdutil.GetGlobalColorization(),
+		Stdout:       os.Stdout,
+		Workspace:    pkgWorkspace.Instance,
+		LoginManager: cmdBackend.DefaultLoginManager,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Check a stack's state for integrity issues",
+		Long: `Check a stack's state for integrity issues
+
+This command runs all of the integrity checks that Pulumi performs on a stack's
+state and reports every issue it finds, rather than stopping at the first one.
+It checks for:
+
+* references to parents, dependencies or providers that are missing from the
+  state or that appear after the resources that refer to them;
+* multiple resources with the same URN that are not pending deletion;
+* pending update and delete operations on resources that no longer exist;
+* resources that refer to providers which are pending deletion;
+* secrets that cannot be decrypted with the stack's secrets provider.
+
+The state is not modified. If any issues are found, the command exits with an
+error; ` + "`pulumi state repair`" + ` can be used to attempt to fix them.`,
+		Args: cmdutil.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return stateVerify.run(cmd.Context())
+		},
+	}
+
+	cmd.Flags().StringVarP(&stateVerify.Stack,
+		"stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+
+	return cmd
+}
+
+func (cmd *stateVerifyCmd) run(ctx context.Context) error {
+	// As with `state repair`, we need to disable integrity checking in order to be able to load invalid states at all.
+	backend.DisableIntegrityChecking = true
+	defer func() { backend.DisableIntegrityChecking = false }()
+
+	s, err := cmdStack.RequireStack(
+		ctx,
+		cmdutil.Diag(),
+		cmd.Workspace,
+		cmd.LoginManager,
+		cmd.Stack,
+		cmdStack.LoadOnly,
+		display.Options{Color: cmd.Colorizer},
+	)
+	if err != nil {
+		return err
+	}
+
+	snap, secretIssues, err := loadSnapshotForRepair(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	issues := slices.Concat(secretIssues, snap.Verify())
+	if len(issues) == 0 {
+		fmt.Fprintln(cmd.Stdout, "No integrity issues found")
+		return nil
+	}
+
+	for _, issue := range issues {
+		fmt.Fprintln(cmd.Stdout, cmd.Colorizer.Colorize(colors.SpecError+"*"+colors.Reset), issue.String())
+	}
+
+	return fmt.Errorf("found %d integrity issue(s); run `pulumi state repair` to attempt to fix them", len(issues))
+}
@@ -0,0 +1,84 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest // State verification modifies the DisableIntegrityChecking global variable
+func TestStateVerify_ReportsNoIssuesForValidState(t *testing.T) {
+	// Arrange.
+	cmd, stdout := newStateVerifyCmdFixture(t, []*resource.State{
+		{URN: "a"},
+		{URN: "b", Dependencies: []resource.URN{"a"}},
+	}, nil)
+
+	// Act.
+	err := cmd.run(context.Background())
+
+	// Assert.
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "No integrity issues found")
+}
+
+//nolint:paralleltest // State verification modifies the DisableIntegrityChecking global variable
+func TestStateVerify_ReportsEveryIssue(t *testing.T) {
+	// Arrange.
+	cmd, stdout := newStateVerifyCmdFixture(t, []*resource.State{
+		{URN: "b", Dependencies: []resource.URN{"a"}},
+		{URN: "a"},
+		{URN: "c", Parent: "missing"},
+		{URN: "c"},
+	}, []resource.Operation{
+		resource.NewOperation(&resource.State{URN: "gone", Type: "pkgA:m:typA"}, resource.OperationTypeUpdating),
+	})
+
+	// Act.
+	err := cmd.run(context.Background())
+
+	// Assert.
+	assert.ErrorContains(t, err, "found 4 integrity issue(s)")
+	assert.Contains(t, stdout.String(), "[out-of-order] resource b's dependency a comes after it")
+	assert.Contains(t, stdout.String(), "[dangling-reference] resource c's parent missing is missing")
+	assert.Contains(t, stdout.String(), "[duplicate-urn] duplicate resource c")
+	assert.Contains(t, stdout.String(),
+		"[orphaned-pending-operation] pending updating operation refers to missing resource gone")
+}
+
+func newStateVerifyCmdFixture(
+	t *testing.T,
+	resources []*resource.State,
+	ops []resource.Operation,
+) (*stateVerifyCmd, *bytes.Buffer) {
+	// Verification loads states in the same way as repair, so we can reuse its fixture.
+	fx := newStateRepairCmdFixtureWithOperations(t, resources, ops)
+
+	var stdout bytes.Buffer
+	return &stateVerifyCmd{
+		Stack:        fx.cmd.Args.Stack,
+		Colorizer:    colors.Never,
+		Stdout:       &stdout,
+		Workspace:    fx.cmd.Workspace,
+		LoginManager: fx.cmd.LoginManager,
+	}, &stdout
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 )
 
@@ -174,7 +175,8 @@ func NewBasePluginMapper(
 // With a list of plugins constructed, the mapper will then query each in turn:
 //
 //   - If the plugin's name matches that in the hint, the mapper will pass parameterization information to the plugin as
-//     part of its instantiation.
+//     part of its instantiation. If the hint includes a version, the mapper will ask for that version of the plugin,
+//     falling back to any version if it can not be loaded.
 //   - With a plugin loaded, GetMappings (note the "s") will be called to see if the plugin reports the set of providers
 //     for which it has mappings (e.g. an AWS provider plugin might report `["aws"]`).
 //   - If GetMappings returns a non-empty result, the mapper will then call GetMapping (singular) if any of the keys
@@ -236,7 +238,14 @@ func (m *basePluginMapper) GetMapping(
 
 	// Try the list of plugins we have and see if any of them produce a mapping we can return.
 	for _, mapperSpec := range m.pluginSpecs {
-		pluginSpec, err := workspace.NewPluginSpec(ctx, mapperSpec.name, apitype.ResourcePlugin, nil, "", nil)
+		// If the current plugin's name matches that which we are looking for, and we have a hint that includes a
+		// version, we will ask for that version of the plugin.
+		var version *semver.Version
+		if mapperSpec.name == pluginName && hint != nil && hint.Version != nil {
+			version = hint.Version
+		}
+
+		pluginSpec, err := workspace.NewPluginSpec(ctx, mapperSpec.name, apitype.ResourcePlugin, version, "", nil)
 		if err != nil {
 			return nil, fmt.Errorf("could not create plugin spec for plugin %s: %w", pluginSpec.Name, err)
 		}
@@ -250,6 +259,14 @@ func (m *basePluginMapper) GetMapping(
 		}
 
 		providerPlugin, err := m.providerFactory(descriptor)
+		if err != nil && version != nil {
+			// The hinted version may not be installed, in which case we fall back to whichever version the factory
+			// picks for us.
+			logging.V(7).Infof("could not load version %s of plugin %s, falling back to any version: %v",
+				version, mapperSpec.name, err)
+			descriptor.Version = nil
+			providerPlugin, err = m.providerFactory(descriptor)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("could not create provider for package %s: %w", descriptor.PackageName(), err)
 		}
@@ -709,3 +709,70 @@ func semverMustParse(s string) *semver.Version {
 	v := semver.MustParse(s)
 	return &v
 }
+
+// Tests that a base plugin mapper will ask for the version of a plugin given in a hint, and that it will fall back to
+// any version of the plugin if the hinted version can not be loaded.
+func TestBasePluginMapper_UsesVersionHint(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	ws := &testWorkspace{
+		infos: []workspace.PluginInfo{
+			{
+				Name:    "pulumiProviderGcp",
+				Kind:    apitype.ResourcePlugin,
+				Version: semverMustParse("2.0.0"),
+			},
+		},
+	}
+
+	testProvider := &testProvider{
+		pkg: "pulumiProviderGcp",
+		GetMappingF: func(key, provider string) ([]byte, string, error) {
+			return []byte("datagcp"), "gcp", nil
+		},
+	}
+
+	var requested []*semver.Version
+	providerFactory := func(descriptor workspace.PackageDescriptor) (plugin.Provider, error) {
+		requested = append(requested, descriptor.Version)
+		if descriptor.Version != nil && descriptor.Version.EQ(semver.MustParse("1.0.0")) {
+			return nil, fmt.Errorf("version %s is not installed", descriptor.Version)
+		}
+		return testProvider, nil
+	}
+
+	installPlugin := func(pluginName string) *semver.Version {
+		t.Fatal("should not be called")
+		return nil
+	}
+
+	mapper, err := NewBasePluginMapper(
+		ws,
+		"key", /*conversionKey*/
+		providerFactory,
+		installPlugin,
+		nil, /*mappings*/
+	)
+	require.NoError(t, err)
+
+	// Act.
+	installed := semver.MustParse("2.0.0")
+	data, err := mapper.GetMapping(context.Background(), "gcp", &MapperPackageHint{
+		PluginName: "pulumiProviderGcp",
+		Version:    &installed,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("datagcp"), data)
+
+	missing := semver.MustParse("1.0.0")
+	data, err = mapper.GetMapping(context.Background(), "gcp", &MapperPackageHint{
+		PluginName: "pulumiProviderGcp",
+		Version:    &missing,
+	})
+
+	// Assert.
+	require.NoError(t, err)
+	assert.Equal(t, []byte("datagcp"), data)
+	assert.Equal(t, []*semver.Version{&installed, &missing, nil}, requested)
+}
@@ -17,6 +17,8 @@ package convert
 import (
 	"context"
 
+	"github.com/blang/semver"
+
 	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 )
 
@@ -45,4 +47,9 @@ type MapperPackageHint struct {
 	// by the "terraform-provider" plugin, but only when it is parameterized with the appropriate Terraform provider
 	// information.
 	Parameterization *workspace.Parameterization
+
+	// An optional version of the named plugin that should be used to provide the mapping, e.g. the version of a
+	// provider that is recorded in a stack's state. Implementations may fall back to other versions of the plugin if
+	// this version is not available.
+	Version *semver.Version
 }
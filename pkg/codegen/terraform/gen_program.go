@@ -0,0 +1,727 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package terraform generates Terraform HCL from PCL programs, and Terraform state files from Pulumi state, for
+// resources from bridged Terraform providers. Pulumi tokens and property names are mapped back to their Terraform
+// equivalents using the same mapping data that `pulumi convert` uses to import Terraform programs.
+package terraform
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/convert"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/hcl2/model/format"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/pcl"
+)
+
+type generator struct {
+	// The formatter to use when generating code.
+	*format.Formatter
+
+	ctx         context.Context
+	program     *pcl.Program
+	mapper      convert.Mapper
+	diagnostics hcl.Diagnostics
+
+	// The Terraform mappings of the packages used by the program, keyed by package name. A nil entry means that the
+	// package does not offer a mapping. This is shared by the generators for the program's components.
+	mappings map[string]*providerMapping
+
+	// The data blocks generated for invokes, keyed by the call that they replace.
+	dataSources map[*model.FunctionCallExpression]*dataSource
+	// The data blocks that replace local variables whose values are invokes.
+	localDataSources map[*pcl.LocalVariable]*dataSource
+	// The names of the data blocks generated so far.
+	dataSourceNames codegen.StringSet
+
+	// The range expression of the resource or module currently being generated, and how references to `range` within
+	// it are translated.
+	rangeExpr model.Expression
+	rangeKind rangeKind
+
+	// The mapping for the items of the splat expression currently being generated.
+	splatMapping *objectMapping
+}
+
+// dataSource describes a data block generated for an invoke.
+type dataSource struct {
+	tfType  string
+	name    string
+	mapping *objectMapping
+}
+
+func (d *dataSource) reference() string {
+	return fmt.Sprintf("data.%s.%s", d.tfType, d.name)
+}
+
+// rangeKind describes the Terraform meta-argument used to translate a resource's range option.
+type rangeKind int
+
+const (
+	rangeNone rangeKind = iota
+	// rangeCount ranges over a number using `count`.
+	rangeCount
+	// rangeList ranges over the elements of a list using `count`.
+	rangeList
+	// rangeEach ranges over the entries of a map using `for_each`.
+	rangeEach
+)
+
+// property is a single named value in the body of a resource, data source, provider or module.
+type property struct {
+	name  string
+	value model.Expression
+}
+
+// GenerateProgram generates Terraform HCL for the given program, returning a map from file names to their contents.
+// The mapper is used to look up the Terraform mappings of the program's packages, all of which must be bridged
+// Terraform providers. Components are generated as local modules in a directory of their own.
+func GenerateProgram(
+	ctx context.Context, program *pcl.Program, mapper convert.Mapper,
+) (map[string][]byte, hcl.Diagnostics, error) {
+	files := map[string][]byte{}
+	mappings := map[string]*providerMapping{}
+
+	g := newGenerator(ctx, program, mapper, mappings)
+	if err := g.genModule(files, ""); err != nil {
+		return nil, g.diagnostics, err
+	}
+	diagnostics := g.diagnostics
+
+	components := program.CollectComponents()
+	componentDirs := make([]string, 0, len(components))
+	for componentDir := range components {
+		componentDirs = append(componentDirs, componentDir)
+	}
+	sort.Strings(componentDirs)
+	for _, componentDir := range componentDirs {
+		cg := newGenerator(ctx, components[componentDir].Program, mapper, mappings)
+		err := cg.genModule(files, filepath.Base(componentDir))
+		diagnostics = append(diagnostics, cg.diagnostics...)
+		if err != nil {
+			return nil, diagnostics, err
+		}
+	}
+
+	return files, diagnostics, nil
+}
+
+func newGenerator(
+	ctx context.Context, program *pcl.Program, mapper convert.Mapper, mappings map[string]*providerMapping,
+) *generator {
+	g := &generator{
+		ctx:              ctx,
+		program:          program,
+		mapper:           mapper,
+		mappings:         mappings,
+		dataSources:      map[*model.FunctionCallExpression]*dataSource{},
+		localDataSources: map[*pcl.LocalVariable]*dataSource{},
+		dataSourceNames:  codegen.NewStringSet(),
+	}
+	g.Formatter = format.NewFormatter(g)
+	return g
+}
+
+// genModule generates the files of a Terraform module for the generator's program into the given directory.
+func (g *generator) genModule(files map[string][]byte, dir string) error {
+	var main, variables, outputs bytes.Buffer
+	for _, n := range pcl.Linearize(g.program) {
+		switch n := n.(type) {
+		case *pcl.ConfigVariable:
+			g.genConfigVariable(&variables, n)
+		case *pcl.LocalVariable:
+			if err := g.genDataSources(&main, n); err != nil {
+				return err
+			}
+			g.genLocalVariable(&main, n)
+		case *pcl.Resource:
+			if err := g.genDataSources(&main, n); err != nil {
+				return err
+			}
+			if err := g.genResource(&main, n); err != nil {
+				return err
+			}
+		case *pcl.Component:
+			if err := g.genDataSources(&main, n); err != nil {
+				return err
+			}
+			g.genComponent(&main, n)
+		case *pcl.OutputVariable:
+			if err := g.genDataSources(&main, n); err != nil {
+				return err
+			}
+			g.genOutputVariable(&outputs, n)
+		}
+	}
+
+	for name, buf := range map[string]*bytes.Buffer{"main.tf": &main, "variables.tf": &variables, "outputs.tf": &outputs} {
+		if buf.Len() == 0 {
+			continue
+		}
+		files[path.Join(dir, name)] = hclwrite.Format(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+	}
+	return nil
+}
+
+// packageMapping returns the Terraform mapping for the given package, loading it on first use.
+func (g *generator) packageMapping(pkg string) (*providerMapping, error) {
+	if mapping, ok := g.mappings[pkg]; ok {
+		return mapping, nil
+	}
+	mapping, err := loadProviderMapping(g.ctx, g.mapper, pkg, nil /*version*/)
+	if err != nil {
+		return nil, err
+	}
+	g.mappings[pkg] = mapping
+	return mapping, nil
+}
+
+func (g *generator) errorf(rng hcl.Range, format string, args ...interface{}) {
+	g.diagnostics = append(g.diagnostics, &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  fmt.Sprintf(format, args...),
+		Subject:  &rng,
+	})
+}
+
+func (g *generator) warnf(rng hcl.Range, format string, args ...interface{}) {
+	g.diagnostics = append(g.diagnostics, &hcl.Diagnostic{
+		Severity: hcl.DiagWarning,
+		Summary:  fmt.Sprintf(format, args...),
+		Subject:  &rng,
+	})
+}
+
+// genConfigVariable generates a variable block for a config variable.
+func (g *generator) genConfigVariable(w io.Writer, v *pcl.ConfigVariable) {
+	g.Fgenf(w, "variable %q {\n", v.LogicalName())
+	g.Indented(func() {
+		g.Fgenf(w, "%stype = %s\n", g.Indent, variableType(v.Type()))
+		if v.Description != "" {
+			g.Fgenf(w, "%sdescription = %s\n", g.Indent, quote(v.Description))
+		}
+		switch {
+		case v.DefaultValue != nil:
+			g.Fgenf(w, "%sdefault = %v\n", g.Indent, v.DefaultValue)
+		case v.Nullable:
+			g.Fgenf(w, "%sdefault = null\n", g.Indent)
+		}
+	})
+	g.Fgen(w, "}\n\n")
+}
+
+// variableType returns the Terraform type constraint for the given type.
+func variableType(t model.Type) string {
+	switch t := pcl.UnwrapOption(model.ResolveOutputs(t)).(type) {
+	case *model.ListType:
+		return fmt.Sprintf("list(%s)", variableType(t.ElementType))
+	case *model.SetType:
+		return fmt.Sprintf("set(%s)", variableType(t.ElementType))
+	case *model.MapType:
+		return fmt.Sprintf("map(%s)", variableType(t.ElementType))
+	case *model.ObjectType:
+		names := make([]string, 0, len(t.Properties))
+		for name := range t.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		attributes := make([]string, len(names))
+		for i, name := range names {
+			attributes[i] = fmt.Sprintf("%s = %s", name, variableType(t.Properties[name]))
+		}
+		return fmt.Sprintf("object({%s})", strings.Join(attributes, ", "))
+	default:
+		switch t {
+		case model.BoolType:
+			return "bool"
+		case model.IntType, model.NumberType:
+			return "number"
+		case model.StringType:
+			return "string"
+		default:
+			return "any"
+		}
+	}
+}
+
+// genLocalVariable generates a locals block for a local variable, unless it has been replaced by a data block.
+func (g *generator) genLocalVariable(w io.Writer, v *pcl.LocalVariable) {
+	if _, ok := g.localDataSources[v]; ok {
+		return
+	}
+	g.Fgen(w, "locals {\n")
+	g.Indented(func() {
+		g.Fgenf(w, "%s%s = %v\n", g.Indent, v.Name(), v.Definition.Value)
+	})
+	g.Fgen(w, "}\n\n")
+}
+
+// genOutputVariable generates an output block for an output variable. Secret outputs are marked as sensitive.
+func (g *generator) genOutputVariable(w io.Writer, v *pcl.OutputVariable) {
+	value, sensitive := v.Value, false
+	if call, ok := value.(*model.FunctionCallExpression); ok && call.Name == "secret" {
+		value, sensitive = call.Args[0], true
+	}
+
+	g.Fgenf(w, "output %q {\n", v.LogicalName())
+	g.Indented(func() {
+		g.Fgenf(w, "%svalue = %v\n", g.Indent, value)
+		if sensitive {
+			g.Fgenf(w, "%ssensitive = true\n", g.Indent)
+		}
+	})
+	g.Fgen(w, "}\n\n")
+}
+
+// genComponent generates a module block for a component. The component itself is generated into the module's
+// directory.
+func (g *generator) genComponent(w io.Writer, c *pcl.Component) {
+	g.Fgenf(w, "module %q {\n", c.Name())
+	g.Indented(func() {
+		g.Fgenf(w, "%ssource = %q\n", g.Indent, "./"+filepath.Base(c.DirPath()))
+		if c.Options != nil && c.Options.Range != nil {
+			g.genRange(w, c.Options.Range)
+		}
+
+		for _, input := range c.Inputs {
+			g.Fgenf(w, "%s%s = %v\n", g.Indent, input.Name, input.Value)
+		}
+
+		if c.Options != nil {
+			g.genDependsOn(w, c.Options)
+		}
+	})
+	g.Fgen(w, "}\n\n")
+	g.rangeExpr, g.rangeKind = nil, rangeNone
+}
+
+// genResource generates a resource block, or a provider block for a provider resource.
+func (g *generator) genResource(w io.Writer, r *pcl.Resource) error {
+	pkg, mod, _, _ := r.DecomposeToken()
+	if pkg == "pulumi" && mod == "providers" {
+		return g.genProvider(w, r)
+	}
+
+	mapping, err := g.packageMapping(pkg)
+	if err != nil {
+		return err
+	}
+	if mapping == nil {
+		g.errorf(r.SyntaxNode().Range(),
+			"resource %s can not be converted to Terraform: package %s is not a bridged Terraform provider", r.Name(), pkg)
+		return nil
+	}
+
+	token := r.Token
+	if r.Schema != nil {
+		token = r.Schema.Token
+	}
+	tfType, objectMapping, ok := mapping.resource(token)
+	if !ok {
+		g.errorf(r.SyntaxNode().Range(), "resource %s can not be converted to Terraform: no Terraform resource maps to %s",
+			r.Name(), token)
+		return nil
+	}
+
+	g.Fgenf(w, "resource %q %q {\n", tfType, r.Name())
+	g.Indented(func() {
+		if r.Options != nil {
+			if r.Options.Range != nil {
+				g.genRange(w, r.Options.Range)
+			}
+			if r.Options.Provider != nil {
+				g.Fgenf(w, "%sprovider = %v\n", g.Indent, r.Options.Provider)
+			}
+		}
+
+		properties := make([]property, len(r.Inputs))
+		for i, input := range r.Inputs {
+			properties[i] = property{name: input.Name, value: input.Value}
+		}
+		g.genBody(w, properties, objectMapping, r.InputType)
+
+		if r.Options != nil {
+			g.genDependsOn(w, r.Options)
+			g.genLifecycle(w, r.Options, objectMapping)
+			g.warnUnsupportedOptions(r)
+		}
+	})
+	g.Fgen(w, "}\n\n")
+	g.rangeExpr, g.rangeKind = nil, rangeNone
+
+	if r.Options != nil && r.Options.ImportID != nil {
+		g.Fgen(w, "import {\n")
+		g.Indented(func() {
+			g.Fgenf(w, "%sto = %s.%s\n", g.Indent, tfType, r.Name())
+			g.Fgenf(w, "%sid = %v\n", g.Indent, r.Options.ImportID)
+		})
+		g.Fgen(w, "}\n\n")
+	}
+	return nil
+}
+
+// genProvider generates a provider block for an explicit provider resource. The provider is aliased by the resource's
+// name so that resources can refer to it.
+func (g *generator) genProvider(w io.Writer, r *pcl.Resource) error {
+	_, _, pkg, _ := r.DecomposeToken()
+	mapping, err := g.packageMapping(pkg)
+	if err != nil {
+		return err
+	}
+	if mapping == nil {
+		g.errorf(r.SyntaxNode().Range(),
+			"provider %s can not be converted to Terraform: package %s is not a bridged Terraform provider", r.Name(), pkg)
+		return nil
+	}
+
+	g.Fgenf(w, "provider %q {\n", mapping.Name)
+	g.Indented(func() {
+		g.Fgenf(w, "%salias = %q\n", g.Indent, r.Name())
+		properties := make([]property, len(r.Inputs))
+		for i, input := range r.Inputs {
+			properties[i] = property{name: input.Name, value: input.Value}
+		}
+		g.genBody(w, properties, mapping.config(), r.InputType)
+	})
+	g.Fgen(w, "}\n\n")
+
+	if r.Options != nil {
+		g.warnUnsupportedOptions(r)
+	}
+	return nil
+}
+
+// genRange generates the count or for_each meta-argument for a range option, and records how references to `range`
+// should be generated.
+func (g *generator) genRange(w io.Writer, rng model.Expression) {
+	g.rangeExpr = rng
+	switch t := pcl.UnwrapOption(model.ResolveOutputs(rng.Type())); t.(type) {
+	case *model.ListType, *model.TupleType, *model.SetType:
+		g.rangeKind = rangeList
+		g.Fgenf(w, "%scount = length(%v)\n", g.Indent, rng)
+	case *model.MapType, *model.ObjectType:
+		g.rangeKind = rangeEach
+		g.Fgenf(w, "%sfor_each = %v\n", g.Indent, rng)
+	default:
+		g.rangeKind = rangeCount
+		if t == model.BoolType {
+			g.Fgenf(w, "%scount = %.2v ? 1 : 0\n", g.Indent, rng)
+		} else {
+			g.Fgenf(w, "%scount = %v\n", g.Indent, rng)
+		}
+	}
+}
+
+func (g *generator) genDependsOn(w io.Writer, options *pcl.ResourceOptions) {
+	if options.DependsOn != nil {
+		g.Fgenf(w, "%sdepends_on = %v\n", g.Indent, options.DependsOn)
+	}
+}
+
+// genLifecycle generates a lifecycle block for the protect and ignoreChanges options.
+func (g *generator) genLifecycle(w io.Writer, options *pcl.ResourceOptions, objectMapping *objectMapping) {
+	if options.Protect == nil && options.IgnoreChanges == nil {
+		return
+	}
+
+	g.Fgenf(w, "\n%slifecycle {\n", g.Indent)
+	g.Indented(func() {
+		if options.Protect != nil {
+			g.Fgenf(w, "%sprevent_destroy = %v\n", g.Indent, options.Protect)
+		}
+		if options.IgnoreChanges != nil {
+			g.Fgenf(w, "%signore_changes = ", g.Indent)
+			g.genIgnoreChanges(w, options.IgnoreChanges, objectMapping)
+			g.Fgen(w, "\n")
+		}
+	})
+	g.Fgenf(w, "%s}\n", g.Indent)
+}
+
+// genIgnoreChanges generates the list of attribute paths to ignore, mapping each path to its Terraform names.
+func (g *generator) genIgnoreChanges(w io.Writer, ignoreChanges model.Expression, objectMapping *objectMapping) {
+	paths, ok := ignoreChanges.(*model.TupleConsExpression)
+	if !ok {
+		g.Fgenf(w, "%v", ignoreChanges)
+		return
+	}
+
+	g.Fgen(w, "[")
+	for i, p := range paths.Expressions {
+		if i > 0 {
+			g.Fgen(w, ", ")
+		}
+		switch p := p.(type) {
+		case *model.ScopeTraversalExpression:
+			g.genTraversal(w, p.Traversal, objectMapping)
+		default:
+			if name := pcl.LiteralValueString(p); name != "" {
+				g.Fgen(w, objectMapping.property(name).name)
+			} else {
+				g.Fgenf(w, "%v", p)
+			}
+		}
+	}
+	g.Fgen(w, "]")
+}
+
+// warnUnsupportedOptions warns about any resource options that have no Terraform equivalent.
+func (g *generator) warnUnsupportedOptions(r *pcl.Resource) {
+	options := r.Options
+	for name, option := range map[string]model.Expression{
+		"parent":            options.Parent,
+		"providers":         options.Providers,
+		"retainOnDelete":    options.RetainOnDelete,
+		"deletedWith":       options.DeletedWith,
+		"version":           options.Version,
+		"pluginDownloadURL": options.PluginDownloadURL,
+	} {
+		if option != nil {
+			g.warnf(option.SyntaxNode().Range(),
+				"the %s option of resource %s has no Terraform equivalent and was dropped", name, r.Name())
+		}
+	}
+}
+
+// genBody generates the attributes and blocks for the given properties of a resource, data source or block.
+func (g *generator) genBody(w io.Writer, properties []property, objectMapping *objectMapping, typ model.Type) {
+	for _, p := range properties {
+		propertyMapping := objectMapping.property(p.name)
+		propertyType := propertyType(typ, p.name)
+		if isBlock(propertyMapping, propertyType) {
+			if g.genBlocks(w, propertyMapping, p.value, propertyType) {
+				continue
+			}
+			g.warnf(p.value.SyntaxNode().Range(),
+				"%s is a Terraform block but its value is not a literal object; it was generated as an attribute", p.name)
+		}
+		g.Fgenf(w, "%s%s = %v\n", g.Indent, propertyMapping.name, p.value)
+	}
+}
+
+// genBlocks generates one block for each of the objects in the given value. It returns false if the value is not an
+// object literal or a list of object literals.
+func (g *generator) genBlocks(w io.Writer, p *propertyMapping, value model.Expression, typ model.Type) bool {
+	var objects []*model.ObjectConsExpression
+	elementType := typ
+	switch value := value.(type) {
+	case *model.ObjectConsExpression:
+		objects = []*model.ObjectConsExpression{value}
+	case *model.TupleConsExpression:
+		for _, element := range value.Expressions {
+			object, ok := element.(*model.ObjectConsExpression)
+			if !ok {
+				return false
+			}
+			objects = append(objects, object)
+		}
+		if list, ok := unwrapType(typ).(*model.ListType); ok {
+			elementType = list.ElementType
+		}
+	default:
+		return false
+	}
+
+	var properties [][]property
+	for _, object := range objects {
+		items := make([]property, len(object.Items))
+		for i, item := range object.Items {
+			name := pcl.LiteralValueString(item.Key)
+			if name == "" {
+				return false
+			}
+			items[i] = property{name: name, value: item.Value}
+		}
+		properties = append(properties, items)
+	}
+
+	for _, items := range properties {
+		g.Fgenf(w, "\n%s%s {\n", g.Indent, p.name)
+		g.Indented(func() {
+			g.genBody(w, items, p.object(), elementType)
+		})
+		g.Fgenf(w, "%s}\n", g.Indent)
+	}
+	return true
+}
+
+// isBlock returns true if the given property should be generated as a block. If the Terraform schema does not say,
+// properties with object values are assumed to be blocks.
+func isBlock(p *propertyMapping, typ model.Type) bool {
+	if block, known := p.block(); known {
+		return block
+	}
+	switch typ := unwrapType(typ).(type) {
+	case *model.ObjectType:
+		return true
+	case *model.ListType:
+		_, isObject := unwrapType(typ.ElementType).(*model.ObjectType)
+		return isObject
+	default:
+		return false
+	}
+}
+
+// propertyType returns the type of the named property of the given object type, or the dynamic type if it is not
+// known.
+func propertyType(typ model.Type, name string) model.Type {
+	if object, ok := unwrapType(typ).(*model.ObjectType); ok {
+		if t, ok := object.Properties[name]; ok {
+			return t
+		}
+	}
+	return model.DynamicType
+}
+
+// unwrapType strips outputs, promises and optionality from a type, and picks the most structured member of a union.
+func unwrapType(typ model.Type) model.Type {
+	typ = pcl.UnwrapOption(model.ResolveOutputs(typ))
+	union, ok := typ.(*model.UnionType)
+	if !ok {
+		return typ
+	}
+	for _, t := range union.ElementTypes {
+		switch t := unwrapType(t).(type) {
+		case *model.ObjectType, *model.ListType, *model.MapType:
+			return t
+		}
+	}
+	return typ
+}
+
+// genDataSources generates data blocks for the invokes in a node, so that they can be referenced by the node.
+func (g *generator) genDataSources(w io.Writer, n pcl.Node) error {
+	var err error
+	diags := n.VisitExpressions(nil, func(expr model.Expression) (model.Expression, hcl.Diagnostics) {
+		call, ok := expr.(*model.FunctionCallExpression)
+		if !ok || call.Name != pcl.Invoke || err != nil {
+			return expr, nil
+		}
+		if _, ok := g.dataSources[call]; ok {
+			return expr, nil
+		}
+
+		name := ""
+		if v, ok := n.(*pcl.LocalVariable); ok && v.Definition.Value == call {
+			name = v.Name()
+		}
+		var ds *dataSource
+		ds, err = g.genDataSource(w, call, n.Name(), name)
+		if ds != nil {
+			g.dataSources[call] = ds
+			if name != "" {
+				g.localDataSources[n.(*pcl.LocalVariable)] = ds
+			}
+		}
+		return expr, nil
+	})
+	g.diagnostics = append(g.diagnostics, diags...)
+	return err
+}
+
+// genDataSource generates a data block for an invoke. If no name is given, one is derived from the name of the node
+// that contains the invoke.
+func (g *generator) genDataSource(
+	w io.Writer, call *model.FunctionCallExpression, nodeName, name string,
+) (*dataSource, error) {
+	token := pcl.LiteralValueString(call.Args[0])
+	pkg, _, member, diags := pcl.DecomposeToken(token, call.Args[0].SyntaxNode().Range())
+	if diags.HasErrors() {
+		g.diagnostics = append(g.diagnostics, diags...)
+		return nil, nil
+	}
+
+	mapping, err := g.packageMapping(pkg)
+	if err != nil {
+		return nil, err
+	}
+	if mapping == nil {
+		g.errorf(call.SyntaxNode().Range(),
+			"invoke of %s can not be converted to Terraform: package %s is not a bridged Terraform provider", token, pkg)
+		return nil, nil
+	}
+	tfType, objectMapping, ok := mapping.dataSource(token)
+	if !ok {
+		g.errorf(call.SyntaxNode().Range(),
+			"invoke of %s can not be converted to Terraform: no Terraform data source maps to it", token)
+		return nil, nil
+	}
+
+	if name == "" {
+		name = nodeName + "_" + terraformName(strings.TrimPrefix(member, "get"))
+	}
+	base := name
+	for i := 2; g.dataSourceNames.Has(tfType + "." + name); i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	g.dataSourceNames.Add(tfType + "." + name)
+
+	g.Fgenf(w, "data %q %q {\n", tfType, name)
+	g.Indented(func() {
+		if len(call.Args) > 2 {
+			g.genInvokeOptions(w, call.Args[2])
+		}
+		if len(call.Args) > 1 {
+			args, ok := call.Args[1].(*model.ObjectConsExpression)
+			if !ok {
+				g.errorf(call.Args[1].SyntaxNode().Range(), "the arguments of invoke %s must be an object literal", token)
+				return
+			}
+			properties := make([]property, len(args.Items))
+			for i, item := range args.Items {
+				properties[i] = property{name: pcl.LiteralValueString(item.Key), value: item.Value}
+			}
+			g.genBody(w, properties, objectMapping, call.Signature.Parameters[1].Type)
+		}
+	})
+	g.Fgen(w, "}\n\n")
+
+	return &dataSource{tfType: tfType, name: name, mapping: objectMapping}, nil
+}
+
+// genInvokeOptions generates the meta-arguments for the options of an invoke.
+func (g *generator) genInvokeOptions(w io.Writer, options model.Expression) {
+	object, ok := options.(*model.ObjectConsExpression)
+	if !ok {
+		return
+	}
+	for _, item := range object.Items {
+		switch name := pcl.LiteralValueString(item.Key); name {
+		case "provider":
+			g.Fgenf(w, "%sprovider = %v\n", g.Indent, item.Value)
+		case "dependsOn":
+			g.Fgenf(w, "%sdepends_on = %v\n", g.Indent, item.Value)
+		default:
+			g.warnf(item.Value.SyntaxNode().Range(), "the %s invoke option has no Terraform equivalent and was dropped", name)
+		}
+	}
+}
+
+// quote returns the given string as a quoted HCL string literal.
+func quote(s string) string {
+	return string(hclwrite.TokensForValue(cty.StringVal(s)).Bytes())
+}
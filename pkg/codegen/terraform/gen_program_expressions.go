@@ -0,0 +1,445 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"io"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/pcl"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// functionNames maps the names of PCL functions to the names of equivalent Terraform functions that take the same
+// arguments.
+var functionNames = map[string]string{
+	"can":              "can",
+	"element":          "element",
+	"filebase64":       "filebase64",
+	"filebase64sha256": "filebase64sha256",
+	"fromBase64":       "base64decode",
+	"join":             "join",
+	"length":           "length",
+	"lookup":           "lookup",
+	"range":            "range",
+	"readFile":         "file",
+	"secret":           "sensitive",
+	"sha1":             "sha1",
+	"singleOrNone":     "one",
+	"split":            "split",
+	"toBase64":         "base64encode",
+	"toJSON":           "jsonencode",
+	"try":              "try",
+	"unsecret":         "nonsensitive",
+}
+
+// GetPrecedence returns the precedence for the indicated expression. Higher numbers bind more tightly than lower
+// numbers.
+func (g *generator) GetPrecedence(expr model.Expression) int {
+	// Precedence is derived from
+	// https://developer.hashicorp.com/terraform/language/expressions/operators#operator-precedence.
+	switch expr := expr.(type) {
+	case *model.ConditionalExpression:
+		return 1
+	case *model.BinaryOpExpression:
+		switch expr.Operation {
+		case hclsyntax.OpLogicalOr:
+			return 2
+		case hclsyntax.OpLogicalAnd:
+			return 3
+		case hclsyntax.OpEqual, hclsyntax.OpNotEqual:
+			return 4
+		case hclsyntax.OpGreaterThan, hclsyntax.OpGreaterThanOrEqual, hclsyntax.OpLessThan,
+			hclsyntax.OpLessThanOrEqual:
+			return 5
+		case hclsyntax.OpAdd, hclsyntax.OpSubtract:
+			return 6
+		case hclsyntax.OpMultiply, hclsyntax.OpDivide, hclsyntax.OpModulo:
+			return 7
+		default:
+			contract.Failf("unexpected binary expression %v", expr)
+		}
+	case *model.UnaryOpExpression:
+		return 8
+	case *model.AnonymousFunctionExpression, *model.ForExpression, *model.FunctionCallExpression,
+		*model.IndexExpression, *model.LiteralValueExpression, *model.ObjectConsExpression,
+		*model.RelativeTraversalExpression, *model.ScopeTraversalExpression, *model.SplatExpression,
+		*model.TemplateExpression, *model.TemplateJoinExpression, *model.TupleConsExpression:
+		return 9
+	default:
+		contract.Failf("unexpected expression %v of type %T", expr, expr)
+	}
+	return 0
+}
+
+func (g *generator) GenAnonymousFunctionExpression(w io.Writer, expr *model.AnonymousFunctionExpression) {
+	g.warnf(expr.SyntaxNode().Range(), "anonymous functions have no Terraform equivalent")
+	g.Fgen(w, "null")
+}
+
+func (g *generator) GenBinaryOpExpression(w io.Writer, expr *model.BinaryOpExpression) {
+	var opstr string
+	switch expr.Operation {
+	case hclsyntax.OpAdd:
+		opstr = "+"
+	case hclsyntax.OpDivide:
+		opstr = "/"
+	case hclsyntax.OpEqual:
+		opstr = "=="
+	case hclsyntax.OpGreaterThan:
+		opstr = ">"
+	case hclsyntax.OpGreaterThanOrEqual:
+		opstr = ">="
+	case hclsyntax.OpLessThan:
+		opstr = "<"
+	case hclsyntax.OpLessThanOrEqual:
+		opstr = "<="
+	case hclsyntax.OpLogicalAnd:
+		opstr = "&&"
+	case hclsyntax.OpLogicalOr:
+		opstr = "||"
+	case hclsyntax.OpModulo:
+		opstr = "%"
+	case hclsyntax.OpMultiply:
+		opstr = "*"
+	case hclsyntax.OpNotEqual:
+		opstr = "!="
+	case hclsyntax.OpSubtract:
+		opstr = "-"
+	}
+
+	precedence := g.GetPrecedence(expr)
+	g.Fgenf(w, "%.[1]*[2]v %[3]v %.[1]*[4]o", precedence, expr.LeftOperand, opstr, expr.RightOperand)
+}
+
+func (g *generator) GenConditionalExpression(w io.Writer, expr *model.ConditionalExpression) {
+	g.Fgenf(w, "%.2v ? %.2v : %.1v", expr.Condition, expr.TrueResult, expr.FalseResult)
+}
+
+func (g *generator) GenForExpression(w io.Writer, expr *model.ForExpression) {
+	openBracket, closeBracket := "[", "]"
+	if expr.Key != nil {
+		openBracket, closeBracket = "{", "}"
+	}
+
+	g.Fgen(w, openBracket, "for ")
+	if expr.KeyVariable != nil {
+		g.Fgenf(w, "%s, ", expr.KeyVariable.Name)
+	}
+	g.Fgenf(w, "%s in %v : ", expr.ValueVariable.Name, expr.Collection)
+	if expr.Key != nil {
+		g.Fgenf(w, "%v => ", expr.Key)
+	}
+	g.Fgenf(w, "%v", expr.Value)
+	if expr.Group {
+		g.Fgen(w, "...")
+	}
+	if expr.Condition != nil {
+		g.Fgenf(w, " if %v", expr.Condition)
+	}
+	g.Fgen(w, closeBracket)
+}
+
+func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionCallExpression) {
+	switch expr.Name {
+	case pcl.Invoke:
+		if ds, ok := g.dataSources[expr]; ok {
+			g.Fgen(w, ds.reference())
+		} else {
+			g.Fgen(w, "null")
+		}
+	case pcl.IntrinsicConvert:
+		g.Fgenf(w, "%v", expr.Args[0])
+	case "fileAsset", "fileArchive", "remoteAsset", "remoteArchive":
+		// Terraform providers take the paths of assets and archives as plain strings.
+		g.Fgenf(w, "%v", expr.Args[0])
+	case "readDir":
+		g.Fgenf(w, "fileset(%v, \"*\")", expr.Args[0])
+	case "entries":
+		g.Fgenf(w, "[for k, v in %v : { key = k, value = v }]", expr.Args[0])
+	case "cwd":
+		g.Fgen(w, "path.cwd")
+	case "rootDirectory":
+		g.Fgen(w, "path.root")
+	case "stack":
+		g.Fgen(w, "terraform.workspace")
+	default:
+		name, ok := functionNames[expr.Name]
+		if !ok {
+			g.warnf(expr.SyntaxNode().Range(), "the %s function has no Terraform equivalent", expr.Name)
+			g.Fgen(w, "null")
+			return
+		}
+
+		g.Fgenf(w, "%s(", name)
+		for i, arg := range expr.Args {
+			if i > 0 {
+				g.Fgen(w, ", ")
+			}
+			g.Fgenf(w, "%v", arg)
+		}
+		g.Fgen(w, ")")
+	}
+}
+
+func (g *generator) GenIndexExpression(w io.Writer, expr *model.IndexExpression) {
+	g.Fgenf(w, "%.9v[%v]", expr.Collection, expr.Key)
+}
+
+func (g *generator) GenLiteralValueExpression(w io.Writer, expr *model.LiteralValueExpression) {
+	g.Fgen(w, string(hclwrite.TokensForValue(expr.Value).Bytes()))
+}
+
+func (g *generator) GenObjectConsExpression(w io.Writer, expr *model.ObjectConsExpression) {
+	if len(expr.Items) == 0 {
+		g.Fgen(w, "{}")
+		return
+	}
+
+	g.Fgen(w, "{\n")
+	g.Indented(func() {
+		for _, item := range expr.Items {
+			g.Fgen(w, g.Indent)
+			if key := pcl.LiteralValueString(item.Key); key != "" {
+				if hclsyntax.ValidIdentifier(key) {
+					g.Fgen(w, key)
+				} else {
+					g.Fgen(w, quote(key))
+				}
+			} else {
+				g.Fgenf(w, "(%v)", item.Key)
+			}
+			g.Fgenf(w, " = %v\n", item.Value)
+		}
+	})
+	g.Fgenf(w, "%s}", g.Indent)
+}
+
+func (g *generator) GenRelativeTraversalExpression(w io.Writer, expr *model.RelativeTraversalExpression) {
+	if call, ok := expr.Source.(*model.FunctionCallExpression); ok && call.Name == pcl.Invoke {
+		if ds, ok := g.dataSources[call]; ok {
+			g.Fgen(w, ds.reference())
+			g.genTraversal(w, expr.Traversal, ds.mapping)
+			return
+		}
+	}
+
+	g.Fgenf(w, "%.9v", expr.Source)
+	g.genTraversal(w, expr.Traversal, nil)
+}
+
+func (g *generator) GenScopeTraversalExpression(w io.Writer, expr *model.ScopeTraversalExpression) {
+	rest := expr.Traversal[1:]
+	switch root := expr.Parts[0].(type) {
+	case *pcl.ConfigVariable:
+		g.Fgenf(w, "var.%s", root.LogicalName())
+		g.genTraversal(w, rest, nil)
+	case *pcl.LocalVariable:
+		if ds, ok := g.localDataSources[root]; ok {
+			g.Fgen(w, ds.reference())
+			g.genTraversal(w, rest, ds.mapping)
+			return
+		}
+		g.Fgenf(w, "local.%s", root.Name())
+		g.genTraversal(w, rest, nil)
+	case *pcl.Component:
+		g.Fgenf(w, "module.%s", root.Name())
+		g.genTraversal(w, rest, nil)
+	case *pcl.Resource:
+		g.genResourceReference(w, root, rest)
+	case *model.SplatVariable:
+		// Splat items are generated by GenSplatExpression; only their traversal remains.
+		g.genTraversal(w, rest, g.splatMapping)
+	case *model.Variable:
+		if root.Name == "range" && g.rangeKind != rangeNone {
+			g.genRangeReference(w, rest)
+			return
+		}
+		g.Fgen(w, expr.RootName)
+		g.genTraversal(w, rest, nil)
+	default:
+		g.Fgen(w, expr.RootName)
+		g.genTraversal(w, rest, nil)
+	}
+}
+
+// genResourceReference generates a reference to a resource or provider, mapping the traversed attributes to their
+// Terraform names.
+func (g *generator) genResourceReference(w io.Writer, r *pcl.Resource, traversal hcl.Traversal) {
+	pkg, mod, name, _ := r.DecomposeToken()
+	if pkg == "pulumi" && mod == "providers" {
+		if mapping, _ := g.packageMapping(name); mapping != nil {
+			name = mapping.Name
+		}
+		g.Fgenf(w, "%s.%s", name, r.Name())
+		return
+	}
+
+	var objectMapping *objectMapping
+	tfType := pkg + "_" + terraformName(name)
+	if mapping, _ := g.packageMapping(pkg); mapping != nil {
+		token := r.Token
+		if r.Schema != nil {
+			token = r.Schema.Token
+		}
+		if t, m, ok := mapping.resource(token); ok {
+			tfType, objectMapping = t, m
+		}
+	}
+	g.Fgenf(w, "%s.%s", tfType, r.Name())
+	g.genTraversal(w, traversal, objectMapping)
+}
+
+// genRangeReference generates a reference to the `range` variable of the resource or module being generated.
+func (g *generator) genRangeReference(w io.Writer, traversal hcl.Traversal) {
+	if len(traversal) == 0 {
+		g.Fgen(w, "each")
+		return
+	}
+	attr, ok := traversal[0].(hcl.TraverseAttr)
+	if !ok {
+		g.Fgen(w, "each")
+		g.genTraversal(w, traversal, nil)
+		return
+	}
+
+	switch {
+	case g.rangeKind == rangeEach:
+		g.Fgenf(w, "each.%s", attr.Name)
+	case attr.Name == "value" && g.rangeKind == rangeList:
+		g.Fgenf(w, "%.9v[count.index]", g.rangeExpr)
+	default:
+		g.Fgen(w, "count.index")
+	}
+	g.genTraversal(w, traversal[1:], nil)
+}
+
+// genTraversal generates the given traversal, mapping attribute names to their Terraform names using the given
+// object mapping. Attributes that Pulumi flattens from single-element lists are indexed accordingly.
+func (g *generator) genTraversal(w io.Writer, traversal hcl.Traversal, objectMapping *objectMapping) {
+	for _, traverser := range traversal {
+		switch traverser := traverser.(type) {
+		case hcl.TraverseRoot:
+			p := objectMapping.property(traverser.Name)
+			g.Fgen(w, p.name)
+			objectMapping = p.object()
+		case hcl.TraverseAttr:
+			if objectMapping == nil {
+				g.Fgenf(w, ".%s", traverser.Name)
+				continue
+			}
+			p := objectMapping.property(traverser.Name)
+			g.Fgenf(w, ".%s", p.name)
+			if p.maxItemsOne() {
+				g.Fgen(w, "[0]")
+			}
+			objectMapping = p.object()
+		case hcl.TraverseIndex:
+			g.Fgenf(w, "[%s]", hclwrite.TokensForValue(traverser.Key).Bytes())
+		case hcl.TraverseSplat:
+			g.Fgen(w, "[*]")
+		}
+	}
+}
+
+func (g *generator) GenSplatExpression(w io.Writer, expr *model.SplatExpression) {
+	each, ok := expr.Each.(*model.ScopeTraversalExpression)
+	if !ok {
+		g.warnf(expr.SyntaxNode().Range(), "only attribute splats can be converted to Terraform")
+		g.Fgen(w, "null")
+		return
+	}
+
+	g.Fgenf(w, "%.9v[*]", expr.Source)
+	splatMapping := g.splatMapping
+	g.splatMapping = g.elementMapping(expr.Source)
+	g.GenScopeTraversalExpression(w, each)
+	g.splatMapping = splatMapping
+}
+
+// elementMapping returns the object mapping for the elements of the given expression if it refers to a resource that
+// uses a range option.
+func (g *generator) elementMapping(expr model.Expression) *objectMapping {
+	traversal, ok := expr.(*model.ScopeTraversalExpression)
+	if !ok || len(traversal.Traversal) != 1 {
+		return nil
+	}
+	r, ok := traversal.Parts[0].(*pcl.Resource)
+	if !ok {
+		return nil
+	}
+	pkg, _, _, _ := r.DecomposeToken()
+	mapping, _ := g.packageMapping(pkg)
+	if mapping == nil {
+		return nil
+	}
+	token := r.Token
+	if r.Schema != nil {
+		token = r.Schema.Token
+	}
+	_, objectMapping, _ := mapping.resource(token)
+	return objectMapping
+}
+
+func (g *generator) GenTemplateExpression(w io.Writer, expr *model.TemplateExpression) {
+	if len(expr.Parts) == 1 {
+		if lit, ok := expr.Parts[0].(*model.LiteralValueExpression); ok && model.StringType.AssignableFrom(lit.Type()) {
+			g.GenLiteralValueExpression(w, lit)
+			return
+		}
+	}
+
+	g.Fgen(w, `"`)
+	for _, part := range expr.Parts {
+		if lit, ok := part.(*model.LiteralValueExpression); ok && model.StringType.AssignableFrom(lit.Type()) {
+			// Reuse the quoting of a whole string, which escapes interpolation sequences, without its quotes.
+			quoted := quote(lit.Value.AsString())
+			g.Fgen(w, quoted[1:len(quoted)-1])
+		} else {
+			g.Fgenf(w, "${%v}", part)
+		}
+	}
+	g.Fgen(w, `"`)
+}
+
+func (g *generator) GenTemplateJoinExpression(w io.Writer, expr *model.TemplateJoinExpression) {
+	g.Fgenf(w, "join(\"\", %v)", expr.Tuple)
+}
+
+func (g *generator) GenTupleConsExpression(w io.Writer, expr *model.TupleConsExpression) {
+	g.Fgen(w, "[")
+	for i, element := range expr.Expressions {
+		if i > 0 {
+			g.Fgen(w, ", ")
+		}
+		g.Fgenf(w, "%v", element)
+	}
+	g.Fgen(w, "]")
+}
+
+func (g *generator) GenUnaryOpExpression(w io.Writer, expr *model.UnaryOpExpression) {
+	opstr, precedence := "", g.GetPrecedence(expr)
+	switch expr.Operation {
+	case hclsyntax.OpLogicalNot:
+		opstr = "!"
+	case hclsyntax.OpNegate:
+		opstr = "-"
+	}
+	g.Fgenf(w, "%[2]v%.[1]*[3]v", precedence, opstr, expr.Operand)
+}
@@ -0,0 +1,255 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/convert"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/hcl2/syntax"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/pcl"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/testing/utils"
+)
+
+var testdataPath = filepath.Join("..", "testing", "test", "testdata")
+
+// testMapper serves fixed mappings keyed by Terraform provider name.
+type testMapper map[string]string
+
+func (m testMapper) GetMapping(_ context.Context, provider string, _ *convert.MapperPackageHint) ([]byte, error) {
+	return []byte(m[provider]), nil
+}
+
+var mappings = testMapper{
+	"tls": `{
+		"name": "tls",
+		"provider": {
+			"resources": {
+				"tls_private_key": {
+					"algorithm": {"type": 4},
+					"ecdsa_curve": {"type": 4},
+					"private_key_pem": {"type": 4}
+				},
+				"tls_self_signed_cert": {
+					"allowed_uses": {"type": 5, "element": {"schema": {"type": 4}}},
+					"cert_pem": {"type": 4},
+					"private_key_pem": {"type": 4},
+					"subject": {"type": 5, "maxItems": 1, "element": {"resource": {
+						"common_name": {"type": 4},
+						"organization": {"type": 4}
+					}}},
+					"validity_period_hours": {"type": 2}
+				}
+			},
+			"dataSources": {
+				"tls_public_key": {
+					"private_key_pem": {"type": 4},
+					"public_key_openssh": {"type": 4}
+				}
+			}
+		},
+		"resources": {
+			"tls_private_key": {"tok": "tls:index/privateKey:PrivateKey"},
+			"tls_self_signed_cert": {"tok": "tls:index/selfSignedCert:SelfSignedCert"}
+		},
+		"dataSources": {
+			"tls_public_key": {"tok": "tls:index/getPublicKey:getPublicKey"}
+		}
+	}`,
+	"random": `{
+		"name": "random",
+		"provider": {
+			"resources": {
+				"random_pet": {
+					"keepers": {"type": 6, "element": {"schema": {"type": 4}}},
+					"prefix": {"type": 4}
+				}
+			}
+		},
+		"resources": {
+			"random_pet": {"tok": "random:index/randomPet:RandomPet"}
+		}
+	}`,
+}
+
+func parseAndBindProgram(t *testing.T, text string) *pcl.Program {
+	parser := syntax.NewParser()
+	err := parser.ParseFile(strings.NewReader(text), "main.pp")
+	require.NoError(t, err)
+	require.False(t, parser.Diagnostics.HasErrors(), "%v", parser.Diagnostics)
+
+	program, diags, err := pcl.BindProgram(parser.Files, pcl.PluginHost(utils.NewHost(testdataPath)))
+	require.NoError(t, err)
+	require.False(t, diags.HasErrors(), "%v", diags)
+	return program
+}
+
+func TestGenerateProgram(t *testing.T) {
+	t.Parallel()
+
+	program := parseAndBindProgram(t, `
+config "commonName" "string" {
+	default = "example.com"
+	description = "The common name of the certificate."
+}
+
+resource "key" "tls:index/privateKey:PrivateKey" {
+	algorithm = "ECDSA"
+	ecdsaCurve = "P384"
+}
+
+resource "cert" "tls:index/selfSignedCert:SelfSignedCert" {
+	privateKeyPem = key.privateKeyPem
+	validityPeriodHours = 12
+	allowedUses = ["key_encipherment", "digital_signature"]
+	subject = {
+		commonName = commonName
+		organization = "ACME Examples, Inc"
+	}
+
+	options {
+		protect = true
+		ignoreChanges = [subject]
+	}
+}
+
+publicKey = invoke("tls:index/getPublicKey:getPublicKey", {
+	privateKeyPem = key.privateKeyPem
+})
+
+output "publicKey" {
+	value = publicKey.publicKeyOpenssh
+}
+
+output "certPem" {
+	value = secret(cert.certPem)
+}
+
+output "commonName" {
+	value = "CN=${cert.subject.commonName}"
+}
+`)
+
+	files, diags, err := GenerateProgram(context.Background(), program, mappings)
+	require.NoError(t, err)
+	assert.Empty(t, diags)
+
+	assert.Equal(t, `variable "commonName" {
+  type        = string
+  description = "The common name of the certificate."
+  default     = "example.com"
+}
+`, string(files["variables.tf"]))
+
+	assert.Equal(t, `resource "tls_private_key" "key" {
+  algorithm   = "ECDSA"
+  ecdsa_curve = "P384"
+}
+
+resource "tls_self_signed_cert" "cert" {
+  private_key_pem       = tls_private_key.key.private_key_pem
+  validity_period_hours = 12
+  allowed_uses          = ["key_encipherment", "digital_signature"]
+
+  subject {
+    common_name  = var.commonName
+    organization = "ACME Examples, Inc"
+  }
+
+  lifecycle {
+    prevent_destroy = true
+    ignore_changes  = [subject]
+  }
+}
+
+data "tls_public_key" "publicKey" {
+  private_key_pem = tls_private_key.key.private_key_pem
+}
+`, string(files["main.tf"]))
+
+	assert.Equal(t, `output "publicKey" {
+  value = data.tls_public_key.publicKey.public_key_openssh
+}
+
+output "certPem" {
+  value     = tls_self_signed_cert.cert.cert_pem
+  sensitive = true
+}
+
+output "commonName" {
+  value = "CN=${tls_self_signed_cert.cert.subject[0].common_name}"
+}
+`, string(files["outputs.tf"]))
+}
+
+func TestGenerateProgramRange(t *testing.T) {
+	t.Parallel()
+
+	program := parseAndBindProgram(t, `
+config "names" "list(string)" {
+}
+
+resource "pets" "random:index/randomPet:RandomPet" {
+	options {
+		range = names
+	}
+	prefix = range.value
+	keepers = {
+		"index" = "${range.key}"
+	}
+}
+
+output "petIds" {
+	value = pets[*].id
+}
+`)
+
+	files, diags, err := GenerateProgram(context.Background(), program, mappings)
+	require.NoError(t, err)
+	assert.Empty(t, diags)
+
+	assert.Equal(t, `resource "random_pet" "pets" {
+  count  = length(var.names)
+  prefix = var.names[count.index]
+  keepers = {
+    index = count.index
+  }
+}
+`, string(files["main.tf"]))
+	assert.Equal(t, `output "petIds" {
+  value = random_pet.pets[*].id
+}
+`, string(files["outputs.tf"]))
+}
+
+func TestGenerateProgramUnbridgedPackage(t *testing.T) {
+	t.Parallel()
+
+	program := parseAndBindProgram(t, `
+resource "ns" "kubernetes:core/v1:Namespace" {
+}
+`)
+
+	_, diags, err := GenerateProgram(context.Background(), program, mappings)
+	require.NoError(t, err)
+	require.True(t, diags.HasErrors())
+	assert.Contains(t, diags.Error(), "package kubernetes is not a bridged Terraform provider")
+}
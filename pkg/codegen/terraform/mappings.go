@@ -0,0 +1,322 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/blang/semver"
+	"github.com/pulumi/inflector"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/convert"
+)
+
+// The Terraform value types, as numbered by the bridge's schema shim.
+const (
+	tfTypeList = 5
+	tfTypeMap  = 6
+	tfTypeSet  = 7
+)
+
+// terraformProviderNames maps the names of bridged Pulumi packages to the names of the Terraform providers they wrap,
+// for the packages whose names differ. Any package not listed here is assumed to share its name with its provider.
+var terraformProviderNames = map[string]string{
+	"azure": "azurerm",
+	"gcp":   "google",
+}
+
+// providerMapping is the subset of a bridged provider's Terraform mapping (the marshalled provider info returned by
+// GetMapping) that is needed to map Pulumi tokens and property names back to their Terraform equivalents.
+type providerMapping struct {
+	// The name of the Terraform provider.
+	Name string `json:"name"`
+	// The Terraform schema of the provider.
+	Provider *tfProviderSchema `json:"provider"`
+	// Overrides for the names of the provider's configuration values, keyed by Terraform name.
+	Config map[string]*fieldInfo `json:"config"`
+	// The provider's resources, keyed by Terraform resource type.
+	Resources map[string]*resourceInfo `json:"resources"`
+	// The provider's data sources, keyed by Terraform data source type.
+	DataSources map[string]*resourceInfo `json:"dataSources"`
+
+	// Indices from Pulumi tokens to Terraform type names, built by index.
+	resourceTokens   map[string]string
+	dataSourceTokens map[string]string
+}
+
+type tfProviderSchema struct {
+	Schema      map[string]*tfSchema            `json:"schema"`
+	Resources   map[string]map[string]*tfSchema `json:"resources"`
+	DataSources map[string]map[string]*tfSchema `json:"dataSources"`
+}
+
+type tfSchema struct {
+	Type     int        `json:"type"`
+	MaxItems int        `json:"maxItems"`
+	Elem     *tfElement `json:"element"`
+}
+
+type tfElement struct {
+	Schema   *tfSchema            `json:"schema"`
+	Resource map[string]*tfSchema `json:"resource"`
+}
+
+type resourceInfo struct {
+	Tok    string                `json:"tok"`
+	Fields map[string]*fieldInfo `json:"fields"`
+}
+
+type fieldInfo struct {
+	Name        string                `json:"name"`
+	MaxItemsOne *bool                 `json:"maxItemsOne"`
+	Elem        *fieldInfo            `json:"element"`
+	Fields      map[string]*fieldInfo `json:"fields"`
+}
+
+// loadProviderMapping fetches and decodes the Terraform mapping for the given Pulumi package. If version is not nil,
+// the mapping is preferably read from that version of the package. It returns nil if the package does not offer a
+// mapping, i.e. if it is not a bridged Terraform provider.
+func loadProviderMapping(
+	ctx context.Context, mapper convert.Mapper, pkg string, version *semver.Version,
+) (*providerMapping, error) {
+	provider := pkg
+	if name, ok := terraformProviderNames[pkg]; ok {
+		provider = name
+	}
+
+	data, err := mapper.GetMapping(ctx, provider, &convert.MapperPackageHint{PluginName: pkg, Version: version})
+	if err != nil {
+		return nil, fmt.Errorf("could not get Terraform mapping for package %s: %w", pkg, err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var mapping providerMapping
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("could not decode Terraform mapping for package %s: %w", pkg, err)
+	}
+	if mapping.Name == "" {
+		mapping.Name = provider
+	}
+	if mapping.Provider == nil {
+		mapping.Provider = &tfProviderSchema{}
+	}
+	mapping.index()
+	return &mapping, nil
+}
+
+func (m *providerMapping) index() {
+	m.resourceTokens = indexTokens(m.Resources)
+	m.dataSourceTokens = indexTokens(m.DataSources)
+}
+
+// indexTokens maps the normalized form of each Pulumi token to the Terraform type name that it was mapped from.
+func indexTokens(infos map[string]*resourceInfo) map[string]string {
+	tokens := make(map[string]string, len(infos))
+	for tfName, info := range infos {
+		tokens[normalizeToken(info.Tok)] = tfName
+	}
+	return tokens
+}
+
+// normalizeToken removes the trailing name segment and any "index" module from a token, so that both
+// `pkg:index/bucket:Bucket` and `pkg::Bucket` become `pkg::Bucket`.
+func normalizeToken(tok string) string {
+	components := strings.Split(tok, ":")
+	if len(components) != 3 {
+		return tok
+	}
+	module := components[1]
+	if i := strings.Index(module, "/"); i != -1 {
+		module = module[:i]
+	}
+	if module == "index" {
+		module = ""
+	}
+	return components[0] + ":" + module + ":" + components[2]
+}
+
+// resource returns the Terraform type name and object mapping for the resource with the given Pulumi token.
+func (m *providerMapping) resource(tok string) (string, *objectMapping, bool) {
+	tfName, ok := lookupToken(m.resourceTokens, tok)
+	if !ok {
+		return "", nil, false
+	}
+	return tfName, newObjectMapping(m.Provider.Resources[tfName], m.Resources[tfName].Fields), true
+}
+
+// dataSource returns the Terraform type name and object mapping for the data source with the given Pulumi token.
+func (m *providerMapping) dataSource(tok string) (string, *objectMapping, bool) {
+	tfName, ok := lookupToken(m.dataSourceTokens, tok)
+	if !ok {
+		return "", nil, false
+	}
+	return tfName, newObjectMapping(m.Provider.DataSources[tfName], m.DataSources[tfName].Fields), true
+}
+
+// config returns the object mapping for the provider's configuration.
+func (m *providerMapping) config() *objectMapping {
+	return newObjectMapping(m.Provider.Schema, m.Config)
+}
+
+func lookupToken(tokens map[string]string, tok string) (string, bool) {
+	tfName, ok := tokens[normalizeToken(tok)]
+	return tfName, ok
+}
+
+// objectMapping maps the properties of a Pulumi object to the attributes and blocks of a Terraform object. A nil
+// mapping leaves property names untouched.
+type objectMapping struct {
+	schema map[string]*tfSchema
+	fields map[string]*fieldInfo
+
+	// The Terraform names of the object's properties, keyed by their Pulumi names.
+	names map[string]string
+}
+
+func newObjectMapping(schema map[string]*tfSchema, fields map[string]*fieldInfo) *objectMapping {
+	m := &objectMapping{schema: schema, fields: fields, names: map[string]string{}}
+	for tfName, s := range schema {
+		m.addName(tfName, s, fields[tfName])
+	}
+	// Fields may carry overrides for names that are missing from an incomplete schema.
+	for tfName, info := range fields {
+		if _, ok := schema[tfName]; !ok {
+			m.addName(tfName, nil, info)
+		}
+	}
+	return m
+}
+
+func (m *objectMapping) addName(tfName string, s *tfSchema, info *fieldInfo) {
+	if info != nil && info.Name != "" {
+		m.names[info.Name] = tfName
+		return
+	}
+	name := pulumiName(tfName)
+	m.names[name] = tfName
+	// Lists and sets are usually pluralized by the bridge, unless they are flattened into a single value.
+	p := propertyMapping{name: tfName, schema: s, info: info}
+	if s != nil && (s.Type == tfTypeList || s.Type == tfTypeSet) && !p.maxItemsOne() {
+		if plural := inflector.Pluralize(name); plural != name {
+			if _, ok := m.names[plural]; !ok {
+				m.names[plural] = tfName
+			}
+		}
+	}
+}
+
+// property returns the mapping for the Pulumi property with the given name.
+func (m *objectMapping) property(name string) *propertyMapping {
+	if m == nil {
+		return &propertyMapping{name: name, verbatim: true}
+	}
+	tfName, ok := m.names[name]
+	if !ok {
+		tfName = terraformName(name)
+	}
+	return &propertyMapping{name: tfName, schema: m.schema[tfName], info: m.fields[tfName]}
+}
+
+// propertyMapping describes the Terraform attribute or block that a Pulumi property maps to.
+type propertyMapping struct {
+	// The Terraform name of the property.
+	name string
+	// The Terraform schema of the property, if known.
+	schema *tfSchema
+	// The bridge's overrides for the property, if any.
+	info *fieldInfo
+	// True if the property belongs to an unmapped object, such as a map or a module's outputs.
+	verbatim bool
+}
+
+// maxItemsOne returns true if the property is a Terraform list or set that Pulumi flattens into a single value.
+func (p *propertyMapping) maxItemsOne() bool {
+	if p.info != nil && p.info.MaxItemsOne != nil {
+		return *p.info.MaxItemsOne
+	}
+	return p.schema != nil && (p.schema.Type == tfTypeList || p.schema.Type == tfTypeSet) && p.schema.MaxItems == 1
+}
+
+// block returns true if the property is a Terraform block. known is false if the schema does not say either way.
+func (p *propertyMapping) block() (block bool, known bool) {
+	if p.schema == nil {
+		return false, false
+	}
+	return p.schema.Elem != nil && p.schema.Elem.Resource != nil && p.schema.Type != tfTypeMap, true
+}
+
+// object returns the mapping for the properties of the property's value, or of its elements if it is a list of
+// objects. It returns nil if the property's value is a map or its keys should otherwise be left alone.
+func (p *propertyMapping) object() *objectMapping {
+	if p.verbatim {
+		return nil
+	}
+	var fields map[string]*fieldInfo
+	if p.info != nil {
+		fields = p.info.Fields
+		if p.info.Elem != nil && len(p.info.Elem.Fields) != 0 {
+			fields = p.info.Elem.Fields
+		}
+	}
+	switch {
+	case p.schema == nil:
+		return newObjectMapping(nil, fields)
+	case p.schema.Type == tfTypeMap:
+		return nil
+	case p.schema.Elem != nil && p.schema.Elem.Resource != nil:
+		return newObjectMapping(p.schema.Elem.Resource, fields)
+	default:
+		return nil
+	}
+}
+
+// pulumiName returns the default Pulumi name for a Terraform name, e.g. `private_key_pem` becomes `privateKeyPem`.
+func pulumiName(tfName string) string {
+	var sb strings.Builder
+	upper := false
+	for _, r := range tfName {
+		switch {
+		case r == '_':
+			upper = true
+		case upper:
+			sb.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// terraformName returns the default Terraform name for a Pulumi name, e.g. `privateKeyPem` becomes `private_key_pem`.
+func terraformName(name string) string {
+	var sb strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				sb.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
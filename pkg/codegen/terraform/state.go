@@ -0,0 +1,455 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/convert"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+// ErrSecretsInState is returned by ConvertState if the state contains secrets but StateOptions.ShowSecrets is not set.
+// Terraform state files hold every value in plaintext, so secrets must be revealed explicitly.
+var ErrSecretsInState = errors.New("the state contains secret values that would be written in plaintext")
+
+// The version of Terraform recorded in converted state files. Terraform accepts state written by older versions, so
+// this is the oldest release that reads version 4 state files without needing an upgrade.
+const stateTerraformVersion = "1.0.0"
+
+// State is a Terraform state file, in version 4 of Terraform's state format.
+type State struct {
+	Version          int                    `json:"version"`
+	TerraformVersion string                 `json:"terraform_version"`
+	Serial           int                    `json:"serial"`
+	Lineage          string                 `json:"lineage"`
+	Outputs          map[string]StateOutput `json:"outputs"`
+	Resources        []StateResource        `json:"resources"`
+	CheckResults     []interface{}          `json:"check_results"`
+}
+
+// StateOutput is a root module output in a Terraform state file.
+type StateOutput struct {
+	Value     interface{} `json:"value"`
+	Type      interface{} `json:"type"`
+	Sensitive bool        `json:"sensitive,omitempty"`
+}
+
+// StateResource is a resource in a Terraform state file.
+type StateResource struct {
+	Mode      string          `json:"mode"`
+	Type      string          `json:"type"`
+	Name      string          `json:"name"`
+	Provider  string          `json:"provider"`
+	Instances []StateInstance `json:"instances"`
+}
+
+// StateInstance is an instance of a resource in a Terraform state file.
+type StateInstance struct {
+	SchemaVersion       int                    `json:"schema_version"`
+	Attributes          map[string]interface{} `json:"attributes"`
+	SensitiveAttributes [][]StatePathStep      `json:"sensitive_attributes"`
+	Dependencies        []string               `json:"dependencies,omitempty"`
+}
+
+// StatePathStep is a step in the path to a sensitive attribute.
+type StatePathStep struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// StateOptions controls how Pulumi state is converted to Terraform state.
+type StateOptions struct {
+	// True if secret values may be written to the Terraform state in plaintext.
+	ShowSecrets bool
+}
+
+// ConvertState converts the given Pulumi resources into a Terraform state file. Custom resources from bridged
+// Terraform providers are converted to managed Terraform resources, using the mapper to look up their Terraform types
+// and attribute names. The outputs of the root stack become the state's outputs. Other resources have no Terraform
+// equivalent and are skipped with a warning.
+func ConvertState(
+	ctx context.Context, resources []*resource.State, mapper convert.Mapper, opts StateOptions,
+) (*State, hcl.Diagnostics, error) {
+	lineage, err := uuid.NewV4()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c := &stateConverter{
+		ctx:              ctx,
+		mapper:           mapper,
+		mappings:         map[string]*providerMapping{},
+		providerVersions: map[string]*semver.Version{},
+		addresses:        map[resource.URN]string{},
+		names:            codegen.NewStringSet(),
+	}
+	state := &State{
+		Version:          4,
+		TerraformVersion: stateTerraformVersion,
+		Serial:           1,
+		Lineage:          lineage.String(),
+		Outputs:          map[string]StateOutput{},
+		Resources:        []StateResource{},
+		CheckResults:     nil,
+	}
+
+	for _, r := range resources {
+		if r.Delete {
+			continue
+		}
+
+		switch {
+		case r.Type == resource.RootStackType:
+			for _, k := range r.Outputs.StableKeys() {
+				c.sensitive = nil
+				value := c.convertValue(r.Outputs[k], &propertyMapping{name: string(k), verbatim: true}, nil)
+				state.Outputs[string(k)] = StateOutput{
+					Value:     value,
+					Type:      valueType(value),
+					Sensitive: len(c.sensitive) != 0,
+				}
+			}
+		case providers.IsProviderType(r.Type):
+			// Providers are referred to by the resources that use them. Their versions are recorded so that mappings
+			// can be read from the version of the provider that manages the resources.
+			c.recordProviderVersion(r)
+		case !r.Custom:
+			// Components have no Terraform equivalent.
+			continue
+		case r.External:
+			c.warnf("resource %s was skipped: it is read from an external source rather than managed", r.URN)
+		default:
+			sr, err := c.convertResource(r)
+			if err != nil {
+				return nil, c.diagnostics, err
+			}
+			if sr != nil {
+				state.Resources = append(state.Resources, *sr)
+			}
+		}
+	}
+
+	if c.secrets && !opts.ShowSecrets {
+		return nil, c.diagnostics, ErrSecretsInState
+	}
+	return state, c.diagnostics, nil
+}
+
+type stateConverter struct {
+	ctx         context.Context
+	mapper      convert.Mapper
+	diagnostics hcl.Diagnostics
+
+	// The Terraform mappings of the packages in the state, keyed by package name.
+	mappings map[string]*providerMapping
+	// The versions of the providers in the state, keyed by provider reference.
+	providerVersions map[string]*semver.Version
+	// The Terraform addresses of the resources converted so far, keyed by URN.
+	addresses map[resource.URN]string
+	// The Terraform addresses that have been used so far.
+	names codegen.StringSet
+
+	// True if any secret values have been converted.
+	secrets bool
+	// The paths to the sensitive attributes of the value currently being converted.
+	sensitive [][]StatePathStep
+}
+
+func (c *stateConverter) warnf(format string, args ...interface{}) {
+	c.diagnostics = append(c.diagnostics, &hcl.Diagnostic{
+		Severity: hcl.DiagWarning,
+		Summary:  fmt.Sprintf(format, args...),
+	})
+}
+
+// recordProviderVersion records the version of a provider resource, if it has one.
+func (c *stateConverter) recordProviderVersion(r *resource.State) {
+	ref, err := providers.NewReference(r.URN, r.ID)
+	if err != nil {
+		return
+	}
+	version, err := providers.GetProviderVersion(r.Inputs)
+	if err != nil || version == nil {
+		return
+	}
+	c.providerVersions[ref.String()] = version
+}
+
+// packageMapping returns the Terraform mapping for the given package. Mappings are loaded once per package, from the
+// version of the provider used by the first resource of that package if it is known.
+func (c *stateConverter) packageMapping(pkg string, version *semver.Version) (*providerMapping, error) {
+	if mapping, ok := c.mappings[pkg]; ok {
+		return mapping, nil
+	}
+	mapping, err := loadProviderMapping(c.ctx, c.mapper, pkg, version)
+	if err != nil {
+		return nil, err
+	}
+	c.mappings[pkg] = mapping
+	return mapping, nil
+}
+
+// convertResource converts a custom resource to a Terraform resource. It returns nil if the resource does not come from
+// a bridged Terraform provider.
+func (c *stateConverter) convertResource(r *resource.State) (*StateResource, error) {
+	pkg := string(r.Type.Package())
+	mapping, err := c.packageMapping(pkg, c.providerVersions[r.Provider])
+	if err != nil {
+		return nil, err
+	}
+	if mapping == nil {
+		c.warnf("resource %s was skipped: package %s is not a bridged Terraform provider", r.URN, pkg)
+		return nil, nil
+	}
+	tfType, objectMapping, ok := mapping.resource(string(r.Type))
+	if !ok {
+		c.warnf("resource %s was skipped: no Terraform resource maps to %s", r.URN, r.Type)
+		return nil, nil
+	}
+
+	name := terraformIdentifier(r.URN.Name())
+	base := name
+	for i := 2; c.names.Has(tfType + "." + name); i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	address := tfType + "." + name
+	c.names.Add(address)
+	c.addresses[r.URN] = address
+
+	c.sensitive = nil
+	attributes := c.convertObject(r.Outputs, objectMapping, nil)
+	attributes["id"] = r.ID.String()
+
+	var dependencies []string
+	for _, dep := range r.Dependencies {
+		if address, ok := c.addresses[dep]; ok {
+			dependencies = append(dependencies, address)
+		}
+	}
+
+	sensitive := c.sensitive
+	if sensitive == nil {
+		sensitive = [][]StatePathStep{}
+	}
+	return &StateResource{
+		Mode:     "managed",
+		Type:     tfType,
+		Name:     name,
+		Provider: c.providerAddress(r, mapping),
+		Instances: []StateInstance{{
+			SchemaVersion:       schemaVersion(r.Outputs),
+			Attributes:          attributes,
+			SensitiveAttributes: sensitive,
+			Dependencies:        dependencies,
+		}},
+	}, nil
+}
+
+// providerAddress returns the Terraform address of the provider configuration used by a resource. Explicit providers
+// become aliased configurations of the provider.
+func (c *stateConverter) providerAddress(r *resource.State, mapping *providerMapping) string {
+	address := fmt.Sprintf("provider[\"registry.terraform.io/hashicorp/%s\"]", mapping.Name)
+	if r.Provider == "" {
+		return address
+	}
+	ref, err := providers.ParseReference(r.Provider)
+	if err != nil || providers.IsDefaultProvider(ref.URN()) {
+		return address
+	}
+	return address + "." + terraformIdentifier(ref.URN().Name())
+}
+
+// convertObject converts the properties of an object to Terraform attributes. Attributes that Pulumi omits are
+// written as null, or as empty lists for blocks, as Terraform expects every attribute of its schema to be present.
+func (c *stateConverter) convertObject(
+	props resource.PropertyMap, m *objectMapping, path []StatePathStep,
+) map[string]interface{} {
+	attributes := map[string]interface{}{}
+	for _, k := range props.StableKeys() {
+		// Skip the bridge's internal metadata.
+		if m != nil && strings.HasPrefix(string(k), "__") {
+			continue
+		}
+		p := m.property(string(k))
+		attributes[p.name] = c.convertValue(props[k], p, appendStep(path, StatePathStep{Type: "get_attr", Value: p.name}))
+	}
+
+	if m != nil {
+		for name, s := range m.schema {
+			if _, ok := attributes[name]; ok {
+				continue
+			}
+			if block, _ := (&propertyMapping{schema: s}).block(); block {
+				attributes[name] = []interface{}{}
+			} else {
+				attributes[name] = nil
+			}
+		}
+	}
+	return attributes
+}
+
+// convertValue converts a property value to a Terraform attribute value.
+func (c *stateConverter) convertValue(v resource.PropertyValue, p *propertyMapping, path []StatePathStep) interface{} {
+	switch {
+	case v.IsSecret():
+		c.secrets = true
+		c.sensitive = append(c.sensitive, path)
+		return c.convertValue(v.SecretValue().Element, p, path)
+	case v.IsOutput():
+		output := v.OutputValue()
+		if output.Secret {
+			c.secrets = true
+			c.sensitive = append(c.sensitive, path)
+		}
+		if !output.Known {
+			return nil
+		}
+		return c.convertValue(output.Element, p, path)
+	case v.IsComputed(), v.IsNull():
+		if block, _ := p.block(); block {
+			return []interface{}{}
+		}
+		return nil
+	case p.maxItemsOne() && !v.IsArray():
+		return []interface{}{c.convertElement(v, p, appendStep(path, indexStep(0)))}
+	case v.IsBool():
+		return v.BoolValue()
+	case v.IsNumber():
+		return v.NumberValue()
+	case v.IsString():
+		return v.StringValue()
+	case v.IsAsset():
+		asset := v.AssetValue()
+		switch {
+		case asset.IsPath():
+			return asset.Path
+		case asset.IsURI():
+			return asset.URI
+		default:
+			return asset.Text
+		}
+	case v.IsArchive():
+		archive := v.ArchiveValue()
+		if archive.IsPath() {
+			return archive.Path
+		} else if archive.IsURI() {
+			return archive.URI
+		}
+		return nil
+	case v.IsResourceReference():
+		ref := v.ResourceReferenceValue()
+		if id, ok := ref.ID.V.(string); ok && !ref.ID.IsNull() {
+			return id
+		}
+		return string(ref.URN)
+	case v.IsArray():
+		elements := v.ArrayValue()
+		values := make([]interface{}, len(elements))
+		for i, element := range elements {
+			values[i] = c.convertElement(element, p, appendStep(path, indexStep(i)))
+		}
+		return values
+	case v.IsObject():
+		return c.convertElement(v, p, path)
+	default:
+		return nil
+	}
+}
+
+// convertElement converts a value that is an element of a property rather than the property itself.
+func (c *stateConverter) convertElement(v resource.PropertyValue, p *propertyMapping, path []StatePathStep) interface{} {
+	if v.IsObject() {
+		return c.convertObject(v.ObjectValue(), p.object(), path)
+	}
+	return c.convertValue(v, &propertyMapping{name: p.name, verbatim: true}, path)
+}
+
+func appendStep(path []StatePathStep, step StatePathStep) []StatePathStep {
+	return append(path[:len(path):len(path)], step)
+}
+
+func indexStep(i int) StatePathStep {
+	return StatePathStep{Type: "index", Value: map[string]interface{}{"value": i, "type": "number"}}
+}
+
+// schemaVersion returns the Terraform schema version that the bridge recorded for a resource's state.
+func schemaVersion(outputs resource.PropertyMap) int {
+	meta, ok := outputs["__meta"]
+	if !ok || !meta.IsString() {
+		return 0
+	}
+	var parsed struct {
+		SchemaVersion string `json:"schema_version"`
+	}
+	if err := json.Unmarshal([]byte(meta.StringValue()), &parsed); err != nil {
+		return 0
+	}
+	version, err := strconv.Atoi(parsed.SchemaVersion)
+	if err != nil {
+		return 0
+	}
+	return version
+}
+
+// valueType returns the type of a converted value in Terraform's JSON encoding of types.
+func valueType(v interface{}) interface{} {
+	switch v := v.(type) {
+	case bool:
+		return "bool"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		types := make([]interface{}, len(v))
+		for i, element := range v {
+			types[i] = valueType(element)
+		}
+		return []interface{}{"tuple", types}
+	case map[string]interface{}:
+		types := make(map[string]interface{}, len(v))
+		for k, element := range v {
+			types[k] = valueType(element)
+		}
+		return []interface{}{"object", types}
+	default:
+		return "dynamic"
+	}
+}
+
+var invalidIdentifierChars = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// terraformIdentifier returns a valid Terraform identifier for the given name.
+func terraformIdentifier(name string) string {
+	name = invalidIdentifierChars.ReplaceAllString(name, "_")
+	if name == "" || !(name[0] == '_' || 'a' <= name[0] && name[0] <= 'z' || 'A' <= name[0] && name[0] <= 'Z') {
+		name = "_" + name
+	}
+	return name
+}
@@ -0,0 +1,203 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/convert"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+func testStateResources() []*resource.State {
+	stackURN := resource.NewURN("dev", "proj", "", resource.RootStackType, "proj-dev")
+	keyURN := resource.NewURN("dev", "proj", "", "tls:index/privateKey:PrivateKey", "my-key")
+	certURN := resource.NewURN("dev", "proj", "", "tls:index/selfSignedCert:SelfSignedCert", "cert")
+	providerURN := resource.NewURN("dev", "proj", "", "pulumi:providers:tls", "default_5_0_0")
+	provider := string(providerURN) + "::provider-id"
+
+	return []*resource.State{
+		{
+			Type: resource.RootStackType,
+			URN:  stackURN,
+			Outputs: resource.PropertyMap{
+				"certPem": resource.MakeSecret(resource.NewStringProperty("CERT")),
+				"ids": resource.NewArrayProperty([]resource.PropertyValue{
+					resource.NewStringProperty("key-id"),
+				}),
+			},
+		},
+		{
+			Type:   "pulumi:providers:tls",
+			URN:    providerURN,
+			Custom: true,
+			ID:     "provider-id",
+		},
+		{
+			Type:     "tls:index/privateKey:PrivateKey",
+			URN:      keyURN,
+			Custom:   true,
+			ID:       "key-id",
+			Parent:   stackURN,
+			Provider: provider,
+			Outputs: resource.PropertyMap{
+				"algorithm":     resource.NewStringProperty("ECDSA"),
+				"privateKeyPem": resource.MakeSecret(resource.NewStringProperty("KEY")),
+				"__meta":        resource.NewStringProperty(`{"schema_version":"1"}`),
+			},
+		},
+		{
+			Type:         "tls:index/selfSignedCert:SelfSignedCert",
+			URN:          certURN,
+			Custom:       true,
+			ID:           "cert-id",
+			Parent:       stackURN,
+			Provider:     provider,
+			Dependencies: []resource.URN{keyURN},
+			Outputs: resource.PropertyMap{
+				"allowedUses": resource.NewArrayProperty([]resource.PropertyValue{
+					resource.NewStringProperty("key_encipherment"),
+				}),
+				"certPem":             resource.NewStringProperty("CERT"),
+				"validityPeriodHours": resource.NewNumberProperty(12),
+				"subject": resource.NewObjectProperty(resource.PropertyMap{
+					"commonName": resource.NewStringProperty("example.com"),
+				}),
+			},
+		},
+	}
+}
+
+func TestConvertState(t *testing.T) {
+	t.Parallel()
+
+	state, diags, err := ConvertState(context.Background(), testStateResources(), mappings, StateOptions{
+		ShowSecrets: true,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, diags)
+	assert.NotEmpty(t, state.Lineage)
+
+	state.Lineage = "lineage"
+	actual, err := json.MarshalIndent(state, "", "  ")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+  "version": 4,
+  "terraform_version": "1.0.0",
+  "serial": 1,
+  "lineage": "lineage",
+  "outputs": {
+    "certPem": {"value": "CERT", "type": "string", "sensitive": true},
+    "ids": {"value": ["key-id"], "type": ["tuple", ["string"]]}
+  },
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "tls_private_key",
+      "name": "my-key",
+      "provider": "provider[\"registry.terraform.io/hashicorp/tls\"]",
+      "instances": [{
+        "schema_version": 1,
+        "attributes": {
+          "id": "key-id",
+          "algorithm": "ECDSA",
+          "ecdsa_curve": null,
+          "private_key_pem": "KEY"
+        },
+        "sensitive_attributes": [[{"type": "get_attr", "value": "private_key_pem"}]]
+      }]
+    },
+    {
+      "mode": "managed",
+      "type": "tls_self_signed_cert",
+      "name": "cert",
+      "provider": "provider[\"registry.terraform.io/hashicorp/tls\"]",
+      "instances": [{
+        "schema_version": 0,
+        "attributes": {
+          "id": "cert-id",
+          "allowed_uses": ["key_encipherment"],
+          "cert_pem": "CERT",
+          "private_key_pem": null,
+          "subject": [{"common_name": "example.com", "organization": null}],
+          "validity_period_hours": 12
+        },
+        "sensitive_attributes": [],
+        "dependencies": ["tls_private_key.my-key"]
+      }]
+    }
+  ],
+  "check_results": null
+}`, string(actual))
+}
+
+func TestConvertStateRequiresShowSecrets(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := ConvertState(context.Background(), testStateResources(), mappings, StateOptions{})
+	assert.ErrorIs(t, err, ErrSecretsInState)
+}
+
+func TestConvertStateUnbridgedPackage(t *testing.T) {
+	t.Parallel()
+
+	resources := []*resource.State{{
+		Type:   tokens.Type("kubernetes:core/v1:Namespace"),
+		URN:    resource.NewURN("dev", "proj", "", "kubernetes:core/v1:Namespace", "ns"),
+		Custom: true,
+		ID:     "ns",
+	}}
+
+	state, diags, err := ConvertState(context.Background(), resources, mappings, StateOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, state.Resources)
+	require.Len(t, diags, 1)
+	assert.Contains(t, diags[0].Summary, "package kubernetes is not a bridged Terraform provider")
+}
+
+// hintRecordingMapper records the hints that mappings are requested with.
+type hintRecordingMapper struct {
+	testMapper
+	hints map[string]*convert.MapperPackageHint
+}
+
+func (m *hintRecordingMapper) GetMapping(
+	ctx context.Context, provider string, hint *convert.MapperPackageHint,
+) ([]byte, error) {
+	m.hints[provider] = hint
+	return m.testMapper.GetMapping(ctx, provider, hint)
+}
+
+func TestConvertStateUsesProviderVersion(t *testing.T) {
+	t.Parallel()
+
+	resources := testStateResources()
+	resources[1].Inputs = resource.PropertyMap{"version": resource.NewStringProperty("5.0.0")}
+
+	mapper := &hintRecordingMapper{testMapper: mappings, hints: map[string]*convert.MapperPackageHint{}}
+	_, _, err := ConvertState(context.Background(), resources, mapper, StateOptions{ShowSecrets: true})
+	require.NoError(t, err)
+
+	require.Contains(t, mapper.hints, "tls")
+	require.NotNil(t, mapper.hints["tls"].Version)
+	assert.Equal(t, semver.MustParse("5.0.0"), *mapper.hints["tls"].Version)
+}
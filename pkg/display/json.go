@@ -45,6 +45,9 @@ type PreviewDigest struct {
 	ChangeSummary ResourceChanges `json:"changeSummary,omitempty"`
 	// MaybeCorrupt indicates whether one or more resources may be corrupt.
 	MaybeCorrupt bool `json:"maybeCorrupt,omitempty"`
+	// RenameSuggestions lists resources being created that appear to be renames of resources being deleted, and
+	// which could be updated in place by adding an alias.
+	RenameSuggestions []apitype.RenameSuggestion `json:"renameSuggestions,omitempty"`
 }
 
 // PropertyDiff contains information about the difference in a single property value.
@@ -238,6 +238,7 @@ func newDeployment(
 		GeneratePlan:              opts.GeneratePlan,
 		ContinueOnError:           opts.ContinueOnError,
 		Autonamer:                 opts.Autonamer,
+		RenameAliases:             opts.RenameAliases,
 	}
 
 	var depl *deploy.Deployment
@@ -397,21 +398,19 @@ func (deployment *deployment) run(cancelCtx *Context) (*deploy.Plan, display.Res
 	duration := time.Since(start)
 	changes := deployment.Actions.Changes()
 
-	// Refresh and Import do not execute Policy Packs.
 	policies := map[string]string{}
-	if !deployment.Options.isRefresh && !deployment.Options.isImport {
-		for _, p := range deployment.Options.RequiredPolicies {
-			policies[p.Name()] = p.Version()
-		}
-		for _, pack := range deployment.Options.LocalPolicyPacks {
-			packName := pack.NameForEvents()
-			policies[packName] = pack.Version
-		}
+	for _, p := range deployment.Options.RequiredPolicies {
+		policies[p.Name()] = p.Version()
+	}
+	for _, pack := range deployment.Options.LocalPolicyPacks {
+		packName := pack.NameForEvents()
+		policies[packName] = pack.Version
 	}
 
 	// Emit a summary event.
 	deployment.Options.Events.summaryEvent(
-		deployment.Options.DryRun, deployment.Actions.MaybeCorrupt(), duration, changes, policies)
+		deployment.Options.DryRun, deployment.Actions.MaybeCorrupt(), duration, changes, policies,
+		deployment.Deployment.RenameSuggestions())
 
 	return newPlan, changes, err
 }
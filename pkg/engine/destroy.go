@@ -146,13 +146,21 @@ func newDestroySource(
 		}
 	}
 
-	// Like Update, if we're missing plugins, attempt to download the missing plugins.
+	// Like Update, if we're missing plugins, attempt to download the missing plugins, honoring the plugin lock.
+	lock, err := loadPluginLock(plugctx.Root, opts)
+	if err != nil {
+		return nil, err
+	}
 	allPlugins := snapshotPackages.ToPluginSet().Deduplicate()
 
+	projectPlugins := plugctx.Host.GetProjectPlugins()
 	if err := EnsurePluginsAreInstalled(ctx, opts, plugctx.Diag, allPlugins,
-		plugctx.Host.GetProjectPlugins(), false /*reinstall*/, false /*explicitInstall*/); err != nil {
+		projectPlugins, lock, false /*reinstall*/, false /*explicitInstall*/); err != nil {
 		logging.V(7).Infof("newDestroySource(): failed to install missing plugins: %v", err)
 	}
+	if err := VerifyPluginLock(plugctx.Diag, allPlugins, projectPlugins, lock); err != nil {
+		return nil, err
+	}
 
 	// We don't need the language plugin, since destroy doesn't run code, so we will leave that out.
 	if err := ensurePluginsAreLoaded(plugctx, allPlugins, plugin.AnalyzerPlugins); err != nil {
@@ -228,11 +228,12 @@ type PreludeEventPayload struct {
 }
 
 type SummaryEventPayload struct {
-	IsPreview       bool                    // true if this summary is for a plan operation
-	MaybeCorrupt    bool                    // true if one or more resources may be corrupt
-	Duration        time.Duration           // the duration of the entire update operation (zero values for previews)
-	ResourceChanges display.ResourceChanges // count of changed resources, useful for reporting
-	PolicyPacks     map[string]string       // {policy-pack: version} for each policy pack applied
+	IsPreview         bool                      // true if this summary is for a plan operation
+	MaybeCorrupt      bool                      // true if one or more resources may be corrupt
+	Duration          time.Duration             // the duration of the entire update operation (zero values for previews)
+	ResourceChanges   display.ResourceChanges   // count of changed resources, useful for reporting
+	PolicyPacks       map[string]string         // {policy-pack: version} for each policy pack applied
+	RenameSuggestions []deploy.RenameSuggestion // created resources that appear to be renames of deleted ones
 }
 
 type ResourceOperationFailedPayload struct {
@@ -519,15 +520,17 @@ func (e *eventEmitter) preludeEvent(isPreview bool, cfg config.Map) {
 
 func (e *eventEmitter) summaryEvent(preview, maybeCorrupt bool, duration time.Duration,
 	resourceChanges display.ResourceChanges, policyPacks map[string]string,
+	renameSuggestions []deploy.RenameSuggestion,
 ) {
 	contract.Requiref(e != nil, "e", "!= nil")
 
 	e.sendEvent(NewEvent(SummaryEventPayload{
-		IsPreview:       preview,
-		MaybeCorrupt:    maybeCorrupt,
-		Duration:        duration,
-		ResourceChanges: resourceChanges,
-		PolicyPacks:     policyPacks,
+		IsPreview:         preview,
+		MaybeCorrupt:      maybeCorrupt,
+		Duration:          duration,
+		ResourceChanges:   resourceChanges,
+		PolicyPacks:       policyPacks,
+		RenameSuggestions: renameSuggestions,
 	}))
 }
 
@@ -536,11 +539,12 @@ func (e *eventEmitter) policyViolationEvent(urn resource.URN, d plugin.AnalyzeDi
 
 	// Write prefix.
 	var prefix bytes.Buffer
-	//nolint:exhaustive // We only expect mandatory or advisory events here.
+	//nolint:exhaustive // We only expect mandatory, advisory, or remediate events here.
 	switch d.EnforcementLevel {
 	case apitype.Mandatory:
 		prefix.WriteString(colors.SpecError)
-	case apitype.Advisory:
+	case apitype.Advisory, apitype.Remediate:
+		// Remediate violations are only reported for resources that an update would bring back into compliance.
 		prefix.WriteString(colors.SpecWarning)
 	default:
 		contract.Failf("Unrecognized diagnostic severity: %v", d)
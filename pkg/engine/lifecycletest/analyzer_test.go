@@ -548,3 +548,257 @@ func TestAnalyzerCancellation(t *testing.T) {
 	assert.Error(t, err)
 	assert.True(t, gracefulShutdown)
 }
+
+// TestAnalyzeStackComponents checks that stack analysis sees component resources and their inputs, so that policies can
+// reason about a component and its children.
+func TestAnalyzeStackComponents(t *testing.T) {
+	t.Parallel()
+
+	var analyzed []plugin.AnalyzerStackResource
+	loaders := []*deploytest.PluginLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+		deploytest.NewAnalyzerLoader("analyzerA", func(_ *plugin.PolicyAnalyzerOptions) (plugin.Analyzer, error) {
+			return &deploytest.Analyzer{
+				AnalyzeStackF: func(rs []plugin.AnalyzerStackResource) ([]plugin.AnalyzeDiagnostic, error) {
+					analyzed = rs
+					return nil, nil
+				},
+			}, nil
+		}),
+	}
+
+	programF := deploytest.NewLanguageRuntimeF(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		comp, err := monitor.RegisterResource("pkgA:m:Component", "comp", false, deploytest.ResourceOptions{
+			Inputs: resource.PropertyMap{"size": resource.NewNumberProperty(3)},
+		})
+		require.NoError(t, err)
+		_, err = monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Parent: comp.URN,
+		})
+		require.NoError(t, err)
+		return nil
+	})
+	hostF := deploytest.NewPluginHostF(nil, nil, programF, loaders...)
+
+	p := &lt.TestPlan{
+		Options: lt.TestUpdateOptions{
+			T: t,
+			UpdateOptions: UpdateOptions{
+				RequiredPolicies: []RequiredPolicy{NewRequiredPolicy("analyzerA", "", nil)},
+			},
+			HostF: hostF,
+		},
+	}
+
+	project := p.GetProject()
+	_, err := lt.TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	require.NoError(t, err)
+
+	byName := map[string]plugin.AnalyzerStackResource{}
+	for _, r := range analyzed {
+		byName[r.Name] = r
+	}
+	require.Contains(t, byName, "comp")
+	require.Contains(t, byName, "resA")
+	assert.False(t, byName["comp"].Custom)
+	assert.Equal(t, resource.NewNumberProperty(3), byName["comp"].Inputs["size"])
+	assert.True(t, byName["resA"].Custom)
+	assert.Equal(t, byName["comp"].URN, byName["resA"].Parent)
+}
+
+// TestAnalyzeObservedResources tests that resources read by a refresh or an import are checked against policies, and
+// that remediations are reported rather than applied.
+func TestAnalyzeObservedResources(t *testing.T) {
+	t.Parallel()
+
+	readInputs := resource.PropertyMap{"public": resource.NewBoolProperty(true)}
+	loaders := []*deploytest.PluginLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				ReadF: func(_ context.Context, req plugin.ReadRequest) (plugin.ReadResponse, error) {
+					return plugin.ReadResponse{
+						ReadResult: plugin.ReadResult{
+							ID:      req.ID,
+							Inputs:  readInputs,
+							Outputs: readInputs,
+						},
+						Status: resource.StatusOK,
+					}, nil
+				},
+			}, nil
+		}),
+		deploytest.NewAnalyzerLoader("analyzerA", func(_ *plugin.PolicyAnalyzerOptions) (plugin.Analyzer, error) {
+			return &deploytest.Analyzer{
+				RemediateF: func(r plugin.AnalyzerResource) ([]plugin.Remediation, error) {
+					if r.Type != "pkgA:m:typA" {
+						return nil, nil
+					}
+					return []plugin.Remediation{{
+						PolicyName:     "private",
+						PolicyPackName: "analyzerA",
+						Description:    "resources must not be public",
+						Properties:     resource.PropertyMap{"public": resource.NewBoolProperty(false)},
+					}}, nil
+				},
+				AnalyzeF: func(r plugin.AnalyzerResource) ([]plugin.AnalyzeDiagnostic, error) {
+					if r.Type != "pkgA:m:typA" {
+						return nil, nil
+					}
+					var diagnostics []plugin.AnalyzeDiagnostic
+					if r.Properties["public"].IsBool() && r.Properties["public"].BoolValue() {
+						diagnostics = append(diagnostics, plugin.AnalyzeDiagnostic{
+							PolicyName:       "private",
+							PolicyPackName:   "analyzerA",
+							Message:          "resource is public",
+							EnforcementLevel: apitype.Remediate,
+							URN:              r.URN,
+						})
+					}
+					return append(diagnostics, plugin.AnalyzeDiagnostic{
+						PolicyName:       "tagged",
+						PolicyPackName:   "analyzerA",
+						Message:          "resource has no tags",
+						EnforcementLevel: apitype.Advisory,
+						URN:              r.URN,
+					}), nil
+				},
+			}, nil
+		}),
+	}
+
+	programF := deploytest.NewLanguageRuntimeF(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Inputs: resource.PropertyMap{"public": resource.NewBoolProperty(false)},
+		})
+		require.NoError(t, err)
+		return nil
+	})
+	hostF := deploytest.NewPluginHostF(nil, nil, programF, loaders...)
+
+	p := &lt.TestPlan{
+		Options: lt.TestUpdateOptions{T: t, HostF: hostF},
+	}
+	project := p.GetProject()
+	snap, err := lt.TestOp(Update).RunStep(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil, "0")
+	require.NoError(t, err)
+
+	policyOptions := p.Options
+	policyOptions.UpdateOptions.RequiredPolicies = []RequiredPolicy{NewRequiredPolicy("analyzerA", "", nil)}
+
+	resourceNamed := func(snap *deploy.Snapshot, name string) *resource.State {
+		for _, r := range snap.Resources {
+			if r.URN.Name() == name {
+				return r
+			}
+		}
+		require.Failf(t, "resource not found", "no resource named %q", name)
+		return nil
+	}
+
+	validateViolations := func(name string) lt.ValidateFunc {
+		return func(project workspace.Project, target deploy.Target, entries JournalEntries,
+			events []Event, err error,
+		) error {
+			violations := map[string]apitype.EnforcementLevel{}
+			for _, e := range events {
+				if e.Type == PolicyViolationEvent {
+					payload := e.Payload().(PolicyViolationEventPayload)
+					assert.Equal(t, name, payload.ResourceURN.Name())
+					assert.NotContains(t, violations, payload.PolicyName, "violations are reported once")
+					violations[payload.PolicyName] = payload.EnforcementLevel
+				}
+			}
+			assert.Equal(t, map[string]apitype.EnforcementLevel{
+				"private": apitype.Remediate,
+				"tagged":  apitype.Advisory,
+			}, violations)
+			return err
+		}
+	}
+
+	// Refresh the resource, which has been made public out-of-band. The refresh succeeds and keeps the state that was
+	// read, but reports the resource as out of compliance.
+	snap, err = lt.TestOp(Refresh).RunStep(project, p.GetTarget(t, snap), policyOptions, false, p.BackendClient,
+		validateViolations("resA"), "1")
+	require.NoError(t, err)
+	assert.Equal(t, readInputs, resourceNamed(snap, "resA").Inputs)
+
+	// The same is true of refreshes that run the program.
+	snap, err = lt.TestOp(RefreshV2).RunStep(project, p.GetTarget(t, snap), policyOptions, false, p.BackendClient,
+		validateViolations("resA"), "2")
+	require.NoError(t, err)
+	assert.Equal(t, readInputs, resourceNamed(snap, "resA").Inputs)
+
+	// Import another public resource, which is reported in the same way.
+	snap, err = lt.ImportOp([]deploy.Import{{
+		Type: "pkgA:m:typA",
+		Name: "resB",
+		ID:   "imported-id",
+	}}).RunStep(project, p.GetTarget(t, snap), policyOptions, false, p.BackendClient, validateViolations("resB"), "3")
+	require.NoError(t, err)
+	assert.Equal(t, readInputs, resourceNamed(snap, "resB").Inputs)
+}
+
+// Tests that an analyzer failing while checking refreshed or imported resources fails the refresh or import.
+func TestAnalyzeObservedResourcesError(t *testing.T) {
+	t.Parallel()
+
+	loaders := []*deploytest.PluginLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				ReadF: func(_ context.Context, req plugin.ReadRequest) (plugin.ReadResponse, error) {
+					return plugin.ReadResponse{
+						ReadResult: plugin.ReadResult{
+							ID:      req.ID,
+							Inputs:  resource.PropertyMap{},
+							Outputs: resource.PropertyMap{},
+						},
+						Status: resource.StatusOK,
+					}, nil
+				},
+			}, nil
+		}),
+		deploytest.NewAnalyzerLoader("analyzerA", func(_ *plugin.PolicyAnalyzerOptions) (plugin.Analyzer, error) {
+			return &deploytest.Analyzer{
+				RemediateF: func(r plugin.AnalyzerResource) ([]plugin.Remediation, error) {
+					if r.Type != "pkgA:m:typA" {
+						return nil, nil
+					}
+					return nil, errors.New("policy crashed")
+				},
+			}, nil
+		}),
+	}
+
+	programF := deploytest.NewLanguageRuntimeF(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, err := monitor.RegisterResource("pkgA:m:typA", "resA", true)
+		require.NoError(t, err)
+		return nil
+	})
+	hostF := deploytest.NewPluginHostF(nil, nil, programF, loaders...)
+
+	p := &lt.TestPlan{
+		Options: lt.TestUpdateOptions{T: t, HostF: hostF, SkipDisplayTests: true},
+	}
+	project := p.GetProject()
+	snap, err := lt.TestOp(Update).RunStep(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil, "0")
+	require.NoError(t, err)
+
+	policyOptions := p.Options
+	policyOptions.UpdateOptions.RequiredPolicies = []RequiredPolicy{NewRequiredPolicy("analyzerA", "", nil)}
+
+	// An analyzer that fails to check a refreshed or imported resource fails the operation, as it would during an
+	// update.
+	_, err = lt.TestOp(Refresh).RunStep(project, p.GetTarget(t, snap), policyOptions, false, p.BackendClient,
+		nil, "1")
+	assert.ErrorContains(t, err, "policy crashed")
+
+	_, err = lt.ImportOp([]deploy.Import{{
+		Type: "pkgA:m:typA",
+		Name: "resB",
+		ID:   "imported-id",
+	}}).RunStep(project, p.GetTarget(t, snap), policyOptions, false, p.BackendClient, nil, "2")
+	assert.ErrorContains(t, err, "policy crashed")
+}
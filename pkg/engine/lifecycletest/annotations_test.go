@@ -0,0 +1,134 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycletest
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/pulumi/pulumi/pkg/v3/engine" //nolint:revive
+	lt "github.com/pulumi/pulumi/pkg/v3/engine/lifecycletest/framework"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+)
+
+// Tests that annotations are stored in state without being sent to the provider, that they are passed to analyzers,
+// and that annotations added with `pulumi state annotate` survive updates that change the program's annotations.
+func TestResourceAnnotations(t *testing.T) {
+	t.Parallel()
+
+	var analyzed sync.Map
+	loaders := []*deploytest.PluginLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(_ context.Context, req plugin.CreateRequest) (plugin.CreateResponse, error) {
+					assert.NotContains(t, req.Properties, resource.PropertyKey("owner"))
+					return plugin.CreateResponse{ID: "id", Properties: req.Properties, Status: resource.StatusOK}, nil
+				},
+			}, nil
+		}),
+		deploytest.NewAnalyzerLoader("analyzerA", func(_ *plugin.PolicyAnalyzerOptions) (plugin.Analyzer, error) {
+			return &deploytest.Analyzer{
+				AnalyzeF: func(r plugin.AnalyzerResource) ([]plugin.AnalyzeDiagnostic, error) {
+					analyzed.Store(r.URN.Name(), r.Options.Annotations)
+					return nil, nil
+				},
+			}, nil
+		}),
+	}
+
+	owner := "alice"
+	programF := deploytest.NewLanguageRuntimeF(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		var annotations map[string]string
+		if owner != "" {
+			annotations = map[string]string{"owner": owner}
+		}
+		_, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Annotations: annotations,
+		})
+		require.NoError(t, err)
+		_, err = monitor.RegisterResource("pkgA:m:typA", "resB", true)
+		require.NoError(t, err)
+		return nil
+	})
+	hostF := deploytest.NewPluginHostF(nil, nil, programF, loaders...)
+
+	p := &lt.TestPlan{
+		Options: lt.TestUpdateOptions{
+			T:     t,
+			HostF: hostF,
+			UpdateOptions: UpdateOptions{
+				RequiredPolicies: []RequiredPolicy{NewRequiredPolicy("analyzerA", "", nil)},
+			},
+		},
+	}
+	project := p.GetProject()
+
+	byName := func(snap *deploy.Snapshot, name string) *resource.State {
+		for _, r := range snap.Resources {
+			if r.URN.Name() == name {
+				return r
+			}
+		}
+		require.Failf(t, "resource not found", "%s", name)
+		return nil
+	}
+
+	snap, err := lt.TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"owner": "alice"}, byName(snap, "resA").Annotations)
+	assert.Nil(t, byName(snap, "resB").Annotations)
+	seen, ok := analyzed.Load("resA")
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{"owner": "alice"}, seen)
+
+	// Annotate both resources in the state, as `pulumi state annotate` does. The program's annotations win for resA, but
+	// the other annotations are kept.
+	annotate := func(r *resource.State, annotations map[string]string) {
+		r.Annotations = maps.Clone(r.Annotations)
+		if r.Annotations == nil {
+			r.Annotations = map[string]string{}
+		}
+		maps.Copy(r.Annotations, annotations)
+		r.StateAnnotations = annotations
+	}
+	annotate(byName(snap, "resA"), map[string]string{"owner": "mallory", "ticket": "OPS-2"})
+	annotate(byName(snap, "resB"), map[string]string{"ticket": "OPS-1"})
+	snap, err = lt.TestOp(Update).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"owner": "alice", "ticket": "OPS-2"}, byName(snap, "resA").Annotations)
+	assert.Equal(t, map[string]string{"ticket": "OPS-1"}, byName(snap, "resB").Annotations)
+
+	// Changing an annotation in the program updates the state.
+	owner = "bob"
+	snap, err = lt.TestOp(Update).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"owner": "bob", "ticket": "OPS-2"}, byName(snap, "resA").Annotations)
+
+	// A program that stops setting annotations clears its own, but not those set in the state.
+	owner = ""
+	snap, err = lt.TestOp(Update).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"owner": "mallory", "ticket": "OPS-2"}, byName(snap, "resA").Annotations)
+	assert.Equal(t, map[string]string{"ticket": "OPS-1"}, byName(snap, "resB").Annotations)
+}
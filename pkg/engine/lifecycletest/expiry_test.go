@@ -0,0 +1,123 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycletest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/pulumi/pulumi/pkg/v3/engine" //nolint:revive
+	lt "github.com/pulumi/pulumi/pkg/v3/engine/lifecycletest/framework"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// Tests that resource expiry is recorded in state relative to when the resource was created, that the stack-wide
+// `pulumi:expires` setting applies to resources without their own expiry, and that previews warn about resources
+// that are about to expire.
+func TestResourceExpiry(t *testing.T) {
+	t.Parallel()
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	expiresA := "48h"
+	programF := deploytest.NewLanguageRuntimeF(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Expires: expiresA,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = monitor.RegisterResource("pkgA:m:typA", "resB", true)
+		require.NoError(t, err)
+		return nil
+	})
+	hostF := deploytest.NewPluginHostF(nil, nil, programF, loaders...)
+
+	p := &lt.TestPlan{
+		Options: lt.TestUpdateOptions{T: t, HostF: hostF},
+		Config: config.Map{
+			config.MustMakeKey("pulumi", "expires"): config.NewValue("30d"),
+		},
+	}
+
+	snap, err := lt.TestOp(Update).
+		RunStep(p.GetProject(), p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil, "0")
+	require.NoError(t, err)
+
+	byName := func(snap *deploy.Snapshot, name string) *resource.State {
+		for _, r := range snap.Resources {
+			if r.URN.Name() == name {
+				return r
+			}
+		}
+		require.Failf(t, "resource not found", "%s", name)
+		return nil
+	}
+	resA, resB := byName(snap, "resA"), byName(snap, "resB")
+	require.NotNil(t, resA.Created)
+	require.NotNil(t, resA.Expires)
+	assert.Equal(t, resA.Created.Add(48*time.Hour), *resA.Expires)
+	require.NotNil(t, resB.Expires)
+	assert.Equal(t, resB.Created.Add(30*24*time.Hour), *resB.Expires)
+	assert.Nil(t, byName(snap, "default").Expires, "providers should not expire")
+
+	// resA expires within the warning window, so a preview should warn about it but not about resB.
+	validate := func(
+		project workspace.Project, target deploy.Target, entries JournalEntries, events []Event, err error,
+	) error {
+		var sawA bool
+		for _, e := range events {
+			if e.Type != DiagEvent {
+				continue
+			}
+			payload := e.Payload().(DiagEventPayload)
+			if payload.URN.Name() == "resA" {
+				assert.Contains(t, payload.Message, "this resource expires in")
+				sawA = true
+			}
+			assert.NotEqual(t, "resB", payload.URN.Name())
+		}
+		assert.True(t, sawA, "did not see a warning for resA")
+		return err
+	}
+	_, err = lt.TestOp(Update).
+		RunStep(p.GetProject(), p.GetTarget(t, snap), p.Options, true, p.BackendClient, validate, "1")
+	require.NoError(t, err)
+
+	// Updating the resources doesn't move their expiry.
+	snap2, err := lt.TestOp(Update).
+		RunStep(p.GetProject(), p.GetTarget(t, snap), p.Options, false, p.BackendClient, nil, "2")
+	require.NoError(t, err)
+	assert.Equal(t, *resA.Expires, *byName(snap2, "resA").Expires)
+
+	// An invalid expiry is rejected.
+	expiresA = "soon"
+	_, err = lt.TestOp(Update).
+		RunStep(p.GetProject(), p.GetTarget(t, snap2), p.Options, false, p.BackendClient, nil, "3")
+	assert.ErrorContains(t, err, `invalid expiry "soon"`)
+}
@@ -1124,6 +1124,67 @@ func TestStackReference(t *testing.T) {
 	p.Run(t, nil)
 }
 
+// Tests that stack output reads recorded against a StackReference are saved with the StackReference's state.
+func TestStackReferenceRecordOutputReads(t *testing.T) {
+	t.Parallel()
+
+	programF := deploytest.NewLanguageRuntimeF(func(info plugin.RunInfo, mon *deploytest.ResourceMonitor) error {
+		urn, _, err := mon.ReadResource("pulumi:pulumi:StackReference", "other", "other", "",
+			resource.NewPropertyMapFromMap(map[string]interface{}{
+				"name": "other",
+			}), "", "", "", "")
+		require.NoError(t, err)
+
+		_, _, err = mon.Invoke("pulumi:pulumi:recordStackOutputReads", resource.NewPropertyMapFromMap(
+			map[string]interface{}{
+				"urn":     string(urn),
+				"outputs": []string{"foo"},
+			}), "", "", "")
+		require.NoError(t, err)
+		return nil
+	})
+	p := &lt.TestPlan{
+		BackendClient: &deploytest.BackendClient{
+			GetStackOutputsF: func(ctx context.Context, name string, _ func(error) error) (resource.PropertyMap, error) {
+				return resource.NewPropertyMapFromMap(map[string]interface{}{
+					"foo": "bar",
+				}), nil
+			},
+		},
+		Options: lt.TestUpdateOptions{
+			T:                t,
+			HostF:            deploytest.NewPluginHostF(nil, nil, programF),
+			SkipDisplayTests: true,
+		},
+	}
+
+	project := p.GetProject()
+	resURN := p.NewURN("pulumi:pulumi:StackReference", "other", "")
+	snap, err := lt.TestOp(Update).RunStep(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient,
+		func(_ workspace.Project, _ deploy.Target, entries JournalEntries, _ []Event, err error) error {
+			// The reads must be saved through the snapshot rather than only being applied to the in-memory state.
+			saved := false
+			for _, entry := range entries {
+				if entry.Kind == JournalEntryOutputs && entry.Step.URN() == resURN {
+					saved = true
+				}
+			}
+			assert.True(t, saved, "expected the stack output reads to be saved")
+			return err
+		}, "0")
+	require.NoError(t, err)
+
+	var ref *resource.State
+	for _, res := range snap.Resources {
+		if res.URN == resURN {
+			ref = res
+		}
+	}
+	require.NotNil(t, ref)
+	assert.Equal(t, resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("foo")}),
+		ref.Outputs["readOutputs"])
+}
+
 // Tests that registering (rather than reading) a StackReference resource works as intended, but warns the user that
 // it's deprecated.
 func TestStackReferenceRegister(t *testing.T) {
@@ -0,0 +1,97 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycletest
+
+import (
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/pulumi/pulumi/pkg/v3/engine" //nolint:revive
+	lt "github.com/pulumi/pulumi/pkg/v3/engine/lifecycletest/framework"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// Tests that a preview suggests aliases for resources that look like they have been renamed, and that an update
+// given those suggestions as rename aliases updates the resources in place instead of replacing them.
+func TestRenameSuggestions(t *testing.T) {
+	t.Parallel()
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	name := "resA"
+	programF := deploytest.NewLanguageRuntimeF(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, err := monitor.RegisterResource("pkgA:m:typA", name, true, deploytest.ResourceOptions{
+			Inputs: resource.NewPropertyMapFromMap(map[string]interface{}{"size": 10, "region": "us-west-2"}),
+		})
+		return err
+	})
+	hostF := deploytest.NewPluginHostF(nil, nil, programF, loaders...)
+	p := &lt.TestPlan{Options: lt.TestUpdateOptions{T: t, HostF: hostF}}
+
+	snap, err := lt.TestOp(Update).
+		RunStep(p.GetProject(), p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil, "0")
+	require.NoError(t, err)
+	oldURN := snap.Resources[1].URN
+
+	// Rename the resource. The preview should suggest the old URN as an alias of the new one.
+	name = "resB"
+	var suggestions []deploy.RenameSuggestion
+	validate := func(
+		project workspace.Project, target deploy.Target, entries JournalEntries, events []Event, err error,
+	) error {
+		for _, e := range events {
+			if e.Type == SummaryEvent {
+				suggestions = e.Payload().(SummaryEventPayload).RenameSuggestions
+			}
+		}
+		return err
+	}
+	_, err = lt.TestOp(Update).
+		RunStep(p.GetProject(), p.GetTarget(t, snap), p.Options, true, p.BackendClient, validate, "1")
+	require.NoError(t, err)
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, oldURN, suggestions[0].OldURN)
+	assert.Equal(t, "resB", suggestions[0].URN.Name())
+	assert.Equal(t, float64(1), suggestions[0].Similarity)
+
+	// Updating with the suggestion as an alias renames the resource in place.
+	p.Options.RenameAliases = map[resource.URN]resource.URN{suggestions[0].URN: suggestions[0].OldURN}
+	validate = func(
+		project workspace.Project, target deploy.Target, entries JournalEntries, events []Event, err error,
+	) error {
+		for _, entry := range entries {
+			op := entry.Step.Op()
+			assert.NotEqual(t, deploy.OpCreate, op, "unexpected create of %v", entry.Step.URN())
+			assert.NotEqual(t, deploy.OpDelete, op, "unexpected delete of %v", entry.Step.URN())
+		}
+		return err
+	}
+	snap, err = lt.TestOp(Update).
+		RunStep(p.GetProject(), p.GetTarget(t, snap), p.Options, false, p.BackendClient, validate, "2")
+	require.NoError(t, err)
+	require.Len(t, snap.Resources, 2)
+	assert.Equal(t, suggestions[0].URN, snap.Resources[1].URN)
+}
@@ -333,11 +333,24 @@ func gatherPackagesFromSnapshot(plugctx *plugin.Context, target *deploy.Target)
 
 // EnsurePluginsAreInstalled inspects all plugins in the plugin set and, if any plugins are not currently installed,
 // uses the given backend client to install them. Installations are processed in parallel, though
-// ensurePluginsAreInstalled does not return until all installations are completed.
+// ensurePluginsAreInstalled does not return until all installations are completed. If a plugin lock is given, plugins
+// are installed at their locked versions and verified against the locked checksums, and the checksums of any plugins
+// that are downloaded are recorded in the lock.
 func EnsurePluginsAreInstalled(ctx context.Context, opts *deploymentOptions, d diag.Sink, plugins PluginSet,
-	projectPlugins []workspace.ProjectPlugin, reinstall, explicitInstall bool,
+	projectPlugins []workspace.ProjectPlugin, lock *workspace.PluginLock, reinstall, explicitInstall bool,
 ) error {
 	logging.V(preparePluginLog).Infof("ensurePluginsAreInstalled(): beginning")
+
+	// If the project has a plugin lock, we'll need to know which platform we're recording checksums for.
+	var platform string
+	if lock != nil {
+		p, err := workspace.PluginPlatform()
+		if err != nil {
+			return err
+		}
+		platform = p
+	}
+
 	var installTasks errgroup.Group
 	for _, plug := range plugins.Values() {
 		if plug.Name == "pulumi" && plug.Kind == apitype.ResourcePlugin {
@@ -345,17 +358,32 @@ func EnsurePluginsAreInstalled(ctx context.Context, opts *deploymentOptions, d d
 			continue
 		}
 
+		// Plugins that the project provides from a local path aren't downloaded, so there's nothing to lock.
+		if lock != nil && !isProjectPlugin(plug, projectPlugins) {
+			pinned, err := lock.Pin(plug)
+			if err != nil {
+				return err
+			}
+			plug = pinned
+		}
+
+		// When explicitly installing a locked project, download any plugin whose checksum hasn't been recorded for
+		// this platform yet, even if it's already installed, so that the lock covers every platform it's used on.
+		recordChecksum := lock != nil && explicitInstall && plug.Version != nil &&
+			!isProjectPlugin(plug, projectPlugins) && !workspace.IsPluginBundled(plug.Kind, plug.Name) &&
+			!lock.HasChecksum(plug, platform)
+
 		path, err := workspace.GetPluginPath(ctx, d, plug, projectPlugins)
 		if err == nil && path != "" {
 			logging.V(preparePluginLog).Infof(
 				"ensurePluginsAreInstalled(): plugin %s %s already installed", plug.Name, plug.Version)
 
-			if !reinstall {
+			if !reinstall && !recordChecksum {
 				continue
 			}
 		}
 
-		if !reinstall {
+		if !reinstall && !recordChecksum {
 			// If the plugin already exists, don't download it unless `reinstall` was specified.
 			label := fmt.Sprintf("%s plugin %s", plug.Kind, plug)
 			if plug.Version != nil {
@@ -394,7 +422,7 @@ func EnsurePluginsAreInstalled(ctx context.Context, opts *deploymentOptions, d d
 		installTasks.Go(func() error {
 			logging.V(preparePluginLog).Infof(
 				"EnsurePluginsAreInstalled(): plugin %s %s not installed, doing install", info.Name, info.Version)
-			return installPlugin(ctx, opts, info)
+			return installPlugin(ctx, opts, info, lock, reinstall || recordChecksum)
 		})
 	}
 
@@ -403,6 +431,153 @@ func EnsurePluginsAreInstalled(ctx context.Context, opts *deploymentOptions, d d
 	return err
 }
 
+// VerifyPluginLock checks the installed plugins in the given plugin set against the checksums that the plugin lock
+// records for this platform, returning an error if any of them was installed from a different archive. Plugins whose
+// archive is unknown, e.g. because they were installed by an earlier version of Pulumi, can't be checked, and a warning
+// is issued for them instead.
+func VerifyPluginLock(d diag.Sink, plugins PluginSet, projectPlugins []workspace.ProjectPlugin,
+	lock *workspace.PluginLock,
+) error {
+	if lock == nil {
+		return nil
+	}
+	platform, err := workspace.PluginPlatform()
+	if err != nil {
+		return err
+	}
+
+	for _, plug := range plugins.Values() {
+		if plug.Name == "pulumi" && plug.Kind == apitype.ResourcePlugin {
+			continue
+		}
+		if isProjectPlugin(plug, projectPlugins) || workspace.IsPluginBundled(plug.Kind, plug.Name) {
+			continue
+		}
+		plug, err := lock.Pin(plug)
+		if err != nil {
+			return err
+		}
+		if !lock.HasChecksum(plug, platform) || !workspace.HasPlugin(plug) {
+			continue
+		}
+
+		checksum, err := plug.InstalledChecksum()
+		if err != nil {
+			return err
+		}
+		if checksum == nil {
+			d.Warningf(diag.Message("", "cannot check %s plugin %s against %s because the archive it was installed "+
+				"from is unknown; run `pulumi plugin install --reinstall %s %s %s` to check it"),
+				plug.Kind, plug, lock.Path(), plug.Kind, plug.Name, plug.Version)
+			continue
+		}
+		if err := lock.VerifyChecksum(plug, platform, checksum); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckPluginLockVersions returns an error if any of the given plugins is locked, but not at the version it asks for,
+// unless the lock allows updates. The builtin provider and plugins that the project provides from a local path aren't
+// locked, so they're skipped.
+func CheckPluginLockVersions(
+	lock *workspace.PluginLock, plugins PluginSet, projectPlugins []workspace.ProjectPlugin,
+) error {
+	if lock == nil {
+		return nil
+	}
+	for _, plug := range plugins.Values() {
+		if plug.Name == "pulumi" && plug.Kind == apitype.ResourcePlugin || isProjectPlugin(plug, projectPlugins) {
+			continue
+		}
+		if err := lock.CheckVersion(plug); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadPluginLock loads the plugin lock of the project in the given root directory. It returns nil if there's no
+// project root, e.g. for inline programs.
+func loadPluginLock(root string, opts *deploymentOptions) (*workspace.PluginLock, error) {
+	if root == "" {
+		return nil, nil
+	}
+	lock, err := workspace.LoadPluginLock(root)
+	if err != nil {
+		return nil, err
+	}
+	if opts != nil && opts.UpdatePluginLock {
+		lock.AllowUpdates()
+	}
+	return lock, nil
+}
+
+// RecordPluginLock adds the given plugins, of any kind, to the plugin lock, along with the parameterizations of the
+// given packages. The builtin provider, plugins that are bundled with Pulumi and plugins that the project provides from
+// a local path are skipped.
+func RecordPluginLock(
+	lock *workspace.PluginLock, plugins PluginSet, packages PackageSet, projectPlugins []workspace.ProjectPlugin,
+) {
+	skip := func(plug workspace.PluginSpec) bool {
+		return plug.Name == "pulumi" && plug.Kind == apitype.ResourcePlugin ||
+			isProjectPlugin(plug, projectPlugins) || workspace.IsPluginBundled(plug.Kind, plug.Name)
+	}
+	for _, plug := range plugins.Values() {
+		if !skip(plug) {
+			lock.Record(workspace.PackageDescriptor{PluginSpec: plug})
+		}
+	}
+	for _, pkg := range packages.Values() {
+		if !skip(pkg.PluginSpec) {
+			lock.Record(pkg)
+		}
+	}
+}
+
+// lockablePackages returns the packages an update should record in the plugin lock: the program's packages, plus any
+// snapshot packages whose plugins the program doesn't ask for. Languages don't have to report the packages a program
+// uses, so the snapshot is the only record of some of them.
+func lockablePackages(programPackages, snapshotPackages PackageSet) PackageSet {
+	type pluginKey struct {
+		kind apitype.PluginKind
+		name string
+	}
+	programPlugins := map[pluginKey]bool{}
+	for _, pkg := range programPackages.Values() {
+		programPlugins[pluginKey{pkg.Kind, pkg.Name}] = true
+	}
+
+	result := NewPackageSet(programPackages.Values()...)
+	for _, pkg := range snapshotPackages.Values() {
+		if !programPlugins[pluginKey{pkg.Kind, pkg.Name}] {
+			result.Add(pkg)
+		}
+	}
+	return result
+}
+
+// LanguagePluginSpec returns the spec of the plugin for the given language runtime, or false if the plugin doesn't
+// report its version.
+func LanguagePluginSpec(lang plugin.LanguageRuntime) (workspace.PluginSpec, bool) {
+	info, err := lang.GetPluginInfo()
+	if err != nil || info.Version == nil {
+		return workspace.PluginSpec{}, false
+	}
+	return workspace.PluginSpec{Name: info.Name, Kind: info.Kind, Version: info.Version}, true
+}
+
+// isProjectPlugin returns true if the given plugin is provided from a local path by the project.
+func isProjectPlugin(plug workspace.PluginSpec, projectPlugins []workspace.ProjectPlugin) bool {
+	for _, p := range projectPlugins {
+		if p.Kind == plug.Kind && p.Name == plug.Name {
+			return true
+		}
+	}
+	return false
+}
+
 // ensurePluginsAreLoaded ensures that all of the plugins in the given plugin set that match the given plugin flags are
 // loaded.
 func ensurePluginsAreLoaded(plugctx *plugin.Context, plugins PluginSet, kinds plugin.Flags) error {
@@ -414,6 +589,8 @@ func installPlugin(
 	ctx context.Context,
 	opts *deploymentOptions,
 	plugin workspace.PluginSpec,
+	lock *workspace.PluginLock,
+	reinstall bool,
 ) error {
 	logging.V(preparePluginLog).Infof("installPlugin(%s, %s): beginning install", plugin.Name, plugin.Version)
 
@@ -475,6 +652,22 @@ func installPlugin(
 	}
 	defer func() { contract.IgnoreError(os.Remove(tarball.Name())) }()
 
+	// Check the archive against the plugin lock, if any, before installing it, and remember its checksum so that the
+	// installed plugin can be checked against plugin locks later.
+	checksum, err := workspace.FileChecksum(tarball)
+	if err != nil {
+		return fmt.Errorf("computing checksum of plugin %s: %w", plugin, err)
+	}
+	if lock != nil {
+		platform, err := workspace.PluginPlatform()
+		if err != nil {
+			return err
+		}
+		if err := lock.RecordChecksum(plugin, platform, checksum); err != nil {
+			return err
+		}
+	}
+
 	logging.V(preparePluginVerboseLog).Infof(
 		"installPlugin(%s, %s): extracting tarball to installation directory", plugin.Name, plugin.Version)
 
@@ -507,11 +700,14 @@ func installPlugin(
 	if err := plugin.InstallWithContext(
 		ctx,
 		workspace.TarPlugin(withInstallProgress(tarball)),
-		false,
+		reinstall,
 	); err != nil {
 		return fmt.Errorf("installing plugin; run `pulumi plugin install %s %s v%s` to retry manually: %w",
 			plugin.Kind, plugin.Name, plugin.Version, err)
 	}
+	if err := plugin.WriteInstalledChecksum(checksum); err != nil {
+		return fmt.Errorf("recording checksum of plugin %s: %w", plugin, err)
+	}
 
 	logging.V(7).Infof("installPlugin(%s, %s): installation complete", plugin.Name, plugin.Version)
 	return nil
@@ -15,7 +15,10 @@
 package engine
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"os"
 	"testing"
 
 	"github.com/blang/semver"
@@ -23,6 +26,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 )
@@ -620,3 +625,119 @@ func TestDefaultProviderPluginsSorting(t *testing.T) {
 		"foo": p2,
 	}, result)
 }
+
+func TestRecordPluginLock(t *testing.T) {
+	t.Parallel()
+
+	lock, err := workspace.LoadPluginLock(t.TempDir())
+	require.NoError(t, err)
+
+	v1 := mustMakeVersion("1.0.0")
+	plugins := NewPluginSet(
+		workspace.PluginSpec{Name: "pulumi", Kind: apitype.ResourcePlugin, Version: v1},
+		workspace.PluginSpec{Name: "nodejs", Kind: apitype.LanguagePlugin, Version: v1},
+		workspace.PluginSpec{Name: "kotlin", Kind: apitype.LanguagePlugin, Version: v1},
+		workspace.PluginSpec{Name: "local", Kind: apitype.ResourcePlugin, Version: v1},
+		workspace.PluginSpec{Name: "random", Kind: apitype.ResourcePlugin, Version: v1},
+	)
+	packages := NewPackageSet(workspace.PackageDescriptor{
+		PluginSpec:       workspace.PluginSpec{Name: "terraform-provider", Kind: apitype.ResourcePlugin, Version: v1},
+		Parameterization: &workspace.Parameterization{Name: "netlify", Version: *v1, Value: []byte("params")},
+	})
+	projectPlugins := []workspace.ProjectPlugin{{Name: "local", Kind: apitype.ResourcePlugin, Path: "./local"}}
+
+	RecordPluginLock(lock, plugins, packages, projectPlugins)
+
+	var recorded []string
+	for _, e := range lock.Plugins {
+		recorded = append(recorded, fmt.Sprintf("%s %s %s %d", e.Kind, e.Name, e.Server, len(e.Parameterizations)))
+	}
+	assert.Equal(t, []string{
+		"language kotlin github://api.github.com/pulumi 0",
+		"resource random github://api.github.com/pulumi 0",
+		"resource terraform-provider github://api.github.com/pulumi 1",
+	}, recorded)
+}
+
+func TestLockablePackages(t *testing.T) {
+	t.Parallel()
+
+	v1, v2 := mustMakeVersion("1.0.0"), mustMakeVersion("2.0.0")
+	random1 := workspace.PackageDescriptor{
+		PluginSpec: workspace.PluginSpec{Name: "random", Kind: apitype.ResourcePlugin, Version: v1},
+	}
+	random2 := workspace.PackageDescriptor{
+		PluginSpec: workspace.PluginSpec{Name: "random", Kind: apitype.ResourcePlugin, Version: v2},
+	}
+	aws := workspace.PackageDescriptor{
+		PluginSpec: workspace.PluginSpec{Name: "aws", Kind: apitype.ResourcePlugin, Version: v1},
+	}
+
+	// The snapshot's older version of random is dropped, but aws, which the program doesn't report, is kept.
+	result := lockablePackages(NewPackageSet(random2), NewPackageSet(random1, aws))
+	assert.ElementsMatch(t, []workspace.PackageDescriptor{random2, aws}, result.Values())
+}
+
+func TestCheckPluginLockVersions(t *testing.T) {
+	t.Parallel()
+
+	lock, err := workspace.LoadPluginLock(t.TempDir())
+	require.NoError(t, err)
+	lock.Record(workspace.PackageDescriptor{PluginSpec: workspace.PluginSpec{
+		Name: "random", Kind: apitype.ResourcePlugin, Version: mustMakeVersion("1.0.0"),
+	}})
+
+	random2 := workspace.PluginSpec{Name: "random", Kind: apitype.ResourcePlugin, Version: mustMakeVersion("2.0.0")}
+	err = CheckPluginLockVersions(lock, NewPluginSet(random2), nil)
+	assert.ErrorContains(t, err, "resource plugin random is locked to version 1.0.0")
+
+	// Plugins the project provides from a local path aren't locked.
+	projectPlugins := []workspace.ProjectPlugin{{Name: "random", Kind: apitype.ResourcePlugin, Path: "./random"}}
+	require.NoError(t, CheckPluginLockVersions(lock, NewPluginSet(random2), projectPlugins))
+
+	lock.AllowUpdates()
+	require.NoError(t, CheckPluginLockVersions(lock, NewPluginSet(random2), nil))
+	require.NoError(t, CheckPluginLockVersions(nil, NewPluginSet(random2), nil))
+}
+
+func TestVerifyPluginLock(t *testing.T) {
+	t.Parallel()
+
+	pluginDir := t.TempDir()
+	installed := workspace.PluginSpec{
+		Name: "random", Kind: apitype.ResourcePlugin, Version: mustMakeVersion("1.0.0"), PluginDir: pluginDir,
+	}
+	unknown := workspace.PluginSpec{
+		Name: "aws", Kind: apitype.ResourcePlugin, Version: mustMakeVersion("2.0.0"), PluginDir: pluginDir,
+	}
+	for _, spec := range []workspace.PluginSpec{installed, unknown} {
+		dir, err := spec.DirPath()
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(dir, 0o700))
+	}
+	require.NoError(t, installed.WriteInstalledChecksum([]byte{0xab}))
+
+	platform, err := workspace.PluginPlatform()
+	require.NoError(t, err)
+	lock, err := workspace.LoadPluginLock(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, lock.RecordChecksum(installed, platform, []byte{0xab}))
+	require.NoError(t, lock.RecordChecksum(unknown, platform, []byte{0xcd}))
+
+	var stderr bytes.Buffer
+	sink := diag.DefaultSink(io.Discard, &stderr, diag.FormatOptions{Color: colors.Never})
+	plugins := NewPluginSet(installed, unknown)
+
+	// Plugins installed from an unknown archive can't be checked, so they're only warned about.
+	require.NoError(t, VerifyPluginLock(sink, plugins, nil, lock))
+	assert.Contains(t, stderr.String(), "cannot check resource plugin aws-2.0.0")
+	assert.NotContains(t, stderr.String(), "random")
+
+	// Plugins installed from a different archive than the locked one are rejected.
+	require.NoError(t, installed.WriteInstalledChecksum([]byte{0xef}))
+	err = VerifyPluginLock(sink, plugins, nil, lock)
+	assert.ErrorContains(t, err, "checksum mismatch for resource plugin random-1.0.0")
+
+	// Without a lock there's nothing to check against.
+	require.NoError(t, VerifyPluginLock(sink, plugins, nil, nil))
+}
@@ -115,11 +115,25 @@ func newRefreshSource(
 		}
 	}
 
-	// Like Update, if we're missing plugins, attempt to download the missing plugins.
-	if err := EnsurePluginsAreInstalled(ctx, opts, plugctx.Diag, snapshotPackages.ToPluginSet().Deduplicate(),
-		plugctx.Host.GetProjectPlugins(), false /*reinstall*/, false /*explicitInstall*/); err != nil {
+	// Like Update, if we're missing plugins, attempt to download the missing plugins, honoring the plugin lock.
+	lock, err := loadPluginLock(plugctx.Root, opts)
+	if err != nil {
+		return nil, err
+	}
+	allPlugins := snapshotPackages.ToPluginSet().Deduplicate()
+	projectPlugins := plugctx.Host.GetProjectPlugins()
+	if err := EnsurePluginsAreInstalled(ctx, opts, plugctx.Diag, allPlugins,
+		projectPlugins, lock, false /*reinstall*/, false /*explicitInstall*/); err != nil {
 		logging.V(7).Infof("newRefreshSource(): failed to install missing plugins: %v", err)
 	}
+	if err := VerifyPluginLock(plugctx.Diag, allPlugins, projectPlugins, lock); err != nil {
+		return nil, err
+	}
+
+	// Refreshed and imported resources are checked against any policy packs, so load them now.
+	if err := loadPolicyPacks(plugctx, opts, proj, target); err != nil {
+		return nil, err
+	}
 
 	// Just return an error source. Refresh doesn't use its source.
 	return deploy.NewErrorSource(proj.Name), nil
@@ -195,11 +195,22 @@ type UpdateOptions struct {
 	// Autonamer can resolve user's preference for custom autonaming options for a given resource.
 	Autonamer autonaming.Autonamer
 
+	// RenameAliases maps the URNs of resources to old URNs that should be treated as aliases for them. This is used
+	// to apply the rename suggestions of a preview to the update that follows it.
+	RenameAliases map[resource.URN]resource.URN
+
 	// The execution kind of the operation.
 	ExecKind string
 
 	// ShowSecrets is true if the engine should display secrets in the CLI.
 	ShowSecrets bool
+
+	// WritePluginLock is true if the engine should record the plugins used by an update in the project's Pulumi.lock.
+	WritePluginLock bool
+
+	// UpdatePluginLock is true if the program may use plugins at versions other than the ones locked in the project's
+	// Pulumi.lock. The new versions replace the locked ones when the lock is written.
+	UpdatePluginLock bool
 }
 
 // HasChanges returns true if there are any non-same changes in the resulting summary.
@@ -290,13 +301,43 @@ func installPlugins(
 	// Note that this is purely a best-effort thing. If we can't install missing plugins, just proceed; we'll fail later
 	// with an error message indicating exactly what plugins are missing. If `returnInstallErrors` is set, then return
 	// the error.
+	//
+	// If the project has a plugin lock, plugins are installed at their locked versions and checked against the locked
+	// checksums, and any new plugins are added to the lock once the update has installed them. The program may only
+	// ask for other versions of locked plugins if the lock is being updated.
+	lock, err := loadPluginLock(plugctx.Root, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	projectPlugins := plugctx.Host.GetProjectPlugins()
+	if err := CheckPluginLockVersions(lock, languagePackages.ToPluginSet(), projectPlugins); err != nil {
+		return nil, nil, err
+	}
 	if err := EnsurePluginsAreInstalled(ctx, opts, plugctx.Diag, allPlugins,
-		plugctx.Host.GetProjectPlugins(), false /*reinstall*/, false /*explicitInstall*/); err != nil {
+		projectPlugins, lock, false /*reinstall*/, false /*explicitInstall*/); err != nil {
 		if returnInstallErrors {
 			return nil, nil, err
 		}
 		logging.V(7).Infof("newUpdateSource(): failed to install missing plugins: %v", err)
 	}
+	if err := VerifyPluginLock(plugctx.Diag, allPlugins, projectPlugins, lock); err != nil {
+		return nil, nil, err
+	}
+	if lock != nil && opts != nil && opts.WritePluginLock && !opts.DryRun {
+		// Versions of the program's plugins that only the snapshot still refers to aren't recorded, so that updating a
+		// plugin replaces its locked version rather than adding to it.
+		lockPackages := lockablePackages(languagePackages, snapshotPackages)
+		lockPlugins := lockPackages.ToPluginSet()
+		if lang, err := plugctx.Host.LanguageRuntime(runtime, programInfo); err == nil {
+			if spec, ok := LanguagePluginSpec(lang); ok {
+				lockPlugins.Add(spec)
+			}
+		}
+		RecordPluginLock(lock, lockPlugins, lockPackages, projectPlugins)
+		if err := lock.Save(); err != nil {
+			return nil, nil, fmt.Errorf("writing %s: %w", lock.Path(), err)
+		}
+	}
 
 	// Collect the version information for default providers.
 	defaultProviderVersions := computeDefaultProviderPackages(languagePackages, allPackages)
@@ -304,6 +345,26 @@ func installPlugins(
 	return allPlugins, defaultProviderVersions, nil
 }
 
+// loadPolicyPacks installs and loads the policy packs for a deployment of the given target, passing them the target's
+// decrypted configuration.
+func loadPolicyPacks(plugctx *plugin.Context, opts *deploymentOptions, proj *workspace.Project,
+	target *deploy.Target,
+) error {
+	config, err := target.Config.Decrypt(target.Decrypter)
+	if err != nil {
+		return err
+	}
+	analyzerOpts := &plugin.PolicyAnalyzerOptions{
+		Organization:     target.Organization.String(),
+		Project:          proj.Name.String(),
+		Stack:            target.Name.String(),
+		Config:           config,
+		ConfigSecretKeys: target.Config.SecureKeys(),
+		DryRun:           opts.DryRun,
+	}
+	return installAndLoadPolicyPlugins(plugctx, opts, analyzerOpts)
+}
+
 // installAndLoadPolicyPlugins loads and installs all requird policy plugins and packages as well as any
 // local policy packs. It returns fully populated metadata about those policy plugins.
 func installAndLoadPolicyPlugins(plugctx *plugin.Context,
@@ -488,20 +549,7 @@ func newUpdateSource(ctx context.Context,
 	// Step 2: Install and load policy plugins.
 	//
 
-	// Decrypt the configuration.
-	config, err := target.Config.Decrypt(target.Decrypter)
-	if err != nil {
-		return nil, err
-	}
-	analyzerOpts := &plugin.PolicyAnalyzerOptions{
-		Organization:     target.Organization.String(),
-		Project:          proj.Name.String(),
-		Stack:            target.Name.String(),
-		Config:           config,
-		ConfigSecretKeys: target.Config.SecureKeys(),
-		DryRun:           opts.DryRun,
-	}
-	if err := installAndLoadPolicyPlugins(plugctx, opts, analyzerOpts); err != nil {
+	if err := loadPolicyPacks(plugctx, opts, proj, target); err != nil {
 		return nil, err
 	}
 
@@ -745,6 +793,14 @@ func (acts *updateActions) OnResourceOutputs(step deploy.Step) error {
 	return acts.Context.SnapshotManager.RegisterResourceOutputs(step)
 }
 
+func (acts *updateActions) OnResourceStateChanged(step deploy.Step) error {
+	acts.MapLock.Lock()
+	assertSeen(acts.Seen, step)
+	acts.MapLock.Unlock()
+
+	return acts.Context.SnapshotManager.RegisterResourceOutputs(step)
+}
+
 func (acts *updateActions) OnPolicyViolation(urn resource.URN, d plugin.AnalyzeDiagnostic) {
 	acts.Opts.Events.policyViolationEvent(urn, d)
 }
@@ -884,6 +940,11 @@ func (acts *previewActions) OnResourceOutputs(step deploy.Step) error {
 	return nil
 }
 
+func (acts *previewActions) OnResourceStateChanged(step deploy.Step) error {
+	// Previews don't save state.
+	return nil
+}
+
 func (acts *previewActions) OnPolicyViolation(urn resource.URN, d plugin.AnalyzeDiagnostic) {
 	acts.Opts.Events.policyViolationEvent(urn, d)
 }
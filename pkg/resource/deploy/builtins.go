@@ -46,6 +46,9 @@ type builtinProvider struct {
 	news *gsync.Map[resource.URN, *resource.State]
 	// reads is a map of URNs to resource states that have been read during the current deployment.
 	reads *gsync.Map[resource.URN, *resource.State]
+	// onStackReferenceChanged is called after the state of a StackReference has been changed, so that the change can
+	// be saved.
+	onStackReferenceChanged func(urn resource.URN) error
 }
 
 func newBuiltinProvider(
@@ -222,6 +225,10 @@ func (p *builtinProvider) Read(_ context.Context, req plugin.ReadRequest) (plugi
 	if err != nil {
 		return plugin.ReadResponse{Status: resource.StatusUnknown}, err
 	}
+	// Keep the record of which outputs the program read, so that refreshing a stack reference doesn't lose it.
+	if readOutputs, ok := req.State[stackReferenceReadOutputs]; ok {
+		outputs[stackReferenceReadOutputs] = readOutputs
+	}
 
 	return plugin.ReadResponse{
 		ReadResult: plugin.ReadResult{
@@ -240,8 +247,13 @@ const (
 	readStackOutputs         = "pulumi:pulumi:readStackOutputs"
 	readStackResourceOutputs = "pulumi:pulumi:readStackResourceOutputs" //nolint:gosec // not a credential
 	getResource              = "pulumi:pulumi:getResource"
+	recordStackOutputReads   = "pulumi:pulumi:recordStackOutputReads"
 )
 
+// stackReferenceReadOutputs is the output property of a StackReference that records the names of the outputs of the
+// referenced stack that the program has read.
+const stackReferenceReadOutputs = "readOutputs"
+
 func (p *builtinProvider) Invoke(_ context.Context, req plugin.InvokeRequest) (plugin.InvokeResponse, error) {
 	var outs resource.PropertyMap
 	var err error
@@ -252,6 +264,8 @@ func (p *builtinProvider) Invoke(_ context.Context, req plugin.InvokeRequest) (p
 		outs, err = p.readStackResourceOutputs(req.Args)
 	case getResource:
 		outs, err = p.getResource(req.Args)
+	case recordStackOutputReads:
+		outs, err = p.recordStackOutputReads(req.Args)
 	default:
 		err = fmt.Errorf("unrecognized function name: '%v'", req.Tok)
 	}
@@ -340,6 +354,63 @@ func (p *builtinProvider) readStackResourceOutputs(inputs resource.PropertyMap)
 	}, nil
 }
 
+// recordStackOutputReads records that the program read the given outputs through the StackReference with the given
+// URN. The names are added to the StackReference's readOutputs property, and the StackReference's state is saved.
+func (p *builtinProvider) recordStackOutputReads(inputs resource.PropertyMap) (resource.PropertyMap, error) {
+	urnInput, ok := inputs["urn"]
+	contract.Assertf(ok, "missing required property 'urn'")
+	contract.Assertf(urnInput.IsString(), "expected 'urn' to be a string")
+	namesInput, ok := inputs["outputs"]
+	contract.Assertf(ok, "missing required property 'outputs'")
+	contract.Assertf(namesInput.IsArray(), "expected 'outputs' to be an array")
+
+	urn := resource.URN(urnInput.StringValue())
+	state, ok := p.reads.Load(urn)
+	if !ok {
+		state, ok = p.news.Load(urn)
+	}
+	if !ok || state.Type != stackReferenceType {
+		return nil, fmt.Errorf("unknown stack reference %v", urnInput.StringValue())
+	}
+
+	state.Lock.Lock()
+	names := map[string]bool{}
+	if v := state.Outputs[stackReferenceReadOutputs]; v.IsArray() {
+		for _, n := range v.ArrayValue() {
+			if n.IsString() {
+				names[n.StringValue()] = true
+			}
+		}
+	}
+	for _, n := range namesInput.ArrayValue() {
+		if n.IsString() {
+			names[n.StringValue()] = true
+		}
+	}
+	sorted := make([]string, 0, len(names))
+	for n := range names {
+		sorted = append(sorted, n)
+	}
+	sort.Strings(sorted)
+	readOutputs := make([]resource.PropertyValue, len(sorted))
+	for i, n := range sorted {
+		readOutputs[i] = resource.NewStringProperty(n)
+	}
+
+	// getResource hands out the outputs map without holding the lock, so replace it rather than modifying it in place.
+	outputs := state.Outputs.Copy()
+	outputs[stackReferenceReadOutputs] = resource.NewArrayProperty(readOutputs)
+	state.Outputs = outputs
+	state.Lock.Unlock()
+
+	if p.onStackReferenceChanged != nil {
+		if err := p.onStackReferenceChanged(urn); err != nil {
+			return nil, fmt.Errorf("saving stack output reads: %w", err)
+		}
+	}
+	return resource.PropertyMap{}, nil
+}
+
 func (p *builtinProvider) getResource(inputs resource.PropertyMap) (resource.PropertyMap, error) {
 	urnInput, ok := inputs["urn"]
 	contract.Assertf(ok, "missing required property 'urn'")
@@ -308,6 +308,78 @@ func TestBuiltinProvider(t *testing.T) {
 				assert.ErrorContains(t, err, "unknown resource")
 			})
 		})
+		t.Run(recordStackOutputReads, func(t *testing.T) {
+			t.Parallel()
+
+			t.Run("ok", func(t *testing.T) {
+				t.Parallel()
+
+				var changed []urn.URN
+				p := &builtinProvider{
+					news:  &gsync.Map[urn.URN, *resource.State]{},
+					reads: &gsync.Map[urn.URN, *resource.State]{},
+					onStackReferenceChanged: func(urn urn.URN) error {
+						changed = append(changed, urn)
+						return nil
+					},
+				}
+
+				outputs := resource.PropertyMap{
+					"name": resource.NewStringProperty("org/project/stack"),
+					"outputs": resource.NewObjectProperty(resource.PropertyMap{
+						"a": resource.NewStringProperty("1"),
+						"b": resource.NewStringProperty("2"),
+					}),
+				}
+				state := &resource.State{Type: stackReferenceType, Outputs: outputs}
+				p.reads.Store("ref", state)
+
+				record := func(names ...string) {
+					values := make([]resource.PropertyValue, len(names))
+					for i, n := range names {
+						values[i] = resource.NewStringProperty(n)
+					}
+					_, err := p.Invoke(context.Background(), plugin.InvokeRequest{
+						Tok: recordStackOutputReads,
+						Args: resource.PropertyMap{
+							"urn":     resource.NewStringProperty("ref"),
+							"outputs": resource.NewArrayProperty(values),
+						},
+					})
+					require.NoError(t, err)
+				}
+				record("b")
+				record("a", "b")
+
+				assert.Equal(t, resource.NewArrayProperty([]resource.PropertyValue{
+					resource.NewStringProperty("a"),
+					resource.NewStringProperty("b"),
+				}), state.Outputs[stackReferenceReadOutputs])
+				// Each change is saved.
+				assert.Equal(t, []urn.URN{"ref", "ref"}, changed)
+				// The outputs map that was handed out before the reads were recorded is left untouched.
+				assert.NotContains(t, outputs, resource.PropertyKey(stackReferenceReadOutputs))
+			})
+
+			t.Run("err", func(t *testing.T) {
+				t.Parallel()
+				p := &builtinProvider{
+					news:  &gsync.Map[urn.URN, *resource.State]{},
+					reads: &gsync.Map[urn.URN, *resource.State]{},
+				}
+				p.news.Store("res", &resource.State{Type: "pkg:index:Resource"})
+				for _, u := range []string{"res", "missing"} {
+					_, err := p.Invoke(context.Background(), plugin.InvokeRequest{
+						Tok: recordStackOutputReads,
+						Args: resource.PropertyMap{
+							"urn":     resource.NewStringProperty(u),
+							"outputs": resource.NewArrayProperty(nil),
+						},
+					})
+					assert.ErrorContains(t, err, "unknown stack reference")
+				}
+			})
+		})
 	})
 	t.Run("Call (unimplemented)", func(t *testing.T) {
 		t.Parallel()
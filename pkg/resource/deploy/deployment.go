@@ -43,8 +43,9 @@ import (
 // BackendClient is used to retrieve information about stacks from a backend.
 type BackendClient interface {
 	// GetStackOutputs returns the outputs (if any) for the named stack, returning an error if the stack cannot be found
-	// or loaded. If the stack contains secrets that cannot be decrypted, the onDecryptError callback will be called
-	// with the error. The callback should return a new error to be returned to the caller, or nil to ignore the error.
+	// or loaded. The name may refer to a stack in another backend using the form "<backend-url>#<stack>". If the stack
+	// contains secrets that cannot be decrypted, the onDecryptError callback will be called with the error. The
+	// callback should return a new error to be returned to the caller, or nil to ignore the error.
 	GetStackOutputs(
 		ctx context.Context,
 		name string,
@@ -104,6 +105,9 @@ type Options struct {
 	ContinueOnError bool
 	// Autonamer can resolve user's preference for custom autonaming options for a given resource.
 	Autonamer autonaming.Autonamer
+	// RenameAliases maps the URNs of resources to old URNs that should be treated as aliases for them, typically
+	// taken from the RenameSuggestions of a preview.
+	RenameAliases map[resource.URN]resource.URN
 }
 
 // DegreeOfParallelism returns the degree of parallelism that should be used during the
@@ -234,6 +238,10 @@ type StepExecutorEvents interface {
 	OnResourceStepPre(step Step) (interface{}, error)
 	OnResourceStepPost(ctx interface{}, step Step, status resource.Status, err error) error
 	OnResourceOutputs(step Step) error
+	// OnResourceStateChanged is called when the new state of a step that has already completed is changed by the
+	// deployment, e.g. when the outputs a program reads through a StackReference are recorded, so that the change can be
+	// saved. Unlike OnResourceOutputs, the change is not displayed.
+	OnResourceStateChanged(step Step) error
 }
 
 // PolicyEvents is an interface that can be used to hook policy events.
@@ -326,10 +334,14 @@ type Deployment struct {
 	newPlans *resourcePlans
 	// the set of resources read as part of the deployment
 	reads *gsync.Map[resource.URN, *resource.State]
+	// the steps that produced the StackReferences in news and reads, whose states change as the program reads outputs.
+	stackReferenceSteps *gsync.Map[resource.URN, Step]
 	// the resource status server.
 	resourceStatus *resourceStatusServer
 	// the resource hook registry for this deployment
 	resourceHooks *ResourceHooks
+	// resources that appear to have been renamed, detected once the deletes for the deployment are known.
+	renameSuggestions []RenameSuggestion
 }
 
 // addDefaultProviders adds any necessary default provider definitions and references to the given snapshot. Version
@@ -557,8 +569,10 @@ func NewDeployment(
 		news:                            newResources,
 		newPlans:                        newResourcePlan(target.Config),
 		reads:                           reads,
+		stackReferenceSteps:             &gsync.Map[resource.URN, Step]{},
 		resourceHooks:                   resourceHooks,
 	}
+	builtins.onStackReferenceChanged = deployment.onStackReferenceChanged
 
 	// Create a new resource status server for this deployment.
 	deployment.resourceStatus, err = newResourceStatusServer(deployment)
@@ -576,6 +590,20 @@ func (d *Deployment) Prev() *Snapshot                        { return d.prev }
 func (d *Deployment) Olds() map[resource.URN]*resource.State { return d.olds }
 func (d *Deployment) Source() Source                         { return d.source }
 
+// onStackReferenceChanged saves the state of the StackReference with the given URN after it has been changed by the
+// deployment.
+func (d *Deployment) onStackReferenceChanged(urn resource.URN) error {
+	step, ok := d.stackReferenceSteps.Load(urn)
+	if !ok || d.events == nil {
+		return nil
+	}
+	return d.events.OnResourceStateChanged(step)
+}
+
+// RenameSuggestions returns the resources created by the deployment that appear to be renames of resources it
+// deleted. It is only complete once the deployment has finished executing.
+func (d *Deployment) RenameSuggestions() []RenameSuggestion { return d.renameSuggestions }
+
 func (d *Deployment) SameProvider(res *resource.State) error {
 	var ctx context.Context
 	if d.ctx == nil {
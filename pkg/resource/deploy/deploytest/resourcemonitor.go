@@ -275,6 +275,8 @@ type ResourceOptions struct {
 
 	SupportsResultReporting bool
 	PackageRef              string
+	Expires                 string
+	Annotations             map[string]string
 }
 
 func (rm *ResourceMonitor) unmarshalProperties(props *structpb.Struct) (resource.PropertyMap, error) {
@@ -409,6 +411,8 @@ func (rm *ResourceMonitor) RegisterResource(t tokens.Type, name string, custom b
 		SupportsResultReporting:    opts.SupportsResultReporting,
 		PackageRef:                 opts.PackageRef,
 		Hooks:                      resourceHooks,
+		Expires:                    opts.Expires,
+		Annotations:                opts.Annotations,
 	}
 
 	ctx := context.Background()
@@ -220,7 +220,7 @@ func (i *importer) getOrCreateStackResource(ctx context.Context) (resource.URN,
 	typ, name := resource.RootStackType, fmt.Sprintf("%s-%s", projectName, stackName)
 	urn := resource.NewURN(stackName.Q(), projectName, "", typ, name)
 	state := resource.NewState(typ, urn, false, false, "", resource.PropertyMap{}, nil, "", false, false, nil, nil, "",
-		nil, false, nil, nil, nil, "", false, "", nil, nil, "", nil, nil, false, "", nil)
+		nil, false, nil, nil, nil, "", false, "", nil, nil, "", nil, nil, false, "", nil, nil, nil)
 	// TODO(seqnum) should stacks be created with 1? When do they ever get recreated/replaced?
 	if !i.executeSerial(ctx, NewCreateStep(i.deployment, noopEvent(0), state)) {
 		return "", false, false
@@ -329,7 +329,7 @@ func (i *importer) registerProviders(ctx context.Context) (map[resource.URN]stri
 		}
 
 		state := resource.NewState(typ, urn, true, false, "", inputs, nil, "", false, false, nil, nil, "", nil, false,
-			nil, nil, nil, "", false, "", nil, nil, "", nil, nil, false, "", nil)
+			nil, nil, nil, "", false, "", nil, nil, "", nil, nil, false, "", nil, nil, nil)
 		// TODO(seqnum) should default providers be created with 1? When do they ever get recreated/replaced?
 		if issueCheckErrors(i.deployment, state, urn, resp.Failures) {
 			return nil, false, nil
@@ -428,7 +428,7 @@ func (i *importer) importResources(ctx context.Context) error {
 		new := resource.NewState(
 			urn.Type(), urn, !imp.Component, false, "", resource.PropertyMap{}, nil, parent, imp.Protect,
 			false, nil, nil, provider, nil, false, nil, nil, nil, imp.ID, false, "", nil, nil, "", nil,
-			nil, false, "", nil)
+			nil, false, "", nil, nil, nil)
 		// Set a dummy goal so the resource is tracked as managed.
 		i.deployment.goals.Store(urn, &resource.Goal{})
 
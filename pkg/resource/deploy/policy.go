@@ -0,0 +1,127 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// analyzeObservedResource runs the deployment's analyzers against a resource state that was read from its provider by
+// a refresh or an import, rather than registered by a program. This surfaces resources that have drifted out of
+// compliance with policy outside of Pulumi.
+//
+// Unlike analysis during an update, the results never change the state or fail the operation: the state must reflect
+// what the provider returned. Instead, any remediation that would change the resource's properties is reported as a
+// remediate-level violation, which a follow-up update will apply; `pulumi refresh` offers to run that update for
+// the resources reported. Violations reported by Analyze for a policy that already reported a remediation are not
+// reported a second time. An error running an analyzer fails the step, as it would during an update.
+func (d *Deployment) analyzeObservedResource(state *resource.State) error {
+	analyzers := d.ctx.Host.ListAnalyzers()
+	if len(analyzers) == 0 || !state.Custom || providers.IsProviderType(state.Type) {
+		return nil
+	}
+
+	r := plugin.AnalyzerResource{
+		URN:        state.URN,
+		Type:       state.Type,
+		Name:       state.URN.Name(),
+		Properties: state.Inputs,
+		Options: plugin.AnalyzerResourceOptions{
+			Protect:                 state.Protect,
+			IgnoreChanges:           state.IgnoreChanges,
+			AdditionalSecretOutputs: state.AdditionalSecretOutputs,
+			Aliases:                 state.GetAliases(),
+			CustomTimeouts:          state.CustomTimeouts,
+			Parent:                  state.Parent,
+			Annotations:             state.Annotations,
+		},
+	}
+	if providerResource := d.observedProvider(state.Provider); providerResource != nil {
+		r.Provider = &plugin.AnalyzerProviderResource{
+			URN:        providerResource.URN,
+			Type:       providerResource.Type,
+			Name:       providerResource.URN.Name(),
+			Properties: providerResource.Inputs,
+		}
+	}
+
+	for _, analyzer := range analyzers {
+		remediated := map[string]bool{}
+		tresults, err := analyzer.Remediate(r)
+		if err != nil {
+			return fmt.Errorf("failed to run remediation: %w", err)
+		}
+		for _, tresult := range tresults {
+			violation := plugin.AnalyzeDiagnostic{
+				PolicyName:        tresult.PolicyName,
+				PolicyPackName:    tresult.PolicyPackName,
+				PolicyPackVersion: tresult.PolicyPackVersion,
+				Description:       tresult.Description,
+				URN:               state.URN,
+			}
+			if tresult.Diagnostic != "" {
+				// If there is a diagnostic, we have a warning to display.
+				violation.Message = tresult.Diagnostic
+				violation.EnforcementLevel = apitype.Advisory
+			} else if tresult.Properties != nil && !tresult.Properties.DeepEquals(state.Inputs) {
+				violation.Message = "This resource is out of compliance with this policy. " +
+					"Run an update to remediate it."
+				violation.EnforcementLevel = apitype.Remediate
+				remediated[tresult.PolicyName] = true
+			} else {
+				continue
+			}
+			d.events.OnPolicyViolation(state.URN, violation)
+		}
+
+		diagnostics, err := analyzer.Analyze(r)
+		if err != nil {
+			return fmt.Errorf("failed to run policy: %w", err)
+		}
+		for _, diagnostic := range diagnostics {
+			if remediated[diagnostic.PolicyName] {
+				continue
+			}
+			d.events.OnPolicyViolation(state.URN, diagnostic)
+		}
+	}
+
+	return nil
+}
+
+// observedProvider returns the state of the provider resource with the given reference, if it is known to this
+// deployment. Providers are looked up among the resources registered by this deployment, and then among the resources
+// in the base snapshot.
+func (d *Deployment) observedProvider(ref string) *resource.State {
+	if ref == "" {
+		return nil
+	}
+	providerRef, err := providers.ParseReference(ref)
+	contract.AssertNoErrorf(err, "failed to parse provider reference")
+
+	if state, ok := d.news.Load(providerRef.URN()); ok && state.ID == providerRef.ID() {
+		return state
+	}
+	if state, ok := d.olds[providerRef.URN()]; ok && state.ID == providerRef.ID() {
+		return state
+	}
+	return nil
+}
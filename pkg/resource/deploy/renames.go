@@ -0,0 +1,174 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"slices"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+// MinRenameSimilarity is the fraction of inputs that must be equal for a created and a deleted resource to be
+// considered a rename. A renamed resource usually differs from the resource it replaces only in an explicit name or
+// tag, so this allows one input in five to differ while still rejecting resources of the same type that merely share
+// a few common settings. A resource with fewer than five inputs must match on all of them. Since a suggestion can turn
+// a replacement into an in-place update when --alias-renames is used, the threshold errs on the side of missing
+// renames rather than suggesting false ones.
+const MinRenameSimilarity = 0.8
+
+// defaultsKey is the input under which providers built with the Terraform bridge record the names of the inputs they
+// filled in with default values, such as autonames. Those inputs are expected to differ between a resource and its
+// renamed replacement, so they are ignored when comparing inputs.
+const defaultsKey = resource.PropertyKey("__defaults")
+
+// A RenameSuggestion describes a resource that appears to have been renamed or reparented, because a resource of the
+// same type with identical or near-identical inputs is being deleted in the same deployment that it is created.
+type RenameSuggestion struct {
+	// The URN of the resource being created.
+	URN resource.URN
+	// The URN of the resource being deleted, which should be added as an alias of URN.
+	OldURN resource.URN
+	// The type of both resources.
+	Type tokens.Type
+	// The fraction of the inputs that are equal, between MinRenameSimilarity and 1.
+	Similarity float64
+	// The inputs that differ between the two resources.
+	ChangedInputs []resource.PropertyKey
+}
+
+// DetectRenames pairs resources being created with resources being deleted that look like the same resource under a
+// new URN. A created and a deleted resource are paired if they have the same type and their inputs are at least
+// MinRenameSimilarity similar, and if each is the other's only best match, so that ambiguous candidates are never
+// suggested. Provider resources are never paired, since new default providers routinely replace old ones with the
+// same inputs. Suggestions are returned in the order of the created resources.
+func DetectRenames(creates, deletes []*resource.State) []RenameSuggestion {
+	type match struct {
+		similarity float64
+		changed    []resource.PropertyKey
+	}
+
+	// Score every candidate pair.
+	scores := make([][]*match, len(creates))
+	for i, created := range creates {
+		scores[i] = make([]*match, len(deletes))
+		if !isRenameCandidate(created) {
+			continue
+		}
+		for j, deleted := range deletes {
+			if !isRenameCandidate(deleted) || created.Type != deleted.Type || created.URN == deleted.URN {
+				continue
+			}
+			similarity, changed, ok := inputSimilarity(deleted.Inputs, created.Inputs)
+			if !ok {
+				// Neither resource has any inputs to compare, which is common for components. Only pair them if
+				// they have the same name, i.e. if the resource has been reparented.
+				if created.URN.Name() != deleted.URN.Name() {
+					continue
+				}
+				similarity = 1
+			}
+			if similarity >= MinRenameSimilarity {
+				scores[i][j] = &match{similarity: similarity, changed: changed}
+			}
+		}
+	}
+
+	// uniqueBest returns the index of the best match in the given scores, or -1 if there is no match or the best
+	// match is tied.
+	uniqueBest := func(n int, score func(int) *match) int {
+		best, tied := -1, false
+		for k := 0; k < n; k++ {
+			m := score(k)
+			switch {
+			case m == nil:
+			case best == -1 || m.similarity > score(best).similarity:
+				best, tied = k, false
+			case m.similarity == score(best).similarity:
+				tied = true
+			}
+		}
+		if tied {
+			return -1
+		}
+		return best
+	}
+
+	var suggestions []RenameSuggestion
+	for i, created := range creates {
+		j := uniqueBest(len(deletes), func(j int) *match { return scores[i][j] })
+		if j == -1 {
+			continue
+		}
+		if uniqueBest(len(creates), func(i int) *match { return scores[i][j] }) != i {
+			continue
+		}
+		suggestions = append(suggestions, RenameSuggestion{
+			URN:           created.URN,
+			OldURN:        deletes[j].URN,
+			Type:          created.Type,
+			Similarity:    scores[i][j].similarity,
+			ChangedInputs: scores[i][j].changed,
+		})
+	}
+	return suggestions
+}
+
+// isRenameCandidate returns true if the given resource could be one side of a rename.
+func isRenameCandidate(res *resource.State) bool {
+	return !providers.IsProviderType(res.Type) && !res.External && res.Type != resource.RootStackType
+}
+
+// inputSimilarity returns the fraction of the inputs of two resources that are equal, along with the keys of the
+// inputs that differ. Internal inputs, and inputs that either provider filled in with defaults, are ignored. If there
+// are no inputs left to compare, ok is false.
+func inputSimilarity(olds, news resource.PropertyMap) (similarity float64, changed []resource.PropertyKey, ok bool) {
+	ignored := map[resource.PropertyKey]bool{}
+	for _, inputs := range []resource.PropertyMap{olds, news} {
+		if defaults, has := inputs[defaultsKey]; has && defaults.IsArray() {
+			for _, v := range defaults.ArrayValue() {
+				if v.IsString() {
+					ignored[resource.PropertyKey(v.StringValue())] = true
+				}
+			}
+		}
+	}
+
+	var keys []resource.PropertyKey
+	for _, inputs := range []resource.PropertyMap{olds, news} {
+		for k := range inputs {
+			if !resource.IsInternalPropertyKey(k) && !ignored[k] && !slices.Contains(keys, k) {
+				keys = append(keys, k)
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return 0, nil, false
+	}
+	slices.Sort(keys)
+
+	equal := 0
+	for _, k := range keys {
+		old, hasOld := olds[k]
+		new, hasNew := news[k]
+		if hasOld == hasNew && old.DeepEquals(new) {
+			equal++
+		} else {
+			changed = append(changed, k)
+		}
+	}
+	return float64(equal) / float64(len(keys)), changed, true
+}
@@ -0,0 +1,156 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+func newRenameTestState(parent tokens.Type, typ tokens.Type, name string, inputs resource.PropertyMap) *resource.State {
+	return &resource.State{
+		Type:   typ,
+		URN:    resource.NewURN("stack", "proj", parent, typ, name),
+		Custom: true,
+		Inputs: inputs,
+	}
+}
+
+func TestDetectRenamesIdenticalInputs(t *testing.T) {
+	t.Parallel()
+
+	inputs := resource.NewPropertyMapFromMap(map[string]interface{}{"size": 10, "region": "us-west-2"})
+	old := newRenameTestState("", "pkg:index:Bucket", "old", inputs)
+	created := newRenameTestState("", "pkg:index:Bucket", "new", inputs.Copy())
+
+	assert.Equal(t, []RenameSuggestion{{
+		URN:        created.URN,
+		OldURN:     old.URN,
+		Type:       "pkg:index:Bucket",
+		Similarity: 1,
+	}}, DetectRenames([]*resource.State{created}, []*resource.State{old}))
+}
+
+func TestDetectRenamesNearIdenticalInputs(t *testing.T) {
+	t.Parallel()
+
+	olds := resource.NewPropertyMapFromMap(map[string]interface{}{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5})
+	news := olds.Copy()
+	news["e"] = resource.NewNumberProperty(6)
+	old := newRenameTestState("", "pkg:index:Bucket", "old", olds)
+	created := newRenameTestState("", "pkg:index:Bucket", "new", news)
+
+	suggestions := DetectRenames([]*resource.State{created}, []*resource.State{old})
+	assert.Len(t, suggestions, 1)
+	assert.Equal(t, 0.8, suggestions[0].Similarity)
+	assert.Equal(t, []resource.PropertyKey{"e"}, suggestions[0].ChangedInputs)
+
+	// Changing a second input drops the similarity below the threshold.
+	news["d"] = resource.NewNumberProperty(7)
+	assert.Empty(t, DetectRenames([]*resource.State{created}, []*resource.State{old}))
+}
+
+func TestDetectRenamesIgnoresDefaults(t *testing.T) {
+	t.Parallel()
+
+	// The autonamed name differs, but the provider reports it as a default so it isn't compared.
+	defaults := resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("name")})
+	old := newRenameTestState("", "pkg:index:Bucket", "old", resource.PropertyMap{
+		"name":       resource.NewStringProperty("old-1234"),
+		"size":       resource.NewNumberProperty(10),
+		"__defaults": defaults,
+	})
+	created := newRenameTestState("", "pkg:index:Bucket", "new", resource.PropertyMap{
+		"name":       resource.NewStringProperty("new-5678"),
+		"size":       resource.NewNumberProperty(10),
+		"__defaults": defaults,
+	})
+
+	suggestions := DetectRenames([]*resource.State{created}, []*resource.State{old})
+	assert.Len(t, suggestions, 1)
+	assert.Equal(t, float64(1), suggestions[0].Similarity)
+}
+
+func TestDetectRenamesRequiresSameType(t *testing.T) {
+	t.Parallel()
+
+	inputs := resource.NewPropertyMapFromMap(map[string]interface{}{"size": 10})
+	old := newRenameTestState("", "pkg:index:Bucket", "old", inputs)
+	created := newRenameTestState("", "pkg:index:Queue", "new", inputs)
+
+	assert.Empty(t, DetectRenames([]*resource.State{created}, []*resource.State{old}))
+}
+
+func TestDetectRenamesSkipsAmbiguousMatches(t *testing.T) {
+	t.Parallel()
+
+	inputs := resource.NewPropertyMapFromMap(map[string]interface{}{"size": 10})
+	oldA := newRenameTestState("", "pkg:index:Bucket", "a", inputs)
+	oldB := newRenameTestState("", "pkg:index:Bucket", "b", inputs)
+	created := newRenameTestState("", "pkg:index:Bucket", "c", inputs)
+
+	// Two deleted resources match equally well, so neither is suggested.
+	assert.Empty(t, DetectRenames([]*resource.State{created}, []*resource.State{oldA, oldB}))
+
+	// Likewise if two created resources match the same deleted resource.
+	createdB := newRenameTestState("", "pkg:index:Bucket", "d", inputs)
+	assert.Empty(t, DetectRenames([]*resource.State{created, createdB}, []*resource.State{oldA}))
+}
+
+func TestDetectRenamesPrefersBestMatch(t *testing.T) {
+	t.Parallel()
+
+	olds := resource.NewPropertyMapFromMap(map[string]interface{}{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5})
+	near := olds.Copy()
+	near["e"] = resource.NewNumberProperty(6)
+	oldExact := newRenameTestState("", "pkg:index:Bucket", "exact", olds)
+	oldNear := newRenameTestState("", "pkg:index:Bucket", "near", near)
+	created := newRenameTestState("", "pkg:index:Bucket", "new", olds.Copy())
+
+	suggestions := DetectRenames([]*resource.State{created}, []*resource.State{oldNear, oldExact})
+	assert.Len(t, suggestions, 1)
+	assert.Equal(t, oldExact.URN, suggestions[0].OldURN)
+}
+
+func TestDetectRenamesSkipsProviders(t *testing.T) {
+	t.Parallel()
+
+	inputs := resource.NewPropertyMapFromMap(map[string]interface{}{"region": "us-west-2"})
+	old := newRenameTestState("", "pulumi:providers:aws", "default", inputs)
+	created := newRenameTestState("", "pulumi:providers:aws", "default_6_0_0", inputs)
+
+	assert.Empty(t, DetectRenames([]*resource.State{created}, []*resource.State{old}))
+}
+
+func TestDetectRenamesReparentedComponent(t *testing.T) {
+	t.Parallel()
+
+	// Components have no inputs, so they are only paired if their names match.
+	old := newRenameTestState("", "my:index:Component", "comp", nil)
+	old.Custom = false
+	moved := newRenameTestState("my:index:Parent", "my:index:Component", "comp", nil)
+	moved.Custom = false
+	other := newRenameTestState("my:index:Parent", "my:index:Component", "other", nil)
+	other.Custom = false
+
+	suggestions := DetectRenames([]*resource.State{other, moved}, []*resource.State{old})
+	assert.Len(t, suggestions, 1)
+	assert.Equal(t, moved.URN, suggestions[0].URN)
+	assert.Equal(t, old.URN, suggestions[0].OldURN)
+}
@@ -556,6 +556,296 @@ func (snap *Snapshot) VerifyIntegrity() error {
 	return nil
 }
 
+// SnapshotIssueKind classifies the problems that Verify can find in a snapshot.
+type SnapshotIssueKind string
+
+const (
+	// The snapshot's magic cookie doesn't match its manifest.
+	SnapshotIssueMagicMismatch SnapshotIssueKind = "magic-mismatch"
+	// A provider resource can't be referenced, or a resource's provider reference can't be parsed.
+	SnapshotIssueInvalidProviderReference SnapshotIssueKind = "invalid-provider-reference"
+	// A resource refers to a provider that isn't in the snapshot.
+	SnapshotIssueUnknownProvider SnapshotIssueKind = "unknown-provider"
+	// A resource that isn't pending deletion refers to a provider that is.
+	SnapshotIssueDeletedProvider SnapshotIssueKind = "deleted-provider"
+	// A resource's parent, dependency, property dependency or deleted-with resource isn't in the snapshot.
+	SnapshotIssueDanglingReference SnapshotIssueKind = "dangling-reference"
+	// A resource's provider, parent or dependency appears after it in the snapshot.
+	SnapshotIssueOutOfOrder SnapshotIssueKind = "out-of-order"
+	// More than one resource with the same URN is not pending deletion.
+	SnapshotIssueDuplicateURN SnapshotIssueKind = "duplicate-urn"
+	// A pending update or delete refers to a resource that isn't in the snapshot.
+	SnapshotIssueOrphanedPendingOperation SnapshotIssueKind = "orphaned-pending-operation"
+	// A secret in the snapshot could not be decrypted. Verify never reports this kind of issue, since by the time a
+	// snapshot has been deserialized its secrets have been decrypted; it is reported by the code that deserializes it.
+	SnapshotIssueUndecryptableSecret SnapshotIssueKind = "undecryptable-secret"
+)
+
+// A SnapshotIssue describes a single problem found in a snapshot.
+type SnapshotIssue struct {
+	// The kind of problem.
+	Kind SnapshotIssueKind
+	// The URN of the resource or pending operation with the problem, if any.
+	URN resource.URN
+	// A human-readable description of the problem.
+	Message string
+}
+
+func (i SnapshotIssue) String() string {
+	return fmt.Sprintf("[%s] %s", i.Kind, i.Message)
+}
+
+// Verify checks a snapshot for the same problems as VerifyIntegrity, but rather than stopping at the first one it
+// returns every problem it finds. It additionally reports resources that refer to providers which are pending
+// deletion, and pending operations that refer to resources which are no longer in the snapshot, neither of which
+// VerifyIntegrity treats as errors. A snapshot for which Verify returns no issues also passes VerifyIntegrity.
+func (snap *Snapshot) Verify() []SnapshotIssue {
+	if snap == nil {
+		return nil
+	}
+
+	var issues []SnapshotIssue
+	report := func(kind SnapshotIssueKind, urn resource.URN, format string, args ...interface{}) {
+		issues = append(issues, SnapshotIssue{Kind: kind, URN: urn, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if snap.Manifest.Magic != snap.Manifest.NewMagic() {
+		report(SnapshotIssueMagicMismatch, "", "magic cookie mismatch; possible tampering/corruption detected")
+	}
+
+	// Unlike VerifyIntegrity, we want to distinguish references to resources that appear later in the snapshot from
+	// references to resources that are missing entirely, so we index the whole snapshot up front.
+	present := make(map[resource.URN]bool, len(snap.Resources))
+	providerDeleted := make(map[providers.Reference]bool)
+	for _, state := range snap.Resources {
+		present[state.URN] = true
+		if providers.IsProviderType(state.Type) {
+			if ref, err := providers.NewReference(state.URN, state.ID); err == nil {
+				// A provider only counts as deleted if every state with its reference is pending deletion.
+				deleted, has := providerDeleted[ref]
+				providerDeleted[ref] = state.Delete && (!has || deleted)
+			}
+		}
+	}
+
+	urns := make(map[resource.URN]bool, len(snap.Resources))
+	provs := make(map[providers.Reference]bool)
+	for _, state := range snap.Resources {
+		urn := state.URN
+
+		if providers.IsProviderType(state.Type) {
+			ref, err := providers.NewReference(urn, state.ID)
+			if err != nil {
+				report(SnapshotIssueInvalidProviderReference, urn, "provider %s is not referenceable: %v", urn, err)
+			} else {
+				provs[ref] = true
+			}
+		}
+
+		provider, allDeps := state.GetAllDependencies()
+		if provider != "" {
+			ref, err := providers.ParseReference(provider)
+			switch {
+			case err != nil:
+				report(SnapshotIssueInvalidProviderReference, urn,
+					"failed to parse provider reference for resource %s: %v", urn, err)
+			case !provs[ref] && !state.PendingReplacement:
+				if _, has := providerDeleted[ref]; has {
+					report(SnapshotIssueOutOfOrder, urn, "resource %s's provider %s comes after it", urn, ref)
+				} else {
+					report(SnapshotIssueUnknownProvider, urn, "resource %s refers to unknown provider %s", urn, ref)
+				}
+			case providerDeleted[ref] && !state.Delete:
+				report(SnapshotIssueDeletedProvider, urn,
+					"resource %s refers to provider %s, which is pending deletion", urn, ref)
+			}
+		}
+
+		for _, dep := range allDeps {
+			if urns[dep.URN] {
+				continue
+			}
+
+			var what string
+			switch dep.Type {
+			case resource.ResourceParent:
+				what = "parent"
+			case resource.ResourceDependency:
+				what = "dependency"
+			case resource.ResourcePropertyDependency:
+				what = fmt.Sprintf("property dependency (from property %s)", dep.Key)
+			case resource.ResourceDeletedWith:
+				what = "deleted-with resource"
+			}
+
+			if present[dep.URN] {
+				report(SnapshotIssueOutOfOrder, urn, "resource %s's %s %s comes after it", urn, what, dep.URN)
+			} else {
+				report(SnapshotIssueDanglingReference, urn, "resource %s's %s %s is missing", urn, what, dep.URN)
+			}
+		}
+
+		if urns[urn] && !state.Delete {
+			report(SnapshotIssueDuplicateURN, urn, "duplicate resource %s (not marked for deletion)", urn)
+		}
+		urns[urn] = true
+	}
+
+	for _, op := range snap.PendingOperations {
+		if isOrphanedPendingOperation(op, present) {
+			report(SnapshotIssueOrphanedPendingOperation, op.Resource.URN,
+				"pending %s operation refers to missing resource %s", op.Type, op.Resource.URN)
+		}
+	}
+
+	return issues
+}
+
+// isOrphanedPendingOperation returns true if the given pending operation can no longer be resolved against the
+// resources in a snapshot. Pending creates, reads and imports are expected to refer to resources that aren't yet in the
+// snapshot, but pending updates and deletes should always refer to a resource that is.
+func isOrphanedPendingOperation(op resource.Operation, present map[resource.URN]bool) bool {
+	if op.Type != resource.OperationTypeUpdating && op.Type != resource.OperationTypeDeleting {
+		return false
+	}
+	return op.Resource == nil || !present[op.Resource.URN]
+}
+
+// RemoveDuplicateResources removes resources that share a URN with a later resource that is not pending deletion,
+// keeping only the most recently written state for each URN. It returns the states that were removed. Resources that
+// are pending deletion are never removed, since duplicates of those are expected in the middle of replacements.
+func (snap *Snapshot) RemoveDuplicateResources() []*resource.State {
+	last := map[resource.URN]*resource.State{}
+	for _, state := range snap.Resources {
+		if !state.Delete {
+			last[state.URN] = state
+		}
+	}
+
+	var kept, removed []*resource.State
+	for _, state := range snap.Resources {
+		if !state.Delete && last[state.URN] != state {
+			removed = append(removed, state)
+			continue
+		}
+		kept = append(kept, state)
+	}
+
+	if len(removed) > 0 {
+		snap.Resources = kept
+	}
+	return removed
+}
+
+// RemoveOrphanedPendingOperations removes pending updates and deletes that refer to resources which are no longer in
+// the snapshot, returning the operations that were removed.
+func (snap *Snapshot) RemoveOrphanedPendingOperations() []resource.Operation {
+	present := make(map[resource.URN]bool, len(snap.Resources))
+	for _, state := range snap.Resources {
+		present[state.URN] = true
+	}
+
+	var kept, removed []resource.Operation
+	for _, op := range snap.PendingOperations {
+		if isOrphanedPendingOperation(op, present) {
+			removed = append(removed, op)
+			continue
+		}
+		kept = append(kept, op)
+	}
+
+	if len(removed) > 0 {
+		snap.PendingOperations = kept
+	}
+	return removed
+}
+
+// A ProviderRepairResult describes a provider reference rewritten by RepairProviderReferences.
+type ProviderRepairResult struct {
+	// The URN of the resource whose provider reference was rewritten.
+	URN resource.URN
+	// True if and only if the resource is pending deletion.
+	Delete bool
+	// The provider reference before it was rewritten.
+	OldProvider string
+	// The provider reference after it was rewritten.
+	NewProvider string
+}
+
+// RepairProviderReferences fixes resources that refer to providers which are missing or pending deletion. If a
+// provider with the same URN that is not pending deletion exists (for instance because the provider was replaced but
+// the update was interrupted before its dependents were updated), such references are rewritten to point to it.
+// Otherwise, a provider that is pending deletion but still referenced by resources that are not is restored by clearing
+// its Delete flag. RepairProviderReferences returns the rewritten references and the restored providers. References
+// to missing providers with no replacement are left as they are, since resources must have a provider; VerifyIntegrity
+// will continue to report them.
+func (snap *Snapshot) RepairProviderReferences() ([]ProviderRepairResult, []*resource.State) {
+	live := map[resource.URN]*resource.State{}
+	deleted := map[providers.Reference]*resource.State{}
+	for _, state := range snap.Resources {
+		if !providers.IsProviderType(state.Type) {
+			continue
+		}
+		ref, err := providers.NewReference(state.URN, state.ID)
+		if err != nil {
+			continue
+		}
+		if state.Delete {
+			deleted[ref] = state
+		} else {
+			live[state.URN] = state
+		}
+	}
+
+	var results []ProviderRepairResult
+	restored := map[*resource.State]bool{}
+	var restoredList []*resource.State
+	for _, state := range snap.Resources {
+		if state.Provider == "" || state.Delete {
+			continue
+		}
+		ref, err := providers.ParseReference(state.Provider)
+		if err != nil {
+			continue
+		}
+
+		provider, isLive := live[ref.URN()]
+		switch {
+		case isLive && provider.ID == ref.ID():
+			// The reference is fine.
+		case isLive:
+			newRef, err := providers.NewReference(provider.URN, provider.ID)
+			contract.AssertNoErrorf(err, "live provider %s should be referenceable", provider.URN)
+
+			func() {
+				state.Lock.Lock()
+				defer state.Lock.Unlock()
+
+				results = append(results, ProviderRepairResult{
+					URN:         state.URN,
+					Delete:      state.Delete,
+					OldProvider: state.Provider,
+					NewProvider: newRef.String(),
+				})
+				state.Provider = newRef.String()
+			}()
+		case deleted[ref] != nil:
+			// If there are several copies of the provider pending deletion, we restore only the last one, so as not to
+			// introduce duplicate URNs.
+			if provider := deleted[ref]; !restored[provider] {
+				restored[provider] = true
+				restoredList = append(restoredList, provider)
+
+				provider.Lock.Lock()
+				provider.Delete = false
+				provider.Lock.Unlock()
+			}
+		}
+	}
+
+	return results, restoredList
+}
+
 // Applies a non-mutating modification for every resource.State in the
 // Snapshot, returns the edited Snapshot.
 func (snap *Snapshot) withUpdatedResources(update func(*resource.State) *resource.State) *Snapshot {
@@ -1125,3 +1125,183 @@ func TestSnapshotToposort_DetectsCycles(t *testing.T) {
 		})
 	}
 }
+
+func TestSnapshotVerify_ReportsAllIssues(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	snap := &Snapshot{
+		Resources: []*resource.State{
+			{
+				URN:      "urn:pulumi:stack::project::t::a",
+				Provider: "urn:pulumi:stack::project::pulumi:providers:p::missing::id",
+			},
+			{
+				URN:          "urn:pulumi:stack::project::t::b",
+				Dependencies: []resource.URN{"urn:pulumi:stack::project::t::c", "urn:pulumi:stack::project::t::gone"},
+			},
+			{URN: "urn:pulumi:stack::project::t::c"},
+			{URN: "urn:pulumi:stack::project::t::c"},
+			{
+				Type:   "pulumi:providers:p",
+				URN:    "urn:pulumi:stack::project::pulumi:providers:p::old",
+				ID:     "id",
+				Delete: true,
+			},
+			{
+				URN:      "urn:pulumi:stack::project::t::d",
+				Provider: "urn:pulumi:stack::project::pulumi:providers:p::old::id",
+			},
+		},
+		PendingOperations: []resource.Operation{
+			resource.NewOperation(&resource.State{URN: "urn:pulumi:stack::project::t::gone"}, resource.OperationTypeDeleting),
+			resource.NewOperation(&resource.State{URN: "urn:pulumi:stack::project::t::new"}, resource.OperationTypeCreating),
+		},
+	}
+
+	// Act.
+	issues := snap.Verify()
+
+	// Assert.
+	kinds := make([]SnapshotIssueKind, len(issues))
+	for i, issue := range issues {
+		kinds[i] = issue.Kind
+	}
+	assert.Equal(t, []SnapshotIssueKind{
+		SnapshotIssueUnknownProvider,
+		SnapshotIssueOutOfOrder,
+		SnapshotIssueDanglingReference,
+		SnapshotIssueDuplicateURN,
+		SnapshotIssueDeletedProvider,
+		SnapshotIssueOrphanedPendingOperation,
+	}, kinds)
+	assert.Equal(t, resource.URN("urn:pulumi:stack::project::t::gone"), issues[5].URN)
+	assert.Error(t, snap.VerifyIntegrity())
+}
+
+func TestSnapshotVerify_PreservesValidSnapshots(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	snap := &Snapshot{
+		Resources: []*resource.State{
+			{
+				Type: "pulumi:providers:p",
+				URN:  "urn:pulumi:stack::project::pulumi:providers:p::a",
+				ID:   "id",
+			},
+			{
+				URN:      "urn:pulumi:stack::project::t::b",
+				Provider: "urn:pulumi:stack::project::pulumi:providers:p::a::id",
+			},
+			{
+				URN:    "urn:pulumi:stack::project::t::b",
+				Delete: true,
+			},
+		},
+		PendingOperations: []resource.Operation{
+			resource.NewOperation(&resource.State{URN: "urn:pulumi:stack::project::t::b"}, resource.OperationTypeUpdating),
+		},
+	}
+
+	// Act.
+	issues := snap.Verify()
+
+	// Assert.
+	assert.Empty(t, issues)
+	assert.NoError(t, snap.VerifyIntegrity())
+}
+
+func TestSnapshotRemoveDuplicateResources(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	first := &resource.State{URN: "urn:pulumi:stack::project::t::a", ID: "1"}
+	deleted := &resource.State{URN: "urn:pulumi:stack::project::t::a", ID: "0", Delete: true}
+	second := &resource.State{URN: "urn:pulumi:stack::project::t::a", ID: "2"}
+	other := &resource.State{URN: "urn:pulumi:stack::project::t::b"}
+	snap := &Snapshot{Resources: []*resource.State{first, other, second, deleted}}
+
+	// Act.
+	removed := snap.RemoveDuplicateResources()
+
+	// Assert.
+	assert.Equal(t, []*resource.State{first}, removed)
+	assert.Equal(t, []*resource.State{other, second, deleted}, snap.Resources)
+}
+
+func TestSnapshotRemoveOrphanedPendingOperations(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	orphan := resource.NewOperation(
+		&resource.State{URN: "urn:pulumi:stack::project::t::gone"}, resource.OperationTypeUpdating)
+	update := resource.NewOperation(
+		&resource.State{URN: "urn:pulumi:stack::project::t::a"}, resource.OperationTypeUpdating)
+	create := resource.NewOperation(
+		&resource.State{URN: "urn:pulumi:stack::project::t::new"}, resource.OperationTypeCreating)
+	snap := &Snapshot{
+		Resources:         []*resource.State{{URN: "urn:pulumi:stack::project::t::a"}},
+		PendingOperations: []resource.Operation{orphan, update, create},
+	}
+
+	// Act.
+	removed := snap.RemoveOrphanedPendingOperations()
+
+	// Assert.
+	assert.Equal(t, []resource.Operation{orphan}, removed)
+	assert.Equal(t, []resource.Operation{update, create}, snap.PendingOperations)
+}
+
+func TestSnapshotRepairProviderReferences(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	replaced := &resource.State{
+		Type:   "pulumi:providers:p",
+		URN:    "urn:pulumi:stack::project::pulumi:providers:p::a",
+		ID:     "old",
+		Delete: true,
+	}
+	replacement := &resource.State{
+		Type: "pulumi:providers:p",
+		URN:  "urn:pulumi:stack::project::pulumi:providers:p::a",
+		ID:   "new",
+	}
+	deleted := &resource.State{
+		Type:   "pulumi:providers:p",
+		URN:    "urn:pulumi:stack::project::pulumi:providers:p::b",
+		ID:     "id",
+		Delete: true,
+	}
+	usesReplaced := &resource.State{
+		URN:      "urn:pulumi:stack::project::t::x",
+		Provider: "urn:pulumi:stack::project::pulumi:providers:p::a::old",
+	}
+	usesDeleted := &resource.State{
+		URN:      "urn:pulumi:stack::project::t::y",
+		Provider: "urn:pulumi:stack::project::pulumi:providers:p::b::id",
+	}
+	usesMissing := &resource.State{
+		URN:      "urn:pulumi:stack::project::t::z",
+		Provider: "urn:pulumi:stack::project::pulumi:providers:p::c::id",
+	}
+	snap := &Snapshot{
+		Resources: []*resource.State{replacement, deleted, usesReplaced, usesDeleted, usesMissing, replaced},
+	}
+
+	// Act.
+	results, restored := snap.RepairProviderReferences()
+
+	// Assert.
+	assert.Equal(t, []ProviderRepairResult{{
+		URN:         usesReplaced.URN,
+		OldProvider: "urn:pulumi:stack::project::pulumi:providers:p::a::old",
+		NewProvider: "urn:pulumi:stack::project::pulumi:providers:p::a::new",
+	}}, results)
+	assert.Equal(t, "urn:pulumi:stack::project::pulumi:providers:p::a::new", usesReplaced.Provider)
+	assert.Equal(t, []*resource.State{deleted}, restored)
+	assert.False(t, deleted.Delete)
+	assert.True(t, replaced.Delete)
+	assert.Equal(t, "urn:pulumi:stack::project::pulumi:providers:p::c::id", usesMissing.Provider)
+}
@@ -1746,6 +1746,25 @@ func (rm *resmon) RegisterResourceHook(ctx context.Context, req *pulumirpc.Regis
 	return nil, err
 }
 
+// defaultExpiry returns the stack-wide `pulumi:expires` setting, or the empty string if it isn't set.
+func (rm *resmon) defaultExpiry() (string, error) {
+	if rm.defaultProviders == nil || rm.defaultProviders.config == nil {
+		return "", nil
+	}
+	pConfig, err := rm.defaultProviders.config.GetPackageConfig("pulumi")
+	if err != nil {
+		return "", err
+	}
+	value, ok := pConfig["expires"]
+	if !ok {
+		return "", nil
+	}
+	if !value.IsString() {
+		return "", errors.New("pulumi:expires must be a string")
+	}
+	return value.StringValue(), nil
+}
+
 // inheritFromParent returns a new goal that inherits from the given parent goal.
 // Currently only inherits DeletedWith, Protect, and RetainOnDelete from parent.
 func inheritFromParent(child resource.Goal, parent resource.Goal) *resource.Goal {
@@ -2350,6 +2369,25 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 	}
 	customTimeouts := opts.CustomTimeouts
 
+	// Resources without an explicit expiry pick up the stack's `pulumi:expires` setting, if any. Providers and the
+	// stack itself never expire, as destroying them would take everything else with them.
+	expires := req.GetExpires()
+	if expires == "" && !providers.IsProviderType(t) && t != resource.RootStackType {
+		expires, err = rm.defaultExpiry()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if expires != "" {
+		if _, err := resource.ParseExpiry(expires, time.Now()); err != nil {
+			return nil, rpcerror.New(codes.InvalidArgument, err.Error())
+		}
+	}
+	annotations := req.GetAnnotations()
+	if _, has := annotations[""]; has {
+		return nil, rpcerror.New(codes.InvalidArgument, "annotation keys must not be empty")
+	}
+
 	additionalSecretOutputs := opts.GetAdditionalSecretOutputs()
 
 	// Grab the names for all of the hooks of a given type.
@@ -2539,6 +2577,8 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 			additionalSecretKeys, parsedAliases, id, &timeouts, replaceOnChanges, retainOnDelete, deletedWith,
 			sourcePosition, resourceHooks,
 		)
+		goal.Expires = expires
+		goal.Annotations = annotations
 
 		if goal.Parent != "" {
 			rm.resGoalsLock.Lock()
@@ -182,7 +182,7 @@ func fixedProgram(steps []RegisterResourceEvent) deploytest.ProgramFunc {
 			s.Done(&RegisterResult{
 				State: resource.NewState(g.Type, resp.URN, g.Custom, false, resp.ID, g.Properties, resp.Outputs, g.Parent,
 					protect, false, g.Dependencies, nil, g.Provider, g.PropertyDependencies, false, nil, nil, nil,
-					"", false, "", nil, nil, "", nil, nil, false, "", nil),
+					"", false, "", nil, nil, "", nil, nil, false, "", nil, nil, nil),
 			})
 		}
 		return nil
@@ -361,7 +361,7 @@ func TestRegisterNoDefaultProviders(t *testing.T) {
 		reg.Done(&RegisterResult{
 			State: resource.NewState(goal.Type, urn, goal.Custom, false, id, goal.Properties, resource.PropertyMap{},
 				goal.Parent, protect, false, goal.Dependencies, nil, goal.Provider, goal.PropertyDependencies,
-				false, nil, nil, nil, "", false, "", nil, nil, "", nil, nil, false, "", nil),
+				false, nil, nil, nil, "", false, "", nil, nil, "", nil, nil, false, "", nil, nil, nil),
 		})
 
 		processed++
@@ -468,7 +468,7 @@ func TestRegisterDefaultProviders(t *testing.T) {
 		reg.Done(&RegisterResult{
 			State: resource.NewState(goal.Type, urn, goal.Custom, false, id, goal.Properties, resource.PropertyMap{},
 				goal.Parent, protect, false, goal.Dependencies, nil, goal.Provider, goal.PropertyDependencies,
-				false, nil, nil, nil, "", false, "", nil, nil, "", nil, nil, false, "", nil),
+				false, nil, nil, nil, "", false, "", nil, nil, "", nil, nil, false, "", nil, nil, nil),
 		})
 
 		processed++
@@ -563,7 +563,7 @@ func TestReadInvokeNoDefaultProviders(t *testing.T) {
 		read.Done(&ReadResult{
 			State: resource.NewState(read.Type(), urn, true, false, read.ID(), read.Properties(),
 				resource.PropertyMap{}, read.Parent(), false, false, read.Dependencies(), nil, read.Provider(), nil,
-				false, nil, nil, nil, "", false, "", nil, nil, "", nil, nil, false, "", nil),
+				false, nil, nil, nil, "", false, "", nil, nil, "", nil, nil, false, "", nil, nil, nil),
 		})
 		reads++
 	}
@@ -658,7 +658,7 @@ func TestReadInvokeDefaultProviders(t *testing.T) {
 			e.Done(&RegisterResult{
 				State: resource.NewState(goal.Type, urn, goal.Custom, false, id, goal.Properties, resource.PropertyMap{},
 					goal.Parent, protect, false, goal.Dependencies, nil, goal.Provider, goal.PropertyDependencies,
-					false, nil, nil, nil, "", false, "", nil, nil, "", nil, nil, false, "", nil),
+					false, nil, nil, nil, "", false, "", nil, nil, "", nil, nil, false, "", nil, nil, nil),
 			})
 			registers++
 
@@ -667,7 +667,7 @@ func TestReadInvokeDefaultProviders(t *testing.T) {
 			e.Done(&ReadResult{
 				State: resource.NewState(e.Type(), urn, true, false, e.ID(), e.Properties(),
 					resource.PropertyMap{}, e.Parent(), false, false, e.Dependencies(), nil, e.Provider(), nil, false,
-					nil, nil, nil, "", false, "", nil, nil, "", nil, nil, false, "", nil),
+					nil, nil, nil, "", false, "", nil, nil, "", nil, nil, false, "", nil, nil, nil),
 			})
 			reads++
 		}
@@ -825,7 +825,7 @@ func TestDisableDefaultProviders(t *testing.T) {
 					event.Done(&ReadResult{
 						State: resource.NewState(event.Type(), urn, true, false, event.ID(), event.Properties(),
 							resource.PropertyMap{}, event.Parent(), false, false, event.Dependencies(), nil, event.Provider(), nil,
-							false, nil, nil, nil, "", false, "", nil, nil, "", nil, nil, false, "", nil),
+							false, nil, nil, nil, "", false, "", nil, nil, "", nil, nil, false, "", nil, nil, nil),
 					})
 					reads++
 				case RegisterResourceEvent:
@@ -834,7 +834,7 @@ func TestDisableDefaultProviders(t *testing.T) {
 						State: resource.NewState(event.Goal().Type, urn, true, false, "id", event.Goal().Properties,
 							resource.PropertyMap{}, event.Goal().Parent, false, false, event.Goal().Dependencies, nil,
 							event.Goal().Provider, nil, false, nil, nil, nil, "", false, "", nil, nil, "", nil, nil, false,
-							"", nil),
+							"", nil, nil, nil),
 					})
 					registers++
 				default:
@@ -373,6 +373,15 @@ func (s *CreateStep) Apply() (resource.Status, StepCompleteFunc, error) {
 	s.new.Created = &now
 	s.new.Modified = &now
 
+	// An expiry given as a time to live counts from the moment the resource was created.
+	if s.new.Expires != nil && s.reg != nil {
+		if goal := s.reg.Goal(); goal != nil && goal.Expires != "" {
+			if t, err := resource.ParseExpiry(goal.Expires, now); err == nil {
+				s.new.Expires = &t
+			}
+		}
+	}
+
 	// Mark the old resource as pending deletion if necessary.
 	if s.replacing && s.pendingDelete {
 		contract.Assertf(s.old != s.new, "old and new states should not be the same")
@@ -1408,6 +1417,14 @@ func (s *RefreshStep) Apply() (resource.Status, StepCompleteFunc, error) {
 		s.isDeleted = true
 	}
 
+	// A standalone refresh doesn't register any resources, so check the refreshed state against any policies here.
+	// Refreshes that precede an update don't need this, as the update analyzes resources as they are registered.
+	if s.deployment.opts.RefreshOnly && !s.isDeleted {
+		if err := s.deployment.analyzeObservedResource(s.new); err != nil {
+			return refreshed.Status, nil, err
+		}
+	}
+
 	complete := func() {
 		// s.cts will be empty for refreshes that are just being done on state, rather than via a program.
 		if s.cts != nil {
@@ -1642,7 +1659,7 @@ func (s *ImportStep) Apply() (_ resource.Status, _ StepCompleteFunc, err error)
 		s.new.Parent, s.new.Protect, false, s.new.Dependencies, s.new.InitErrors, s.new.Provider,
 		s.new.PropertyDependencies, false, nil, nil, &s.new.CustomTimeouts, s.new.ImportID, s.new.RetainOnDelete,
 		s.new.DeletedWith, nil, nil, s.new.SourcePosition, s.new.IgnoreChanges, s.new.ReplaceOnChanges,
-		s.new.RefreshBeforeUpdate, s.new.ViewOf, nil)
+		s.new.RefreshBeforeUpdate, s.new.ViewOf, nil, s.new.Expires, s.new.Annotations)
 
 	// Import takes a resource that Pulumi did not create and imports it into pulumi state.
 	now := time.Now().UTC()
@@ -1722,6 +1739,11 @@ func (s *ImportStep) Apply() (_ resource.Status, _ StepCompleteFunc, err error)
 
 		issueCheckFailures(s.deployment.Diag().Warningf, s.new, s.new.URN, resp.Failures)
 
+		// Imported resources aren't registered by a program, so check them against any policies here.
+		if err := s.deployment.analyzeObservedResource(s.new); err != nil {
+			return rst, nil, err
+		}
+
 		return rst, complete, nil
 	}
 
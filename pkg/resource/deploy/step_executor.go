@@ -544,6 +544,9 @@ func (se *stepExecutor) continueExecuteStep(payload interface{}, workerID int, s
 		} else if step.Op() == OpRead || step.Op() == OpReadReplacement {
 			se.deployment.reads.Store(newState.URN, newState)
 		}
+		if newState.Type == stackReferenceType {
+			se.deployment.stackReferenceSteps.Store(newState.URN, step)
+		}
 
 		// If we're generating plans update the resource's outputs in the generated plan.
 		if se.deployment.opts.GeneratePlan {
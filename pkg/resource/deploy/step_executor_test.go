@@ -57,11 +57,12 @@ func (e *mockRegisterResourceOutputsEvent) Outputs() resource.PropertyMap {
 func (e *mockRegisterResourceOutputsEvent) Done() {}
 
 type mockEvents struct {
-	OnResourceStepPreF   func(step Step) (interface{}, error)
-	OnResourceStepPostF  func(ctx interface{}, step Step, status resource.Status, err error) error
-	OnResourceOutputsF   func(step Step) error
-	OnPolicyViolationF   func(resource.URN, plugin.AnalyzeDiagnostic)
-	OnPolicyRemediationF func(resource.URN, plugin.Remediation, resource.PropertyMap, resource.PropertyMap)
+	OnResourceStepPreF      func(step Step) (interface{}, error)
+	OnResourceStepPostF     func(ctx interface{}, step Step, status resource.Status, err error) error
+	OnResourceOutputsF      func(step Step) error
+	OnResourceStateChangedF func(step Step) error
+	OnPolicyViolationF      func(resource.URN, plugin.AnalyzeDiagnostic)
+	OnPolicyRemediationF    func(resource.URN, plugin.Remediation, resource.PropertyMap, resource.PropertyMap)
 }
 
 func (e *mockEvents) OnResourceStepPre(step Step) (interface{}, error) {
@@ -85,6 +86,13 @@ func (e *mockEvents) OnResourceOutputs(step Step) error {
 	panic("unimplemented")
 }
 
+func (e *mockEvents) OnResourceStateChanged(step Step) error {
+	if e.OnResourceStateChangedF != nil {
+		return e.OnResourceStateChangedF(step)
+	}
+	panic("unimplemented")
+}
+
 func (e *mockEvents) OnPolicyViolation(resource.URN, plugin.AnalyzeDiagnostic) {
 	panic("unimplemented")
 }
@@ -19,6 +19,7 @@ import (
 	cryptorand "crypto/rand"
 	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
 	"time"
@@ -79,6 +80,9 @@ type stepGenerator struct {
 	sames     map[resource.URN]bool // set of URNs that were not changed in this deployment
 	refreshes map[resource.URN]bool // set of URNs that were refreshed in this deployment
 
+	// the new states of the resources created in this deployment, in order, used to detect renames.
+	createdStates []*resource.State
+
 	// set of URNs that would have been created, but were filtered out because the user didn't
 	// specify them with --target, or because they were skipped as part of a destroy run where we
 	// can't create any new resources.
@@ -280,6 +284,8 @@ func (sg *stepGenerator) GenerateReadSteps(event ReadResourceEvent) ([]Step, err
 		false, /* refreshBeforeUpdate */
 		"",    /* viewOf */
 		nil,   /* resourceHooks */
+		nil,   /* expires */
+		nil,   /* annotations */
 	)
 	old, hasOld := sg.deployment.Olds()[urn]
 
@@ -566,6 +572,41 @@ func (sg *stepGenerator) generateAliases(goal *resource.Goal) []resource.URN {
 	return result
 }
 
+// expiryWarningWindow is how far ahead of a resource's expiry previews start warning that it will expire.
+const expiryWarningWindow = 72 * time.Hour
+
+// resolveExpiry returns the time that a resource expires, if it has an expiry. Times to live are relative to when the
+// resource was first created, so that updating a resource doesn't push its expiry back. During previews, this also
+// warns about resources that have expired or will expire soon.
+func (sg *stepGenerator) resolveExpiry(
+	urn resource.URN, goal *resource.Goal, created *time.Time,
+) (*time.Time, error) {
+	if goal.Expires == "" {
+		return nil, nil
+	}
+	now := time.Now()
+	base := now
+	if created != nil {
+		base = *created
+	}
+	expires, err := resource.ParseExpiry(goal.Expires, base)
+	if err != nil {
+		return nil, err
+	}
+
+	if sg.deployment.opts.DryRun {
+		if remaining := expires.Sub(now); remaining <= 0 {
+			sg.deployment.Diag().Warningf(diag.Message(urn,
+				"this resource expired at %s and will be deleted by `pulumi stack reap`"), expires.Format(time.RFC3339))
+		} else if remaining <= expiryWarningWindow {
+			sg.deployment.Diag().Warningf(diag.Message(urn,
+				"this resource expires in %s, at %s, after which it will be deleted by `pulumi stack reap`"),
+				remaining.Round(time.Minute), expires.Format(time.RFC3339))
+		}
+	}
+	return &expires, nil
+}
+
 func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, bool, error) {
 	var invalid bool // will be set to true if this object fails validation.
 
@@ -585,6 +626,11 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, boo
 
 	// Generate the aliases for this resource.
 	aliases := sg.generateAliases(goal)
+	// If we've been asked to treat this resource as a rename of an old resource, add that resource as an alias.
+	renamedFrom, isRename := sg.deployment.opts.RenameAliases[urn]
+	if isRename && !slices.Contains(aliases, renamedFrom) {
+		aliases = append(aliases, renamedFrom)
+	}
 	// Log the aliases we're going to use to help with debugging aliasing issues.
 	logging.V(7).Infof("Generated aliases for %s: %v", urn, aliases)
 
@@ -630,6 +676,9 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, boo
 
 				// Log the alias we matched to help with debugging aliasing issues.
 				logging.V(7).Infof("Matched alias %v resolving to %v for resource %v", urnOrAlias, old.URN, urn)
+				if isRename && urnOrAlias == renamedFrom {
+					sg.deployment.Diag().Infof(diag.Message(urn, "treating %v as a rename of %v"), urn, renamedFrom)
+				}
 			}
 			break
 		}
@@ -668,12 +717,29 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, boo
 		refreshBeforeUpdate = old.RefreshBeforeUpdate
 	}
 
+	expires, err := sg.resolveExpiry(urn, goal, createdAt)
+	if err != nil {
+		invalid = true
+		sg.deployment.Diag().Errorf(diag.Message(urn, "%v"), err)
+	}
+
+	// Annotations set by the program replace those in the old state, except for those added with `pulumi state
+	// annotate`, which are kept unless the program sets the same keys.
+	annotations := goal.Annotations
+	var stateAnnotations map[string]string
+	if old != nil && len(old.StateAnnotations) > 0 {
+		stateAnnotations = old.StateAnnotations
+		annotations = maps.Clone(stateAnnotations)
+		maps.Copy(annotations, goal.Annotations)
+	}
+
 	new := resource.NewState(
 		goal.Type, urn, goal.Custom, false, "", goal.Properties, nil, goal.Parent, protectState, false,
 		goal.Dependencies, goal.InitErrors, goal.Provider, goal.PropertyDependencies, false,
 		goal.AdditionalSecretOutputs, aliasUrns, &goal.CustomTimeouts, goal.ID, retainOnDelete, goal.DeletedWith,
 		createdAt, modifiedAt, goal.SourcePosition, goal.IgnoreChanges, goal.ReplaceOnChanges,
-		refreshBeforeUpdate, "", goal.ResourceHooks)
+		refreshBeforeUpdate, "", goal.ResourceHooks, expires, annotations)
+	new.StateAnnotations = stateAnnotations
 
 	if providers.IsProviderType(goal.Type) {
 		sg.providers[urn] = new
@@ -895,7 +961,8 @@ func (sg *stepGenerator) continueStepsFromRefresh(event ContinueResourceRefreshE
 					goal.Dependencies, goal.InitErrors, goal.Provider, goal.PropertyDependencies, false,
 					goal.AdditionalSecretOutputs, new.Aliases, &goal.CustomTimeouts, "", new.RetainOnDelete, goal.DeletedWith,
 					new.Created, new.Modified, goal.SourcePosition, goal.IgnoreChanges, goal.ReplaceOnChanges,
-					new.RefreshBeforeUpdate, "", goal.ResourceHooks)
+					new.RefreshBeforeUpdate, "", goal.ResourceHooks, new.Expires, new.Annotations)
+				newnew.StateAnnotations = new.StateAnnotations
 			}
 
 			sg.events <- &continueResourceImportEvent{
@@ -1134,6 +1201,7 @@ func (sg *stepGenerator) continueStepsFromImport(event ContinueResourceImportEve
 					Aliases:                 new.GetAliases(),
 					CustomTimeouts:          new.CustomTimeouts,
 					Parent:                  new.Parent,
+					Annotations:             new.Annotations,
 				},
 			}
 			providerResource := sg.getProviderResource(new.URN, new.Provider)
@@ -1484,6 +1552,7 @@ func (sg *stepGenerator) continueStepsFromImport(event ContinueResourceImportEve
 	}
 
 	sg.creates[urn] = true
+	sg.createdStates = append(sg.createdStates, new)
 	logging.V(7).Infof("Planner decided to create '%v' (inputs=%v)", urn, new.Inputs)
 	return []Step{NewCreateStep(sg.deployment, event, new)}, false, nil
 }
@@ -1940,6 +2009,8 @@ func (sg *stepGenerator) GenerateDeletes(targetsOpt UrnTargets, excludesOpt UrnT
 
 	// Doesn't matter what order we build this list of steps in as we'll sort them in ScheduleDeletes.
 	steps := slice.Prealloc[Step](len(sg.toDelete))
+	// The resources that are being deleted because the program no longer registers them, which may have been renamed.
+	var unregistered []*resource.State
 	if prev := sg.deployment.prev; prev != nil {
 		for _, res := range prev.Resources {
 			if res.ViewOf != "" {
@@ -1988,6 +2059,7 @@ func (sg *stepGenerator) GenerateDeletes(targetsOpt UrnTargets, excludesOpt UrnT
 					if !res.PendingReplacement {
 						oldViews := sg.deployment.GetOldViews(res.URN)
 						steps = append(steps, NewDeleteStep(sg.deployment, sg.deletes, res, oldViews))
+						unregistered = append(unregistered, res)
 					} else {
 						steps = append(steps, NewRemovePendingReplaceStep(sg.deployment, res))
 					}
@@ -2018,6 +2090,13 @@ func (sg *stepGenerator) GenerateDeletes(targetsOpt UrnTargets, excludesOpt UrnT
 		}
 	}
 
+	// Look for resources that are being deleted and created under a new URN, which is usually the result of a
+	// refactoring that didn't add aliases. These are reported so that users can add aliases before the update
+	// replaces the resources.
+	if sg.mode != destroyMode {
+		sg.deployment.renameSuggestions = DetectRenames(sg.createdStates, unregistered)
+	}
+
 	// Check each proposed delete against the relevant resource plan
 	for _, s := range steps {
 		if sg.deployment.plan != nil {
@@ -2867,11 +2946,14 @@ func (sg *stepGenerator) AnalyzeResources() error {
 						Aliases:                 v.GetAliases(),
 						CustomTimeouts:          v.CustomTimeouts,
 						Parent:                  v.Parent,
+						Annotations:             v.Annotations,
 					},
 				},
 				Parent:               v.Parent,
 				Dependencies:         v.Dependencies,
 				PropertyDependencies: v.PropertyDependencies,
+				Custom:               v.Custom,
+				Inputs:               v.Inputs,
 			}
 			// N.B. This feels very unideal but I can't find a better way to check this. When we get here
 			// there is a chance that we'll have a resource in `news` but _won't_ have it's matching provider.
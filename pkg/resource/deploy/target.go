@@ -34,11 +34,14 @@ const (
 	nodejsDynamicProviderPackage = "pulumi-nodejs"
 	// The package name for the Python dynamic provider.
 	pythonDynamicProviderPackage = "pulumi-python"
+	// The package name for the Go dynamic provider.
+	goDynamicProviderPackage = "pulumi-go"
 )
 
 // Returns true if the given package refers to a dynamic provider.
 func isDynamicProvider(pkg tokens.Package) bool {
-	return pkg == tokens.Package(nodejsDynamicProviderPackage) || pkg == tokens.Package(pythonDynamicProviderPackage)
+	return pkg == tokens.Package(nodejsDynamicProviderPackage) || pkg == tokens.Package(pythonDynamicProviderPackage) ||
+		pkg == tokens.Package(goDynamicProviderPackage)
 }
 
 // GetPackageConfig returns the set of configuration parameters for the indicated package, if any.
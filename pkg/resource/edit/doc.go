@@ -14,4 +14,7 @@
 
 // Package edit contains functions suitable for editing a snapshot in-place. It is designed to be used by higher-level
 // tools that present a means for users to surgically edit their state.
+//
+// Edits can also be grouped into a Transaction, which applies them to a copy of a stack's state, reports the changes
+// they make with Diff, and writes them back through a Store once the result has been validated.
 package edit
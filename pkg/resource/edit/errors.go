@@ -39,3 +39,23 @@ type ResourceProtectedError struct {
 func (ResourceProtectedError) Error() string {
 	return "Can't delete protected resource"
 }
+
+// ResourceNotFoundError is returned when no resource in a snapshot has a requested URN.
+type ResourceNotFoundError struct {
+	URN resource.URN
+}
+
+func (r ResourceNotFoundError) Error() string {
+	return fmt.Sprintf("No such resource %q exists in the state", r.URN)
+}
+
+// AmbiguousResourceError is returned when a requested URN refers to more than one resource that is not pending
+// deletion.
+type AmbiguousResourceError struct {
+	URN       resource.URN
+	Resources []*resource.State
+}
+
+func (r AmbiguousResourceError) Error() string {
+	return fmt.Sprintf("Resource URN %q ambiguously refers to %d resources", r.URN, len(r.Resources))
+}
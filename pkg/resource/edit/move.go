@@ -0,0 +1,325 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/graph"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+// MoveOptions controls the behaviour of MoveResources.
+type MoveOptions struct {
+	// The name of the destination stack, which the URNs of moved resources are rewritten to use.
+	DestStack tokens.StackName
+	// The name of the destination project, which the URNs of moved resources are rewritten to use. MoveResources fails
+	// if this is empty and there are resources to move.
+	DestProject tokens.PackageName
+	// True to also move the parents of the requested resources.
+	IncludeParents bool
+}
+
+// A BrokenDependency is a dependency between two resources that was removed because MoveResources moved one of them
+// to another stack but not the other.
+type BrokenDependency struct {
+	// The URN of the resource that had the dependency.
+	Resource resource.URN
+	// The URN of the resource it depended on.
+	Dependency resource.URN
+	// The kind of dependency: a dependency, a property dependency or a deleted-with relationship.
+	Type resource.StateDependencyType
+	// The property the dependency was for, if it was a property dependency.
+	Key resource.PropertyKey
+}
+
+// A MoveResult describes the outcome of MoveResources.
+type MoveResult struct {
+	// The moved resources, as they were in the source snapshot and in the order they appeared there.
+	Moved []*resource.State
+	// The requested URNs that did not match any resource in the source snapshot.
+	Unmatched []resource.URN
+	// Dependencies of resources remaining in the source snapshot on resources that were moved.
+	BrokenSourceDependencies []BrokenDependency
+	// Dependencies of moved resources on resources that remain in the source snapshot.
+	BrokenDestDependencies []BrokenDependency
+}
+
+// MoveResources moves the resources with the given URNs, along with their children, from the source snapshot to the
+// destination snapshot, editing both in place. The providers of moved resources are copied to the destination, unless
+// it already contains an equivalent provider, and the URNs of moved resources are rewritten to use the destination
+// stack and project. Moved resources whose parents are not moved are parented to the destination's root stack
+// resource, which is created if necessary. Dependencies between moved resources and resources that remain in the
+// source snapshot are removed and returned in the result, since they can't be expressed across stacks.
+//
+// Callers that persist both snapshots should write the destination first, so that if writing the source fails the
+// resources are still tracked by at least one stack.
+func MoveResources(
+	source, dest *deploy.Snapshot, urns []resource.URN, opts MoveOptions,
+) (*MoveResult, error) {
+	result := &MoveResult{}
+
+	resourcesToMove := make(map[string]*resource.State)
+	providersToCopy := make(map[string]bool)
+	matched := make(map[resource.URN]bool)
+	rootStackURN := ""
+	for _, res := range source.Resources {
+		for _, urn := range urns {
+			if res.URN != urn {
+				continue
+			}
+			if strings.HasPrefix(string(res.Type), "pulumi:providers:") {
+				return nil, errors.New("cannot move providers. Only resources can be moved, " +
+					"and providers will be included automatically")
+			}
+			if res.Type == resource.RootStackType && res.Parent == "" {
+				rootStackURN = string(res.URN)
+			}
+			resourcesToMove[string(res.URN)] = res
+			providersToCopy[res.Provider] = true
+			matched[urn] = true
+			break
+		}
+	}
+
+	for _, urn := range urns {
+		if !matched[urn] {
+			result.Unmatched = append(result.Unmatched, urn)
+		}
+	}
+
+	if len(resourcesToMove) == 0 {
+		return nil, errors.New("no resources found to move")
+	}
+	if opts.DestProject == "" {
+		return nil, errors.New("cannot get project name. " +
+			"Please upgrade your project with `pulumi state upgrade` to solve this.")
+	}
+
+	sourceDepGraph := graph.NewDependencyGraph(source.Resources)
+
+	if opts.IncludeParents {
+		for _, res := range resourcesToMove {
+			for _, parent := range sourceDepGraph.ParentsOf(res) {
+				if res.Type == resource.RootStackType && res.Parent == "" {
+					// We don't move the root stack explicitly, the code below will take care of dealing with that correctly.
+					continue
+				}
+				resourcesToMove[string(parent.URN)] = parent
+				providersToCopy[parent.Provider] = true
+			}
+		}
+	}
+
+	// include all children in the list of resources to move
+	for _, res := range resourcesToMove {
+		for _, dep := range sourceDepGraph.ChildrenOf(res) {
+			resourcesToMove[string(dep.URN)] = dep
+			providersToCopy[dep.Provider] = true
+		}
+	}
+
+	// We don't want to include the root stack in the list of resources to move.  The root stack
+	// either already exists in the destination stack or will be created when we move the resources.
+	delete(resourcesToMove, rootStackURN)
+
+	// We want to move the resources in the order they appear in the source snapshot,
+	// so that resources with relationships are in the right order.  Also check which
+	// resources are remaining in the source stack, now that we know all resources
+	// that are going to be moved.
+	remainingResources := make(map[string]*resource.State)
+	for _, res := range source.Resources {
+		if _, ok := resourcesToMove[string(res.URN)]; ok {
+			result.Moved = append(result.Moved, res)
+		} else {
+			remainingResources[string(res.URN)] = res
+		}
+	}
+
+	// run through the source snapshot and find all the providers
+	// that need to be copied, remove all the resources that need
+	// to be removed, and break the dependencies that are no
+	// longer valid.
+	var providers []*resource.State
+	i := 0
+	for _, res := range source.Resources {
+		// Find providers that need to be copied
+		if _, ok := resourcesToMove[string(res.URN)]; ok {
+			continue
+		}
+		if providersToCopy[string(res.URN)+"::"+string(res.ID)] {
+			providers = append(providers, res)
+		}
+
+		source.Resources[i] = res
+		i++
+		result.BrokenSourceDependencies = append(result.BrokenSourceDependencies,
+			breakDependencies(res, resourcesToMove)...)
+	}
+	source.Resources = source.Resources[:i]
+
+	// Create a root stack if there is none
+	rootStack, err := stack.GetRootStackResource(dest)
+	if err != nil {
+		return nil, err
+	}
+	if rootStack == nil {
+		rootStack = stack.CreateRootStackResource(opts.DestStack.Q(), opts.DestProject)
+		dest.Resources = append([]*resource.State{rootStack}, dest.Resources...)
+	}
+
+	destResMap := make(map[resource.URN]*resource.State)
+	for _, res := range dest.Resources {
+		destResMap[res.URN] = res
+	}
+
+	rewriteMap := make(map[string]string)
+	for _, res := range providers {
+		// Providers stay in the source stack, so we need a copy of the provider to be able to
+		// rewrite the URNs of the resource.
+		r := res.Copy()
+		if _, ok := resourcesToMove[string(r.Parent)]; !ok {
+			r.Parent = rootStack.URN
+		}
+		rewriteURNs(r, opts, nil)
+
+		if destRes, ok := destResMap[r.URN]; ok {
+			// If the provider ID matches, we can assume that the provider has previously been copied and we can just copy it.
+			if destRes.ID == r.ID {
+				continue
+			}
+			// If all the inputs of the provider in the destination stack are the same as the provider in the source stack,
+			// we can assume that the provider is equal for the purpose of resources depending on it.  We don't need to copy
+			// it, but we need to set the provider for all resources to the provider in the destination stack.
+			if destRes.Inputs.DeepEquals(r.Inputs) {
+				rewriteMap[fmt.Sprintf("%s::%s", res.URN, res.ID)] = fmt.Sprintf("%s::%s", destRes.URN, destRes.ID)
+				continue
+			}
+			return nil, fmt.Errorf("provider %s already exists in destination stack", r.URN)
+		}
+
+		dest.Resources = append(dest.Resources, r)
+	}
+
+	for _, res := range result.Moved {
+		// The caller may need the original resources later, e.g. to report errors, so we copy them before modifying them.
+		r := res.Copy()
+		if _, ok := resourcesToMove[string(r.Parent)]; !ok {
+			r.Parent = rootStack.URN
+		}
+
+		result.BrokenDestDependencies = append(result.BrokenDestDependencies,
+			breakDependencies(r, remainingResources)...)
+		rewriteURNs(r, opts, rewriteMap)
+
+		if _, ok := destResMap[r.URN]; ok {
+			return nil, fmt.Errorf("resource %s already exists in destination stack", r.URN)
+		}
+
+		dest.Resources = append(dest.Resources, r)
+	}
+
+	return result, nil
+}
+
+// breakDependencies removes the dependencies of the given resource on any of the given resources, returning the
+// removed dependencies.
+func breakDependencies(res *resource.State, resources map[string]*resource.State) []BrokenDependency {
+	var brokenDeps []BrokenDependency
+
+	var preservedDeps []resource.URN
+	preservedPropDeps := map[resource.PropertyKey][]resource.URN{}
+	preservedDeletedWith := resource.URN("")
+
+	// Providers are always moved, so we don't need to break the dependency and can ignore them here.
+	_, allDeps := res.GetAllDependencies()
+	for _, dep := range allDeps {
+		if dep.Type == resource.ResourceParent {
+			// Resources are reparented appropriately later on, so we ignore parent dependencies here.
+			continue
+		}
+
+		if _, ok := resources[string(dep.URN)]; ok {
+			brokenDeps = append(brokenDeps, BrokenDependency{
+				Resource:   res.URN,
+				Dependency: dep.URN,
+				Type:       dep.Type,
+				Key:        dep.Key,
+			})
+			continue
+		}
+
+		switch dep.Type {
+		case resource.ResourceDependency:
+			preservedDeps = append(preservedDeps, dep.URN)
+		case resource.ResourcePropertyDependency:
+			preservedPropDeps[dep.Key] = append(preservedPropDeps[dep.Key], dep.URN)
+		case resource.ResourceDeletedWith:
+			preservedDeletedWith = dep.URN
+		}
+	}
+
+	res.Dependencies = preservedDeps
+	res.PropertyDependencies = preservedPropDeps
+	res.DeletedWith = preservedDeletedWith
+
+	return brokenDeps
+}
+
+// rewriteURNs rewrites the URN of the given resource, and the URNs of all the resources it refers to, to use the
+// destination stack and project. Provider references found in rewriteMap are replaced with the mapped reference
+// instead.
+func rewriteURNs(res *resource.State, opts MoveOptions, rewriteMap map[string]string) {
+	rename := func(urn resource.URN) resource.URN {
+		return urn.RenameStack(opts.DestStack).RenameProject(opts.DestProject)
+	}
+
+	res.URN = rename(res.URN)
+
+	provider, allDeps := res.GetAllDependencies()
+	if provider != "" {
+		if newProviderURN, ok := rewriteMap[provider]; ok {
+			res.Provider = newProviderURN
+		} else {
+			res.Provider = string(rename(resource.URN(provider)))
+		}
+	}
+
+	var rewrittenDeps []resource.URN
+	rewrittenPropDeps := map[resource.PropertyKey][]resource.URN{}
+
+	for _, dep := range allDeps {
+		rewrittenURN := rename(dep.URN)
+
+		switch dep.Type {
+		case resource.ResourceParent:
+			res.Parent = rewrittenURN
+		case resource.ResourceDependency:
+			rewrittenDeps = append(rewrittenDeps, rewrittenURN)
+		case resource.ResourcePropertyDependency:
+			rewrittenPropDeps[dep.Key] = append(rewrittenPropDeps[dep.Key], rewrittenURN)
+		case resource.ResourceDeletedWith:
+			res.DeletedWith = rewrittenURN
+		}
+	}
+
+	res.Dependencies = rewrittenDeps
+	res.PropertyDependencies = rewrittenPropDeps
+}
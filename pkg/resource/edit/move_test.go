@@ -0,0 +1,103 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edit
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveResources(t *testing.T) {
+	t.Parallel()
+
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	b := NewResource("b", pA, a.URN)
+	c := NewResource("c", pA)
+	c.Parent = a.URN
+	c.URN = resource.NewURN("test", "test", a.Type, c.Type, "c")
+	source := NewSnapshot([]*resource.State{pA, a, b, c})
+	dest := NewSnapshot(nil)
+
+	missing := NewResource("missing", nil).URN
+	result, err := MoveResources(source, dest, []resource.URN{a.URN, missing}, MoveOptions{
+		DestStack:   tokens.MustParseStackName("dest"),
+		DestProject: "other",
+	})
+	require.NoError(t, err)
+
+	// The child moves with its parent, and b remains behind.
+	assert.Equal(t, []*resource.State{a, c}, result.Moved)
+	assert.Equal(t, []resource.URN{missing}, result.Unmatched)
+	assert.Equal(t, []BrokenDependency{
+		{Resource: b.URN, Dependency: a.URN, Type: resource.ResourceDependency},
+	}, result.BrokenSourceDependencies)
+	assert.Empty(t, result.BrokenDestDependencies)
+
+	assert.Equal(t, []*resource.State{pA, b}, source.Resources)
+	assert.Empty(t, b.Dependencies)
+
+	// The destination gains a root stack, a copy of the provider, and the moved resources.
+	require.Len(t, dest.Resources, 4)
+	assert.Equal(t, resource.RootStackType, dest.Resources[0].Type)
+	for _, res := range dest.Resources {
+		assert.Equal(t, "dest", string(res.URN.Stack()))
+		assert.Equal(t, "other", string(res.URN.Project()))
+	}
+	assert.NoError(t, source.VerifyIntegrity())
+	assert.NoError(t, dest.VerifyIntegrity())
+}
+
+func TestMoveResourcesNothingToMove(t *testing.T) {
+	t.Parallel()
+
+	pA := NewProviderResource("a", "p1", "0")
+	source := NewSnapshot([]*resource.State{pA})
+
+	_, err := MoveResources(source, NewSnapshot(nil), []resource.URN{NewResource("missing", nil).URN}, MoveOptions{
+		DestStack: tokens.MustParseStackName("dest"),
+	})
+	assert.EqualError(t, err, "no resources found to move")
+
+	_, err = MoveResources(source, NewSnapshot(nil), []resource.URN{pA.URN}, MoveOptions{
+		DestStack: tokens.MustParseStackName("dest"),
+	})
+	assert.ErrorContains(t, err, "cannot move providers")
+}
+
+func TestMoveResourcesWithoutProject(t *testing.T) {
+	t.Parallel()
+
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	source := NewSnapshot([]*resource.State{pA, a})
+
+	// A missing project name is only an error if there is something to move.
+	_, err := MoveResources(source, NewSnapshot(nil), []resource.URN{NewResource("missing", nil).URN}, MoveOptions{
+		DestStack: tokens.MustParseStackName("dest"),
+	})
+	assert.EqualError(t, err, "no resources found to move")
+
+	_, err = MoveResources(source, NewSnapshot(nil), []resource.URN{a.URN}, MoveOptions{
+		DestStack: tokens.MustParseStackName("dest"),
+	})
+	assert.ErrorContains(t, err, "cannot get project name")
+	assert.Equal(t, []*resource.State{pA, a}, source.Resources)
+}
@@ -15,6 +15,9 @@
 package edit
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
 	"github.com/pulumi/pulumi/pkg/v3/resource/graph"
@@ -142,6 +145,261 @@ func LocateResource(snap *deploy.Snapshot, urn resource.URN) []*resource.State {
 	return resources
 }
 
+// ChangeResourceURN changes the URN of the unique resource with the given URN, rewriting all references to it in the
+// snapshot. If the resource has children, their URNs are changed to reflect the new parent type, recursively. If the
+// resource is a provider, references to it are rewritten as well.
+func ChangeResourceURN(snap *deploy.Snapshot, oldURN resource.URN, newURN resource.URN) error {
+	contract.Requiref(oldURN != "", "oldURN", "must not be empty")
+	contract.Requiref(newURN != "", "newURN", "must not be empty")
+
+	// Check whether the input URN corresponds to an existing resource
+	existingResources := LocateResource(snap, oldURN)
+	if len(existingResources) != 1 {
+		return errors.New("The input URN does not correspond to an existing resource")
+	}
+
+	// If the URN hasn't changed then there's nothing to do.
+	if oldURN == newURN {
+		return nil
+	}
+
+	inputResource := existingResources[0]
+	contract.Assertf(inputResource.URN == oldURN, "The input resource does not match the input URN")
+	// Check whether the new URN _does not_ correspond to an existing resource
+	candidateResources := LocateResource(snap, newURN)
+	if len(candidateResources) > 0 {
+		return errors.New("The chosen new urn for the state corresponds to an already existing resource")
+	}
+
+	// Update the URN of the input resource
+	inputResource.URN = newURN
+	// Update the dependants of the input resource
+	for _, existingResource := range snap.Resources {
+		// update resources other than the input resource
+		if existingResource.URN != inputResource.URN {
+			var updatedDeps []resource.URN
+			updatedPropDeps := map[resource.PropertyKey][]resource.URN{}
+
+			// We handle providers separately later on, so ignore the return here.
+			_, allDeps := existingResource.GetAllDependencies()
+			for _, dep := range allDeps {
+				switch dep.Type {
+				case resource.ResourceParent:
+					if dep.URN == oldURN {
+						existingResource.Parent = newURN
+
+						// We also need to update this resources URN now
+						oldChildURN := existingResource.URN
+						newChildURN := resource.NewURN(
+							oldChildURN.Stack(), oldChildURN.Project(),
+							newURN.QualifiedType(), oldChildURN.Type(),
+							oldChildURN.Name())
+						err := ChangeResourceURN(snap, oldChildURN, newChildURN)
+						if err != nil {
+							return fmt.Errorf("failed to update %s with new parent %s: %w", oldChildURN, newURN, err)
+						}
+					}
+				case resource.ResourceDependency:
+					if dep.URN == oldURN {
+						dep.URN = newURN
+					}
+					updatedDeps = append(updatedDeps, dep.URN)
+				case resource.ResourcePropertyDependency:
+					if dep.URN == oldURN {
+						dep.URN = newURN
+					}
+					updatedPropDeps[dep.Key] = append(updatedPropDeps[dep.Key], dep.URN)
+				case resource.ResourceDeletedWith:
+					if dep.URN == oldURN {
+						dep.URN = newURN
+					}
+					existingResource.DeletedWith = dep.URN
+				}
+			}
+
+			existingResource.Dependencies = updatedDeps
+			existingResource.PropertyDependencies = updatedPropDeps
+		}
+	}
+
+	updateProvider := func(newRef providers.Reference) error {
+		// Loop through all resources and rename references to the provider.
+		for _, curResource := range snap.Resources {
+			if curResource.Provider == "" {
+				// Skip resources that don't use a provider.
+				continue
+			}
+
+			curResourceProviderRef, err := providers.ParseReference(curResource.Provider)
+			if err != nil {
+				return err
+			}
+
+			// Skip resources that don't use the renamed provider.
+			if curResourceProviderRef.URN() != oldURN {
+				continue
+			}
+
+			// Update the provider.
+			curResource.Provider = newRef.String()
+		}
+		return nil
+	}
+
+	// If the renamed resource is a Provider, fix all resources referring to the old name.
+	if providers.IsProviderType(inputResource.Type) {
+		newRef, err := providers.NewReference(newURN, inputResource.ID)
+		if err != nil {
+			return err
+		}
+		return updateProvider(newRef)
+	}
+
+	return nil
+}
+
+// ReparentResource changes the parent of the given resource, which changes its URN and those of its children. If
+// newParent is empty, the resource is left without a parent. If the new parent appears after the resource in the
+// snapshot, the snapshot is re-sorted so that it precedes the resource.
+func ReparentResource(snap *deploy.Snapshot, res *resource.State, newParent resource.URN) error {
+	contract.Requiref(snap != nil, "snap", "must not be nil")
+	contract.Requiref(res != nil, "res", "must not be nil")
+
+	parentType := tokens.Type("")
+	if newParent != "" {
+		parent, err := locateUniqueResource(snap, newParent)
+		if err != nil {
+			return err
+		}
+		// Walk up from the new parent to make sure we aren't about to create a cycle.
+		seen := map[*resource.State]bool{}
+		for ancestor := parent; ancestor != nil && !seen[ancestor]; {
+			if ancestor == res {
+				return fmt.Errorf("cannot make %s the parent of %s, since it is one of its descendants", newParent, res.URN)
+			}
+			seen[ancestor] = true
+			if ancestor.Parent == "" {
+				break
+			}
+			ancestor, _ = locateUniqueResource(snap, ancestor.Parent)
+		}
+		if newParent.QualifiedType() != resource.RootStackType {
+			parentType = newParent.QualifiedType()
+		}
+	}
+
+	newURN := resource.NewURN(res.URN.Stack(), res.URN.Project(), parentType, res.URN.Type(), res.URN.Name())
+	if err := ChangeResourceURN(snap, res.URN, newURN); err != nil {
+		return err
+	}
+	res.Parent = newParent
+
+	return sortIfNeeded(snap, res, newParent)
+}
+
+// SetResourceProvider changes the provider of the given resource. The provider must be present in the snapshot and
+// must be for the same package as the resource.
+func SetResourceProvider(snap *deploy.Snapshot, res *resource.State, ref providers.Reference) error {
+	contract.Requiref(snap != nil, "snap", "must not be nil")
+	contract.Requiref(res != nil, "res", "must not be nil")
+
+	if !res.Custom {
+		return fmt.Errorf("resource %s is not a custom resource and cannot have a provider", res.URN)
+	}
+
+	var provider *resource.State
+	for _, candidate := range snap.Resources {
+		if candidate.URN == ref.URN() && candidate.ID == ref.ID() && !candidate.Delete {
+			provider = candidate
+			break
+		}
+	}
+	if provider == nil || !providers.IsProviderType(provider.Type) {
+		return fmt.Errorf("no provider %s exists in the snapshot", ref)
+	}
+	if pkg := providers.GetProviderPackage(provider.Type); pkg != res.Type.Package() {
+		return fmt.Errorf("provider %s is for package %s, but resource %s is from package %s",
+			ref, pkg, res.URN, res.Type.Package())
+	}
+
+	res.Provider = ref.String()
+	return sortIfNeeded(snap, res, provider.URN)
+}
+
+// ResourceFlag is a boolean field of a resource's state that can be set with SetResourceFlag.
+type ResourceFlag string
+
+const (
+	// FlagProtect marks a resource as protected from deletion.
+	FlagProtect ResourceFlag = "protect"
+	// FlagRetainOnDelete marks a resource to be left in place when it is deleted from the stack.
+	FlagRetainOnDelete ResourceFlag = "retainOnDelete"
+	// FlagPendingReplacement marks a resource as deleted in the cloud but awaiting replacement.
+	FlagPendingReplacement ResourceFlag = "pendingReplacement"
+	// FlagExternal marks a resource as not being managed by the stack.
+	FlagExternal ResourceFlag = "external"
+)
+
+// SetResourceFlag sets or clears one of the boolean flags of the given resource.
+func SetResourceFlag(res *resource.State, flag ResourceFlag, value bool) error {
+	contract.Requiref(res != nil, "res", "must not be nil")
+
+	switch flag {
+	case FlagProtect:
+		res.Protect = value
+	case FlagRetainOnDelete:
+		res.RetainOnDelete = value
+	case FlagPendingReplacement:
+		res.PendingReplacement = value
+	case FlagExternal:
+		res.External = value
+	default:
+		return fmt.Errorf("unknown resource flag %q", flag)
+	}
+	return nil
+}
+
+// locateUniqueResource returns the resource with the given URN. If several resources have the URN, the one that is not
+// pending deletion is returned.
+func locateUniqueResource(snap *deploy.Snapshot, urn resource.URN) (*resource.State, error) {
+	candidates := LocateResource(snap, urn)
+	if len(candidates) > 1 {
+		var live []*resource.State
+		for _, candidate := range candidates {
+			if !candidate.Delete {
+				live = append(live, candidate)
+			}
+		}
+		candidates = live
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, ResourceNotFoundError{URN: urn}
+	case 1:
+		return candidates[0], nil
+	default:
+		return nil, AmbiguousResourceError{URN: urn, Resources: candidates}
+	}
+}
+
+// sortIfNeeded re-sorts the snapshot if the resource with the given URN, on which res has just been made to depend,
+// appears after res.
+func sortIfNeeded(snap *deploy.Snapshot, res *resource.State, dependency resource.URN) error {
+	if dependency == "" {
+		return nil
+	}
+	for _, candidate := range snap.Resources {
+		if candidate == res {
+			return snap.Toposort()
+		}
+		if candidate.URN == dependency {
+			return nil
+		}
+	}
+	return nil
+}
+
 // RenameStack changes the `stackName` component of every URN in a deployment. In addition, it rewrites the name of
 // the root Stack resource itself. May optionally change the project/package name as well.
 func RenameStack(deployment *apitype.DeploymentV3, newName tokens.StackName, newProject tokens.PackageName) error {
@@ -668,3 +668,99 @@ func TestRenameStack(t *testing.T) {
 		require.Len(t, locateResource(deployment, updatedResourceURN), 1)
 	})
 }
+
+func TestChangeResourceURNRewritesReferences(t *testing.T) {
+	t.Parallel()
+
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	b := NewResource("b", pA, a.URN)
+	b.Parent = a.URN
+	b.URN = resource.NewURN("test", "test", a.Type, b.Type, "b")
+	b.PropertyDependencies = map[resource.PropertyKey][]resource.URN{"prop": {a.URN}}
+	snap := NewSnapshot([]*resource.State{pA, a, b})
+
+	newURN := a.URN.Rename("renamed")
+	require.NoError(t, ChangeResourceURN(snap, a.URN, newURN))
+
+	assert.Equal(t, newURN, a.URN)
+	assert.Equal(t, newURN, b.Parent)
+	assert.Equal(t, []resource.URN{newURN}, b.Dependencies)
+	assert.Equal(t, []resource.URN{newURN}, b.PropertyDependencies["prop"])
+	assert.NoError(t, snap.VerifyIntegrity())
+
+	err := ChangeResourceURN(snap, b.URN, newURN)
+	assert.ErrorContains(t, err, "already existing resource")
+}
+
+func TestReparentResource(t *testing.T) {
+	t.Parallel()
+
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	b := NewResource("b", pA)
+	c := NewResource("c", pA)
+	c.Parent = b.URN
+	c.URN = resource.NewURN("test", "test", b.Type, c.Type, "c")
+	snap := NewSnapshot([]*resource.State{pA, b, c, a})
+
+	require.NoError(t, ReparentResource(snap, b, a.URN))
+
+	assert.Equal(t, a.URN, b.Parent)
+	assert.Equal(t, resource.NewURN("test", "test", a.Type, b.Type, "b"), b.URN)
+	assert.Equal(t, b.URN, c.Parent)
+	assert.Equal(t, resource.NewURN("test", "test", a.Type+"$"+b.Type, c.Type, "c"), c.URN)
+	// The snapshot is re-sorted so that the new parent comes before its children.
+	assert.NoError(t, snap.VerifyIntegrity())
+
+	// A resource can't be made a child of its own descendant.
+	err := ReparentResource(snap, a, c.URN)
+	assert.ErrorContains(t, err, "one of its descendants")
+
+	require.NoError(t, ReparentResource(snap, b, ""))
+	assert.Equal(t, resource.URN(""), b.Parent)
+	assert.Equal(t, resource.NewURN("test", "test", "", b.Type, "b"), b.URN)
+	assert.NoError(t, snap.VerifyIntegrity())
+}
+
+func TestSetResourceProvider(t *testing.T) {
+	t.Parallel()
+
+	pA1 := NewProviderResource("a", "p1", "0")
+	pA2 := NewProviderResource("a", "p2", "1")
+	pB := NewProviderResource("b", "p3", "2")
+	a := NewResource("a", pA1)
+	a.Custom = true
+	snap := NewSnapshot([]*resource.State{pA1, a, pA2, pB})
+
+	ref, err := providers.NewReference(pA2.URN, pA2.ID)
+	require.NoError(t, err)
+	require.NoError(t, SetResourceProvider(snap, a, ref))
+	assert.Equal(t, ref.String(), a.Provider)
+	assert.NoError(t, snap.VerifyIntegrity())
+
+	ref, err = providers.NewReference(pB.URN, pB.ID)
+	require.NoError(t, err)
+	err = SetResourceProvider(snap, a, ref)
+	assert.ErrorContains(t, err, "is for package b")
+
+	ref, err = providers.NewReference(pA2.URN, "unknown")
+	require.NoError(t, err)
+	err = SetResourceProvider(snap, a, ref)
+	assert.ErrorContains(t, err, "no provider")
+}
+
+func TestSetResourceFlag(t *testing.T) {
+	t.Parallel()
+
+	a := NewResource("a", nil)
+	require.NoError(t, SetResourceFlag(a, FlagProtect, true))
+	require.NoError(t, SetResourceFlag(a, FlagExternal, true))
+	assert.True(t, a.Protect)
+	assert.True(t, a.External)
+
+	require.NoError(t, SetResourceFlag(a, FlagProtect, false))
+	assert.False(t, a.Protect)
+
+	assert.Error(t, SetResourceFlag(a, "unknown", true))
+}
@@ -0,0 +1,484 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// ErrConcurrentModification is returned by Commit if the stack's state changed after the transaction was opened, or
+// if the stack's lock was lost while the transaction was being written.
+var ErrConcurrentModification = errors.New("the stack's state was modified after the transaction was opened")
+
+// A Store reads and writes the state of a single stack. backend.NewStackStore adapts a backend stack to this
+// interface.
+type Store interface {
+	// ExportDeployment returns the stack's current deployment.
+	ExportDeployment(ctx context.Context) (*apitype.UntypedDeployment, error)
+	// ImportDeployment replaces the stack's deployment. If the store's lock is held but has been lost, for example
+	// because it was removed with `pulumi cancel`, ImportDeployment returns an error wrapping
+	// ErrConcurrentModification.
+	ImportDeployment(ctx context.Context, deployment *apitype.UntypedDeployment) error
+	// Lock takes the stack's lock, so that no other process can change the stack's state until the returned function
+	// is called. Reads and writes made through the store while the lock is held don't release it. Stores that can't be
+	// locked return a function that does nothing.
+	Lock(ctx context.Context) (unlock func(), err error)
+}
+
+// A Transaction is a set of edits to a stack's state that are validated and written together. Edits are made to a
+// private copy of the state, so the snapshot a transaction is opened with is never modified. Each edit either succeeds
+// or leaves the transaction as it was, and the accumulated edits can be reviewed with Diff before they are written
+// with Commit.
+type Transaction struct {
+	// The store the transaction was opened from, if any.
+	store Store
+	// The deployment read from the store when the transaction was opened, used to detect concurrent modification.
+	baseDeployment *apitype.UntypedDeployment
+
+	// The snapshot the transaction was opened with.
+	base *deploy.Snapshot
+	// The edited copy of the snapshot.
+	snap *deploy.Snapshot
+	// Maps each resource in the edited snapshot to the resource in the base snapshot it was copied from. Resources that
+	// have been added to the edited snapshot have no entry.
+	origins map[*resource.State]*resource.State
+
+	committed bool
+}
+
+// NewTransaction begins a transaction that edits a copy of the given snapshot. Transactions created this way have no
+// store, so their edits can be inspected with Snapshot and Diff but not committed.
+func NewTransaction(snap *deploy.Snapshot) *Transaction {
+	contract.Requiref(snap != nil, "snap", "must not be nil")
+
+	copied, origins := copySnapshot(snap, nil)
+	return &Transaction{base: snap, snap: copied, origins: origins}
+}
+
+// Open reads the current state of the stack from the given store and begins a transaction that edits it. An empty
+// snapshot is used if the stack has no state.
+func Open(ctx context.Context, store Store, secretsProvider secrets.Provider) (*Transaction, error) {
+	contract.Requiref(store != nil, "store", "must not be nil")
+
+	deployment, err := store.ExportDeployment(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading state: %w", err)
+	}
+
+	snap := &deploy.Snapshot{}
+	if deployment != nil && len(deployment.Deployment) > 0 {
+		snap, err = stack.DeserializeUntypedDeployment(ctx, deployment, secretsProvider)
+		if err != nil {
+			return nil, fmt.Errorf("reading state: %w", err)
+		}
+	}
+
+	tx := NewTransaction(snap)
+	tx.store = store
+	tx.baseDeployment = deployment
+	return tx, nil
+}
+
+// Snapshot returns the edited snapshot. Changes made to it directly, rather than through the transaction's methods,
+// are not rolled back if a later edit fails.
+func (tx *Transaction) Snapshot() *deploy.Snapshot {
+	return tx.snap
+}
+
+// Apply runs the given operation on the resource with the given URN. If several resources have the URN, the one that
+// is not pending deletion is used. If the operation fails, all of its changes are rolled back.
+func (tx *Transaction) Apply(urn resource.URN, op OperationFunc) error {
+	return tx.do(func(snap *deploy.Snapshot) error {
+		res, err := locateUniqueResource(snap, urn)
+		if err != nil {
+			return err
+		}
+		return op(snap, res)
+	})
+}
+
+// ApplyToAll runs the given operation on every resource for which match returns true, such as the resources selected
+// by a filter expression, returning the number of resources it was run on. If the operation fails for any resource,
+// the changes made to all of them are rolled back.
+func (tx *Transaction) ApplyToAll(match func(*resource.State) bool, op OperationFunc) (int, error) {
+	var count int
+	err := tx.do(func(snap *deploy.Snapshot) error {
+		// Select the resources up front, since the operation may change the snapshot's resources.
+		var matches []*resource.State
+		for _, res := range snap.Resources {
+			if match(res) {
+				matches = append(matches, res)
+			}
+		}
+
+		for _, res := range matches {
+			if err := op(snap, res); err != nil {
+				return fmt.Errorf("editing %s: %w", res.URN, err)
+			}
+		}
+		count = len(matches)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ChangeURN changes the URN of a resource, rewriting references to it and the URNs of its children.
+func (tx *Transaction) ChangeURN(oldURN, newURN resource.URN) error {
+	return tx.do(func(snap *deploy.Snapshot) error {
+		return ChangeResourceURN(snap, oldURN, newURN)
+	})
+}
+
+// Rename changes the name of a resource, rewriting references to it and the URNs of its children.
+func (tx *Transaction) Rename(urn resource.URN, newName string) error {
+	return tx.ChangeURN(urn, urn.Rename(newName))
+}
+
+// Reparent changes the parent of a resource, which changes its URN and those of its children. An empty parent leaves
+// the resource without one.
+func (tx *Transaction) Reparent(urn resource.URN, newParent resource.URN) error {
+	return tx.Apply(urn, func(snap *deploy.Snapshot, res *resource.State) error {
+		return ReparentResource(snap, res, newParent)
+	})
+}
+
+// SetProvider changes the provider of a resource.
+func (tx *Transaction) SetProvider(urn resource.URN, ref providers.Reference) error {
+	return tx.Apply(urn, func(snap *deploy.Snapshot, res *resource.State) error {
+		return SetResourceProvider(snap, res, ref)
+	})
+}
+
+// SetFlag sets or clears one of the boolean flags of a resource.
+func (tx *Transaction) SetFlag(urn resource.URN, flag ResourceFlag, value bool) error {
+	return tx.Apply(urn, func(_ *deploy.Snapshot, res *resource.State) error {
+		return SetResourceFlag(res, flag, value)
+	})
+}
+
+// Delete removes a resource from the state. If targetDependents is true, the resources that depend on it are removed as
+// well; otherwise a ResourceHasDependenciesError is returned if there are any. Protected resources are never removed.
+func (tx *Transaction) Delete(urn resource.URN, targetDependents bool) error {
+	return tx.Apply(urn, func(snap *deploy.Snapshot, res *resource.State) error {
+		return DeleteResource(snap, res, nil, targetDependents)
+	})
+}
+
+// Move moves resources, along with their children, from this transaction's state to that of dest, as described by
+// MoveResources. Both transactions are rolled back if the move fails. Use CommitMove to write the result.
+func (tx *Transaction) Move(dest *Transaction, urns []resource.URN, opts MoveOptions) (*MoveResult, error) {
+	contract.Requiref(dest != nil && dest != tx, "dest", "must be a different transaction")
+
+	var result *MoveResult
+	destSnap, destOrigins := copySnapshot(dest.snap, dest.origins)
+	err := tx.do(func(snap *deploy.Snapshot) error {
+		var err error
+		result, err = MoveResources(snap, dest.snap, urns, opts)
+		return err
+	})
+	if err != nil {
+		dest.snap, dest.origins = destSnap, destOrigins
+		return nil, err
+	}
+	return result, nil
+}
+
+// Validate checks that the edited state is well-formed.
+func (tx *Transaction) Validate() error {
+	if err := tx.snap.VerifyIntegrity(); err != nil {
+		return fmt.Errorf("edited state is invalid: %w", err)
+	}
+	return nil
+}
+
+// Commit validates the edited state and writes it to the store the transaction was opened from. Before writing,
+// Commit checks that the stack's state hasn't changed since the transaction was opened, returning
+// ErrConcurrentModification if it has. The store's lock is held from that check until the write completes, so no other
+// update can change the state in between.
+func (tx *Transaction) Commit(ctx context.Context) error {
+	if tx.store == nil {
+		return errors.New("cannot commit a transaction that was not opened from a store")
+	}
+	if tx.committed {
+		return errors.New("transaction has already been committed")
+	}
+
+	if err := tx.Validate(); err != nil {
+		return err
+	}
+
+	unlock, err := tx.store.Lock(ctx)
+	if err != nil {
+		return fmt.Errorf("locking stack: %w", err)
+	}
+	defer unlock()
+
+	current, err := tx.store.ExportDeployment(ctx)
+	if err != nil {
+		return fmt.Errorf("reading state: %w", err)
+	}
+	if !sameDeployment(current, tx.baseDeployment) {
+		return ErrConcurrentModification
+	}
+
+	deployment, err := serializeSnapshot(ctx, tx.snap)
+	if err != nil {
+		return err
+	}
+	if err := tx.store.ImportDeployment(ctx, deployment); err != nil {
+		return fmt.Errorf("writing state: %w", err)
+	}
+
+	tx.committed = true
+	return nil
+}
+
+// CommitMove commits the transactions on either side of a Move. The destination is written first, so that if writing
+// the source fails the moved resources are still tracked; in that case CommitMove attempts to restore the destination's
+// original state, and returns an error describing what, if anything, needs to be cleaned up by hand.
+func CommitMove(ctx context.Context, source, dest *Transaction) error {
+	contract.Requiref(source != nil, "source", "must not be nil")
+	contract.Requiref(dest != nil, "dest", "must not be nil")
+
+	// Validate both sides before writing either of them.
+	if err := source.Validate(); err != nil {
+		return fmt.Errorf("source: %w", err)
+	}
+	if err := dest.Validate(); err != nil {
+		return fmt.Errorf("destination: %w", err)
+	}
+
+	if err := dest.Commit(ctx); err != nil {
+		return fmt.Errorf("writing destination state: %w; none of the resources have been moved", err)
+	}
+
+	if err := source.Commit(ctx); err != nil {
+		restoreErr := errors.New("no original state to restore")
+		if dest.baseDeployment != nil {
+			restoreErr = dest.restore(ctx)
+		}
+		if restoreErr != nil {
+			return fmt.Errorf("writing source state: %w; the moved resources are now in both stacks and must be "+
+				"removed from the source stack by hand (restoring the destination failed: %w)", err, restoreErr)
+		}
+		return fmt.Errorf("writing source state: %w; none of the resources have been moved", err)
+	}
+
+	return nil
+}
+
+// restore writes the deployment the transaction was opened with back to its store, holding the store's lock while it
+// does so.
+func (tx *Transaction) restore(ctx context.Context) error {
+	unlock, err := tx.store.Lock(ctx)
+	if err != nil {
+		return fmt.Errorf("locking stack: %w", err)
+	}
+	defer unlock()
+
+	return tx.store.ImportDeployment(ctx, tx.baseDeployment)
+}
+
+// do runs the given edit on the transaction's snapshot, rolling the snapshot back if the edit fails.
+func (tx *Transaction) do(edit func(*deploy.Snapshot) error) error {
+	if tx.committed {
+		return errors.New("transaction has already been committed")
+	}
+
+	checkpoint, origins := copySnapshot(tx.snap, tx.origins)
+	if err := edit(tx.snap); err != nil {
+		tx.snap, tx.origins = checkpoint, origins
+		return err
+	}
+	return nil
+}
+
+// copySnapshot returns a copy of the given snapshot whose resources and pending operations can be edited without
+// affecting the original, along with a map from each copied resource to its origin. If origins is nil, the origin of
+// each copied resource is the resource it was copied from; otherwise it is looked up in origins.
+func copySnapshot(
+	snap *deploy.Snapshot, origins map[*resource.State]*resource.State,
+) (*deploy.Snapshot, map[*resource.State]*resource.State) {
+	copied := *snap
+	copied.Resources = make([]*resource.State, len(snap.Resources))
+	newOrigins := make(map[*resource.State]*resource.State, len(snap.Resources))
+	for i, res := range snap.Resources {
+		copied.Resources[i] = res.Copy()
+		if origins == nil {
+			newOrigins[copied.Resources[i]] = res
+		} else if origin, ok := origins[res]; ok {
+			newOrigins[copied.Resources[i]] = origin
+		}
+	}
+
+	copied.PendingOperations = slices.Clone(snap.PendingOperations)
+	for i, op := range copied.PendingOperations {
+		if op.Resource != nil {
+			copied.PendingOperations[i].Resource = op.Resource.Copy()
+		}
+	}
+
+	return &copied, newOrigins
+}
+
+// serializeSnapshot serializes a snapshot into a deployment suitable for writing to a store.
+func serializeSnapshot(ctx context.Context, snap *deploy.Snapshot) (*apitype.UntypedDeployment, error) {
+	sdep, err := stack.SerializeDeployment(ctx, snap, false /* showSecrets */)
+	if err != nil {
+		return nil, fmt.Errorf("serializing deployment: %w", err)
+	}
+	data, err := json.Marshal(sdep)
+	if err != nil {
+		return nil, err
+	}
+	return &apitype.UntypedDeployment{
+		Version:    apitype.DeploymentSchemaVersionCurrent,
+		Deployment: data,
+	}, nil
+}
+
+// sameDeployment returns true if the two deployments are equal, ignoring insignificant whitespace.
+func sameDeployment(a, b *apitype.UntypedDeployment) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Version != b.Version || !slices.Equal(a.Features, b.Features) {
+		return false
+	}
+
+	var ca, cb bytes.Buffer
+	if json.Compact(&ca, a.Deployment) != nil || json.Compact(&cb, b.Deployment) != nil {
+		return bytes.Equal(a.Deployment, b.Deployment)
+	}
+	return bytes.Equal(ca.Bytes(), cb.Bytes())
+}
+
+// ChangeKind describes how a resource was changed by a transaction.
+type ChangeKind string
+
+const (
+	// ChangeAdd indicates a resource that was added to the state.
+	ChangeAdd ChangeKind = "add"
+	// ChangeRemove indicates a resource that was removed from the state.
+	ChangeRemove ChangeKind = "remove"
+	// ChangeModify indicates a resource whose state was changed.
+	ChangeModify ChangeKind = "modify"
+)
+
+// A ResourceChange describes the change a transaction makes to a single resource.
+type ResourceChange struct {
+	// The kind of change.
+	Kind ChangeKind
+	// The URN of the resource. For removed resources, this is the URN it had before it was removed.
+	URN resource.URN
+	// The URN of the resource before the transaction, if it was modified and its URN changed.
+	OldURN resource.URN
+	// The names of the fields that were modified, such as "parent" or "protect".
+	Fields []string
+}
+
+func (c ResourceChange) String() string {
+	switch c.Kind {
+	case ChangeAdd:
+		return "+ " + string(c.URN)
+	case ChangeRemove:
+		return "- " + string(c.URN)
+	default:
+		urn := string(c.URN)
+		if c.OldURN != "" {
+			urn = string(c.OldURN) + " => " + urn
+		}
+		return "~ " + urn + " [" + strings.Join(c.Fields, ", ") + "]"
+	}
+}
+
+// Diff returns the changes the transaction makes to the state, in the order the affected resources appear in the edited
+// state, followed by the resources that have been removed in the order they appeared originally. Changes to the order
+// of resources alone are not reported.
+func (tx *Transaction) Diff() []ResourceChange {
+	var changes []ResourceChange
+	kept := make(map[*resource.State]bool, len(tx.snap.Resources))
+	for _, res := range tx.snap.Resources {
+		origin, ok := tx.origins[res]
+		if !ok {
+			changes = append(changes, ResourceChange{Kind: ChangeAdd, URN: res.URN})
+			continue
+		}
+		kept[origin] = true
+
+		if fields := changedFields(origin, res); len(fields) > 0 {
+			change := ResourceChange{Kind: ChangeModify, URN: res.URN, Fields: fields}
+			if origin.URN != res.URN {
+				change.OldURN = origin.URN
+			}
+			changes = append(changes, change)
+		}
+	}
+
+	for _, res := range tx.base.Resources {
+		if !kept[res] {
+			changes = append(changes, ResourceChange{Kind: ChangeRemove, URN: res.URN})
+		}
+	}
+
+	return changes
+}
+
+// changedFields returns the names of the fields that differ between two states of the same resource.
+func changedFields(old, new *resource.State) []string {
+	var fields []string
+	check := func(name string, equal bool) {
+		if !equal {
+			fields = append(fields, name)
+		}
+	}
+
+	check("urn", old.URN == new.URN)
+	check("parent", old.Parent == new.Parent)
+	check("provider", old.Provider == new.Provider)
+	check("dependencies", slices.Equal(old.Dependencies, new.Dependencies))
+	check("propertyDependencies", maps.EqualFunc(old.PropertyDependencies, new.PropertyDependencies,
+		func(a, b []resource.URN) bool { return slices.Equal(a, b) }))
+	check("deletedWith", old.DeletedWith == new.DeletedWith)
+	check("delete", old.Delete == new.Delete)
+	check("protect", old.Protect == new.Protect)
+	check("retainOnDelete", old.RetainOnDelete == new.RetainOnDelete)
+	check("pendingReplacement", old.PendingReplacement == new.PendingReplacement)
+	check("external", old.External == new.External)
+	check("inputs", old.Inputs.DeepEquals(new.Inputs))
+	check("outputs", old.Outputs.DeepEquals(new.Outputs))
+	check("annotations", maps.Equal(old.Annotations, new.Annotations))
+	check("stateAnnotations", maps.Equal(old.StateAnnotations, new.StateAnnotations))
+
+	return fields
+}
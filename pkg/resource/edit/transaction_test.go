@@ -0,0 +1,324 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/b64"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryStore struct {
+	deployment *apitype.UntypedDeployment
+	importErr  error
+	imports    int
+	lockErr    error
+	locked     bool
+	// The number of imports made without the lock held.
+	unlockedImports int
+}
+
+func (s *memoryStore) ExportDeployment(context.Context) (*apitype.UntypedDeployment, error) {
+	return s.deployment, nil
+}
+
+func (s *memoryStore) ImportDeployment(_ context.Context, deployment *apitype.UntypedDeployment) error {
+	if s.importErr != nil {
+		return s.importErr
+	}
+	s.imports++
+	if !s.locked {
+		s.unlockedImports++
+	}
+	s.deployment = deployment
+	return nil
+}
+
+func (s *memoryStore) Lock(context.Context) (func(), error) {
+	if s.lockErr != nil {
+		return nil, s.lockErr
+	}
+	if s.locked {
+		return nil, errors.New("store is already locked")
+	}
+	s.locked = true
+	return func() { s.locked = false }, nil
+}
+
+func newMemoryStore(t *testing.T, snap *deploy.Snapshot) *memoryStore {
+	deployment, err := serializeSnapshot(context.Background(), snap)
+	require.NoError(t, err)
+	return &memoryStore{deployment: deployment}
+}
+
+// newCustomProviderResource returns a provider that can round-trip through serialization, which requires resources
+// with IDs to be custom resources.
+func newCustomProviderResource(pkg, name, id string) *resource.State {
+	p := NewProviderResource(pkg, name, id)
+	p.Custom = true
+	return p
+}
+
+func (s *memoryStore) snapshot(t *testing.T) *deploy.Snapshot {
+	snap, err := stack.DeserializeUntypedDeployment(context.Background(), s.deployment, b64.Base64SecretsProvider)
+	require.NoError(t, err)
+	return snap
+}
+
+func TestTransactionDoesNotModifyBase(t *testing.T) {
+	t.Parallel()
+
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	b := NewResource("b", pA, a.URN)
+	snap := NewSnapshot([]*resource.State{pA, a, b})
+
+	tx := NewTransaction(snap)
+	require.NoError(t, tx.Rename(a.URN, "renamed"))
+	require.NoError(t, tx.SetFlag(b.URN, FlagProtect, true))
+
+	assert.Equal(t, "a", a.URN.Name())
+	assert.Equal(t, []resource.URN{a.URN}, b.Dependencies)
+	assert.False(t, b.Protect)
+
+	edited := tx.Snapshot()
+	assert.Equal(t, "renamed", edited.Resources[1].URN.Name())
+	assert.Equal(t, []resource.URN{edited.Resources[1].URN}, edited.Resources[2].Dependencies)
+	assert.True(t, edited.Resources[2].Protect)
+	assert.NoError(t, tx.Validate())
+}
+
+func TestTransactionRollsBackFailedEdits(t *testing.T) {
+	t.Parallel()
+
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	b := NewResource("b", pA)
+	b.Protect = true
+	snap := NewSnapshot([]*resource.State{pA, a, b})
+
+	tx := NewTransaction(snap)
+
+	// Renaming a resource that doesn't exist fails and leaves nothing behind.
+	err := tx.ChangeURN(NewResource("missing", nil).URN, NewResource("other", nil).URN)
+	assert.Error(t, err)
+
+	// ApplyToAll is all or nothing: it succeeds on a but fails on b, which is protected.
+	count, err := tx.ApplyToAll(
+		func(*resource.State) bool { return true },
+		func(_ *deploy.Snapshot, res *resource.State) error {
+			res.RetainOnDelete = true
+			if res.Protect {
+				return errors.New("protected")
+			}
+			return nil
+		})
+	assert.ErrorContains(t, err, "protected")
+	assert.Equal(t, 0, count)
+
+	assert.Empty(t, tx.Diff())
+	for _, res := range tx.Snapshot().Resources {
+		assert.False(t, res.RetainOnDelete)
+	}
+}
+
+func TestTransactionApplyToAll(t *testing.T) {
+	t.Parallel()
+
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	b := NewResource("b", pA)
+	snap := NewSnapshot([]*resource.State{pA, a, b})
+
+	tx := NewTransaction(snap)
+	count, err := tx.ApplyToAll(
+		func(res *resource.State) bool { return res.Type == "a:b:c" },
+		func(_ *deploy.Snapshot, res *resource.State) error {
+			return SetResourceFlag(res, FlagRetainOnDelete, true)
+		})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	assert.Equal(t, []ResourceChange{
+		{Kind: ChangeModify, URN: a.URN, Fields: []string{"retainOnDelete"}},
+		{Kind: ChangeModify, URN: b.URN, Fields: []string{"retainOnDelete"}},
+	}, tx.Diff())
+}
+
+func TestTransactionDiff(t *testing.T) {
+	t.Parallel()
+
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	b := NewResource("b", pA, a.URN)
+	c := NewResource("c", pA)
+	snap := NewSnapshot([]*resource.State{pA, a, b, c})
+
+	tx := NewTransaction(snap)
+	require.NoError(t, tx.Rename(a.URN, "renamed"))
+	require.NoError(t, tx.Delete(c.URN, false))
+
+	renamed := a.URN.Rename("renamed")
+	changes := tx.Diff()
+	assert.Equal(t, []ResourceChange{
+		{Kind: ChangeModify, URN: renamed, OldURN: a.URN, Fields: []string{"urn"}},
+		{Kind: ChangeModify, URN: b.URN, Fields: []string{"dependencies"}},
+		{Kind: ChangeRemove, URN: c.URN},
+	}, changes)
+	assert.Equal(t, "~ "+string(a.URN)+" => "+string(renamed)+" [urn]", changes[0].String())
+	assert.Equal(t, "- "+string(c.URN), changes[2].String())
+}
+
+func TestTransactionCommit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	pA := newCustomProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	store := newMemoryStore(t, NewSnapshot([]*resource.State{pA, a}))
+
+	tx, err := Open(ctx, store, b64.Base64SecretsProvider)
+	require.NoError(t, err)
+	require.NoError(t, tx.SetFlag(a.URN, FlagProtect, true))
+	require.NoError(t, tx.Commit(ctx))
+	assert.Equal(t, 1, store.imports)
+	assert.Equal(t, 0, store.unlockedImports)
+	assert.False(t, store.locked)
+
+	written := store.snapshot(t)
+	require.Len(t, written.Resources, 2)
+	assert.True(t, written.Resources[1].Protect)
+
+	// A committed transaction can't be edited or committed again.
+	assert.Error(t, tx.SetFlag(a.URN, FlagProtect, false))
+	assert.Error(t, tx.Commit(ctx))
+}
+
+func TestTransactionCommitRejectsInvalidState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	pA := newCustomProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	store := newMemoryStore(t, NewSnapshot([]*resource.State{pA, a}))
+
+	tx, err := Open(ctx, store, b64.Base64SecretsProvider)
+	require.NoError(t, err)
+	require.NoError(t, tx.Apply(a.URN, func(_ *deploy.Snapshot, res *resource.State) error {
+		res.Dependencies = []resource.URN{NewResource("missing", nil).URN}
+		return nil
+	}))
+
+	assert.ErrorContains(t, tx.Commit(ctx), "edited state is invalid")
+	assert.Equal(t, 0, store.imports)
+}
+
+func TestTransactionCommitDetectsConcurrentModification(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	pA := newCustomProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	store := newMemoryStore(t, NewSnapshot([]*resource.State{pA, a}))
+
+	tx, err := Open(ctx, store, b64.Base64SecretsProvider)
+	require.NoError(t, err)
+	require.NoError(t, tx.Rename(a.URN, "renamed"))
+
+	// Someone else updates the stack in the meantime.
+	b := NewResource("b", pA)
+	other := newMemoryStore(t, NewSnapshot([]*resource.State{pA, a, b}))
+	store.deployment = other.deployment
+
+	assert.ErrorIs(t, tx.Commit(ctx), ErrConcurrentModification)
+	assert.Len(t, store.snapshot(t).Resources, 3)
+}
+
+func TestTransactionCommitLockFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	pA := newCustomProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	store := newMemoryStore(t, NewSnapshot([]*resource.State{pA, a}))
+
+	tx, err := Open(ctx, store, b64.Base64SecretsProvider)
+	require.NoError(t, err)
+	require.NoError(t, tx.SetFlag(a.URN, FlagProtect, true))
+
+	// The stack is locked by another process, so nothing is written.
+	store.lockErr = errors.New("the stack is currently locked")
+	assert.ErrorContains(t, tx.Commit(ctx), "locking stack: the stack is currently locked")
+	assert.Equal(t, 0, store.imports)
+
+	// The transaction can be committed once the lock is released.
+	store.lockErr = nil
+	require.NoError(t, tx.Commit(ctx))
+	assert.Equal(t, 1, store.imports)
+}
+
+func TestTransactionCommitMove(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	pA := newCustomProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	b := NewResource("b", pA)
+	sourceStore := newMemoryStore(t, NewSnapshot([]*resource.State{pA, a, b}))
+	destStore := newMemoryStore(t, NewSnapshot(nil))
+
+	source, err := Open(ctx, sourceStore, b64.Base64SecretsProvider)
+	require.NoError(t, err)
+	dest, err := Open(ctx, destStore, b64.Base64SecretsProvider)
+	require.NoError(t, err)
+
+	result, err := source.Move(dest, []resource.URN{a.URN}, MoveOptions{DestStack: tokens.MustParseStackName("dest"), DestProject: "test"})
+	require.NoError(t, err)
+	require.Len(t, result.Moved, 1)
+
+	// If writing the source fails, the destination is restored.
+	sourceStore.importErr = errors.New("boom")
+	err = CommitMove(ctx, source, dest)
+	assert.ErrorContains(t, err, "none of the resources have been moved")
+	assert.Empty(t, destStore.snapshot(t).Resources)
+	assert.Len(t, sourceStore.snapshot(t).Resources, 3)
+	// Both writes to the destination, including the restore, were made with its lock held.
+	assert.Equal(t, 2, destStore.imports)
+	assert.Equal(t, 0, destStore.unlockedImports)
+}
+
+func TestTransactionMoveRollsBackBothSides(t *testing.T) {
+	t.Parallel()
+
+	pA := newCustomProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	source := NewTransaction(NewSnapshot([]*resource.State{pA, a}))
+	dest := NewTransaction(NewSnapshot(nil))
+
+	_, err := source.Move(dest, []resource.URN{pA.URN}, MoveOptions{DestStack: tokens.MustParseStackName("dest"), DestProject: "test"})
+	assert.ErrorContains(t, err, "cannot move providers")
+	assert.Len(t, source.Snapshot().Resources, 2)
+	assert.Empty(t, dest.Snapshot().Resources)
+}
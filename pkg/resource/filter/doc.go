@@ -0,0 +1,37 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package filter implements a small expression language for selecting resources in a stack's state.
+//
+// An expression is made up of comparisons of the form `field op value`, combined with `and`, `or`, `not` and
+// parentheses, e.g.
+//
+//	type = "aws:s3/*" and outputs.tags.env = prod and not protect
+//
+// The supported fields are:
+//
+//   - type, name, id: the resource's type token, URN name and ID.
+//   - urn, parent, provider: the URN of the resource, its parent or its provider.
+//   - ancestor: the URN of any of the resource's ancestors, selecting the subtree under a component.
+//   - custom, delete, protect, retainOnDelete, external, pendingReplacement: the resource's state flags.
+//   - created, modified, expires: the times the resource was created, last modified and expires.
+//   - inputs.PATH, outputs.PATH: a property of the resource's inputs or outputs, e.g. outputs.tags["env"].
+//   - annotations.KEY: one of the resource's annotations, e.g. annotations.owner.
+//
+// Strings are compared with `=` and `!=` as globs, where `*` matches any sequence of characters. For URN fields, `*`
+// doesn't match the `::` separated parts of the URN and `**` matches anything, as with `--target`. Numbers, times and
+// strings can be ordered with `<`, `<=`, `>` and `>=`. Times are written as RFC3339 timestamps, dates such as
+// 2024-01-31, or relative to now, e.g. `created > -7d` for resources created in the last week. A field on its own,
+// such as `protect` or `outputs.arn`, selects resources where the field is set to a value other than false or null.
+package filter
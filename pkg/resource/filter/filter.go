@@ -0,0 +1,566 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+// fieldKind describes how the values of a field are compared.
+type fieldKind int
+
+const (
+	stringField fieldKind = iota
+	urnField
+	boolField
+	timeField
+	propertyField
+)
+
+// fields maps the name of each field, other than inputs, outputs and annotations, to its kind.
+var fields = map[string]fieldKind{
+	"type":               stringField,
+	"name":               stringField,
+	"id":                 stringField,
+	"urn":                urnField,
+	"parent":             urnField,
+	"ancestor":           urnField,
+	"provider":           urnField,
+	"custom":             boolField,
+	"delete":             boolField,
+	"protect":            boolField,
+	"retainOnDelete":     boolField,
+	"external":           boolField,
+	"pendingReplacement": boolField,
+	"created":            timeField,
+	"modified":           timeField,
+	"expires":            timeField,
+}
+
+// IsField returns true if name is a field that can be used in a filter, e.g. "protect" or "outputs.arn".
+func IsField(name string) bool {
+	_, err := parseField(name)
+	return err == nil
+}
+
+// field is a reference to a value of a resource.
+type field struct {
+	name string
+	// path is the property path for inputs, outputs and annotations fields.
+	path resource.PropertyPath
+}
+
+func (f field) kind() fieldKind {
+	if f.path != nil {
+		return propertyField
+	}
+	return fields[f.name]
+}
+
+func (f field) String() string {
+	if f.path != nil {
+		return f.name + "." + f.path.String()
+	}
+	return f.name
+}
+
+// Filter is a parsed filter expression.
+type Filter struct {
+	expr string
+	root node
+}
+
+// Parse parses a filter expression.
+func Parse(expr string) (*Filter, error) {
+	return parse(expr, time.Now())
+}
+
+func parse(expr string, now time.Time) (*Filter, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{expr: expr, tokens: tokens, now: now}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.errorf(t, "unexpected %q", t.text)
+	}
+	return &Filter{expr: expr, root: root}, nil
+}
+
+// String returns the expression the filter was parsed from.
+func (f *Filter) String() string {
+	return f.expr
+}
+
+// Select returns the resources that match the filter, in the order they appear in resources. Parents that are
+// referenced by the ancestor field are looked up in resources.
+func (f *Filter) Select(resources []*resource.State) []*resource.State {
+	ctx := newEvalContext(resources)
+	var matches []*resource.State
+	for _, r := range resources {
+		if f.root.eval(ctx, r) {
+			matches = append(matches, r)
+		}
+	}
+	return matches
+}
+
+// Matches returns true if the resource matches the filter. resources is used to look up the resource's ancestors.
+func (f *Filter) Matches(r *resource.State, resources []*resource.State) bool {
+	return f.root.eval(newEvalContext(resources), r)
+}
+
+// IsExpression returns true if s looks like a filter expression rather than a URN or URN glob. This allows commands
+// to accept either a URN or an expression in the same argument.
+func IsExpression(s string) bool {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.HasPrefix(s, "urn:") {
+		return false
+	}
+	if strings.ContainsAny(s, "=<>() \t\n") {
+		return true
+	}
+	return IsField(s)
+}
+
+// Get returns the value of a field, or a property path into the resource's inputs, outputs or annotations, for
+// display. The second return value is false if the resource doesn't have a value for the field. Values that are, or
+// are nested in, secrets are returned as secrets.
+func Get(r *resource.State, name string) (resource.PropertyValue, bool, error) {
+	f, err := parseField(name)
+	if err != nil {
+		return resource.PropertyValue{}, false, err
+	}
+	switch f.kind() {
+	case propertyField:
+		v, secret, ok := f.property(r)
+		if secret {
+			v = resource.MakeSecret(v)
+		}
+		return v, ok, nil
+	case boolField:
+		return resource.NewBoolProperty(f.bool(r)), true, nil
+	case timeField:
+		t := f.time(r)
+		if t == nil {
+			return resource.PropertyValue{}, false, nil
+		}
+		return resource.NewStringProperty(t.Format(time.RFC3339)), true, nil
+	default:
+		values := f.strings(nil, r)
+		if len(values) == 0 {
+			return resource.PropertyValue{}, false, nil
+		}
+		return resource.NewStringProperty(values[0]), true, nil
+	}
+}
+
+type evalContext struct {
+	resources map[resource.URN]*resource.State
+}
+
+func newEvalContext(resources []*resource.State) *evalContext {
+	byURN := make(map[resource.URN]*resource.State, len(resources))
+	for _, r := range resources {
+		// Prefer live resources over ones that are pending deletion.
+		if existing, has := byURN[r.URN]; !has || existing.Delete {
+			byURN[r.URN] = r
+		}
+	}
+	return &evalContext{resources: byURN}
+}
+
+// strings returns the values of a string or URN field. Most fields have a single value, but ancestor has one for
+// each of the resource's ancestors.
+func (f field) strings(ctx *evalContext, r *resource.State) []string {
+	switch f.name {
+	case "type":
+		return []string{string(r.Type)}
+	case "name":
+		return []string{r.URN.Name()}
+	case "id":
+		if r.ID == "" {
+			return nil
+		}
+		return []string{string(r.ID)}
+	case "urn":
+		return []string{string(r.URN)}
+	case "parent":
+		if r.Parent == "" {
+			return nil
+		}
+		return []string{string(r.Parent)}
+	case "provider":
+		if r.Provider == "" {
+			return nil
+		}
+		if ref, err := providers.ParseReference(r.Provider); err == nil {
+			return []string{string(ref.URN())}
+		}
+		return []string{r.Provider}
+	case "ancestor":
+		var ancestors []string
+		seen := map[resource.URN]bool{}
+		for parent := r.Parent; parent != "" && !seen[parent]; {
+			seen[parent] = true
+			ancestors = append(ancestors, string(parent))
+			if ctx == nil {
+				break
+			}
+			p, ok := ctx.resources[parent]
+			if !ok {
+				break
+			}
+			parent = p.Parent
+		}
+		return ancestors
+	}
+	return nil
+}
+
+func (f field) bool(r *resource.State) bool {
+	switch f.name {
+	case "custom":
+		return r.Custom
+	case "delete":
+		return r.Delete
+	case "protect":
+		return r.Protect
+	case "retainOnDelete":
+		return r.RetainOnDelete
+	case "external":
+		return r.External
+	case "pendingReplacement":
+		return r.PendingReplacement
+	}
+	return false
+}
+
+func (f field) time(r *resource.State) *time.Time {
+	switch f.name {
+	case "created":
+		return r.Created
+	case "modified":
+		return r.Modified
+	case "expires":
+		return r.Expires
+	}
+	return nil
+}
+
+// property returns the value at the field's path in the resource's inputs, outputs or annotations. Secrets are
+// unwrapped so that their values can be compared; secret is true if any part of the path was secret.
+func (f field) property(r *resource.State) (v resource.PropertyValue, secret bool, ok bool) {
+	var props resource.PropertyMap
+	switch f.name {
+	case "inputs":
+		props = r.Inputs
+	case "outputs":
+		props = r.Outputs
+	case "annotations":
+		props = make(resource.PropertyMap, len(r.Annotations))
+		for k, v := range r.Annotations {
+			props[resource.PropertyKey(k)] = resource.NewStringProperty(v)
+		}
+	}
+	v = resource.NewObjectProperty(props)
+	for _, key := range f.path {
+		for v.IsSecret() {
+			v, secret = v.SecretValue().Element, true
+		}
+		if v, ok = (resource.PropertyPath{key}).Get(v); !ok {
+			return resource.PropertyValue{}, false, false
+		}
+	}
+	for v.IsSecret() {
+		v, secret = v.SecretValue().Element, true
+	}
+	return v, secret, true
+}
+
+type node interface {
+	eval(ctx *evalContext, r *resource.State) bool
+}
+
+type andNode struct{ left, right node }
+
+func (n *andNode) eval(ctx *evalContext, r *resource.State) bool {
+	return n.left.eval(ctx, r) && n.right.eval(ctx, r)
+}
+
+type orNode struct{ left, right node }
+
+func (n *orNode) eval(ctx *evalContext, r *resource.State) bool {
+	return n.left.eval(ctx, r) || n.right.eval(ctx, r)
+}
+
+type notNode struct{ operand node }
+
+func (n *notNode) eval(ctx *evalContext, r *resource.State) bool {
+	return !n.operand.eval(ctx, r)
+}
+
+// truthyNode matches resources where a field is set to something other than false, null or the empty string.
+type truthyNode struct{ field field }
+
+func (n *truthyNode) eval(ctx *evalContext, r *resource.State) bool {
+	switch n.field.kind() {
+	case boolField:
+		return n.field.bool(r)
+	case timeField:
+		return n.field.time(r) != nil
+	case propertyField:
+		v, _, ok := n.field.property(r)
+		if !ok || v.IsNull() {
+			return false
+		}
+		if v.IsBool() {
+			return v.BoolValue()
+		}
+		return true
+	default:
+		for _, s := range n.field.strings(ctx, r) {
+			if s != "" {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// literal is a value on the right-hand side of a comparison.
+type literal struct {
+	text   string
+	quoted bool
+}
+
+// compareNode compares a field with a literal.
+type compareNode struct {
+	field field
+	op    string
+
+	// glob matches strings for = and !=.
+	glob *regexp.Regexp
+	// urns matches URNs for = and !=.
+	urns deploy.UrnTargets
+	// pkg is set when a provider is matched by its package name rather than its URN.
+	pkg tokens.Package
+
+	text    string
+	boolean *bool
+	number  *float64
+	null    bool
+	time    time.Time
+}
+
+func newCompareNode(f field, op string, lit literal, now time.Time) (node, error) {
+	n := &compareNode{field: f, op: op, text: lit.text}
+	equality := op == "=" || op == "!="
+
+	switch f.kind() {
+	case boolField:
+		b, err := strconv.ParseBool(lit.text)
+		if err != nil || !equality {
+			return nil, fmt.Errorf("%s can only be compared with true or false using = or !=", f)
+		}
+		n.boolean = &b
+	case timeField:
+		t, err := parseTime(lit.text, now)
+		if err != nil {
+			return nil, err
+		}
+		n.time = t
+	case urnField:
+		if !equality {
+			return nil, fmt.Errorf("%s can only be compared using = or !=", f)
+		}
+		if f.name == "provider" && !strings.HasPrefix(lit.text, "urn:") && !strings.ContainsRune(lit.text, '*') {
+			n.pkg = tokens.Package(lit.text)
+		} else {
+			n.urns = deploy.NewUrnTargets([]string{lit.text})
+		}
+	case stringField:
+		n.glob = globRegexp(lit.text)
+	case propertyField:
+		n.glob = globRegexp(lit.text)
+		if !lit.quoted {
+			switch lit.text {
+			case "null":
+				if !equality {
+					return nil, fmt.Errorf("%s can only be compared with null using = or !=", f)
+				}
+				n.null = true
+			case "true", "false":
+				b := lit.text == "true"
+				n.boolean = &b
+			default:
+				if f, err := strconv.ParseFloat(lit.text, 64); err == nil {
+					n.number = &f
+				}
+			}
+		}
+	}
+	return n, nil
+}
+
+// globRegexp returns a regular expression that matches strings against a glob, where * matches any sequence of
+// characters.
+func globRegexp(glob string) *regexp.Regexp {
+	parts := strings.Split(glob, "*")
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$")
+}
+
+// relativeTime matches times relative to now, e.g. -7d.
+var relativeTime = regexp.MustCompile(`^([+-]?)(\d+)([smhdw])$`)
+
+func parseTime(s string, now time.Time) (time.Time, error) {
+	if m := relativeTime.FindStringSubmatch(s); m != nil {
+		n, err := strconv.Atoi(m[2])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid relative time %q: %w", s, err)
+		}
+		unit := map[string]time.Duration{
+			"s": time.Second,
+			"m": time.Minute,
+			"h": time.Hour,
+			"d": 24 * time.Hour,
+			"w": 7 * 24 * time.Hour,
+		}[m[3]]
+		d := time.Duration(n) * unit
+		if m[1] == "-" {
+			d = -d
+		}
+		return now.Add(d), nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, expected an RFC3339 time, a date or a relative time like -7d", s)
+}
+
+// ordered applies an ordering operator to the result of a three-way comparison.
+func ordered(op string, cmp int) bool {
+	switch op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+func (n *compareNode) matchString(s string) bool {
+	switch n.op {
+	case "=":
+		return n.glob.MatchString(s)
+	case "!=":
+		return !n.glob.MatchString(s)
+	default:
+		return ordered(n.op, strings.Compare(s, n.text))
+	}
+}
+
+func (n *compareNode) eval(ctx *evalContext, r *resource.State) bool {
+	switch n.field.kind() {
+	case boolField:
+		return (n.field.bool(r) == *n.boolean) == (n.op == "=")
+	case timeField:
+		t := n.field.time(r)
+		if t == nil {
+			return n.op == "!="
+		}
+		return ordered(n.op, t.Compare(n.time))
+	case urnField:
+		matched := false
+		for _, s := range n.field.strings(ctx, r) {
+			if n.pkg != "" {
+				matched = matched || resource.URN(s).Type() == providers.MakeProviderType(n.pkg)
+			} else {
+				matched = matched || n.urns.Contains(resource.URN(s))
+			}
+		}
+		return matched == (n.op == "=")
+	case stringField:
+		values := n.field.strings(ctx, r)
+		if len(values) == 0 {
+			return n.op == "!="
+		}
+		return n.matchString(values[0])
+	case propertyField:
+		v, _, ok := n.field.property(r)
+		if !ok || v.IsNull() {
+			if n.null {
+				return n.op == "="
+			}
+			return n.op == "!="
+		}
+		switch {
+		case n.null:
+			return n.op == "!="
+		case v.IsBool():
+			if n.boolean == nil {
+				return n.op == "!="
+			}
+			return (v.BoolValue() == *n.boolean) == (n.op == "=")
+		case v.IsNumber():
+			if n.number == nil {
+				return n.op == "!="
+			}
+			return ordered(n.op, compareFloats(v.NumberValue(), *n.number))
+		case v.IsString():
+			return n.matchString(v.StringValue())
+		default:
+			return n.op == "!="
+		}
+	}
+	return false
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
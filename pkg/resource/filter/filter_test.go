@@ -0,0 +1,212 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+func testResources() []*resource.State {
+	created := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	modified := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	expires := time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC)
+
+	provider := &resource.State{
+		Type:   "pulumi:providers:aws",
+		URN:    "urn:pulumi:dev::proj::pulumi:providers:aws::default",
+		Custom: true,
+		ID:     "provider-id",
+	}
+	component := &resource.State{
+		Type: "my:index:Website",
+		URN:  "urn:pulumi:dev::proj::my:index:Website::site",
+	}
+	bucket := &resource.State{
+		Type:     "aws:s3/bucket:Bucket",
+		URN:      "urn:pulumi:dev::proj::my:index:Website$aws:s3/bucket:Bucket::content",
+		Custom:   true,
+		ID:       "content-1234",
+		Parent:   component.URN,
+		Provider: string(provider.URN) + "::provider-id",
+		Protect:  true,
+		Created:  &created,
+		Modified: &modified,
+		Expires:  &expires,
+		Annotations: map[string]string{
+			"owner": "web-team",
+		},
+		Inputs: resource.PropertyMap{
+			"tags": resource.NewObjectProperty(resource.PropertyMap{
+				"env": resource.NewStringProperty("prod"),
+			}),
+		},
+		Outputs: resource.PropertyMap{
+			"arn":     resource.NewStringProperty("arn:aws:s3:::content-1234"),
+			"size":    resource.NewNumberProperty(42),
+			"website": resource.NewBoolProperty(true),
+			"secret":  resource.MakeSecret(resource.NewStringProperty("hunter2")),
+			"tags": resource.NewObjectProperty(resource.PropertyMap{
+				"env": resource.NewStringProperty("prod"),
+			}),
+		},
+	}
+	object := &resource.State{
+		Type:           "aws:s3/bucketObject:BucketObject",
+		URN:            "urn:pulumi:dev::proj::my:index:Website$aws:s3/bucket:Bucket$aws:s3/bucketObject:BucketObject::index",
+		Custom:         true,
+		ID:             "index.html",
+		Parent:         bucket.URN,
+		Provider:       string(provider.URN) + "::provider-id",
+		RetainOnDelete: true,
+		Created:        &modified,
+		Outputs: resource.PropertyMap{
+			"size": resource.NewNumberProperty(7),
+			"tags": resource.NewObjectProperty(resource.PropertyMap{
+				"env": resource.NewStringProperty("dev"),
+			}),
+		},
+	}
+	return []*resource.State{provider, component, bucket, object}
+}
+
+func TestSelect(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	resources := testResources()
+
+	cases := []struct {
+		expr     string
+		expected []string
+	}{
+		{`type = "aws:s3/*"`, []string{"content", "index"}},
+		{`type = aws:s3/bucket:Bucket`, []string{"content"}},
+		{`type != "aws:*"`, []string{"default", "site"}},
+		{`name = ind*`, []string{"index"}},
+		{`protect`, []string{"content"}},
+		{`not protect and custom`, []string{"default", "index"}},
+		{`retainOnDelete = true`, []string{"index"}},
+		{`protect or retainOnDelete`, []string{"content", "index"}},
+		{`ancestor = "urn:pulumi:dev::proj::my:index:Website::site"`, []string{"content", "index"}},
+		{`parent = "urn:pulumi:dev::proj::my:index:Website::site"`, []string{"content"}},
+		{`parent = "urn:pulumi:dev::proj::**"`, []string{"content", "index"}},
+		{`provider = aws`, []string{"content", "index"}},
+		{`provider = gcp`, nil},
+		{`provider = "urn:pulumi:dev::proj::pulumi:providers:aws::*"`, []string{"content", "index"}},
+		{`outputs.size > 10`, []string{"content"}},
+		{`outputs.size <= 10`, []string{"index"}},
+		{`outputs.tags.env = prod`, []string{"content"}},
+		{`outputs.tags["env"] != prod`, []string{"default", "site", "index"}},
+		{`inputs.tags.env = prod`, []string{"content"}},
+		{`outputs.website`, []string{"content"}},
+		{`outputs.website = false`, nil},
+		{`outputs.arn = "arn:aws:s3:::*"`, []string{"content"}},
+		{`outputs.arn = null`, []string{"default", "site", "index"}},
+		{`outputs.secret = hunter2`, []string{"content"}},
+		{`created < 2024-02-01`, []string{"content"}},
+		{`created > -7d`, []string{"index"}},
+		{`modified >= "2024-03-01T00:00:00Z"`, []string{"content"}},
+		{`expires < +2d`, []string{"content"}},
+		{`expires < -2d`, nil},
+		{`annotations.owner = web-team`, []string{"content"}},
+		{`annotations.owner`, []string{"content"}},
+		{`not annotations.owner`, []string{"default", "site", "index"}},
+		{`(type = "aws:*" or name = site) and not outputs.tags.env = dev`, []string{"site", "content"}},
+		{`id == "index.html"`, []string{"index"}},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.expr, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := parse(c.expr, now)
+			require.NoError(t, err)
+			var names []string
+			for _, r := range f.Select(resources) {
+				names = append(names, r.URN.Name())
+			}
+			assert.Equal(t, c.expected, names)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		`colour = red`:         `invalid filter "colour = red" at position 1: unknown field "colour"`,
+		`protect = maybe`:      "protect can only be compared with true or false using = or !=",
+		`urn > foo`:            "urn can only be compared using = or !=",
+		`type = "aws:*`:        "unterminated string",
+		`(protect`:             `expected ")"`,
+		`protect and`:          "unexpected end of filter",
+		`created > yesterday`:  `invalid time "yesterday"`,
+		`type = aws protect`:   `unexpected "protect"`,
+		`type ! aws`:           `expected "!="`,
+		`outputs.size = <`:     "expected a value to compare outputs.size with",
+		`outputs.tags = null)`: `unexpected ")"`,
+	}
+	for expr, expected := range cases {
+		_, err := Parse(expr)
+		assert.ErrorContains(t, err, expected, expr)
+	}
+}
+
+func TestIsExpression(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsExpression("type = aws:s3/bucket:Bucket"))
+	assert.True(t, IsExpression("protect"))
+	assert.True(t, IsExpression("outputs.arn"))
+	assert.True(t, IsExpression("created>-1d"))
+	assert.False(t, IsExpression("urn:pulumi:dev::proj::aws:s3/bucket:Bucket::content"))
+	assert.False(t, IsExpression("urn:pulumi:dev::proj::**"))
+	assert.False(t, IsExpression("**"))
+	assert.False(t, IsExpression(""))
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+
+	bucket := testResources()[2]
+
+	v, ok, err := Get(bucket, "outputs.tags.env")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, resource.NewStringProperty("prod"), v)
+
+	v, ok, err = Get(bucket, "created")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, resource.NewStringProperty("2024-01-10T00:00:00Z"), v)
+
+	v, ok, err = Get(bucket, "provider")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, resource.NewStringProperty("urn:pulumi:dev::proj::pulumi:providers:aws::default"), v)
+
+	_, ok, err = Get(bucket, "outputs.missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = Get(bucket, "nope")
+	assert.ErrorContains(t, err, `unknown field "nope"`)
+}
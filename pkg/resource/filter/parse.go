@@ -0,0 +1,256 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// A SyntaxError is returned when an expression can't be parsed.
+type SyntaxError struct {
+	Expr    string
+	Pos     int
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("invalid filter %q at position %d: %s", e.Expr, e.Pos+1, e.Message)
+}
+
+// isWordRune returns true if r can be part of an unquoted word. Words are deliberately permissive so that URNs,
+// globs and property paths can usually be written without quotes.
+func isWordRune(r rune) bool {
+	if unicode.IsSpace(r) {
+		return false
+	}
+	switch r {
+	case '(', ')', '=', '!', '<', '>':
+		return false
+	}
+	return true
+}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{tokenLParen, "(", i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokenRParen, ")", i})
+			i++
+		case r == '=' || r == '!' || r == '<' || r == '>':
+			start := i
+			i++
+			if i < len(runes) && runes[i] == '=' {
+				i++
+			}
+			op := string(runes[start:i])
+			if op == "!" {
+				return nil, &SyntaxError{expr, start, `expected "!="`}
+			}
+			if op == "==" {
+				op = "="
+			}
+			tokens = append(tokens, token{tokenOp, op, start})
+		case r == '"' || r == '\'':
+			start := i
+			i++
+			var sb strings.Builder
+			for ; i < len(runes) && runes[i] != r; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				sb.WriteRune(runes[i])
+			}
+			if i == len(runes) {
+				return nil, &SyntaxError{expr, start, "unterminated string"}
+			}
+			i++
+			tokens = append(tokens, token{tokenString, sb.String(), start})
+		default:
+			start := i
+			for i < len(runes) && isWordRune(runes[i]) {
+				// Allow quoted keys inside property paths, e.g. outputs.tags["Name"].
+				if (runes[i] == '"' || runes[i] == '\'') && i > start {
+					q := runes[i]
+					for i++; i < len(runes) && runes[i] != q; i++ {
+					}
+					if i == len(runes) {
+						return nil, &SyntaxError{expr, start, "unterminated string"}
+					}
+				}
+				i++
+			}
+			tokens = append(tokens, token{tokenWord, string(runes[start:i]), start})
+		}
+	}
+	return append(tokens, token{tokenEOF, "", len(runes)}), nil
+}
+
+type parser struct {
+	expr   string
+	tokens []token
+	pos    int
+	// now is the time that relative times are resolved against.
+	now time.Time
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) errorf(t token, format string, args ...interface{}) error {
+	return &SyntaxError{p.expr, t.pos, fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) isKeyword(word string) bool {
+	t := p.peek()
+	return t.kind == tokenWord && t.text == word
+}
+
+// parseOr parses `and` expressions separated by `or`.
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left, right}
+	}
+	return left, nil
+}
+
+// parseAnd parses unary expressions separated by `and`.
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.isKeyword("not") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{operand}, nil
+	}
+
+	t := p.next()
+	switch t.kind {
+	case tokenLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, p.errorf(closing, `expected ")"`)
+		}
+		return inner, nil
+	case tokenWord:
+		f, err := parseField(t.text)
+		if err != nil {
+			return nil, p.errorf(t, "%v", err)
+		}
+		if op := p.peek(); op.kind == tokenOp {
+			p.next()
+			v := p.next()
+			if v.kind != tokenWord && v.kind != tokenString {
+				return nil, p.errorf(v, "expected a value to compare %s with", t.text)
+			}
+			return newCompareNode(f, op.text, literal{text: v.text, quoted: v.kind == tokenString}, p.now)
+		}
+		return &truthyNode{f}, nil
+	case tokenEOF:
+		return nil, p.errorf(t, "unexpected end of filter")
+	default:
+		return nil, p.errorf(t, "unexpected %q", t.text)
+	}
+}
+
+// parseField parses the name of a field.
+func parseField(name string) (field, error) {
+	for _, prefix := range []string{"inputs", "outputs", "annotations"} {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		rest := name[len(prefix):]
+		if rest == "" || (rest[0] != '.' && rest[0] != '[') {
+			continue
+		}
+		rest = strings.TrimPrefix(rest, ".")
+		path, err := resource.ParsePropertyPath(rest)
+		if err != nil {
+			return field{}, fmt.Errorf("invalid property path %q: %w", rest, err)
+		}
+		return field{name: prefix, path: path}, nil
+	}
+	if _, ok := fields[name]; !ok {
+		return field{}, fmt.Errorf("unknown field %q", name)
+	}
+	return field{name: name}, nil
+}
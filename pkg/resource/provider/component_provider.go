@@ -43,6 +43,11 @@ type Options struct {
 	Schema    []byte
 	Construct provider.ConstructFunc
 	Call      provider.CallFunc
+
+	// Components are component resources whose schema is inferred from their Go types. If Schema is empty, the
+	// provider serves a schema containing these components, and Construct requests for them are dispatched by type
+	// token, falling back to Construct for any other type.
+	Components []Component
 }
 
 // MainWithOptions is an entrypoint for a resource provider plugin that implements `Construct` and optionally also
@@ -51,6 +56,10 @@ type Options struct {
 // Using it isn't required but can cut down significantly on the amount of boilerplate necessary to fire up a new
 // resource provider for components.
 func MainWithOptions(opts Options) error {
+	opts, err := componentOptions(opts)
+	if err != nil {
+		return err
+	}
 	return Main(opts.Name, func(host *HostClient) (pulumirpc.ResourceProviderServer, error) {
 		return &componentProvider{
 			host:      host,
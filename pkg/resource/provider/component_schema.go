@@ -0,0 +1,359 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/provider"
+)
+
+// Component is a component resource whose schema is inferred from its Go types rather than written by hand. Pass
+// components to MainWithOptions, which serves their schema and dispatches Construct requests to them by type token.
+type Component struct {
+	// Token is the component's type token. If empty, the token is "<provider>:index:<component type name>".
+	Token string
+	// Description documents the component in the package schema.
+	Description string
+
+	argsType      reflect.Type
+	componentType reflect.Type
+	construct     func(ctx *pulumi.Context, name string, inputs provider.ConstructInputs,
+		options pulumi.ResourceOption) (pulumi.ComponentResource, error)
+}
+
+// NewComponent returns a component that is constructed by the given function, which has the shape of a typical Go
+// component constructor, e.g.
+//
+//	func NewStaticSite(ctx *pulumi.Context, name string, args *StaticSiteArgs,
+//		opts ...pulumi.ResourceOption) (*StaticSite, error)
+//
+// The component's inputs are the `pulumi:"..."` tagged fields of the args struct, and its outputs are the tagged fields
+// of the component struct. Fields tagged `pulumi:"name,optional"` and fields of pointer types, such as *string or
+// StringPtrInput, are optional. Fields of plain Go types are plain inputs, while fields of Input and Output types may
+// be passed outputs of other resources. Struct types referenced by fields become object types in the schema, named
+// after their Go types.
+func NewComponent[A any, C pulumi.ComponentResource](
+	construct func(ctx *pulumi.Context, name string, args *A, opts ...pulumi.ResourceOption) (C, error),
+) Component {
+	return Component{
+		argsType:      reflect.TypeOf((*A)(nil)).Elem(),
+		componentType: reflect.TypeOf((*C)(nil)).Elem(),
+		construct: func(ctx *pulumi.Context, name string, inputs provider.ConstructInputs,
+			options pulumi.ResourceOption,
+		) (pulumi.ComponentResource, error) {
+			args := new(A)
+			if err := inputs.CopyTo(args); err != nil {
+				return nil, fmt.Errorf("setting args: %w", err)
+			}
+			return construct(ctx, name, args, options)
+		},
+	}
+}
+
+// token returns the component's type token within the given package.
+func (c Component) token(pkg string) string {
+	if c.Token != "" {
+		return c.Token
+	}
+	typ := c.componentType
+	if typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	name := ""
+	if typ != nil {
+		name = typ.Name()
+	}
+	return pkg + ":index:" + name
+}
+
+// ComponentSchema returns the schema of a package named name that contains the given components.
+func ComponentSchema(name, version string, components []Component) (schema.PackageSpec, error) {
+	inferrer := &schemaInferrer{
+		pkg:   name,
+		types: map[string]schema.ComplexTypeSpec{},
+		seen:  map[string]reflect.Type{},
+	}
+	spec := schema.PackageSpec{
+		Name:      name,
+		Version:   version,
+		Resources: map[string]schema.ResourceSpec{},
+		Types:     inferrer.types,
+	}
+	for _, c := range components {
+		token := c.token(name)
+		if _, has := spec.Resources[token]; has {
+			return schema.PackageSpec{}, fmt.Errorf("component %s is defined more than once", token)
+		}
+		res, err := inferrer.component(c)
+		if err != nil {
+			return schema.PackageSpec{}, fmt.Errorf("inferring the schema of component %s: %w", token, err)
+		}
+		spec.Resources[token] = res
+	}
+	return spec, nil
+}
+
+// componentOptions fills in the schema and Construct function of the given options from their components, if any.
+// A hand-written schema takes precedence over the inferred one, and the options' own Construct function is used for
+// types that aren't one of the components.
+func componentOptions(opts Options) (Options, error) {
+	if len(opts.Components) == 0 {
+		return opts, nil
+	}
+
+	if len(opts.Schema) == 0 {
+		spec, err := ComponentSchema(opts.Name, opts.Version, opts.Components)
+		if err != nil {
+			return Options{}, err
+		}
+		bytes, err := json.Marshal(spec)
+		if err != nil {
+			return Options{}, fmt.Errorf("marshaling schema: %w", err)
+		}
+		opts.Schema = bytes
+	}
+
+	components := map[string]Component{}
+	for _, c := range opts.Components {
+		components[c.token(opts.Name)] = c
+	}
+	fallback := opts.Construct
+	opts.Construct = func(ctx *pulumi.Context, typ, name string, inputs provider.ConstructInputs,
+		options pulumi.ResourceOption,
+	) (*provider.ConstructResult, error) {
+		c, has := components[typ]
+		if !has {
+			if fallback != nil {
+				return fallback(ctx, typ, name, inputs, options)
+			}
+			return nil, fmt.Errorf("unknown resource type %s", typ)
+		}
+		res, err := c.construct(ctx, name, inputs, options)
+		if err != nil {
+			return nil, err
+		}
+		return provider.NewConstructResult(res)
+	}
+	return opts, nil
+}
+
+var (
+	inputType          = reflect.TypeOf((*pulumi.Input)(nil)).Elem()
+	outputType         = reflect.TypeOf((*pulumi.Output)(nil)).Elem()
+	resourceType       = reflect.TypeOf((*pulumi.Resource)(nil)).Elem()
+	assetType          = reflect.TypeOf((*pulumi.Asset)(nil)).Elem()
+	archiveType        = reflect.TypeOf((*pulumi.Archive)(nil)).Elem()
+	assetOrArchiveType = reflect.TypeOf((*pulumi.AssetOrArchive)(nil)).Elem()
+)
+
+// schemaInferrer builds schema types from Go types, collecting the object types it encounters along the way.
+type schemaInferrer struct {
+	pkg   string
+	types map[string]schema.ComplexTypeSpec
+	seen  map[string]reflect.Type
+}
+
+// component returns the schema of the given component.
+func (s *schemaInferrer) component(c Component) (schema.ResourceSpec, error) {
+	if c.argsType == nil || c.componentType == nil {
+		return schema.ResourceSpec{}, errors.New("components must be created with NewComponent")
+	}
+	if c.argsType.Kind() != reflect.Struct {
+		return schema.ResourceSpec{}, fmt.Errorf("args type %v must be a struct", c.argsType)
+	}
+	if c.componentType.Kind() != reflect.Ptr || c.componentType.Elem().Kind() != reflect.Struct {
+		return schema.ResourceSpec{}, fmt.Errorf("component type %v must be a pointer to a struct", c.componentType)
+	}
+
+	inputs, requiredInputs, err := s.properties(c.argsType, true)
+	if err != nil {
+		return schema.ResourceSpec{}, err
+	}
+	outputs, required, err := s.properties(c.componentType.Elem(), false)
+	if err != nil {
+		return schema.ResourceSpec{}, err
+	}
+	return schema.ResourceSpec{
+		ObjectTypeSpec: schema.ObjectTypeSpec{
+			Description: c.Description,
+			Type:        "object",
+			Properties:  outputs,
+			Required:    required,
+		},
+		InputProperties: inputs,
+		RequiredInputs:  requiredInputs,
+		IsComponent:     true,
+	}, nil
+}
+
+// properties returns the properties of the `pulumi:"..."` tagged fields of the given struct, along with the names of
+// those that are required. Fields of plain types are marked plain if markPlain is set.
+func (s *schemaInferrer) properties(
+	typ reflect.Type, markPlain bool,
+) (map[string]schema.PropertySpec, []string, error) {
+	props := map[string]schema.PropertySpec{}
+	var required []string
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag, has := field.Tag.Lookup("pulumi")
+		if !has || !field.IsExported() {
+			continue
+		}
+		name, flags, _ := strings.Cut(tag, ",")
+		if name == "" {
+			return nil, nil, fmt.Errorf("field %s.%s has an empty pulumi tag", typ, field.Name)
+		}
+
+		spec, plain, err := s.typeSpec(field.Type)
+		if err != nil {
+			return nil, nil, fmt.Errorf("field %s.%s: %w", typ, field.Name, err)
+		}
+		spec.Plain = plain && markPlain
+		props[name] = schema.PropertySpec{TypeSpec: spec}
+
+		valueType := field.Type
+		if elem, ok := elementType(valueType); ok {
+			valueType = elem
+		}
+		optional := slices.Contains(strings.Split(flags, ","), "optional") || valueType.Kind() == reflect.Ptr
+		if !optional {
+			required = append(required, name)
+		}
+	}
+	slices.Sort(required)
+	return props, required, nil
+}
+
+// typeSpec returns the schema type of values of the given Go type, and whether the type is plain rather than an Input
+// or Output type.
+func (s *schemaInferrer) typeSpec(typ reflect.Type) (schema.TypeSpec, bool, error) {
+	if elem, ok := elementType(typ); ok {
+		spec, err := s.elementTypeSpec(elem)
+		return spec, false, err
+	}
+	spec, err := s.elementTypeSpec(typ)
+	return spec, true, err
+}
+
+// elementType returns the type of the values held by the given Input or Output type.
+func elementType(typ reflect.Type) (reflect.Type, bool) {
+	switch {
+	case typ == inputType || typ == outputType:
+		return reflect.TypeOf((*interface{})(nil)).Elem(), true
+	case typ.Kind() == reflect.Interface && typ.Implements(inputType):
+		// Input interfaces such as StringInput don't have a value to ask, but their To<Type>Output method returns an
+		// Output type that does.
+		for i := 0; i < typ.NumMethod(); i++ {
+			m := typ.Method(i)
+			if !strings.HasPrefix(m.Name, "To") || m.Type.NumIn() != 0 || m.Type.NumOut() != 1 {
+				continue
+			}
+			if out := m.Type.Out(0); out.Kind() != reflect.Interface && out.Implements(outputType) {
+				return elementType(out)
+			}
+		}
+		return nil, false
+	case typ.Kind() != reflect.Interface && typ.Kind() != reflect.Ptr && typ.Implements(inputType):
+		return reflect.Zero(typ).Interface().(pulumi.Input).ElementType(), true
+	default:
+		return nil, false
+	}
+}
+
+// elementTypeSpec returns the schema type of the given plain Go type.
+func (s *schemaInferrer) elementTypeSpec(typ reflect.Type) (schema.TypeSpec, error) {
+	switch typ {
+	case assetType, assetOrArchiveType:
+		return schema.TypeSpec{Ref: "pulumi.json#/Asset"}, nil
+	case archiveType:
+		return schema.TypeSpec{Ref: "pulumi.json#/Archive"}, nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Ptr:
+		return s.elementTypeSpec(typ.Elem())
+	case reflect.Bool:
+		return schema.TypeSpec{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return schema.TypeSpec{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return schema.TypeSpec{Type: "number"}, nil
+	case reflect.String:
+		return schema.TypeSpec{Type: "string"}, nil
+	case reflect.Slice, reflect.Array:
+		items, _, err := s.typeSpec(typ.Elem())
+		if err != nil {
+			return schema.TypeSpec{}, err
+		}
+		return schema.TypeSpec{Type: "array", Items: &items}, nil
+	case reflect.Map:
+		if typ.Key().Kind() != reflect.String {
+			return schema.TypeSpec{}, fmt.Errorf("map type %v must have string keys", typ)
+		}
+		values, _, err := s.typeSpec(typ.Elem())
+		if err != nil {
+			return schema.TypeSpec{}, err
+		}
+		return schema.TypeSpec{Type: "object", AdditionalProperties: &values}, nil
+	case reflect.Interface:
+		if typ.NumMethod() == 0 {
+			return schema.TypeSpec{Ref: "pulumi.json#/Any"}, nil
+		}
+	case reflect.Struct:
+		return s.objectTypeSpec(typ)
+	}
+	return schema.TypeSpec{}, fmt.Errorf("type %v is not supported in component schemas", typ)
+}
+
+// objectTypeSpec returns a reference to the object type for the given struct, adding the type to the schema if this
+// is the first time it has been seen.
+func (s *schemaInferrer) objectTypeSpec(typ reflect.Type) (schema.TypeSpec, error) {
+	if reflect.PointerTo(typ).Implements(resourceType) {
+		return schema.TypeSpec{}, fmt.Errorf("resource type %v is not supported in component schemas", typ)
+	}
+	if typ.Name() == "" {
+		return schema.TypeSpec{}, fmt.Errorf("anonymous struct type %v is not supported in component schemas", typ)
+	}
+
+	token := s.pkg + ":index:" + typ.Name()
+	ref := schema.TypeSpec{Ref: "#/types/" + token}
+	if seen, has := s.seen[token]; has {
+		if seen != typ {
+			return schema.TypeSpec{}, fmt.Errorf("types %v and %v would both be named %s", seen, typ, token)
+		}
+		return ref, nil
+	}
+	s.seen[token] = typ
+
+	props, required, err := s.properties(typ, false)
+	if err != nil {
+		return schema.TypeSpec{}, err
+	}
+	s.types[token] = schema.ComplexTypeSpec{ObjectTypeSpec: schema.ObjectTypeSpec{
+		Type:       "object",
+		Properties: props,
+		Required:   required,
+	}}
+	return ref, nil
+}
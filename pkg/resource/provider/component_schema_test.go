@@ -0,0 +1,228 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/provider"
+)
+
+type CacheRule struct {
+	Path   string `pulumi:"path"`
+	TTL    *int   `pulumi:"ttl"`
+	Public bool   `pulumi:"public,optional"`
+}
+
+type StaticSiteArgs struct {
+	Domain     pulumi.StringInput      `pulumi:"domain"`
+	IndexPage  pulumi.StringPtrInput   `pulumi:"indexPage"`
+	Replicas   int                     `pulumi:"replicas,optional"`
+	Tags       pulumi.StringMapInput   `pulumi:"tags,optional"`
+	Rules      []CacheRule             `pulumi:"rules,optional"`
+	Aliases    pulumi.StringArrayInput `pulumi:"aliases,optional"`
+	Content    pulumi.AssetOrArchive   `pulumi:"content,optional"`
+	Extra      pulumi.Input            `pulumi:"extra,optional"`
+	unexported string
+}
+
+type StaticSite struct {
+	pulumi.ResourceState
+
+	URL      pulumi.StringOutput `pulumi:"url"`
+	Replicas pulumi.IntOutput    `pulumi:"replicas"`
+	Rules    pulumi.ArrayOutput  `pulumi:"rules,optional"`
+}
+
+func NewStaticSite(ctx *pulumi.Context, name string, args *StaticSiteArgs,
+	opts ...pulumi.ResourceOption,
+) (*StaticSite, error) {
+	return &StaticSite{}, nil
+}
+
+type Bucket struct {
+	pulumi.ResourceState
+
+	Name   pulumi.StringOutput `pulumi:"name"`
+	Origin pulumi.AnyOutput    `pulumi:"origin"`
+}
+
+type BucketArgs struct {
+	Rule CacheRule `pulumi:"rule"`
+}
+
+func NewBucket(ctx *pulumi.Context, name string, args *BucketArgs, opts ...pulumi.ResourceOption) (*Bucket, error) {
+	return &Bucket{}, nil
+}
+
+func TestComponentSchema(t *testing.T) {
+	t.Parallel()
+
+	site := NewComponent(NewStaticSite)
+	site.Description = "A static website."
+	bucket := NewComponent(NewBucket)
+	bucket.Token = "web:storage:Bucket"
+
+	spec, err := ComponentSchema("web", "1.2.3", []Component{site, bucket})
+	require.NoError(t, err)
+
+	actual, err := json.MarshalIndent(spec, "", "  ")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+  "name": "web",
+  "version": "1.2.3",
+  "config": {},
+  "provider": {},
+  "types": {
+    "web:index:CacheRule": {
+      "type": "object",
+      "properties": {
+        "path": {"type": "string"},
+        "public": {"type": "boolean"},
+        "ttl": {"type": "integer"}
+      },
+      "required": ["path"]
+    }
+  },
+  "resources": {
+    "web:index:StaticSite": {
+      "description": "A static website.",
+      "type": "object",
+      "isComponent": true,
+      "properties": {
+        "replicas": {"type": "integer"},
+        "rules": {"type": "array", "items": {"$ref": "pulumi.json#/Any"}},
+        "url": {"type": "string"}
+      },
+      "required": ["replicas", "url"],
+      "inputProperties": {
+        "aliases": {"type": "array", "items": {"type": "string"}},
+        "content": {"$ref": "pulumi.json#/Asset", "plain": true},
+        "domain": {"type": "string"},
+        "extra": {"$ref": "pulumi.json#/Any"},
+        "indexPage": {"type": "string"},
+        "replicas": {"type": "integer", "plain": true},
+        "rules": {"type": "array", "items": {"$ref": "#/types/web:index:CacheRule"}, "plain": true},
+        "tags": {"type": "object", "additionalProperties": {"type": "string"}}
+      },
+      "requiredInputs": ["domain"]
+    },
+    "web:storage:Bucket": {
+      "type": "object",
+      "isComponent": true,
+      "properties": {
+        "name": {"type": "string"},
+        "origin": {"$ref": "pulumi.json#/Any"}
+      },
+      "required": ["name", "origin"],
+      "inputProperties": {
+        "rule": {"$ref": "#/types/web:index:CacheRule", "plain": true}
+      },
+      "requiredInputs": ["rule"]
+    }
+  }
+}`, string(actual))
+
+	// The inferred schema must be one that SDKs can be generated from.
+	_, err = schema.ImportSpec(spec, nil, schema.ValidationOptions{})
+	require.NoError(t, err)
+}
+
+type unsupportedArgs struct {
+	Callback func() `pulumi:"callback"`
+}
+
+func TestComponentSchemaErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		components []Component
+		err        string
+	}{
+		{
+			name: "unsupported type",
+			components: []Component{NewComponent(func(*pulumi.Context, string, *unsupportedArgs,
+				...pulumi.ResourceOption,
+			) (*StaticSite, error) {
+				return nil, nil
+			})},
+			err: "field provider.unsupportedArgs.Callback: type func() is not supported in component schemas",
+		},
+		{
+			name:       "duplicate component",
+			components: []Component{NewComponent(NewStaticSite), NewComponent(NewStaticSite)},
+			err:        "component web:index:StaticSite is defined more than once",
+		},
+		{
+			name:       "not created with NewComponent",
+			components: []Component{{Token: "web:index:Empty"}},
+			err:        "components must be created with NewComponent",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ComponentSchema("web", "1.0.0", c.components)
+			assert.ErrorContains(t, err, c.err)
+		})
+	}
+}
+
+func TestComponentOptions(t *testing.T) {
+	t.Parallel()
+
+	var fellBack string
+	opts, err := componentOptions(Options{
+		Name:       "web",
+		Version:    "1.0.0",
+		Components: []Component{NewComponent(NewStaticSite)},
+		Construct: func(_ *pulumi.Context, typ, _ string, _ provider.ConstructInputs,
+			_ pulumi.ResourceOption,
+		) (*provider.ConstructResult, error) {
+			fellBack = typ
+			return &provider.ConstructResult{}, nil
+		},
+	})
+	require.NoError(t, err)
+
+	var spec schema.PackageSpec
+	require.NoError(t, json.Unmarshal(opts.Schema, &spec))
+	assert.Contains(t, spec.Resources, "web:index:StaticSite")
+
+	// Types that aren't components are passed to the options' own Construct function.
+	_, err = opts.Construct(nil, "web:index:Other", "other", provider.ConstructInputs{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "web:index:Other", fellBack)
+
+	// A hand-written schema is served as-is.
+	opts, err = componentOptions(Options{
+		Name:       "web",
+		Schema:     []byte(`{"name":"web"}`),
+		Components: []Component{NewComponent(NewStaticSite)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"web"}`, string(opts.Schema))
+
+	_, err = opts.Construct(nil, "web:index:Other", "other", provider.ConstructInputs{}, nil)
+	assert.ErrorContains(t, err, "unknown resource type web:index:Other")
+}
@@ -276,6 +276,41 @@ func DeserializeDeploymentV3(
 	ctx context.Context,
 	deployment apitype.DeploymentV3,
 	secretsProv secrets.Provider,
+) (*deploy.Snapshot, error) {
+	return deserializeDeploymentV3(ctx, deployment, secretsProv, nil)
+}
+
+// DeserializeDeploymentV3AllowingUndecryptableSecrets deserializes a typed DeploymentV3 into a `deploy.Snapshot` like
+// DeserializeDeploymentV3, but rather than failing when a secret cannot be decrypted, it replaces the secret's value
+// with null and reports the failure as a snapshot issue. This is intended for commands that inspect and repair broken
+// states; the resulting snapshot loses the values of any such secrets if it is written back, so callers should only
+// write it back if the user has explicitly agreed to that.
+func DeserializeDeploymentV3AllowingUndecryptableSecrets(
+	ctx context.Context,
+	deployment apitype.DeploymentV3,
+	secretsProv secrets.Provider,
+) (*deploy.Snapshot, []deploy.SnapshotIssue, error) {
+	var issues []deploy.SnapshotIssue
+	snap, err := deserializeDeploymentV3(ctx, deployment, secretsProv, func(urn resource.URN, err error) {
+		issues = append(issues, deploy.SnapshotIssue{
+			Kind:    deploy.SnapshotIssueUndecryptableSecret,
+			URN:     urn,
+			Message: fmt.Sprintf("failed to decrypt a secret of resource %s: %v", urn, err),
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return snap, issues, nil
+}
+
+// deserializeDeploymentV3 deserializes a typed DeploymentV3 into a `deploy.Snapshot`. If onDecryptError is not nil,
+// secrets that fail to decrypt are replaced with null and passed to it instead of failing deserialization.
+func deserializeDeploymentV3(
+	ctx context.Context,
+	deployment apitype.DeploymentV3,
+	secretsProv secrets.Provider,
+	onDecryptError func(urn resource.URN, err error),
 ) (*deploy.Snapshot, error) {
 	// Unpack the versions.
 	manifest, err := deploy.DeserializeManifest(deployment.Manifest)
@@ -299,7 +334,9 @@ func DeserializeDeploymentV3(
 	var dec config.Decrypter
 	var completeBatch CompleteCrypterBatch
 	if secretsManager != nil {
-		if batchingSecretsManager, ok := secretsManager.(BatchingSecretsManager); ok {
+		// Batched decryption reports failures only once the whole batch completes, so if we need to tolerate them we
+		// decrypt each secret individually instead.
+		if batchingSecretsManager, ok := secretsManager.(BatchingSecretsManager); ok && onDecryptError == nil {
 			// If the secrets manager supports batching, start a batch operation.
 			dec, completeBatch = batchingSecretsManager.BeginBatchDecryption()
 		} else {
@@ -310,9 +347,18 @@ func DeserializeDeploymentV3(
 		dec = config.NewErrorCrypter("snapshot contains encrypted secrets but no secrets manager could be found")
 	}
 
+	var tolerant *tolerantDecrypter
+	if onDecryptError != nil {
+		tolerant = &tolerantDecrypter{Decrypter: dec, onError: onDecryptError}
+		dec = tolerant
+	}
+
 	// For every serialized resource vertex, create a ResourceDeployment out of it.
 	resources := slice.Prealloc[*resource.State](len(deployment.Resources))
 	for _, res := range deployment.Resources {
+		if tolerant != nil {
+			tolerant.urn = res.URN
+		}
 		desres, err := DeserializeResource(res, dec)
 		if err != nil {
 			return nil, err
@@ -322,6 +368,9 @@ func DeserializeDeploymentV3(
 
 	ops := slice.Prealloc[resource.Operation](len(deployment.PendingOperations))
 	for _, op := range deployment.PendingOperations {
+		if tolerant != nil {
+			tolerant.urn = op.Resource.URN
+		}
 		desop, err := DeserializeOperation(op, dec)
 		if err != nil {
 			return nil, err
@@ -348,6 +397,24 @@ func DeserializeDeploymentV3(
 	return deploy.NewSnapshot(*manifest, secretsManager, resources, ops, metadata), nil
 }
 
+// tolerantDecrypter wraps a decrypter so that values which fail to decrypt are replaced with null, reporting the
+// failure against the resource currently being deserialized.
+type tolerantDecrypter struct {
+	config.Decrypter
+
+	urn     resource.URN
+	onError func(urn resource.URN, err error)
+}
+
+func (d *tolerantDecrypter) DecryptValue(ctx context.Context, ciphertext string) (string, error) {
+	plaintext, err := d.Decrypter.DecryptValue(ctx, ciphertext)
+	if err != nil {
+		d.onError(d.urn, err)
+		return "null", nil
+	}
+	return plaintext, nil
+}
+
 // SerializeResource turns a resource into a structure suitable for serialization.
 func SerializeResource(
 	ctx context.Context, res *resource.State, enc config.Encrypter, showSecrets bool,
@@ -405,6 +472,9 @@ func SerializeResource(
 		RefreshBeforeUpdate:     res.RefreshBeforeUpdate,
 		ViewOf:                  res.ViewOf,
 		ResourceHooks:           res.ResourceHooks,
+		Expires:                 res.Expires,
+		Annotations:             res.Annotations,
+		StateAnnotations:        res.StateAnnotations,
 	}
 
 	if res.CustomTimeouts.IsNotEmpty() {
@@ -566,7 +636,7 @@ func DeserializeResource(res apitype.ResourceV3, dec config.Decrypter) (*resourc
 		return nil, fmt.Errorf("resource '%s' has 'custom' false but non-empty ID", res.URN)
 	}
 
-	return resource.NewState(
+	state := resource.NewState(
 		res.Type, res.URN, res.Custom, res.Delete, res.ID,
 		inputs, outputs, res.Parent, res.Protect, res.External, res.Dependencies, res.InitErrors, res.Provider,
 		res.PropertyDependencies, res.PendingReplacement, res.AdditionalSecretOutputs, res.Aliases, res.CustomTimeouts,
@@ -575,7 +645,11 @@ func DeserializeResource(res apitype.ResourceV3, dec config.Decrypter) (*resourc
 		res.RefreshBeforeUpdate,
 		res.ViewOf,
 		res.ResourceHooks,
-	), nil
+		res.Expires,
+		res.Annotations,
+	)
+	state.StateAnnotations = res.StateAnnotations
+	return state, nil
 }
 
 // DeserializeOperation hydrates a pending resource/operation pair.
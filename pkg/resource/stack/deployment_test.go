@@ -115,7 +115,10 @@ func TestDeploymentSerialization(t *testing.T) {
 			resource.BeforeCreate: {"hook1"},
 			resource.AfterDelete:  {"hook2"},
 		},
+		nil,
+		map[string]string{"owner": "platform-team"},
 	)
+	res.StateAnnotations = map[string]string{"owner": "platform-team"}
 
 	dep, err := SerializeResource(context.Background(), res, config.NopEncrypter, false /* showSecrets */)
 	require.NoError(t, err)
@@ -132,6 +135,7 @@ func TestDeploymentSerialization(t *testing.T) {
 		"BeforeCreate": {"hook1"},
 		"AfterDelete":  {"hook2"},
 	}, dep.ResourceHooks)
+	assert.Equal(t, map[string]string{"owner": "platform-team"}, dep.StateAnnotations)
 
 	// assert some things about the inputs:
 	require.NotNil(t, dep.Inputs)
@@ -603,6 +607,39 @@ func TestDeserializeMissingSecretsManager(t *testing.T) {
 	})
 }
 
+func TestDeserializeAllowingUndecryptableSecrets(t *testing.T) {
+	t.Parallel()
+
+	urn := resource.URN("urn:pulumi:test_stack::test_project::pkg:index:type::name")
+	ctx := context.Background()
+	snap, issues, err := DeserializeDeploymentV3AllowingUndecryptableSecrets(ctx, apitype.DeploymentV3{
+		Resources: []apitype.ResourceV3{
+			{
+				URN:  urn,
+				Type: "pkg:index:type",
+				Outputs: map[string]interface{}{
+					"plain": "value",
+					"secret": map[string]interface{}{
+						"4dabf18193072939515e22adb298388d": "1b47061264138c4ac30d75fd1eb44270",
+						"ciphertext":                       "v1:xRi3+sQJSJHR8sha:RM8BfzSAJI84QMl+zLGjzPvwSqV6zOSdd/I/V34h",
+					},
+				},
+			},
+		},
+	}, b64.Base64SecretsProvider)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, deploy.SnapshotIssueUndecryptableSecret, issues[0].Kind)
+	assert.Equal(t, urn, issues[0].URN)
+	assert.Contains(t, issues[0].Message, "no secrets manager could be found")
+
+	require.Len(t, snap.Resources, 1)
+	assert.Equal(t, resource.PropertyMap{
+		"plain":  resource.NewStringProperty("value"),
+		"secret": resource.MakeSecret(resource.NewNullProperty()),
+	}, snap.Resources[0].Outputs)
+}
+
 func TestSerializePropertyValue(t *testing.T) {
 	t.Parallel()
 
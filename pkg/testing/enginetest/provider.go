@@ -0,0 +1,155 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/blang/semver"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+// Provider is a fake resource provider for a package. By default it behaves like a well-behaved real provider: it
+// creates resources whose outputs are their inputs, diffs resources by comparing their inputs, updates changed
+// resources in place unless a property listed in ReplaceOnChanges changed, and reads resources back unchanged. Any of
+// these operations can be scripted by setting the corresponding function.
+type Provider struct {
+	// Package is the name of the package the provider implements, e.g. "aws".
+	Package string
+	// Version is the provider's version. If empty, it is 1.0.0.
+	Version string
+
+	// ReplaceOnChanges lists, by resource type token, the input properties whose changes replace a resource rather
+	// than updating it in place.
+	ReplaceOnChanges map[string][]string
+
+	CheckF  func(context.Context, plugin.CheckRequest) (plugin.CheckResponse, error)
+	DiffF   func(context.Context, plugin.DiffRequest) (plugin.DiffResponse, error)
+	CreateF func(context.Context, plugin.CreateRequest) (plugin.CreateResponse, error)
+	ReadF   func(context.Context, plugin.ReadRequest) (plugin.ReadResponse, error)
+	UpdateF func(context.Context, plugin.UpdateRequest) (plugin.UpdateResponse, error)
+	DeleteF func(context.Context, plugin.DeleteRequest) (plugin.DeleteResponse, error)
+	InvokeF func(context.Context, plugin.InvokeRequest) (plugin.InvokeResponse, error)
+
+	lock   sync.Mutex
+	nextID int
+}
+
+// version returns the provider's version.
+func (p *Provider) version() (semver.Version, error) {
+	if p.Version == "" {
+		return semver.MustParse("1.0.0"), nil
+	}
+	v, err := semver.ParseTolerant(p.Version)
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("invalid version %q for provider %s: %w", p.Version, p.Package, err)
+	}
+	return v, nil
+}
+
+// loader returns a loader for the provider's plugin.
+func (p *Provider) loader() (*deploytest.ProviderLoader, error) {
+	version, err := p.version()
+	if err != nil {
+		return nil, err
+	}
+	return deploytest.NewProviderLoader(tokens.Package(p.Package), version, func() (plugin.Provider, error) {
+		return &deploytest.Provider{
+			Package: tokens.Package(p.Package),
+			Version: version,
+			CheckF:  p.CheckF,
+			DiffF:   p.diff,
+			CreateF: p.create,
+			ReadF:   p.read,
+			UpdateF: p.UpdateF,
+			DeleteF: p.DeleteF,
+			InvokeF: p.InvokeF,
+		}, nil
+	}), nil
+}
+
+func (p *Provider) diff(ctx context.Context, req plugin.DiffRequest) (plugin.DiffResponse, error) {
+	if p.DiffF != nil {
+		return p.DiffF(ctx, req)
+	}
+
+	replaceOnChanges := p.ReplaceOnChanges[string(req.Type)]
+	diff := req.OldInputs.Diff(req.NewInputs)
+	if !diff.AnyChanges() {
+		return plugin.DiffResult{Changes: plugin.DiffNone}, nil
+	}
+	result := plugin.DiffResult{
+		Changes:      plugin.DiffSome,
+		DetailedDiff: map[string]plugin.PropertyDiff{},
+	}
+	for _, k := range diff.ChangedKeys() {
+		result.ChangedKeys = append(result.ChangedKeys, k)
+		replace := slices.Contains(replaceOnChanges, string(k))
+		if replace {
+			result.ReplaceKeys = append(result.ReplaceKeys, k)
+		}
+
+		kind := plugin.DiffUpdate
+		switch {
+		case diff.Added(k):
+			kind = plugin.DiffAdd
+		case diff.Deleted(k):
+			kind = plugin.DiffDelete
+		}
+		if replace {
+			kind = kind.AsReplace()
+		}
+		result.DetailedDiff[string(k)] = plugin.PropertyDiff{Kind: kind, InputDiff: true}
+	}
+	slices.Sort(result.ChangedKeys)
+	slices.Sort(result.ReplaceKeys)
+	return result, nil
+}
+
+func (p *Provider) create(ctx context.Context, req plugin.CreateRequest) (plugin.CreateResponse, error) {
+	if p.CreateF != nil {
+		return p.CreateF(ctx, req)
+	}
+	if req.Preview {
+		return plugin.CreateResponse{Properties: req.Properties, Status: resource.StatusOK}, nil
+	}
+
+	p.lock.Lock()
+	p.nextID++
+	id := resource.ID(fmt.Sprintf("%s-%d", req.URN.Name(), p.nextID))
+	p.lock.Unlock()
+	return plugin.CreateResponse{ID: id, Properties: req.Properties, Status: resource.StatusOK}, nil
+}
+
+func (p *Provider) read(ctx context.Context, req plugin.ReadRequest) (plugin.ReadResponse, error) {
+	if p.ReadF != nil {
+		return p.ReadF(ctx, req)
+	}
+	return plugin.ReadResponse{
+		ReadResult: plugin.ReadResult{
+			ID:      req.ID,
+			Inputs:  req.Inputs,
+			Outputs: req.State,
+		},
+		Status: resource.StatusOK,
+	}, nil
+}
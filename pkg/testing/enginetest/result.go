@@ -0,0 +1,147 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v3/display"
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+// Step is a step that the engine planned for a resource.
+type Step struct {
+	// Op is the step's operation, e.g. deploy.OpCreate or deploy.OpReplace.
+	Op display.StepOp
+	// URN is the URN of the resource.
+	URN resource.URN
+	// Type is the resource's type.
+	Type tokens.Type
+	// Old is the resource's state before the step, if any.
+	Old *resource.State
+	// New is the resource's state after the step, if any. During previews outputs may be unknown.
+	New *resource.State
+	// Diffs are the properties that differ between the old and new states.
+	Diffs []resource.PropertyKey
+	// Keys are the properties whose changes caused the resource to be replaced.
+	Keys []resource.PropertyKey
+	// DetailedDiff is the provider's detailed diff of the resource, if any.
+	DetailedDiff map[string]plugin.PropertyDiff
+}
+
+// Diagnostic is a message reported during an operation.
+type Diagnostic struct {
+	// URN is the resource the message is about, if any.
+	URN resource.URN
+	// Severity is the message's severity, e.g. diag.Warning.
+	Severity diag.Severity
+	// Message is the message's text, without any formatting.
+	Message string
+}
+
+// Result is the outcome of an operation on a stack.
+type Result struct {
+	// Steps are the steps the engine planned, in the order it planned them.
+	Steps []Step
+	// Changes counts the steps of each operation, as shown in the operation's summary.
+	Changes display.ResourceChanges
+	// Diagnostics are the messages reported by the engine, providers and program.
+	Diagnostics []Diagnostic
+}
+
+func (r *Result) collect(e engine.Event) {
+	switch e.Type {
+	case engine.ResourcePreEvent:
+		m := e.Payload().(engine.ResourcePreEventPayload).Metadata
+		step := Step{
+			Op:           m.Op,
+			URN:          m.URN,
+			Type:         m.Type,
+			Diffs:        m.Diffs,
+			Keys:         m.Keys,
+			DetailedDiff: m.DetailedDiff,
+		}
+		if m.Old != nil {
+			step.Old = m.Old.State
+		}
+		if m.New != nil {
+			step.New = m.New.State
+		}
+		r.Steps = append(r.Steps, step)
+	case engine.DiagEvent:
+		p := e.Payload().(engine.DiagEventPayload)
+		if p.Ephemeral {
+			return
+		}
+		r.Diagnostics = append(r.Diagnostics, Diagnostic{
+			URN:      p.URN,
+			Severity: p.Severity,
+			Message:  strings.TrimSpace(colors.Never.Colorize(p.Message)),
+		})
+	}
+}
+
+// matches returns true if the URN is the given URN or has the given name.
+func matches(urn resource.URN, name string) bool {
+	return string(urn) == name || urn.Name() == name
+}
+
+// StepsFor returns the steps for the resource with the given name or URN. A replacement is planned as several steps.
+func (r *Result) StepsFor(name string) []Step {
+	var steps []Step
+	for _, s := range r.Steps {
+		if matches(s.URN, name) {
+			steps = append(steps, s)
+		}
+	}
+	return steps
+}
+
+// Ops returns the operations planned for the resource with the given name or URN.
+func (r *Result) Ops(name string) []display.StepOp {
+	var ops []display.StepOp
+	for _, s := range r.StepsFor(name) {
+		ops = append(ops, s.Op)
+	}
+	return ops
+}
+
+// Replaced returns the URNs of the resources that are replaced.
+func (r *Result) Replaced() []resource.URN {
+	var urns []resource.URN
+	for _, s := range r.Steps {
+		if s.Op == deploy.OpReplace {
+			urns = append(urns, s.URN)
+		}
+	}
+	return urns
+}
+
+// Errors returns the messages of the error diagnostics that were reported.
+func (r *Result) Errors() []string {
+	var errs []string
+	for _, d := range r.Diagnostics {
+		if d.Severity == diag.Error {
+			errs = append(errs, d.Message)
+		}
+	}
+	return errs
+}
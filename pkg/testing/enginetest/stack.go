@@ -0,0 +1,300 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package enginetest runs Go Pulumi programs through the real deployment engine in tests, against an in-memory stack
+// and fake resource providers. Unlike pulumi.WithMocks, which fakes the resource monitor, programs run this way are
+// planned by the engine's step generator, so tests see the same diffs, replacements, aliases and protections that a
+// real deployment would. For example:
+//
+//	func TestBucketIsNotReplaced(t *testing.T) {
+//		aws := &enginetest.Provider{
+//			Package:          "aws",
+//			ReplaceOnChanges: map[string][]string{"aws:s3/bucket:Bucket": {"bucket"}},
+//		}
+//		stack := enginetest.NewStack(program, enginetest.WithProviders(aws))
+//		_, err := stack.Up()
+//		require.NoError(t, err)
+//
+//		stack.SetConfig("siteName", "renamed")
+//		preview, err := stack.Preview()
+//		require.NoError(t, err)
+//		assert.Empty(t, preview.Replaced())
+//	}
+package enginetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"runtime"
+
+	"github.com/mitchellh/copystructure"
+
+	"github.com/pulumi/pulumi/pkg/v3/display"
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/pkg/v3/util/cancel"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/promise"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// Stack is an in-memory stack that a Go program is deployed to by the engine. The stack's state carries over from
+// one operation to the next, so tests can deploy a program, change its code or configuration, and check what the
+// next preview or update would do.
+type Stack struct {
+	project   tokens.PackageName
+	name      tokens.StackName
+	program   pulumi.RunFunc
+	providers []*Provider
+	config    config.Map
+	snapshot  *deploy.Snapshot
+}
+
+// Option configures a Stack.
+type Option func(s *Stack)
+
+// WithProviders sets the fake providers that the stack's resources are managed by. Resources of packages without a
+// provider fail to deploy.
+func WithProviders(providers ...*Provider) Option {
+	return func(s *Stack) {
+		s.providers = append(s.providers, providers...)
+	}
+}
+
+// WithProject sets the name of the stack's project, which is "test" by default.
+func WithProject(name string) Option {
+	return func(s *Stack) {
+		s.project = tokens.PackageName(name)
+	}
+}
+
+// WithStackName sets the name of the stack, which is "test" by default.
+func WithStackName(name string) Option {
+	return func(s *Stack) {
+		s.name = tokens.MustParseStackName(name)
+	}
+}
+
+// WithSnapshot sets the initial state of the stack, which is empty by default.
+func WithSnapshot(snapshot *deploy.Snapshot) Option {
+	return func(s *Stack) {
+		s.snapshot = snapshot
+	}
+}
+
+// NewStack returns a new, empty stack that deploys the given program.
+func NewStack(program pulumi.RunFunc, opts ...Option) *Stack {
+	s := &Stack{
+		project: "test",
+		name:    tokens.MustParseStackName("test"),
+		program: program,
+		config:  config.Map{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// SetProgram changes the program that the stack deploys, as if its code had been changed.
+func (s *Stack) SetProgram(program pulumi.RunFunc) {
+	s.program = program
+}
+
+// SetConfig sets a configuration value. Keys without a namespace are in the namespace of the stack's project.
+func (s *Stack) SetConfig(key, value string) {
+	s.config[s.configKey(key)] = config.NewValue(value)
+}
+
+// SetSecretConfig sets a secret configuration value. Keys without a namespace are in the namespace of the stack's
+// project.
+func (s *Stack) SetSecretConfig(key, value string) {
+	ciphertext, err := config.Base64Crypter.EncryptValue(context.Background(), value)
+	contract.AssertNoErrorf(err, "base64 encryption cannot fail")
+	s.config[s.configKey(key)] = config.NewSecureValue(ciphertext)
+}
+
+func (s *Stack) configKey(key string) config.Key {
+	k, err := config.ParseKey(key)
+	if err != nil {
+		k = config.MustMakeKey(string(s.project), key)
+	}
+	return k
+}
+
+// Snapshot returns the stack's current state.
+func (s *Stack) Snapshot() *deploy.Snapshot {
+	return s.snapshot
+}
+
+// Resources returns the stack's resources, including the stack resource and any providers.
+func (s *Stack) Resources() []*resource.State {
+	if s.snapshot == nil {
+		return nil
+	}
+	return s.snapshot.Resources
+}
+
+// Resource returns the live resource with the given name or URN, or nil if the stack has no such resource.
+func (s *Stack) Resource(name string) *resource.State {
+	for _, r := range s.Resources() {
+		if !r.Delete && matches(r.URN, name) {
+			return r
+		}
+	}
+	return nil
+}
+
+// Outputs returns the stack's outputs.
+func (s *Stack) Outputs() resource.PropertyMap {
+	for _, r := range s.Resources() {
+		if r.Type == resource.RootStackType && r.Parent == "" {
+			return r.Outputs
+		}
+	}
+	return resource.PropertyMap{}
+}
+
+// Preview runs a preview of the stack's program, returning the steps that an update would take. The stack's state is
+// unchanged.
+func (s *Stack) Preview() (*Result, error) {
+	return s.run(engine.Update, true)
+}
+
+// Up deploys the stack's program, returning the steps that were taken. The stack's state is updated with the results
+// of the steps, even if the update fails part way through.
+func (s *Stack) Up() (*Result, error) {
+	return s.run(engine.Update, false)
+}
+
+// Refresh refreshes the stack's state from its providers.
+func (s *Stack) Refresh() (*Result, error) {
+	return s.run(engine.Refresh, false)
+}
+
+// Destroy deletes all of the stack's resources.
+func (s *Stack) Destroy() (*Result, error) {
+	return s.run(engine.Destroy, false)
+}
+
+type operation func(engine.UpdateInfo, *engine.Context, engine.UpdateOptions, bool) (
+	*deploy.Plan, display.ResourceChanges, error)
+
+// run runs an operation against a copy of the stack's state, saving the resulting state unless this is a dry run.
+func (s *Stack) run(op operation, dryRun bool) (*Result, error) {
+	// The engine mutates the snapshot it's given, even in previews, so it always gets a copy.
+	var base *deploy.Snapshot
+	if s.snapshot != nil {
+		copied := copystructure.Must(copystructure.Copy(*s.snapshot)).(deploy.Snapshot)
+		base = &copied
+	}
+
+	var loaders []*deploytest.ProviderLoader
+	for _, p := range s.providers {
+		loader, err := p.loader()
+		if err != nil {
+			return nil, err
+		}
+		loaders = append(loaders, loader)
+	}
+	host := deploytest.NewPluginHostF(nil, nil, deploytest.NewLanguageRuntimeF(s.runProgram), loaders...)()
+	defer contract.IgnoreClose(host)
+
+	project := &workspace.Project{
+		Name:    s.project,
+		Runtime: workspace.NewProjectRuntimeInfo("go", nil),
+	}
+	target := &deploy.Target{
+		Name:      s.name,
+		Config:    maps.Clone(s.config),
+		Decrypter: config.Base64Crypter,
+		Snapshot:  base,
+	}
+	info := engine.UpdateInfo{Root: "/", Project: project, Target: target}
+
+	cancelCtx, _ := cancel.NewContext(context.Background())
+	events := make(chan engine.Event)
+	journal := engine.NewJournal()
+	ctx := &engine.Context{
+		Cancel:          cancelCtx,
+		Events:          events,
+		SnapshotManager: journal,
+	}
+	opts := engine.UpdateOptions{
+		Host:     host,
+		Parallel: int32(runtime.NumCPU()), //nolint:gosec // NumCPU isn't going to overflow int32
+	}
+
+	collected := promise.Run(func() (*Result, error) {
+		res := &Result{}
+		for e := range events {
+			res.collect(e)
+		}
+		return res, nil
+	})
+
+	_, changes, opErr := op(info, ctx, opts, dryRun)
+	close(events)
+	closeErr := journal.Close()
+	res, err := collected.Result(context.Background())
+	contract.AssertNoErrorf(err, "collecting events cannot fail")
+	res.Changes = changes
+
+	if !dryRun {
+		snap, err := journal.Entries().Snap(base)
+		if err != nil {
+			return res, errors.Join(opErr, fmt.Errorf("building snapshot: %w", err))
+		}
+		if err := snap.VerifyIntegrity(); err != nil {
+			return res, errors.Join(opErr, fmt.Errorf("invalid snapshot: %w", err))
+		}
+		s.snapshot = snap
+	}
+	return res, errors.Join(opErr, closeErr)
+}
+
+// runProgram runs the stack's program with the Go SDK, connected to the engine's resource monitor.
+func (s *Stack) runProgram(info plugin.RunInfo, _ *deploytest.ResourceMonitor) error {
+	cfg := map[string]string{}
+	for k, v := range info.Config {
+		cfg[k.String()] = v
+	}
+	secretKeys := make([]string, 0, len(info.ConfigSecretKeys))
+	for _, k := range info.ConfigSecretKeys {
+		secretKeys = append(secretKeys, k.String())
+	}
+
+	ctx, err := pulumi.NewContext(context.Background(), pulumi.RunInfo{
+		Project:          info.Project,
+		Stack:            info.Stack,
+		Config:           cfg,
+		ConfigSecretKeys: secretKeys,
+		Parallel:         info.Parallel,
+		DryRun:           info.DryRun,
+		MonitorAddr:      info.MonitorAddress,
+		Organization:     info.Organization,
+	})
+	if err != nil {
+		return err
+	}
+	return pulumi.RunWithContext(ctx, s.program)
+}
@@ -0,0 +1,198 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/display"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/config"
+)
+
+const bucketType = "storage:index:Bucket"
+
+// bucketProgram returns a program that registers a bucket with the given name, region and options.
+func bucketProgram(name, region string, opts ...pulumi.ResourceOption) pulumi.RunFunc {
+	return func(ctx *pulumi.Context) error {
+		var bucket pulumi.CustomResourceState
+		err := ctx.RegisterResource(bucketType, name, pulumi.Map{
+			"region": pulumi.String(region),
+			"acl":    pulumi.String(config.Get(ctx, "acl")),
+		}, &bucket, opts...)
+		if err != nil {
+			return err
+		}
+		ctx.Export("bucketId", bucket.ID())
+		return nil
+	}
+}
+
+func storageProvider() *Provider {
+	return &Provider{
+		Package:          "storage",
+		ReplaceOnChanges: map[string][]string{bucketType: {"region"}},
+	}
+}
+
+func TestStackLifecycle(t *testing.T) {
+	t.Parallel()
+
+	stack := NewStack(bucketProgram("site", "us-east-1"), WithProviders(storageProvider()))
+
+	preview, err := stack.Preview()
+	require.NoError(t, err)
+	assert.Equal(t, []display.StepOp{deploy.OpCreate}, preview.Ops("site"))
+	assert.Nil(t, stack.Snapshot(), "previews don't change the stack's state")
+
+	up, err := stack.Up()
+	require.NoError(t, err)
+	assert.Equal(t, []display.StepOp{deploy.OpCreate}, up.Ops("site"))
+	bucket := stack.Resource("site")
+	require.NotNil(t, bucket)
+	assert.Equal(t, resource.ID("site-1"), bucket.ID)
+	assert.Equal(t, resource.NewStringProperty("us-east-1"), bucket.Outputs["region"])
+	assert.Equal(t, resource.NewStringProperty("site-1"), stack.Outputs()["bucketId"])
+
+	// Running the same program again changes nothing.
+	up, err = stack.Up()
+	require.NoError(t, err)
+	assert.Equal(t, []display.StepOp{deploy.OpSame}, up.Ops("site"))
+
+	// Changing configuration updates the bucket in place.
+	stack.SetConfig("acl", "private")
+	preview, err = stack.Preview()
+	require.NoError(t, err)
+	require.Equal(t, []display.StepOp{deploy.OpUpdate}, preview.Ops("site"))
+	assert.Equal(t, []resource.PropertyKey{"acl"}, preview.StepsFor("site")[0].Diffs)
+	assert.Empty(t, preview.Replaced())
+
+	// Changing the program's code so that a replace-on-change property changes replaces it.
+	stack.SetProgram(bucketProgram("site", "eu-west-1"))
+	preview, err = stack.Preview()
+	require.NoError(t, err)
+	assert.Equal(t, []resource.URN{stack.Resource("site").URN}, preview.Replaced())
+	assert.Equal(t, []resource.PropertyKey{"region"}, preview.StepsFor("site")[0].Keys)
+
+	up, err = stack.Up()
+	require.NoError(t, err)
+	assert.Equal(t, 1, up.Changes[deploy.OpReplace])
+	assert.Equal(t, resource.ID("site-2"), stack.Resource("site").ID)
+
+	destroy, err := stack.Destroy()
+	require.NoError(t, err)
+	assert.Equal(t, []display.StepOp{deploy.OpDelete}, destroy.Ops("site"))
+	assert.Nil(t, stack.Resource("site"))
+}
+
+func TestStackAliases(t *testing.T) {
+	t.Parallel()
+
+	stack := NewStack(bucketProgram("site", "us-east-1"), WithProviders(storageProvider()))
+	_, err := stack.Up()
+	require.NoError(t, err)
+
+	// Renaming the bucket without an alias would replace it, but with one it's the same resource.
+	stack.SetProgram(bucketProgram("website", "us-east-1"))
+	preview, err := stack.Preview()
+	require.NoError(t, err)
+	assert.Equal(t, []display.StepOp{deploy.OpCreate}, preview.Ops("website"))
+	assert.Equal(t, []display.StepOp{deploy.OpDelete}, preview.Ops("site"))
+
+	stack.SetProgram(bucketProgram("website", "us-east-1", pulumi.Aliases([]pulumi.Alias{{Name: pulumi.String("site")}})))
+	preview, err = stack.Preview()
+	require.NoError(t, err)
+	assert.Equal(t, []display.StepOp{deploy.OpSame}, preview.Ops("website"))
+	assert.Empty(t, preview.Ops("site"))
+}
+
+func TestStackProtect(t *testing.T) {
+	t.Parallel()
+
+	stack := NewStack(bucketProgram("site", "us-east-1", pulumi.Protect(true)), WithProviders(storageProvider()))
+	_, err := stack.Up()
+	require.NoError(t, err)
+	assert.True(t, stack.Resource("site").Protect)
+
+	// Protected resources can't be replaced.
+	stack.SetProgram(bucketProgram("site", "eu-west-1", pulumi.Protect(true)))
+	res, err := stack.Up()
+	assert.Error(t, err)
+	require.NotEmpty(t, res.Errors())
+	assert.Contains(t, res.Errors()[0], "marked for protection")
+	assert.Equal(t, resource.ID("site-1"), stack.Resource("site").ID)
+}
+
+func TestStackProviderFailures(t *testing.T) {
+	t.Parallel()
+
+	provider := storageProvider()
+	provider.CreateF = func(context.Context, plugin.CreateRequest) (plugin.CreateResponse, error) {
+		return plugin.CreateResponse{}, errors.New("quota exceeded")
+	}
+	stack := NewStack(bucketProgram("site", "us-east-1"), WithProviders(provider))
+
+	res, err := stack.Up()
+	assert.Error(t, err)
+	assert.Contains(t, res.Errors(), "quota exceeded")
+	assert.Nil(t, stack.Resource("site"))
+}
+
+func TestStackRefresh(t *testing.T) {
+	t.Parallel()
+
+	provider := storageProvider()
+	stack := NewStack(bucketProgram("site", "us-east-1"), WithProviders(provider))
+	_, err := stack.Up()
+	require.NoError(t, err)
+
+	// The bucket is deleted out of band.
+	provider.ReadF = func(context.Context, plugin.ReadRequest) (plugin.ReadResponse, error) {
+		return plugin.ReadResponse{Status: resource.StatusOK}, nil
+	}
+	_, err = stack.Refresh()
+	require.NoError(t, err)
+	assert.Nil(t, stack.Resource("site"))
+
+	up, err := stack.Up()
+	require.NoError(t, err)
+	assert.Equal(t, []display.StepOp{deploy.OpCreate}, up.Ops("site"))
+}
+
+func TestStackSecretConfig(t *testing.T) {
+	t.Parallel()
+
+	stack := NewStack(func(ctx *pulumi.Context) error {
+		var bucket pulumi.CustomResourceState
+		return ctx.RegisterResource(bucketType, "site", pulumi.Map{
+			"acl": config.RequireSecret(ctx, "acl"),
+		}, &bucket)
+	}, WithProviders(storageProvider()))
+	stack.SetSecretConfig("acl", "private")
+	_, err := stack.Up()
+	require.NoError(t, err)
+
+	acl := stack.Resource("site").Inputs["acl"]
+	require.True(t, acl.IsSecret())
+	assert.Equal(t, resource.NewStringProperty("private"), acl.SecretValue().Element)
+}
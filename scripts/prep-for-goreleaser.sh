@@ -38,6 +38,9 @@ install_file sdk/python/dist/pulumi-analyzer-policy-python.cmd              wind
 install_file sdk/python/dist/pulumi-resource-pulumi-python                  linux   darwin
 install_file sdk/python/dist/pulumi-resource-pulumi-python.cmd              windows
 
+install_file sdk/go/dist/pulumi-resource-pulumi-go                          linux   darwin
+install_file sdk/go/dist/pulumi-resource-pulumi-go.cmd                      windows
+
 install_file sdk/python/cmd/pulumi-language-python-exec          linux darwin windows
 
 # Get pulumi-watch binaries
@@ -0,0 +1,251 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tail"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// LogSegments returns the rotated segments of the event log at the given path, ordered from oldest to newest.
+// Rotated segments are named `<path>.<N>` (or `<path>.<N>.gz` when compressed), where N increases monotonically
+// with each rotation. The live log at path itself is not included in the result.
+func LogSegments(path string) ([]string, error) {
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		return nil, err
+	}
+
+	indices := map[string]int{}
+	var segments []string
+	for _, m := range matches {
+		index, ok := segmentIndex(path, m)
+		if !ok {
+			continue
+		}
+		indices[m] = index
+		segments = append(segments, m)
+	}
+	sort.Slice(segments, func(i, j int) bool { return indices[segments[i]] < indices[segments[j]] })
+	return segments, nil
+}
+
+// segmentIndex returns the rotation index of a segment of the event log at the given path. The index of a segment
+// does not change when it is compressed.
+func segmentIndex(path, segment string) (int, bool) {
+	suffix := strings.TrimSuffix(strings.TrimPrefix(segment, path+"."), ".gz")
+	index, err := strconv.Atoi(suffix)
+	if err != nil || index <= 0 {
+		return 0, false
+	}
+	return index, true
+}
+
+// ReadLog reads every event in the event log at the given path, including any rotated segments, in the order they
+// were written. The callback is invoked once per event; returning an error from it stops reading and returns that
+// error. Events that cannot be decoded are passed to the callback with their Error field set.
+func ReadLog(path string, fn func(EngineEvent) error) error {
+	segments, err := LogSegments(path)
+	if err != nil {
+		return err
+	}
+	for _, segment := range segments {
+		if err := readLogFile(segment, fn); err != nil {
+			return err
+		}
+	}
+	return readLogFile(path, fn)
+}
+
+func readLogFile(path string, fn func(EngineEvent) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer contract.IgnoreClose(f)
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
+		defer contract.IgnoreClose(zr)
+		r = zr
+	}
+
+	scanner := bufio.NewScanner(r)
+	// Events carrying large resource states can easily exceed bufio's default line limit.
+	scanner.Buffer(nil, 64*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if err := fn(decodeEvent(line)); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	return nil
+}
+
+func decodeEvent(line []byte) EngineEvent {
+	var e apitype.EngineEvent
+	if err := json.Unmarshal(line, &e); err != nil {
+		return EngineEvent{Error: err}
+	}
+	return EngineEvent{EngineEvent: e}
+}
+
+// TailLog streams the events in the event log at the given path to out. Rotated segments that already exist are
+// read first, after which the live log is followed, including across rotations, until either a cancel event is
+// observed or the context is canceled. When the context is canceled, any events already written to the live log are
+// still delivered before TailLog returns. The out channel is not closed.
+func TailLog(ctx context.Context, path string, out chan<- EngineEvent) error {
+	send := func(e EngineEvent) error {
+		out <- e
+		if e.CancelEvent != nil {
+			return errStopTail
+		}
+		return nil
+	}
+
+	t, err := openLiveLog(ctx, path, send)
+	if err != nil {
+		if errors.Is(err, errStopTail) {
+			return nil
+		}
+		return err
+	} else if t == nil {
+		return nil
+	}
+	defer t.Cleanup()
+
+	canceled := ctx.Done()
+	for {
+		select {
+		case <-canceled:
+			// Keep reading until the tailer reaches the end of the live log. StopAtEOF blocks until the tailer
+			// exits, so it must not be called from this goroutine.
+			go func() { contract.IgnoreError(t.StopAtEOF()) }()
+			canceled = nil
+		case line, ok := <-t.Lines:
+			if !ok {
+				return nil
+			}
+			e := EngineEvent{Error: line.Err}
+			if line.Err == nil {
+				e = decodeEvent([]byte(line.Text))
+			}
+			if send(e) != nil {
+				// The cancel event is the last event in the log, so any error from stopping the tailer, such as the
+				// one reported if it was already stopping at the end of the file, doesn't matter.
+				contract.IgnoreError(t.Stop())
+				return nil
+			}
+		}
+	}
+}
+
+var errStopTail = errors.New("stop tailing")
+
+// liveLogPollInterval is how often openLiveLog checks whether the live log has been created.
+const liveLogPollInterval = 100 * time.Millisecond
+
+// openLiveLog reads the rotated segments of the event log at the given path and then starts tailing the live log. If
+// the log is rotated between listing its segments and opening the live log, the newly rotated segment would be
+// neither read nor followed, so the segments are listed again once the live log is open and the process is repeated
+// until no rotation has raced with it. Segments are identified by their index, so a segment that is compressed after
+// it has been read is not read again. openLiveLog returns nil if the context is canceled before the live log exists.
+func openLiveLog(ctx context.Context, path string, send func(EngineEvent) error) (*tail.Tail, error) {
+	read := map[int]bool{}
+	readSegments := func() (bool, error) {
+		segments, err := LogSegments(path)
+		if err != nil {
+			return false, err
+		}
+		rotated := false
+		for _, segment := range segments {
+			index, _ := segmentIndex(path, segment)
+			if read[index] {
+				continue
+			}
+			rotated = true
+			if err := readLogFile(segment, send); err != nil {
+				return false, err
+			}
+			read[index] = true
+		}
+		return rotated, nil
+	}
+
+	for {
+		if _, err := readSegments(); err != nil {
+			return nil, err
+		}
+
+		t, err := tail.File(path, tail.Config{
+			Follow:        true,
+			ReOpen:        true,
+			MustExist:     true,
+			Poll:          runtime.GOOS == "windows", // on Windows poll for file changes instead of using the default inotify
+			Logger:        tail.DiscardingLogger,
+			CompleteLines: true,
+		})
+		if errors.Is(err, fs.ErrNotExist) {
+			select {
+			case <-ctx.Done():
+				return nil, nil
+			case <-time.After(liveLogPollInterval):
+				continue
+			}
+		} else if err != nil {
+			return nil, err
+		}
+
+		rotated, err := readSegments()
+		if err != nil || rotated {
+			// The tailer may have opened the segment that was just read rather than the new live log, so start
+			// again. Nothing has been received from the tailer yet, so no events are lost or repeated.
+			contract.IgnoreError(t.Stop())
+			t.Cleanup()
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		return t, nil
+	}
+}
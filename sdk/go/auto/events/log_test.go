@@ -0,0 +1,178 @@
+// Copyright 2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeGzip(t *testing.T, path, contents string) {
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	zw := gzip.NewWriter(f)
+	_, err = zw.Write([]byte(contents))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	require.NoError(t, f.Close())
+}
+
+// writeSegment writes a rotated segment of an event log. As with the CLI, the segment is moved into place so that it
+// never appears partially written.
+func writeSegment(t *testing.T, path, contents string) {
+	require.NoError(t, os.WriteFile(path+".tmp", []byte(contents), 0o600))
+	require.NoError(t, os.Rename(path+".tmp", path))
+}
+
+func TestLogSegments(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "events.json")
+	for _, name := range []string{"events.json", "events.json.10.gz", "events.json.2", "events.json.bak", "other.1"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o600))
+	}
+
+	segments, err := LogSegments(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{path + ".2", path + ".10.gz"}, segments)
+}
+
+func TestReadLog(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "events.json")
+	writeGzip(t, path+".1.gz", "{\"sequence\":0}\n{\"sequence\":1}\n")
+	require.NoError(t, os.WriteFile(path+".2", []byte("{\"sequence\":2}\n"), 0o600))
+	require.NoError(t, os.WriteFile(path, []byte("{\"sequence\":3}\nnot json\n"), 0o600))
+
+	var sequences []int
+	var errs int
+	err := ReadLog(path, func(e EngineEvent) error {
+		if e.Error != nil {
+			errs++
+			return nil
+		}
+		sequences = append(sequences, e.Sequence)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3}, sequences)
+	assert.Equal(t, 1, errs)
+}
+
+func TestTailLog(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "events.json")
+	writeGzip(t, path+".1.gz", "{\"sequence\":0}\n")
+	require.NoError(t, os.WriteFile(path, []byte("{\"sequence\":1}\n"), 0o600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	out := make(chan EngineEvent)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- TailLog(ctx, path, out)
+		close(out)
+	}()
+
+	assert.Equal(t, 0, (<-out).Sequence)
+	assert.Equal(t, 1, (<-out).Sequence)
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString("{\"sequence\":2,\"cancelEvent\":{}}\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	last := <-out
+	assert.Equal(t, 2, last.Sequence)
+	assert.NotNil(t, last.CancelEvent)
+
+	_, ok := <-out
+	assert.False(t, ok)
+	require.NoError(t, <-errc)
+}
+
+func TestTailLogFollowsRotation(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "events.json")
+	require.NoError(t, os.WriteFile(path+".1", []byte("{\"sequence\":0}\n"), 0o600))
+	require.NoError(t, os.WriteFile(path, []byte("{\"sequence\":1}\n"), 0o600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	out := make(chan EngineEvent)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- TailLog(ctx, path, out)
+		close(out)
+	}()
+
+	assert.Equal(t, 0, (<-out).Sequence)
+	assert.Equal(t, 1, (<-out).Sequence)
+
+	// Rotate and compress the live log, then start a new one.
+	require.NoError(t, os.Rename(path, path+".2"))
+	writeGzip(t, path+".2.gz", "{\"sequence\":1}\n")
+	require.NoError(t, os.Remove(path+".2"))
+	require.NoError(t, os.WriteFile(path, []byte("{\"sequence\":2,\"cancelEvent\":{}}\n"), 0o600))
+
+	last := <-out
+	assert.Equal(t, 2, last.Sequence)
+	assert.NotNil(t, last.CancelEvent)
+
+	_, ok := <-out
+	assert.False(t, ok)
+	require.NoError(t, <-errc)
+}
+
+func TestTailLogWaitsForLiveLog(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "events.json")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	out := make(chan EngineEvent)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- TailLog(ctx, path, out)
+		close(out)
+	}()
+
+	// The log is rotated before the tailer could have opened it, so its first segment must still be read.
+	writeSegment(t, path+".1", "{\"sequence\":0}\n")
+	require.NoError(t, os.WriteFile(path, []byte("{\"sequence\":1,\"cancelEvent\":{}}\n"), 0o600))
+
+	assert.Equal(t, 0, (<-out).Sequence)
+	assert.Equal(t, 1, (<-out).Sequence)
+
+	_, ok := <-out
+	assert.False(t, ok)
+	require.NoError(t, <-errc)
+}
@@ -127,7 +127,6 @@ import (
 	"github.com/pulumi/pulumi/sdk/v3/go/common/constant"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/slice"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/tail"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
@@ -1806,53 +1805,39 @@ func (s *languageRuntimeServer) InstallDependencies(
 }
 
 type fileWatcher struct {
-	Filename  string
-	tail      *tail.Tail
-	receivers []chan<- events.EngineEvent
-	done      chan bool
+	Filename string
+	cancel   context.CancelFunc
+	done     chan bool
 }
 
+// watchFile streams the events written to the event log at the given path to each of the receivers, following the
+// log across rotations. The receivers are closed once the watcher is closed or the log ends with a cancel event.
 func watchFile(path string, receivers []chan<- events.EngineEvent) (*fileWatcher, error) {
-	t, err := tail.File(path, tail.Config{
-		Follow:        true,
-		Poll:          runtime.GOOS == "windows", // on Windows poll for file changes instead of using the default inotify
-		Logger:        tail.DiscardingLogger,
-		CompleteLines: true,
-	})
-	if err != nil {
-		return nil, err
-	}
+	ctx, cancel := context.WithCancel(context.Background())
+	logEvents := make(chan events.EngineEvent)
+	go func() {
+		if err := events.TailLog(ctx, path, logEvents); err != nil {
+			logEvents <- events.EngineEvent{Error: err}
+		}
+		close(logEvents)
+	}()
+
 	done := make(chan bool)
-	go func(tailedLog *tail.Tail) {
-		for line := range tailedLog.Lines {
-			if line.Err != nil {
-				for _, r := range receivers {
-					r <- events.EngineEvent{Error: line.Err}
-				}
-				continue
-			}
-			var e apitype.EngineEvent
-			err = json.Unmarshal([]byte(line.Text), &e)
-			if err != nil {
-				for _, r := range receivers {
-					r <- events.EngineEvent{Error: err}
-				}
-				continue
-			}
+	go func() {
+		for e := range logEvents {
 			for _, r := range receivers {
-				r <- events.EngineEvent{EngineEvent: e}
+				r <- e
 			}
 		}
 		for _, r := range receivers {
 			close(r)
 		}
 		close(done)
-	}(t)
+	}()
 	return &fileWatcher{
-		Filename:  t.Filename,
-		tail:      t,
-		receivers: receivers,
-		done:      done,
+		Filename: path,
+		cancel:   cancel,
+		done:     done,
 	}, nil
 }
 
@@ -1872,18 +1857,15 @@ func tailLogs(command string, receivers []chan<- events.EngineEvent) (*fileWatch
 }
 
 func (fw *fileWatcher) Close() {
-	if fw.tail == nil {
+	if fw.cancel == nil {
 		return
 	}
 
-	// Tell the watcher to end on next EoF, wait for the done event, then cleanup.
-	//nolint:errcheck
-	fw.tail.StopAtEOF()
+	// Tell the watcher to stop once it has read the rest of the log, wait for it to finish, then clean up.
+	fw.cancel()
 	<-fw.done
-	logDir := filepath.Dir(fw.tail.Filename)
-	fw.tail.Cleanup()
-	os.RemoveAll(logDir)
+	os.RemoveAll(filepath.Dir(fw.Filename))
 
 	// set to nil so we can safely close again in defer
-	fw.tail = nil
+	fw.cancel = nil
 }
@@ -186,6 +186,39 @@ func TestAlwaysReadsCompleteLine(t *testing.T) {
 	assert.Equal(t, "red", event2.StdoutEvent.Color)
 }
 
+func TestWatchFileFollowsRotation(t *testing.T) {
+	t.Parallel()
+
+	tmpFile := filepath.Join(t.TempDir(), "eventlog.txt")
+	require.NoError(t, os.WriteFile(tmpFile, []byte(`{"sequence": 0}`+"\n"), 0o600))
+
+	engineEvents := make(chan events.EngineEvent, 20)
+	watcher, err := watchFile(tmpFile, []chan<- events.EngineEvent{engineEvents})
+	require.NoError(t, err)
+	defer watcher.Close()
+
+	event := <-engineEvents
+	require.NoError(t, event.Error)
+	assert.Equal(t, 0, event.Sequence)
+
+	// Rotate the log in the same way as the CLI: the live log is renamed to the next segment and a new live log is
+	// started in its place.
+	f, err := os.OpenFile(tmpFile, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"sequence": 1}` + "\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.NoError(t, os.Rename(tmpFile, tmpFile+".1"))
+	require.NoError(t, os.WriteFile(tmpFile, []byte(`{"sequence": 2, "cancelEvent": {}}`+"\n"), 0o600))
+
+	var sequences []int
+	for e := range engineEvents {
+		require.NoError(t, e.Error)
+		sequences = append(sequences, e.Sequence)
+	}
+	assert.Equal(t, []int{1, 2}, sequences)
+}
+
 func TestUpOptsConfigFileNestedSecretLocalBackend(t *testing.T) {
 	t.Parallel()
 
@@ -372,6 +372,13 @@ type ResourceV3 struct {
 	ViewOf resource.URN `json:"viewOf,omitempty" yaml:"viewOf,omitempty"`
 	// ResourceHooks is a map of hook types to lists of hook names for the given type.
 	ResourceHooks map[resource.HookType][]string `json:"resourceHooks,omitempty" yaml:"resourceHooks,omitempty"`
+	// Expires is the time after which the resource may be deleted by `pulumi stack reap`.
+	Expires *time.Time `json:"expires,omitempty" yaml:"expires,omitempty"`
+	// Annotations is user-defined key/value metadata stored with the resource. It is never sent to the provider.
+	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	// StateAnnotations are the annotations that were set with `pulumi state annotate` rather than by the program. They
+	// are included in Annotations, and are kept there when the program changes its own annotations.
+	StateAnnotations map[string]string `json:"stateAnnotations,omitempty" yaml:"stateAnnotations,omitempty"`
 }
 
 // ManifestV1 captures meta-information about this checkpoint file, such as versions of binaries, etc.
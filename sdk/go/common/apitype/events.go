@@ -97,6 +97,25 @@ type SummaryEvent struct {
 	// compatibility. For older clients this will map to the version, while for newer ones
 	// it will be the version tag prepended with "v".
 	PolicyPacks map[string]string `json:"PolicyPacks"`
+	// RenameSuggestions lists resources that are being created and deleted in the same operation, but which look
+	// like a single resource that has been renamed or reparented.
+	RenameSuggestions []RenameSuggestion `json:"renameSuggestions,omitempty"`
+}
+
+// RenameSuggestion describes a resource that the engine believes has been renamed or reparented, because a resource
+// of the same type with identical or near-identical inputs is being deleted as it is created. Adding an alias from
+// the new URN to the old one updates the existing resource instead of replacing it.
+type RenameSuggestion struct {
+	// URN is the URN of the resource being created.
+	URN string `json:"urn"`
+	// OldURN is the URN of the resource being deleted, which should be added as an alias.
+	OldURN string `json:"oldUrn"`
+	// Type is the type of both resources.
+	Type string `json:"type"`
+	// Similarity is the fraction of the resources' inputs that are equal, between 0 and 1.
+	Similarity float64 `json:"similarity"`
+	// ChangedInputs lists the inputs that differ between the two resources, if any.
+	ChangedInputs []string `json:"changedInputs,omitempty"`
 }
 
 // DiffKind describes the kind of a particular property diff.
@@ -178,6 +197,8 @@ type StepEventStateMetadata struct {
 	Outputs map[string]interface{} `json:"outputs"`
 	// Provider is the resource's provider reference
 	Provider string `json:"provider"`
+	// Dependencies is the set of resources that this resource depends on.
+	Dependencies []string `json:"dependencies,omitempty"`
 	// InitErrors is the set of errors encountered in the process of initializing resource.
 	InitErrors []string `json:"initErrors,omitempty"`
 }
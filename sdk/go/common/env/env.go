@@ -144,6 +144,11 @@ var RunProgram = env.Bool("RUN_PROGRAM",
 // will capture any GitHub-hosted plugin and redirect to its corresponding folder under https://foo.com/downloads
 var PluginDownloadURLOverrides = env.String("PLUGIN_DOWNLOAD_URL_OVERRIDES", "")
 
+// PluginMirror is a directory (or file:// URL) of plugin archives, as created by `pulumi plugin mirror`. Plugins are
+// installed from the mirror if it has them, before falling back to downloading them.
+var PluginMirror = env.String("PLUGIN_MIRROR", "A directory of plugin archives to install plugins from "+
+	"before downloading them.")
+
 // Environment variables that affect the DIY backend.
 var (
 	DIYBackendNoLegacyWarning = env.Bool("DIY_BACKEND_NO_LEGACY_WARNING",
@@ -0,0 +1,58 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseExpiry parses the expiry of a resource, which is either an RFC 3339 time or a time to live relative to the
+// time the resource was created, such as "36h" or "7d". Times to live accept any Go duration, as well as whole
+// numbers of days ("d") and weeks ("w").
+func ParseExpiry(expiry string, created time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, expiry); err == nil {
+		return t.UTC(), nil
+	}
+	ttl, err := ParseTTL(expiry)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid expiry %q: expected an RFC 3339 time or a duration such as 7d", expiry)
+	}
+	return created.Add(ttl).UTC(), nil
+}
+
+// ParseTTL parses a time to live, which is a Go duration such as "36h", or a whole number of days or weeks such as
+// "7d" or "2w".
+func ParseTTL(ttl string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(ttl, suffix); ok {
+			count, err := strconv.ParseUint(n, 10, 32)
+			if err != nil || count == 0 {
+				return 0, fmt.Errorf("invalid time to live %q", ttl)
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(ttl)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time to live %q", ttl)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid time to live %q: must be positive", ttl)
+	}
+	return d, nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExpiry(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := map[string]time.Time{
+		"2024-06-01T00:00:00Z":      time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		"2024-06-01T02:00:00+02:00": time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		"36h":                       created.Add(36 * time.Hour),
+		"90m":                       created.Add(90 * time.Minute),
+		"7d":                        created.Add(7 * 24 * time.Hour),
+		"2w":                        created.Add(14 * 24 * time.Hour),
+	}
+	for expiry, expected := range cases {
+		actual, err := ParseExpiry(expiry, created)
+		require.NoError(t, err, expiry)
+		assert.Equal(t, expected, actual, expiry)
+	}
+
+	for _, expiry := range []string{"", "tomorrow", "-1h", "0s", "0d", "1.5d", "2024-06-01"} {
+		_, err := ParseExpiry(expiry, created)
+		assert.Error(t, err, expiry)
+	}
+}
@@ -69,6 +69,8 @@ type AnalyzerStackResource struct {
 	Parent               resource.URN                            // an optional parent URN for this resource.
 	Dependencies         []resource.URN                          // dependencies of this resource object.
 	PropertyDependencies map[resource.PropertyKey][]resource.URN // the set of dependencies that affect each property.
+	Custom               bool                                    // true for custom resources, false for components.
+	Inputs               resource.PropertyMap                    // the resource's inputs; Properties are its outputs.
 }
 
 // AnalyzerResourceOptions mirrors resource options sent to the analyzer.
@@ -81,6 +83,7 @@ type AnalyzerResourceOptions struct {
 	Aliases                 []resource.Alias        // additional URNs that should be aliased to this resource.
 	CustomTimeouts          resource.CustomTimeouts // an optional config object for resource options
 	Parent                  resource.URN            // an optional parent URN for this resource.
+	Annotations             map[string]string       // user-defined metadata stored with the resource.
 }
 
 // AnalyzerProviderResource mirrors a resource's provider sent to the analyzer.
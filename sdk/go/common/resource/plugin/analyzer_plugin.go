@@ -340,6 +340,12 @@ func (a *analyzer) AnalyzeStack(resources []AnalyzerStackResource) ([]AnalyzeDia
 			return nil, fmt.Errorf("marshalling properties: %w", err)
 		}
 
+		inputs, err := MarshalProperties(resource.Inputs,
+			MarshalOptions{KeepUnknowns: true, KeepSecrets: true, SkipInternalKeys: true})
+		if err != nil {
+			return nil, fmt.Errorf("marshalling inputs: %w", err)
+		}
+
 		provider, err := marshalProvider(resource.Provider)
 		if err != nil {
 			return nil, err
@@ -371,6 +377,8 @@ func (a *analyzer) AnalyzeStack(resources []AnalyzerStackResource) ([]AnalyzeDia
 			Parent:               string(resource.Parent),
 			Dependencies:         convertURNs(resource.Dependencies),
 			PropertyDependencies: propertyDeps,
+			Custom:               &resource.Custom,
+			Inputs:               inputs,
 		}
 	}
 
@@ -672,7 +680,8 @@ func marshalResourceOptions(opts AnalyzerResourceOptions) *pulumirpc.AnalyzerRes
 			Update: opts.CustomTimeouts.Update,
 			Delete: opts.CustomTimeouts.Delete,
 		},
-		Parent: string(opts.Parent),
+		Parent:      string(opts.Parent),
+		Annotations: opts.Annotations,
 	}
 	return result
 }
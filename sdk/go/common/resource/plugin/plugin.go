@@ -731,5 +731,6 @@ func (p *Plugin) Close() error {
 
 func isDynamicPluginBinary(path string) bool {
 	return strings.HasSuffix(path, "pulumi-resource-pulumi-nodejs") ||
-		strings.HasSuffix(path, "pulumi-resource-pulumi-python")
+		strings.HasSuffix(path, "pulumi-resource-pulumi-python") ||
+		strings.HasSuffix(path, "pulumi-resource-pulumi-go")
 }
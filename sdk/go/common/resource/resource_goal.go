@@ -45,6 +45,10 @@ type Goal struct {
 	DeletedWith    URN
 	SourcePosition string                // If set, the source location of the resource registration
 	ResourceHooks  map[HookType][]string // The resource hooks attached to the resource, by type.
+	// if set, an RFC 3339 time or a time to live after which the resource may be deleted by `pulumi stack reap`.
+	Expires string
+	// user-defined key/value metadata that is stored with the resource but never sent to its provider.
+	Annotations map[string]string
 }
 
 // NewGoal allocates a new resource goal state.
@@ -62,6 +62,9 @@ type State struct {
 	RefreshBeforeUpdate     bool                  // true if this resource should always be refreshed prior to updates.
 	ViewOf                  URN                   // If set, the URN of the resource this resource is a view of.
 	ResourceHooks           map[HookType][]string // The resource hooks attached to the resource, by type.
+	Expires                 *time.Time            // If set, the time after which the resource may be deleted by `pulumi stack reap`.
+	Annotations             map[string]string     // User-defined metadata stored with the resource, never sent to providers.
+	StateAnnotations        map[string]string     // The annotations set by `pulumi state annotate`, kept across updates.
 }
 
 // Copy creates a deep copy of the resource state, except without copying the lock.
@@ -96,6 +99,9 @@ func (s *State) Copy() *State {
 		RefreshBeforeUpdate:     s.RefreshBeforeUpdate,
 		ViewOf:                  s.ViewOf,
 		ResourceHooks:           s.ResourceHooks,
+		Expires:                 s.Expires,
+		Annotations:             s.Annotations,
+		StateAnnotations:        s.StateAnnotations,
 	}
 }
 
@@ -119,7 +125,7 @@ func NewState(t tokens.Type, urn URN, custom bool, del bool, id ID,
 	additionalSecretOutputs []PropertyKey, aliases []URN, timeouts *CustomTimeouts,
 	importID ID, retainOnDelete bool, deletedWith URN, created *time.Time, modified *time.Time,
 	sourcePosition string, ignoreChanges []string, replaceOnChanges []string, refreshBeforeUpdate bool,
-	viewOf URN, resourceHooks map[HookType][]string,
+	viewOf URN, resourceHooks map[HookType][]string, expires *time.Time, annotations map[string]string,
 ) *State {
 	contract.Assertf(t != "", "type was empty")
 	contract.Assertf(custom || id == "", "is custom or had empty ID")
@@ -153,6 +159,8 @@ func NewState(t tokens.Type, urn URN, custom bool, del bool, id ID,
 		RefreshBeforeUpdate:     refreshBeforeUpdate,
 		ViewOf:                  viewOf,
 		ResourceHooks:           resourceHooks,
+		Expires:                 expires,
+		Annotations:             annotations,
 	}
 
 	if timeouts != nil {
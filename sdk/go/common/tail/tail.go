@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log"
 	"os"
 	"strings"
@@ -376,15 +377,29 @@ func (tail *Tail) tailFileSync() {
 // moved or truncated. When moved or deleted - the file will be
 // reopened if ReOpen is true. Truncated files are always reopened.
 func (tail *Tail) waitForChanges() error {
+	pos, err := tail.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
 	if tail.changes == nil {
-		pos, err := tail.file.Seek(0, io.SeekCurrent)
-		if err != nil {
-			return err
-		}
-		tail.changes, err = tail.watcher.ChangeEvents(&tail.Tomb, pos)
+		changes, err := tail.watcher.ChangeEvents(&tail.Tomb, pos)
 		if err != nil {
+			// If the file was moved or deleted before the watcher could start watching it, read what's left of it
+			// and then reopen it.
+			if errors.Is(err, fs.ErrNotExist) {
+				if changed, err := tail.checkMissedChanges(pos); changed || err != nil {
+					return err
+				}
+			}
 			return err
 		}
+		tail.changes = changes
+	}
+
+	// The watcher only reports changes made after it started watching the file that is currently at the path, so
+	// check for any that were made before then, e.g. a write followed by a rename before the watcher started.
+	if changed, err := tail.checkMissedChanges(pos); changed || err != nil {
+		return err
 	}
 
 	select {
@@ -393,6 +408,11 @@ func (tail *Tail) waitForChanges() error {
 	case <-tail.changes.Deleted:
 		tail.changes = nil
 		if tail.ReOpen {
+			// If checkMissedChanges has already reopened the file, this notification is for the file that was moved
+			// away, which has been read to its end.
+			if tail.isCurrent() {
+				return nil
+			}
 			// XXX: we must not log from a library.
 			tail.Logger.Printf("Re-opening moved/deleted file %s ...", tail.Filename)
 			if err := tail.reopen(); err != nil {
@@ -418,6 +438,40 @@ func (tail *Tail) waitForChanges() error {
 	}
 }
 
+// checkMissedChanges returns true if the file has grown past pos, or if it has been moved or deleted and has since
+// been reopened.
+func (tail *Tail) checkMissedChanges(pos int64) (bool, error) {
+	fi, err := tail.file.Stat()
+	if err != nil {
+		// Leave it to the watcher to report what happened to the file.
+		return false, nil
+	}
+	if fi.Size() > pos {
+		return true, nil
+	}
+	if !tail.ReOpen || tail.isCurrent() {
+		return false, nil
+	}
+
+	tail.Logger.Printf("Re-opening moved/deleted file %s ...", tail.Filename)
+	if err := tail.reopen(); err != nil {
+		return true, err
+	}
+	tail.Logger.Printf("Successfully reopened %s", tail.Filename)
+	tail.openReader()
+	return true, nil
+}
+
+// isCurrent returns true if the open file is the one currently at the tailed path.
+func (tail *Tail) isCurrent() bool {
+	fi, err := tail.file.Stat()
+	if err != nil {
+		return false
+	}
+	pathFi, err := os.Stat(tail.Filename)
+	return err == nil && os.SameFile(fi, pathFi)
+}
+
 func (tail *Tail) openReader() {
 	tail.lk.Lock()
 	if tail.MaxLineSize > 0 {
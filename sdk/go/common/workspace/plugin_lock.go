@@ -0,0 +1,471 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/blang/semver"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/encoding"
+)
+
+// PluginLockFile is the name of the file, next to Pulumi.yaml, that pins the plugins used by a project.
+const PluginLockFile = "Pulumi.lock"
+
+// pluginLockVersion is the current version of the plugin lock file format.
+const pluginLockVersion = 1
+
+// defaultPluginServer is the download URL recorded for plugins that don't specify one. Such plugins are downloaded
+// from Pulumi's GitHub organization, falling back to get.pulumi.com.
+const defaultPluginServer = "github://api.github.com/pulumi"
+
+// pluginServer returns the download URL that the lock records for the given plugin.
+func pluginServer(spec PluginSpec) string {
+	if spec.PluginDownloadURL == "" {
+		return defaultPluginServer
+	}
+	return spec.PluginDownloadURL
+}
+
+// PluginLock pins the plugins that a project uses to exact versions, download servers and, for each platform the
+// plugin has been installed on, the SHA-256 checksum of the plugin archive. It is safe for concurrent use.
+type PluginLock struct {
+	// Version is the version of the lock file format.
+	Version int `json:"version" yaml:"version"`
+	// Plugins are the locked plugins, ordered by kind, name and version.
+	Plugins []*PluginLockEntry `json:"plugins,omitempty" yaml:"plugins,omitempty"`
+
+	path    string
+	changed bool
+	// update is true if plugins may be used at versions other than their locked ones, see AllowUpdates.
+	update bool
+	// recorded are the entries recorded since the lock was loaded, which are kept when another version of the same
+	// plugin is recorded.
+	recorded map[*PluginLockEntry]bool
+	mu       sync.Mutex
+}
+
+// PluginLockEntry pins a single version of a plugin.
+type PluginLockEntry struct {
+	Name    string             `json:"name" yaml:"name"`
+	Kind    apitype.PluginKind `json:"kind" yaml:"kind"`
+	Version string             `json:"version" yaml:"version"`
+	// Server is the URL the plugin is downloaded from.
+	Server string `json:"server,omitempty" yaml:"server,omitempty"`
+	// Parameterizations are the packages the project uses that are provided by parameterizing this plugin.
+	Parameterizations []PluginLockParameterization `json:"parameterizations,omitempty" yaml:"parameterizations,omitempty"`
+	// Checksums are the hex encoded SHA-256 checksums of the plugin archive, keyed by "$os-$arch", e.g. "linux-amd64".
+	Checksums map[string]string `json:"checksums,omitempty" yaml:"checksums,omitempty"`
+}
+
+// PluginLockParameterization records a package that is provided by parameterizing a plugin.
+type PluginLockParameterization struct {
+	Name    string `json:"name" yaml:"name"`
+	Version string `json:"version" yaml:"version"`
+	// Value is the base64 encoded parameter value passed to the plugin.
+	Value string `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+// LoadPluginLock loads the plugin lock file of the project in the given root directory. If the project doesn't have a
+// lock file yet, an empty lock is returned which will be written to the root directory when saved.
+func LoadPluginLock(root string) (*PluginLock, error) {
+	path := filepath.Join(root, PluginLockFile)
+	lock := &PluginLock{Version: pluginLockVersion, path: path}
+
+	b, err := readFileStripUTF8BOM(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return lock, nil
+		}
+		return nil, err
+	}
+	if err := encoding.YAML.Unmarshal(b, lock); err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", path, err)
+	}
+	if lock.Version > pluginLockVersion {
+		return nil, fmt.Errorf("%s has version %d, which is not supported by this version of Pulumi; "+
+			"please upgrade", path, lock.Version)
+	}
+	lock.Version = pluginLockVersion
+	return lock, nil
+}
+
+// AllowUpdates allows plugins to be used at versions other than the ones they're locked to. Recording such a plugin
+// replaces its locked versions with the new one.
+func (l *PluginLock) AllowUpdates() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.update = true
+}
+
+// Path returns the path of the lock file.
+func (l *PluginLock) Path() string {
+	return l.path
+}
+
+// Save writes the lock file if it has changed since it was loaded.
+func (l *PluginLock) Save() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.changed {
+		return nil
+	}
+	b, err := encoding.YAML.Marshal(l)
+	if err != nil {
+		return err
+	}
+	//nolint:gosec // the lock file is meant to be checked in alongside Pulumi.yaml
+	if err := os.WriteFile(l.path, b, 0o644); err != nil {
+		return err
+	}
+	l.changed = false
+	return nil
+}
+
+func (l *PluginLock) find(kind apitype.PluginKind, name, version string) *PluginLockEntry {
+	for _, e := range l.Plugins {
+		if e.Kind == kind && e.Name == name && e.Version == version {
+			return e
+		}
+	}
+	return nil
+}
+
+// latest returns the entry for the highest locked version of the given plugin.
+func (l *PluginLock) latest(kind apitype.PluginKind, name string) *PluginLockEntry {
+	var latest *PluginLockEntry
+	var latestVersion semver.Version
+	for _, e := range l.Plugins {
+		if e.Kind != kind || e.Name != name {
+			continue
+		}
+		v, err := semver.ParseTolerant(e.Version)
+		if err != nil {
+			continue
+		}
+		if latest == nil || v.GT(latestVersion) {
+			latest, latestVersion = e, v
+		}
+	}
+	return latest
+}
+
+// Pin applies the lock to the given plugin. A plugin without a version is pinned to the highest locked version of
+// that plugin. If the plugin's version is locked, the locked download server and checksums are applied to the plugin,
+// so that downloading it fails if the archive doesn't match. An error is returned if the plugin asks for a different
+// server or checksum than the one it is locked to.
+func (l *PluginLock) Pin(spec PluginSpec) (PluginSpec, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var entry *PluginLockEntry
+	if spec.Version == nil {
+		entry = l.latest(spec.Kind, spec.Name)
+		if entry == nil {
+			return spec, nil
+		}
+		v, err := semver.ParseTolerant(entry.Version)
+		if err != nil {
+			return spec, fmt.Errorf("%s: invalid version %q for %s plugin %s", l.path, entry.Version, spec.Kind, spec.Name)
+		}
+		spec.Version = &v
+	} else {
+		entry = l.find(spec.Kind, spec.Name, spec.Version.String())
+		if entry == nil {
+			return spec, nil
+		}
+	}
+
+	// Entries written by earlier versions of Pulumi don't record the default download URL.
+	server := entry.Server
+	if server == "" {
+		server = defaultPluginServer
+	}
+	switch {
+	case spec.PluginDownloadURL == "":
+		// Leave the default download URL unset so that the plugin can still fall back to get.pulumi.com.
+		if server != defaultPluginServer {
+			spec.PluginDownloadURL = server
+		}
+	case spec.PluginDownloadURL != server:
+		return spec, fmt.Errorf("%s plugin %s is locked to be downloaded from %s, but %s was requested; "+
+			"update %s if the plugin should be downloaded from the new location",
+			spec.Kind, spec, server, spec.PluginDownloadURL, l.path)
+	}
+
+	if len(entry.Checksums) > 0 {
+		checksums := make(map[string][]byte, len(entry.Checksums)+len(spec.Checksums))
+		for platform, sum := range spec.Checksums {
+			checksums[platform] = sum
+		}
+		for platform, hexSum := range entry.Checksums {
+			sum, err := hex.DecodeString(hexSum)
+			if err != nil {
+				return spec, fmt.Errorf("%s: invalid %s checksum for %s plugin %s: %w",
+					l.path, platform, spec.Kind, spec, err)
+			}
+			if existing, ok := checksums[platform]; ok && !bytes.Equal(existing, sum) {
+				return spec, fmt.Errorf("%s plugin %s is locked with %s checksum %s, but %x was requested",
+					spec.Kind, spec, platform, hexSum, existing)
+			}
+			checksums[platform] = sum
+		}
+		spec.Checksums = checksums
+	}
+
+	return spec, nil
+}
+
+// CheckVersion returns an error if the given plugin is locked, but not at the version it asks for, unless AllowUpdates
+// has been called. Plugins without a version are pinned to a locked version by Pin, so they're always allowed.
+func (l *PluginLock) CheckVersion(spec PluginSpec) error {
+	if spec.Version == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.update || l.find(spec.Kind, spec.Name, spec.Version.String()) != nil {
+		return nil
+	}
+	if latest := l.latest(spec.Kind, spec.Name); latest != nil {
+		return fmt.Errorf("%s plugin %s is locked to version %s in %s, but version %s was requested; "+
+			"run with --update-lock to update the locked version",
+			spec.Kind, spec.Name, latest.Version, l.path, spec.Version)
+	}
+	return nil
+}
+
+// Record adds the given package to the lock, if its plugin version isn't locked already. Any other locked versions of
+// the plugin are replaced, unless they were also recorded since the lock was loaded. Packages without a version can't
+// be locked and are ignored.
+func (l *PluginLock) Record(pkg PackageDescriptor) {
+	if pkg.Version == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry := l.find(pkg.Kind, pkg.Name, pkg.Version.String())
+	if entry == nil {
+		entry = &PluginLockEntry{
+			Name:    pkg.Name,
+			Kind:    pkg.Kind,
+			Version: pkg.Version.String(),
+			Server:  pluginServer(pkg.PluginSpec),
+		}
+		plugins := []*PluginLockEntry{entry}
+		for _, e := range l.Plugins {
+			if e.Kind != pkg.Kind || e.Name != pkg.Name || l.recorded[e] {
+				plugins = append(plugins, e)
+			}
+		}
+		l.Plugins = plugins
+		l.sort()
+		l.changed = true
+	} else if entry.Server == "" {
+		entry.Server = pluginServer(pkg.PluginSpec)
+		l.changed = true
+	}
+	if l.recorded == nil {
+		l.recorded = map[*PluginLockEntry]bool{}
+	}
+	l.recorded[entry] = true
+
+	if p := pkg.Parameterization; p != nil {
+		param := PluginLockParameterization{
+			Name:    p.Name,
+			Version: p.Version.String(),
+			Value:   base64.StdEncoding.EncodeToString(p.Value),
+		}
+		for _, existing := range entry.Parameterizations {
+			if existing == param {
+				return
+			}
+		}
+		entry.Parameterizations = append(entry.Parameterizations, param)
+		sort.Slice(entry.Parameterizations, func(i, j int) bool {
+			a, b := entry.Parameterizations[i], entry.Parameterizations[j]
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			return a.Version < b.Version
+		})
+		l.changed = true
+	}
+}
+
+// HasChecksum returns true if the lock records a checksum for the given plugin on the given platform.
+func (l *PluginLock) HasChecksum(spec PluginSpec, platform string) bool {
+	if spec.Version == nil {
+		return false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry := l.find(spec.Kind, spec.Name, spec.Version.String())
+	return entry != nil && entry.Checksums[platform] != ""
+}
+
+// VerifyChecksum returns an error if the lock records a checksum for the given plugin on the given platform that
+// differs from the given checksum.
+func (l *PluginLock) VerifyChecksum(spec PluginSpec, platform string, checksum []byte) error {
+	if spec.Version == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry := l.find(spec.Kind, spec.Name, spec.Version.String())
+	if entry == nil {
+		return nil
+	}
+	if locked, ok := entry.Checksums[platform]; ok && locked != hex.EncodeToString(checksum) {
+		return fmt.Errorf("checksum mismatch for %s plugin %s on %s: %s locks %s, but the installed plugin has %x",
+			spec.Kind, spec, platform, l.path, locked, checksum)
+	}
+	return nil
+}
+
+// RecordChecksum records the checksum of the given plugin's archive on the given platform, adding the plugin to the
+// lock if necessary. An error is returned if a different checksum is already recorded.
+func (l *PluginLock) RecordChecksum(spec PluginSpec, platform string, checksum []byte) error {
+	if spec.Version == nil {
+		return fmt.Errorf("cannot lock %s plugin %s without a version", spec.Kind, spec.Name)
+	}
+	l.Record(PackageDescriptor{PluginSpec: spec})
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry := l.find(spec.Kind, spec.Name, spec.Version.String())
+	sum := hex.EncodeToString(checksum)
+	if existing, ok := entry.Checksums[platform]; ok {
+		if existing != sum {
+			return fmt.Errorf("checksum mismatch for %s plugin %s on %s: %s locks %s, but the downloaded archive has %s",
+				spec.Kind, spec, platform, l.path, existing, sum)
+		}
+		return nil
+	}
+	if entry.Checksums == nil {
+		entry.Checksums = map[string]string{}
+	}
+	entry.Checksums[platform] = sum
+	l.changed = true
+	return nil
+}
+
+func (l *PluginLock) sort() {
+	sort.Slice(l.Plugins, func(i, j int) bool {
+		a, b := l.Plugins[i], l.Plugins[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		av, aerr := semver.ParseTolerant(a.Version)
+		bv, berr := semver.ParseTolerant(b.Version)
+		if aerr != nil || berr != nil {
+			return a.Version < b.Version
+		}
+		return av.LT(bv)
+	})
+}
+
+// PluginPlatform returns the platform plugins are downloaded for on this machine, in the "$os-$arch" form used to key
+// plugin checksums.
+func PluginPlatform() (string, error) {
+	opSy, arch, err := pluginPlatform()
+	if err != nil {
+		return "", err
+	}
+	return opSy + "-" + arch, nil
+}
+
+// checksumFilePath returns the path of the file that records the SHA-256 checksum of the archive the plugin was
+// installed from.
+func (spec PluginSpec) checksumFilePath() (string, error) {
+	dir, err := spec.DirPath()
+	if err != nil {
+		return "", err
+	}
+	return dir + ".sha256", nil
+}
+
+// WriteInstalledChecksum records the SHA-256 checksum of the archive the plugin was installed from, so that the
+// installed plugin can be checked against a plugin lock when it's used.
+func (spec PluginSpec) WriteInstalledChecksum(checksum []byte) error {
+	path, err := spec.checksumFilePath()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(hex.EncodeToString(checksum)+"\n"), 0o600)
+}
+
+// InstalledChecksum returns the SHA-256 checksum of the archive the plugin was installed from, or nil if it wasn't
+// recorded, e.g. because the plugin was installed from a directory or by an earlier version of Pulumi.
+func (spec PluginSpec) InstalledChecksum() ([]byte, error) {
+	path, err := spec.checksumFilePath()
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	sum, err := hex.DecodeString(string(bytes.TrimSpace(b)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid checksum in %s: %w", path, err)
+	}
+	return sum, nil
+}
+
+// FileChecksum returns the SHA-256 checksum of the given file's contents, and rewinds the file so that it can be
+// read again.
+func FileChecksum(f *os.File) ([]byte, error) {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
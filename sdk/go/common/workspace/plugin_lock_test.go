@@ -0,0 +1,245 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+)
+
+func TestPluginLockRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	lock, err := LoadPluginLock(root)
+	require.NoError(t, err)
+	assert.Empty(t, lock.Plugins)
+
+	// Saving an unchanged lock doesn't create the file.
+	require.NoError(t, lock.Save())
+	_, err = os.Stat(filepath.Join(root, PluginLockFile))
+	assert.True(t, os.IsNotExist(err))
+
+	v1 := semver.MustParse("1.0.0")
+	v2 := semver.MustParse("2.0.0")
+	lock.Record(PackageDescriptor{PluginSpec: PluginSpec{
+		Name: "random", Kind: apitype.ResourcePlugin, Version: &v2,
+	}})
+	lock.Record(PackageDescriptor{
+		PluginSpec: PluginSpec{
+			Name: "terraform-provider", Kind: apitype.ResourcePlugin, Version: &v1,
+			PluginDownloadURL: "github://api.github.com/pulumi",
+		},
+		Parameterization: &Parameterization{Name: "netlify", Version: v2, Value: []byte("params")},
+	})
+	lock.Record(PackageDescriptor{PluginSpec: PluginSpec{
+		Name: "random", Kind: apitype.ResourcePlugin, Version: &v1,
+	}})
+	lock.Record(PackageDescriptor{PluginSpec: PluginSpec{
+		Name: "kotlin", Kind: apitype.LanguagePlugin, Version: &v1, PluginDownloadURL: "https://example.com/plugins",
+	}})
+	// Plugins without a version can't be locked.
+	lock.Record(PackageDescriptor{PluginSpec: PluginSpec{Name: "aws", Kind: apitype.ResourcePlugin}})
+	require.NoError(t, lock.RecordChecksum(
+		PluginSpec{Name: "random", Kind: apitype.ResourcePlugin, Version: &v2}, "linux-amd64", []byte{0xab, 0xcd}))
+	require.NoError(t, lock.Save())
+
+	loaded, err := LoadPluginLock(root)
+	require.NoError(t, err)
+	assert.Equal(t, []*PluginLockEntry{
+		{Name: "kotlin", Kind: apitype.LanguagePlugin, Version: "1.0.0", Server: "https://example.com/plugins"},
+		{Name: "random", Kind: apitype.ResourcePlugin, Version: "1.0.0", Server: "github://api.github.com/pulumi"},
+		{
+			Name: "random", Kind: apitype.ResourcePlugin, Version: "2.0.0", Server: "github://api.github.com/pulumi",
+			Checksums: map[string]string{"linux-amd64": "abcd"},
+		},
+		{
+			Name: "terraform-provider", Kind: apitype.ResourcePlugin, Version: "1.0.0",
+			Server: "github://api.github.com/pulumi",
+			Parameterizations: []PluginLockParameterization{
+				{Name: "netlify", Version: "2.0.0", Value: "cGFyYW1z"},
+			},
+		},
+	}, loaded.Plugins)
+}
+
+func TestPluginLockPin(t *testing.T) {
+	t.Parallel()
+
+	lock := &PluginLock{Plugins: []*PluginLockEntry{
+		{Name: "random", Kind: apitype.ResourcePlugin, Version: "1.0.0"},
+		{
+			Name: "random", Kind: apitype.ResourcePlugin, Version: "2.0.0",
+			Server:    "https://example.com/plugins",
+			Checksums: map[string]string{"linux-amd64": "abcd", "darwin-arm64": "ef01"},
+		},
+	}}
+
+	// A plugin without a version is pinned to the highest locked version.
+	pinned, err := lock.Pin(PluginSpec{Name: "random", Kind: apitype.ResourcePlugin})
+	require.NoError(t, err)
+	require.NotNil(t, pinned.Version)
+	assert.Equal(t, "2.0.0", pinned.Version.String())
+	assert.Equal(t, "https://example.com/plugins", pinned.PluginDownloadURL)
+	assert.Equal(t, map[string][]byte{
+		"linux-amd64":  {0xab, 0xcd},
+		"darwin-arm64": {0xef, 0x01},
+	}, pinned.Checksums)
+
+	// Versions that aren't locked are left alone.
+	v3 := semver.MustParse("3.0.0")
+	pinned, err = lock.Pin(PluginSpec{Name: "random", Kind: apitype.ResourcePlugin, Version: &v3})
+	require.NoError(t, err)
+	assert.Empty(t, pinned.PluginDownloadURL)
+	assert.Nil(t, pinned.Checksums)
+
+	v2 := semver.MustParse("2.0.0")
+	_, err = lock.Pin(PluginSpec{
+		Name: "random", Kind: apitype.ResourcePlugin, Version: &v2, PluginDownloadURL: "https://other.com",
+	})
+	assert.ErrorContains(t, err, "is locked to be downloaded from https://example.com/plugins")
+
+	_, err = lock.Pin(PluginSpec{
+		Name: "random", Kind: apitype.ResourcePlugin, Version: &v2,
+		Checksums: map[string][]byte{"linux-amd64": {0x12}},
+	})
+	assert.ErrorContains(t, err, "is locked with linux-amd64 checksum abcd, but 12 was requested")
+
+	// Plugins locked to the default server keep downloading from the default location, including entries written
+	// before the default server was recorded.
+	v1 := semver.MustParse("1.0.0")
+	for _, server := range []string{"", "github://api.github.com/pulumi"} {
+		lock.Plugins[0].Server = server
+		pinned, err = lock.Pin(PluginSpec{Name: "random", Kind: apitype.ResourcePlugin, Version: &v1})
+		require.NoError(t, err)
+		assert.Empty(t, pinned.PluginDownloadURL)
+
+		_, err = lock.Pin(PluginSpec{
+			Name: "random", Kind: apitype.ResourcePlugin, Version: &v1, PluginDownloadURL: "https://other.com",
+		})
+		assert.ErrorContains(t, err, "is locked to be downloaded from github://api.github.com/pulumi")
+	}
+}
+
+func TestPluginLockCheckVersion(t *testing.T) {
+	t.Parallel()
+
+	lock := &PluginLock{path: PluginLockFile, Plugins: []*PluginLockEntry{
+		{Name: "random", Kind: apitype.ResourcePlugin, Version: "1.0.0"},
+		{Name: "random", Kind: apitype.ResourcePlugin, Version: "2.0.0"},
+	}}
+
+	v1, v3 := semver.MustParse("1.0.0"), semver.MustParse("3.0.0")
+	assert.NoError(t, lock.CheckVersion(PluginSpec{Name: "random", Kind: apitype.ResourcePlugin}))
+	assert.NoError(t, lock.CheckVersion(PluginSpec{Name: "random", Kind: apitype.ResourcePlugin, Version: &v1}))
+	assert.NoError(t, lock.CheckVersion(PluginSpec{Name: "aws", Kind: apitype.ResourcePlugin, Version: &v3}))
+
+	// Versions of a locked plugin that aren't locked are rejected, unless updates are allowed.
+	err := lock.CheckVersion(PluginSpec{Name: "random", Kind: apitype.ResourcePlugin, Version: &v3})
+	assert.ErrorContains(t, err,
+		"resource plugin random is locked to version 2.0.0 in Pulumi.lock, but version 3.0.0 was requested")
+
+	lock.AllowUpdates()
+	assert.NoError(t, lock.CheckVersion(PluginSpec{Name: "random", Kind: apitype.ResourcePlugin, Version: &v3}))
+}
+
+func TestPluginLockRecordReplacesVersions(t *testing.T) {
+	t.Parallel()
+
+	lock := &PluginLock{Plugins: []*PluginLockEntry{
+		{Name: "aws", Kind: apitype.ResourcePlugin, Version: "6.0.0"},
+		{Name: "random", Kind: apitype.ResourcePlugin, Version: "1.0.0"},
+		{Name: "random", Kind: apitype.ResourcePlugin, Version: "2.0.0"},
+	}}
+
+	// Recording a new version of a plugin replaces its previously locked versions.
+	v3 := semver.MustParse("3.0.0")
+	lock.Record(PackageDescriptor{PluginSpec: PluginSpec{Name: "random", Kind: apitype.ResourcePlugin, Version: &v3}})
+	assert.Equal(t, []*PluginLockEntry{
+		{Name: "aws", Kind: apitype.ResourcePlugin, Version: "6.0.0"},
+		{Name: "random", Kind: apitype.ResourcePlugin, Version: "3.0.0", Server: defaultPluginServer},
+	}, lock.Plugins)
+
+	// Versions recorded by the same operation are kept, so a project can use several versions of a plugin.
+	v4 := semver.MustParse("4.0.0")
+	lock.Record(PackageDescriptor{PluginSpec: PluginSpec{Name: "random", Kind: apitype.ResourcePlugin, Version: &v4}})
+	assert.Equal(t, []*PluginLockEntry{
+		{Name: "aws", Kind: apitype.ResourcePlugin, Version: "6.0.0"},
+		{Name: "random", Kind: apitype.ResourcePlugin, Version: "3.0.0", Server: defaultPluginServer},
+		{Name: "random", Kind: apitype.ResourcePlugin, Version: "4.0.0", Server: defaultPluginServer},
+	}, lock.Plugins)
+}
+
+func TestPluginLockVerifyChecksum(t *testing.T) {
+	t.Parallel()
+
+	v1 := semver.MustParse("1.0.0")
+	v2 := semver.MustParse("2.0.0")
+	spec := PluginSpec{Name: "random", Kind: apitype.ResourcePlugin, Version: &v1, PluginDir: t.TempDir()}
+
+	lock := &PluginLock{path: PluginLockFile}
+	require.NoError(t, lock.RecordChecksum(spec, "linux-amd64", []byte{0xab}))
+
+	// The checksum of the archive a plugin was installed from is kept alongside the plugin.
+	sum, err := spec.InstalledChecksum()
+	require.NoError(t, err)
+	assert.Nil(t, sum)
+	require.NoError(t, spec.WriteInstalledChecksum([]byte{0xcd}))
+	sum, err = spec.InstalledChecksum()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xcd}, sum)
+
+	assert.EqualError(t, lock.VerifyChecksum(spec, "linux-amd64", sum),
+		"checksum mismatch for resource plugin random-1.0.0 on linux-amd64: "+
+			"Pulumi.lock locks ab, but the installed plugin has cd")
+	require.NoError(t, lock.VerifyChecksum(spec, "linux-amd64", []byte{0xab}))
+	// Platforms and versions without a locked checksum can't be checked.
+	require.NoError(t, lock.VerifyChecksum(spec, "darwin-arm64", sum))
+	spec.Version = &v2
+	require.NoError(t, lock.VerifyChecksum(spec, "linux-amd64", sum))
+}
+
+func TestPluginLockRecordChecksumMismatch(t *testing.T) {
+	t.Parallel()
+
+	v1 := semver.MustParse("1.0.0")
+	spec := PluginSpec{Name: "random", Kind: apitype.ResourcePlugin, Version: &v1}
+
+	lock := &PluginLock{path: PluginLockFile}
+	assert.False(t, lock.HasChecksum(spec, "linux-amd64"))
+	require.NoError(t, lock.RecordChecksum(spec, "linux-amd64", []byte{0xab}))
+	assert.True(t, lock.HasChecksum(spec, "linux-amd64"))
+	require.NoError(t, lock.RecordChecksum(spec, "linux-amd64", []byte{0xab}))
+
+	err := lock.RecordChecksum(spec, "linux-amd64", []byte{0xcd})
+	assert.EqualError(t, err, "checksum mismatch for resource plugin random-1.0.0 on linux-amd64: "+
+		"Pulumi.lock locks ab, but the downloaded archive has cd")
+}
+
+func TestLoadPluginLockRejectsNewerVersion(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, PluginLockFile), []byte("version: 99\n"), 0o600))
+	_, err := LoadPluginLock(root)
+	assert.ErrorContains(t, err, "has version 99, which is not supported")
+}
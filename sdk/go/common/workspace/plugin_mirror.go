@@ -0,0 +1,246 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/blang/semver"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+)
+
+// fileSource reads plugins from a directory on the local filesystem, such as a mirror created by
+// `pulumi plugin mirror`. Plugin archives use the same names as they do on http servers, e.g.
+// pulumi-resource-aws-v6.0.0-linux-amd64.tar.gz, directly inside the directory.
+type fileSource struct {
+	name string
+	kind apitype.PluginKind
+	dir  string
+}
+
+func newFileSource(name string, kind apitype.PluginKind, dir string) *fileSource {
+	return &fileSource{
+		name: name,
+		kind: kind,
+		dir:  dir,
+	}
+}
+
+// pluginMirrorDirectory returns the directory named by a plugin mirror setting, which may either be a path or a
+// file:// URL.
+func pluginMirrorDirectory(mirror string) (string, error) {
+	if !strings.HasPrefix(mirror, "file:") {
+		return mirror, nil
+	}
+	u, err := url.Parse(mirror)
+	if err != nil {
+		return "", fmt.Errorf("invalid plugin mirror URL %q: %w", mirror, err)
+	}
+	return fileURLPath(u), nil
+}
+
+// fileURLPath returns the local path named by a file:// URL.
+func fileURLPath(u *url.URL) string {
+	path := u.Path
+	if u.Opaque != "" {
+		// file:relative/path
+		path = u.Opaque
+	} else if u.Host != "" && u.Host != "localhost" {
+		// file://relative/path parses "relative" as the host, but means a relative path.
+		path = u.Host + path
+	}
+	// file:///C:/plugins on Windows has a path of /C:/plugins.
+	if len(path) >= 3 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	return filepath.FromSlash(path)
+}
+
+func (source *fileSource) assetPrefix() string {
+	return fmt.Sprintf("pulumi-%s-%s-v", source.kind, source.name)
+}
+
+func (source *fileSource) GetLatestVersion(
+	ctx context.Context,
+	getHTTPResponse func(*http.Request) (io.ReadCloser, int64, error),
+) (*semver.Version, error) {
+	opSy, arch, err := pluginPlatform()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(source.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only consider versions that are available for this platform.
+	prefix, suffix := source.assetPrefix(), fmt.Sprintf("-%s-%s.tar.gz", opSy, arch)
+	var latest *semver.Version
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		v, err := semver.Parse(strings.TrimSuffix(strings.TrimPrefix(name, prefix), suffix))
+		if err != nil {
+			continue
+		}
+		if latest == nil || v.GT(*latest) {
+			latest = &v
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no %s plugin %s for %s-%s found in %s: %w", source.kind, source.name, opSy, arch,
+			source.dir, fs.ErrNotExist)
+	}
+	return latest, nil
+}
+
+func (source *fileSource) Download(
+	ctx context.Context,
+	version semver.Version, opSy string, arch string,
+	getHTTPResponse func(*http.Request) (io.ReadCloser, int64, error),
+) (io.ReadCloser, int64, error) {
+	path := filepath.Join(source.dir, standardAssetName(source.name, source.kind, version, opSy, arch))
+	logging.V(1).Infof("%s reading from %s", source.name, path)
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, -1, err
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		contract.IgnoreClose(f)
+		return nil, -1, err
+	}
+	return f, stat.Size(), nil
+}
+
+func (source *fileSource) URL() string {
+	return "file://" + filepath.ToSlash(source.dir)
+}
+
+// mirrorSource looks for plugins in a local mirror before falling back to the plugin's usual source.
+type mirrorSource struct {
+	mirror *fileSource
+	source PluginSource
+}
+
+func newMirrorSource(mirror *fileSource, source PluginSource) *mirrorSource {
+	return &mirrorSource{
+		mirror: mirror,
+		source: source,
+	}
+}
+
+func (source *mirrorSource) GetLatestVersion(
+	ctx context.Context,
+	getHTTPResponse func(*http.Request) (io.ReadCloser, int64, error),
+) (*semver.Version, error) {
+	version, err := source.mirror.GetLatestVersion(ctx, getHTTPResponse)
+	if err == nil {
+		return version, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	logging.V(1).Infof("%s not found in plugin mirror, falling back to %s", source.mirror.name, source.source.URL())
+	return source.source.GetLatestVersion(ctx, getHTTPResponse)
+}
+
+func (source *mirrorSource) Download(
+	ctx context.Context,
+	version semver.Version, opSy string, arch string,
+	getHTTPResponse func(*http.Request) (io.ReadCloser, int64, error),
+) (io.ReadCloser, int64, error) {
+	resp, length, err := source.mirror.Download(ctx, version, opSy, arch, getHTTPResponse)
+	if err == nil {
+		return resp, length, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, -1, err
+	}
+	logging.V(1).Infof("%s %s not found in plugin mirror, falling back to %s",
+		source.mirror.name, version, source.source.URL())
+	return source.source.Download(ctx, version, opSy, arch, getHTTPResponse)
+}
+
+func (source *mirrorSource) URL() string {
+	// The mirror is only a cache, plugins are still identified by where they would otherwise be downloaded from.
+	return source.source.URL()
+}
+
+// MirrorPlugin downloads the archive of the given plugin for the given OS and architecture into the mirror directory
+// dir, returning the path of the archive and its SHA-256 checksum. If the archive is already in the mirror it isn't
+// downloaded again.
+func MirrorPlugin(ctx context.Context, spec PluginSpec, dir, opSy, arch string) (string, []byte, error) {
+	if spec.Version == nil {
+		return "", nil, fmt.Errorf("unknown version for plugin %s", spec.Name)
+	}
+
+	path := filepath.Join(dir, standardAssetName(spec.Name, spec.Kind, *spec.Version, opSy, arch))
+	if f, err := os.Open(path); err == nil {
+		defer contract.IgnoreClose(f)
+		checksum, err := FileChecksum(f)
+		if err != nil {
+			return "", nil, err
+		}
+		return path, checksum, nil
+	}
+
+	source, err := spec.GetSource()
+	if err != nil {
+		return "", nil, err
+	}
+	stream, _, err := source.Download(ctx, *spec.Version, opSy, arch, getHTTPResponseWithRetry)
+	if err != nil {
+		return "", nil, err
+	}
+	defer contract.IgnoreClose(stream)
+
+	// Write to a temporary file first so that a failed download never leaves a partial archive in the mirror.
+	tmp, err := os.CreateTemp(dir, ".pulumi-mirror-*")
+	if err != nil {
+		return "", nil, err
+	}
+	defer func() { contract.IgnoreError(os.Remove(tmp.Name())) }()
+
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmp, h), stream)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", nil, err
+	}
+	return path, h.Sum(nil), nil
+}
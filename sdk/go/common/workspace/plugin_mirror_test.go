@@ -0,0 +1,181 @@
+// Copyright 2016-2024, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+)
+
+func writeMirrorAsset(t *testing.T, dir, name, version, opSy, arch, content string) {
+	path := filepath.Join(dir, standardAssetName(name, apitype.ResourcePlugin, semver.MustParse(version), opSy, arch))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestFileSource(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeMirrorAsset(t, dir, "mock", "1.0.0", runtime.GOOS, runtime.GOARCH, "one")
+	writeMirrorAsset(t, dir, "mock", "1.2.0", runtime.GOOS, runtime.GOARCH, "one point two")
+	// Versions that aren't available for this platform aren't considered the latest.
+	writeMirrorAsset(t, dir, "mock", "2.0.0", "plan9", runtime.GOARCH, "two")
+	writeMirrorAsset(t, dir, "mock-other", "3.0.0", runtime.GOOS, runtime.GOARCH, "three")
+
+	source, err := newPluginSource("mock", apitype.ResourcePlugin, "file://"+filepath.ToSlash(dir))
+	require.NoError(t, err)
+	assert.Equal(t, "file://"+filepath.ToSlash(dir), source.URL())
+
+	latest, err := source.GetLatestVersion(context.Background(), getHTTPResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.0", latest.String())
+
+	r, size, err := source.Download(
+		context.Background(), semver.MustParse("1.0.0"), runtime.GOOS, runtime.GOARCH, getHTTPResponse)
+	require.NoError(t, err)
+	defer r.Close()
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "one", string(b))
+	assert.Equal(t, int64(3), size)
+
+	_, _, err = source.Download(
+		context.Background(), semver.MustParse("9.0.0"), runtime.GOOS, runtime.GOARCH, getHTTPResponse)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	missing := newFileSource("missing", apitype.ResourcePlugin, dir)
+	_, err = missing.GetLatestVersion(context.Background(), getHTTPResponse)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+type failingPluginSource struct {
+	downloads int
+}
+
+func (s *failingPluginSource) GetLatestVersion(
+	context.Context, func(*http.Request) (io.ReadCloser, int64, error),
+) (*semver.Version, error) {
+	v := semver.MustParse("5.0.0")
+	return &v, nil
+}
+
+func (s *failingPluginSource) Download(
+	context.Context, semver.Version, string, string, func(*http.Request) (io.ReadCloser, int64, error),
+) (io.ReadCloser, int64, error) {
+	s.downloads++
+	return nil, -1, errors.New("network unreachable")
+}
+
+func (s *failingPluginSource) URL() string {
+	return "github://api.github.com/pulumi"
+}
+
+func TestMirrorSourceFallsBack(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeMirrorAsset(t, dir, "mock", "1.0.0", runtime.GOOS, runtime.GOARCH, "mirrored")
+
+	upstream := &failingPluginSource{}
+	source := newMirrorSource(newFileSource("mock", apitype.ResourcePlugin, dir), upstream)
+	assert.Equal(t, "github://api.github.com/pulumi", source.URL())
+
+	// Versions and archives in the mirror are used without going to the upstream source.
+	latest, err := source.GetLatestVersion(context.Background(), getHTTPResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", latest.String())
+
+	r, _, err := source.Download(
+		context.Background(), semver.MustParse("1.0.0"), runtime.GOOS, runtime.GOARCH, getHTTPResponse)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, 0, upstream.downloads)
+
+	// Anything else falls back to the upstream source.
+	_, _, err = source.Download(
+		context.Background(), semver.MustParse("2.0.0"), runtime.GOOS, runtime.GOARCH, getHTTPResponse)
+	assert.EqualError(t, err, "network unreachable")
+	assert.Equal(t, 1, upstream.downloads)
+
+	other := newMirrorSource(newFileSource("other", apitype.ResourcePlugin, dir), upstream)
+	latest, err = other.GetLatestVersion(context.Background(), getHTTPResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "5.0.0", latest.String())
+}
+
+func TestMirrorPlugin(t *testing.T) {
+	t.Parallel()
+
+	upstream := t.TempDir()
+	writeMirrorAsset(t, upstream, "mock", "1.0.0", "linux", "arm64", "archive")
+
+	v := semver.MustParse("1.0.0")
+	spec := PluginSpec{
+		Name:              "mock",
+		Kind:              apitype.ResourcePlugin,
+		Version:           &v,
+		PluginDownloadURL: "file://" + filepath.ToSlash(upstream),
+	}
+
+	mirror := t.TempDir()
+	path, checksum, err := MirrorPlugin(context.Background(), spec, mirror, "linux", "arm64")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(mirror, "pulumi-resource-mock-v1.0.0-linux-arm64.tar.gz"), path)
+	expected := sha256.Sum256([]byte("archive"))
+	assert.Equal(t, expected[:], checksum)
+
+	// Mirroring again reuses the existing archive.
+	require.NoError(t, os.Remove(filepath.Join(upstream, "pulumi-resource-mock-v1.0.0-linux-arm64.tar.gz")))
+	_, checksum, err = MirrorPlugin(context.Background(), spec, mirror, "linux", "arm64")
+	require.NoError(t, err)
+	assert.Equal(t, expected[:], checksum)
+
+	// Failed downloads don't leave anything behind.
+	_, _, err = MirrorPlugin(context.Background(), spec, mirror, "darwin", "arm64")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	entries, err := os.ReadDir(mirror)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestPluginMirrorDirectory(t *testing.T) {
+	t.Parallel()
+
+	dir, err := pluginMirrorDirectory("/srv/plugins")
+	require.NoError(t, err)
+	assert.Equal(t, "/srv/plugins", dir)
+
+	dir, err = pluginMirrorDirectory("file:///srv/plugins")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.FromSlash("/srv/plugins"), dir)
+
+	u, err := url.Parse("file://plugins/mirror")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.FromSlash("plugins/mirror"), fileURLPath(u))
+}
@@ -1273,10 +1273,11 @@ func (info *PluginInfo) Delete() error {
 	if err := os.RemoveAll(dir); err != nil {
 		return err
 	}
-	// Attempt to delete any leftover .partial or .lock files.
+	// Attempt to delete any leftover .partial, .lock or .sha256 files.
 	// Don't fail the operation if we can't delete these.
 	contract.IgnoreError(os.Remove(dir + ".partial"))
 	contract.IgnoreError(os.Remove(dir + ".lock"))
+	contract.IgnoreError(os.Remove(dir + ".sha256"))
 	return nil
 }
 
@@ -1334,6 +1335,8 @@ func newPluginSource(name string, kind apitype.PluginKind, pluginDownloadURL str
 		return newHTTPSource(name, kind, url), nil
 	case "git":
 		return newGitHTTPSSource(url)
+	case "file":
+		return newFileSource(name, kind, fileURLPath(url)), nil
 	default:
 		return nil, fmt.Errorf("unknown plugin source scheme: %s", url.Scheme)
 	}
@@ -1362,6 +1365,15 @@ func (spec PluginSpec) GetSource() (PluginSource, error) {
 		}
 	}
 
+	// If a plugin mirror is configured, look for the plugin there first.
+	if mirror := env.PluginMirror.Value(); mirror != "" {
+		dir, err := pluginMirrorDirectory(mirror)
+		if err != nil {
+			return nil, err
+		}
+		source = newMirrorSource(newFileSource(spec.Name, spec.Kind, dir), source)
+	}
+
 	// Apply checksums if defined.
 	if len(spec.Checksums) != 0 {
 		source = newChecksumSource(source, spec.Checksums)
@@ -1380,23 +1392,32 @@ func (spec PluginSpec) GetLatestVersion(ctx context.Context) (*semver.Version, e
 	return source.GetLatestVersion(ctx, getHTTPResponseWithRetry)
 }
 
-// Download fetches an io.ReadCloser for this plugin and also returns the size of the response (if known).
-// The context allows for I/O cancellation.
-func (spec PluginSpec) Download(ctx context.Context) (io.ReadCloser, int64, error) {
-	// Figure out the OS/ARCH pair for the download URL.
+// pluginPlatform returns the OS and architecture that plugins are downloaded for on this machine.
+func pluginPlatform() (string, string, error) {
 	var opSy string
 	switch runtime.GOOS {
 	case "darwin", "linux", "windows":
 		opSy = runtime.GOOS
 	default:
-		return nil, -1, fmt.Errorf("unsupported plugin OS: %s", runtime.GOOS)
+		return "", "", fmt.Errorf("unsupported plugin OS: %s", runtime.GOOS)
 	}
 	var arch string
 	switch runtime.GOARCH {
 	case "amd64", "arm64":
 		arch = runtime.GOARCH
 	default:
-		return nil, -1, fmt.Errorf("unsupported plugin architecture: %s", runtime.GOARCH)
+		return "", "", fmt.Errorf("unsupported plugin architecture: %s", runtime.GOARCH)
+	}
+	return opSy, arch, nil
+}
+
+// Download fetches an io.ReadCloser for this plugin and also returns the size of the response (if known).
+// The context allows for I/O cancellation.
+func (spec PluginSpec) Download(ctx context.Context) (io.ReadCloser, int64, error) {
+	// Figure out the OS/ARCH pair for the download URL.
+	opSy, arch, err := pluginPlatform()
+	if err != nil {
+		return nil, -1, err
 	}
 
 	// The plugin version is necessary for the endpoint. If it's not present, return an error.
@@ -1892,6 +1913,12 @@ func (spec PluginSpec) InstallWithContext(ctx context.Context, content PluginCon
 		return err
 	}
 
+	// The checksum of the archive a previous installation came from no longer applies. Installers that know the
+	// checksum of the new archive record it once it has been installed.
+	if err := os.Remove(finalDir + ".sha256"); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
 	// Create the final directory.
 	if err := os.MkdirAll(finalDir, 0o700); err != nil {
 		return err
@@ -2220,6 +2247,7 @@ func IsPluginBundled(kind apitype.PluginKind, name string) bool {
 		(kind == apitype.LanguagePlugin && name == "java") ||
 		(kind == apitype.ResourcePlugin && name == "pulumi-nodejs") ||
 		(kind == apitype.ResourcePlugin && name == "pulumi-python") ||
+		(kind == apitype.ResourcePlugin && name == "pulumi-go") ||
 		(kind == apitype.AnalyzerPlugin && name == "policy") ||
 		(kind == apitype.AnalyzerPlugin && name == "policy-python")
 }
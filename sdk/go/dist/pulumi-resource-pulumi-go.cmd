@@ -0,0 +1,4 @@
+@echo off
+REM Serves the dynamic providers of the Go program in the current directory, using the Go language host installed
+REM alongside this script.
+@"%~dp0pulumi-language-go.exe" -dynamic-provider %*
@@ -0,0 +1,504 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/executable"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// defaultBuildCacheMaxEntries is the number of compiled programs kept in the build cache by default.
+const defaultBuildCacheMaxEntries = 10
+
+// buildCacheEvictionGracePeriod is how recently a cached program must have been used to be kept regardless of the
+// cache's size. A program is marked as used when it's taken from the cache, just before it's run, so this keeps
+// programs that another process has just picked from being evicted before that process has started them.
+const buildCacheEvictionGracePeriod = 10 * time.Minute
+
+// buildCacheEnv lists the Go environment variables that affect the output of `go build`, and so are part of the key
+// of a cached program. Besides the target platform and toolchain, these include the C toolchain and flags used by cgo,
+// and GOFLAGS, which may carry -ldflags, -gcflags or -tags.
+var buildCacheEnv = []string{
+	"GOVERSION", "GOOS", "GOARCH", "GOAMD64", "GOARM", "GOARM64", "GOFLAGS", "GOEXPERIMENT", "GOWORK",
+	"CGO_ENABLED", "CC", "CXX", "CGO_CFLAGS", "CGO_CPPFLAGS", "CGO_CXXFLAGS", "CGO_FFLAGS", "CGO_LDFLAGS",
+}
+
+// buildProgram compiles the program in programDirectory according to the given options, returning the path of the
+// compiled program along with a function that cleans up after it once it has run. If a build target is set, the
+// program is built there. Otherwise, the program is taken from the build cache if it is enabled, or built to a
+// temporary file if not.
+func buildProgram(
+	programDirectory string, opts goOptions, withDebugFlags bool, stdout io.Writer,
+) (string, func(), error) {
+	if opts.buildTarget != "" {
+		program, err := compileProgram(programDirectory, opts.buildTarget, withDebugFlags, stdout)
+		return program, func() {}, err
+	}
+
+	if !opts.buildCacheDisabled {
+		program, err := cachedProgram(programDirectory, opts, withDebugFlags, stdout)
+		if err == nil {
+			return program, func() {}, nil
+		}
+		if !errors.Is(err, errNotCacheable) {
+			return "", nil, err
+		}
+		logging.V(5).Infof("Not caching the build of the Go program in %s: %v", programDirectory, err)
+	}
+
+	program, err := compileProgram(programDirectory, "", withDebugFlags, stdout)
+	if err != nil {
+		return "", nil, err
+	}
+	return program, func() { contract.IgnoreError(os.Remove(program)) }, nil
+}
+
+// errNotCacheable is returned by cachedProgram for programs whose builds can't be cached, such as those that aren't
+// in a Go module.
+var errNotCacheable = errors.New("program is not cacheable")
+
+// cachedProgram returns the path of the compiled program in programDirectory from the build cache, building it and
+// adding it to the cache if it isn't there yet.
+func cachedProgram(programDirectory string, opts goOptions, withDebugFlags bool, stdout io.Writer) (string, error) {
+	dir := opts.buildCacheDir
+	if dir == "" {
+		var err error
+		dir, err = workspace.GetPulumiPath("cache", "go")
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", errNotCacheable, err)
+		}
+	}
+
+	gobin, err := executable.FindExecutable("go")
+	if err != nil {
+		return "", fmt.Errorf("unable to find 'go' executable: %w", err)
+	}
+	key, err := buildCacheKey(gobin, programDirectory, withDebugFlags)
+	if err != nil {
+		return "", err
+	}
+
+	program := filepath.Join(dir, key)
+	if runtime.GOOS == "windows" {
+		program += ".exe"
+	}
+	if _, err := os.Stat(program); err == nil {
+		logging.V(5).Infof("Using cached build of Go program in %s: %s", programDirectory, program)
+		// Touch the program so that eviction keeps the most recently used programs.
+		now := time.Now()
+		if err := os.Chtimes(program, now, now); err != nil {
+			logging.V(5).Infof("Failed to update the modification time of %s: %v", program, err)
+		}
+		return program, nil
+	}
+	logging.V(5).Infof("No cached build of Go program in %s, building it to %s", programDirectory, program)
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("creating Go build cache: %w", err)
+	}
+	// Build to a temporary file in the cache and then move it into place, so that concurrent builds of the same
+	// program never see a partially written one.
+	f, err := os.CreateTemp(dir, "build-*")
+	if err != nil {
+		return "", fmt.Errorf("unable to create go program temp file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("unable to close go program temp file: %w", err)
+	}
+	tmp := f.Name()
+	if _, err := compileProgram(programDirectory, tmp, withDebugFlags, stdout); err != nil {
+		contract.IgnoreError(os.Remove(tmp))
+		return "", err
+	}
+	if err := os.Rename(tmp, program); err != nil {
+		contract.IgnoreError(os.Remove(tmp))
+		return "", fmt.Errorf("adding program to Go build cache: %w", err)
+	}
+
+	evictBuildCache(dir, opts.buildCacheMaxEntries)
+	return program, nil
+}
+
+// evictBuildCache removes all but the maxEntries most recently used programs from the build cache in dir. Programs
+// used within buildCacheEvictionGracePeriod are always kept. Each program is moved out of the way before it's removed,
+// so that other processes find either the whole program or nothing. Removing a program that's running is fine on
+// Unix, and fails on Windows, in which case the moved program is removed by a later eviction instead.
+func evictBuildCache(dir string, maxEntries int) {
+	if maxEntries <= 0 {
+		maxEntries = defaultBuildCacheMaxEntries
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		logging.V(5).Infof("Failed to read Go build cache %s: %v", dir, err)
+		return
+	}
+	type cached struct {
+		path    string
+		modTime time.Time
+	}
+	var programs []cached
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), "build-") {
+			continue
+		}
+		if strings.HasPrefix(entry.Name(), "evict-") {
+			// A program that was evicted while it was running.
+			removeEvicted(filepath.Join(dir, entry.Name()))
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		programs = append(programs, cached{path: filepath.Join(dir, entry.Name()), modTime: info.ModTime()})
+	}
+	if len(programs) <= maxEntries {
+		return
+	}
+
+	slices.SortFunc(programs, func(a, b cached) int {
+		return b.modTime.Compare(a.modTime)
+	})
+	cutoff := time.Now().Add(-buildCacheEvictionGracePeriod)
+	for _, p := range programs[maxEntries:] {
+		if p.modTime.After(cutoff) {
+			continue
+		}
+		logging.V(5).Infof("Evicting %s from the Go build cache", p.path)
+		evicted := filepath.Join(dir, "evict-"+filepath.Base(p.path))
+		if err := os.Rename(p.path, evicted); err != nil {
+			logging.V(5).Infof("Failed to evict %s from the Go build cache: %v", p.path, err)
+			continue
+		}
+		removeEvicted(evicted)
+	}
+}
+
+// removeEvicted removes a program that has been evicted from the build cache.
+func removeEvicted(path string) {
+	if err := os.Remove(path); err != nil {
+		logging.V(5).Infof("Failed to remove %s from the Go build cache: %v", path, err)
+	}
+}
+
+// buildCacheKey returns the key of the compiled program in programDirectory. The key is a hash of everything that
+// affects the output of `go build`: the Go environment, the build flags and the sources of the program's module,
+// including its go.mod and go.sum and any local modules that it replaces dependencies with or shares a workspace with.
+func buildCacheKey(gobin, programDirectory string, withDebugFlags bool) (string, error) {
+	programDirectory, err := filepath.Abs(programDirectory)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", errNotCacheable, err)
+	}
+
+	cmd := exec.Command(gobin, append([]string{"env"}, buildCacheEnv...)...)
+	cmd.Dir = programDirectory
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%w: go env: %w", errNotCacheable, err)
+	}
+	var gowork string
+	if env := strings.Split(string(out), "\n"); len(env) >= len(buildCacheEnv) {
+		gowork = strings.TrimSpace(env[slices.Index(buildCacheEnv, "GOWORK")])
+	}
+
+	modDir, err := findModuleRoot(programDirectory)
+	if err != nil {
+		return "", err
+	}
+	program, err := filepath.Rel(modDir, programDirectory)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", errNotCacheable, err)
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "env:%s\nflags:%v\nprogram:%s\n", out, withDebugFlags, filepath.ToSlash(program))
+
+	roots, err := moduleRoots(modDir, gowork)
+	if err != nil {
+		return "", err
+	}
+	for _, root := range roots {
+		if err := hashModule(h, root); err != nil {
+			return "", fmt.Errorf("hashing sources of %s: %w", root, err)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// findModuleRoot returns the directory of the go.mod file for the program in programDirectory.
+func findModuleRoot(programDirectory string) (string, error) {
+	dir, err := filepath.Abs(programDirectory)
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("%w: no go.mod file found for %s", errNotCacheable, programDirectory)
+		}
+		dir = parent
+	}
+}
+
+// moduleRoots returns the directories of the modules whose sources may be built into the program in the module at
+// modDir: the module itself, the modules of the workspace it's built in, if any, and any modules that these replace
+// dependencies with local directories. The workspace's go.work file is also returned, as it affects the build too.
+func moduleRoots(modDir, gowork string) ([]string, error) {
+	seen := map[string]bool{}
+	var roots []string
+	var addModule func(dir string) error
+	addModule = func(dir string) error {
+		dir = filepath.Clean(dir)
+		if seen[dir] {
+			return nil
+		}
+		seen[dir] = true
+		roots = append(roots, dir)
+
+		path := filepath.Join(dir, "go.mod")
+		body, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		f, err := modfile.Parse(path, body, nil)
+		if err != nil {
+			return fmt.Errorf("parse: %w", err)
+		}
+		for _, r := range f.Replace {
+			if modfile.IsDirectoryPath(r.New.Path) {
+				if err := addModule(resolvePath(dir, r.New.Path)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	if err := addModule(modDir); err != nil {
+		return nil, err
+	}
+
+	if gowork != "" && gowork != "off" {
+		body, err := os.ReadFile(gowork)
+		if err != nil {
+			return nil, err
+		}
+		f, err := modfile.ParseWork(gowork, body, nil)
+		if err != nil {
+			return nil, fmt.Errorf("parse: %w", err)
+		}
+		workDir := filepath.Dir(gowork)
+		roots = append(roots, gowork)
+		for _, use := range f.Use {
+			if err := addModule(resolvePath(workDir, use.Path)); err != nil {
+				return nil, err
+			}
+		}
+		for _, r := range f.Replace {
+			if modfile.IsDirectoryPath(r.New.Path) {
+				if err := addModule(resolvePath(workDir, r.New.Path)); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return roots, nil
+}
+
+// resolvePath resolves a path in a go.mod or go.work file relative to the directory containing that file.
+func resolvePath(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, filepath.FromSlash(path))
+}
+
+// goBuildSourceExts lists the extensions of the files in a package, besides Go files, that `go build` compiles into
+// it: assembly, cgo and SWIG sources and headers, and prebuilt system objects.
+var goBuildSourceExts = []string{
+	".s", ".S", ".sx", ".c", ".cc", ".cpp", ".cxx", ".h", ".hh", ".hpp", ".hxx", ".m", ".f", ".F", ".for", ".f90",
+	".swig", ".swigcxx", ".syso",
+}
+
+// hashModule writes the names and contents of the files in the module at root that `go build` reads to h: its go.mod
+// and go.sum, its Go sources and the other sources compiled along with them, and the files they embed. A root that is
+// a file, such as a go.work file, is hashed by itself. Other files, and files that `go build` ignores, such as tests
+// and the contents of hidden directories and nested modules, are skipped.
+func hashModule(h hash.Hash, root string) error {
+	embedded := map[string]bool{}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path == root {
+				return nil
+			}
+			if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || strings.HasSuffix(name, "_test.go") {
+			return nil
+		}
+		isGo := filepath.Ext(name) == ".go"
+		if path != root && name != "go.mod" && name != "go.sum" && !isGo &&
+			!slices.Contains(goBuildSourceExts, filepath.Ext(name)) {
+			return nil
+		}
+
+		if err := hashFile(h, path); err != nil {
+			return err
+		}
+		if isGo {
+			return addEmbeddedFiles(embedded, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	files := make([]string, 0, len(embedded))
+	for path := range embedded {
+		files = append(files, path)
+	}
+	slices.Sort(files)
+	for _, path := range files {
+		if err := hashFile(h, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hashFile writes the name and contents of the file at path to h.
+func hashFile(h hash.Hash, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer contract.IgnoreClose(f)
+	fmt.Fprintf(h, "file:%s\n", filepath.ToSlash(path))
+	_, err = io.Copy(h, f)
+	return err
+}
+
+// addEmbeddedFiles adds the files matched by the //go:embed directives in the Go source file at path to embedded.
+// Patterns that match directories embed their contents, except for hidden files unless the pattern has the "all:"
+// prefix, as with `go build`. Patterns that match nothing are ignored, since `go build` fails for them anyway.
+func addEmbeddedFiles(embedded map[string]bool, path string) error {
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	for _, line := range strings.Split(string(body), "\n") {
+		directive, ok := strings.CutPrefix(strings.TrimSpace(line), "//go:embed")
+		if !ok || (directive != "" && directive[0] != ' ' && directive[0] != '\t') {
+			continue
+		}
+		for _, pattern := range embedPatterns(directive) {
+			pattern, all := strings.CutPrefix(pattern, "all:")
+			matches, err := filepath.Glob(filepath.Join(dir, filepath.FromSlash(pattern)))
+			if err != nil {
+				continue
+			}
+			for _, match := range matches {
+				err := filepath.WalkDir(match, func(path string, d fs.DirEntry, err error) error {
+					if err != nil {
+						return err
+					}
+					if path != match && !all && (strings.HasPrefix(d.Name(), ".") || strings.HasPrefix(d.Name(), "_")) {
+						if d.IsDir() {
+							return filepath.SkipDir
+						}
+						return nil
+					}
+					if d.Type().IsRegular() {
+						embedded[path] = true
+					}
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// embedPatterns splits the arguments of a //go:embed directive into its patterns, which are separated by spaces and
+// may be quoted with double quotes or backquotes.
+func embedPatterns(args string) []string {
+	var patterns []string
+	args = strings.TrimSpace(args)
+	for args != "" {
+		var pattern string
+		switch args[0] {
+		case '"', '`':
+			end := strings.IndexByte(args[1:], args[0])
+			if end < 0 {
+				return patterns
+			}
+			pattern = args[1 : end+1]
+			if args[0] == '"' {
+				if unquoted, err := strconv.Unquote(args[:end+2]); err == nil {
+					pattern = unquoted
+				}
+			}
+			args = args[end+2:]
+		default:
+			end := strings.IndexAny(args, " \t")
+			if end < 0 {
+				end = len(args)
+			}
+			pattern, args = args[:end], args[end:]
+		}
+		patterns = append(patterns, pattern)
+		args = strings.TrimSpace(args)
+	}
+	return patterns
+}
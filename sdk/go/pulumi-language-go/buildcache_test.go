@@ -0,0 +1,216 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/executable"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	}
+}
+
+func TestBuildCache(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	embedMain := "package main\n\nimport _ \"embed\"\n\n//go:embed data.txt\nvar data string\n\nfunc main() { println(data) }\n"
+	writeFiles(t, root, map[string]string{
+		"go.mod":                  "module example.com/prog\n\ngo 1.21\n",
+		"main.go":                 "package main\n\nimport \"example.com/prog/lib\"\n\nfunc main() { lib.Hello() }\n",
+		"lib/lib.go":              "package lib\n\nfunc Hello() {}\n",
+		"lib/lib_test.go":         "package lib\n",
+		"nested/go.mod":           "module example.com/nested\n",
+		".idea/workspace.xml":     "<project/>\n",
+		"infra/program/main.go":   embedMain,
+		"infra/program/data.txt":  "embedded\n",
+		"infra/program/README.md": "# Program\n",
+	})
+	opts := goOptions{buildCacheDir: filepath.Join(t.TempDir(), "cache")}
+
+	program, cleanup, err := buildProgram(root, opts, false, io.Discard)
+	require.NoError(t, err)
+	cleanup()
+	assert.Equal(t, opts.buildCacheDir, filepath.Dir(program))
+	assert.FileExists(t, program, "cached programs outlive their runs")
+
+	// Rebuilding the unchanged program reuses the cached build.
+	again, cleanup, err := buildProgram(root, opts, false, io.Discard)
+	require.NoError(t, err)
+	cleanup()
+	assert.Equal(t, program, again)
+
+	// Changes to tests, hidden directories, nested modules and files that aren't built or embedded don't affect the
+	// build.
+	writeFiles(t, root, map[string]string{
+		"lib/lib_test.go":         "package lib\n\n// A change.\n",
+		".idea/workspace.xml":     "<project version=\"4\"/>\n",
+		"nested/go.mod":           "module example.com/nested\n\ngo 1.21\n",
+		"infra/program/README.md": "# Program\n\nA change.\n",
+	})
+	again, cleanup, err = buildProgram(root, opts, false, io.Discard)
+	require.NoError(t, err)
+	cleanup()
+	assert.Equal(t, program, again)
+
+	// Changes to the module's sources, build flags and program directory do.
+	debug, cleanup, err := buildProgram(root, opts, true, io.Discard)
+	require.NoError(t, err)
+	cleanup()
+	assert.NotEqual(t, program, debug)
+
+	subdir, cleanup, err := buildProgram(filepath.Join(root, "infra", "program"), opts, false, io.Discard)
+	require.NoError(t, err)
+	cleanup()
+	assert.NotEqual(t, program, subdir)
+
+	// Changes to embedded files do.
+	writeFiles(t, root, map[string]string{"infra/program/data.txt": "changed\n"})
+	changed, cleanup, err := buildProgram(filepath.Join(root, "infra", "program"), opts, false, io.Discard)
+	require.NoError(t, err)
+	cleanup()
+	assert.NotEqual(t, subdir, changed)
+
+	writeFiles(t, root, map[string]string{"lib/lib.go": "package lib\n\nfunc Hello() { println() }\n"})
+	changed, cleanup, err = buildProgram(root, opts, false, io.Discard)
+	require.NoError(t, err)
+	cleanup()
+	assert.NotEqual(t, program, changed)
+}
+
+func TestBuildCacheDisabled(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.mod":  "module example.com/prog\n\ngo 1.21\n",
+		"main.go": "package main\n\nfunc main() {}\n",
+	})
+	cacheDir := filepath.Join(t.TempDir(), "cache")
+	opts := goOptions{buildCacheDisabled: true, buildCacheDir: cacheDir}
+
+	program, cleanup, err := buildProgram(root, opts, false, io.Discard)
+	require.NoError(t, err)
+	assert.FileExists(t, program)
+	cleanup()
+	assert.NoFileExists(t, program, "uncached programs are removed after their runs")
+	assert.NoDirExists(t, cacheDir)
+}
+
+func TestBuildCacheReplacedModules(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"prog/go.mod": "module example.com/prog\n\ngo 1.21\n\nrequire example.com/lib v0.0.0\n\n" +
+			"replace example.com/lib => ../lib\n",
+		"prog/main.go": "package main\n\nimport \"example.com/lib\"\n\nfunc main() { lib.Hello() }\n",
+		"lib/go.mod":   "module example.com/lib\n\ngo 1.21\n",
+		"lib/lib.go":   "package lib\n\nfunc Hello() {}\n",
+	})
+	opts := goOptions{buildCacheDir: t.TempDir()}
+
+	program, cleanup, err := buildProgram(filepath.Join(root, "prog"), opts, false, io.Discard)
+	require.NoError(t, err)
+	cleanup()
+
+	// Changes to the replacement module's sources rebuild the program.
+	writeFiles(t, root, map[string]string{"lib/lib.go": "package lib\n\nfunc Hello() { println() }\n"})
+	changed, cleanup, err := buildProgram(filepath.Join(root, "prog"), opts, false, io.Discard)
+	require.NoError(t, err)
+	cleanup()
+	assert.NotEqual(t, program, changed)
+}
+
+func TestEvictBuildCache(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	now := time.Now()
+	for i, name := range []string{"newest", "newer", "older", "oldest"} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, nil, 0o600))
+		modTime := now.Add(-time.Duration(i) * time.Hour)
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+	// Programs that are still being built are left alone, and so are programs that have just been used, while those
+	// left over from an earlier eviction are removed.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "build-123"), nil, 0o600))
+	recent := now.Add(-time.Minute)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "recent"), nil, 0o600))
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "recent"), recent, recent))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "evict-stale"), nil, 0o600))
+
+	evictBuildCache(dir, 1)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.ElementsMatch(t, []string{"newest", "recent", "build-123"}, names)
+}
+
+func TestEmbedPatterns(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"a.txt", "static/*", "all:dir", "with space.txt", "raw name"},
+		embedPatterns(" a.txt  static/*\tall:dir \"with space.txt\" `raw name`"))
+	assert.Empty(t, embedPatterns(""))
+}
+
+//nolint:paralleltest // mutates environment variables
+func TestBuildCacheKeyEnv(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.mod":  "module example.com/prog\n\ngo 1.21\n",
+		"main.go": "package main\n\nfunc main() {}\n",
+	})
+	gobin, err := executable.FindExecutable("go")
+	require.NoError(t, err)
+
+	key, err := buildCacheKey(gobin, root, false)
+	require.NoError(t, err)
+
+	// The C toolchain and its flags affect programs that use cgo, and GOFLAGS may carry linker flags.
+	for name, value := range map[string]string{
+		"CC":          "clang-does-not-exist",
+		"CGO_LDFLAGS": "-lm",
+		"GOFLAGS":     "-ldflags=-s",
+	} {
+		//nolint:paralleltest // mutates environment variables
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(name, value)
+			changed, err := buildCacheKey(gobin, root, false)
+			require.NoError(t, err)
+			assert.NotEqual(t, key, changed)
+		})
+	}
+}
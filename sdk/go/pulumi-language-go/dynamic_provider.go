@@ -0,0 +1,94 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/executable"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// runDynamicProvider serves the dynamic providers of the Go program in the current directory, returning the status to
+// exit with. The engine launches the pulumi-go resource plugin, which runs this, in the program's directory and passes
+// the program's runtime options as PULUMI_RUNTIME_* environment variables.
+//
+// The program is built, or found on PATH if the binary runtime option is set, and then run with
+// PULUMI_GO_DYNAMIC_PROVIDER set. The Go SDK then serves the program's dynamic providers over the provider protocol
+// instead of running the program, so the program's output, including the port it is serving on, is passed straight
+// through to the engine.
+func runDynamicProvider(engineAddress string) int {
+	program, cleanup, err := dynamicProviderProgram()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 1
+	}
+	defer cleanup()
+
+	args := []string{}
+	if engineAddress != "" {
+		args = append(args, engineAddress)
+	}
+	cmd := exec.Command(program, args...)
+	cmd.Env = append(os.Environ(), pulumi.EnvDynamicProvider+"=true")
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode()
+		}
+		fmt.Fprintf(os.Stderr, "error: running dynamic provider: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// dynamicProviderProgram returns the path of the program to serve dynamic providers from, along with a function that
+// cleans up after it. If the binary runtime option is set, that executable is used. Otherwise the program in the
+// current directory is built, or taken from the build cache. The buildTarget option is not used, so that building the
+// provider never overwrites a program that the engine may be running at the same time.
+func dynamicProviderProgram() (string, func(), error) {
+	options := map[string]interface{}{}
+	for _, name := range []string{"binary", "buildCache", "buildCacheDir", "buildCacheMaxEntries"} {
+		if v, ok := os.LookupEnv("PULUMI_RUNTIME_" + strings.ToUpper(name)); ok {
+			options[name] = v
+		}
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", nil, err
+	}
+	opts, err := parseOptions(cwd, options)
+	if err != nil {
+		return "", nil, err
+	}
+	if opts.binary != "" {
+		bin, err := executable.FindExecutable(opts.binary)
+		if err != nil {
+			return "", nil, fmt.Errorf("unable to find '%s' executable: %w", opts.binary, err)
+		}
+		return bin, func() {}, nil
+	}
+
+	logging.V(5).Infof("Building Go program in %s to serve its dynamic providers", cwd)
+
+	// The engine reads the provider's port from stdout, so build output must go to stderr.
+	return buildProgram(cwd, opts, false, os.Stderr)
+}
@@ -72,8 +72,9 @@ const preferredDebugPort = 57134
 
 // This function takes a file target to specify where to compile to.
 // If `outfile` is "", the binary is compiled to a new temporary file.
+// Output from `go build` is written to stdout and os.Stderr.
 // This function returns the path of the file that was produced.
-func compileProgram(programDirectory string, outfile string, withDebugFlags bool) (string, error) {
+func compileProgram(programDirectory string, outfile string, withDebugFlags bool, stdout io.Writer) (string, error) {
 	goFileSearchPattern := filepath.Join(programDirectory, "*.go")
 	if matches, err := filepath.Glob(goFileSearchPattern); err != nil || len(matches) == 0 {
 		return "", fmt.Errorf("Failed to find go files for 'go build' matching %s", goFileSearchPattern)
@@ -103,7 +104,7 @@ func compileProgram(programDirectory string, outfile string, withDebugFlags bool
 	}
 	buildCmd := exec.Command(gobin, args...)
 	buildCmd.Dir = programDirectory
-	buildCmd.Stdout, buildCmd.Stderr = os.Stdout, os.Stderr
+	buildCmd.Stdout, buildCmd.Stderr = stdout, os.Stderr
 
 	if err := buildCmd.Run(); err != nil {
 		return "", fmt.Errorf("unable to run `go build`: %w", err)
@@ -122,8 +123,9 @@ func compileProgram(programDirectory string, outfile string, withDebugFlags bool
 
 // runParams defines the command line arguments accepted by this program.
 type runParams struct {
-	tracing       string
-	engineAddress string
+	tracing         string
+	engineAddress   string
+	dynamicProvider bool
 }
 
 // parseRunParams parses the given arguments into a runParams structure,
@@ -131,6 +133,8 @@ type runParams struct {
 func parseRunParams(flag *flag.FlagSet, args []string) (*runParams, error) {
 	var p runParams
 	flag.StringVar(&p.tracing, "tracing", "", "Emit tracing to a Zipkin-compatible tracing endpoint")
+	flag.BoolVar(&p.dynamicProvider, "dynamic-provider", false,
+		"Serve the dynamic providers of the program in the current directory instead of running as a language host")
 	flag.String("binary", "", "[obsolete] Look on path for a binary executable with this name")
 	flag.String("buildTarget", "", "[obsolete] Path to use to output the compiled Pulumi Go program")
 	flag.String("root", "", "[obsolete] Project root path to use")
@@ -160,6 +164,10 @@ func main() {
 	logging.InitLogging(false, 0, false)
 	cmdutil.InitTracing("pulumi-language-go", "pulumi-language-go", p.tracing)
 
+	if p.dynamicProvider {
+		os.Exit(runDynamicProvider(p.engineAddress))
+	}
+
 	var cmd mainCmd
 	if err := cmd.Run(p); err != nil {
 		cmdutil.Exit(err)
@@ -243,6 +251,12 @@ type goOptions struct {
 	binary string
 	// Path to use to output the compiled Pulumi Go program.
 	buildTarget string
+	// Whether to always build the program rather than reusing a cached build.
+	buildCacheDisabled bool
+	// Directory to cache compiled programs in, by default ~/.pulumi/cache/go.
+	buildCacheDir string
+	// Number of compiled programs to keep in the cache.
+	buildCacheMaxEntries int
 }
 
 func parseOptions(root string, options map[string]interface{}) (goOptions, error) {
@@ -267,6 +281,52 @@ func parseOptions(root string, options map[string]interface{}) (goOptions, error
 		return goOptions, errors.New("binary and buildTarget cannot both be specified")
 	}
 
+	// The build cache options may also be given as strings, as they are when passed to dynamic providers through the
+	// environment.
+	if buildCache, ok := options["buildCache"]; ok {
+		switch buildCache := buildCache.(type) {
+		case bool:
+			goOptions.buildCacheDisabled = !buildCache
+		case string:
+			enabled, err := strconv.ParseBool(buildCache)
+			if err != nil {
+				return goOptions, errors.New("buildCache option must be a boolean")
+			}
+			goOptions.buildCacheDisabled = !enabled
+		default:
+			return goOptions, errors.New("buildCache option must be a boolean")
+		}
+	}
+
+	if buildCacheDir, ok := options["buildCacheDir"]; ok {
+		if dir, ok := buildCacheDir.(string); ok {
+			if dir != "" && !filepath.IsAbs(dir) {
+				dir = filepath.Join(root, dir)
+			}
+			goOptions.buildCacheDir = dir
+		} else {
+			return goOptions, errors.New("buildCacheDir option must be a string")
+		}
+	}
+
+	if maxEntries, ok := options["buildCacheMaxEntries"]; ok {
+		switch maxEntries := maxEntries.(type) {
+		case float64:
+			goOptions.buildCacheMaxEntries = int(maxEntries)
+		case string:
+			n, err := strconv.Atoi(maxEntries)
+			if err != nil {
+				return goOptions, errors.New("buildCacheMaxEntries option must be a number")
+			}
+			goOptions.buildCacheMaxEntries = n
+		default:
+			return goOptions, errors.New("buildCacheMaxEntries option must be a number")
+		}
+		if goOptions.buildCacheMaxEntries < 1 {
+			return goOptions, errors.New("buildCacheMaxEntries option must be at least 1")
+		}
+	}
+
 	return goOptions, nil
 }
 
@@ -1017,14 +1077,11 @@ func (host *goLanguageHost) Run(ctx context.Context, req *pulumirpc.RunRequest)
 	// user did not specify a binary and we will compile and run the binary on-demand
 	logging.V(5).Infof("No prebuilt executable specified, attempting invocation via compilation")
 
-	program, err := compileProgram(req.Info.ProgramDirectory, opts.buildTarget, req.GetAttachDebugger())
+	program, cleanup, err := buildProgram(req.Info.ProgramDirectory, opts, req.GetAttachDebugger(), os.Stdout)
 	if err != nil {
 		return nil, errutil.ErrorWithStderr(err, "error in compiling Go")
 	}
-	if opts.buildTarget == "" {
-		// If there is no specified buildTarget, delete the temporary program after running it.
-		defer os.Remove(program)
-	}
+	defer cleanup()
 
 	return runProgram(ctx, engineClient, req, req.Pwd, program, env), nil
 }
@@ -1223,11 +1280,17 @@ func (host *goLanguageHost) RunPlugin(
 	}
 	defer contract.IgnoreClose(closer)
 
-	program, err := compileProgram(req.Info.ProgramDirectory, "", false)
+	opts, err := parseOptions(req.Info.RootDirectory, req.Info.Options.AsMap())
+	if err != nil {
+		return err
+	}
+	// Plugins are always built, as the binary option names the program rather than the plugin.
+	opts.binary = ""
+	program, cleanup, err := buildProgram(req.Info.ProgramDirectory, opts, false, os.Stdout)
 	if err != nil {
 		return errutil.ErrorWithStderr(err, "error in compiling Go")
 	}
-	defer os.Remove(program)
+	defer cleanup()
 
 	closer, stdout, stderr, err := rpcutil.MakeRunPluginStreams(server, false)
 	if err != nil {
@@ -58,6 +58,14 @@ func TestParseRunParams(t *testing.T) {
 				engineAddress: "localhost:1234",
 			},
 		},
+		{
+			desc: "dynamic provider",
+			give: []string{"-dynamic-provider", "localhost:1234"},
+			want: runParams{
+				engineAddress:   "localhost:1234",
+				dynamicProvider: true,
+			},
+		},
 		{
 			desc: "binary",
 			give: []string{"-binary", "foo", "localhost:1234"},
@@ -0,0 +1,432 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/spf13/cast"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+var (
+	outputType          = reflect.TypeOf((*pulumi.Output)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Bind populates the exported fields of the struct that v points to from the configuration in the project's namespace.
+// Each field is bound to the configuration key named by its `config` tag, or to its name with the first letter in
+// lower case if it has no tag. A tag of "-" skips the field, and keys that contain a colon, e.g. "aws:region", are
+// read from that namespace instead.
+//
+// Strings, booleans and numbers are parsed as they are by Get, GetBool, GetInt and so on, and values of any other
+// type are decoded from JSON as they are by GetObject. Fields whose type is a pulumi.Output, e.g. pulumi.StringOutput,
+// are bound to secrets: the value is decoded as the output's element type and wrapped in a secret output.
+//
+// A `default` tag gives the value to use if the key isn't set. Fields whose keys aren't set and that have no default
+// are left unchanged. A `validate` tag gives a comma separated list of rules that the value must satisfy:
+//
+//   - required: the key must be set.
+//   - min=N, max=N: numbers must be at least or at most N, and strings, slices and maps must have at least or at most
+//     N elements.
+//   - oneof=A B C: the value must be one of the space separated values.
+//   - pattern=RE: strings must match the regular expression, which can't contain commas.
+//
+// Every field is checked before Bind returns, and all of the problems that are found are reported in a single error,
+// so programs can return it before registering any resources:
+//
+//	type Config struct {
+//		Domain   string              `config:"domain" validate:"required"`
+//		Replicas int                 `config:"replicas" default:"2" validate:"min=1,max=10"`
+//		Password pulumi.StringOutput `config:"dbPassword" validate:"required"`
+//		Region   string              `config:"aws:region"`
+//	}
+//
+//	var cfg Config
+//	if err := config.Bind(ctx, &cfg); err != nil {
+//		return err
+//	}
+func Bind(ctx *pulumi.Context, v interface{}) error {
+	return bind(ctx, ctx.Project(), v)
+}
+
+// Bind populates the exported fields of the struct that v points to from the configuration in the bag's namespace. See
+// the package level Bind for how fields are bound.
+func (c *Config) Bind(v interface{}) error {
+	return bind(c.ctx, c.namespace, v)
+}
+
+// ProjectConfigTypes returns the config declarations for the `config` section of a project's Pulumi.yaml that match
+// the fields of the struct v, or that v points to, as they would be bound by Bind. Fields in namespaces other than
+// the project's can't be declared and are skipped. A `description` tag sets the declaration's description.
+func ProjectConfigTypes(project string, v interface{}) (map[string]workspace.ProjectConfigType, error) {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	fields, err := bindFields(project, t)
+	if err != nil {
+		return nil, err
+	}
+
+	types := map[string]workspace.ProjectConfigType{}
+	for _, f := range fields {
+		name, ok := strings.CutPrefix(f.key, project+":")
+		if !ok {
+			continue
+		}
+
+		configType := workspace.ProjectConfigType{Description: f.description, Secret: f.secret}
+		if typeName, items, ok := projectConfigType(f.valueType); ok {
+			configType.Type, configType.Items = &typeName, items
+		}
+		if f.defaultValue != nil {
+			value, err := decodeValue(*f.defaultValue, f.valueType)
+			if err != nil {
+				return nil, fmt.Errorf("invalid default for field %s: %w", f.name, err)
+			}
+			if configType.Default, err = plainValue(value); err != nil {
+				return nil, fmt.Errorf("invalid default for field %s: %w", f.name, err)
+			}
+		}
+		types[name] = configType
+	}
+	return types, nil
+}
+
+func bind(ctx *pulumi.Context, namespace string, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config.Bind requires a non-nil pointer to a struct, not %T", v)
+	}
+	fields, err := bindFields(namespace, rv.Elem().Type())
+	if err != nil {
+		return err
+	}
+
+	var result error
+	for _, f := range fields {
+		if err := f.bind(ctx, rv.Elem().Field(f.index)); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+	if result != nil {
+		return fmt.Errorf("invalid configuration: %w", result)
+	}
+	return nil
+}
+
+// bindField describes how a struct field is bound to a configuration key.
+type bindField struct {
+	index        int
+	name         string
+	key          string
+	secret       bool
+	valueType    reflect.Type
+	defaultValue *string
+	description  string
+	rules        bindRules
+}
+
+// bindRules are the rules from a field's `validate` tag.
+type bindRules struct {
+	required bool
+	min, max *float64
+	oneOf    []string
+	pattern  *regexp.Regexp
+}
+
+// bindFields returns the bindings for the fields of the struct type t, reading keys without a namespace from the given
+// namespace.
+func bindFields(namespace string, t reflect.Type) ([]bindField, error) {
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config bindings must be structs, not %v", t)
+	}
+
+	var fields []bindField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		key, hasKey := sf.Tag.Lookup("config")
+		if !sf.IsExported() || key == "-" {
+			continue
+		}
+		if !hasKey || key == "" {
+			r, size := utf8.DecodeRuneInString(sf.Name)
+			key = string(unicode.ToLower(r)) + sf.Name[size:]
+		}
+		if !strings.Contains(key, ":") {
+			key = namespace + ":" + key
+		}
+
+		f := bindField{
+			index:       i,
+			name:        sf.Name,
+			key:         key,
+			valueType:   sf.Type,
+			description: sf.Tag.Get("description"),
+		}
+		if sf.Type.Implements(outputType) {
+			if sf.Type.Kind() == reflect.Interface {
+				return nil, fmt.Errorf("field %s must have a concrete output type, e.g. pulumi.StringOutput", sf.Name)
+			}
+			f.secret = true
+			f.valueType = reflect.Zero(sf.Type).Interface().(pulumi.Output).ElementType()
+		}
+		if def, ok := sf.Tag.Lookup("default"); ok {
+			f.defaultValue = &def
+		}
+
+		rules, err := parseBindRules(sf.Tag.Get("validate"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid validate tag on field %s: %w", sf.Name, err)
+		}
+		if rules.required && f.defaultValue != nil {
+			return nil, fmt.Errorf("field %s is required but has a default", sf.Name)
+		}
+		f.rules = rules
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+func parseBindRules(tag string) (bindRules, error) {
+	var rules bindRules
+	if tag == "" {
+		return rules, nil
+	}
+	for _, rule := range strings.Split(tag, ",") {
+		name, arg, hasArg := strings.Cut(strings.TrimSpace(rule), "=")
+		switch {
+		case name == "required" && !hasArg:
+			rules.required = true
+		case (name == "min" || name == "max") && hasArg:
+			n, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				return rules, fmt.Errorf("%s must be a number: %w", name, err)
+			}
+			if name == "min" {
+				rules.min = &n
+			} else {
+				rules.max = &n
+			}
+		case name == "oneof" && hasArg:
+			rules.oneOf = strings.Fields(arg)
+		case name == "pattern" && hasArg:
+			re, err := regexp.Compile(arg)
+			if err != nil {
+				return rules, fmt.Errorf("invalid pattern: %w", err)
+			}
+			rules.pattern = re
+		default:
+			return rules, fmt.Errorf("unknown rule %q", rule)
+		}
+	}
+	return rules, nil
+}
+
+// bind sets the field to its configuration value, returning an error if the value is missing, malformed or invalid.
+// Errors never include the value, which may be secret.
+func (f *bindField) bind(ctx *pulumi.Context, field reflect.Value) error {
+	raw, ok := ctx.GetConfig(f.key)
+	if !ok && f.defaultValue != nil {
+		raw, ok = *f.defaultValue, true
+	}
+	if !ok && f.rules.required {
+		return missingVariable{f.key}
+	}
+
+	value := reflect.Zero(f.valueType)
+	if ok {
+		var err error
+		if value, err = decodeValue(raw, f.valueType); err != nil {
+			return fmt.Errorf("unable to parse configuration variable '%s': %w", f.key, err)
+		}
+		if err := f.rules.check(value); err != nil {
+			return fmt.Errorf("configuration variable '%s' %w", f.key, err)
+		}
+	} else if !f.secret {
+		return nil
+	}
+
+	if f.secret {
+		secret := reflect.ValueOf(pulumi.ToSecret(value.Interface()))
+		if !secret.Type().AssignableTo(field.Type()) {
+			return fmt.Errorf("unable to bind secret configuration variable '%s' to a %v", f.key, field.Type())
+		}
+		value = secret
+	}
+	field.Set(value)
+	return nil
+}
+
+// check returns an error describing the first rule that the value doesn't satisfy.
+func (rules *bindRules) check(v reflect.Value) error {
+	// min and max bound numbers, and the lengths of everything else.
+	var size float64
+	var unit string
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		size = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		size = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		size = v.Float()
+	case reflect.String:
+		size, unit = float64(utf8.RuneCountInString(v.String())), " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		size, unit = float64(v.Len()), " elements"
+	default:
+		if rules.min != nil || rules.max != nil {
+			return fmt.Errorf("can't be checked against min or max, as it is a %v", v.Type())
+		}
+	}
+	if rules.min != nil && size < *rules.min {
+		return fmt.Errorf("must be at least %v%s", *rules.min, unit)
+	}
+	if rules.max != nil && size > *rules.max {
+		return fmt.Errorf("must be at most %v%s", *rules.max, unit)
+	}
+
+	if len(rules.oneOf) > 0 {
+		s := fmt.Sprint(v.Interface())
+		found := false
+		for _, allowed := range rules.oneOf {
+			found = found || s == allowed
+		}
+		if !found {
+			return fmt.Errorf("must be one of %s", strings.Join(rules.oneOf, ", "))
+		}
+	}
+
+	if rules.pattern != nil {
+		if v.Kind() != reflect.String {
+			return fmt.Errorf("can't be checked against a pattern, as it is a %v", v.Type())
+		}
+		if !rules.pattern.MatchString(v.String()) {
+			return fmt.Errorf("must match the pattern %s", rules.pattern)
+		}
+	}
+	return nil
+}
+
+// decodeValue decodes a raw configuration value as a value of type t.
+func decodeValue(raw string, t reflect.Type) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		err := v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+		return v, err
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := cast.ToBoolE(raw)
+		if err != nil {
+			return v, err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := cast.ToInt64E(raw)
+		if err != nil {
+			return v, err
+		}
+		if v.OverflowInt(i) {
+			return v, fmt.Errorf("%d overflows %v", i, t)
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := cast.ToUint64E(raw)
+		if err != nil {
+			return v, err
+		}
+		if v.OverflowUint(u) {
+			return v, fmt.Errorf("%d overflows %v", u, t)
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := cast.ToFloat64E(raw)
+		if err != nil {
+			return v, err
+		}
+		v.SetFloat(f)
+	default:
+		if err := json.Unmarshal([]byte(raw), v.Addr().Interface()); err != nil {
+			return v, err
+		}
+	}
+	return v, nil
+}
+
+// projectConfigType returns the Pulumi.yaml config type for values of type t, if there is one.
+func projectConfigType(t reflect.Type) (string, *workspace.ProjectConfigItemsType, bool) {
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return "", nil, false
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string", nil, true
+	case reflect.Bool:
+		return "boolean", nil, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "integer", nil, true
+	case reflect.Slice, reflect.Array:
+		name, items, ok := projectConfigType(t.Elem())
+		if !ok {
+			return "", nil, false
+		}
+		return "array", &workspace.ProjectConfigItemsType{Type: name, Items: items}, true
+	default:
+		return "", nil, false
+	}
+}
+
+// plainValue converts a decoded value to the plain form that Pulumi.yaml defaults take.
+func plainValue(v reflect.Value) (interface{}, error) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() > uint64(^uint(0)>>1) {
+			return nil, errors.New("value is too large")
+		}
+		return int(v.Uint()), nil //nolint:gosec // checked for overflow above
+	}
+
+	bytes, err := json.Marshal(v.Interface())
+	if err != nil {
+		return nil, err
+	}
+	var plain interface{}
+	err = json.Unmarshal(bytes, &plain)
+	return plain, err
+}
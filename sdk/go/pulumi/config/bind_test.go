@@ -0,0 +1,171 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+type siteConfig struct {
+	Domain   string              `config:"domain" validate:"required,pattern=^[a-z.]+$" description:"The domain."`
+	Replicas int                 `config:"replicas" default:"2" validate:"min=1,max=10"`
+	Tier     string              `default:"standard" validate:"oneof=standard premium"`
+	Tags     []string            `config:"tags" validate:"max=3"`
+	Limits   map[string]int      `config:"limits"`
+	Password pulumi.StringOutput `config:"dbPassword" validate:"required"`
+	Ports    pulumi.IntArrayOutput
+	Region   string `config:"aws:region"`
+	Debug    bool   `config:"-"`
+	internal string //nolint:unused // unexported fields aren't bound
+}
+
+func newBindContext(t *testing.T, config map[string]string) *pulumi.Context {
+	ctx, err := pulumi.NewContext(context.Background(), pulumi.RunInfo{
+		Project: "site",
+		Config:  config,
+	})
+	require.NoError(t, err)
+	return ctx
+}
+
+func awaitSecret(t *testing.T, o pulumi.Output) interface{} {
+	t.Helper()
+	assert.True(t, pulumi.IsSecret(o))
+	result := make(chan interface{}, 1)
+	o.ApplyT(func(v interface{}) interface{} {
+		result <- v
+		return v
+	})
+	return <-result
+}
+
+func TestBind(t *testing.T) {
+	t.Parallel()
+
+	ctx := newBindContext(t, map[string]string{
+		"site:domain":     "example.com",
+		"site:tags":       `["a", "b"]`,
+		"site:limits":     `{"cpu": 2}`,
+		"site:dbPassword": "hunter2",
+		"site:ports":      `[80, 443]`,
+		"site:debug":      "true",
+		"aws:region":      "us-west-2",
+	})
+
+	cfg := siteConfig{Debug: true}
+	require.NoError(t, Bind(ctx, &cfg))
+	assert.Equal(t, "example.com", cfg.Domain)
+	assert.Equal(t, 2, cfg.Replicas)
+	assert.Equal(t, "standard", cfg.Tier)
+	assert.Equal(t, []string{"a", "b"}, cfg.Tags)
+	assert.Equal(t, map[string]int{"cpu": 2}, cfg.Limits)
+	assert.Equal(t, "us-west-2", cfg.Region)
+	assert.True(t, cfg.Debug)
+	assert.Equal(t, "hunter2", awaitSecret(t, cfg.Password))
+	assert.Equal(t, []int{80, 443}, awaitSecret(t, cfg.Ports))
+}
+
+func TestBindNamespace(t *testing.T) {
+	t.Parallel()
+
+	ctx := newBindContext(t, map[string]string{
+		"other:domain":     "example.com",
+		"other:dbPassword": "hunter2",
+	})
+
+	var cfg siteConfig
+	require.NoError(t, New(ctx, "other").Bind(&cfg))
+	assert.Equal(t, "example.com", cfg.Domain)
+	assert.Equal(t, "hunter2", awaitSecret(t, cfg.Password))
+}
+
+func TestBindErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx := newBindContext(t, map[string]string{
+		"site:domain":   "Example.com",
+		"site:replicas": "11",
+		"site:tier":     "free",
+		"site:tags":     `["a", "b", "c", "d"]`,
+		"site:limits":   "not json",
+		"site:ports":    "[80]",
+	})
+
+	var cfg siteConfig
+	err := Bind(ctx, &cfg)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrMissingVar))
+	for _, msg := range []string{
+		"configuration variable 'site:domain' must match the pattern ^[a-z.]+$",
+		"configuration variable 'site:replicas' must be at most 10",
+		"configuration variable 'site:tier' must be one of standard, premium",
+		"configuration variable 'site:tags' must be at most 3 elements",
+		"unable to parse configuration variable 'site:limits'",
+		"missing required configuration variable 'site:dbPassword'",
+	} {
+		assert.ErrorContains(t, err, msg)
+	}
+	assert.NotContains(t, err.Error(), "Example.com", "errors don't include values, which may be secret")
+
+	// Malformed bindings are reported before any configuration is read.
+	assert.ErrorContains(t, Bind(ctx, cfg), "requires a non-nil pointer to a struct")
+	assert.ErrorContains(t, Bind(ctx, &struct {
+		Name string `validate:"required,long"`
+	}{}), `invalid validate tag on field Name: unknown rule "long"`)
+	assert.ErrorContains(t, Bind(ctx, &struct {
+		Name string `default:"x" validate:"required"`
+	}{}), "field Name is required but has a default")
+}
+
+func TestProjectConfigTypes(t *testing.T) {
+	t.Parallel()
+
+	types, err := ProjectConfigTypes("site", &siteConfig{})
+	require.NoError(t, err)
+
+	str, integer, array := "string", "integer", "array"
+	assert.Equal(t, map[string]workspace.ProjectConfigType{
+		"domain":   {Type: &str, Description: "The domain."},
+		"replicas": {Type: &integer, Default: 2},
+		"tier":     {Type: &str, Default: "standard"},
+		"tags":     {Type: &array, Items: &workspace.ProjectConfigItemsType{Type: "string"}},
+		"limits":   {},
+		"dbPassword": {
+			Type:   &str,
+			Secret: true,
+		},
+		"ports": {
+			Type:   &array,
+			Items:  &workspace.ProjectConfigItemsType{Type: "integer"},
+			Secret: true,
+		},
+	}, types)
+
+	// The declarations are valid in a project.
+	project := &workspace.Project{
+		Name:    "site",
+		Runtime: workspace.NewProjectRuntimeInfo("go", nil),
+		Config:  types,
+	}
+	assert.NoError(t, project.Validate())
+}
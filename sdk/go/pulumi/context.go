@@ -79,6 +79,9 @@ type contextState struct {
 	rpcError                 error        // the first error (if any) encountered during an RPC.
 	registeredOutputsLock    sync.Mutex   // a lock protecting the registeredOutputs map
 	registeredOutputs        map[URN]bool // tracks which resources have had outputs registered
+	stackOutputReadsLock     sync.Mutex   // a lock protecting the stackOutputReads map
+	// the names of the outputs read through each StackReference, recorded with the engine once the program finishes.
+	stackOutputReads map[URN]map[string]bool
 
 	join workGroup // the waitgroup for non-RPC async work associated with this context
 }
@@ -202,6 +205,7 @@ func NewContext(ctx context.Context, info RunInfo) (*Context, error) {
 		supportsParameterization: supportsParameterization,
 		supportsResourceHooks:    supportsResourceHooks,
 		registeredOutputs:        make(map[URN]bool),
+		stackOutputReads:         make(map[URN]map[string]bool),
 	}
 	contextState.rpcsDone = sync.NewCond(&contextState.rpcsLock)
 	context := &Context{
@@ -1460,6 +1464,72 @@ func (ctx *Context) getResource(urn string) (*pulumirpc.RegisterResourceResponse
 	}, nil
 }
 
+// noteStackOutputRead notes that the program read the named output through the StackReference with the given URN. The
+// reads are sent to the engine in one batch per StackReference by flushStackOutputReads.
+func (ctx *Context) noteStackOutputRead(urn URN, name string) {
+	ctx.state.stackOutputReadsLock.Lock()
+	defer ctx.state.stackOutputReadsLock.Unlock()
+
+	names, ok := ctx.state.stackOutputReads[urn]
+	if !ok {
+		names = make(map[string]bool)
+		ctx.state.stackOutputReads[urn] = names
+	}
+	names[name] = true
+}
+
+// flushStackOutputReads records the stack output reads noted during the program's run with the engine. Older engines
+// don't support recording reads, so failing to record them is logged rather than returned.
+func (ctx *Context) flushStackOutputReads() {
+	ctx.state.stackOutputReadsLock.Lock()
+	reads := ctx.state.stackOutputReads
+	ctx.state.stackOutputReads = make(map[URN]map[string]bool)
+	ctx.state.stackOutputReadsLock.Unlock()
+
+	urns := make([]URN, 0, len(reads))
+	for urn := range reads {
+		urns = append(urns, urn)
+	}
+	sort.Slice(urns, func(i, j int) bool { return urns[i] < urns[j] })
+
+	for _, urn := range urns {
+		names := make([]string, 0, len(reads[urn]))
+		for name := range reads[urn] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		if err := ctx.recordStackOutputReads(urn, names); err != nil {
+			logging.V(5).Infof("failed to record reads of outputs of %v: %v", urn, err)
+		}
+	}
+}
+
+// recordStackOutputReads tells the engine that the program read the given outputs through the StackReference with the
+// given URN, so that they are recorded in the StackReference's state.
+func (ctx *Context) recordStackOutputReads(urn URN, names []string) error {
+	resolvedArgsMap := resource.NewPropertyMapFromMap(map[string]interface{}{
+		"urn":     string(urn),
+		"outputs": names,
+	})
+
+	rpcArgs, err := plugin.MarshalProperties(resolvedArgsMap, plugin.MarshalOptions{})
+	if err != nil {
+		return fmt.Errorf("marshaling arguments: %w", err)
+	}
+
+	tok := "pulumi:pulumi:recordStackOutputReads"
+	logging.V(9).Infof("Invoke(%s, #args=%d): RPC call being made synchronously", tok, len(resolvedArgsMap))
+	_, err = ctx.state.monitor.Invoke(ctx.ctx, &pulumirpc.ResourceInvokeRequest{
+		Tok:  tok,
+		Args: rpcArgs,
+	})
+	if err != nil {
+		return fmt.Errorf("invoke(%s, ...): error: %w", tok, err)
+	}
+	return nil
+}
+
 // registerResourceHook starts up a callback server if not already running and registers the given hook function.
 func (ctx *Context) registerResourceHook(f ResourceHookFunction) (*pulumirpc.Callback, error) {
 	if !ctx.state.supportsResourceHooks {
@@ -1758,6 +1828,8 @@ func (ctx *Context) registerResource(
 				SupportsResultReporting:    true,
 				PackageRef:                 packageRef,
 				Hooks:                      hooks,
+				Expires:                    inputs.expires,
+				Annotations:                inputs.annotations,
 			})
 			if err != nil {
 				logging.V(9).Infof("RegisterResource(%s, %s): error: %v", t, name, err)
@@ -2217,6 +2289,8 @@ type resourceInputs struct {
 	replaceOnChanges        []string
 	retainOnDelete          *bool
 	deletedWith             string
+	expires                 string
+	annotations             map[string]string
 }
 
 func (ctx *Context) resolveAliasParent(alias Alias, spec *pulumirpc.Alias_Spec) error {
@@ -2490,6 +2564,8 @@ func (ctx *Context) prepareResourceInputs(res Resource, props Input, t string, o
 		replaceOnChanges:        resOpts.replaceOnChanges,
 		retainOnDelete:          opts.RetainOnDelete,
 		deletedWith:             string(deletedWithURN),
+		expires:                 opts.Expires,
+		annotations:             opts.Annotations,
 	}, nil
 }
 
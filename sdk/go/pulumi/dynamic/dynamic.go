@@ -0,0 +1,409 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dynamic lets a Pulumi Go program implement the lifecycle of a resource inline, rather than in a separately
+// built provider plugin.
+//
+// A program registers a provider with [NewProvider] when its package is initialized, typically in a package-level
+// variable, and then creates resources with [ResourceProvider.New]:
+//
+//	type webhookArgs struct {
+//		URL string `pulumi:"url"`
+//	}
+//
+//	type webhookState struct {
+//		HookID string `pulumi:"hookId"`
+//	}
+//
+//	var webhooks = dynamic.NewProvider[webhookArgs, webhookState]("webhook", &webhookProvider{})
+//
+//	func main() {
+//		pulumi.Run(func(ctx *pulumi.Context) error {
+//			hook, err := webhooks.New(ctx, "hook", pulumi.Map{"url": pulumi.String("https://example.com")})
+//			...
+//		})
+//	}
+//
+// Dynamic resources are served to the engine by the Go language host, which builds the program and runs it in a mode
+// where it serves the registered providers instead of running the program body. Each resource's state records the
+// name of the provider that created it along with its inputs and outputs, so that later operations, including a
+// `pulumi destroy` after the resource has been removed from the program, are dispatched to the same provider. A
+// provider must therefore stay registered under the same name until every resource it created has been deleted.
+package dynamic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/mapper"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumix"
+)
+
+// ResourceType is the type of every dynamic resource created by a Go program.
+const ResourceType = "pulumi-go:dynamic:Resource"
+
+const (
+	// providerKey is the input and output under which the name of a resource's provider is recorded.
+	providerKey = resource.PropertyKey("__provider")
+	// outputsKey is the output under which the outputs returned by a resource's provider are recorded.
+	outputsKey = resource.PropertyKey("outputs")
+)
+
+// ErrNotFound may be returned by Reader.Read to indicate that the resource no longer exists.
+var ErrNotFound = errors.New("resource not found")
+
+// Provider implements the lifecycle of a dynamic resource with inputs of type I and outputs of type O. I and O are
+// usually structs whose fields are tagged with `pulumi:"name"`. A Provider must implement Create, and may implement
+// any of Reader, Updater, Deleter, Differ and Configurer to take part in the rest of the lifecycle.
+type Provider[I, O any] interface {
+	// Create creates a resource with the given inputs, returning its ID and outputs.
+	Create(ctx context.Context, inputs I) (id string, outputs O, err error)
+}
+
+// Reader is implemented by providers that can read the current state of a resource, for `pulumi refresh`. Read
+// returns ErrNotFound if the resource no longer exists. If a provider does not implement Reader, refreshing a resource
+// leaves its outputs unchanged.
+type Reader[I, O any] interface {
+	Read(ctx context.Context, id string, inputs I, outputs O) (O, error)
+}
+
+// Updater is implemented by providers that can update a resource in place. If a provider does not implement Updater,
+// any change to a resource's inputs replaces it.
+type Updater[I, O any] interface {
+	Update(ctx context.Context, id string, olds, news I, outputs O) (O, error)
+}
+
+// Deleter is implemented by providers that need to clean up when a resource is deleted. If a provider does not
+// implement Deleter, deleting a resource only removes it from the stack's state.
+type Deleter[I, O any] interface {
+	Delete(ctx context.Context, id string, inputs I, outputs O) error
+}
+
+// Differ is implemented by providers that decide for themselves whether a change to a resource's inputs requires an
+// update or a replacement. If a provider does not implement Differ, any input that has changed is reported as an
+// update, or as a replacement if the provider does not implement Updater.
+type Differ[I, O any] interface {
+	Diff(ctx context.Context, id string, olds, news I, outputs O) (DiffResult, error)
+}
+
+// Configurer is implemented by providers that need the stack's configuration, for example to read credentials. The
+// configuration is keyed by full configuration key, such as "myproject:apiToken", and secrets are decrypted.
+type Configurer interface {
+	Configure(ctx context.Context, config map[string]string) error
+}
+
+// DiffResult is the result of a call to Differ.Diff.
+type DiffResult struct {
+	// Changes is true if the resource needs to be updated or replaced.
+	Changes bool
+	// Replaces lists the inputs whose changes require the resource to be replaced.
+	Replaces []string
+	// DeleteBeforeReplace is true if the resource must be deleted before its replacement is created.
+	DeleteBeforeReplace bool
+}
+
+// ResourceProvider is a registered dynamic provider, which creates resources with inputs of type I and outputs of
+// type O.
+type ResourceProvider[I, O any] struct {
+	name     string
+	provider Provider[I, O]
+}
+
+// registeredProvider is the untyped view of a ResourceProvider that the provider server dispatches to.
+type registeredProvider interface {
+	configure(ctx context.Context, config map[string]string) error
+	create(ctx context.Context, inputs resource.PropertyMap) (string, resource.PropertyMap, error)
+	read(ctx context.Context, id string, inputs, outputs resource.PropertyMap) (resource.PropertyMap, error)
+	update(ctx context.Context, id string, olds, news, outputs resource.PropertyMap) (resource.PropertyMap, error)
+	delete(ctx context.Context, id string, inputs, outputs resource.PropertyMap) error
+	diff(ctx context.Context, id string, olds, news, outputs resource.PropertyMap) (*DiffResult, error)
+	canUpdate() bool
+}
+
+var (
+	registryLock sync.Mutex
+	registry     = map[string]registeredProvider{}
+)
+
+// NewProvider registers a dynamic provider under the given name. The name is recorded in the state of every resource
+// the provider creates and is used to find the provider again for later operations on those resources, so it must be
+// unique within the program and must not change. NewProvider must be called while the program's packages are
+// initialized, such as from a package-level variable or an init function, so that the provider is registered before
+// the program starts serving it. NewProvider panics if the name is empty or already registered.
+func NewProvider[I, O any](name string, provider Provider[I, O]) *ResourceProvider[I, O] {
+	if name == "" {
+		panic("dynamic provider name must not be empty")
+	}
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if _, has := registry[name]; has {
+		panic(fmt.Sprintf("a dynamic provider named %q is already registered", name))
+	}
+	p := &ResourceProvider[I, O]{name: name, provider: provider}
+	registry[name] = p
+	return p
+}
+
+// lookupProvider returns the provider registered under the given name.
+func lookupProvider(name string) (registeredProvider, error) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	p, has := registry[name]
+	if !has {
+		return nil, &providerNotRegisteredError{name: name}
+	}
+	return p, nil
+}
+
+// providerNotRegisteredError is returned by lookupProvider if no provider is registered under the given name.
+type providerNotRegisteredError struct {
+	name string
+}
+
+func (e *providerNotRegisteredError) Error() string {
+	return fmt.Sprintf("no dynamic provider named %q is registered in this program; a dynamic provider must "+
+		"remain registered until every resource it created has been deleted", e.name)
+}
+
+// Name returns the name the provider is registered under.
+func (p *ResourceProvider[I, O]) Name() string {
+	return p.name
+}
+
+// Resource is a resource managed by a dynamic provider with outputs of type O.
+type Resource[O any] struct {
+	pulumi.CustomResourceState
+
+	// Outputs holds the outputs returned by the resource's provider.
+	Outputs pulumi.MapOutput `pulumi:"outputs"`
+}
+
+// Result returns the outputs returned by the resource's provider as a value of type O.
+func (r *Resource[O]) Result() pulumix.Output[O] {
+	return pulumix.ApplyErr[map[string]interface{}](r.Outputs, func(outputs map[string]interface{}) (O, error) {
+		var result O
+		if err := decodeValue(outputs, &result); err != nil {
+			return result, fmt.Errorf("decoding outputs: %w", err)
+		}
+		return result, nil
+	})
+}
+
+// New creates a resource managed by this provider. The resolved args are decoded into the provider's input type I.
+func (p *ResourceProvider[I, O]) New(
+	ctx *pulumi.Context, name string, args pulumi.Map, opts ...pulumi.ResourceOption,
+) (*Resource[O], error) {
+	props := pulumi.Map{}
+	for k, v := range args {
+		props[k] = v
+	}
+	props[string(providerKey)] = pulumi.String(p.name)
+
+	var res Resource[O]
+	if err := ctx.RegisterResource(ResourceType, name, props, &res, opts...); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (p *ResourceProvider[I, O]) configure(ctx context.Context, config map[string]string) error {
+	if c, ok := p.provider.(Configurer); ok {
+		return c.Configure(ctx, config)
+	}
+	return nil
+}
+
+func (p *ResourceProvider[I, O]) create(
+	ctx context.Context, inputs resource.PropertyMap,
+) (string, resource.PropertyMap, error) {
+	in, err := decode[I](inputs)
+	if err != nil {
+		return "", nil, fmt.Errorf("decoding inputs: %w", err)
+	}
+	id, out, err := p.provider.Create(ctx, in)
+	if err != nil {
+		return "", nil, err
+	}
+	if id == "" {
+		return "", nil, errors.New("dynamic provider returned an empty ID from Create")
+	}
+	outputs, err := encode(out)
+	if err != nil {
+		return "", nil, fmt.Errorf("encoding outputs: %w", err)
+	}
+	return id, outputs, nil
+}
+
+func (p *ResourceProvider[I, O]) read(
+	ctx context.Context, id string, inputs, outputs resource.PropertyMap,
+) (resource.PropertyMap, error) {
+	r, ok := p.provider.(Reader[I, O])
+	if !ok {
+		return outputs, nil
+	}
+	in, err := decode[I](inputs)
+	if err != nil {
+		return nil, fmt.Errorf("decoding inputs: %w", err)
+	}
+	out, err := decode[O](outputs)
+	if err != nil {
+		return nil, fmt.Errorf("decoding outputs: %w", err)
+	}
+	out, err = r.Read(ctx, id, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return encode(out)
+}
+
+func (p *ResourceProvider[I, O]) update(
+	ctx context.Context, id string, olds, news, outputs resource.PropertyMap,
+) (resource.PropertyMap, error) {
+	u, ok := p.provider.(Updater[I, O])
+	if !ok {
+		return nil, fmt.Errorf("dynamic provider %q does not support updates", p.name)
+	}
+	oldIn, err := decode[I](olds)
+	if err != nil {
+		return nil, fmt.Errorf("decoding old inputs: %w", err)
+	}
+	newIn, err := decode[I](news)
+	if err != nil {
+		return nil, fmt.Errorf("decoding inputs: %w", err)
+	}
+	out, err := decode[O](outputs)
+	if err != nil {
+		return nil, fmt.Errorf("decoding outputs: %w", err)
+	}
+	out, err = u.Update(ctx, id, oldIn, newIn, out)
+	if err != nil {
+		return nil, err
+	}
+	return encode(out)
+}
+
+func (p *ResourceProvider[I, O]) delete(ctx context.Context, id string, inputs, outputs resource.PropertyMap) error {
+	d, ok := p.provider.(Deleter[I, O])
+	if !ok {
+		return nil
+	}
+	in, err := decode[I](inputs)
+	if err != nil {
+		return fmt.Errorf("decoding inputs: %w", err)
+	}
+	out, err := decode[O](outputs)
+	if err != nil {
+		return fmt.Errorf("decoding outputs: %w", err)
+	}
+	return d.Delete(ctx, id, in, out)
+}
+
+// diff calls the provider's Differ, or returns nil if the provider does not implement it.
+func (p *ResourceProvider[I, O]) diff(
+	ctx context.Context, id string, olds, news, outputs resource.PropertyMap,
+) (*DiffResult, error) {
+	d, ok := p.provider.(Differ[I, O])
+	if !ok {
+		return nil, nil
+	}
+	oldIn, err := decode[I](olds)
+	if err != nil {
+		return nil, fmt.Errorf("decoding old inputs: %w", err)
+	}
+	newIn, err := decode[I](news)
+	if err != nil {
+		return nil, fmt.Errorf("decoding inputs: %w", err)
+	}
+	out, err := decode[O](outputs)
+	if err != nil {
+		return nil, fmt.Errorf("decoding outputs: %w", err)
+	}
+	result, err := d.Diff(ctx, id, oldIn, newIn, out)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (p *ResourceProvider[I, O]) canUpdate() bool {
+	_, ok := p.provider.(Updater[I, O])
+	return ok
+}
+
+// plainValue unwraps secrets and known outputs so that a property map can be decoded into a Go value.
+func plainValue(v resource.PropertyValue) (interface{}, bool) {
+	switch {
+	case v.IsSecret():
+		return v.SecretValue().Element.MapRepl(nil, plainValue), true
+	case v.IsOutput() && v.OutputValue().Known:
+		return v.OutputValue().Element.MapRepl(nil, plainValue), true
+	}
+	return nil, false
+}
+
+// decode decodes a property map into a value of type T.
+func decode[T any](props resource.PropertyMap) (T, error) {
+	var result T
+	obj := props.MapRepl(nil, plainValue)
+	delete(obj, string(providerKey))
+	err := decodeValue(obj, &result)
+	return result, err
+}
+
+// decodeValue decodes an object into target, which must be a pointer to a struct, a map or an interface.
+func decodeValue(obj map[string]interface{}, target interface{}) error {
+	switch t := target.(type) {
+	case *map[string]interface{}:
+		*t = obj
+		return nil
+	case *interface{}:
+		*t = obj
+		return nil
+	}
+	if err := mapper.MapI(obj, target); err != nil {
+		return err
+	}
+	return nil
+}
+
+// encode encodes a value of any type into a property map.
+func encode(v interface{}) (resource.PropertyMap, error) {
+	if v == nil {
+		return resource.PropertyMap{}, nil
+	}
+	if m, ok := v.(map[string]interface{}); ok {
+		return resource.NewPropertyMapFromMap(m), nil
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return resource.PropertyMap{}, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct or a map, not %v", rv.Type())
+	}
+	obj, err := mapper.Unmap(rv.Interface())
+	if err != nil {
+		return nil, err
+	}
+	return resource.NewPropertyMapFromMap(obj), nil
+}
@@ -0,0 +1,307 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+type hookArgs struct {
+	URL     string `pulumi:"url"`
+	Retries int    `pulumi:"retries,optional"`
+}
+
+type hookState struct {
+	HookID string `pulumi:"hookId"`
+	URL    string `pulumi:"url"`
+}
+
+// hookProvider is a provider that records the hooks it manages in memory.
+type hookProvider struct {
+	hooks   map[string]string
+	nextID  int
+	deleted []string
+}
+
+func (p *hookProvider) Create(_ context.Context, inputs hookArgs) (string, hookState, error) {
+	p.nextID++
+	id := "hook-" + strconv.Itoa(p.nextID)
+	p.hooks[id] = inputs.URL
+	return id, hookState{HookID: id, URL: inputs.URL}, nil
+}
+
+func (p *hookProvider) Read(_ context.Context, id string, _ hookArgs, outputs hookState) (hookState, error) {
+	url, has := p.hooks[id]
+	if !has {
+		return hookState{}, ErrNotFound
+	}
+	outputs.URL = url
+	return outputs, nil
+}
+
+func (p *hookProvider) Delete(_ context.Context, id string, _ hookArgs, outputs hookState) error {
+	delete(p.hooks, id)
+	p.deleted = append(p.deleted, outputs.HookID)
+	return nil
+}
+
+// updatableHookProvider is a hookProvider that can update hooks in place.
+type updatableHookProvider struct{ hookProvider }
+
+func (p *updatableHookProvider) Update(
+	_ context.Context, id string, _, news hookArgs, outputs hookState,
+) (hookState, error) {
+	p.hooks[id] = news.URL
+	outputs.URL = news.URL
+	return outputs, nil
+}
+
+func hookInputs(provider, url string) resource.PropertyMap {
+	return resource.PropertyMap{
+		providerKey: resource.NewStringProperty(provider),
+		"url":       resource.NewStringProperty(url),
+		"retries":   resource.NewNumberProperty(3),
+	}
+}
+
+func TestDynamicProviderLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	impl := &hookProvider{hooks: map[string]string{}}
+	NewProvider[hookArgs, hookState]("test-lifecycle", impl)
+	s := &server{}
+
+	inputs := hookInputs("test-lifecycle", "https://example.com")
+	check, err := s.Check(ctx, plugin.CheckRequest{News: inputs})
+	require.NoError(t, err)
+	require.Empty(t, check.Failures)
+
+	// Previews don't call the provider.
+	preview, err := s.Create(ctx, plugin.CreateRequest{Properties: inputs, Preview: true})
+	require.NoError(t, err)
+	assert.True(t, preview.Properties[outputsKey].IsComputed())
+	assert.Empty(t, impl.hooks)
+
+	created, err := s.Create(ctx, plugin.CreateRequest{Properties: inputs})
+	require.NoError(t, err)
+	assert.Equal(t, resource.ID("hook-1"), created.ID)
+	assert.Equal(t, resource.PropertyMap{
+		providerKey: resource.NewStringProperty("test-lifecycle"),
+		outputsKey: resource.NewObjectProperty(resource.PropertyMap{
+			"hookId": resource.NewStringProperty("hook-1"),
+			"url":    resource.NewStringProperty("https://example.com"),
+		}),
+	}, created.Properties)
+
+	// The provider doesn't support updates, so changing the URL replaces the hook.
+	news := hookInputs("test-lifecycle", "https://example.org")
+	diff, err := s.Diff(ctx, plugin.DiffRequest{
+		ID: created.ID, OldInputs: inputs, OldOutputs: created.Properties, NewInputs: news,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, plugin.DiffSome, diff.Changes)
+	assert.Equal(t, []resource.PropertyKey{"url"}, diff.ReplaceKeys)
+
+	diff, err = s.Diff(ctx, plugin.DiffRequest{
+		ID: created.ID, OldInputs: inputs, OldOutputs: created.Properties, NewInputs: inputs,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, plugin.DiffNone, diff.Changes)
+
+	// Refreshing reads the hook back, and reports it deleted once it's gone.
+	read, err := s.Read(ctx, plugin.ReadRequest{ID: created.ID, Inputs: inputs, State: created.Properties})
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, read.ID)
+	assert.Equal(t, created.Properties, read.Outputs)
+
+	// Deleting only needs the state, which records the provider.
+	_, err = s.Delete(ctx, plugin.DeleteRequest{ID: created.ID, Inputs: inputs, Outputs: created.Properties})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hook-1"}, impl.deleted)
+
+	read, err = s.Read(ctx, plugin.ReadRequest{ID: created.ID, Inputs: inputs, State: created.Properties})
+	require.NoError(t, err)
+	assert.Equal(t, resource.ID(""), read.ID)
+}
+
+func TestDynamicProviderUpdate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	impl := &updatableHookProvider{hookProvider{hooks: map[string]string{}}}
+	NewProvider[hookArgs, hookState]("test-update", impl)
+	s := &server{}
+
+	inputs := hookInputs("test-update", "https://example.com")
+	created, err := s.Create(ctx, plugin.CreateRequest{Properties: inputs})
+	require.NoError(t, err)
+
+	news := hookInputs("test-update", "https://example.org")
+	diff, err := s.Diff(ctx, plugin.DiffRequest{
+		ID: created.ID, OldInputs: inputs, OldOutputs: created.Properties, NewInputs: news,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, plugin.DiffSome, diff.Changes)
+	assert.Equal(t, []resource.PropertyKey{"url"}, diff.ChangedKeys)
+	assert.Empty(t, diff.ReplaceKeys)
+
+	updated, err := s.Update(ctx, plugin.UpdateRequest{
+		ID: created.ID, OldInputs: inputs, OldOutputs: created.Properties, NewInputs: news,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.org", impl.hooks["hook-1"])
+	assert.Equal(t, resource.NewStringProperty("https://example.org"),
+		updated.Properties[outputsKey].ObjectValue()["url"])
+
+	// Moving the resource to a provider that isn't registered fails, and moving it to one that is replaces it.
+	_, err = s.Diff(ctx, plugin.DiffRequest{
+		ID: created.ID, OldInputs: inputs, OldOutputs: created.Properties,
+		NewInputs: hookInputs("test-lifecycle-other", "https://example.com"),
+	})
+	assert.ErrorContains(t, err, `no dynamic provider named "test-lifecycle-other"`)
+
+	NewProvider[hookArgs, hookState]("test-update-other", impl)
+	diff, err = s.Diff(ctx, plugin.DiffRequest{
+		ID: created.ID, OldInputs: inputs, OldOutputs: created.Properties,
+		NewInputs: hookInputs("test-update-other", "https://example.com"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []resource.PropertyKey{providerKey}, diff.ReplaceKeys)
+}
+
+func TestDynamicProviderSecrets(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	impl := &hookProvider{hooks: map[string]string{}}
+	NewProvider[hookArgs, hookState]("test-secrets", impl)
+	s := &server{}
+
+	inputs := hookInputs("test-secrets", "")
+	inputs["url"] = resource.MakeSecret(resource.NewStringProperty("https://token@example.com"))
+	created, err := s.Create(ctx, plugin.CreateRequest{Properties: inputs})
+	require.NoError(t, err)
+
+	// The provider sees the plain value, but its outputs are kept secret.
+	assert.Equal(t, "https://token@example.com", impl.hooks["hook-1"])
+	assert.True(t, created.Properties[outputsKey].IsSecret())
+	assert.Equal(t, resource.NewStringProperty("test-secrets"), created.Properties[providerKey])
+}
+
+func TestDynamicProviderUnregistered(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := &server{}
+
+	check, err := s.Check(ctx, plugin.CheckRequest{News: hookInputs("test-missing", "https://example.com")})
+	require.NoError(t, err)
+	require.Len(t, check.Failures, 1)
+	assert.Contains(t, check.Failures[0].Reason, `no dynamic provider named "test-missing" is registered`)
+
+	_, err = s.Delete(ctx, plugin.DeleteRequest{ID: "hook-1", Outputs: resource.PropertyMap{}})
+	assert.ErrorContains(t, err, "does not record the name of its provider")
+}
+
+func TestDynamicProviderDeleteAfterRemoval(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	impl := &hookProvider{hooks: map[string]string{}}
+	NewProvider[hookArgs, hookState]("test-removal", impl)
+	s := &server{}
+
+	inputs := hookInputs("test-removal", "https://example.com")
+	created, err := s.Create(ctx, plugin.CreateRequest{Properties: inputs})
+	require.NoError(t, err)
+
+	// Destroying the resource once its provider has been removed from the program, or renamed, fails, and says how
+	// to remove the resource from the state instead.
+	urn := resource.URN("urn:pulumi:stack::project::pulumi-go:dynamic:Resource::hook")
+	inputs[providerKey] = resource.NewStringProperty("test-removal-renamed")
+	outputs := created.Properties.Copy()
+	outputs[providerKey] = inputs[providerKey]
+	_, err = s.Delete(ctx, plugin.DeleteRequest{URN: urn, ID: created.ID, Inputs: inputs, Outputs: outputs})
+	assert.ErrorContains(t, err, `the dynamic provider "test-removal-renamed" that created it is no longer registered`)
+	assert.ErrorContains(t, err, "pulumi state delete '"+string(urn)+"'")
+	assert.Equal(t, map[string]string{"hook-1": "https://example.com"}, impl.hooks)
+}
+
+func TestNewProviderRejectsDuplicateNames(t *testing.T) {
+	t.Parallel()
+
+	NewProvider[hookArgs, hookState]("test-duplicate", &hookProvider{})
+	assert.PanicsWithValue(t, `a dynamic provider named "test-duplicate" is already registered`, func() {
+		NewProvider[hookArgs, hookState]("test-duplicate", &hookProvider{})
+	})
+}
+
+type dynamicMocks struct {
+	inputs resource.PropertyMap
+}
+
+func (m *dynamicMocks) NewResource(args pulumi.MockResourceArgs) (string, resource.PropertyMap, error) {
+	m.inputs = args.Inputs
+	return "hook-1", resource.PropertyMap{
+		providerKey: args.Inputs[providerKey],
+		outputsKey: resource.NewObjectProperty(resource.PropertyMap{
+			"hookId": resource.NewStringProperty("hook-1"),
+			"url":    args.Inputs["url"],
+		}),
+	}, nil
+}
+
+func (m *dynamicMocks) Call(args pulumi.MockCallArgs) (resource.PropertyMap, error) {
+	return args.Args, nil
+}
+
+func TestResourceProviderNew(t *testing.T) {
+	t.Parallel()
+
+	hooks := NewProvider[hookArgs, hookState]("test-new", &hookProvider{})
+	mocks := &dynamicMocks{}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	var state hookState
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		hook, err := hooks.New(ctx, "hook", pulumi.Map{"url": pulumi.String("https://example.com")})
+		if err != nil {
+			return err
+		}
+		hook.Result().ApplyT(func(s hookState) error {
+			state = s
+			wg.Done()
+			return nil
+		})
+		return nil
+	}, pulumi.WithMocks("project", "stack", mocks))
+	require.NoError(t, err)
+	wg.Wait()
+
+	assert.Equal(t, resource.NewStringProperty("test-new"), mocks.inputs[providerKey])
+	assert.Equal(t, hookState{HookID: "hook-1", URL: "https://example.com"}, state)
+}
@@ -0,0 +1,331 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/blang/semver"
+	"google.golang.org/grpc"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/version"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+func init() {
+	linkedSetMain(serve)
+}
+
+// linkedSetMain is made available here from ../dynamic_linked.go via go:linkname.
+func linkedSetMain(main func() error)
+
+// serve serves the program's dynamic providers to the engine, following the provider plugin protocol.
+func serve() error {
+	port, done, err := rpcutil.Serve(0, nil, []func(*grpc.Server) error{
+		func(srv *grpc.Server) error {
+			pulumirpc.RegisterResourceProviderServer(srv, plugin.NewProviderServer(&server{}))
+			return nil
+		},
+	}, nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "fatal: %v\n", err)
+		return err
+	}
+
+	// The provider protocol requires that we now write out the port we have chosen to listen on.
+	fmt.Printf("%d\n", port)
+
+	if err := <-done; err != nil {
+		fmt.Fprintf(os.Stderr, "fatal: %v\n", err)
+		return err
+	}
+	return nil
+}
+
+// server implements the engine's view of the dynamic resource provider, dispatching each request to the registered
+// provider named by the resource's state.
+type server struct {
+	plugin.UnimplementedProvider
+}
+
+var _ plugin.Provider = (*server)(nil)
+
+func (s *server) Pkg() tokens.Package {
+	return "pulumi-go"
+}
+
+func (s *server) Close() error {
+	return nil
+}
+
+func (s *server) SignalCancellation(context.Context) error {
+	return nil
+}
+
+func (s *server) Handshake(
+	context.Context, plugin.ProviderHandshakeRequest,
+) (*plugin.ProviderHandshakeResponse, error) {
+	return &plugin.ProviderHandshakeResponse{}, nil
+}
+
+func (s *server) GetPluginInfo(context.Context) (workspace.PluginInfo, error) {
+	v, err := semver.ParseTolerant(version.Version)
+	if err != nil {
+		v = semver.Version{}
+	}
+	return workspace.PluginInfo{Version: &v}, nil
+}
+
+func (s *server) CheckConfig(_ context.Context, req plugin.CheckConfigRequest) (plugin.CheckConfigResponse, error) {
+	return plugin.CheckConfigResponse{Properties: req.News}, nil
+}
+
+func (s *server) DiffConfig(context.Context, plugin.DiffConfigRequest) (plugin.DiffConfigResponse, error) {
+	return plugin.DiffResult{Changes: plugin.DiffNone}, nil
+}
+
+func (s *server) Configure(ctx context.Context, req plugin.ConfigureRequest) (plugin.ConfigureResponse, error) {
+	// Dynamic providers are configured with the whole of the stack's configuration.
+	config := map[string]string{}
+	for k, v := range req.Inputs {
+		for v.IsSecret() {
+			v = v.SecretValue().Element
+		}
+		if v.IsString() {
+			config[string(k)] = v.StringValue()
+		}
+	}
+
+	registryLock.Lock()
+	providers := make([]registeredProvider, 0, len(registry))
+	for _, p := range registry {
+		providers = append(providers, p)
+	}
+	registryLock.Unlock()
+
+	for _, p := range providers {
+		if err := p.configure(ctx, config); err != nil {
+			return plugin.ConfigureResponse{}, err
+		}
+	}
+	return plugin.ConfigureResponse{}, nil
+}
+
+// providerFor returns the provider named by the given properties, which are a dynamic resource's inputs or outputs.
+func providerFor(props ...resource.PropertyMap) (registeredProvider, error) {
+	for _, p := range props {
+		if v, has := p[providerKey]; has && v.IsString() {
+			return lookupProvider(v.StringValue())
+		}
+	}
+	return nil, errors.New("dynamic resource state does not record the name of its provider")
+}
+
+func (s *server) Check(_ context.Context, req plugin.CheckRequest) (plugin.CheckResponse, error) {
+	name, has := req.News[providerKey]
+	if !has || !name.IsString() {
+		return plugin.CheckResponse{Failures: []plugin.CheckFailure{{
+			Property: providerKey,
+			Reason:   "dynamic resources must be created with a dynamic provider",
+		}}}, nil
+	}
+	if _, err := lookupProvider(name.StringValue()); err != nil {
+		return plugin.CheckResponse{Failures: []plugin.CheckFailure{{Property: providerKey, Reason: err.Error()}}}, nil
+	}
+	return plugin.CheckResponse{Properties: req.News}, nil
+}
+
+func (s *server) Diff(ctx context.Context, req plugin.DiffRequest) (plugin.DiffResponse, error) {
+	p, err := providerFor(req.NewInputs, req.OldOutputs, req.OldInputs)
+	if err != nil {
+		return plugin.DiffResult{}, err
+	}
+
+	// Changing a resource's provider always replaces it.
+	var changed, replaces []resource.PropertyKey
+	for _, k := range inputKeys(req.OldInputs, req.NewInputs) {
+		if !req.OldInputs[k].DeepEquals(req.NewInputs[k]) {
+			changed = append(changed, k)
+			if k == providerKey || !p.canUpdate() {
+				replaces = append(replaces, k)
+			}
+		}
+	}
+
+	// Unknown inputs can't be decoded, so only consult the provider's Differ once every input is known.
+	if !req.NewInputs.ContainsUnknowns() && !slices.Contains(changed, providerKey) {
+		custom, err := p.diff(ctx, string(req.ID), req.OldInputs, req.NewInputs, outputsOf(req.OldOutputs))
+		if err != nil {
+			return plugin.DiffResult{}, err
+		}
+		if custom != nil {
+			if !custom.Changes {
+				return plugin.DiffResult{Changes: plugin.DiffNone}, nil
+			}
+			result := plugin.DiffResult{
+				Changes:             plugin.DiffSome,
+				ChangedKeys:         changed,
+				DeleteBeforeReplace: custom.DeleteBeforeReplace,
+			}
+			for _, k := range custom.Replaces {
+				result.ReplaceKeys = append(result.ReplaceKeys, resource.PropertyKey(k))
+			}
+			if !p.canUpdate() && len(result.ReplaceKeys) == 0 {
+				result.ReplaceKeys = changed
+			}
+			return result, nil
+		}
+	}
+
+	if len(changed) == 0 {
+		return plugin.DiffResult{Changes: plugin.DiffNone}, nil
+	}
+	return plugin.DiffResult{Changes: plugin.DiffSome, ChangedKeys: changed, ReplaceKeys: replaces}, nil
+}
+
+func (s *server) Create(ctx context.Context, req plugin.CreateRequest) (plugin.CreateResponse, error) {
+	p, err := providerFor(req.Properties)
+	if err != nil {
+		return plugin.CreateResponse{}, err
+	}
+	if req.Preview {
+		return plugin.CreateResponse{Properties: previewOutputs(req.Properties)}, nil
+	}
+
+	id, outputs, err := p.create(ctx, req.Properties)
+	if err != nil {
+		return plugin.CreateResponse{}, err
+	}
+	return plugin.CreateResponse{
+		ID:         resource.ID(id),
+		Properties: makeOutputs(req.Properties, outputs),
+		Status:     resource.StatusOK,
+	}, nil
+}
+
+func (s *server) Read(ctx context.Context, req plugin.ReadRequest) (plugin.ReadResponse, error) {
+	p, err := providerFor(req.State, req.Inputs)
+	if err != nil {
+		return plugin.ReadResponse{}, err
+	}
+
+	outputs, err := p.read(ctx, string(req.ID), req.Inputs, outputsOf(req.State))
+	if errors.Is(err, ErrNotFound) {
+		return plugin.ReadResponse{Status: resource.StatusOK}, nil
+	} else if err != nil {
+		return plugin.ReadResponse{}, err
+	}
+	return plugin.ReadResponse{
+		ReadResult: plugin.ReadResult{
+			ID:      req.ID,
+			Inputs:  req.Inputs,
+			Outputs: makeOutputs(req.State, outputs),
+		},
+		Status: resource.StatusOK,
+	}, nil
+}
+
+func (s *server) Update(ctx context.Context, req plugin.UpdateRequest) (plugin.UpdateResponse, error) {
+	p, err := providerFor(req.NewInputs, req.OldOutputs)
+	if err != nil {
+		return plugin.UpdateResponse{}, err
+	}
+	if req.Preview {
+		return plugin.UpdateResponse{Properties: previewOutputs(req.NewInputs)}, nil
+	}
+
+	outputs, err := p.update(ctx, string(req.ID), req.OldInputs, req.NewInputs, outputsOf(req.OldOutputs))
+	if err != nil {
+		return plugin.UpdateResponse{}, err
+	}
+	return plugin.UpdateResponse{
+		Properties: makeOutputs(req.NewInputs, outputs),
+		Status:     resource.StatusOK,
+	}, nil
+}
+
+func (s *server) Delete(ctx context.Context, req plugin.DeleteRequest) (plugin.DeleteResponse, error) {
+	p, err := providerFor(req.Outputs, req.Inputs)
+	var notRegistered *providerNotRegisteredError
+	if errors.As(err, &notRegistered) {
+		// The provider has been removed from the program or renamed, so there's nothing that can delete the resource.
+		return plugin.DeleteResponse{}, fmt.Errorf("cannot delete resource %q: the dynamic provider %q that created "+
+			"it is no longer registered in the program. Register the provider under that name again to delete the "+
+			"resource, or, if it has already been deleted or will be deleted by hand, remove it from the stack's "+
+			"state with `pulumi state delete '%s'`", req.ID, notRegistered.name, req.URN)
+	} else if err != nil {
+		return plugin.DeleteResponse{}, err
+	}
+	if err := p.delete(ctx, string(req.ID), req.Inputs, outputsOf(req.Outputs)); err != nil {
+		return plugin.DeleteResponse{}, err
+	}
+	return plugin.DeleteResponse{Status: resource.StatusOK}, nil
+}
+
+// inputKeys returns the keys of either set of inputs, in a stable order.
+func inputKeys(olds, news resource.PropertyMap) []resource.PropertyKey {
+	keys := olds.StableKeys()
+	for _, k := range news.StableKeys() {
+		if _, has := olds[k]; !has {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// outputsOf returns the provider's outputs from a dynamic resource's state.
+func outputsOf(state resource.PropertyMap) resource.PropertyMap {
+	if v, has := state[outputsKey]; has {
+		for v.IsSecret() {
+			v = v.SecretValue().Element
+		}
+		if v.IsObject() {
+			return v.ObjectValue()
+		}
+	}
+	return resource.PropertyMap{}
+}
+
+// makeOutputs returns the state of a dynamic resource with the given inputs and provider outputs. The provider's name
+// is recorded so that the resource can be deleted even once it has been removed from the program, and the provider's
+// outputs are kept secret if any input was.
+func makeOutputs(inputs, outputs resource.PropertyMap) resource.PropertyMap {
+	value := resource.NewObjectProperty(outputs)
+	if inputs.ContainsSecrets() {
+		value = resource.MakeSecret(value)
+	}
+	return resource.PropertyMap{
+		providerKey: inputs[providerKey],
+		outputsKey:  value,
+	}
+}
+
+// previewOutputs returns the state of a dynamic resource whose outputs are not yet known.
+func previewOutputs(inputs resource.PropertyMap) resource.PropertyMap {
+	return resource.PropertyMap{
+		providerKey: inputs[providerKey],
+		outputsKey:  resource.MakeComputed(resource.NewStringProperty("")),
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	_ "unsafe" // unsafe is needed to use go:linkname
+)
+
+// dynamicProviderMain serves the program's dynamic providers. It is set by the dynamic package when a program imports
+// it, so that Run can serve the providers instead of running the program when the Go language host launches the
+// program as a dynamic provider.
+var dynamicProviderMain func() error
+
+//go:linkname linkedSetMain github.com/pulumi/pulumi/sdk/v3/go/pulumi/dynamic.linkedSetMain
+func linkedSetMain(main func() error) {
+	dynamicProviderMain = main
+}
@@ -160,6 +160,9 @@ func (m *mockMonitor) Invoke(ctx context.Context, in *pulumirpc.ResourceInvokeRe
 			Return: result,
 		}, nil
 	}
+	if in.GetTok() == "pulumi:pulumi:recordStackOutputReads" {
+		return &pulumirpc.InvokeResponse{}, nil
+	}
 	resultV, err := m.mocks.Call(MockCallArgs{
 		Token:    in.GetTok(),
 		Args:     args,
@@ -56,6 +56,11 @@ type AnalyzerResource struct {
 	Dependencies []string
 	// PropertyDependencies maps property names to the list of URNs they depend on.
 	PropertyDependencies map[string][]string
+	// Custom is true if the resource is managed by a provider, and false if it is a component.
+	Custom bool
+	// Inputs are the resource's inputs. For a component these are the arguments it was constructed with, whereas its
+	// Properties are the outputs it registered.
+	Inputs property.Map
 }
 
 // ResourceValidationArgs contains the arguments passed to a resource validation policy.
@@ -84,6 +89,31 @@ type StackValidationArgs struct {
 	Resources []AnalyzerResource
 }
 
+// ComponentValidationArgs contains the arguments passed to a component validation policy.
+type ComponentValidationArgs struct {
+	// Manager is the policy manager.
+	Manager PolicyManager
+	// Component is the component being validated.
+	Component AnalyzerResource
+	// Children are the resources in the component's subtree: its children, their children, and so on, in the order
+	// the engine sent them.
+	Children []AnalyzerResource
+	// Config is the policy configuration.
+	Config map[string]any
+}
+
+// ChildrenOf returns the resources in the component's subtree whose parent is the resource with the given URN. The
+// children of the component itself are ChildrenOf(args.Component.URN).
+func (args ComponentValidationArgs) ChildrenOf(urn string) []AnalyzerResource {
+	var children []AnalyzerResource
+	for _, r := range args.Children {
+		if r.Parent == urn {
+			children = append(children, r)
+		}
+	}
+	return children
+}
+
 // Policy is the interface implemented by all policies.
 type Policy interface {
 	isPolicy()
@@ -118,6 +148,16 @@ type StackValidationPolicy interface {
 	Validate(ctx context.Context, args StackValidationArgs) error
 }
 
+// ComponentValidationPolicy is a policy that validates component resources along with the resources in their
+// subtrees, so that it can enforce rules about how a component is put together.
+type ComponentValidationPolicy interface {
+	Policy
+	// ComponentType returns the type token of the components that the policy validates, or "" for all components.
+	ComponentType() string
+	// Validate validates a component.
+	Validate(ctx context.Context, args ComponentValidationArgs) error
+}
+
 // ResourceValidationPolicyArgs contains the arguments for creating a resource validation policy.
 type ResourceValidationPolicyArgs struct {
 	// Description is the description of the policy.
@@ -247,3 +287,79 @@ func NewResourceRemediationPolicy(
 		configSchema:      args.ConfigSchema,
 	}
 }
+
+// ComponentValidationPolicyArgs contains the arguments for creating a component validation policy.
+type ComponentValidationPolicyArgs struct {
+	// Description is the description of the policy.
+	Description string
+	// EnforcementLevel is the enforcement level of the policy.
+	EnforcementLevel EnforcementLevel
+	// ConfigSchema is the configuration schema for the policy, if any.
+	ConfigSchema *ConfigSchema
+	// ComponentType is the type token of the components that the policy validates, e.g. "my:index:DataLake". If
+	// empty, every component other than the stack itself is validated.
+	ComponentType string
+	// ValidateComponent is the validation function for the policy.
+	ValidateComponent func(ctx context.Context, args ComponentValidationArgs) error
+}
+
+// componentValidationPolicy is an implementation of ComponentValidationPolicy.
+type componentValidationPolicy struct {
+	name              string
+	description       string
+	enforcementLevel  EnforcementLevel
+	configSchema      *ConfigSchema
+	componentType     string
+	validateComponent func(ctx context.Context, args ComponentValidationArgs) error
+}
+
+// isPolicy marks componentValidationPolicy as a Policy.
+func (p *componentValidationPolicy) isPolicy() {}
+
+// Name returns the name of the policy.
+func (p *componentValidationPolicy) Name() string {
+	return p.name
+}
+
+// Description returns the description of the policy.
+func (p *componentValidationPolicy) Description() string {
+	return p.description
+}
+
+// EnforcementLevel returns the enforcement level of the policy.
+func (p *componentValidationPolicy) EnforcementLevel() EnforcementLevel {
+	return p.enforcementLevel
+}
+
+// ConfigSchema returns the configuration schema for the policy, if any.
+func (p *componentValidationPolicy) ConfigSchema() *ConfigSchema {
+	return p.configSchema
+}
+
+// ComponentType returns the type token of the components that the policy validates.
+func (p *componentValidationPolicy) ComponentType() string {
+	return p.componentType
+}
+
+// Validate validates a component using the policy's validation function.
+func (p *componentValidationPolicy) Validate(ctx context.Context, args ComponentValidationArgs) error {
+	if p.validateComponent == nil {
+		return nil
+	}
+	return p.validateComponent(ctx, args)
+}
+
+// NewComponentValidationPolicy creates a new ComponentValidationPolicy with the given name and arguments.
+func NewComponentValidationPolicy(
+	name string,
+	args ComponentValidationPolicyArgs,
+) ComponentValidationPolicy {
+	return &componentValidationPolicy{
+		name:              name,
+		description:       args.Description,
+		enforcementLevel:  args.EnforcementLevel,
+		configSchema:      args.ConfigSchema,
+		componentType:     args.ComponentType,
+		validateComponent: args.ValidateComponent,
+	}
+}
@@ -219,7 +219,7 @@ func (srv *analyzerServer) Analyze(
 						Properties:           resource.FromResourcePropertyMap(pm),
 						URN:                  req.GetUrn(),
 						Name:                 req.GetName(),
-						Options:              pulumi.ResourceOptions{},
+						Options:              pulumi.ResourceOptions{Annotations: req.GetOptions().GetAnnotations()},
 						Provider:             AnalyzerProviderResource{},
 						Parent:               "",  /* TODO */
 						Dependencies:         nil, /* TODO */
@@ -274,7 +274,7 @@ func (srv *analyzerServer) Remediate(
 						Properties:           props,
 						URN:                  req.GetUrn(),
 						Name:                 req.GetName(),
-						Options:              pulumi.ResourceOptions{},
+						Options:              pulumi.ResourceOptions{Annotations: req.GetOptions().GetAnnotations()},
 						Provider:             AnalyzerProviderResource{},
 						Parent:               "",  /* TODO */
 						Dependencies:         nil, /* TODO */
@@ -323,8 +323,162 @@ func (srv *analyzerServer) AnalyzeStack(ctx context.Context, req *pulumirpc.Anal
 	AnalyzeResponse,
 	error,
 ) {
-	// TODO: Implement stack analysis
-	return &pulumirpc.AnalyzeResponse{}, nil
+	resources := make([]AnalyzerResource, 0, len(req.GetResources()))
+	// Older engines don't say which resources are components, in which case component policies can't be run.
+	hasComponents := true
+	for _, r := range req.GetResources() {
+		res, err := srv.unmarshalStackResource(r)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, res)
+		hasComponents = hasComponents && r.Custom != nil
+	}
+
+	var ds []*pulumirpc.AnalyzeDiagnostic
+	for _, p := range srv.policyPack.Policies() {
+		config, hasConfig := srv.config[p.Name()]
+
+		enforcementLevel := p.EnforcementLevel()
+		if hasConfig {
+			enforcementLevel = config.EnforcementLevel
+		}
+		if enforcementLevel == EnforcementLevelDisabled {
+			continue
+		}
+
+		// Violations without a URN are reported against the component being validated, if any.
+		var defaultURN string
+		policyManager := &policyManager{
+			reportViolation: func(message string, urn string) {
+				if urn == "" {
+					urn = defaultURN
+				}
+
+				violationMessage := p.Description()
+				if message != "" {
+					violationMessage += "\n" + message
+				}
+
+				ds = append(ds, &pulumirpc.AnalyzeDiagnostic{
+					PolicyName:        p.Name(),
+					PolicyPackName:    srv.policyPack.Name(),
+					PolicyPackVersion: srv.policyPack.Version().String(),
+					Description:       p.Description(),
+					Message:           violationMessage,
+					EnforcementLevel:  pulumirpc.EnforcementLevel(enforcementLevel),
+					Urn:               urn,
+				})
+			},
+		}
+
+		switch p := p.(type) {
+		case StackValidationPolicy:
+			err := p.Validate(ctx, StackValidationArgs{
+				Manager:   policyManager,
+				Resources: resources,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to validate stack with policy %q: %w", p.Name(), err)
+			}
+		case ComponentValidationPolicy:
+			if !hasComponents {
+				continue
+			}
+			for _, r := range resources {
+				if r.Custom || r.Type == string(resource.RootStackType) ||
+					(p.ComponentType() != "" && r.Type != p.ComponentType()) {
+					continue
+				}
+
+				defaultURN = r.URN
+				err := p.Validate(ctx, ComponentValidationArgs{
+					Manager:   policyManager,
+					Component: r,
+					Children:  subtree(resources, r.URN),
+					Config:    config.Properties,
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to validate component %q with policy %q: %w", r.URN, p.Name(), err)
+				}
+			}
+		}
+	}
+
+	return &pulumirpc.AnalyzeResponse{
+		Diagnostics: ds,
+	}, nil
+}
+
+// unmarshalStackResource converts a resource sent for stack analysis into an AnalyzerResource.
+func (srv *analyzerServer) unmarshalStackResource(r *pulumirpc.AnalyzerResource) (AnalyzerResource, error) {
+	opts := plugin.MarshalOptions{
+		Label:            srv.policyPack.Name() + ".analyzeStack",
+		KeepUnknowns:     true,
+		KeepSecrets:      true,
+		KeepResources:    true,
+		KeepOutputValues: true,
+	}
+	props, err := plugin.UnmarshalProperties(r.GetProperties(), opts)
+	if err != nil {
+		return AnalyzerResource{}, fmt.Errorf("failed to unmarshal properties for %q: %w", r.GetUrn(), err)
+	}
+	inputs, err := plugin.UnmarshalProperties(r.GetInputs(), opts)
+	if err != nil {
+		return AnalyzerResource{}, fmt.Errorf("failed to unmarshal inputs for %q: %w", r.GetUrn(), err)
+	}
+
+	var provider AnalyzerProviderResource
+	if p := r.GetProvider(); p != nil {
+		providerProps, err := plugin.UnmarshalProperties(p.GetProperties(), opts)
+		if err != nil {
+			return AnalyzerResource{}, fmt.Errorf("failed to unmarshal properties for %q: %w", p.GetUrn(), err)
+		}
+		provider = AnalyzerProviderResource{
+			Type:       p.GetType(),
+			Properties: resource.FromResourcePropertyMap(providerProps),
+			URN:        p.GetUrn(),
+			Name:       p.GetName(),
+		}
+	}
+
+	propertyDependencies := make(map[string][]string, len(r.GetPropertyDependencies()))
+	for k, v := range r.GetPropertyDependencies() {
+		propertyDependencies[k] = v.GetUrns()
+	}
+
+	return AnalyzerResource{
+		Type:                 r.GetType(),
+		Properties:           resource.FromResourcePropertyMap(props),
+		URN:                  r.GetUrn(),
+		Name:                 r.GetName(),
+		Options:              pulumi.ResourceOptions{Annotations: r.GetOptions().GetAnnotations()},
+		Provider:             provider,
+		Parent:               r.GetParent(),
+		Dependencies:         r.GetDependencies(),
+		PropertyDependencies: propertyDependencies,
+		Custom:               r.GetCustom(),
+		Inputs:               resource.FromResourcePropertyMap(inputs),
+	}, nil
+}
+
+// subtree returns the resources that descend from the resource with the given URN, in the order they are given.
+func subtree(resources []AnalyzerResource, root string) []AnalyzerResource {
+	parents := make(map[string]string, len(resources))
+	for _, r := range resources {
+		parents[r.URN] = r.Parent
+	}
+
+	var descendants []AnalyzerResource
+	for _, r := range resources {
+		for parent := r.Parent; parent != ""; parent = parents[parent] {
+			if parent == root {
+				descendants = append(descendants, r)
+				break
+			}
+		}
+	}
+	return descendants
 }
 
 func (srv *analyzerServer) Cancel(ctx context.Context, req *pbempty.Empty) (*pbempty.Empty, error) {
@@ -0,0 +1,334 @@
+// Copyright 2025, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policyx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+const (
+	stackURN    = "urn:pulumi:test::proj::pulumi:pulumi:Stack::proj-test"
+	lakeURN     = "urn:pulumi:test::proj::pulumi:pulumi:Stack$my:index:Lake::lake"
+	bucketURN   = "urn:pulumi:test::proj::pulumi:pulumi:Stack$my:index:Lake$aws:s3:Bucket::bucket"
+	zoneURN     = "urn:pulumi:test::proj::pulumi:pulumi:Stack$my:index:Lake$my:index:Zone::zone"
+	tableURN    = "urn:pulumi:test::proj::pulumi:pulumi:Stack$my:index:Lake$my:index:Zone$aws:glue:Table::table"
+	otherURN    = "urn:pulumi:test::proj::pulumi:pulumi:Stack$my:index:Lake::other"
+	otherBktURN = "urn:pulumi:test::proj::pulumi:pulumi:Stack$my:index:Lake$aws:s3:Bucket::other-bucket"
+	looseURN    = "urn:pulumi:test::proj::pulumi:pulumi:Stack$aws:s3:Bucket::loose"
+)
+
+func marshalProps(t *testing.T, props resource.PropertyMap) *structpb.Struct {
+	t.Helper()
+	s, err := plugin.MarshalProperties(props, plugin.MarshalOptions{KeepSecrets: true})
+	require.NoError(t, err)
+	return s
+}
+
+func stackResource(t *testing.T, typ, urn, parent string, custom bool) *pulumirpc.AnalyzerResource {
+	t.Helper()
+	return &pulumirpc.AnalyzerResource{
+		Type:       typ,
+		Urn:        urn,
+		Name:       resource.URN(urn).Name(),
+		Parent:     parent,
+		Properties: marshalProps(t, resource.PropertyMap{}),
+		Custom:     proto.Bool(custom),
+	}
+}
+
+// testStack returns a stack with two components of the same type, one of which contains a nested component, along
+// with a resource that isn't in any component:
+//
+//	stack
+//	├── lake (my:index:Lake)
+//	│   ├── bucket
+//	│   └── zone (my:index:Zone)
+//	│       └── table
+//	├── other (my:index:Lake)
+//	│   └── other-bucket
+//	└── loose
+func testStack(t *testing.T) []*pulumirpc.AnalyzerResource {
+	return []*pulumirpc.AnalyzerResource{
+		stackResource(t, string(resource.RootStackType), stackURN, "", false),
+		stackResource(t, "my:index:Lake", lakeURN, stackURN, false),
+		stackResource(t, "aws:s3:Bucket", bucketURN, lakeURN, true),
+		stackResource(t, "my:index:Zone", zoneURN, lakeURN, false),
+		stackResource(t, "aws:glue:Table", tableURN, zoneURN, true),
+		stackResource(t, "my:index:Lake", otherURN, stackURN, false),
+		stackResource(t, "aws:s3:Bucket", otherBktURN, otherURN, true),
+		stackResource(t, "aws:s3:Bucket", looseURN, stackURN, true),
+	}
+}
+
+// testStackPolicy is a StackValidationPolicy that validates the stack with the given function.
+type testStackPolicy struct {
+	name             string
+	enforcementLevel EnforcementLevel
+	validate         func(ctx context.Context, args StackValidationArgs) error
+}
+
+func (p *testStackPolicy) isPolicy()                          {}
+func (p *testStackPolicy) Name() string                       { return p.name }
+func (p *testStackPolicy) Description() string                { return p.name }
+func (p *testStackPolicy) EnforcementLevel() EnforcementLevel { return p.enforcementLevel }
+func (p *testStackPolicy) ConfigSchema() *ConfigSchema        { return nil }
+
+func (p *testStackPolicy) Validate(ctx context.Context, args StackValidationArgs) error {
+	return p.validate(ctx, args)
+}
+
+func newTestServer(t *testing.T, policies ...Policy) *analyzerServer {
+	t.Helper()
+	pack, err := NewPolicyPack("test-pack", semver.MustParse("1.0.0"), EnforcementLevelMandatory, nil, policies)
+	require.NoError(t, err)
+	return &analyzerServer{policyPack: pack}
+}
+
+func urnsOf(resources []AnalyzerResource) []string {
+	urns := make([]string, 0, len(resources))
+	for _, r := range resources {
+		urns = append(urns, r.URN)
+	}
+	return urns
+}
+
+func TestSubtree(t *testing.T) {
+	t.Parallel()
+
+	resources := []AnalyzerResource{
+		{URN: stackURN},
+		{URN: lakeURN, Parent: stackURN},
+		{URN: bucketURN, Parent: lakeURN},
+		{URN: zoneURN, Parent: lakeURN},
+		{URN: tableURN, Parent: zoneURN},
+		{URN: otherURN, Parent: stackURN},
+		{URN: otherBktURN, Parent: otherURN},
+		{URN: looseURN, Parent: stackURN},
+	}
+
+	// A component's subtree includes the children of nested components, but not its siblings or their children.
+	assert.Equal(t, []string{bucketURN, zoneURN, tableURN}, urnsOf(subtree(resources, lakeURN)))
+	assert.Equal(t, []string{tableURN}, urnsOf(subtree(resources, zoneURN)))
+	assert.Equal(t, []string{otherBktURN}, urnsOf(subtree(resources, otherURN)))
+	assert.Empty(t, subtree(resources, looseURN))
+}
+
+func TestComponentValidationArgsChildrenOf(t *testing.T) {
+	t.Parallel()
+
+	args := ComponentValidationArgs{
+		Component: AnalyzerResource{URN: lakeURN},
+		Children: []AnalyzerResource{
+			{URN: bucketURN, Parent: lakeURN},
+			{URN: zoneURN, Parent: lakeURN},
+			{URN: tableURN, Parent: zoneURN},
+		},
+	}
+	assert.Equal(t, []string{bucketURN, zoneURN}, urnsOf(args.ChildrenOf(lakeURN)))
+	assert.Equal(t, []string{tableURN}, urnsOf(args.ChildrenOf(zoneURN)))
+}
+
+func TestAnalyzeStackComponentPolicy(t *testing.T) {
+	t.Parallel()
+
+	subtrees := map[string][]string{}
+	policy := NewComponentValidationPolicy("lake-has-bucket", ComponentValidationPolicyArgs{
+		Description:      "Lakes must have a bucket.",
+		EnforcementLevel: EnforcementLevelMandatory,
+		ComponentType:    "my:index:Lake",
+		ValidateComponent: func(_ context.Context, args ComponentValidationArgs) error {
+			subtrees[args.Component.URN] = urnsOf(args.Children)
+			if args.Component.URN == otherURN {
+				// Violations without a URN are attributed to the component, and those with one to that resource.
+				args.Manager.ReportViolation("no zone", "")
+				args.Manager.ReportViolation("unencrypted", otherBktURN)
+			}
+			return nil
+		},
+	})
+	srv := newTestServer(t, policy)
+
+	resp, err := srv.AnalyzeStack(context.Background(), &pulumirpc.AnalyzeStackRequest{Resources: testStack(t)})
+	require.NoError(t, err)
+
+	// Only components of the policy's type are validated, each with its own subtree.
+	assert.Equal(t, map[string][]string{
+		lakeURN:  {bucketURN, zoneURN, tableURN},
+		otherURN: {otherBktURN},
+	}, subtrees)
+
+	require.Len(t, resp.Diagnostics, 2)
+	assert.Equal(t, otherURN, resp.Diagnostics[0].Urn)
+	assert.Equal(t, "Lakes must have a bucket.\nno zone", resp.Diagnostics[0].Message)
+	assert.Equal(t, "lake-has-bucket", resp.Diagnostics[0].PolicyName)
+	assert.Equal(t, "test-pack", resp.Diagnostics[0].PolicyPackName)
+	assert.Equal(t, pulumirpc.EnforcementLevel_MANDATORY, resp.Diagnostics[0].EnforcementLevel)
+	assert.Equal(t, otherBktURN, resp.Diagnostics[1].Urn)
+}
+
+func TestAnalyzeStackAllComponents(t *testing.T) {
+	t.Parallel()
+
+	var components []string
+	policy := NewComponentValidationPolicy("all-components", ComponentValidationPolicyArgs{
+		EnforcementLevel: EnforcementLevelAdvisory,
+		ValidateComponent: func(_ context.Context, args ComponentValidationArgs) error {
+			components = append(components, args.Component.URN)
+			return nil
+		},
+	})
+	srv := newTestServer(t, policy)
+
+	_, err := srv.AnalyzeStack(context.Background(), &pulumirpc.AnalyzeStackRequest{Resources: testStack(t)})
+	require.NoError(t, err)
+
+	// Every component is validated, including nested ones, but not the stack itself or custom resources.
+	assert.Equal(t, []string{lakeURN, zoneURN, otherURN}, components)
+}
+
+func TestAnalyzeStackComponentAndStackPolicies(t *testing.T) {
+	t.Parallel()
+
+	componentPolicy := NewComponentValidationPolicy("component", ComponentValidationPolicyArgs{
+		Description:      "component",
+		EnforcementLevel: EnforcementLevelMandatory,
+		ComponentType:    "my:index:Zone",
+		ValidateComponent: func(_ context.Context, args ComponentValidationArgs) error {
+			args.Manager.ReportViolation("", "")
+			return nil
+		},
+	})
+	var stackResources []string
+	stackPolicy := &testStackPolicy{
+		name:             "stack",
+		enforcementLevel: EnforcementLevelAdvisory,
+		validate: func(_ context.Context, args StackValidationArgs) error {
+			stackResources = urnsOf(args.Resources)
+			args.Manager.ReportViolation("", "")
+			args.Manager.ReportViolation("", looseURN)
+			return nil
+		},
+	}
+	disabledPolicy := NewComponentValidationPolicy("disabled", ComponentValidationPolicyArgs{
+		EnforcementLevel: EnforcementLevelMandatory,
+		ValidateComponent: func(_ context.Context, args ComponentValidationArgs) error {
+			args.Manager.ReportViolation("", "")
+			return nil
+		},
+	})
+	srv := newTestServer(t, componentPolicy, stackPolicy, disabledPolicy)
+	srv.config = map[string]PolicyConfig{"disabled": {EnforcementLevel: EnforcementLevelDisabled}}
+
+	resp, err := srv.AnalyzeStack(context.Background(), &pulumirpc.AnalyzeStackRequest{Resources: testStack(t)})
+	require.NoError(t, err)
+
+	// The stack policy sees every resource, and its violations aren't attributed to the component validated by the
+	// policy before it.
+	assert.Len(t, stackResources, 8)
+	require.Len(t, resp.Diagnostics, 3)
+	assert.Equal(t, "component", resp.Diagnostics[0].PolicyName)
+	assert.Equal(t, zoneURN, resp.Diagnostics[0].Urn)
+	assert.Equal(t, pulumirpc.EnforcementLevel_MANDATORY, resp.Diagnostics[0].EnforcementLevel)
+	assert.Equal(t, "stack", resp.Diagnostics[1].PolicyName)
+	assert.Equal(t, "", resp.Diagnostics[1].Urn)
+	assert.Equal(t, pulumirpc.EnforcementLevel_ADVISORY, resp.Diagnostics[1].EnforcementLevel)
+	assert.Equal(t, "stack", resp.Diagnostics[2].PolicyName)
+	assert.Equal(t, looseURN, resp.Diagnostics[2].Urn)
+}
+
+func TestAnalyzeStackWithoutComponentInfo(t *testing.T) {
+	t.Parallel()
+
+	called := false
+	policy := NewComponentValidationPolicy("component", ComponentValidationPolicyArgs{
+		EnforcementLevel: EnforcementLevelMandatory,
+		ValidateComponent: func(context.Context, ComponentValidationArgs) error {
+			called = true
+			return nil
+		},
+	})
+	srv := newTestServer(t, policy)
+
+	// Older engines don't say which resources are custom, so components can't be told apart from them.
+	resources := testStack(t)
+	for _, r := range resources {
+		r.Custom = nil
+	}
+	_, err := srv.AnalyzeStack(context.Background(), &pulumirpc.AnalyzeStackRequest{Resources: resources})
+	require.NoError(t, err)
+	assert.False(t, called)
+}
+
+func TestUnmarshalStackResource(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+	res, err := srv.unmarshalStackResource(&pulumirpc.AnalyzerResource{
+		Type:   "my:index:Lake",
+		Urn:    lakeURN,
+		Name:   "lake",
+		Parent: stackURN,
+		Properties: marshalProps(t, resource.PropertyMap{
+			"arn": resource.NewStringProperty("arn:lake"),
+		}),
+		Inputs: marshalProps(t, resource.PropertyMap{
+			"password": resource.MakeSecret(resource.NewStringProperty("hunter2")),
+		}),
+		Options:      &pulumirpc.AnalyzerResourceOptions{Annotations: map[string]string{"owner": "data"}},
+		Dependencies: []string{looseURN},
+		PropertyDependencies: map[string]*pulumirpc.AnalyzerPropertyDependencies{
+			"bucket": {Urns: []string{looseURN}},
+		},
+		Provider: &pulumirpc.AnalyzerProviderResource{
+			Type:       "pulumi:providers:aws",
+			Urn:        "urn:pulumi:test::proj::pulumi:providers:aws::default",
+			Name:       "default",
+			Properties: marshalProps(t, resource.PropertyMap{"region": resource.NewStringProperty("us-west-2")}),
+		},
+		Custom: proto.Bool(false),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "my:index:Lake", res.Type)
+	assert.Equal(t, lakeURN, res.URN)
+	assert.Equal(t, "lake", res.Name)
+	assert.Equal(t, stackURN, res.Parent)
+	assert.False(t, res.Custom)
+	assert.Equal(t, property.NewMap(map[string]property.Value{"arn": property.New("arn:lake")}), res.Properties)
+	assert.Equal(t, property.NewMap(map[string]property.Value{
+		"password": property.New("hunter2").WithSecret(true),
+	}), res.Inputs)
+	assert.Equal(t, map[string]string{"owner": "data"}, res.Options.Annotations)
+	assert.Equal(t, []string{looseURN}, res.Dependencies)
+	assert.Equal(t, map[string][]string{"bucket": {looseURN}}, res.PropertyDependencies)
+	assert.Equal(t, AnalyzerProviderResource{
+		Type:       "pulumi:providers:aws",
+		URN:        "urn:pulumi:test::proj::pulumi:providers:aws::default",
+		Name:       "default",
+		Properties: property.NewMap(map[string]property.Value{"region": property.New("us-west-2")}),
+	}, res.Provider)
+}
@@ -21,6 +21,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/common/promise"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
@@ -496,6 +497,16 @@ type ResourceOptions struct {
 	// Hooks are the optional resource hooks to bind to this resource. The hooks
 	// will be invoked during the lifecycle of the resource.
 	Hooks *ResourceHookBinding
+
+	// Expires is when the resource expires, either as an RFC3339 time or as a
+	// time-to-live such as "72h" or "7d" measured from the resource's creation.
+	// Expired resources are destroyed by `pulumi stack reap`.
+	Expires string
+
+	// Annotations is arbitrary key/value metadata, such as an owner or cost
+	// centre, that is stored with the resource in the stack's state. Unlike
+	// tags, annotations are never sent to the resource's provider.
+	Annotations map[string]string
 }
 
 // NewResourceOptions builds a preview of the effect of the provided options.
@@ -533,6 +544,8 @@ type resourceOptions struct {
 	DeletedWith             Resource
 	Parameterization        []byte
 	Hooks                   *ResourceHookBinding
+	Expires                 string
+	Annotations             map[string]string
 }
 
 func resourceOptionsSnapshot(ro *resourceOptions) *ResourceOptions {
@@ -597,6 +610,8 @@ func resourceOptionsSnapshot(ro *resourceOptions) *ResourceOptions {
 		RetainOnDelete:          flatten(ro.RetainOnDelete),
 		DeletedWith:             ro.DeletedWith,
 		Hooks:                   ro.Hooks,
+		Expires:                 ro.Expires,
+		Annotations:             ro.Annotations,
 	}
 }
 
@@ -1085,6 +1100,36 @@ func DeletedWith(r Resource) ResourceOption {
 	})
 }
 
+// Expires marks the resource as expiring at the given time. Once expired, the resource is
+// destroyed by `pulumi stack reap`.
+func Expires(t time.Time) ResourceOption {
+	return resourceOption(func(ro *resourceOptions) {
+		ro.Expires = t.UTC().Format(time.RFC3339)
+	})
+}
+
+// ExpiresIn marks the resource as expiring once the given duration has passed since it was
+// created. Once expired, the resource is destroyed by `pulumi stack reap`.
+func ExpiresIn(ttl time.Duration) ResourceOption {
+	return resourceOption(func(ro *resourceOptions) {
+		ro.Expires = ttl.String()
+	})
+}
+
+// Annotations adds key/value metadata to the resource that is stored in the stack's state but
+// never sent to its provider. Multiple uses of this option are merged, with later values for the
+// same key taking precedence.
+func Annotations(annotations map[string]string) ResourceOption {
+	return resourceOption(func(ro *resourceOptions) {
+		if ro.Annotations == nil {
+			ro.Annotations = make(map[string]string, len(annotations))
+		}
+		for k, v := range annotations {
+			ro.Annotations[k] = v
+		}
+	})
+}
+
 // If set this resource will be parameterized with the given package reference.
 func Parameterization(parameter []byte) ResourceOrInvokeOption {
 	return resourceOrInvokeOption(func(ro *resourceOptions, io *invokeOptions) {
@@ -1141,6 +1141,24 @@ func TestNewResourceOptions(t *testing.T) {
 			give: DeletedWith(&testRes{foo: "a"}),
 			want: ResourceOptions{DeletedWith: &testRes{foo: "a"}},
 		},
+		{
+			desc: "Expires",
+			give: Expires(time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)),
+			want: ResourceOptions{Expires: "2025-07-01T12:00:00Z"},
+		},
+		{
+			desc: "ExpiresIn",
+			give: ExpiresIn(72 * time.Hour),
+			want: ResourceOptions{Expires: "72h0m0s"},
+		},
+		{
+			desc: "Annotations",
+			give: Composite(
+				Annotations(map[string]string{"owner": "alice", "ticket": "OPS-1"}),
+				Annotations(map[string]string{"owner": "bob"}),
+			),
+			want: ResourceOptions{Annotations: map[string]string{"owner": "bob", "ticket": "OPS-1"}},
+		},
 	}
 
 	for _, tt := range tests {
@@ -69,6 +69,17 @@ func RunErr(body RunFunc, opts ...RunOption) error {
 }
 
 func runErrInner(body RunFunc, logError func(*Context, error), opts ...RunOption) error {
+	// If the language host launched this program as a dynamic provider, serve the providers registered by the
+	// program instead of running it.
+	if serve, _ := strconv.ParseBool(os.Getenv(EnvDynamicProvider)); serve {
+		if dynamicProviderMain == nil {
+			err := errors.New("program was launched as a dynamic provider but does not register any dynamic providers")
+			fmt.Fprintf(os.Stderr, "fatal: %v\n", err)
+			return err
+		}
+		return dynamicProviderMain()
+	}
+
 	// Parse the info out of environment variables.  This is a lame contract with the caller, but helps to keep
 	// boilerplate to a minimum in the average Pulumi Go program.
 	info := getEnvInfo()
@@ -149,6 +160,9 @@ func RunWithContext(ctx *Context, body RunFunc) error {
 		return err
 	}
 
+	// Now that all outputs have resolved, record which stack outputs the program read.
+	ctx.flushStackOutputReads()
+
 	// Propagate the error from the body, if any.
 	return result
 }
@@ -233,6 +247,9 @@ const (
 	EnvMonitor = "PULUMI_MONITOR"
 	// EnvEngine is the envvar used to read the current Pulumi engine RPC address.
 	EnvEngine = "PULUMI_ENGINE"
+	// EnvDynamicProvider is the envvar used to request that the Pulumi program serve its dynamic providers rather than
+	// run.
+	EnvDynamicProvider = "PULUMI_GO_DYNAMIC_PROVIDER"
 	// envPlugins is the envvar used to request that the Pulumi program print its set of required plugins and exit.
 	envPlugins = "PULUMI_PLUGINS"
 )
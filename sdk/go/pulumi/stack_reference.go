@@ -38,12 +38,16 @@ type StackReference struct {
 // GetOutput returns a stack output keyed by the given name as an AnyOutput
 // If the given name is not present in the StackReference, Output<nil> is returned.
 func (s *StackReference) GetOutput(name StringInput) AnyOutput {
-	return All(name, s.rawOutputs).
+	return All(name, s.rawOutputs, s.URN()).
 		ApplyT(func(args []interface{}) (interface{}, error) {
-			n, stack := args[0].(string), args[1].(resource.PropertyMap)
+			n, stack, urn := args[0].(string), args[1].(resource.PropertyMap), args[2].(URN)
 			if !stack["outputs"].IsObject() {
 				return Any(nil), fmt.Errorf("failed to convert stack output %T to object", stack)
 			}
+
+			// Note the read so that `pulumi stack deps` can tell whether this stack is affected by changes to the
+			// referenced stack. Reads are sent to the engine in one batch per reference when the program finishes.
+			s.ctx.noteStackOutputRead(urn, n)
 			outs := stack["outputs"].ObjectValue()
 			v, ok := outs[resource.PropertyKey(n)]
 			if !ok {
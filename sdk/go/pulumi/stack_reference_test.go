@@ -270,3 +270,35 @@ func TestStackReference_GetOutputDetails(t *testing.T) {
 		})
 	}
 }
+
+func TestStackReferenceGetOutputNotesReads(t *testing.T) {
+	t.Parallel()
+	mocks := &testMonitor{
+		NewResourceF: func(args MockResourceArgs) (string, resource.PropertyMap, error) {
+			return args.Inputs["name"].StringValue(), resource.NewPropertyMapFromMap(map[string]interface{}{
+				"name":    "stack",
+				"outputs": map[string]interface{}{"foo": "bar", "baz": "qux"},
+			}), nil
+		},
+	}
+	err := RunErr(func(ctx *Context) error {
+		ref, err := NewStackReference(ctx, "stack", nil)
+		require.NoError(t, err)
+		urn, _, _, _, err := await(ref.URN())
+		require.NoError(t, err)
+
+		for _, name := range []string{"foo", "baz", "foo", "missing"} {
+			_, _, _, _, err := await(ref.GetOutput(String(name)))
+			require.NoError(t, err)
+		}
+
+		// The reads are batched per reference rather than sent to the engine one at a time.
+		ctx.state.stackOutputReadsLock.Lock()
+		defer ctx.state.stackOutputReadsLock.Unlock()
+		assert.Equal(t, map[URN]map[string]bool{
+			urn.(URN): {"foo": true, "baz": true, "missing": true},
+		}, ctx.state.stackOutputReads)
+		return nil
+	}, WithMocks("project", "stack", mocks))
+	require.NoError(t, err)
+}
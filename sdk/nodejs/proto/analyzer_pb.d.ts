@@ -190,6 +190,16 @@ export class AnalyzerResource extends jspb.Message {
     getPropertydependenciesMap(): jspb.Map<string, AnalyzerPropertyDependencies>;
     clearPropertydependenciesMap(): void;
 
+    hasCustom(): boolean;
+    clearCustom(): void;
+    getCustom(): boolean | undefined;
+    setCustom(value: boolean): AnalyzerResource;
+
+    hasInputs(): boolean;
+    clearInputs(): void;
+    getInputs(): google_protobuf_struct_pb.Struct | undefined;
+    setInputs(value?: google_protobuf_struct_pb.Struct): AnalyzerResource;
+
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): AnalyzerResource.AsObject;
     static toObject(includeInstance: boolean, msg: AnalyzerResource): AnalyzerResource.AsObject;
@@ -212,6 +222,8 @@ export namespace AnalyzerResource {
         dependenciesList: Array<string>,
 
         propertydependenciesMap: Array<[string, AnalyzerPropertyDependencies.AsObject]>,
+        custom?: boolean,
+        inputs?: google_protobuf_struct_pb.Struct.AsObject,
     }
 }
 
@@ -242,6 +254,9 @@ export class AnalyzerResourceOptions extends jspb.Message {
     getParent(): string;
     setParent(value: string): AnalyzerResourceOptions;
 
+    getAnnotationsMap(): jspb.Map<string, string>;
+    clearAnnotationsMap(): void;
+
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): AnalyzerResourceOptions.AsObject;
     static toObject(includeInstance: boolean, msg: AnalyzerResourceOptions): AnalyzerResourceOptions.AsObject;
@@ -262,6 +277,8 @@ export namespace AnalyzerResourceOptions {
         aliasesList: Array<string>,
         customtimeouts?: AnalyzerResourceOptions.CustomTimeouts.AsObject,
         parent: string,
+
+        annotationsMap: Array<[string, string]>,
     }
 
 
@@ -1589,7 +1589,9 @@ proto.pulumirpc.AnalyzerResource.toObject = function(includeInstance, msg) {
     provider: (f = msg.getProvider()) && proto.pulumirpc.AnalyzerProviderResource.toObject(includeInstance, f),
     parent: jspb.Message.getFieldWithDefault(msg, 7, ""),
     dependenciesList: (f = jspb.Message.getRepeatedField(msg, 8)) == null ? undefined : f,
-    propertydependenciesMap: (f = msg.getPropertydependenciesMap()) ? f.toObject(includeInstance, proto.pulumirpc.AnalyzerPropertyDependencies.toObject) : []
+    propertydependenciesMap: (f = msg.getPropertydependenciesMap()) ? f.toObject(includeInstance, proto.pulumirpc.AnalyzerPropertyDependencies.toObject) : [],
+    custom: jspb.Message.getBooleanFieldWithDefault(msg, 10, false),
+    inputs: (f = msg.getInputs()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -1667,6 +1669,15 @@ proto.pulumirpc.AnalyzerResource.deserializeBinaryFromReader = function(msg, rea
         jspb.Map.deserializeBinary(message, reader, jspb.BinaryReader.prototype.readString, jspb.BinaryReader.prototype.readMessage, proto.pulumirpc.AnalyzerPropertyDependencies.deserializeBinaryFromReader, "", new proto.pulumirpc.AnalyzerPropertyDependencies());
          });
       break;
+    case 10:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setCustom(value);
+      break;
+    case 11:
+      var value = new google_protobuf_struct_pb.Struct;
+      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
+      msg.setInputs(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -1759,6 +1770,21 @@ proto.pulumirpc.AnalyzerResource.serializeBinaryToWriter = function(message, wri
   if (f && f.getLength() > 0) {
     f.serializeBinary(9, writer, jspb.BinaryWriter.prototype.writeString, jspb.BinaryWriter.prototype.writeMessage, proto.pulumirpc.AnalyzerPropertyDependencies.serializeBinaryToWriter);
   }
+  f = /** @type {boolean} */ (jspb.Message.getField(message, 10));
+  if (f != null) {
+    writer.writeBool(
+      10,
+      f
+    );
+  }
+  f = message.getInputs();
+  if (f != null) {
+    writer.writeMessage(
+      11,
+      f,
+      google_protobuf_struct_pb.Struct.serializeBinaryToWriter
+    );
+  }
 };
 
 
@@ -2004,6 +2030,79 @@ proto.pulumirpc.AnalyzerResource.prototype.clearPropertydependenciesMap = functi
   return this;};
 
 
+/**
+ * optional bool custom = 10;
+ * @return {boolean}
+ */
+proto.pulumirpc.AnalyzerResource.prototype.getCustom = function() {
+  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 10, false));
+};
+
+
+/**
+ * @param {boolean} value
+ * @return {!proto.pulumirpc.AnalyzerResource} returns this
+ */
+proto.pulumirpc.AnalyzerResource.prototype.setCustom = function(value) {
+  return jspb.Message.setField(this, 10, value);
+};
+
+
+/**
+ * Clears the field making it undefined.
+ * @return {!proto.pulumirpc.AnalyzerResource} returns this
+ */
+proto.pulumirpc.AnalyzerResource.prototype.clearCustom = function() {
+  return jspb.Message.setField(this, 10, undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.pulumirpc.AnalyzerResource.prototype.hasCustom = function() {
+  return jspb.Message.getField(this, 10) != null;
+};
+
+
+/**
+ * optional google.protobuf.Struct inputs = 11;
+ * @return {?proto.google.protobuf.Struct}
+ */
+proto.pulumirpc.AnalyzerResource.prototype.getInputs = function() {
+  return /** @type{?proto.google.protobuf.Struct} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 11));
+};
+
+
+/**
+ * @param {?proto.google.protobuf.Struct|undefined} value
+ * @return {!proto.pulumirpc.AnalyzerResource} returns this
+*/
+proto.pulumirpc.AnalyzerResource.prototype.setInputs = function(value) {
+  return jspb.Message.setWrapperField(this, 11, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.pulumirpc.AnalyzerResource} returns this
+ */
+proto.pulumirpc.AnalyzerResource.prototype.clearInputs = function() {
+  return this.setInputs(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.pulumirpc.AnalyzerResource.prototype.hasInputs = function() {
+  return jspb.Message.getField(this, 11) != null;
+};
+
+
 
 /**
  * List of repeated fields within this message type.
@@ -2050,7 +2149,8 @@ proto.pulumirpc.AnalyzerResourceOptions.toObject = function(includeInstance, msg
     additionalsecretoutputsList: (f = jspb.Message.getRepeatedField(msg, 5)) == null ? undefined : f,
     aliasesList: (f = jspb.Message.getRepeatedField(msg, 6)) == null ? undefined : f,
     customtimeouts: (f = msg.getCustomtimeouts()) && proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts.toObject(includeInstance, f),
-    parent: jspb.Message.getFieldWithDefault(msg, 8, "")
+    parent: jspb.Message.getFieldWithDefault(msg, 8, ""),
+    annotationsMap: (f = msg.getAnnotationsMap()) ? f.toObject(includeInstance, undefined) : []
   };
 
   if (includeInstance) {
@@ -2120,6 +2220,12 @@ proto.pulumirpc.AnalyzerResourceOptions.deserializeBinaryFromReader = function(m
       var value = /** @type {string} */ (reader.readString());
       msg.setParent(value);
       break;
+    case 9:
+      var value = msg.getAnnotationsMap();
+      reader.readMessage(value, function(message, reader) {
+        jspb.Map.deserializeBinary(message, reader, jspb.BinaryReader.prototype.readString, jspb.BinaryReader.prototype.readString, null, "", "");
+         });
+      break;
     default:
       reader.skipField();
       break;
@@ -2206,6 +2312,10 @@ proto.pulumirpc.AnalyzerResourceOptions.serializeBinaryToWriter = function(messa
       f
     );
   }
+  f = message.getAnnotationsMap(true);
+  if (f && f.getLength() > 0) {
+    f.serializeBinary(9, writer, jspb.BinaryWriter.prototype.writeString, jspb.BinaryWriter.prototype.writeString);
+  }
 };
 
 
@@ -2619,6 +2729,28 @@ proto.pulumirpc.AnalyzerResourceOptions.prototype.setParent = function(value) {
 };
 
 
+/**
+ * map<string, string> annotations = 9;
+ * @param {boolean=} opt_noLazyCreate Do not create the map if
+ * empty, instead returning `undefined`
+ * @return {!jspb.Map<string,string>}
+ */
+proto.pulumirpc.AnalyzerResourceOptions.prototype.getAnnotationsMap = function(opt_noLazyCreate) {
+  return /** @type {!jspb.Map<string,string>} */ (
+      jspb.Message.getMapField(this, 9, opt_noLazyCreate,
+      null));
+};
+
+
+/**
+ * Clears values from the map. The map will be non-null.
+ * @return {!proto.pulumirpc.AnalyzerResourceOptions} returns this
+ */
+proto.pulumirpc.AnalyzerResourceOptions.prototype.clearAnnotationsMap = function() {
+  this.getAnnotationsMap().clear();
+  return this;};
+
+
 
 
 
@@ -256,6 +256,11 @@ export class RegisterResourceRequest extends jspb.Message {
     clearHooks(): void;
     getHooks(): RegisterResourceRequest.ResourceHooksBinding | undefined;
     setHooks(value?: RegisterResourceRequest.ResourceHooksBinding): RegisterResourceRequest;
+    getExpires(): string;
+    setExpires(value: string): RegisterResourceRequest;
+
+    getAnnotationsMap(): jspb.Map<string, string>;
+    clearAnnotationsMap(): void;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): RegisterResourceRequest.AsObject;
@@ -306,6 +311,9 @@ export namespace RegisterResourceRequest {
         supportsresultreporting: boolean,
         packageref: string,
         hooks?: RegisterResourceRequest.ResourceHooksBinding.AsObject,
+        expires: string,
+
+        annotationsMap: Array<[string, string]>,
     }
 
 
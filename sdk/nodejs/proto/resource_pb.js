@@ -1755,7 +1755,9 @@ proto.pulumirpc.RegisterResourceRequest.toObject = function(includeInstance, msg
     pulumi_callback_pb.Callback.toObject, includeInstance),
     supportsresultreporting: jspb.Message.getBooleanFieldWithDefault(msg, 32, false),
     packageref: jspb.Message.getFieldWithDefault(msg, 33, ""),
-    hooks: (f = msg.getHooks()) && proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.toObject(includeInstance, f)
+    hooks: (f = msg.getHooks()) && proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.toObject(includeInstance, f),
+    expires: jspb.Message.getFieldWithDefault(msg, 35, ""),
+    annotationsMap: (f = msg.getAnnotationsMap()) ? f.toObject(includeInstance, undefined) : []
   };
 
   if (includeInstance) {
@@ -1940,6 +1942,16 @@ proto.pulumirpc.RegisterResourceRequest.deserializeBinaryFromReader = function(m
       reader.readMessage(value,proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.deserializeBinaryFromReader);
       msg.setHooks(value);
       break;
+    case 35:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setExpires(value);
+      break;
+    case 36:
+      var value = msg.getAnnotationsMap();
+      reader.readMessage(value, function(message, reader) {
+        jspb.Map.deserializeBinary(message, reader, jspb.BinaryReader.prototype.readString, jspb.BinaryReader.prototype.readString, null, "", "");
+         });
+      break;
     default:
       reader.skipField();
       break;
@@ -2204,6 +2216,17 @@ proto.pulumirpc.RegisterResourceRequest.serializeBinaryToWriter = function(messa
       proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.serializeBinaryToWriter
     );
   }
+  f = message.getExpires();
+  if (f.length > 0) {
+    writer.writeString(
+      35,
+      f
+    );
+  }
+  f = message.getAnnotationsMap(true);
+  if (f && f.getLength() > 0) {
+    f.serializeBinary(36, writer, jspb.BinaryWriter.prototype.writeString, jspb.BinaryWriter.prototype.writeString);
+  }
 };
 
 
@@ -3825,6 +3848,46 @@ proto.pulumirpc.RegisterResourceRequest.prototype.hasHooks = function() {
 };
 
 
+/**
+ * optional string expires = 35;
+ * @return {string}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getExpires = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 35, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.setExpires = function(value) {
+  return jspb.Message.setProto3StringField(this, 35, value);
+};
+
+
+/**
+ * map<string, string> annotations = 36;
+ * @param {boolean=} opt_noLazyCreate Do not create the map if
+ * empty, instead returning `undefined`
+ * @return {!jspb.Map<string,string>}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getAnnotationsMap = function(opt_noLazyCreate) {
+  return /** @type {!jspb.Map<string,string>} */ (
+      jspb.Message.getMapField(this, 36, opt_noLazyCreate,
+      null));
+};
+
+
+/**
+ * Clears values from the map. The map will be non-null.
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.clearAnnotationsMap = function() {
+  this.getAnnotationsMap().clear();
+  return this;};
+
+
 
 /**
  * List of repeated fields within this message type.
@@ -16,7 +16,7 @@ from google.protobuf import empty_pb2 as google_dot_protobuf_dot_empty__pb2
 from google.protobuf import struct_pb2 as google_dot_protobuf_dot_struct__pb2
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x15pulumi/analyzer.proto\x12\tpulumirpc\x1a\x13pulumi/plugin.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xfd\x01\n\x1d\x41nalyzerStackConfigureRequest\x12\r\n\x05stack\x18\x01 \x01(\t\x12\x0f\n\x07project\x18\x02 \x01(\t\x12\x14\n\x0corganization\x18\x03 \x01(\t\x12\x0f\n\x07\x64ry_run\x18\x04 \x01(\x08\x12\x1a\n\x12\x63onfig_secret_keys\x18\x06 \x03(\t\x12\x44\n\x06\x63onfig\x18\x07 \x03(\x0b\x32\x34.pulumirpc.AnalyzerStackConfigureRequest.ConfigEntry\x1a-\n\x0b\x43onfigEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01J\x04\x08\x05\x10\x06\" \n\x1e\x41nalyzerStackConfigureResponse\"\x98\x01\n\x18\x41nalyzerHandshakeRequest\x12\x16\n\x0e\x65ngine_address\x18\x01 \x01(\t\x12\x1b\n\x0eroot_directory\x18\x02 \x01(\tH\x00\x88\x01\x01\x12\x1e\n\x11program_directory\x18\x03 \x01(\tH\x01\x88\x01\x01\x42\x11\n\x0f_root_directoryB\x14\n\x12_program_directory\"\x1b\n\x19\x41nalyzerHandshakeResponse\"\xd2\x01\n\x0e\x41nalyzeRequest\x12\x0c\n\x04type\x18\x01 \x01(\t\x12+\n\nproperties\x18\x02 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x0b\n\x03urn\x18\x03 \x01(\t\x12\x0c\n\x04name\x18\x04 \x01(\t\x12\x33\n\x07options\x18\x05 \x01(\x0b\x32\".pulumirpc.AnalyzerResourceOptions\x12\x35\n\x08provider\x18\x06 \x01(\x0b\x32#.pulumirpc.AnalyzerProviderResource\"\xb5\x03\n\x10\x41nalyzerResource\x12\x0c\n\x04type\x18\x01 \x01(\t\x12+\n\nproperties\x18\x02 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x0b\n\x03urn\x18\x03 \x01(\t\x12\x0c\n\x04name\x18\x04 \x01(\t\x12\x33\n\x07options\x18\x05 \x01(\x0b\x32\".pulumirpc.AnalyzerResourceOptions\x12\x35\n\x08provider\x18\x06 \x01(\x0b\x32#.pulumirpc.AnalyzerProviderResource\x12\x0e\n\x06parent\x18\x07 \x01(\t\x12\x14\n\x0c\x64\x65pendencies\x18\x08 \x03(\t\x12S\n\x14propertyDependencies\x18\t \x03(\x0b\x32\x35.pulumirpc.AnalyzerResource.PropertyDependenciesEntry\x1a\x64\n\x19PropertyDependenciesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x36\n\x05value\x18\x02 \x01(\x0b\x32\'.pulumirpc.AnalyzerPropertyDependencies:\x02\x38\x01\"\xd1\x02\n\x17\x41nalyzerResourceOptions\x12\x0f\n\x07protect\x18\x01 \x01(\x08\x12\x15\n\rignoreChanges\x18\x02 \x03(\t\x12\x1b\n\x13\x64\x65leteBeforeReplace\x18\x03 \x01(\x08\x12\"\n\x1a\x64\x65leteBeforeReplaceDefined\x18\x04 \x01(\x08\x12\x1f\n\x17\x61\x64\x64itionalSecretOutputs\x18\x05 \x03(\t\x12\x0f\n\x07\x61liases\x18\x06 \x03(\t\x12I\n\x0e\x63ustomTimeouts\x18\x07 \x01(\x0b\x32\x31.pulumirpc.AnalyzerResourceOptions.CustomTimeouts\x12\x0e\n\x06parent\x18\x08 \x01(\t\x1a@\n\x0e\x43ustomTimeouts\x12\x0e\n\x06\x63reate\x18\x01 \x01(\x01\x12\x0e\n\x06update\x18\x02 \x01(\x01\x12\x0e\n\x06\x64\x65lete\x18\x03 \x01(\x01\"p\n\x18\x41nalyzerProviderResource\x12\x0c\n\x04type\x18\x01 \x01(\t\x12+\n\nproperties\x18\x02 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x0b\n\x03urn\x18\x03 \x01(\t\x12\x0c\n\x04name\x18\x04 \x01(\t\",\n\x1c\x41nalyzerPropertyDependencies\x12\x0c\n\x04urns\x18\x01 \x03(\t\"E\n\x13\x41nalyzeStackRequest\x12.\n\tresources\x18\x01 \x03(\x0b\x32\x1b.pulumirpc.AnalyzerResource\"D\n\x0f\x41nalyzeResponse\x12\x31\n\x0b\x64iagnostics\x18\x02 \x03(\x0b\x32\x1c.pulumirpc.AnalyzeDiagnostic\"\xd0\x01\n\x11\x41nalyzeDiagnostic\x12\x12\n\npolicyName\x18\x01 \x01(\t\x12\x16\n\x0epolicyPackName\x18\x02 \x01(\t\x12\x19\n\x11policyPackVersion\x18\x03 \x01(\t\x12\x13\n\x0b\x64\x65scription\x18\x04 \x01(\t\x12\x0f\n\x07message\x18\x05 \x01(\t\x12\x35\n\x10\x65nforcementLevel\x18\x07 \x01(\x0e\x32\x1b.pulumirpc.EnforcementLevel\x12\x0b\n\x03urn\x18\x08 \x01(\tJ\x04\x08\x06\x10\x07R\x04tags\"\xaa\x01\n\x0bRemediation\x12\x12\n\npolicyName\x18\x01 \x01(\t\x12\x16\n\x0epolicyPackName\x18\x02 \x01(\t\x12\x19\n\x11policyPackVersion\x18\x03 \x01(\t\x12\x13\n\x0b\x64\x65scription\x18\x04 \x01(\t\x12+\n\nproperties\x18\x05 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x12\n\ndiagnostic\x18\x06 \x01(\t\"A\n\x11RemediateResponse\x12,\n\x0cremediations\x18\x01 \x03(\x0b\x32\x16.pulumirpc.Remediation\"\x95\x02\n\x0c\x41nalyzerInfo\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x13\n\x0b\x64isplayName\x18\x02 \x01(\t\x12\'\n\x08policies\x18\x03 \x03(\x0b\x32\x15.pulumirpc.PolicyInfo\x12\x0f\n\x07version\x18\x04 \x01(\t\x12\x16\n\x0esupportsConfig\x18\x05 \x01(\x08\x12\x41\n\rinitialConfig\x18\x06 \x03(\x0b\x32*.pulumirpc.AnalyzerInfo.InitialConfigEntry\x1aM\n\x12InitialConfigEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12&\n\x05value\x18\x02 \x01(\x0b\x32\x17.pulumirpc.PolicyConfig:\x02\x38\x01\"\xc1\x01\n\nPolicyInfo\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x13\n\x0b\x64isplayName\x18\x02 \x01(\t\x12\x13\n\x0b\x64\x65scription\x18\x03 \x01(\t\x12\x0f\n\x07message\x18\x04 \x01(\t\x12\x35\n\x10\x65nforcementLevel\x18\x05 \x01(\x0e\x32\x1b.pulumirpc.EnforcementLevel\x12\x33\n\x0c\x63onfigSchema\x18\x06 \x01(\x0b\x32\x1d.pulumirpc.PolicyConfigSchema\"S\n\x12PolicyConfigSchema\x12+\n\nproperties\x18\x01 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x10\n\x08required\x18\x02 \x03(\t\"r\n\x0cPolicyConfig\x12\x35\n\x10\x65nforcementLevel\x18\x01 \x01(\x0e\x32\x1b.pulumirpc.EnforcementLevel\x12+\n\nproperties\x18\x02 \x01(\x0b\x32\x17.google.protobuf.Struct\"\xb5\x01\n\x18\x43onfigureAnalyzerRequest\x12K\n\x0cpolicyConfig\x18\x01 \x03(\x0b\x32\x35.pulumirpc.ConfigureAnalyzerRequest.PolicyConfigEntry\x1aL\n\x11PolicyConfigEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12&\n\x05value\x18\x02 \x01(\x0b\x32\x17.pulumirpc.PolicyConfig:\x02\x38\x01*L\n\x10\x45nforcementLevel\x12\x0c\n\x08\x41\x44VISORY\x10\x00\x12\r\n\tMANDATORY\x10\x01\x12\x0c\n\x08\x44ISABLED\x10\x02\x12\r\n\tREMEDIATE\x10\x03\x32\xb7\x05\n\x08\x41nalyzer\x12\x42\n\x07\x41nalyze\x12\x19.pulumirpc.AnalyzeRequest\x1a\x1a.pulumirpc.AnalyzeResponse\"\x00\x12L\n\x0c\x41nalyzeStack\x12\x1e.pulumirpc.AnalyzeStackRequest\x1a\x1a.pulumirpc.AnalyzeResponse\"\x00\x12\x46\n\tRemediate\x12\x19.pulumirpc.AnalyzeRequest\x1a\x1c.pulumirpc.RemediateResponse\"\x00\x12\x44\n\x0fGetAnalyzerInfo\x12\x16.google.protobuf.Empty\x1a\x17.pulumirpc.AnalyzerInfo\"\x00\x12@\n\rGetPluginInfo\x12\x16.google.protobuf.Empty\x1a\x15.pulumirpc.PluginInfo\"\x00\x12J\n\tConfigure\x12#.pulumirpc.ConfigureAnalyzerRequest\x1a\x16.google.protobuf.Empty\"\x00\x12X\n\tHandshake\x12#.pulumirpc.AnalyzerHandshakeRequest\x1a$.pulumirpc.AnalyzerHandshakeResponse\"\x00\x12g\n\x0e\x43onfigureStack\x12(.pulumirpc.AnalyzerStackConfigureRequest\x1a).pulumirpc.AnalyzerStackConfigureResponse\"\x00\x12:\n\x06\x43\x61ncel\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x00\x42\x34Z2github.com/pulumi/pulumi/sdk/v3/proto/go;pulumirpcb\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x15pulumi/analyzer.proto\x12\tpulumirpc\x1a\x13pulumi/plugin.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xfd\x01\n\x1d\x41nalyzerStackConfigureRequest\x12\r\n\x05stack\x18\x01 \x01(\t\x12\x0f\n\x07project\x18\x02 \x01(\t\x12\x14\n\x0corganization\x18\x03 \x01(\t\x12\x0f\n\x07\x64ry_run\x18\x04 \x01(\x08\x12\x1a\n\x12\x63onfig_secret_keys\x18\x06 \x03(\t\x12\x44\n\x06\x63onfig\x18\x07 \x03(\x0b\x32\x34.pulumirpc.AnalyzerStackConfigureRequest.ConfigEntry\x1a-\n\x0b\x43onfigEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01J\x04\x08\x05\x10\x06\" \n\x1e\x41nalyzerStackConfigureResponse\"\x98\x01\n\x18\x41nalyzerHandshakeRequest\x12\x16\n\x0e\x65ngine_address\x18\x01 \x01(\t\x12\x1b\n\x0eroot_directory\x18\x02 \x01(\tH\x00\x88\x01\x01\x12\x1e\n\x11program_directory\x18\x03 \x01(\tH\x01\x88\x01\x01\x42\x11\n\x0f_root_directoryB\x14\n\x12_program_directory\"\x1b\n\x19\x41nalyzerHandshakeResponse\"\xd2\x01\n\x0e\x41nalyzeRequest\x12\x0c\n\x04type\x18\x01 \x01(\t\x12+\n\nproperties\x18\x02 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x0b\n\x03urn\x18\x03 \x01(\t\x12\x0c\n\x04name\x18\x04 \x01(\t\x12\x33\n\x07options\x18\x05 \x01(\x0b\x32\".pulumirpc.AnalyzerResourceOptions\x12\x35\n\x08provider\x18\x06 \x01(\x0b\x32#.pulumirpc.AnalyzerProviderResource\"\xfe\x03\n\x10\x41nalyzerResource\x12\x0c\n\x04type\x18\x01 \x01(\t\x12+\n\nproperties\x18\x02 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x0b\n\x03urn\x18\x03 \x01(\t\x12\x0c\n\x04name\x18\x04 \x01(\t\x12\x33\n\x07options\x18\x05 \x01(\x0b\x32\".pulumirpc.AnalyzerResourceOptions\x12\x35\n\x08provider\x18\x06 \x01(\x0b\x32#.pulumirpc.AnalyzerProviderResource\x12\x0e\n\x06parent\x18\x07 \x01(\t\x12\x14\n\x0c\x64\x65pendencies\x18\x08 \x03(\t\x12S\n\x14propertyDependencies\x18\t \x03(\x0b\x32\x35.pulumirpc.AnalyzerResource.PropertyDependenciesEntry\x12\x13\n\x06\x63ustom\x18\n \x01(\x08H\x00\x88\x01\x01\x12\'\n\x06inputs\x18\x0b \x01(\x0b\x32\x17.google.protobuf.Struct\x1a\x64\n\x19PropertyDependenciesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x36\n\x05value\x18\x02 \x01(\x0b\x32\'.pulumirpc.AnalyzerPropertyDependencies:\x02\x38\x01\x42\t\n\x07_custom\"\xcf\x03\n\x17\x41nalyzerResourceOptions\x12\x0f\n\x07protect\x18\x01 \x01(\x08\x12\x15\n\rignoreChanges\x18\x02 \x03(\t\x12\x1b\n\x13\x64\x65leteBeforeReplace\x18\x03 \x01(\x08\x12\"\n\x1a\x64\x65leteBeforeReplaceDefined\x18\x04 \x01(\x08\x12\x1f\n\x17\x61\x64\x64itionalSecretOutputs\x18\x05 \x03(\t\x12\x0f\n\x07\x61liases\x18\x06 \x03(\t\x12I\n\x0e\x63ustomTimeouts\x18\x07 \x01(\x0b\x32\x31.pulumirpc.AnalyzerResourceOptions.CustomTimeouts\x12\x0e\n\x06parent\x18\x08 \x01(\t\x12H\n\x0b\x61nnotations\x18\t \x03(\x0b\x32\x33.pulumirpc.AnalyzerResourceOptions.AnnotationsEntry\x1a@\n\x0e\x43ustomTimeouts\x12\x0e\n\x06\x63reate\x18\x01 \x01(\x01\x12\x0e\n\x06update\x18\x02 \x01(\x01\x12\x0e\n\x06\x64\x65lete\x18\x03 \x01(\x01\x1a\x32\n\x10\x41nnotationsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\"p\n\x18\x41nalyzerProviderResource\x12\x0c\n\x04type\x18\x01 \x01(\t\x12+\n\nproperties\x18\x02 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x0b\n\x03urn\x18\x03 \x01(\t\x12\x0c\n\x04name\x18\x04 \x01(\t\",\n\x1c\x41nalyzerPropertyDependencies\x12\x0c\n\x04urns\x18\x01 \x03(\t\"E\n\x13\x41nalyzeStackRequest\x12.\n\tresources\x18\x01 \x03(\x0b\x32\x1b.pulumirpc.AnalyzerResource\"D\n\x0f\x41nalyzeResponse\x12\x31\n\x0b\x64iagnostics\x18\x02 \x03(\x0b\x32\x1c.pulumirpc.AnalyzeDiagnostic\"\xd0\x01\n\x11\x41nalyzeDiagnostic\x12\x12\n\npolicyName\x18\x01 \x01(\t\x12\x16\n\x0epolicyPackName\x18\x02 \x01(\t\x12\x19\n\x11policyPackVersion\x18\x03 \x01(\t\x12\x13\n\x0b\x64\x65scription\x18\x04 \x01(\t\x12\x0f\n\x07message\x18\x05 \x01(\t\x12\x35\n\x10\x65nforcementLevel\x18\x07 \x01(\x0e\x32\x1b.pulumirpc.EnforcementLevel\x12\x0b\n\x03urn\x18\x08 \x01(\tJ\x04\x08\x06\x10\x07R\x04tags\"\xaa\x01\n\x0bRemediation\x12\x12\n\npolicyName\x18\x01 \x01(\t\x12\x16\n\x0epolicyPackName\x18\x02 \x01(\t\x12\x19\n\x11policyPackVersion\x18\x03 \x01(\t\x12\x13\n\x0b\x64\x65scription\x18\x04 \x01(\t\x12+\n\nproperties\x18\x05 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x12\n\ndiagnostic\x18\x06 \x01(\t\"A\n\x11RemediateResponse\x12,\n\x0cremediations\x18\x01 \x03(\x0b\x32\x16.pulumirpc.Remediation\"\x95\x02\n\x0c\x41nalyzerInfo\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x13\n\x0b\x64isplayName\x18\x02 \x01(\t\x12\'\n\x08policies\x18\x03 \x03(\x0b\x32\x15.pulumirpc.PolicyInfo\x12\x0f\n\x07version\x18\x04 \x01(\t\x12\x16\n\x0esupportsConfig\x18\x05 \x01(\x08\x12\x41\n\rinitialConfig\x18\x06 \x03(\x0b\x32*.pulumirpc.AnalyzerInfo.InitialConfigEntry\x1aM\n\x12InitialConfigEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12&\n\x05value\x18\x02 \x01(\x0b\x32\x17.pulumirpc.PolicyConfig:\x02\x38\x01\"\xc1\x01\n\nPolicyInfo\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x13\n\x0b\x64isplayName\x18\x02 \x01(\t\x12\x13\n\x0b\x64\x65scription\x18\x03 \x01(\t\x12\x0f\n\x07message\x18\x04 \x01(\t\x12\x35\n\x10\x65nforcementLevel\x18\x05 \x01(\x0e\x32\x1b.pulumirpc.EnforcementLevel\x12\x33\n\x0c\x63onfigSchema\x18\x06 \x01(\x0b\x32\x1d.pulumirpc.PolicyConfigSchema\"S\n\x12PolicyConfigSchema\x12+\n\nproperties\x18\x01 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x10\n\x08required\x18\x02 \x03(\t\"r\n\x0cPolicyConfig\x12\x35\n\x10\x65nforcementLevel\x18\x01 \x01(\x0e\x32\x1b.pulumirpc.EnforcementLevel\x12+\n\nproperties\x18\x02 \x01(\x0b\x32\x17.google.protobuf.Struct\"\xb5\x01\n\x18\x43onfigureAnalyzerRequest\x12K\n\x0cpolicyConfig\x18\x01 \x03(\x0b\x32\x35.pulumirpc.ConfigureAnalyzerRequest.PolicyConfigEntry\x1aL\n\x11PolicyConfigEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12&\n\x05value\x18\x02 \x01(\x0b\x32\x17.pulumirpc.PolicyConfig:\x02\x38\x01*L\n\x10\x45nforcementLevel\x12\x0c\n\x08\x41\x44VISORY\x10\x00\x12\r\n\tMANDATORY\x10\x01\x12\x0c\n\x08\x44ISABLED\x10\x02\x12\r\n\tREMEDIATE\x10\x03\x32\xb7\x05\n\x08\x41nalyzer\x12\x42\n\x07\x41nalyze\x12\x19.pulumirpc.AnalyzeRequest\x1a\x1a.pulumirpc.AnalyzeResponse\"\x00\x12L\n\x0c\x41nalyzeStack\x12\x1e.pulumirpc.AnalyzeStackRequest\x1a\x1a.pulumirpc.AnalyzeResponse\"\x00\x12\x46\n\tRemediate\x12\x19.pulumirpc.AnalyzeRequest\x1a\x1c.pulumirpc.RemediateResponse\"\x00\x12\x44\n\x0fGetAnalyzerInfo\x12\x16.google.protobuf.Empty\x1a\x17.pulumirpc.AnalyzerInfo\"\x00\x12@\n\rGetPluginInfo\x12\x16.google.protobuf.Empty\x1a\x15.pulumirpc.PluginInfo\"\x00\x12J\n\tConfigure\x12#.pulumirpc.ConfigureAnalyzerRequest\x1a\x16.google.protobuf.Empty\"\x00\x12X\n\tHandshake\x12#.pulumirpc.AnalyzerHandshakeRequest\x1a$.pulumirpc.AnalyzerHandshakeResponse\"\x00\x12g\n\x0e\x43onfigureStack\x12(.pulumirpc.AnalyzerStackConfigureRequest\x1a).pulumirpc.AnalyzerStackConfigureResponse\"\x00\x12:\n\x06\x43\x61ncel\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x00\x42\x34Z2github.com/pulumi/pulumi/sdk/v3/proto/go;pulumirpcb\x06proto3')
 
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, globals())
 _builder.BuildTopDescriptorsAndMessages(DESCRIPTOR, 'pulumi.analyzer_pb2', globals())
@@ -28,12 +28,14 @@ if _descriptor._USE_C_DESCRIPTORS == False:
   _ANALYZERSTACKCONFIGUREREQUEST_CONFIGENTRY._serialized_options = b'8\001'
   _ANALYZERRESOURCE_PROPERTYDEPENDENCIESENTRY._options = None
   _ANALYZERRESOURCE_PROPERTYDEPENDENCIESENTRY._serialized_options = b'8\001'
+  _ANALYZERRESOURCEOPTIONS_ANNOTATIONSENTRY._options = None
+  _ANALYZERRESOURCEOPTIONS_ANNOTATIONSENTRY._serialized_options = b'8\001'
   _ANALYZERINFO_INITIALCONFIGENTRY._options = None
   _ANALYZERINFO_INITIALCONFIGENTRY._serialized_options = b'8\001'
   _CONFIGUREANALYZERREQUEST_POLICYCONFIGENTRY._options = None
   _CONFIGUREANALYZERREQUEST_POLICYCONFIGENTRY._serialized_options = b'8\001'
-  _ENFORCEMENTLEVEL._serialized_start=3196
-  _ENFORCEMENTLEVEL._serialized_end=3272
+  _ENFORCEMENTLEVEL._serialized_start=3395
+  _ENFORCEMENTLEVEL._serialized_end=3471
   _ANALYZERSTACKCONFIGUREREQUEST._serialized_start=117
   _ANALYZERSTACKCONFIGUREREQUEST._serialized_end=370
   _ANALYZERSTACKCONFIGUREREQUEST_CONFIGENTRY._serialized_start=319
@@ -47,41 +49,43 @@ if _descriptor._USE_C_DESCRIPTORS == False:
   _ANALYZEREQUEST._serialized_start=591
   _ANALYZEREQUEST._serialized_end=801
   _ANALYZERRESOURCE._serialized_start=804
-  _ANALYZERRESOURCE._serialized_end=1241
-  _ANALYZERRESOURCE_PROPERTYDEPENDENCIESENTRY._serialized_start=1141
-  _ANALYZERRESOURCE_PROPERTYDEPENDENCIESENTRY._serialized_end=1241
-  _ANALYZERRESOURCEOPTIONS._serialized_start=1244
-  _ANALYZERRESOURCEOPTIONS._serialized_end=1581
-  _ANALYZERRESOURCEOPTIONS_CUSTOMTIMEOUTS._serialized_start=1517
-  _ANALYZERRESOURCEOPTIONS_CUSTOMTIMEOUTS._serialized_end=1581
-  _ANALYZERPROVIDERRESOURCE._serialized_start=1583
-  _ANALYZERPROVIDERRESOURCE._serialized_end=1695
-  _ANALYZERPROPERTYDEPENDENCIES._serialized_start=1697
-  _ANALYZERPROPERTYDEPENDENCIES._serialized_end=1741
-  _ANALYZESTACKREQUEST._serialized_start=1743
-  _ANALYZESTACKREQUEST._serialized_end=1812
-  _ANALYZERESPONSE._serialized_start=1814
-  _ANALYZERESPONSE._serialized_end=1882
-  _ANALYZEDIAGNOSTIC._serialized_start=1885
-  _ANALYZEDIAGNOSTIC._serialized_end=2093
-  _REMEDIATION._serialized_start=2096
-  _REMEDIATION._serialized_end=2266
-  _REMEDIATERESPONSE._serialized_start=2268
-  _REMEDIATERESPONSE._serialized_end=2333
-  _ANALYZERINFO._serialized_start=2336
-  _ANALYZERINFO._serialized_end=2613
-  _ANALYZERINFO_INITIALCONFIGENTRY._serialized_start=2536
-  _ANALYZERINFO_INITIALCONFIGENTRY._serialized_end=2613
-  _POLICYINFO._serialized_start=2616
-  _POLICYINFO._serialized_end=2809
-  _POLICYCONFIGSCHEMA._serialized_start=2811
-  _POLICYCONFIGSCHEMA._serialized_end=2894
-  _POLICYCONFIG._serialized_start=2896
-  _POLICYCONFIG._serialized_end=3010
-  _CONFIGUREANALYZERREQUEST._serialized_start=3013
-  _CONFIGUREANALYZERREQUEST._serialized_end=3194
-  _CONFIGUREANALYZERREQUEST_POLICYCONFIGENTRY._serialized_start=3118
-  _CONFIGUREANALYZERREQUEST_POLICYCONFIGENTRY._serialized_end=3194
-  _ANALYZER._serialized_start=3275
-  _ANALYZER._serialized_end=3970
+  _ANALYZERRESOURCE._serialized_end=1314
+  _ANALYZERRESOURCE_PROPERTYDEPENDENCIESENTRY._serialized_start=1203
+  _ANALYZERRESOURCE_PROPERTYDEPENDENCIESENTRY._serialized_end=1303
+  _ANALYZERRESOURCEOPTIONS._serialized_start=1317
+  _ANALYZERRESOURCEOPTIONS._serialized_end=1780
+  _ANALYZERRESOURCEOPTIONS_CUSTOMTIMEOUTS._serialized_start=1664
+  _ANALYZERRESOURCEOPTIONS_CUSTOMTIMEOUTS._serialized_end=1728
+  _ANALYZERRESOURCEOPTIONS_ANNOTATIONSENTRY._serialized_start=1730
+  _ANALYZERRESOURCEOPTIONS_ANNOTATIONSENTRY._serialized_end=1780
+  _ANALYZERPROVIDERRESOURCE._serialized_start=1782
+  _ANALYZERPROVIDERRESOURCE._serialized_end=1894
+  _ANALYZERPROPERTYDEPENDENCIES._serialized_start=1896
+  _ANALYZERPROPERTYDEPENDENCIES._serialized_end=1940
+  _ANALYZESTACKREQUEST._serialized_start=1942
+  _ANALYZESTACKREQUEST._serialized_end=2011
+  _ANALYZERESPONSE._serialized_start=2013
+  _ANALYZERESPONSE._serialized_end=2081
+  _ANALYZEDIAGNOSTIC._serialized_start=2084
+  _ANALYZEDIAGNOSTIC._serialized_end=2292
+  _REMEDIATION._serialized_start=2295
+  _REMEDIATION._serialized_end=2465
+  _REMEDIATERESPONSE._serialized_start=2467
+  _REMEDIATERESPONSE._serialized_end=2532
+  _ANALYZERINFO._serialized_start=2535
+  _ANALYZERINFO._serialized_end=2812
+  _ANALYZERINFO_INITIALCONFIGENTRY._serialized_start=2735
+  _ANALYZERINFO_INITIALCONFIGENTRY._serialized_end=2812
+  _POLICYINFO._serialized_start=2815
+  _POLICYINFO._serialized_end=3008
+  _POLICYCONFIGSCHEMA._serialized_start=3010
+  _POLICYCONFIGSCHEMA._serialized_end=3093
+  _POLICYCONFIG._serialized_start=3095
+  _POLICYCONFIG._serialized_end=3209
+  _CONFIGUREANALYZERREQUEST._serialized_start=3212
+  _CONFIGUREANALYZERREQUEST._serialized_end=3393
+  _CONFIGUREANALYZERREQUEST_POLICYCONFIGENTRY._serialized_start=3317
+  _CONFIGUREANALYZERREQUEST_POLICYCONFIGENTRY._serialized_end=3393
+  _ANALYZER._serialized_start=3474
+  _ANALYZER._serialized_end=4169
 # @@protoc_insertion_point(module_scope)
@@ -256,6 +256,8 @@ class AnalyzerResource(google.protobuf.message.Message):
     PARENT_FIELD_NUMBER: builtins.int
     DEPENDENCIES_FIELD_NUMBER: builtins.int
     PROPERTYDEPENDENCIES_FIELD_NUMBER: builtins.int
+    CUSTOM_FIELD_NUMBER: builtins.int
+    INPUTS_FIELD_NUMBER: builtins.int
     type: builtins.str
     """the type token of the resource."""
     @property
@@ -279,6 +281,11 @@ class AnalyzerResource(google.protobuf.message.Message):
     @property
     def propertyDependencies(self) -> google.protobuf.internal.containers.MessageMap[builtins.str, global___AnalyzerPropertyDependencies]:
         """a map from property keys to the dependencies of the property."""
+    custom: builtins.bool
+    """true for custom resources, false for components; unset by older engines."""
+    @property
+    def inputs(self) -> google.protobuf.struct_pb2.Struct:
+        """the resource's inputs, e.g. the arguments a component was constructed with."""
     def __init__(
         self,
         *,
@@ -291,9 +298,12 @@ class AnalyzerResource(google.protobuf.message.Message):
         parent: builtins.str = ...,
         dependencies: collections.abc.Iterable[builtins.str] | None = ...,
         propertyDependencies: collections.abc.Mapping[builtins.str, global___AnalyzerPropertyDependencies] | None = ...,
+        custom: builtins.bool | None = ...,
+        inputs: google.protobuf.struct_pb2.Struct | None = ...,
     ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["options", b"options", "properties", b"properties", "provider", b"provider"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["dependencies", b"dependencies", "name", b"name", "options", b"options", "parent", b"parent", "properties", b"properties", "propertyDependencies", b"propertyDependencies", "provider", b"provider", "type", b"type", "urn", b"urn"]) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_custom", b"_custom", "custom", b"custom", "inputs", b"inputs", "options", b"options", "properties", b"properties", "provider", b"provider"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_custom", b"_custom", "custom", b"custom", "dependencies", b"dependencies", "inputs", b"inputs", "name", b"name", "options", b"options", "parent", b"parent", "properties", b"properties", "propertyDependencies", b"propertyDependencies", "provider", b"provider", "type", b"type", "urn", b"urn"]) -> None: ...
+    def WhichOneof(self, oneof_group: typing_extensions.Literal["_custom", b"_custom"]) -> typing_extensions.Literal["custom"] | None: ...
 
 global___AnalyzerResource = AnalyzerResource
 
@@ -327,6 +337,22 @@ class AnalyzerResourceOptions(google.protobuf.message.Message):
         ) -> None: ...
         def ClearField(self, field_name: typing_extensions.Literal["create", b"create", "delete", b"delete", "update", b"update"]) -> None: ...
 
+    @typing_extensions.final
+    class AnnotationsEntry(google.protobuf.message.Message):
+        DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+        KEY_FIELD_NUMBER: builtins.int
+        VALUE_FIELD_NUMBER: builtins.int
+        key: builtins.str
+        value: builtins.str
+        def __init__(
+            self,
+            *,
+            key: builtins.str = ...,
+            value: builtins.str = ...,
+        ) -> None: ...
+        def ClearField(self, field_name: typing_extensions.Literal["key", b"key", "value", b"value"]) -> None: ...
+
     PROTECT_FIELD_NUMBER: builtins.int
     IGNORECHANGES_FIELD_NUMBER: builtins.int
     DELETEBEFOREREPLACE_FIELD_NUMBER: builtins.int
@@ -335,6 +361,7 @@ class AnalyzerResourceOptions(google.protobuf.message.Message):
     ALIASES_FIELD_NUMBER: builtins.int
     CUSTOMTIMEOUTS_FIELD_NUMBER: builtins.int
     PARENT_FIELD_NUMBER: builtins.int
+    ANNOTATIONS_FIELD_NUMBER: builtins.int
     protect: builtins.bool
     """true if the resource should be marked protected."""
     @property
@@ -355,6 +382,9 @@ class AnalyzerResourceOptions(google.protobuf.message.Message):
         """a config block that will be used to configure timeouts for CRUD operations."""
     parent: builtins.str
     """an optional parent URN that this child resource belongs to."""
+    @property
+    def annotations(self) -> google.protobuf.internal.containers.ScalarMap[builtins.str, builtins.str]:
+        """user-defined key/value metadata stored with the resource."""
     def __init__(
         self,
         *,
@@ -366,9 +396,10 @@ class AnalyzerResourceOptions(google.protobuf.message.Message):
         aliases: collections.abc.Iterable[builtins.str] | None = ...,
         customTimeouts: global___AnalyzerResourceOptions.CustomTimeouts | None = ...,
         parent: builtins.str = ...,
+        annotations: collections.abc.Mapping[builtins.str, builtins.str] | None = ...,
     ) -> None: ...
     def HasField(self, field_name: typing_extensions.Literal["customTimeouts", b"customTimeouts"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["additionalSecretOutputs", b"additionalSecretOutputs", "aliases", b"aliases", "customTimeouts", b"customTimeouts", "deleteBeforeReplace", b"deleteBeforeReplace", "deleteBeforeReplaceDefined", b"deleteBeforeReplaceDefined", "ignoreChanges", b"ignoreChanges", "parent", b"parent", "protect", b"protect"]) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["additionalSecretOutputs", b"additionalSecretOutputs", "aliases", b"aliases", "annotations", b"annotations", "customTimeouts", b"customTimeouts", "deleteBeforeReplace", b"deleteBeforeReplace", "deleteBeforeReplaceDefined", b"deleteBeforeReplaceDefined", "ignoreChanges", b"ignoreChanges", "parent", b"parent", "protect", b"protect"]) -> None: ...
 
 global___AnalyzerResourceOptions = AnalyzerResourceOptions
 
@@ -19,7 +19,7 @@ from . import source_pb2 as pulumi_dot_source__pb2
 from . import callback_pb2 as pulumi_dot_callback__pb2
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x15pulumi/resource.proto\x12\tpulumirpc\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x15pulumi/provider.proto\x1a\x12pulumi/alias.proto\x1a\x13pulumi/source.proto\x1a\x15pulumi/callback.proto\"$\n\x16SupportsFeatureRequest\x12\n\n\x02id\x18\x01 \x01(\t\"-\n\x17SupportsFeatureResponse\x12\x12\n\nhasSupport\x18\x01 \x01(\x08\"\xfb\x03\n\x13ReadResourceRequest\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0c\n\x04type\x18\x02 \x01(\t\x12\x0c\n\x04name\x18\x03 \x01(\t\x12\x0e\n\x06parent\x18\x04 \x01(\t\x12+\n\nproperties\x18\x05 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x14\n\x0c\x64\x65pendencies\x18\x06 \x03(\t\x12\x10\n\x08provider\x18\x07 \x01(\t\x12\x0f\n\x07version\x18\x08 \x01(\t\x12\x15\n\racceptSecrets\x18\t \x01(\x08\x12\x1f\n\x17\x61\x64\x64itionalSecretOutputs\x18\n \x03(\t\x12\x17\n\x0f\x61\x63\x63\x65ptResources\x18\x0c \x01(\x08\x12\x19\n\x11pluginDownloadURL\x18\r \x01(\t\x12L\n\x0fpluginChecksums\x18\x0f \x03(\x0b\x32\x33.pulumirpc.ReadResourceRequest.PluginChecksumsEntry\x12\x31\n\x0esourcePosition\x18\x0e \x01(\x0b\x32\x19.pulumirpc.SourcePosition\x12\x12\n\npackageRef\x18\x10 \x01(\t\x1a\x36\n\x14PluginChecksumsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c:\x02\x38\x01J\x04\x08\x0b\x10\x0cR\x07\x61liases\"P\n\x14ReadResourceResponse\x12\x0b\n\x03urn\x18\x01 \x01(\t\x12+\n\nproperties\x18\x02 \x01(\x0b\x32\x17.google.protobuf.Struct\"\x96\r\n\x17RegisterResourceRequest\x12\x0c\n\x04type\x18\x01 \x01(\t\x12\x0c\n\x04name\x18\x02 \x01(\t\x12\x0e\n\x06parent\x18\x03 \x01(\t\x12\x0e\n\x06\x63ustom\x18\x04 \x01(\x08\x12\'\n\x06object\x18\x05 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x14\n\x07protect\x18\x06 \x01(\x08H\x00\x88\x01\x01\x12\x14\n\x0c\x64\x65pendencies\x18\x07 \x03(\t\x12\x10\n\x08provider\x18\x08 \x01(\t\x12Z\n\x14propertyDependencies\x18\t \x03(\x0b\x32<.pulumirpc.RegisterResourceRequest.PropertyDependenciesEntry\x12\x1b\n\x13\x64\x65leteBeforeReplace\x18\n \x01(\x08\x12\x0f\n\x07version\x18\x0b \x01(\t\x12\x15\n\rignoreChanges\x18\x0c \x03(\t\x12\x15\n\racceptSecrets\x18\r \x01(\x08\x12\x1f\n\x17\x61\x64\x64itionalSecretOutputs\x18\x0e \x03(\t\x12\x11\n\taliasURNs\x18\x0f \x03(\t\x12\x10\n\x08importId\x18\x10 \x01(\t\x12I\n\x0e\x63ustomTimeouts\x18\x11 \x01(\x0b\x32\x31.pulumirpc.RegisterResourceRequest.CustomTimeouts\x12\"\n\x1a\x64\x65leteBeforeReplaceDefined\x18\x12 \x01(\x08\x12\x1d\n\x15supportsPartialValues\x18\x13 \x01(\x08\x12\x0e\n\x06remote\x18\x14 \x01(\x08\x12\x17\n\x0f\x61\x63\x63\x65ptResources\x18\x15 \x01(\x08\x12\x44\n\tproviders\x18\x16 \x03(\x0b\x32\x31.pulumirpc.RegisterResourceRequest.ProvidersEntry\x12\x18\n\x10replaceOnChanges\x18\x17 \x03(\t\x12\x19\n\x11pluginDownloadURL\x18\x18 \x01(\t\x12P\n\x0fpluginChecksums\x18\x1e \x03(\x0b\x32\x37.pulumirpc.RegisterResourceRequest.PluginChecksumsEntry\x12\x1b\n\x0eretainOnDelete\x18\x19 \x01(\x08H\x01\x88\x01\x01\x12!\n\x07\x61liases\x18\x1a \x03(\x0b\x32\x10.pulumirpc.Alias\x12\x13\n\x0b\x64\x65letedWith\x18\x1b \x01(\t\x12\x12\n\naliasSpecs\x18\x1c \x01(\x08\x12\x31\n\x0esourcePosition\x18\x1d \x01(\x0b\x32\x19.pulumirpc.SourcePosition\x12\'\n\ntransforms\x18\x1f \x03(\x0b\x32\x13.pulumirpc.Callback\x12\x1f\n\x17supportsResultReporting\x18  \x01(\x08\x12\x12\n\npackageRef\x18! \x01(\t\x12K\n\x05hooks\x18\" \x01(\x0b\x32\x37.pulumirpc.RegisterResourceRequest.ResourceHooksBindingH\x02\x88\x01\x01\x1a$\n\x14PropertyDependencies\x12\x0c\n\x04urns\x18\x01 \x03(\t\x1a@\n\x0e\x43ustomTimeouts\x12\x0e\n\x06\x63reate\x18\x01 \x01(\t\x12\x0e\n\x06update\x18\x02 \x01(\t\x12\x0e\n\x06\x64\x65lete\x18\x03 \x01(\t\x1at\n\x19PropertyDependenciesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x46\n\x05value\x18\x02 \x01(\x0b\x32\x37.pulumirpc.RegisterResourceRequest.PropertyDependencies:\x02\x38\x01\x1a\x30\n\x0eProvidersEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\x1a\x36\n\x14PluginChecksumsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c:\x02\x38\x01\x1a\x9d\x01\n\x14ResourceHooksBinding\x12\x15\n\rbefore_create\x18\x01 \x03(\t\x12\x14\n\x0c\x61\x66ter_create\x18\x02 \x03(\t\x12\x15\n\rbefore_update\x18\x03 \x03(\t\x12\x14\n\x0c\x61\x66ter_update\x18\x04 \x03(\t\x12\x15\n\rbefore_delete\x18\x05 \x03(\t\x12\x14\n\x0c\x61\x66ter_delete\x18\x06 \x03(\tB\n\n\x08_protectB\x11\n\x0f_retainOnDeleteB\x08\n\x06_hooks\"\x9a\x03\n\x18RegisterResourceResponse\x12\x0b\n\x03urn\x18\x01 \x01(\t\x12\n\n\x02id\x18\x02 \x01(\t\x12\'\n\x06object\x18\x03 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x0e\n\x06stable\x18\x04 \x01(\x08\x12\x0f\n\x07stables\x18\x05 \x03(\t\x12[\n\x14propertyDependencies\x18\x06 \x03(\x0b\x32=.pulumirpc.RegisterResourceResponse.PropertyDependenciesEntry\x12!\n\x06result\x18\x07 \x01(\x0e\x32\x11.pulumirpc.Result\x1a$\n\x14PropertyDependencies\x12\x0c\n\x04urns\x18\x01 \x03(\t\x1au\n\x19PropertyDependenciesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12G\n\x05value\x18\x02 \x01(\x0b\x32\x38.pulumirpc.RegisterResourceResponse.PropertyDependencies:\x02\x38\x01\"W\n\x1eRegisterResourceOutputsRequest\x12\x0b\n\x03urn\x18\x01 \x01(\t\x12(\n\x07outputs\x18\x02 \x01(\x0b\x32\x17.google.protobuf.Struct\"\xf1\x02\n\x15ResourceInvokeRequest\x12\x0b\n\x03tok\x18\x01 \x01(\t\x12%\n\x04\x61rgs\x18\x02 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x10\n\x08provider\x18\x03 \x01(\t\x12\x0f\n\x07version\x18\x04 \x01(\t\x12\x17\n\x0f\x61\x63\x63\x65ptResources\x18\x05 \x01(\x08\x12\x19\n\x11pluginDownloadURL\x18\x06 \x01(\t\x12N\n\x0fpluginChecksums\x18\x08 \x03(\x0b\x32\x35.pulumirpc.ResourceInvokeRequest.PluginChecksumsEntry\x12\x31\n\x0esourcePosition\x18\x07 \x01(\x0b\x32\x19.pulumirpc.SourcePosition\x12\x12\n\npackageRef\x18\t \x01(\t\x1a\x36\n\x14PluginChecksumsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c:\x02\x38\x01\"\xc0\x05\n\x13ResourceCallRequest\x12\x0b\n\x03tok\x18\x01 \x01(\t\x12%\n\x04\x61rgs\x18\x02 \x01(\x0b\x32\x17.google.protobuf.Struct\x12L\n\x0f\x61rgDependencies\x18\x03 \x03(\x0b\x32\x33.pulumirpc.ResourceCallRequest.ArgDependenciesEntry\x12\x10\n\x08provider\x18\x04 \x01(\t\x12\x0f\n\x07version\x18\x05 \x01(\t\x12\x19\n\x11pluginDownloadURL\x18\r \x01(\t\x12L\n\x0fpluginChecksums\x18\x10 \x03(\x0b\x32\x33.pulumirpc.ResourceCallRequest.PluginChecksumsEntry\x12\x31\n\x0esourcePosition\x18\x0f \x01(\x0b\x32\x19.pulumirpc.SourcePosition\x12\x12\n\npackageRef\x18\x11 \x01(\t\x1a$\n\x14\x41rgumentDependencies\x12\x0c\n\x04urns\x18\x01 \x03(\t\x1ak\n\x14\x41rgDependenciesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x42\n\x05value\x18\x02 \x01(\x0b\x32\x33.pulumirpc.ResourceCallRequest.ArgumentDependencies:\x02\x38\x01\x1a\x36\n\x14PluginChecksumsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c:\x02\x38\x01J\x04\x08\x06\x10\x07J\x04\x08\x07\x10\x08J\x04\x08\x08\x10\tJ\x04\x08\t\x10\nJ\x04\x08\n\x10\x0bJ\x04\x08\x0b\x10\x0cJ\x04\x08\x0c\x10\rJ\x04\x08\x0e\x10\x0fR\x07projectR\x05stackR\x06\x63onfigR\x10\x63onfigSecretKeysR\x06\x64ryRunR\x08parallelR\x0fmonitorEndpointR\x0corganization\"\xab\x06\n\x18TransformResourceOptions\x12\x12\n\ndepends_on\x18\x01 \x03(\t\x12\x14\n\x07protect\x18\x02 \x01(\x08H\x00\x88\x01\x01\x12\x16\n\x0eignore_changes\x18\x03 \x03(\t\x12\x1a\n\x12replace_on_changes\x18\x04 \x03(\t\x12\x0f\n\x07version\x18\x05 \x01(\t\x12!\n\x07\x61liases\x18\x06 \x03(\x0b\x32\x10.pulumirpc.Alias\x12\x10\n\x08provider\x18\x07 \x01(\t\x12J\n\x0f\x63ustom_timeouts\x18\x08 \x01(\x0b\x32\x31.pulumirpc.RegisterResourceRequest.CustomTimeouts\x12\x1b\n\x13plugin_download_url\x18\t \x01(\t\x12\x1d\n\x10retain_on_delete\x18\n \x01(\x08H\x01\x88\x01\x01\x12\x14\n\x0c\x64\x65leted_with\x18\x0b \x01(\t\x12\"\n\x15\x64\x65lete_before_replace\x18\x0c \x01(\x08H\x02\x88\x01\x01\x12!\n\x19\x61\x64\x64itional_secret_outputs\x18\r \x03(\t\x12\x45\n\tproviders\x18\x0e \x03(\x0b\x32\x32.pulumirpc.TransformResourceOptions.ProvidersEntry\x12R\n\x10plugin_checksums\x18\x0f \x03(\x0b\x32\x38.pulumirpc.TransformResourceOptions.PluginChecksumsEntry\x12\x46\n\x05hooks\x18\x10 \x01(\x0b\x32\x37.pulumirpc.RegisterResourceRequest.ResourceHooksBinding\x1a\x30\n\x0eProvidersEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\x1a\x36\n\x14PluginChecksumsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c:\x02\x38\x01\x42\n\n\x08_protectB\x13\n\x11_retain_on_deleteB\x18\n\x16_delete_before_replace\"\xb1\x01\n\x10TransformRequest\x12\x0c\n\x04type\x18\x01 \x01(\t\x12\x0c\n\x04name\x18\x02 \x01(\t\x12\x0e\n\x06\x63ustom\x18\x03 \x01(\x08\x12\x0e\n\x06parent\x18\x04 \x01(\t\x12+\n\nproperties\x18\x05 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x34\n\x07options\x18\x06 \x01(\x0b\x32#.pulumirpc.TransformResourceOptions\"v\n\x11TransformResponse\x12+\n\nproperties\x18\x01 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x34\n\x07options\x18\x02 \x01(\x0b\x32#.pulumirpc.TransformResourceOptions\"\x82\x01\n\x16TransformInvokeRequest\x12\r\n\x05token\x18\x01 \x01(\t\x12%\n\x04\x61rgs\x18\x02 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x32\n\x07options\x18\x03 \x01(\x0b\x32!.pulumirpc.TransformInvokeOptions\"t\n\x17TransformInvokeResponse\x12%\n\x04\x61rgs\x18\x01 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x32\n\x07options\x18\x02 \x01(\x0b\x32!.pulumirpc.TransformInvokeOptions\"\xe2\x01\n\x16TransformInvokeOptions\x12\x10\n\x08provider\x18\x01 \x01(\t\x12\x1b\n\x13plugin_download_url\x18\x02 \x01(\t\x12\x0f\n\x07version\x18\x03 \x01(\t\x12P\n\x10plugin_checksums\x18\x04 \x03(\x0b\x32\x36.pulumirpc.TransformInvokeOptions.PluginChecksumsEntry\x1a\x36\n\x14PluginChecksumsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c:\x02\x38\x01\"\x80\x02\n\x13ResourceHookRequest\x12\x0b\n\x03urn\x18\x01 \x01(\t\x12\n\n\x02id\x18\x02 \x01(\t\x12\x0c\n\x04name\x18\x03 \x01(\t\x12\x0c\n\x04type\x18\x04 \x01(\t\x12+\n\nnew_inputs\x18\x05 \x01(\x0b\x32\x17.google.protobuf.Struct\x12+\n\nold_inputs\x18\x06 \x01(\x0b\x32\x17.google.protobuf.Struct\x12,\n\x0bnew_outputs\x18\x07 \x01(\x0b\x32\x17.google.protobuf.Struct\x12,\n\x0bold_outputs\x18\x08 \x01(\x0b\x32\x17.google.protobuf.Struct\"%\n\x14ResourceHookResponse\x12\r\n\x05\x65rror\x18\x01 \x01(\t\"\xfb\x01\n\x16RegisterPackageRequest\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x0f\n\x07version\x18\x02 \x01(\t\x12\x14\n\x0c\x64ownload_url\x18\x03 \x01(\t\x12\x43\n\tchecksums\x18\x04 \x03(\x0b\x32\x30.pulumirpc.RegisterPackageRequest.ChecksumsEntry\x12\x35\n\x10parameterization\x18\x05 \x01(\x0b\x32\x1b.pulumirpc.Parameterization\x1a\x30\n\x0e\x43hecksumsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c:\x02\x38\x01\"&\n\x17RegisterPackageResponse\x12\x0b\n\x03ref\x18\x01 \x01(\t\"@\n\x10Parameterization\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x0f\n\x07version\x18\x02 \x01(\t\x12\r\n\x05value\x18\x03 \x01(\x0c\"f\n\x1bRegisterResourceHookRequest\x12\x0c\n\x04name\x18\x01 \x01(\t\x12%\n\x08\x63\x61llback\x18\x02 \x01(\x0b\x32\x13.pulumirpc.Callback\x12\x12\n\non_dry_run\x18\x03 \x01(\x08*)\n\x06Result\x12\x0b\n\x07SUCCESS\x10\x00\x12\x08\n\x04\x46\x41IL\x10\x01\x12\x08\n\x04SKIP\x10\x02\x32\xa7\x07\n\x0fResourceMonitor\x12Z\n\x0fSupportsFeature\x12!.pulumirpc.SupportsFeatureRequest\x1a\".pulumirpc.SupportsFeatureResponse\"\x00\x12G\n\x06Invoke\x12 .pulumirpc.ResourceInvokeRequest\x1a\x19.pulumirpc.InvokeResponse\"\x00\x12\x41\n\x04\x43\x61ll\x12\x1e.pulumirpc.ResourceCallRequest\x1a\x17.pulumirpc.CallResponse\"\x00\x12Q\n\x0cReadResource\x12\x1e.pulumirpc.ReadResourceRequest\x1a\x1f.pulumirpc.ReadResourceResponse\"\x00\x12]\n\x10RegisterResource\x12\".pulumirpc.RegisterResourceRequest\x1a#.pulumirpc.RegisterResourceResponse\"\x00\x12^\n\x17RegisterResourceOutputs\x12).pulumirpc.RegisterResourceOutputsRequest\x1a\x16.google.protobuf.Empty\"\x00\x12G\n\x16RegisterStackTransform\x12\x13.pulumirpc.Callback\x1a\x16.google.protobuf.Empty\"\x00\x12M\n\x1cRegisterStackInvokeTransform\x12\x13.pulumirpc.Callback\x1a\x16.google.protobuf.Empty\"\x00\x12X\n\x14RegisterResourceHook\x12&.pulumirpc.RegisterResourceHookRequest\x1a\x16.google.protobuf.Empty\"\x00\x12Z\n\x0fRegisterPackage\x12!.pulumirpc.RegisterPackageRequest\x1a\".pulumirpc.RegisterPackageResponse\"\x00\x12L\n\x18SignalAndWaitForShutdown\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x00\x42\x34Z2github.com/pulumi/pulumi/sdk/v3/proto/go;pulumirpcb\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x15pulumi/resource.proto\x12\tpulumirpc\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x15pulumi/provider.proto\x1a\x12pulumi/alias.proto\x1a\x13pulumi/source.proto\x1a\x15pulumi/callback.proto\"$\n\x16SupportsFeatureRequest\x12\n\n\x02id\x18\x01 \x01(\t\"-\n\x17SupportsFeatureResponse\x12\x12\n\nhasSupport\x18\x01 \x01(\x08\"\xfb\x03\n\x13ReadResourceRequest\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0c\n\x04type\x18\x02 \x01(\t\x12\x0c\n\x04name\x18\x03 \x01(\t\x12\x0e\n\x06parent\x18\x04 \x01(\t\x12+\n\nproperties\x18\x05 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x14\n\x0c\x64\x65pendencies\x18\x06 \x03(\t\x12\x10\n\x08provider\x18\x07 \x01(\t\x12\x0f\n\x07version\x18\x08 \x01(\t\x12\x15\n\racceptSecrets\x18\t \x01(\x08\x12\x1f\n\x17\x61\x64\x64itionalSecretOutputs\x18\n \x03(\t\x12\x17\n\x0f\x61\x63\x63\x65ptResources\x18\x0c \x01(\x08\x12\x19\n\x11pluginDownloadURL\x18\r \x01(\t\x12L\n\x0fpluginChecksums\x18\x0f \x03(\x0b\x32\x33.pulumirpc.ReadResourceRequest.PluginChecksumsEntry\x12\x31\n\x0esourcePosition\x18\x0e \x01(\x0b\x32\x19.pulumirpc.SourcePosition\x12\x12\n\npackageRef\x18\x10 \x01(\t\x1a\x36\n\x14PluginChecksumsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c:\x02\x38\x01J\x04\x08\x0b\x10\x0cR\x07\x61liases\"P\n\x14ReadResourceResponse\x12\x0b\n\x03urn\x18\x01 \x01(\t\x12+\n\nproperties\x18\x02 \x01(\x0b\x32\x17.google.protobuf.Struct\"\xa5\x0e\n\x17RegisterResourceRequest\x12\x0c\n\x04type\x18\x01 \x01(\t\x12\x0c\n\x04name\x18\x02 \x01(\t\x12\x0e\n\x06parent\x18\x03 \x01(\t\x12\x0e\n\x06\x63ustom\x18\x04 \x01(\x08\x12\'\n\x06object\x18\x05 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x14\n\x07protect\x18\x06 \x01(\x08H\x00\x88\x01\x01\x12\x14\n\x0c\x64\x65pendencies\x18\x07 \x03(\t\x12\x10\n\x08provider\x18\x08 \x01(\t\x12Z\n\x14propertyDependencies\x18\t \x03(\x0b\x32<.pulumirpc.RegisterResourceRequest.PropertyDependenciesEntry\x12\x1b\n\x13\x64\x65leteBeforeReplace\x18\n \x01(\x08\x12\x0f\n\x07version\x18\x0b \x01(\t\x12\x15\n\rignoreChanges\x18\x0c \x03(\t\x12\x15\n\racceptSecrets\x18\r \x01(\x08\x12\x1f\n\x17\x61\x64\x64itionalSecretOutputs\x18\x0e \x03(\t\x12\x11\n\taliasURNs\x18\x0f \x03(\t\x12\x10\n\x08importId\x18\x10 \x01(\t\x12I\n\x0e\x63ustomTimeouts\x18\x11 \x01(\x0b\x32\x31.pulumirpc.RegisterResourceRequest.CustomTimeouts\x12\"\n\x1a\x64\x65leteBeforeReplaceDefined\x18\x12 \x01(\x08\x12\x1d\n\x15supportsPartialValues\x18\x13 \x01(\x08\x12\x0e\n\x06remote\x18\x14 \x01(\x08\x12\x17\n\x0f\x61\x63\x63\x65ptResources\x18\x15 \x01(\x08\x12\x44\n\tproviders\x18\x16 \x03(\x0b\x32\x31.pulumirpc.RegisterResourceRequest.ProvidersEntry\x12\x18\n\x10replaceOnChanges\x18\x17 \x03(\t\x12\x19\n\x11pluginDownloadURL\x18\x18 \x01(\t\x12P\n\x0fpluginChecksums\x18\x1e \x03(\x0b\x32\x37.pulumirpc.RegisterResourceRequest.PluginChecksumsEntry\x12\x1b\n\x0eretainOnDelete\x18\x19 \x01(\x08H\x01\x88\x01\x01\x12!\n\x07\x61liases\x18\x1a \x03(\x0b\x32\x10.pulumirpc.Alias\x12\x13\n\x0b\x64\x65letedWith\x18\x1b \x01(\t\x12\x12\n\naliasSpecs\x18\x1c \x01(\x08\x12\x31\n\x0esourcePosition\x18\x1d \x01(\x0b\x32\x19.pulumirpc.SourcePosition\x12\'\n\ntransforms\x18\x1f \x03(\x0b\x32\x13.pulumirpc.Callback\x12\x1f\n\x17supportsResultReporting\x18  \x01(\x08\x12\x12\n\npackageRef\x18! \x01(\t\x12K\n\x05hooks\x18\" \x01(\x0b\x32\x37.pulumirpc.RegisterResourceRequest.ResourceHooksBindingH\x02\x88\x01\x01\x12\x0f\n\x07\x65xpires\x18# \x01(\t\x12H\n\x0b\x61nnotations\x18$ \x03(\x0b\x32\x33.pulumirpc.RegisterResourceRequest.AnnotationsEntry\x1a$\n\x14PropertyDependencies\x12\x0c\n\x04urns\x18\x01 \x03(\t\x1a@\n\x0e\x43ustomTimeouts\x12\x0e\n\x06\x63reate\x18\x01 \x01(\t\x12\x0e\n\x06update\x18\x02 \x01(\t\x12\x0e\n\x06\x64\x65lete\x18\x03 \x01(\t\x1at\n\x19PropertyDependenciesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x46\n\x05value\x18\x02 \x01(\x0b\x32\x37.pulumirpc.RegisterResourceRequest.PropertyDependencies:\x02\x38\x01\x1a\x30\n\x0eProvidersEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\x1a\x36\n\x14PluginChecksumsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c:\x02\x38\x01\x1a\x9d\x01\n\x14ResourceHooksBinding\x12\x15\n\rbefore_create\x18\x01 \x03(\t\x12\x14\n\x0c\x61\x66ter_create\x18\x02 \x03(\t\x12\x15\n\rbefore_update\x18\x03 \x03(\t\x12\x14\n\x0c\x61\x66ter_update\x18\x04 \x03(\t\x12\x15\n\rbefore_delete\x18\x05 \x03(\t\x12\x14\n\x0c\x61\x66ter_delete\x18\x06 \x03(\t\x1a\x32\n\x10\x41nnotationsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\x42\n\n\x08_protectB\x11\n\x0f_retainOnDeleteB\x08\n\x06_hooks\"\x9a\x03\n\x18RegisterResourceResponse\x12\x0b\n\x03urn\x18\x01 \x01(\t\x12\n\n\x02id\x18\x02 \x01(\t\x12\'\n\x06object\x18\x03 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x0e\n\x06stable\x18\x04 \x01(\x08\x12\x0f\n\x07stables\x18\x05 \x03(\t\x12[\n\x14propertyDependencies\x18\x06 \x03(\x0b\x32=.pulumirpc.RegisterResourceResponse.PropertyDependenciesEntry\x12!\n\x06result\x18\x07 \x01(\x0e\x32\x11.pulumirpc.Result\x1a$\n\x14PropertyDependencies\x12\x0c\n\x04urns\x18\x01 \x03(\t\x1au\n\x19PropertyDependenciesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12G\n\x05value\x18\x02 \x01(\x0b\x32\x38.pulumirpc.RegisterResourceResponse.PropertyDependencies:\x02\x38\x01\"W\n\x1eRegisterResourceOutputsRequest\x12\x0b\n\x03urn\x18\x01 \x01(\t\x12(\n\x07outputs\x18\x02 \x01(\x0b\x32\x17.google.protobuf.Struct\"\xf1\x02\n\x15ResourceInvokeRequest\x12\x0b\n\x03tok\x18\x01 \x01(\t\x12%\n\x04\x61rgs\x18\x02 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x10\n\x08provider\x18\x03 \x01(\t\x12\x0f\n\x07version\x18\x04 \x01(\t\x12\x17\n\x0f\x61\x63\x63\x65ptResources\x18\x05 \x01(\x08\x12\x19\n\x11pluginDownloadURL\x18\x06 \x01(\t\x12N\n\x0fpluginChecksums\x18\x08 \x03(\x0b\x32\x35.pulumirpc.ResourceInvokeRequest.PluginChecksumsEntry\x12\x31\n\x0esourcePosition\x18\x07 \x01(\x0b\x32\x19.pulumirpc.SourcePosition\x12\x12\n\npackageRef\x18\t \x01(\t\x1a\x36\n\x14PluginChecksumsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c:\x02\x38\x01\"\xc0\x05\n\x13ResourceCallRequest\x12\x0b\n\x03tok\x18\x01 \x01(\t\x12%\n\x04\x61rgs\x18\x02 \x01(\x0b\x32\x17.google.protobuf.Struct\x12L\n\x0f\x61rgDependencies\x18\x03 \x03(\x0b\x32\x33.pulumirpc.ResourceCallRequest.ArgDependenciesEntry\x12\x10\n\x08provider\x18\x04 \x01(\t\x12\x0f\n\x07version\x18\x05 \x01(\t\x12\x19\n\x11pluginDownloadURL\x18\r \x01(\t\x12L\n\x0fpluginChecksums\x18\x10 \x03(\x0b\x32\x33.pulumirpc.ResourceCallRequest.PluginChecksumsEntry\x12\x31\n\x0esourcePosition\x18\x0f \x01(\x0b\x32\x19.pulumirpc.SourcePosition\x12\x12\n\npackageRef\x18\x11 \x01(\t\x1a$\n\x14\x41rgumentDependencies\x12\x0c\n\x04urns\x18\x01 \x03(\t\x1ak\n\x14\x41rgDependenciesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x42\n\x05value\x18\x02 \x01(\x0b\x32\x33.pulumirpc.ResourceCallRequest.ArgumentDependencies:\x02\x38\x01\x1a\x36\n\x14PluginChecksumsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c:\x02\x38\x01J\x04\x08\x06\x10\x07J\x04\x08\x07\x10\x08J\x04\x08\x08\x10\tJ\x04\x08\t\x10\nJ\x04\x08\n\x10\x0bJ\x04\x08\x0b\x10\x0cJ\x04\x08\x0c\x10\rJ\x04\x08\x0e\x10\x0fR\x07projectR\x05stackR\x06\x63onfigR\x10\x63onfigSecretKeysR\x06\x64ryRunR\x08parallelR\x0fmonitorEndpointR\x0corganization\"\xab\x06\n\x18TransformResourceOptions\x12\x12\n\ndepends_on\x18\x01 \x03(\t\x12\x14\n\x07protect\x18\x02 \x01(\x08H\x00\x88\x01\x01\x12\x16\n\x0eignore_changes\x18\x03 \x03(\t\x12\x1a\n\x12replace_on_changes\x18\x04 \x03(\t\x12\x0f\n\x07version\x18\x05 \x01(\t\x12!\n\x07\x61liases\x18\x06 \x03(\x0b\x32\x10.pulumirpc.Alias\x12\x10\n\x08provider\x18\x07 \x01(\t\x12J\n\x0f\x63ustom_timeouts\x18\x08 \x01(\x0b\x32\x31.pulumirpc.RegisterResourceRequest.CustomTimeouts\x12\x1b\n\x13plugin_download_url\x18\t \x01(\t\x12\x1d\n\x10retain_on_delete\x18\n \x01(\x08H\x01\x88\x01\x01\x12\x14\n\x0c\x64\x65leted_with\x18\x0b \x01(\t\x12\"\n\x15\x64\x65lete_before_replace\x18\x0c \x01(\x08H\x02\x88\x01\x01\x12!\n\x19\x61\x64\x64itional_secret_outputs\x18\r \x03(\t\x12\x45\n\tproviders\x18\x0e \x03(\x0b\x32\x32.pulumirpc.TransformResourceOptions.ProvidersEntry\x12R\n\x10plugin_checksums\x18\x0f \x03(\x0b\x32\x38.pulumirpc.TransformResourceOptions.PluginChecksumsEntry\x12\x46\n\x05hooks\x18\x10 \x01(\x0b\x32\x37.pulumirpc.RegisterResourceRequest.ResourceHooksBinding\x1a\x30\n\x0eProvidersEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\x1a\x36\n\x14PluginChecksumsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c:\x02\x38\x01\x42\n\n\x08_protectB\x13\n\x11_retain_on_deleteB\x18\n\x16_delete_before_replace\"\xb1\x01\n\x10TransformRequest\x12\x0c\n\x04type\x18\x01 \x01(\t\x12\x0c\n\x04name\x18\x02 \x01(\t\x12\x0e\n\x06\x63ustom\x18\x03 \x01(\x08\x12\x0e\n\x06parent\x18\x04 \x01(\t\x12+\n\nproperties\x18\x05 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x34\n\x07options\x18\x06 \x01(\x0b\x32#.pulumirpc.TransformResourceOptions\"v\n\x11TransformResponse\x12+\n\nproperties\x18\x01 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x34\n\x07options\x18\x02 \x01(\x0b\x32#.pulumirpc.TransformResourceOptions\"\x82\x01\n\x16TransformInvokeRequest\x12\r\n\x05token\x18\x01 \x01(\t\x12%\n\x04\x61rgs\x18\x02 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x32\n\x07options\x18\x03 \x01(\x0b\x32!.pulumirpc.TransformInvokeOptions\"t\n\x17TransformInvokeResponse\x12%\n\x04\x61rgs\x18\x01 \x01(\x0b\x32\x17.google.protobuf.Struct\x12\x32\n\x07options\x18\x02 \x01(\x0b\x32!.pulumirpc.TransformInvokeOptions\"\xe2\x01\n\x16TransformInvokeOptions\x12\x10\n\x08provider\x18\x01 \x01(\t\x12\x1b\n\x13plugin_download_url\x18\x02 \x01(\t\x12\x0f\n\x07version\x18\x03 \x01(\t\x12P\n\x10plugin_checksums\x18\x04 \x03(\x0b\x32\x36.pulumirpc.TransformInvokeOptions.PluginChecksumsEntry\x1a\x36\n\x14PluginChecksumsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c:\x02\x38\x01\"\x80\x02\n\x13ResourceHookRequest\x12\x0b\n\x03urn\x18\x01 \x01(\t\x12\n\n\x02id\x18\x02 \x01(\t\x12\x0c\n\x04name\x18\x03 \x01(\t\x12\x0c\n\x04type\x18\x04 \x01(\t\x12+\n\nnew_inputs\x18\x05 \x01(\x0b\x32\x17.google.protobuf.Struct\x12+\n\nold_inputs\x18\x06 \x01(\x0b\x32\x17.google.protobuf.Struct\x12,\n\x0bnew_outputs\x18\x07 \x01(\x0b\x32\x17.google.protobuf.Struct\x12,\n\x0bold_outputs\x18\x08 \x01(\x0b\x32\x17.google.protobuf.Struct\"%\n\x14ResourceHookResponse\x12\r\n\x05\x65rror\x18\x01 \x01(\t\"\xfb\x01\n\x16RegisterPackageRequest\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x0f\n\x07version\x18\x02 \x01(\t\x12\x14\n\x0c\x64ownload_url\x18\x03 \x01(\t\x12\x43\n\tchecksums\x18\x04 \x03(\x0b\x32\x30.pulumirpc.RegisterPackageRequest.ChecksumsEntry\x12\x35\n\x10parameterization\x18\x05 \x01(\x0b\x32\x1b.pulumirpc.Parameterization\x1a\x30\n\x0e\x43hecksumsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c:\x02\x38\x01\"&\n\x17RegisterPackageResponse\x12\x0b\n\x03ref\x18\x01 \x01(\t\"@\n\x10Parameterization\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x0f\n\x07version\x18\x02 \x01(\t\x12\r\n\x05value\x18\x03 \x01(\x0c\"f\n\x1bRegisterResourceHookRequest\x12\x0c\n\x04name\x18\x01 \x01(\t\x12%\n\x08\x63\x61llback\x18\x02 \x01(\x0b\x32\x13.pulumirpc.Callback\x12\x12\n\non_dry_run\x18\x03 \x01(\x08*)\n\x06Result\x12\x0b\n\x07SUCCESS\x10\x00\x12\x08\n\x04\x46\x41IL\x10\x01\x12\x08\n\x04SKIP\x10\x02\x32\xa7\x07\n\x0fResourceMonitor\x12Z\n\x0fSupportsFeature\x12!.pulumirpc.SupportsFeatureRequest\x1a\".pulumirpc.SupportsFeatureResponse\"\x00\x12G\n\x06Invoke\x12 .pulumirpc.ResourceInvokeRequest\x1a\x19.pulumirpc.InvokeResponse\"\x00\x12\x41\n\x04\x43\x61ll\x12\x1e.pulumirpc.ResourceCallRequest\x1a\x17.pulumirpc.CallResponse\"\x00\x12Q\n\x0cReadResource\x12\x1e.pulumirpc.ReadResourceRequest\x1a\x1f.pulumirpc.ReadResourceResponse\"\x00\x12]\n\x10RegisterResource\x12\".pulumirpc.RegisterResourceRequest\x1a#.pulumirpc.RegisterResourceResponse\"\x00\x12^\n\x17RegisterResourceOutputs\x12).pulumirpc.RegisterResourceOutputsRequest\x1a\x16.google.protobuf.Empty\"\x00\x12G\n\x16RegisterStackTransform\x12\x13.pulumirpc.Callback\x1a\x16.google.protobuf.Empty\"\x00\x12M\n\x1cRegisterStackInvokeTransform\x12\x13.pulumirpc.Callback\x1a\x16.google.protobuf.Empty\"\x00\x12X\n\x14RegisterResourceHook\x12&.pulumirpc.RegisterResourceHookRequest\x1a\x16.google.protobuf.Empty\"\x00\x12Z\n\x0fRegisterPackage\x12!.pulumirpc.RegisterPackageRequest\x1a\".pulumirpc.RegisterPackageResponse\"\x00\x12L\n\x18SignalAndWaitForShutdown\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x00\x42\x34Z2github.com/pulumi/pulumi/sdk/v3/proto/go;pulumirpcb\x06proto3')
 
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, globals())
 _builder.BuildTopDescriptorsAndMessages(DESCRIPTOR, 'pulumi.resource_pb2', globals())
@@ -35,6 +35,8 @@ if _descriptor._USE_C_DESCRIPTORS == False:
   _REGISTERRESOURCEREQUEST_PROVIDERSENTRY._serialized_options = b'8\001'
   _REGISTERRESOURCEREQUEST_PLUGINCHECKSUMSENTRY._options = None
   _REGISTERRESOURCEREQUEST_PLUGINCHECKSUMSENTRY._serialized_options = b'8\001'
+  _REGISTERRESOURCEREQUEST_ANNOTATIONSENTRY._options = None
+  _REGISTERRESOURCEREQUEST_ANNOTATIONSENTRY._serialized_options = b'8\001'
   _REGISTERRESOURCERESPONSE_PROPERTYDEPENDENCIESENTRY._options = None
   _REGISTERRESOURCERESPONSE_PROPERTYDEPENDENCIESENTRY._serialized_options = b'8\001'
   _RESOURCEINVOKEREQUEST_PLUGINCHECKSUMSENTRY._options = None
@@ -51,8 +53,8 @@ if _descriptor._USE_C_DESCRIPTORS == False:
   _TRANSFORMINVOKEOPTIONS_PLUGINCHECKSUMSENTRY._serialized_options = b'8\001'
   _REGISTERPACKAGEREQUEST_CHECKSUMSENTRY._options = None
   _REGISTERPACKAGEREQUEST_CHECKSUMSENTRY._serialized_options = b'8\001'
-  _RESULT._serialized_start=6485
-  _RESULT._serialized_end=6526
+  _RESULT._serialized_start=6628
+  _RESULT._serialized_end=6669
   _SUPPORTSFEATUREREQUEST._serialized_start=182
   _SUPPORTSFEATUREREQUEST._serialized_end=218
   _SUPPORTSFEATURERESPONSE._serialized_start=220
@@ -64,71 +66,73 @@ if _descriptor._USE_C_DESCRIPTORS == False:
   _READRESOURCERESPONSE._serialized_start=777
   _READRESOURCERESPONSE._serialized_end=857
   _REGISTERRESOURCEREQUEST._serialized_start=860
-  _REGISTERRESOURCEREQUEST._serialized_end=2546
-  _REGISTERRESOURCEREQUEST_PROPERTYDEPENDENCIES._serialized_start=2019
-  _REGISTERRESOURCEREQUEST_PROPERTYDEPENDENCIES._serialized_end=2055
-  _REGISTERRESOURCEREQUEST_CUSTOMTIMEOUTS._serialized_start=2057
-  _REGISTERRESOURCEREQUEST_CUSTOMTIMEOUTS._serialized_end=2121
-  _REGISTERRESOURCEREQUEST_PROPERTYDEPENDENCIESENTRY._serialized_start=2123
-  _REGISTERRESOURCEREQUEST_PROPERTYDEPENDENCIESENTRY._serialized_end=2239
-  _REGISTERRESOURCEREQUEST_PROVIDERSENTRY._serialized_start=2241
-  _REGISTERRESOURCEREQUEST_PROVIDERSENTRY._serialized_end=2289
+  _REGISTERRESOURCEREQUEST._serialized_end=2689
+  _REGISTERRESOURCEREQUEST_PROPERTYDEPENDENCIES._serialized_start=2110
+  _REGISTERRESOURCEREQUEST_PROPERTYDEPENDENCIES._serialized_end=2146
+  _REGISTERRESOURCEREQUEST_CUSTOMTIMEOUTS._serialized_start=2148
+  _REGISTERRESOURCEREQUEST_CUSTOMTIMEOUTS._serialized_end=2212
+  _REGISTERRESOURCEREQUEST_PROPERTYDEPENDENCIESENTRY._serialized_start=2214
+  _REGISTERRESOURCEREQUEST_PROPERTYDEPENDENCIESENTRY._serialized_end=2330
+  _REGISTERRESOURCEREQUEST_PROVIDERSENTRY._serialized_start=2332
+  _REGISTERRESOURCEREQUEST_PROVIDERSENTRY._serialized_end=2380
   _REGISTERRESOURCEREQUEST_PLUGINCHECKSUMSENTRY._serialized_start=706
   _REGISTERRESOURCEREQUEST_PLUGINCHECKSUMSENTRY._serialized_end=760
-  _REGISTERRESOURCEREQUEST_RESOURCEHOOKSBINDING._serialized_start=2348
-  _REGISTERRESOURCEREQUEST_RESOURCEHOOKSBINDING._serialized_end=2505
-  _REGISTERRESOURCERESPONSE._serialized_start=2549
-  _REGISTERRESOURCERESPONSE._serialized_end=2959
-  _REGISTERRESOURCERESPONSE_PROPERTYDEPENDENCIES._serialized_start=2019
-  _REGISTERRESOURCERESPONSE_PROPERTYDEPENDENCIES._serialized_end=2055
-  _REGISTERRESOURCERESPONSE_PROPERTYDEPENDENCIESENTRY._serialized_start=2842
-  _REGISTERRESOURCERESPONSE_PROPERTYDEPENDENCIESENTRY._serialized_end=2959
-  _REGISTERRESOURCEOUTPUTSREQUEST._serialized_start=2961
-  _REGISTERRESOURCEOUTPUTSREQUEST._serialized_end=3048
-  _RESOURCEINVOKEREQUEST._serialized_start=3051
-  _RESOURCEINVOKEREQUEST._serialized_end=3420
+  _REGISTERRESOURCEREQUEST_RESOURCEHOOKSBINDING._serialized_start=2439
+  _REGISTERRESOURCEREQUEST_RESOURCEHOOKSBINDING._serialized_end=2596
+  _REGISTERRESOURCEREQUEST_ANNOTATIONSENTRY._serialized_start=2598
+  _REGISTERRESOURCEREQUEST_ANNOTATIONSENTRY._serialized_end=2648
+  _REGISTERRESOURCERESPONSE._serialized_start=2692
+  _REGISTERRESOURCERESPONSE._serialized_end=3102
+  _REGISTERRESOURCERESPONSE_PROPERTYDEPENDENCIES._serialized_start=2110
+  _REGISTERRESOURCERESPONSE_PROPERTYDEPENDENCIES._serialized_end=2146
+  _REGISTERRESOURCERESPONSE_PROPERTYDEPENDENCIESENTRY._serialized_start=2985
+  _REGISTERRESOURCERESPONSE_PROPERTYDEPENDENCIESENTRY._serialized_end=3102
+  _REGISTERRESOURCEOUTPUTSREQUEST._serialized_start=3104
+  _REGISTERRESOURCEOUTPUTSREQUEST._serialized_end=3191
+  _RESOURCEINVOKEREQUEST._serialized_start=3194
+  _RESOURCEINVOKEREQUEST._serialized_end=3563
   _RESOURCEINVOKEREQUEST_PLUGINCHECKSUMSENTRY._serialized_start=706
   _RESOURCEINVOKEREQUEST_PLUGINCHECKSUMSENTRY._serialized_end=760
-  _RESOURCECALLREQUEST._serialized_start=3423
-  _RESOURCECALLREQUEST._serialized_end=4127
-  _RESOURCECALLREQUEST_ARGUMENTDEPENDENCIES._serialized_start=3787
-  _RESOURCECALLREQUEST_ARGUMENTDEPENDENCIES._serialized_end=3823
-  _RESOURCECALLREQUEST_ARGDEPENDENCIESENTRY._serialized_start=3825
-  _RESOURCECALLREQUEST_ARGDEPENDENCIESENTRY._serialized_end=3932
+  _RESOURCECALLREQUEST._serialized_start=3566
+  _RESOURCECALLREQUEST._serialized_end=4270
+  _RESOURCECALLREQUEST_ARGUMENTDEPENDENCIES._serialized_start=3930
+  _RESOURCECALLREQUEST_ARGUMENTDEPENDENCIES._serialized_end=3966
+  _RESOURCECALLREQUEST_ARGDEPENDENCIESENTRY._serialized_start=3968
+  _RESOURCECALLREQUEST_ARGDEPENDENCIESENTRY._serialized_end=4075
   _RESOURCECALLREQUEST_PLUGINCHECKSUMSENTRY._serialized_start=706
   _RESOURCECALLREQUEST_PLUGINCHECKSUMSENTRY._serialized_end=760
-  _TRANSFORMRESOURCEOPTIONS._serialized_start=4130
-  _TRANSFORMRESOURCEOPTIONS._serialized_end=4941
-  _TRANSFORMRESOURCEOPTIONS_PROVIDERSENTRY._serialized_start=2241
-  _TRANSFORMRESOURCEOPTIONS_PROVIDERSENTRY._serialized_end=2289
+  _TRANSFORMRESOURCEOPTIONS._serialized_start=4273
+  _TRANSFORMRESOURCEOPTIONS._serialized_end=5084
+  _TRANSFORMRESOURCEOPTIONS_PROVIDERSENTRY._serialized_start=2332
+  _TRANSFORMRESOURCEOPTIONS_PROVIDERSENTRY._serialized_end=2380
   _TRANSFORMRESOURCEOPTIONS_PLUGINCHECKSUMSENTRY._serialized_start=706
   _TRANSFORMRESOURCEOPTIONS_PLUGINCHECKSUMSENTRY._serialized_end=760
-  _TRANSFORMREQUEST._serialized_start=4944
-  _TRANSFORMREQUEST._serialized_end=5121
-  _TRANSFORMRESPONSE._serialized_start=5123
-  _TRANSFORMRESPONSE._serialized_end=5241
-  _TRANSFORMINVOKEREQUEST._serialized_start=5244
-  _TRANSFORMINVOKEREQUEST._serialized_end=5374
-  _TRANSFORMINVOKERESPONSE._serialized_start=5376
-  _TRANSFORMINVOKERESPONSE._serialized_end=5492
-  _TRANSFORMINVOKEOPTIONS._serialized_start=5495
-  _TRANSFORMINVOKEOPTIONS._serialized_end=5721
+  _TRANSFORMREQUEST._serialized_start=5087
+  _TRANSFORMREQUEST._serialized_end=5264
+  _TRANSFORMRESPONSE._serialized_start=5266
+  _TRANSFORMRESPONSE._serialized_end=5384
+  _TRANSFORMINVOKEREQUEST._serialized_start=5387
+  _TRANSFORMINVOKEREQUEST._serialized_end=5517
+  _TRANSFORMINVOKERESPONSE._serialized_start=5519
+  _TRANSFORMINVOKERESPONSE._serialized_end=5635
+  _TRANSFORMINVOKEOPTIONS._serialized_start=5638
+  _TRANSFORMINVOKEOPTIONS._serialized_end=5864
   _TRANSFORMINVOKEOPTIONS_PLUGINCHECKSUMSENTRY._serialized_start=706
   _TRANSFORMINVOKEOPTIONS_PLUGINCHECKSUMSENTRY._serialized_end=760
-  _RESOURCEHOOKREQUEST._serialized_start=5724
-  _RESOURCEHOOKREQUEST._serialized_end=5980
-  _RESOURCEHOOKRESPONSE._serialized_start=5982
-  _RESOURCEHOOKRESPONSE._serialized_end=6019
-  _REGISTERPACKAGEREQUEST._serialized_start=6022
-  _REGISTERPACKAGEREQUEST._serialized_end=6273
-  _REGISTERPACKAGEREQUEST_CHECKSUMSENTRY._serialized_start=6225
-  _REGISTERPACKAGEREQUEST_CHECKSUMSENTRY._serialized_end=6273
-  _REGISTERPACKAGERESPONSE._serialized_start=6275
-  _REGISTERPACKAGERESPONSE._serialized_end=6313
-  _PARAMETERIZATION._serialized_start=6315
-  _PARAMETERIZATION._serialized_end=6379
-  _REGISTERRESOURCEHOOKREQUEST._serialized_start=6381
-  _REGISTERRESOURCEHOOKREQUEST._serialized_end=6483
-  _RESOURCEMONITOR._serialized_start=6529
-  _RESOURCEMONITOR._serialized_end=7464
+  _RESOURCEHOOKREQUEST._serialized_start=5867
+  _RESOURCEHOOKREQUEST._serialized_end=6123
+  _RESOURCEHOOKRESPONSE._serialized_start=6125
+  _RESOURCEHOOKRESPONSE._serialized_end=6162
+  _REGISTERPACKAGEREQUEST._serialized_start=6165
+  _REGISTERPACKAGEREQUEST._serialized_end=6416
+  _REGISTERPACKAGEREQUEST_CHECKSUMSENTRY._serialized_start=6368
+  _REGISTERPACKAGEREQUEST_CHECKSUMSENTRY._serialized_end=6416
+  _REGISTERPACKAGERESPONSE._serialized_start=6418
+  _REGISTERPACKAGERESPONSE._serialized_end=6456
+  _PARAMETERIZATION._serialized_start=6458
+  _PARAMETERIZATION._serialized_end=6522
+  _REGISTERRESOURCEHOOKREQUEST._serialized_start=6524
+  _REGISTERRESOURCEHOOKREQUEST._serialized_end=6626
+  _RESOURCEMONITOR._serialized_start=6672
+  _RESOURCEMONITOR._serialized_end=7607
 # @@protoc_insertion_point(module_scope)